@@ -17,7 +17,12 @@ const docTemplate = `{
     "paths": {
         "/analyses": {
             "get": {
-                "description": "Retrieve all analyses ordered by creation date (newest first) for the history page",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve analyses ordered by creation date (newest first) for the history page, with pagination",
                 "consumes": [
                     "application/json"
                 ],
@@ -27,38 +32,202 @@ const docTemplate = `{
                 "tags": [
                     "analyses"
                 ],
-                "summary": "List all analyses",
+                "summary": "List analyses",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Maximum number of analyses to return (default: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 0,
+                        "description": "Number of analyses to skip (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "Analyses retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/responses.AnalysisListResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Paginated"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/responses.AnalysisResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Database operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     }
-                },
+                }
+            }
+        },
+        "/analyses/adhoc": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Analyze exactly the given feedbacks, bypassing the pending analysis queue. Requires admin role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analyses"
+                ],
+                "summary": "Run ad-hoc analysis",
+                "parameters": [
+                    {
+                        "description": "Ad-hoc analysis request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.AdhocAnalysisRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Analysis completed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/responses.AnalysisResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid, duplicate, unknown or too many feedback IDs",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - the analyzer is shutting down",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analyses/estimate": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ]
+                ],
+                "description": "Estimate tokens per component and the dollar cost of analyzing the pending feedback queue, or the given feedbacks. Nothing is analyzed and the queue is not modified",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analyses"
+                ],
+                "summary": "Estimate analysis cost",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma-separated feedback IDs to estimate as an ad-hoc analysis (default: the pending queue)",
+                        "name": "feedback_ids",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Estimate computed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/responses.AnalysisEstimateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid, duplicate or too many feedback IDs",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
             }
         },
         "/analyses/latest": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "description": "Retrieve the most recent completed analysis for the dashboard",
                 "consumes": [
                     "application/json"
@@ -70,6 +239,14 @@ const docTemplate = `{
                     "analyses"
                 ],
                 "summary": "Get latest analysis",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Skip analyses over fewer feedbacks than configured to be representative",
+                        "name": "representative_only",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "Latest analysis retrieved successfully",
@@ -80,31 +257,99 @@ const docTemplate = `{
                     "204": {
                         "description": "No analysis found"
                     },
+                    "400": {
+                        "description": "Bad request - representative_only is not a boolean",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     }
-                },
+                }
+            }
+        },
+        "/analyses/trigger": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ]
+                ],
+                "description": "Analyze the pending feedback queue now, regardless of the minimum feedback threshold and debounce. This is the way to run analyses in on-demand mode. The queue is held in memory by each replica, so only the feedbacks queued on the replica receiving the request are analyzed. Requires admin role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analyses"
+                ],
+                "summary": "Trigger analysis",
+                "responses": {
+                    "201": {
+                        "description": "Analysis completed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/responses.AnalysisResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - no pending feedbacks to analyze",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - an analysis is already running on another instance, or the analyzer is shutting down",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - the LLM circuit breaker is open",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
             }
         },
         "/analyses/{id}": {
             "get": {
-                "description": "Retrieve a specific analysis with its topics and analyzed feedbacks with their associated topics",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a specific analysis with its topics, analyzed feedbacks with their associated topics\nand the change in feedbacks per topic versus the previous analysis (topic_deltas).\nWhile the status is \"processing\" the summary is null, the key insights are empty and no topics are returned yet",
                 "consumes": [
                     "application/json"
                 ],
@@ -135,37 +380,34 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad request - invalid analysis ID format",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Analysis not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Database operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     }
-                },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ]
+                }
             }
         },
         "/auth/login": {
@@ -202,22 +444,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad request - invalid request body",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - invalid credentials",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     }
                 }
@@ -257,22 +496,25 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad request - invalid request body or validation error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - registration is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "409": {
                         "description": "Conflict - email already exists",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     }
                 }
@@ -280,6 +522,11 @@ const docTemplate = `{
         },
         "/feedbacks": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "description": "Retrieve a list of feedback entries with optional pagination",
                 "consumes": [
                     "application/json"
@@ -305,45 +552,114 @@ const docTemplate = `{
                         "description": "Number of feedbacks to skip (default: 0)",
                         "name": "offset",
                         "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "web",
+                            "mobile",
+                            "api",
+                            "email"
+                        ],
+                        "type": "string",
+                        "description": "Only return feedbacks submitted through this channel",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "bug",
+                        "description": "Only return feedbacks the submitter tagged with this tag (case-insensitive)",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "web",
+                            "ios",
+                            "android",
+                            "desktop"
+                        ],
+                        "type": "string",
+                        "description": "Only return feedbacks reported from this platform",
+                        "name": "platform",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2.4.1",
+                        "description": "Only return feedbacks reported from this app version",
+                        "name": "app_version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also return soft-deleted feedbacks, with deleted_at populated (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Feedbacks retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/responses.FeedbackListResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Paginated"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/responses.FeedbackResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
                         "description": "Bad request - invalid query parameters",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - include_deleted requires the admin role",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Database operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     }
-                },
+                }
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ]
-            },
-            "post": {
-                "description": "Create a new feedback submission with rating and comment",
+                ],
+                "description": "Create a new feedback submission with rating and comment. Users other than admins can submit at most daily_feedback_quota feedbacks per UTC day, if configured",
                 "consumes": [
                     "application/json"
                 ],
@@ -373,62 +689,45 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request - invalid request body",
+                        "description": "Bad request - invalid request body, or comment language not accepted (details.language, details.accepted_languages)",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable entity - comment flagged by content moderation (details.categories)",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - submission cooldown is active or daily feedback quota reached (details.reset_at)",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     }
-                },
+                }
+            }
+        },
+        "/feedbacks/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "x-examples": {
-                    "comment_too_long": {
-                        "comment": "This comment is way too long and exceeds the maximum allowed length of 1000 characters. Lorem ipsum dolor sit amet, consectetur adipiscing elit. Sed do eiusmod tempor incididunt ut labore et dolore magna aliqua. Ut enim ad minim veniam, quis nostrud exercitation ullamco laboris nisi ut aliquip ex ea commodo consequat. Duis aute irure dolor in reprehenderit in voluptate velit esse cillum dolore eu fugiat nulla pariatur. Excepteur sint occaecat cupidatat non proident, sunt in culpa qui officia deserunt mollit anim id est laborum. Sed ut perspiciatis unde omnis iste natus error sit voluptatem accusantium doloremque laudantium, totam rem aperiam, eaque ipsa quae ab illo inventore veritatis et quasi architecto beatae vitae dicta sunt explicabo. Nemo enim ipsam voluptatem quia voluptas sit aspernatur aut odit aut fugit, sed quia consequuntur magni dolores eos qui ratione voluptatem sequi nesciunt. Neque porro quisquam est, qui dolorem ipsum quia dolor sit amet, consectetur, adipisci velit, sed quia non numquam eius modi tempora incidunt ut labore et dolore magnam aliquam quaerat voluptatem. Ut enim ad minima veniam, quis nostrum exercitationem ullam corporis suscipit laboriosam, nisi ut aliquid ex ea commodi consequatur? Quis autem vel eum iure reprehenderit qui in ea voluptate velit esse quam nihil molestiae consequatur, vel illum qui dolorem eum fugiat quo voluptas nulla pariatur?",
-                        "rating": 2
-                    },
-                    "empty_comment": {
-                        "comment": "",
-                        "rating": 3
-                    },
-                    "full": {
-                        "comment": "Overall, I'm very satisfied with the service. The response time was excellent and the staff was very helpful. There were a few minor issues, but they were quickly resolved.",
-                        "rating": 4
-                    },
-                    "invalid_rating_high": {
-                        "comment": "This rating is too high",
-                        "rating": 6
-                    },
-                    "invalid_rating_low": {
-                        "comment": "This rating is too low",
-                        "rating": 0
-                    },
-                    "minimal": {
-                        "comment": "Great service!",
-                        "rating": 5
-                    }
-                }
-            }
-        },
-        "/feedbacks/{id}": {
-            "get": {
                 "description": "Retrieve a specific feedback entry by its unique identifier",
                 "consumes": [
                     "application/json"
@@ -448,6 +747,13 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "Also return the feedback if it was soft-deleted (admin only)",
+                        "name": "include_deleted",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -458,41 +764,43 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad request - invalid feedback ID format",
+                        "description": "Bad request - invalid feedback ID format or include_deleted value",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - include_deleted requires the admin role",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Feedback not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     }
-                },
+                }
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ]
-            },
-            "delete": {
+                ],
                 "description": "Soft delete a feedback entry by its unique identifier. Requires admin role.",
                 "consumes": [
                     "application/json"
@@ -521,49 +829,44 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad request - invalid feedback ID format",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "403": {
                         "description": "Forbidden - admin role required",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Feedback not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     }
-                },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ]
+                }
             }
         },
         "/topics": {
             "get": {
-                "description": "Retrieve all predefined topics with feedback count and average rating from the latest analysis",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all predefined topics with feedback count and average rating from the latest analysis. no_topics_identified is true when the latest analysis succeeded but the model found no topics",
                 "consumes": [
                     "application/json"
                 ],
@@ -578,33 +881,46 @@ const docTemplate = `{
                     "200": {
                         "description": "Topics with stats retrieved successfully",
                         "schema": {
-                            "$ref": "#/definitions/responses.TopicStatsListResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.TopicStatsListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/responses.TopicStatsResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "401": {
                         "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     }
-                },
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ]
+                }
             }
         },
         "/topics/{topic_enum}": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
                 "description": "Retrieve detailed information about a specific topic enum with all associated feedbacks",
                 "consumes": [
                     "application/json"
@@ -620,7 +936,7 @@ const docTemplate = `{
                     {
                         "type": "string",
                         "example": "product_functionality_features",
-                        "description": "Topic enum value",
+                        "description": "Topic enum value, case-insensitive",
                         "name": "topic_enum",
                         "in": "path",
                         "required": true
@@ -636,332 +952,2396 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad request - invalid topic enum",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized - invalid or missing JWT token",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Topic not found in latest analysis",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/responder.ErrorResponse"
                         }
                     }
-                },
+                }
+            }
+        },
+        "/webhooks/dead-letters": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ]
-            }
-        }
-    },
-    "definitions": {
-        "requests.CreateFeedbackRequest": {
-            "description": "Request payload for creating a new feedback submission.",
-            "type": "object",
-            "required": [
-                "rating"
-            ],
-            "properties": {
-                "comment": {
-                    "description": "Feedback comment text, 1-1000 characters (required)",
-                    "type": "string",
-                    "example": "Really nice!"
-                },
-                "rating": {
-                    "description": "Rating value from 1 to 5 (required)",
-                    "type": "integer",
-                    "maximum": 5,
-                    "minimum": 1,
-                    "example": 5
-                }
-            }
-        },
-        "requests.LoginUserRequest": {
-            "description": "Request payload for user login/authentication.",
-            "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
-            "properties": {
-                "email": {
-                    "description": "User email address (required)",
-                    "type": "string",
-                    "example": "user@example.com"
-                },
-                "password": {
-                    "description": "User password (required)",
-                    "type": "string",
-                    "example": "SecurePassword123!"
-                }
-            }
-        },
-        "requests.RegisterUserRequest": {
-            "description": "Request payload for registering a new user account.",
-            "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
-            "properties": {
-                "email": {
-                    "description": "User email address (required, must be valid email)",
-                    "type": "string",
-                    "example": "user@example.com"
-                },
-                "password": {
-                    "description": "User password (required, minimum 8 characters)",
-                    "type": "string",
-                    "minLength": 8,
-                    "example": "SecurePassword123!"
-                }
-            }
-        },
-        "responses.AnalysisDetailResponse": {
-            "description": "Response payload containing detailed analysis with topics and feedback IDs.",
-            "type": "object",
-            "properties": {
-                "analysis": {
-                    "$ref": "#/definitions/responses.AnalysisResponse"
-                },
-                "feedbacks": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/responses.FeedbackWithTopicsResponse"
+                ],
+                "description": "Retrieve events that could not be delivered to a webhook within the maximum number of attempts, newest first. Requires admin role and the event outbox to be enabled.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List dead-lettered events (Admin only)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Maximum number of dead letters to return (default: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 0,
+                        "description": "Number of dead letters to skip (default: 0)",
+                        "name": "offset",
+                        "in": "query"
                     }
-                },
-                "topics": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/responses.TopicAnalysisResponse"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dead letters retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Paginated"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/responses.DeadLetterResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid query parameters or the event outbox is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "responses.AnalysisListResponse": {
-            "description": "Response payload containing a list of analyses.",
-            "type": "object",
-            "properties": {
-                "analyses": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/responses.AnalysisResponse"
+        "/users/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reactivate a soft-deleted user account. Requires admin role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Restore a deleted user (Admin only)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User restored successfully",
+                        "schema": {
+                            "$ref": "#/definitions/responses.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - user is not deleted",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
                     }
-                },
-                "total": {
-                    "type": "integer",
-                    "example": 10
                 }
             }
         },
-        "responses.AnalysisResponse": {
-            "description": "Response payload containing analysis details.",
-            "type": "object",
-            "properties": {
-                "analysis_duration_ms": {
-                    "type": "integer",
-                    "example": 5000
-                },
-                "completed_at": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
-                },
-                "failure_reason": {
-                    "type": "string"
-                },
-                "feedback_count": {
-                    "type": "integer",
+        "/analyses/{id}/status": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the status and timestamps of an analysis. Cheap enough to poll until the analysis completes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analyses"
+                ],
+                "summary": "Get analysis status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "description": "Analysis ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Analysis status retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/responses.AnalysisStatusResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid analysis ID format",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Analysis not found",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/tag-topic-agreement": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "For feedbacks with user tags, report per tag how often the LLM assigned each topic (based on the\nlatest successful analysis of every feedback) and how often it agrees on the dominant topic of the tag.\nLow agreement rates point at categories the topic taxonomy does not capture. Requires admin role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Get tag/topic agreement",
+                "responses": {
+                    "200": {
+                        "description": "Tag/topic agreement retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/responses.TagTopicAgreementResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analyses/{id}/raw": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the output text exactly as returned by the model, with PII masked, to debug schema\nmismatches and prompt issues. Only available if llm_analysis.store_raw_output is enabled. Requires admin role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analyses"
+                ],
+                "summary": "Get raw analysis output",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "description": "Analysis ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Raw output retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/responses.AnalysisRawOutputResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid analysis ID format",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Analysis not found or no raw output stored",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve non-deleted user accounts, newest first, with optional pagination and filtering. Requires admin role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List users (Admin only)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Maximum number of users to return (default: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 0,
+                        "description": "Number of users to skip (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "inactive",
+                            "suspended"
+                        ],
+                        "type": "string",
+                        "description": "Only return accounts in this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "user",
+                            "admin"
+                        ],
+                        "type": "string",
+                        "description": "Only return accounts holding this role",
+                        "name": "role",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Users retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Paginated"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/responses.UserResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/overview": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Report totals across all analyses: number of analyses, distinct analyzed feedbacks, tokens and\ntheir estimated cost, average feedbacks per analysis and the most common topic (based on the\nlatest successful analysis of every feedback). Requires admin role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Get analytics overview",
+                "responses": {
+                    "200": {
+                        "description": "Analytics overview retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/responses.AnalyticsOverviewResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topic-analyses/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a topic analysis of any analysis, not only the latest, with its assigned feedbacks (newest first).\nUse it to link to a specific topic result within a historical analysis",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "topics"
+                ],
+                "summary": "Get topic analysis by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "description": "Topic analysis ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Topic analysis retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/responses.TopicAnalysisDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid topic analysis ID format",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Topic analysis not found",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analyses/reprocess-unanalyzed": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add the feedbacks listed by GET /feedbacks/unanalyzed back to the pending queue, so feedbacks of failed analyses are analyzed again. Feedbacks already queued or outside the rating filter are skipped. The queue is analyzed as usual afterwards, or with POST /analyses/trigger in on-demand mode. Requires admin role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analyses"
+                ],
+                "summary": "Reprocess unanalyzed feedbacks",
+                "responses": {
+                    "202": {
+                        "description": "Unanalyzed feedbacks added to the pending queue",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ReprocessUnanalyzedResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feedbacks/unanalyzed": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the feedback entries with a comment that were never included in a successful analysis, oldest first, such as feedbacks of failed analyses. Feedbacks of an analysis that is still processing and feedbacks excluded from analysis are omitted. Requires admin role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feedbacks"
+                ],
+                "summary": "List unanalyzed feedbacks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "example": 10,
+                        "description": "Maximum number of feedbacks to return (default: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 0,
+                        "description": "Number of feedbacks to skip (default: 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unanalyzed feedbacks retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Paginated"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/responses.FeedbackResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feedbacks/batch-delete": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft delete the given feedbacks in a single transaction and report the outcome per ID: deleted, not_found or already_deleted. The batch size is capped by max_batch_delete_size. Requires admin role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feedbacks"
+                ],
+                "summary": "Delete feedbacks in batch (Admin only)",
+                "parameters": [
+                    {
+                        "description": "Batch delete request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.BatchDeleteFeedbacksRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Batch processed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/responses.BatchDeleteFeedbacksResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid, duplicate or too many feedback IDs",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analytics/token-accuracy": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Report the distribution of the ratio of estimated to actual total tokens over the analyses with\nrecorded token usage, including failed analyses for which the provider reported usage. Usage is\nonly recorded while record_token_accuracy is enabled. Requires admin role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analytics"
+                ],
+                "summary": "Get token estimator accuracy",
+                "responses": {
+                    "200": {
+                        "description": "Token accuracy retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/responses.TokenAccuracyResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feedbacks/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stream all non-deleted feedbacks matching the filters as CSV, oldest first, for use in external BI tools. The columns are id, user_id, rating, comment and created_at. Comments starting with =, +, -, @, a tab or a carriage return are prefixed with a single quote, so that spreadsheets do not evaluate them as formulas. Rows are written as they are read, so the export is never held in memory. Requires admin role",
+                "produces": [
+                    "text/csv"
+                ],
+                "tags": [
+                    "feedbacks"
+                ],
+                "summary": "Export feedbacks as CSV (Admin only)",
+                "parameters": [
+                    {
+                        "enum": [
+                            "web",
+                            "mobile",
+                            "email",
+                            "api"
+                        ],
+                        "type": "string",
+                        "description": "Only export feedbacks submitted through this channel",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "checkout",
+                        "description": "Only export feedbacks tagged with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "web",
+                            "ios",
+                            "android",
+                            "desktop"
+                        ],
+                        "type": "string",
+                        "description": "Only export feedbacks reported from this platform",
+                        "name": "platform",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2.4.1",
+                        "description": "Only export feedbacks reported from this app version",
+                        "name": "app_version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2024-01-01",
+                        "description": "Only export feedbacks created at or after this time (RFC 3339 or YYYY-MM-DD)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2024-02-01T00:00:00Z",
+                        "description": "Only export feedbacks created before this time (RFC 3339 or YYYY-MM-DD)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 1,
+                        "description": "Only export feedbacks rated at least this value",
+                        "name": "min_rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "example": 3,
+                        "description": "Only export feedbacks rated at most this value",
+                        "name": "max_rating",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "CSV export of the matching feedbacks",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/{topic_enum}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the topic analyses of a topic enum across all successful analyses, oldest first.\nAnalyses that did not identify the topic are not listed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "topics"
+                ],
+                "summary": "Get topic history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "product_functionality_features",
+                        "description": "Topic enum value, case-insensitive",
+                        "name": "topic_enum",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Topic history retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.List"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/responses.TopicHistoryEntryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid topic enum",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/recompute-stats": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Rebuild the feedback count and average rating of every topic of the latest analysis from the current feedbacks, e.g. after feedbacks were deleted, without calling the LLM. Returns the recomputed statistics like GET /topics. Requires admin role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "topics"
+                ],
+                "summary": "Recompute topic statistics",
+                "responses": {
+                    "200": {
+                        "description": "Topic stats recomputed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.TopicStatsListResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/responses.TopicStatsResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analyses/{id}/report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Render an analysis as a shareable report: the period, overall summary, key insights and a section\nper topic with its summary, sentiment and feedback count, topics with the most feedbacks first.\nformat is \"markdown\" (default) or \"html\" for a styled page",
+                "produces": [
+                    "text/markdown",
+                    "text/html"
+                ],
+                "tags": [
+                    "analyses"
+                ],
+                "summary": "Get analysis report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "description": "Analysis ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "markdown",
+                            "html"
+                        ],
+                        "type": "string",
+                        "default": "markdown",
+                        "description": "Report format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Report rendered successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid analysis ID format or report format",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Analysis not found",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feedbacks/{id}/analyses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the analyses of any status that included a feedback, newest first, each with the topics the feedback was assigned to.\nTraces a single feedback back to the aggregate insights it informed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "feedbacks"
+                ],
+                "summary": "Get analyses of a feedback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "description": "Feedback ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Analyses of the feedback retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.List"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/responses.FeedbackAnalysisResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid feedback ID format",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Feedback not found",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/analyses/{id}/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the steps of an analysis (created, llm_called, llm_returned, topics_created, completed,\nfailed) with their timestamps and durations, oldest first. Unlike traces, events are stored for\nevery analysis. Requires admin role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "analyses"
+                ],
+                "summary": "Get analysis events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "550e8400-e29b-41d4-a716-446655440000",
+                        "description": "Analysis ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Events retrieved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/responses.AnalysisEventsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid analysis ID format",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - admin role required",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Analysis not found",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/topics/{topic_enum}/sentiment-trend": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the sentiment of a topic enum in every successful analysis, ordered by period end,\nencoded as sentiment_score (positive=1, mixed=0, negative=-1) next to the feedback count.\nAnalyses that did not identify the topic are listed with null sentiment, score and count",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "topics"
+                ],
+                "summary": "Get topic sentiment trend",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "product_functionality_features",
+                        "description": "Topic enum value, case-insensitive",
+                        "name": "topic_enum",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Topic sentiment trend retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.List"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/responses.TopicSentimentTrendPointResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request - invalid topic enum",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing JWT token",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responder.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "requests.AdhocAnalysisRequest": {
+            "description": "Request payload for analyzing an explicit set of feedbacks outside the pending queue.",
+            "type": "object",
+            "required": [
+                "feedback_ids"
+            ],
+            "properties": {
+                "feedback_ids": {
+                    "description": "IDs of the feedbacks to analyze, capped by max_feedbacks_in_context (required)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "550e8400-e29b-41d4-a716-446655440000"
+                    ]
+                }
+            }
+        },
+        "requests.BatchDeleteFeedbacksRequest": {
+            "description": "Request payload for soft deleting a batch of feedbacks in a single transaction.",
+            "type": "object",
+            "required": [
+                "feedback_ids"
+            ],
+            "properties": {
+                "feedback_ids": {
+                    "description": "IDs of the feedbacks to delete, unique and capped by max_batch_delete_size (required)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "550e8400-e29b-41d4-a716-446655440000"
+                    ]
+                }
+            }
+        },
+        "requests.CreateFeedbackRequest": {
+            "description": "Request payload for creating a new feedback submission.",
+            "type": "object",
+            "required": [
+                "rating"
+            ],
+            "properties": {
+                "comment": {
+                    "description": "Feedback comment text, between the configured minimum length (1 by default) and 1000 characters (required unless rating-only feedback is enabled)",
+                    "type": "string",
+                    "example": "Really nice!"
+                },
+                "metadata": {
+                    "description": "Technical context of the reporter (optional)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/requests.FeedbackMetadataRequest"
+                        }
+                    ]
+                },
+                "rating": {
+                    "description": "Rating value within the configured scale, 1 to 5 by default (required)",
+                    "type": "integer",
+                    "example": 5
+                },
+                "source": {
+                    "description": "Channel the feedback was submitted through: web, mobile, api or email (optional, defaults to web)",
+                    "type": "string",
+                    "example": "web"
+                },
+                "tags": {
+                    "description": "Labels chosen by the submitter: up to 10, each 1-32 letters, digits, '-' or '_', case-insensitive (optional)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "bug",
+                        "feature-request"
+                    ]
+                }
+            }
+        },
+        "requests.FeedbackMetadataRequest": {
+            "description": "Technical context of the reporter; every field is optional.",
+            "type": "object",
+            "properties": {
+                "app_version": {
+                    "description": "App version, up to 32 letters, digits, '.', '-' or '+'",
+                    "type": "string",
+                    "example": "2.4.1"
+                },
+                "os": {
+                    "description": "Operating system, up to 64 characters",
+                    "type": "string",
+                    "example": "iOS 17.2"
+                },
+                "platform": {
+                    "description": "Client platform: web, ios, android or desktop",
+                    "type": "string",
+                    "example": "ios"
+                }
+            }
+        },
+        "requests.LoginUserRequest": {
+            "description": "Request payload for user login/authentication.",
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "description": "User email address (required)",
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "description": "User password (required)",
+                    "type": "string",
+                    "example": "SecurePassword123!"
+                }
+            }
+        },
+        "requests.RegisterUserRequest": {
+            "description": "Request payload for registering a new user account.",
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "description": "User email address (required, must be valid email)",
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "password": {
+                    "description": "User password (required, minimum 8 characters)",
+                    "type": "string",
+                    "minLength": 8,
+                    "example": "SecurePassword123!"
+                }
+            }
+        },
+        "responder.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the machine-readable error code, e.g. not_found or token_expired.",
+                    "type": "string",
+                    "example": "not_found"
+                },
+                "details": {
+                    "description": "Details carries additional structured information about the error, if any.",
+                    "type": "object"
+                },
+                "message": {
+                    "description": "Message is a human-readable description of the error.",
+                    "type": "string",
+                    "example": "Analysis not found"
+                },
+                "request_id": {
+                    "description": "RequestID identifies the request in the server logs, also sent in the X-Request-ID header.",
+                    "type": "string",
+                    "example": "1f0e3b8c-5c4e-4a43-9d59-0f4a0f7f1f5e"
+                }
+            }
+        },
+        "responses.AnalysisDetailResponse": {
+            "description": "Response payload containing detailed analysis with topics and feedback IDs.",
+            "type": "object",
+            "properties": {
+                "analysis": {
+                    "$ref": "#/definitions/responses.AnalysisResponse"
+                },
+                "feedbacks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responses.FeedbackWithTopicsResponse"
+                    }
+                },
+                "topic_deltas": {
+                    "description": "Changes versus the previous analysis, largest first; empty for the first analysis",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responses.TopicDeltaResponse"
+                    }
+                },
+                "topics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responses.TopicAnalysisResponse"
+                    }
+                }
+            }
+        },
+        "responses.AnalysisEstimateResponse": {
+            "description": "Response payload containing the token and cost estimate of an analysis that has not been run.",
+            "type": "object",
+            "properties": {
+                "candidate_count": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "estimated_cost_usd": {
+                    "type": "number",
+                    "example": 0.0031
+                },
+                "feedback_count": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "tokens": {
+                    "$ref": "#/definitions/responses.TokenEstimateResponse"
+                }
+            }
+        },
+        "responses.AnalysisEventResponse": {
+            "type": "object",
+            "properties": {
+                "details": {
+                    "description": "Step specific information, e.g. topics_count or the failure reason",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "duration_ms": {
+                    "description": "Time the step took, for llm_returned, topics_created, completed and failed",
+                    "type": "integer",
+                    "example": 1250
+                },
+                "occurred_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "llm_called",
+                        "llm_returned",
+                        "topics_created",
+                        "completed",
+                        "failed"
+                    ],
+                    "example": "llm_returned"
+                }
+            }
+        },
+        "responses.AnalysisEventsResponse": {
+            "description": "Steps of an analysis in the order they happened, stored for every analysis.",
+            "type": "object",
+            "properties": {
+                "analysis_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responses.AnalysisEventResponse"
+                    }
+                }
+            }
+        },
+        "responses.AnalysisRawOutputResponse": {
+            "description": "Output text of the model as stored for debugging, with PII masked.",
+            "type": "object",
+            "properties": {
+                "analysis_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "output": {
+                    "type": "string",
+                    "example": "{\"overall_summary\":\"Users praise the new dashboard.\"}"
+                }
+            }
+        },
+        "responses.AnalysisResponse": {
+            "description": "Response payload containing analysis details.",
+            "type": "object",
+            "properties": {
+                "analysis_duration_ms": {
+                    "type": "integer",
+                    "example": 5000
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "deduplicated_count": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "failure_reason": {
+                    "type": "string"
+                },
+                "feedback_count": {
+                    "type": "integer",
                     "example": 100
                 },
                 "id": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "key_insights": {
+                "key_insights": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "model": {
+                    "type": "string",
+                    "example": "gpt-5-mini"
+                },
+                "new_feedback_count": {
+                    "type": "integer"
+                },
+                "no_topics_identified": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "overall_summary": {
+                    "description": "Null while processing or if the analysis failed",
+                    "type": "string"
+                },
+                "period_end": {
+                    "type": "string",
+                    "example": "2024-01-31T23:59:59Z"
+                },
+                "period_semantics": {
+                    "description": "What period_start and period_end represent",
+                    "type": "string",
+                    "enum": [
+                        "feedback_span",
+                        "analysis_window"
+                    ],
+                    "example": "feedback_span"
+                },
+                "period_start": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "previous_analysis_id": {
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "Absent for analyses recorded before providers were tracked",
+                    "type": "string",
+                    "example": "openai"
+                },
+                "qualified_model": {
+                    "description": "Provider-qualified model, only the model if the provider is unknown",
+                    "type": "string",
+                    "example": "openai/gpt-5-mini"
+                },
+                "representative": {
+                    "description": "False if fewer feedbacks were analyzed than configured to draw conclusions from",
+                    "type": "boolean",
+                    "example": true
+                },
+                "sentiment": {
+                    "type": "string",
+                    "example": "positive"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "success"
+                },
+                "tokens": {
+                    "type": "integer",
+                    "example": 5000
+                }
+            }
+        },
+        "responses.AnalysisStatusResponse": {
+            "description": "Lightweight response payload for polling an analysis until it completes.",
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "processing"
+                }
+            }
+        },
+        "responses.AnalyticsOverviewResponse": {
+            "description": "Totals across all analyses for executive reporting. The cost prices all tokens at the input token price, since analyses only record their total tokens, and is therefore a lower bound.",
+            "type": "object",
+            "properties": {
+                "analyzed_feedbacks": {
+                    "type": "integer",
+                    "example": 1250
+                },
+                "average_feedbacks_per_analysis": {
+                    "type": "number",
+                    "example": 31.25
+                },
+                "estimated_cost_usd": {
+                    "type": "number",
+                    "example": 0.21
+                },
+                "most_common_topic": {
+                    "type": "string",
+                    "example": "performance_reliability"
+                },
+                "most_common_topic_feedback_count": {
+                    "type": "integer",
+                    "example": 310
+                },
+                "most_common_topic_name": {
+                    "type": "string",
+                    "example": "Performance \u0026 Reliability"
+                },
+                "successful_analyses": {
+                    "type": "integer",
+                    "example": 40
+                },
+                "total_analyses": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "total_tokens": {
+                    "type": "integer",
+                    "example": 850000
+                }
+            }
+        },
+        "responses.BatchDeleteFeedbacksResponse": {
+            "description": "Outcome of a batch delete per requested feedback, in request order.",
+            "type": "object",
+            "properties": {
+                "deleted_count": {
+                    "description": "Number of feedbacks deleted by this request",
+                    "type": "integer",
+                    "example": 2
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responses.FeedbackDeleteResultResponse"
+                    }
+                }
+            }
+        },
+        "responses.DeadLetterResponse": {
+            "description": "Event that could not be delivered to a sink within the maximum number of attempts.",
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "data": {
+                    "type": "object"
+                },
+                "event_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "event_type": {
+                    "type": "string",
+                    "example": "feedback.created"
+                },
+                "failed_at": {
+                    "type": "string",
+                    "example": "2024-01-02T00:00:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "last_error": {
+                    "type": "string",
+                    "example": "webhook endpoint responded with HTTP 503"
+                },
+                "occurred_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "sink": {
+                    "type": "string",
+                    "example": "webhook:https://example.com/hooks/feedback"
+                }
+            }
+        },
+        "responses.FeedbackAnalysisResponse": {
+            "description": "Analysis that included a feedback, with the topics the feedback was assigned to in it.",
+            "type": "object",
+            "properties": {
+                "analysis": {
+                    "$ref": "#/definitions/responses.AnalysisResponse"
+                },
+                "topics": {
+                    "description": "Empty if the feedback was assigned to no topic",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responses.TopicAnalysisResponse"
+                    }
+                }
+            }
+        },
+        "responses.FeedbackDeleteResultResponse": {
+            "description": "Outcome of deleting a single feedback: deleted, not_found or already_deleted.",
+            "type": "object",
+            "properties": {
+                "feedback_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "deleted",
+                        "not_found",
+                        "already_deleted"
+                    ],
+                    "example": "deleted"
+                }
+            }
+        },
+        "responses.FeedbackMetadataResponse": {
+            "description": "Technical context of the reporter; unset fields are omitted.",
+            "type": "object",
+            "properties": {
+                "app_version": {
+                    "description": "App version",
+                    "type": "string",
+                    "example": "2.4.1"
+                },
+                "os": {
+                    "description": "Operating system",
+                    "type": "string",
+                    "example": "iOS 17.2"
+                },
+                "platform": {
+                    "description": "Client platform",
+                    "type": "string",
+                    "example": "ios"
+                }
+            }
+        },
+        "responses.FeedbackResponse": {
+            "description": "Response payload containing feedback details.",
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "description": "Feedback comment text",
+                    "type": "string",
+                    "example": "Great service!"
+                },
+                "comment_language": {
+                    "description": "Detected ISO 639-1 language of the comment (if translation is enabled)",
+                    "type": "string",
+                    "example": "de"
+                },
+                "created_at": {
+                    "description": "Creation timestamp",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "deleted_at": {
+                    "description": "Deletion timestamp (if deleted)",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "id": {
+                    "description": "Feedback unique identifier",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "metadata": {
+                    "description": "Technical context of the reporter",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/responses.FeedbackMetadataResponse"
+                        }
+                    ]
+                },
+                "original_comment": {
+                    "description": "Comment as submitted, before markup was stripped (if preserved)",
+                    "type": "string",
+                    "example": "\u003cp\u003eGreat service!\u003c/p\u003e"
+                },
+                "rating": {
+                    "description": "Rating value within the configured scale",
+                    "type": "integer",
+                    "example": 5
+                },
+                "source": {
+                    "description": "Channel the feedback was submitted through",
+                    "type": "string",
+                    "example": "web"
+                },
+                "tags": {
+                    "description": "Labels chosen by the submitter",
                     "type": "array",
                     "items": {
                         "type": "string"
-                    }
+                    },
+                    "example": [
+                        "bug",
+                        "feature-request"
+                    ]
                 },
-                "model": {
+                "translated_comment": {
+                    "description": "Comment translated into the analysis language (if translated)",
                     "type": "string",
-                    "example": "gpt-5-mini"
+                    "example": "Great service!"
                 },
-                "new_feedback_count": {
-                    "type": "integer"
+                "updated_at": {
+                    "description": "Last update timestamp",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
+        },
+        "responses.FeedbackWithTopicsResponse": {
+            "description": "Response payload containing feedback details with associated topics.",
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string",
+                    "example": "Great product!"
                 },
-                "overall_summary": {
-                    "type": "string"
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
                 },
-                "period_end": {
+                "id": {
                     "type": "string",
-                    "example": "2024-01-31T23:59:59Z"
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "period_start": {
+                "rating": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "topics": {
+                    "description": "Topic enum values",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "responses.List": {
+            "description": "Envelope of a complete, unpaginated list.",
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "All items of the list, typed per endpoint in the API docs",
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                }
+            }
+        },
+        "responses.LoginUserResponse": {
+            "description": "Response payload containing authentication token and user details.",
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "Token expiration time in seconds",
+                    "type": "integer",
+                    "example": 3600
+                },
+                "token": {
+                    "description": "JWT authentication token",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "user": {
+                    "description": "User information including roles",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/responses.UserInfo"
+                        }
+                    ]
+                }
+            }
+        },
+        "responses.Paginated": {
+            "description": "Paginated list envelope shared by all paginated list endpoints.",
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "description": "Whether more items follow the current page",
+                    "type": "boolean",
+                    "example": true
+                },
+                "items": {
+                    "description": "Items of the current page, typed per endpoint in the API docs",
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                },
+                "limit": {
+                    "description": "Maximum number of items in a page",
+                    "type": "integer",
+                    "example": 10
+                },
+                "offset": {
+                    "description": "Number of items skipped before the current page",
+                    "type": "integer",
+                    "example": 0
+                },
+                "total": {
+                    "description": "Number of items matching the query across all pages",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "responses.RegisterUserResponse": {
+            "description": "Response payload containing user registration details.",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Account creation timestamp",
                     "type": "string",
                     "example": "2024-01-01T00:00:00Z"
                 },
-                "previous_analysis_id": {
-                    "type": "string"
+                "email": {
+                    "description": "User email address",
+                    "type": "string",
+                    "example": "user@example.com"
                 },
-                "sentiment": {
+                "id": {
+                    "description": "User unique identifier",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "responses.ReprocessUnanalyzedResponse": {
+            "description": "Number of feedbacks that were never successfully analyzed and were added back to the pending queue.",
+            "type": "object",
+            "properties": {
+                "reprocessed_count": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "responses.SentimentAlignmentResponse": {
+            "description": "How well the topic sentiment agrees with the rating distribution of its feedbacks.",
+            "type": "object",
+            "properties": {
+                "high_rating_share": {
+                    "type": "number",
+                    "example": 0.8
+                },
+                "implied_sentiment": {
                     "type": "string",
                     "example": "positive"
                 },
-                "status": {
-                    "type": "string",
-                    "example": "success"
+                "low_rating_share": {
+                    "type": "number",
+                    "example": 0.1
+                },
+                "mismatch": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "aligned",
+                        "divergent",
+                        "mismatched",
+                        "unknown"
+                    ],
+                    "example": "aligned"
+                }
+            }
+        },
+        "responses.SentimentDistributionResponse": {
+            "description": "Number of feedbacks of a topic that are positive, mixed and negative.",
+            "type": "object",
+            "properties": {
+                "mixed": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "negative": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "positive": {
+                    "type": "integer",
+                    "example": 6
+                }
+            }
+        },
+        "responses.TagAgreementResponse": {
+            "description": "Agreement between a user tag and the topics assigned by the LLM.",
+            "type": "object",
+            "properties": {
+                "agreement_rate": {
+                    "type": "number",
+                    "example": 0.8
+                },
+                "dominant_topic": {
+                    "type": "string",
+                    "example": "performance_reliability"
+                },
+                "feedback_count": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "tag": {
+                    "type": "string",
+                    "example": "bug"
+                },
+                "topics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responses.TagTopicCountResponse"
+                    }
+                }
+            }
+        },
+        "responses.TagTopicAgreementResponse": {
+            "description": "Correlation matrix of user tags and LLM-assigned topics, with the share of tagged feedbacks assigned the dominant topic of their tag as agreement rate.",
+            "type": "object",
+            "properties": {
+                "agreement_rate": {
+                    "type": "number",
+                    "example": 0.72
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responses.TagAgreementResponse"
+                    }
+                }
+            }
+        },
+        "responses.TagTopicCountResponse": {
+            "description": "Number and share of the feedbacks with a tag that were assigned a topic.",
+            "type": "object",
+            "properties": {
+                "feedback_count": {
+                    "type": "integer",
+                    "example": 8
+                },
+                "share": {
+                    "type": "number",
+                    "example": 0.8
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "performance_reliability"
+                },
+                "topic_name": {
+                    "type": "string",
+                    "example": "Performance \u0026 Reliability"
+                }
+            }
+        },
+        "responses.TokenAccuracyBucketResponse": {
+            "description": "Number of analyses whose ratio is at least min_ratio and below max_ratio. The last bucket has no max_ratio.",
+            "type": "object",
+            "properties": {
+                "analysis_count": {
+                    "type": "integer",
+                    "example": 64
+                },
+                "max_ratio": {
+                    "type": "number",
+                    "example": 1.05
                 },
-                "tokens": {
-                    "type": "integer",
-                    "example": 5000
+                "min_ratio": {
+                    "type": "number",
+                    "example": 0.95
                 }
             }
         },
-        "responses.FeedbackListResponse": {
-            "description": "Response payload containing a list of feedback entries.",
+        "responses.TokenAccuracyResponse": {
+            "description": "Distribution of the ratio of estimated to actual total tokens over the analyses with recorded token usage. A ratio above 1 means the estimator overestimated the request. Usage is only recorded while record_token_accuracy is enabled.",
             "type": "object",
             "properties": {
-                "feedbacks": {
-                    "description": "List of feedback entries",
+                "buckets": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/responses.FeedbackResponse"
+                        "$ref": "#/definitions/responses.TokenAccuracyBucketResponse"
                     }
                 },
-                "total": {
-                    "description": "Total number of feedback entries",
+                "max_ratio": {
+                    "type": "number",
+                    "example": 1.93
+                },
+                "mean_ratio": {
+                    "type": "number",
+                    "example": 1.12
+                },
+                "median_ratio": {
+                    "type": "number",
+                    "example": 1.09
+                },
+                "min_ratio": {
+                    "type": "number",
+                    "example": 0.71
+                },
+                "p10_ratio": {
+                    "type": "number",
+                    "example": 0.88
+                },
+                "p90_ratio": {
+                    "type": "number",
+                    "example": 1.41
+                },
+                "recording_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "sample_count": {
                     "type": "integer",
-                    "example": 10
+                    "example": 120
                 }
             }
         },
-        "responses.FeedbackResponse": {
-            "description": "Response payload containing feedback details.",
+        "responses.TokenEstimateResponse": {
+            "description": "Estimated tokens of an analysis request, broken into components.",
             "type": "object",
             "properties": {
-                "comment": {
-                    "description": "Feedback comment text",
-                    "type": "string",
-                    "example": "Great service!"
+                "feedbacks": {
+                    "type": "integer",
+                    "example": 900
                 },
-                "created_at": {
-                    "description": "Creation timestamp",
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "input": {
+                    "type": "integer",
+                    "example": 1320
                 },
-                "deleted_at": {
-                    "description": "Deletion timestamp (if deleted)",
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "payload_overhead": {
+                    "type": "integer",
+                    "example": 50
                 },
-                "id": {
-                    "description": "Feedback unique identifier",
-                    "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                "previous_analysis": {
+                    "type": "integer",
+                    "example": 120
                 },
-                "rating": {
-                    "description": "Rating value from 1 to 5",
+                "response": {
                     "type": "integer",
-                    "example": 5
+                    "example": 1200
                 },
-                "updated_at": {
-                    "description": "Last update timestamp",
-                    "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                "system_prompt": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 2520
                 }
             }
         },
-        "responses.FeedbackWithTopicsResponse": {
-            "description": "Response payload containing feedback details with associated topics.",
+        "responses.TopicAnalysisDetailResponse": {
+            "description": "Response payload containing a topic analysis of any analysis with its assigned feedbacks.",
             "type": "object",
             "properties": {
-                "comment": {
+                "analysis_id": {
                     "type": "string",
-                    "example": "Great product!"
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "confidence": {
+                    "description": "Model confidence between 0 and 1, null unless enabled",
+                    "type": "number",
+                    "example": 0.85
                 },
                 "created_at": {
                     "type": "string",
                     "example": "2024-01-01T00:00:00Z"
                 },
+                "feedback_count": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "feedbacks": {
+                    "description": "Newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responses.FeedbackResponse"
+                    }
+                },
                 "id": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
-                "rating": {
-                    "type": "integer",
-                    "example": 5
-                },
-                "topics": {
-                    "description": "Topic enum values",
+                "recommendations": {
+                    "description": "Recommended actions, empty unless enabled",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
-                }
-            }
-        },
-        "responses.LoginUserResponse": {
-            "description": "Response payload containing authentication token and user details.",
-            "type": "object",
-            "properties": {
-                "expires_in": {
-                    "description": "Token expiration time in seconds",
-                    "type": "integer",
-                    "example": 3600
                 },
-                "token": {
-                    "description": "JWT authentication token",
+                "sentiment": {
+                    "description": "Dominant sentiment of the topic",
                     "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                    "example": "positive"
                 },
-                "user": {
-                    "description": "User information including roles",
+                "sentiment_conflict": {
+                    "description": "The model sentiment contradicted the rating of the only feedback",
+                    "type": "boolean",
+                    "example": false
+                },
+                "sentiment_distribution": {
+                    "description": "Feedbacks per sentiment, null if not counted",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/responses.UserInfo"
+                            "$ref": "#/definitions/responses.SentimentDistributionResponse"
                         }
                     ]
-                }
-            }
-        },
-        "responses.RegisterUserResponse": {
-            "description": "Response payload containing user registration details.",
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "description": "Account creation timestamp",
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "topic": {
                     "type": "string",
-                    "example": "2024-01-01T00:00:00Z"
+                    "example": "product_functionality_features"
                 },
-                "email": {
-                    "description": "User email address",
+                "topic_name": {
                     "type": "string",
-                    "example": "user@example.com"
+                    "example": "Product Functionality \u0026 Features"
                 },
-                "id": {
-                    "description": "User unique identifier",
+                "updated_at": {
                     "type": "string",
-                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                    "example": "2024-01-01T00:00:00Z"
                 }
             }
         },
@@ -969,6 +3349,11 @@ const docTemplate = `{
             "description": "Response payload containing topic analysis details.",
             "type": "object",
             "properties": {
+                "confidence": {
+                    "description": "Model confidence between 0 and 1, null unless enabled",
+                    "type": "number",
+                    "example": 0.85
+                },
                 "created_at": {
                     "type": "string",
                     "example": "2024-01-01T00:00:00Z"
@@ -981,10 +3366,31 @@ const docTemplate = `{
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
                 },
+                "recommendations": {
+                    "description": "Recommended actions, empty unless enabled",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "sentiment": {
+                    "description": "Dominant sentiment of the topic",
                     "type": "string",
                     "example": "positive"
                 },
+                "sentiment_conflict": {
+                    "description": "The model sentiment contradicted the rating of the only feedback",
+                    "type": "boolean",
+                    "example": false
+                },
+                "sentiment_distribution": {
+                    "description": "Feedbacks per sentiment, null if not counted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/responses.SentimentDistributionResponse"
+                        }
+                    ]
+                },
                 "summary": {
                     "type": "string"
                 },
@@ -1002,6 +3408,35 @@ const docTemplate = `{
                 }
             }
         },
+        "responses.TopicDeltaResponse": {
+            "description": "Change in the number of feedbacks of a topic versus the previous analysis, stored when the analysis completed.",
+            "type": "object",
+            "properties": {
+                "current_count": {
+                    "description": "0 if the topic is not identified anymore",
+                    "type": "integer",
+                    "example": 5
+                },
+                "delta": {
+                    "description": "current_count - previous_count",
+                    "type": "integer",
+                    "example": 3
+                },
+                "previous_count": {
+                    "description": "0 if the previous analysis did not identify the topic",
+                    "type": "integer",
+                    "example": 2
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "performance_reliability"
+                },
+                "topic_name": {
+                    "type": "string",
+                    "example": "Performance \u0026 Reliability"
+                }
+            }
+        },
         "responses.TopicDetailsResponse": {
             "description": "Response payload containing detailed topic information with feedbacks.",
             "type": "object",
@@ -1020,6 +3455,9 @@ const docTemplate = `{
                         "$ref": "#/definitions/responses.FeedbackResponse"
                     }
                 },
+                "rating_alignment": {
+                    "$ref": "#/definitions/responses.SentimentAlignmentResponse"
+                },
                 "sentiment": {
                     "type": "string",
                     "example": "positive"
@@ -1040,19 +3478,105 @@ const docTemplate = `{
                 }
             }
         },
+        "responses.TopicHistoryEntryResponse": {
+            "description": "Result of a topic within a single analysis, used to follow the topic across analyses.",
+            "type": "object",
+            "properties": {
+                "analysis_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "analyzed_at": {
+                    "description": "Creation time of the analysis",
+                    "type": "string",
+                    "example": "2024-01-02T00:00:00Z"
+                },
+                "feedback_count": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "period_end": {
+                    "type": "string",
+                    "example": "2024-01-02T00:00:00Z"
+                },
+                "period_start": {
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "sentiment": {
+                    "type": "string",
+                    "example": "positive"
+                },
+                "summary": {
+                    "type": "string"
+                },
+                "topic_analysis_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "responses.TopicSentimentTrendPointResponse": {
+            "description": "Point of a topic sentiment chart. Sentiment, score and feedback count are null for analyses that did not identify the topic, so that gaps are not interpolated.",
+            "type": "object",
+            "properties": {
+                "analysis_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "feedback_count": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "period_end": {
+                    "type": "string",
+                    "example": "2024-01-02T00:00:00Z"
+                },
+                "sentiment": {
+                    "type": "string",
+                    "example": "negative"
+                },
+                "sentiment_score": {
+                    "description": "1 positive, 0 mixed, -1 negative",
+                    "type": "integer",
+                    "example": -1
+                }
+            }
+        },
         "responses.TopicStatsListResponse": {
-            "description": "Response payload containing a list of topic statistics.",
+            "description": "Response payload containing topic statistics from the latest analysis.",
             "type": "object",
             "properties": {
-                "topics": {
+                "has_more": {
+                    "description": "Whether more items follow the current page",
+                    "type": "boolean",
+                    "example": true
+                },
+                "items": {
+                    "description": "Items of the current page, typed per endpoint in the API docs",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/responses.TopicStatsResponse"
+                        "type": "object"
                     }
                 },
+                "limit": {
+                    "description": "Maximum number of items in a page",
+                    "type": "integer",
+                    "example": 10
+                },
+                "no_topics_identified": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "offset": {
+                    "description": "Number of items skipped before the current page",
+                    "type": "integer",
+                    "example": 0
+                },
                 "total": {
+                    "description": "Number of items matching the query across all pages",
                     "type": "integer",
-                    "example": 13
+                    "example": 42
                 }
             }
         },
@@ -1103,6 +3627,47 @@ const docTemplate = `{
                     ]
                 }
             }
+        },
+        "responses.UserResponse": {
+            "description": "User account details including status.",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Account creation timestamp",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "email": {
+                    "description": "User email address",
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "id": {
+                    "description": "User unique identifier",
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                },
+                "roles": {
+                    "description": "User roles",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"user\"]"
+                    ]
+                },
+                "status": {
+                    "description": "Account status",
+                    "type": "string",
+                    "example": "active"
+                },
+                "updated_at": {
+                    "description": "Last update timestamp",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -7,27 +7,37 @@ import (
 	"log"
 	"net/http"
 	"os/signal"
+	"slices"
 	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/external/llm"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external/moderation"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external/webhook"
 	handlersv1 "github.com/ktruedat/llm-feedback-analysis/internal/app/handlers/http/v1"
 	analysisRepository "github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/analysis"
 	feedbackRepository "github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/feedback"
+	outboxRepository "github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/outbox"
 	userRepository "github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/user"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/services/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services/events"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/services/feedback"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/services/user"
+	domainAnalysis "github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	domainFeedback "github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
 	"github.com/ktruedat/llm-feedback-analysis/migrations"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
 	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/http/responder"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/querier"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
 )
 
 type App struct {
@@ -38,6 +48,7 @@ type App struct {
 	srv           *server
 	restResponder responder.RestResponder
 	analyzer      services.AnalyzerService
+	events        services.EventPublisher
 }
 
 func (app *App) Start() error {
@@ -46,7 +57,12 @@ func (app *App) Start() error {
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
-	pgxPool, err := trace.InstrumentPgxPool(ctx, app.cfg.DB.DSN, app.tracing)
+	pgxPool, err := trace.InstrumentPgxPool(
+		ctx,
+		app.cfg.DB.DSN,
+		app.tracing,
+		trace.WithPoolLimits(app.cfg.DB.PoolLimits()),
+	)
 	if err != nil {
 		return fmt.Errorf("failed to create pgx pool: %w", err)
 	}
@@ -56,20 +72,170 @@ func (app *App) Start() error {
 		return fmt.Errorf("failed to apply migrations: %w", err)
 	}
 
-	q := querier.NewPgxPool(pgxPool)
-	feedbackRepo := feedbackRepository.NewFeedbackRepository(q)
-	userRepo := userRepository.NewUserRepository(q)
-	analysisRepo := analysisRepository.NewAnalysisRepository(q)
+	if app.cfg.Feedback.RatingMin != 0 || app.cfg.Feedback.RatingMax != 0 {
+		if err := domainFeedback.ConfigureRatingScale(
+			domainFeedback.RatingScale{Min: app.cfg.Feedback.RatingMin, Max: app.cfg.Feedback.RatingMax},
+		); err != nil {
+			return fmt.Errorf("failed to configure rating scale: %w", err)
+		}
+	}
+	domainFeedback.ConfigureRatingOnly(app.cfg.Feedback.AllowRatingOnly)
+	if app.cfg.Feedback.InvalidUTF8Action != "" {
+		policy := domainFeedback.InvalidUTF8Policy(app.cfg.Feedback.InvalidUTF8Action)
+		if err := domainFeedback.ConfigureInvalidUTF8Policy(policy); err != nil {
+			return fmt.Errorf("failed to configure invalid UTF-8 policy: %w", err)
+		}
+	}
+	if app.cfg.Feedback.MinCommentLength != 0 {
+		if err := domainFeedback.ConfigureMinCommentLength(app.cfg.Feedback.MinCommentLength); err != nil {
+			return fmt.Errorf("failed to configure minimum comment length: %w", err)
+		}
+	}
+
+	q, err := querier.NewPgxPool(pgxPool)
+	if err != nil {
+		return fmt.Errorf("failed to create querier: %w", err)
+	}
+	feedbackRepo, err := feedbackRepository.NewFeedbackRepository(q)
+	if err != nil {
+		return err
+	}
+	userRepo, err := userRepository.NewUserRepository(q)
+	if err != nil {
+		return err
+	}
+	analysisRepo, err := analysisRepository.NewAnalysisRepository(
+		q,
+		analysisRepository.WithCopyThreshold(app.cfg.DB.CopyThreshold()),
+	)
+	if err != nil {
+		return err
+	}
 
 	errChecker := ce.NewErrorChecker()
 	transactor := sql.NewTransactionManager(pgxPool)
 
+	// PII scrubber for feedback comments, nil if disabled
+	piiScrubber, err := app.cfg.Feedback.NewPIIScrubber()
+	if err != nil {
+		return fmt.Errorf("failed to configure pii scrubbing: %w", err)
+	}
+
+	// Markup stripper for feedback comments, nil if disabled
+	markupStripper, err := app.cfg.Feedback.NewMarkupStripper()
+	if err != nil {
+		return fmt.Errorf("failed to configure markup stripping: %w", err)
+	}
+
 	// Create OpenAI LLM client
+	apiStyle, err := llm.ParseAPIStyle(app.cfg.LLMAnalysis.OpenAIAPIStyle)
+	if err != nil {
+		return fmt.Errorf("failed to configure llm client: %w", err)
+	}
+	payloadFields, err := llm.ParsePayloadFields(app.cfg.LLMAnalysis.PayloadFields)
+	if err != nil {
+		return fmt.Errorf("failed to configure llm client: %w", err)
+	}
+	enabledTopics, err := llm.ParseEnabledTopics(app.cfg.LLMAnalysis.EnabledTopics)
+	if err != nil {
+		return fmt.Errorf("failed to configure llm client: %w", err)
+	}
+	disabledTopicPolicy, err := llm.ParseDisabledTopicPolicy(app.cfg.LLMAnalysis.DisabledTopicPolicy)
+	if err != nil {
+		return fmt.Errorf("failed to configure llm client: %w", err)
+	}
+	llmBreaker := llm.NewCircuitBreaker(
+		app.cfg.LLMAnalysis.CircuitBreakerThreshold,
+		app.cfg.LLMAnalysis.CircuitBreakerCooldown(),
+		clock.New(),
+	)
+	llmOptions := []llm.ClientOption{
+		llm.WithAPIStyle(apiStyle),
+		llm.WithBaseURL(app.cfg.LLMAnalysis.OpenAIBaseURL),
+		llm.WithConcurrencyLimiter(llm.NewConcurrencyLimiter(app.cfg.LLMAnalysis.MaxConcurrentRequests)),
+		llm.WithCircuitBreaker(llmBreaker),
+		llm.WithPayloadFields(payloadFields),
+		llm.WithEnabledTopics(enabledTopics, disabledTopicPolicy),
+		llm.WithMaxSummaryLength(app.cfg.LLMAnalysis.MaxSummaryLength),
+		llm.WithMaxKeyInsights(app.cfg.LLMAnalysis.MaxKeyInsights),
+		llm.WithTopicRecommendations(app.cfg.LLMAnalysis.IncludeTopicRecommendations),
+		llm.WithTopicConfidence(app.cfg.LLMAnalysis.IncludeTopicConfidence),
+		llm.WithDuplicateTopicMerging(app.cfg.LLMAnalysis.MergeDuplicateTopics),
+		llm.WithFallbackSentiment(domainAnalysis.Sentiment(app.cfg.LLMAnalysis.FallbackSentiment)),
+		llm.WithSampling(
+			llm.Sampling{
+				Temperature: app.cfg.LLMAnalysis.Temperature,
+				TopP:        app.cfg.LLMAnalysis.TopP,
+				Seed:        app.cfg.LLMAnalysis.Seed,
+			},
+		),
+		llm.WithAudit(
+			llm.Audit{
+				Store:    app.cfg.LLMAnalysis.Store,
+				Metadata: app.cfg.LLMAnalysis.RequestMetadata,
+			},
+		),
+		llm.WithCommentScrubber(piiScrubber),
+		llm.WithCorrelationHeaders(
+			llm.CorrelationHeaders{
+				TraceID:   app.cfg.LLMAnalysis.TraceIDHeader,
+				RequestID: app.cfg.LLMAnalysis.RequestIDHeader,
+			},
+		),
+	}
+	if app.cfg.LLMAnalysis.StoreRawOutput {
+		// Stored outputs are always redacted, even if comments are not scrubbed
+		rawOutputScrubber, err := app.cfg.Feedback.NewRedactionScrubber()
+		if err != nil {
+			return fmt.Errorf("failed to configure raw output redaction: %w", err)
+		}
+		llmOptions = append(llmOptions, llm.WithRawOutputCapture(rawOutputScrubber))
+	}
 	llmClient := llm.NewOpenAIClient(
 		app.cfg.LLMAnalysis.OpenAIAPIKey,
 		app.cfg.LLMAnalysis.OpenAIModel,
+		app.cfg.LLMAnalysis.MaxTopicsPerAnalysis,
+		logger,
+		llmOptions...,
+	)
+
+	var publisherOpts []events.PublisherOption
+	if app.cfg.Webhooks.OutboxEnabled {
+		outboxRepo, err := outboxRepository.NewOutboxRepository(q)
+		if err != nil {
+			return err
+		}
+		publisherOpts = append(
+			publisherOpts,
+			events.WithOutbox(outboxRepo, transactor, &app.cfg.Webhooks, clock.New()),
+		)
+	}
+	eventPublisher := events.NewEventPublisher(
 		logger,
+		time.Duration(app.cfg.Webhooks.DeliveryTimeoutSeconds)*time.Second,
+		publisherOpts...,
 	)
+	webhookURLs, webhookEvents := webhookSubscriptions(&app.cfg.Webhooks)
+	for _, webhookURL := range webhookURLs {
+		eventPublisher.Register(
+			webhook.NewSink(
+				webhookURL,
+				app.cfg.Webhooks.Secret,
+				app.cfg.Webhooks.MaxRetries,
+				time.Duration(app.cfg.Webhooks.InitialBackoffMillis)*time.Millisecond,
+				time.Duration(app.cfg.Webhooks.RequestTimeoutSeconds)*time.Second,
+				clock.New(),
+				logger,
+			),
+			webhookEvents[webhookURL]...,
+		)
+	}
+	app.events = eventPublisher
+
+	// Sinks are registered before starting, so that the dispatcher can deliver events left over from a previous run
+	if err := eventPublisher.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start event publisher: %w", err)
+	}
 
 	// Create analyzer service (performs analysis)
 	analyzerSvc := analysis.NewAnalyzerService(
@@ -78,6 +244,10 @@ func (app *App) Start() error {
 		analysisRepo,
 		feedbackRepo,
 		llmClient,
+		llmBreaker,
+		eventPublisher,
+		sql.NewAdvisoryLocker(pgxPool),
+		clock.New(),
 	)
 	app.analyzer = analyzerSvc
 
@@ -87,17 +257,53 @@ func (app *App) Start() error {
 		return fmt.Errorf("failed to start analyzer: %w", err)
 	}
 
+	var moderator external.Moderator = external.NoopModerator{}
+	if app.cfg.Feedback.ModerationEnabled {
+		moderator = moderation.NewOpenAIModerator(
+			app.cfg.LLMAnalysis.OpenAIAPIKey,
+			app.cfg.Feedback.ModerationModel,
+			app.cfg.LLMAnalysis.OpenAIBaseURL,
+			logger,
+		)
+	}
+
+	var translator external.Translator = external.NoopTranslator{}
+	if app.cfg.Feedback.TranslationEnabled {
+		translator = newTranslationClient(&app.cfg.LLMAnalysis, llmOptions, logger)
+	}
+
 	feedbackSvc := feedback.NewFeedbackService(
 		logger,
 		&app.cfg.Pagination,
+		&app.cfg.Feedback,
+		&app.cfg.DB,
 		errChecker,
 		feedbackRepo,
 		transactor,
 		analyzerSvc,
+		eventPublisher,
+		clock.New(),
+		piiScrubber,
+		moderator,
+		translator,
+		markupStripper,
+	)
+	userSvc := user.NewUserService(
+		logger,
+		errChecker,
+		userRepo,
+		&app.cfg.JWT,
+		&app.cfg.Registration,
+		transactor,
 	)
-	userSvc := user.NewUserService(logger, errChecker, userRepo, &app.cfg.JWT, transactor)
 
-	feedbackSummarySvc := analysis.NewFeedbackSummaryService(logger, analysisRepo, feedbackRepo)
+	feedbackSummarySvc := analysis.NewFeedbackSummaryService(
+		logger,
+		&app.cfg.LLMAnalysis,
+		&app.cfg.DB,
+		analysisRepo,
+		feedbackRepo,
+	)
 	feedbackV1Handlers := handlersv1.NewHandlers(
 		app.router,
 		logger,
@@ -105,6 +311,8 @@ func (app *App) Start() error {
 		feedbackSvc,
 		userSvc,
 		feedbackSummarySvc,
+		analyzerSvc,
+		eventPublisher,
 		&app.cfg.JWT,
 		trace.WithTracingEnabled(app.cfg.Tracing.Enabled),
 	)
@@ -119,8 +327,17 @@ func (app *App) Start() error {
 	}()
 	<-ctx.Done()
 
-	// Shutdown application gracefully
-	ctx, cancel := context.WithTimeout(ctx, time.Duration(app.cfg.Server.GracefulShutdownSeconds)*time.Second)
+	return app.shutdown(ctx)
+}
+
+// shutdown closes the application once signalCtx is done. The grace period is derived from a context detached from
+// signalCtx, since the signal context is already cancelled by the time shutdown starts and would otherwise cut every
+// component's graceful stop short.
+func (app *App) shutdown(signalCtx context.Context) error {
+	ctx, cancel := context.WithTimeout(
+		context.WithoutCancel(signalCtx),
+		time.Duration(app.cfg.Server.GracefulShutdownSeconds)*time.Second,
+	)
 	defer cancel()
 
 	if err := app.Close(ctx); err != nil {
@@ -130,15 +347,64 @@ func (app *App) Start() error {
 	return nil
 }
 
+// webhookSubscriptions returns the configured webhook URLs in configuration order and the event types each of them
+// receives. A URL configured for several event types gets a single sink, since sinks are identified by their URL.
+func webhookSubscriptions(cfg *config.Webhooks) ([]string, map[string][]external.EventType) {
+	var urls []string
+	eventTypes := make(map[string][]external.EventType)
+	subscribe := func(webhookURLs []string, eventType external.EventType) {
+		for _, webhookURL := range webhookURLs {
+			if _, ok := eventTypes[webhookURL]; !ok {
+				urls = append(urls, webhookURL)
+			}
+			if !slices.Contains(eventTypes[webhookURL], eventType) {
+				eventTypes[webhookURL] = append(eventTypes[webhookURL], eventType)
+			}
+		}
+	}
+	subscribe(cfg.FeedbackCreatedURLs, external.EventFeedbackCreated)
+	subscribe(cfg.SentimentAlertURLs, external.EventSentimentDeclined)
+	return urls, eventTypes
+}
+
+// newTranslationClient returns the LLM client translating feedback comments. Comments are translated while feedback
+// is submitted, so the client gets a limiter and breaker of its own instead of the ones in llmOptions: a backlog of
+// analyses cannot delay submissions, and a burst of submissions cannot starve the analyses.
+func newTranslationClient(
+	cfg *config.LLMAnalysis,
+	llmOptions []llm.ClientOption,
+	logger tracelog.TraceLogger,
+) *llm.OpenAIClient {
+	translationOptions := append(
+		slices.Clone(llmOptions),
+		llm.WithConcurrencyLimiter(llm.NewConcurrencyLimiter(cfg.MaxConcurrentRequests)),
+		llm.WithCircuitBreaker(llm.NewCircuitBreaker(cfg.CircuitBreakerThreshold, cfg.CircuitBreakerCooldown(), clock.New())),
+	)
+	return llm.NewOpenAIClient(cfg.OpenAIAPIKey, cfg.OpenAIModel, cfg.MaxTopicsPerAnalysis, logger, translationOptions...)
+}
+
+// Close stops every component of the application. A component that fails to stop does not prevent the remaining
+// ones from being stopped; all failures are joined into the returned error.
 func (app *App) Close(ctx context.Context) error {
-	if err := app.srv.Shutdown(ctx); err != nil {
-		return fmt.Errorf("failed to shutdown the server: %w", err)
+	var errs []error
+
+	if app.srv != nil {
+		if err := app.srv.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to shutdown the server: %w", err))
+		}
 	}
 
 	// Stop analyzer gracefully
 	if app.analyzer != nil {
 		if err := app.analyzer.Stop(ctx); err != nil {
-			return fmt.Errorf("failed to stop analyzer: %w", err)
+			errs = append(errs, fmt.Errorf("failed to stop analyzer: %w", err))
+		}
+	}
+
+	// Stop the outbox dispatcher and wait for in-flight event deliveries
+	if app.events != nil {
+		if err := app.events.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop event publisher: %w", err))
 		}
 	}
 
@@ -146,11 +412,11 @@ func (app *App) Close(ctx context.Context) error {
 		app.pgxPool.Close()
 	}
 
-	if app.tracing.tracer != nil {
+	if app.tracing != nil && app.tracing.tracer != nil {
 		if err := app.tracing.tracer.Shutdown(ctx); err != nil {
-			return fmt.Errorf("failed to shutdown tracer: %w", err)
+			errs = append(errs, fmt.Errorf("failed to shutdown tracer: %w", err))
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
@@ -0,0 +1,66 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/mocking"
+	"go.uber.org/mock/gomock"
+)
+
+func newShutdownTestApp(t *testing.T) (*App, *mocking.MockAnalyzerService, *mocking.MockEventPublisher) {
+	t.Helper()
+
+	ctrl := gomock.NewController(t)
+	analyzer := mocking.NewMockAnalyzerService(ctrl)
+	events := mocking.NewMockEventPublisher(ctrl)
+
+	return &App{
+		cfg:      &config.Config{Server: config.Server{GracefulShutdownSeconds: 5}},
+		analyzer: analyzer,
+		events:   events,
+	}, analyzer, events
+}
+
+func TestApp_Shutdown_GracePeriodOutlivesSignalContext(t *testing.T) {
+	app, analyzer, events := newShutdownTestApp(t)
+
+	// The signal context is already done when shutdown starts, exactly as in Start.
+	signalCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assertLiveContext := func(ctx context.Context) error {
+		if err := ctx.Err(); err != nil {
+			t.Errorf("Expected a live shutdown context, got %v", err)
+		}
+		if _, ok := ctx.Deadline(); !ok {
+			t.Error("Expected the shutdown context to carry the grace period deadline")
+		}
+		return nil
+	}
+	analyzer.EXPECT().Stop(gomock.Any()).DoAndReturn(assertLiveContext)
+	events.EXPECT().Stop(gomock.Any()).DoAndReturn(assertLiveContext)
+
+	if err := app.shutdown(signalCtx); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
+
+func TestApp_Shutdown_StopsEveryComponentOnFailure(t *testing.T) {
+	app, analyzer, events := newShutdownTestApp(t)
+
+	analyzerErr := errors.New("analyzer stuck")
+	eventsErr := errors.New("dispatcher stuck")
+	analyzer.EXPECT().Stop(gomock.Any()).Return(analyzerErr)
+	events.EXPECT().Stop(gomock.Any()).Return(eventsErr)
+
+	err := app.shutdown(context.Background())
+	if !errors.Is(err, analyzerErr) {
+		t.Errorf("Expected the analyzer error to be reported, got %v", err)
+	}
+	if !errors.Is(err, eventsErr) {
+		t.Errorf("Expected the event publisher error to be reported, got %v", err)
+	}
+}
@@ -9,13 +9,16 @@ import (
 )
 
 type Config struct {
-	Profile     Profile     `yaml:"profile" envPrefix:"PROFILE_"`
-	Server      Server      `yaml:"server" envPrefix:"SERVER_"`
-	Pagination  Pagination  `yaml:"pagination" envPrefix:"PAGINATION_"`
-	DB          Database    `yaml:"database" envPrefix:"DATABASE_"`
-	Tracing     Tracing     `yaml:"tracing" envPrefix:"TRACING_"`
-	JWT         JWT         `yaml:"jwt" envPrefix:"JWT_"`
-	LLMAnalysis LLMAnalysis `yaml:"llm_analysis" envPrefix:"LLM_ANALYSIS_"`
+	Profile      Profile      `yaml:"profile" envPrefix:"PROFILE_"`
+	Server       Server       `yaml:"server" envPrefix:"SERVER_"`
+	Pagination   Pagination   `yaml:"pagination" envPrefix:"PAGINATION_"`
+	DB           Database     `yaml:"database" envPrefix:"DATABASE_"`
+	Tracing      Tracing      `yaml:"tracing" envPrefix:"TRACING_"`
+	JWT          JWT          `yaml:"jwt" envPrefix:"JWT_"`
+	LLMAnalysis  LLMAnalysis  `yaml:"llm_analysis" envPrefix:"LLM_ANALYSIS_"`
+	Feedback     Feedback     `yaml:"feedback" envPrefix:"FEEDBACK_"`
+	Webhooks     Webhooks     `yaml:"webhooks" envPrefix:"WEBHOOKS_"`
+	Registration Registration `yaml:"registration" envPrefix:"REGISTRATION_"`
 }
 
 func New(path string) (*Config, error) {
@@ -59,6 +62,9 @@ func (c *Config) Validate() error {
 		c.Profile,
 		c.JWT,
 		c.LLMAnalysis,
+		c.Feedback,
+		c.Webhooks,
+		c.Registration,
 	}
 
 	for _, v := range components {
@@ -0,0 +1,273 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLLMAnalysis_Validate_MaxFeedbacksInContextBelowMinimum(t *testing.T) {
+	tests := []struct {
+		name         string
+		minimum      int
+		maxInContext int
+		wantErr      bool
+	}{
+		{name: "max below minimum", minimum: 60, maxInContext: 50, wantErr: true},
+		{name: "max equal to minimum", minimum: 50, maxInContext: 50},
+		{name: "max above minimum", minimum: 7, maxInContext: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				cfg := LLMAnalysis{
+					MinimumNewFeedbacksForAnalysis: tt.minimum,
+					MaxFeedbacksInContext:          tt.maxInContext,
+					MaxTokensPerRequest:            10000,
+					OpenAIModel:                    "gpt-5-mini",
+					OpenAIAPIKey:                   "sk-test",
+				}
+
+				err := cfg.Validate()
+				if tt.wantErr {
+					if err == nil || !strings.Contains(err.Error(), "max_feedbacks_in_context") {
+						t.Fatalf("Expected max_feedbacks_in_context error, got: %v", err)
+					}
+					return
+				}
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestLLMAnalysis_Validate_Provider(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		wantErr  bool
+	}{
+		{name: "default", provider: ""},
+		{name: "simple name", provider: "azure"},
+		{name: "with separators", provider: "azure-openai_eu"},
+		{name: "uppercase", provider: "Azure", wantErr: true},
+		{name: "contains slash", provider: "openai/azure", wantErr: true},
+		{name: "too long", provider: strings.Repeat("a", 51), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				cfg := LLMAnalysis{
+					MinimumNewFeedbacksForAnalysis: 5,
+					MaxFeedbacksInContext:          50,
+					MaxTokensPerRequest:            10000,
+					OpenAIModel:                    "gpt-5-mini",
+					OpenAIAPIKey:                   "sk-test",
+					Provider:                       tt.provider,
+				}
+
+				err := cfg.Validate()
+				if tt.wantErr {
+					if err == nil || !strings.Contains(err.Error(), "provider") {
+						t.Fatalf("Expected provider error, got: %v", err)
+					}
+					return
+				}
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestLLMAnalysis_Validate_RequestMetadata(t *testing.T) {
+	tooMany := make(map[string]string)
+	for i := 0; i < 16; i++ {
+		tooMany[fmt.Sprintf("key%d", i)] = "value"
+	}
+
+	tests := []struct {
+		name     string
+		metadata map[string]string
+		wantErr  bool
+	}{
+		{name: "unset"},
+		{name: "environment", metadata: map[string]string{"environment": "production"}},
+		{name: "reserved key", metadata: map[string]string{"analysis_id": "x"}, wantErr: true},
+		{name: "key too long", metadata: map[string]string{strings.Repeat("k", 65): "x"}, wantErr: true},
+		{name: "value too long", metadata: map[string]string{"note": strings.Repeat("v", 513)}, wantErr: true},
+		{name: "too many entries", metadata: tooMany, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				cfg := LLMAnalysis{
+					MinimumNewFeedbacksForAnalysis: 5,
+					MaxFeedbacksInContext:          50,
+					MaxTokensPerRequest:            10000,
+					OpenAIModel:                    "gpt-5-mini",
+					OpenAIAPIKey:                   "sk-test",
+					RequestMetadata:                tt.metadata,
+				}
+
+				err := cfg.Validate()
+				if tt.wantErr {
+					if err == nil || !strings.Contains(err.Error(), "request_metadata") {
+						t.Fatalf("Expected request_metadata error, got: %v", err)
+					}
+					return
+				}
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestDatabase_Validate_PoolLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      Database
+		wantErr string
+	}{
+		{name: "pgxpool defaults", db: Database{}},
+		{name: "tuned pool", db: Database{MaxConns: 20, MinConns: 5, MaxConnLifetimeSeconds: 3600}},
+		{name: "min conns without max conns", db: Database{MinConns: 5}},
+		{name: "negative max conns", db: Database{MaxConns: -1}, wantErr: "max_conns"},
+		{name: "min conns above max conns", db: Database{MaxConns: 4, MinConns: 5}, wantErr: "min_conns"},
+		{name: "negative lifetime", db: Database{MaxConnLifetimeSeconds: -1}, wantErr: "max_conn_lifetime_seconds"},
+		{name: "negative idle time", db: Database{MaxConnIdleTimeSeconds: -1}, wantErr: "max_conn_idle_time_seconds"},
+		{name: "negative copy threshold", db: Database{BulkInsertCopyThreshold: -1}, wantErr: "bulk_insert_copy_threshold"},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				tt.db.DSN = "postgres://localhost:5432/feedback_db"
+
+				err := tt.db.Validate()
+				if tt.wantErr != "" {
+					if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+						t.Fatalf("Expected %s error, got: %v", tt.wantErr, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestJWT_Validate_PreviousSecrets(t *testing.T) {
+	const secret = "current-secret-that-is-at-least-32-characters"
+	tests := []struct {
+		name            string
+		previousSecrets []string
+		wantErr         bool
+	}{
+		{name: "no previous secrets"},
+		{name: "long previous secret", previousSecrets: []string{"previous-secret-that-is-at-least-32-chars"}},
+		{
+			name:            "short previous secret",
+			previousSecrets: []string{"previous-secret-that-is-at-least-32-chars", "short"},
+			wantErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				err := JWT{Secret: secret, PreviousSecrets: tt.previousSecrets}.Validate()
+				if tt.wantErr {
+					if err == nil || !strings.Contains(err.Error(), "previous secret 2") {
+						t.Fatalf("Expected previous secret error, got: %v", err)
+					}
+					return
+				}
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestFeedback_TranslationTimeout(t *testing.T) {
+	if got := (Feedback{}).TranslationTimeout(); got != DefaultTranslationTimeout {
+		t.Errorf("Expected the default translation timeout %s, got %s", DefaultTranslationTimeout, got)
+	}
+	if got := (Feedback{TranslationTimeoutSeconds: 2}).TranslationTimeout(); got != 2*time.Second {
+		t.Errorf("Expected a translation timeout of 2s, got %s", got)
+	}
+	if err := (Feedback{TranslationTimeoutSeconds: -1}).Validate(); err == nil {
+		t.Error("Expected a negative translation timeout to be rejected")
+	}
+}
+
+func TestFeedback_Validate_AcceptedLanguages(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Feedback
+		wantErr string
+	}{
+		{name: "accept all"},
+		{name: "restricted", cfg: Feedback{AcceptedLanguages: []string{"en", "de"}}},
+		{
+			name:    "unsupported language",
+			cfg:     Feedback{AcceptedLanguages: []string{"English"}},
+			wantErr: "accepted_languages",
+		},
+		{
+			name:    "invalid action",
+			cfg:     Feedback{LanguageRestrictionAction: "flag"},
+			wantErr: "language_restriction_action",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				err := tt.cfg.Validate()
+				if tt.wantErr != "" {
+					if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+						t.Fatalf("Expected %s error, got: %v", tt.wantErr, err)
+					}
+					return
+				}
+				if err != nil {
+					t.Errorf("Expected no error, got: %v", err)
+				}
+			},
+		)
+	}
+}
+
+func TestFeedback_AcceptsLanguage(t *testing.T) {
+	cfg := Feedback{AcceptedLanguages: []string{"en", "de"}}
+	if !cfg.AcceptsLanguage("de") {
+		t.Error("Expected an accepted language to be accepted")
+	}
+	if cfg.AcceptsLanguage("fr") {
+		t.Error("Expected a language outside the accepted set to be refused")
+	}
+	if cfg.AcceptsLanguage("") {
+		t.Error("Expected comments of undetected language to be refused by default")
+	}
+	cfg.AcceptUndetectedLanguages = true
+	if !cfg.AcceptsLanguage("") {
+		t.Error("Expected comments of undetected language to be accepted when configured")
+	}
+	if !(Feedback{}).AcceptsLanguage("fr") {
+		t.Error("Expected every language to be accepted without accepted languages")
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/handlers"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/handlers/http/middleware"
+	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/http/responder"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/log"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
@@ -75,6 +76,20 @@ func initTracing(cfg *config.Config) (*tracing, error) {
 				Endpoint:    cfg.Tracing.OTelEndpoint,
 				Insecure:    cfg.Tracing.Insecure,
 				Environment: cfg.Profile.String(),
+				FailFast:    cfg.Tracing.FailFast,
+				OnExportStatusChange: func(err error) {
+					if err != nil {
+						logger.Warning(
+							"trace export failing, spans are dropped until the endpoint is reachable",
+							"otel_endpoint",
+							cfg.Tracing.OTelEndpoint,
+							"error",
+							err.Error(),
+						)
+						return
+					}
+					logger.Info("trace export recovered", "otel_endpoint", cfg.Tracing.OTelEndpoint)
+				},
 			},
 		)
 		if err != nil {
@@ -92,27 +107,34 @@ func initTracing(cfg *config.Config) (*tracing, error) {
 	tracing.baseLogger = logger
 	// Create a no-op trace logger that wraps the regular logger
 	// This allows the consumers to use tracelog interface even when tracing is off
-	noOpTracer, err := trace.NewTracer(
-		trace.Config{
-			ServiceName: cfg.Tracing.ServiceName,
-			Environment: cfg.Profile.String(),
-		},
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to initialize no-op tracer: %w", err)
-	}
-	tracing.traceLogger = tracelog.NewTraceLogger(tracing.baseLogger, noOpTracer)
+	tracing.traceLogger = tracelog.NewTraceLogger(tracing.baseLogger, trace.NewNoopTracer())
 	tracing.enabled = false
 
 	return &tracing, nil
 }
 
-func initRouter(cfg *config.Config, logger tracelog.TraceLogger, responder responder.RestResponder) *chi.Mux {
+func initRouter(cfg *config.Config, logger tracelog.TraceLogger, restResponder responder.RestResponder) *chi.Mux {
 	router := chi.NewRouter()
 	router.Use(
+		middleware.RequestIDMiddleware(),
 		middleware.LoggerMiddleware(logger),
 		cors.Handler(middleware.CorsOptions()),
-		middleware.JWTMiddleware(&cfg.JWT, logger, responder),
+		middleware.JWTMiddleware(&cfg.JWT, logger, restResponder),
+	)
+
+	// Unknown routes and methods answer with the same error envelope as the handlers
+	router.NotFound(
+		func(w http.ResponseWriter, _ *http.Request) {
+			restResponder.RespondContent(w, &ce.GenericError{Code: ce.ErrorCodeNotFound, Message: "Route not found"})
+		},
+	)
+	router.MethodNotAllowed(
+		func(w http.ResponseWriter, _ *http.Request) {
+			restResponder.RespondContent(
+				w,
+				responder.NewErrorResponse(http.StatusMethodNotAllowed, "method_not_allowed", "Method not allowed"),
+			)
+		},
 	)
 
 	// Register Swagger UI routes
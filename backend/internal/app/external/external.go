@@ -2,21 +2,122 @@ package external
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
 )
 
 // LLMClient defines the interface for LLM operations.
 type LLMClient interface {
 	// AnalyzeFeedbacks performs LLM analysis on the given feedbacks.
+	// The previous analysis and its topic breakdown (both optional) are provided as context
+	// so that summaries evolve across incremental runs. Context feedbacks (optional) are previously
+	// analyzed feedbacks that enrich sparse batches; they are not assigned to topics.
+	// The ID of the analysis the request is made for is read from ctx, see ContextWithAnalysisID.
 	// Returns the analysis result with summary, sentiment, insights, etc.
 	AnalyzeFeedbacks(
 		ctx context.Context,
 		feedbacks []*feedback.Feedback,
 		previousAnalysis *analysis.Analysis,
+		previousTopics []*analysis.TopicAnalysis,
+		contextFeedbacks []*feedback.Feedback,
 	) (*AnalysisResult, error)
+	// ReduceAnalyses combines the results of analyses over consecutive chunks of one feedback batch into
+	// a single overall summary, sentiment and key insights, and a single summary and sentiment per topic.
+	// The returned topics carry neither feedback IDs nor distributions, the caller merges those itself.
+	ReduceAnalyses(ctx context.Context, partials []*AnalysisResult) (*AnalysisResult, error)
+}
+
+// ErrCircuitOpen is returned by an LLMClient instead of sending a request while its circuit breaker is open.
+var ErrCircuitOpen = errors.New("LLM circuit breaker is open, request not sent")
+
+// CircuitState is the state of the circuit breaker guarding the LLM provider.
+type CircuitState string
+
+const (
+	// CircuitClosed lets every request through.
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen rejects every request until the cooldown has passed.
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets a single trial request through, whose outcome closes or reopens the breaker.
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// CircuitStateProvider reports the state of the circuit breaker of an LLM client.
+type CircuitStateProvider interface {
+	CircuitState() CircuitState
+}
+
+type analysisIDKey struct{}
+
+// ContextWithAnalysisID returns a copy of the context carrying the ID of the analysis that LLM requests
+// are made for, so that clients can attach it to the requests for auditing.
+func ContextWithAnalysisID(ctx context.Context, analysisID uuid.UUID) context.Context {
+	return context.WithValue(ctx, analysisIDKey{}, analysisID)
+}
+
+// AnalysisIDFromContext returns the analysis ID carried by the context, or uuid.Nil if there is none.
+func AnalysisIDFromContext(ctx context.Context) uuid.UUID {
+	analysisID, _ := ctx.Value(analysisIDKey{}).(uuid.UUID)
+	return analysisID
+}
+
+// Moderator checks user-submitted text against a content policy before it is stored and analyzed.
+type Moderator interface {
+	// Moderate reports whether the text violates the content policy, and which categories it violates.
+	Moderate(ctx context.Context, text string) (*ModerationResult, error)
+}
+
+// ModerationResult is the verdict of a Moderator on a text.
+type ModerationResult struct {
+	Flagged bool
+	// Categories are the violated policy categories in alphabetical order, e.g. "harassment" or "hate".
+	Categories []string
+}
+
+// NoopModerator is the Moderator used when content moderation is disabled. It never flags any text.
+type NoopModerator struct{}
+
+// Moderate accepts the text without checking it.
+func (NoopModerator) Moderate(context.Context, string) (*ModerationResult, error) {
+	return &ModerationResult{}, nil
+}
+
+// Translator translates user-submitted text into the language feedback is analyzed in.
+type Translator interface {
+	// Translate detects the language of the text and translates it into the target language, given as an
+	// ISO 639-1 code such as "en". Text already in the target language is returned as is.
+	Translate(ctx context.Context, text, targetLanguage string) (*Translation, error)
+}
+
+// Translation is the result of a Translator on a text.
+type Translation struct {
+	// Text is the translated text, or the original text if it was not translated.
+	Text string
+	// SourceLanguage is the detected ISO 639-1 code of the original text, empty if it was not detected.
+	SourceLanguage string
+	// Translated reports whether the original text was in another language than the target language.
+	Translated bool
+}
+
+// TranslatedText returns the translated text, or an empty string if the text was not translated.
+func (t *Translation) TranslatedText() string {
+	if !t.Translated {
+		return ""
+	}
+	return t.Text
+}
+
+// NoopTranslator is the Translator used when translation is disabled. It never translates any text.
+type NoopTranslator struct{}
+
+// Translate returns the text untranslated.
+func (NoopTranslator) Translate(_ context.Context, text, _ string) (*Translation, error) {
+	return &Translation{Text: text}, nil
 }
 
 // AnalysisResult contains the result of an LLM analysis.
@@ -26,6 +127,47 @@ type AnalysisResult struct {
 	KeyInsights    []string
 	TokensUsed     int
 	Topics         []Topic
+	// RawOutput is the output text of the model with PII masked, set only if raw output capture is enabled.
+	RawOutput string
+	// Usage is the token usage reported by the provider, TokensUsed being its total.
+	Usage TokenUsage
+}
+
+// TokenUsage is the token usage of an LLM request as reported by the provider.
+type TokenUsage struct {
+	InputTokens  int
+	OutputTokens int
+	TotalTokens  int
+}
+
+// UsageError is returned by AnalyzeFeedbacks when the request failed after the provider reported its token usage,
+// e.g. because the output could not be parsed. It wraps the actual error.
+type UsageError struct {
+	Usage TokenUsage
+	Err   error
+}
+
+func (e *UsageError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UsageError) Unwrap() error {
+	return e.Err
+}
+
+// InvalidOutputError is returned by AnalyzeFeedbacks when the model output cannot be parsed.
+type InvalidOutputError struct {
+	// RawOutput is the unparsable output text with PII masked, set only if raw output capture is enabled.
+	RawOutput string
+	Err       error
+}
+
+func (e *InvalidOutputError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *InvalidOutputError) Unwrap() error {
+	return e.Err
 }
 
 // Topic represents a topic identified by the LLM.
@@ -34,4 +176,122 @@ type Topic struct {
 	Summary     string
 	FeedbackIDs []uuid.UUID
 	Sentiment   analysis.Sentiment
+	// SentimentDistribution is None if the model did not return a valid distribution.
+	SentimentDistribution optional.Optional[analysis.SentimentDistribution]
+	// Recommendations are the concrete actions suggested for the topic, empty if not requested.
+	Recommendations []string
+	// Confidence is how confident the model is in the topic assignment between 0 and 1, None if not requested
+	// or out of range.
+	Confidence optional.Optional[float64]
+	// SentimentConflict is set by rating reconciliation when the sentiment of the model contradicted the rating
+	// of the topic's only feedback.
+	SentimentConflict bool
+}
+
+// EventType identifies the kind of event emitted to external systems.
+type EventType string
+
+const (
+	// EventFeedbackCreated is emitted after a feedback has been successfully created.
+	EventFeedbackCreated EventType = "feedback.created"
+	// EventSentimentDeclined is emitted after an analysis whose sentiment or average rating declined compared to
+	// the previous analysis.
+	EventSentimentDeclined EventType = "analysis.sentiment_declined"
+)
+
+// Event is an application event forwarded to external systems.
+type Event struct {
+	ID         uuid.UUID `json:"id"`
+	Type       EventType `json:"type"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Data       any       `json:"data"`
+	// Staged is set once the event was stored in the outbox within a transaction, so that publishing it after
+	// the commit does not store it again.
+	Staged bool `json:"-"`
+}
+
+// FeedbackCreatedData is the payload of an EventFeedbackCreated event.
+type FeedbackCreatedData struct {
+	FeedbackID uuid.UUID `json:"feedback_id"`
+	UserID     uuid.UUID `json:"user_id"`
+	Rating     int       `json:"rating"`
+	Comment    string    `json:"comment"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// NewFeedbackCreatedEvent creates an EventFeedbackCreated event for the given feedback.
+func NewFeedbackCreatedEvent(fb *feedback.Feedback, occurredAt time.Time) *Event {
+	return &Event{
+		ID:         uuid.New(),
+		Type:       EventFeedbackCreated,
+		OccurredAt: occurredAt,
+		Data: FeedbackCreatedData{
+			FeedbackID: fb.ID(),
+			UserID:     fb.UserID(),
+			Rating:     fb.Rating().Value(),
+			Comment:    fb.Comment().Value(),
+			CreatedAt:  fb.CreatedAt(),
+		},
+	}
+}
+
+// Reasons of an EventSentimentDeclined event.
+const (
+	// DeclineReasonSentimentNegative is set when the overall sentiment turned negative from positive or mixed.
+	DeclineReasonSentimentNegative = "sentiment_turned_negative"
+	// DeclineReasonRatingDrop is set when the average rating dropped by at least the configured delta.
+	DeclineReasonRatingDrop = "rating_dropped"
+)
+
+// SentimentDeclinedData is the payload of an EventSentimentDeclined event.
+type SentimentDeclinedData struct {
+	AnalysisID         uuid.UUID          `json:"analysis_id"`
+	PreviousAnalysisID uuid.UUID          `json:"previous_analysis_id"`
+	Reasons            []string           `json:"reasons"`
+	Sentiment          analysis.Sentiment `json:"sentiment"`
+	PreviousSentiment  analysis.Sentiment `json:"previous_sentiment"`
+	AverageRating      float64            `json:"average_rating"`
+	// PreviousAverageRating is only compared, and thus only set, if a rating drop is configured.
+	PreviousAverageRating optional.Optional[float64] `json:"previous_average_rating,omitempty"`
+	// Topics are the negative topics of the analysis, the ones with the most feedbacks first.
+	Topics []DecliningTopic `json:"topics"`
+}
+
+// DecliningTopic is a negative topic of an analysis reported in an EventSentimentDeclined event.
+type DecliningTopic struct {
+	Topic                 analysis.Topic     `json:"topic"`
+	Summary               string             `json:"summary"`
+	FeedbackCount         int                `json:"feedback_count"`
+	PreviousFeedbackCount int                `json:"previous_feedback_count"`
+	PreviousSentiment     analysis.Sentiment `json:"previous_sentiment,omitempty"`
+}
+
+// EventSink defines the interface for delivering events to an external system (webhook, queue, etc.).
+type EventSink interface {
+	// Name returns a short identifier of the sink, used for logging and tracing.
+	Name() string
+	// Send delivers the event. Implementations are responsible for their own retries.
+	Send(ctx context.Context, event *Event) error
+}
+
+// OutboxEntry is an event stored in the persistent outbox, waiting to be delivered to a single sink.
+type OutboxEntry struct {
+	ID            uuid.UUID
+	Event         *Event
+	Sink          string
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+}
+
+// DeadLetter is an event that could not be delivered to a sink within the maximum number of attempts.
+type DeadLetter struct {
+	ID        uuid.UUID
+	Event     *Event
+	Sink      string
+	Attempts  int
+	LastError string
+	CreatedAt time.Time
+	FailedAt  time.Time
 }
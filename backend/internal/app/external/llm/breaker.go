@@ -0,0 +1,119 @@
+package llm
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
+)
+
+// CircuitBreaker stops sending requests to a failing LLM provider. After threshold consecutive failures it
+// opens and rejects every request for the cooldown, then half-opens and admits a single trial request:
+// a success closes the breaker again, a failure reopens it for another cooldown.
+// Like the ConcurrencyLimiter, a single breaker is shared by all analyses.
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	clock     clock.Clock
+
+	mu       sync.Mutex
+	failures int
+	open     bool
+	openedAt time.Time
+	// trial is set while the trial request of the half-open state is in flight.
+	trial bool
+}
+
+// NewCircuitBreaker creates a breaker opening after threshold consecutive failures for the given cooldown.
+// A threshold of 0 or less disables the breaker, it then admits every request and stays closed.
+// A nil clock uses the system time.
+func NewCircuitBreaker(threshold int, cooldown time.Duration, clk clock.Clock) *CircuitBreaker {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown, clock: clk}
+}
+
+// Allow reports whether a request may be sent. Every allowed request must be followed by a call to
+// Success, Failure or Abort.
+func (b *CircuitBreaker) Allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state() {
+	case external.CircuitOpen:
+		return false
+	case external.CircuitHalfOpen:
+		if b.trial {
+			return false
+		}
+		b.trial = true
+	}
+	return true
+}
+
+// Success records a request the provider answered, closing the breaker.
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.open = false
+	b.trial = false
+}
+
+// Failure records a failed request. It reports whether the failure opened the breaker, that is whether it
+// reached the threshold or was the trial request of the half-open state.
+func (b *CircuitBreaker) Failure() bool {
+	if b.threshold <= 0 {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if !b.trial && (b.open || b.failures < b.threshold) {
+		return false
+	}
+
+	b.open = true
+	b.openedAt = b.clock.Now()
+	b.trial = false
+	return true
+}
+
+// Abort records a request that ended without an answer or failure of the provider, e.g. because its context
+// was cancelled. It does not change the state, but lets the next request through if it was the trial request.
+func (b *CircuitBreaker) Abort() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+}
+
+// CircuitState returns the current state of the breaker. It implements external.CircuitStateProvider.
+func (b *CircuitBreaker) CircuitState() external.CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state()
+}
+
+// state returns the current state, the open breaker counting as half-open once the cooldown has passed.
+// The caller must hold mu.
+func (b *CircuitBreaker) state() external.CircuitState {
+	switch {
+	case !b.open:
+		return external.CircuitClosed
+	case b.clock.Since(b.openedAt) < b.cooldown:
+		return external.CircuitOpen
+	default:
+		return external.CircuitHalfOpen
+	}
+}
@@ -0,0 +1,134 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
+)
+
+func TestCircuitBreaker_OpensAndRecovers(t *testing.T) {
+	clk := clock.NewMock(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	breaker := NewCircuitBreaker(3, time.Minute, clk)
+
+	for i := 0; i < 2; i++ {
+		if !breaker.Allow() {
+			t.Fatalf("Expected request %d to be allowed while closed", i)
+		}
+		if breaker.Failure() {
+			t.Fatalf("Expected failure %d not to open the breaker", i)
+		}
+	}
+	if !breaker.Allow() || !breaker.Failure() {
+		t.Fatal("Expected the third consecutive failure to open the breaker")
+	}
+	if got := breaker.CircuitState(); got != external.CircuitOpen {
+		t.Fatalf("Expected state %s, got %s", external.CircuitOpen, got)
+	}
+	if breaker.Allow() {
+		t.Fatal("Expected requests to be rejected while open")
+	}
+
+	clk.Set(clk.Now().Add(time.Minute))
+	if got := breaker.CircuitState(); got != external.CircuitHalfOpen {
+		t.Fatalf("Expected state %s after the cooldown, got %s", external.CircuitHalfOpen, got)
+	}
+	if !breaker.Allow() {
+		t.Fatal("Expected the trial request to be allowed")
+	}
+	if breaker.Allow() {
+		t.Fatal("Expected a single trial request while half-open")
+	}
+
+	// A failed trial reopens the breaker for another cooldown
+	if !breaker.Failure() {
+		t.Fatal("Expected the failed trial request to reopen the breaker")
+	}
+	if got := breaker.CircuitState(); got != external.CircuitOpen {
+		t.Fatalf("Expected state %s after a failed trial, got %s", external.CircuitOpen, got)
+	}
+
+	clk.Set(clk.Now().Add(time.Minute))
+	if !breaker.Allow() {
+		t.Fatal("Expected the second trial request to be allowed")
+	}
+	breaker.Success()
+	if got := breaker.CircuitState(); got != external.CircuitClosed {
+		t.Fatalf("Expected state %s after a successful trial, got %s", external.CircuitClosed, got)
+	}
+
+	// The failure count starts over once closed
+	if !breaker.Allow() || breaker.Failure() {
+		t.Error("Expected a single failure not to reopen the recovered breaker")
+	}
+}
+
+func TestCircuitBreaker_SuccessResetsFailures(t *testing.T) {
+	breaker := NewCircuitBreaker(2, time.Minute, nil)
+
+	breaker.Failure()
+	breaker.Success()
+	if breaker.Failure() {
+		t.Error("Expected failures separated by a success not to open the breaker")
+	}
+}
+
+func TestCircuitBreaker_AbortedTrialLetsNextRequestThrough(t *testing.T) {
+	clk := clock.NewMock(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	breaker := NewCircuitBreaker(1, time.Minute, clk)
+	breaker.Failure()
+	clk.Set(clk.Now().Add(time.Minute))
+
+	if !breaker.Allow() {
+		t.Fatal("Expected the trial request to be allowed")
+	}
+	breaker.Abort()
+	if !breaker.Allow() {
+		t.Error("Expected another trial request once the first was aborted")
+	}
+}
+
+func TestCircuitBreaker_Disabled(t *testing.T) {
+	breaker := NewCircuitBreaker(0, time.Minute, nil)
+	for i := 0; i < 100; i++ {
+		if !breaker.Allow() || breaker.Failure() {
+			t.Fatalf("Expected a disabled breaker to allow request %d and never open", i)
+		}
+	}
+	if got := breaker.CircuitState(); got != external.CircuitClosed {
+		t.Errorf("Expected a disabled breaker to stay %s, got %s", external.CircuitClosed, got)
+	}
+}
+
+func TestOpenAIClient_CircuitBreakerShortCircuits(t *testing.T) {
+	var requests atomic.Int64
+	client := newTestClient(
+		t,
+		func(w http.ResponseWriter, _ *http.Request) {
+			requests.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		},
+		WithCircuitBreaker(NewCircuitBreaker(2, time.Minute, nil)),
+	)
+	feedbacks := []*feedback.Feedback{newTestFeedback(t, "Slow")}
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.AnalyzeFeedbacks(context.Background(), feedbacks, nil, nil, nil); err == nil {
+			t.Fatalf("Expected request %d to fail", i)
+		}
+	}
+
+	_, err := client.AnalyzeFeedbacks(context.Background(), feedbacks, nil, nil, nil)
+	if !errors.Is(err, external.ErrCircuitOpen) {
+		t.Fatalf("Expected external.ErrCircuitOpen once the breaker opened, got: %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected the open breaker to keep the request from being sent, got %d requests", got)
+	}
+}
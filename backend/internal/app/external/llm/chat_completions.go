@@ -0,0 +1,109 @@
+package llm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ChatCompletionsResponse represents the response structure from OpenAI Chat Completions API.
+type ChatCompletionsResponse struct {
+	ID      string                 `json:"id"`
+	Choices []ChatCompletionChoice `json:"choices"`
+	Usage   ChatCompletionsUsage   `json:"usage"`
+	Error   *APIError              `json:"error,omitempty"`
+}
+
+// ChatCompletionChoice represents a single completion choice.
+type ChatCompletionChoice struct {
+	Index        int                   `json:"index"`
+	Message      ChatCompletionMessage `json:"message"`
+	FinishReason string                `json:"finish_reason"`
+}
+
+// ChatCompletionMessage represents the assistant message of a choice.
+type ChatCompletionMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+	Refusal string `json:"refusal,omitempty"`
+}
+
+// ChatCompletionsUsage represents token usage information of the Chat Completions API.
+type ChatCompletionsUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// buildChatCompletionsRequestBody creates the Chat Completions request with the same prompt and schema
+// as the Responses API request.
+func (c *OpenAIClient) buildChatCompletionsRequestBody(systemPrompt, userContent, schemaName string, schema Map) Map {
+	return Map{
+		"model": c.model,
+		"messages": []Map{
+			{
+				"role":    "system",
+				"content": systemPrompt,
+			},
+			{
+				"role":    "user",
+				"content": userContent,
+			},
+		},
+		"response_format": Map{
+			"type": "json_schema",
+			"json_schema": Map{
+				"name":   schemaName,
+				"strict": true,
+				"schema": schema,
+			},
+		},
+	}
+}
+
+// parseChatCompletionsResponse extracts the output text and token usage from a Chat Completions response.
+func (c *OpenAIClient) parseChatCompletionsResponse(rawBody []byte) (string, Usage, error) {
+	var apiResp ChatCompletionsResponse
+	if err := json.Unmarshal(rawBody, &apiResp); err != nil {
+		return "", Usage{}, fmt.Errorf("failed to parse API response: %w", err)
+	}
+
+	usage := Usage{
+		InputTokens:  apiResp.Usage.PromptTokens,
+		OutputTokens: apiResp.Usage.CompletionTokens,
+		TotalTokens:  apiResp.Usage.TotalTokens,
+	}
+
+	// Check for API-level errors
+	if apiResp.Error != nil {
+		return "", usage, fmt.Errorf(
+			"OpenAI API error: %s (type: %s)",
+			apiResp.Error.Message,
+			apiResp.Error.Type,
+		)
+	}
+
+	outputText, err := c.extractChatCompletionsText(apiResp)
+	if err != nil {
+		return "", usage, fmt.Errorf("failed to extract output text: %w", err)
+	}
+
+	return outputText, usage, nil
+}
+
+// extractChatCompletionsText extracts the output text from the first choice of the response.
+func (c *OpenAIClient) extractChatCompletionsText(apiResp ChatCompletionsResponse) (string, error) {
+	if len(apiResp.Choices) == 0 {
+		return "", errors.New("no choices found in API response")
+	}
+
+	message := apiResp.Choices[0].Message
+	if message.Refusal != "" {
+		return "", fmt.Errorf("model refused the request: %s", message.Refusal)
+	}
+	if message.Content == "" {
+		return "", errors.New("no message content found in API response")
+	}
+
+	return message.Content, nil
+}
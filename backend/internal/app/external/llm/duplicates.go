@@ -0,0 +1,104 @@
+package llm
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
+)
+
+// mergeDuplicateTopic merges the topic into the topic of the same enum in topics, if any, and reports whether
+// it did. Distinct summaries are joined and truncated again, feedback IDs and recommendations are united and
+// the confidence is the lower one. Sentiment distributions add up unless the topics share feedbacks, which
+// would be counted twice. The sentiment is kept if both agree, otherwise it is the dominant sentiment of the
+// distribution, or mixed without one.
+func mergeDuplicateTopic(
+	topics []external.Topic,
+	topic external.Topic,
+	truncateSummary func(summary, label string) string,
+) bool {
+	existing := -1
+	for i := range topics {
+		if topics[i].Topic == topic.Topic {
+			existing = i
+			break
+		}
+	}
+	if existing < 0 {
+		return false
+	}
+
+	merged := &topics[existing]
+	if summary := strings.TrimSpace(topic.Summary); summary != "" && !strings.Contains(merged.Summary, summary) {
+		merged.Summary = truncateSummary(strings.TrimSpace(merged.Summary+" "+summary), string(topic.Topic))
+	}
+
+	assigned := make(map[uuid.UUID]bool, len(merged.FeedbackIDs))
+	for _, id := range merged.FeedbackIDs {
+		assigned[id] = true
+	}
+	overlapping := false
+	for _, id := range topic.FeedbackIDs {
+		if assigned[id] {
+			overlapping = true
+			continue
+		}
+		assigned[id] = true
+		merged.FeedbackIDs = append(merged.FeedbackIDs, id)
+	}
+
+	if overlapping || merged.SentimentDistribution.IsNone() || topic.SentimentDistribution.IsNone() {
+		merged.SentimentDistribution = optional.None[analysis.SentimentDistribution]()
+	} else {
+		x, y := merged.SentimentDistribution.Unwrap(), topic.SentimentDistribution.Unwrap()
+		merged.SentimentDistribution = optional.Some(
+			analysis.SentimentDistribution{
+				Positive: x.Positive + y.Positive,
+				Mixed:    x.Mixed + y.Mixed,
+				Negative: x.Negative + y.Negative,
+			},
+		)
+	}
+
+	if merged.Sentiment != topic.Sentiment {
+		merged.Sentiment = analysis.SentimentMixed
+		if merged.SentimentDistribution.IsSome() {
+			merged.Sentiment = dominantSentiment(merged.SentimentDistribution.Unwrap())
+		}
+	}
+
+	for _, recommendation := range topic.Recommendations {
+		if !containsString(merged.Recommendations, recommendation) {
+			merged.Recommendations = append(merged.Recommendations, recommendation)
+		}
+	}
+
+	if merged.Confidence.IsNone() ||
+		(topic.Confidence.IsSome() && topic.Confidence.Unwrap() < merged.Confidence.Unwrap()) {
+		merged.Confidence = topic.Confidence
+	}
+	return true
+}
+
+// dominantSentiment returns the sentiment most feedbacks of the distribution have, mixed on a tie.
+func dominantSentiment(distribution analysis.SentimentDistribution) analysis.Sentiment {
+	switch {
+	case distribution.Positive > distribution.Negative && distribution.Positive > distribution.Mixed:
+		return analysis.SentimentPositive
+	case distribution.Negative > distribution.Positive && distribution.Negative > distribution.Mixed:
+		return analysis.SentimentNegative
+	default:
+		return analysis.SentimentMixed
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package llm
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// ConcurrencyLimiter bounds the number of concurrent LLM requests. A single limiter is shared by all
+// analyses, and can be shared by several clients as well, to stay under a provider concurrency cap.
+// Requests over the limit wait for a free slot instead of bursting.
+type ConcurrencyLimiter struct {
+	// slots holds one element per request in flight, nil if the limiter is unbounded.
+	slots    chan struct{}
+	inFlight atomic.Int64
+}
+
+// NewConcurrencyLimiter creates a limiter admitting at most limit concurrent requests.
+// A limit of 0 or less admits every request, while still counting the requests in flight.
+func NewConcurrencyLimiter(limit int) *ConcurrencyLimiter {
+	l := &ConcurrencyLimiter{}
+	if limit > 0 {
+		l.slots = make(chan struct{}, limit)
+	}
+	return l
+}
+
+// Acquire waits for a free slot. It returns the context error if ctx is done before a slot frees up.
+// Every successful Acquire must be followed by a Release.
+func (l *ConcurrencyLimiter) Acquire(ctx context.Context) error {
+	if l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	l.inFlight.Add(1)
+	return nil
+}
+
+// Release frees a slot obtained from Acquire.
+func (l *ConcurrencyLimiter) Release() {
+	l.inFlight.Add(-1)
+	if l.slots != nil {
+		<-l.slots
+	}
+}
+
+// InFlight returns the number of requests currently holding a slot.
+func (l *ConcurrencyLimiter) InFlight() int {
+	return int(l.inFlight.Load())
+}
+
+// Limit returns the maximum number of concurrent requests, 0 if unbounded.
+func (l *ConcurrencyLimiter) Limit() int {
+	return cap(l.slots)
+}
@@ -0,0 +1,76 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConcurrencyLimiter_WaitsForFreeSlot(t *testing.T) {
+	limiter := NewConcurrencyLimiter(2)
+
+	for i := 0; i < 2; i++ {
+		if err := limiter.Acquire(context.Background()); err != nil {
+			t.Fatalf("Expected slot %d to be acquired, got error: %v", i, err)
+		}
+	}
+	if got := limiter.InFlight(); got != 2 {
+		t.Fatalf("Expected 2 requests in flight, got %d", got)
+	}
+
+	acquired := make(chan struct{})
+	go func() {
+		if err := limiter.Acquire(context.Background()); err == nil {
+			close(acquired)
+		}
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("Expected the third request to wait while the limiter is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	limiter.Release()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the third request to acquire the released slot")
+	}
+	if got := limiter.InFlight(); got != 2 {
+		t.Errorf("Expected 2 requests in flight, got %d", got)
+	}
+}
+
+func TestConcurrencyLimiter_RespectsContext(t *testing.T) {
+	limiter := NewConcurrencyLimiter(1)
+	if err := limiter.Acquire(context.Background()); err != nil {
+		t.Fatalf("Expected slot to be acquired, got error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := limiter.Acquire(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded while waiting, got: %v", err)
+	}
+	if got := limiter.InFlight(); got != 1 {
+		t.Errorf("Expected the timed out request not to count as in flight, got %d", got)
+	}
+}
+
+func TestConcurrencyLimiter_Unbounded(t *testing.T) {
+	limiter := NewConcurrencyLimiter(0)
+	for i := 0; i < 100; i++ {
+		if err := limiter.Acquire(context.Background()); err != nil {
+			t.Fatalf("Expected unbounded limiter to admit request %d, got error: %v", i, err)
+		}
+	}
+	if got := limiter.InFlight(); got != 100 {
+		t.Errorf("Expected 100 requests in flight, got %d", got)
+	}
+	if got := limiter.Limit(); got != 0 {
+		t.Errorf("Expected limit 0 for an unbounded limiter, got %d", got)
+	}
+}
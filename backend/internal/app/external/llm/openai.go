@@ -8,28 +8,103 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/pii"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
 )
 
-// OpenAIClient implements the external.LLMClient interface using OpenAI's Responses API.
+// OpenAIClient implements the external.LLMClient interface using OpenAI's Responses API
+// or, when configured, the Chat Completions API.
 type OpenAIClient struct {
 	apiKey string
 	model  string
-	logger tracelog.TraceLogger
+	// maxTopics limits the number of topics kept from a single response (0 means no limit).
+	maxTopics int
+	// maxSummaryLength is the number of characters overall and topic summaries are truncated to.
+	maxSummaryLength int
+	// maxKeyInsights limits the number of key insights kept from a single response (0 means no limit).
+	maxKeyInsights int
+	// apiStyle selects the API flavour the request is built for and the response is parsed as.
+	apiStyle APIStyle
+	// baseURL is the API root the endpoint path of the API style is appended to.
+	baseURL string
+	// httpClient sends the API requests.
+	httpClient *http.Client
+	// limiter bounds the number of concurrent API requests, shared across analyses.
+	limiter *ConcurrencyLimiter
+	// breaker rejects requests while the provider keeps failing, shared across analyses.
+	breaker *CircuitBreaker
+	// payloadFields is the feedback metadata sent next to the id and comment.
+	payloadFields []PayloadField
+	// enabledTopics are the topics offered to the model, all topics unless restricted.
+	enabledTopics []analysis.Topic
+	// disabledTopicPolicy handles valid topics returned by the model outside enabledTopics.
+	disabledTopicPolicy DisabledTopicPolicy
+	// topicRecommendations asks the model for recommended actions per topic.
+	topicRecommendations bool
+	// topicConfidence asks the model for a calibrated confidence per topic.
+	topicConfidence bool
+	// mergeDuplicateTopics merges topics returned more than once in a response into one.
+	mergeDuplicateTopics bool
+	// fallbackSentiment replaces empty or unknown sentiments returned by the model.
+	fallbackSentiment analysis.Sentiment
+	// sampling holds the optional temperature, top_p and seed of the requests.
+	sampling Sampling
+	// audit holds the optional store flag and the metadata of the requests.
+	audit Audit
+	// scrubber masks PII in comments sent to the API, nil if disabled.
+	scrubber *pii.Scrubber
+	// captureRawOutput returns the model output text with the analysis result.
+	captureRawOutput bool
+	// rawOutputScrubber masks PII in captured model output.
+	rawOutputScrubber *pii.Scrubber
+	// correlationHeaders names the headers carrying the trace ID and request ID, empty names are skipped.
+	correlationHeaders CorrelationHeaders
+	logger             tracelog.TraceLogger
 }
 
 // NewOpenAIClient creates a new OpenAI client.
-func NewOpenAIClient(apiKey string, model string, logger tracelog.TraceLogger) *OpenAIClient {
-	return &OpenAIClient{
-		apiKey: apiKey,
-		model:  model,
-		logger: logger,
+// By default, it talks to the Responses API at DefaultBaseURL with a client timing out after DefaultHTTPTimeout,
+// without bounding the number of concurrent requests or a circuit breaker, replaces invalid sentiments with DefaultFallbackSentiment
+// and truncates summaries to DefaultMaxSummaryLength characters.
+func NewOpenAIClient(
+	apiKey string,
+	model string,
+	maxTopics int,
+	logger tracelog.TraceLogger,
+	opts ...ClientOption,
+) *OpenAIClient {
+	c := &OpenAIClient{
+		apiKey:              apiKey,
+		model:               model,
+		maxTopics:           maxTopics,
+		maxSummaryLength:    DefaultMaxSummaryLength,
+		apiStyle:            APIStyleResponses,
+		baseURL:             DefaultBaseURL,
+		httpClient:          &http.Client{Timeout: DefaultHTTPTimeout},
+		limiter:             NewConcurrencyLimiter(0),
+		breaker:             NewCircuitBreaker(0, 0, nil),
+		payloadFields:       DefaultPayloadFields,
+		enabledTopics:       analysis.AllTopics(),
+		disabledTopicPolicy: DisabledTopicDrop,
+		fallbackSentiment:   DefaultFallbackSentiment,
+		logger:              logger,
 	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 // APIResponse represents the response structure from OpenAI Responses API.
@@ -61,6 +136,14 @@ type Usage struct {
 	TotalTokens  int `json:"total_tokens"`
 }
 
+func (u Usage) toExternal() external.TokenUsage {
+	return external.TokenUsage{
+		InputTokens:  u.InputTokens,
+		OutputTokens: u.OutputTokens,
+		TotalTokens:  u.TotalTokens,
+	}
+}
+
 // APIError represents an error from the API.
 type APIError struct {
 	Message string `json:"message"`
@@ -77,10 +160,20 @@ type AnalysisResponse struct {
 
 // TopicResponse represents a topic in the LLM response.
 type TopicResponse struct {
-	TopicEnum   string   `json:"topic_enum"`
-	Summary     string   `json:"summary"`
-	FeedbackIDs []string `json:"feedback_ids"`
-	Sentiment   string   `json:"sentiment"`
+	TopicEnum             string                         `json:"topic_enum"`
+	Summary               string                         `json:"summary"`
+	FeedbackIDs           []string                       `json:"feedback_ids"`
+	Sentiment             string                         `json:"sentiment"`
+	SentimentDistribution *SentimentDistributionResponse `json:"sentiment_distribution"`
+	Recommendations       []string                       `json:"recommendations"`
+	Confidence            *float64                       `json:"confidence"`
+}
+
+// SentimentDistributionResponse represents the number of a topic's feedbacks per sentiment in the LLM response.
+type SentimentDistributionResponse struct {
+	Positive int `json:"positive"`
+	Mixed    int `json:"mixed"`
+	Negative int `json:"negative"`
 }
 
 // AnalyzeFeedbacks performs LLM analysis on the given feedbacks.
@@ -88,126 +181,355 @@ func (c *OpenAIClient) AnalyzeFeedbacks(
 	ctx context.Context,
 	feedbacks []*feedback.Feedback,
 	previousAnalysis *analysis.Analysis,
+	previousTopics []*analysis.TopicAnalysis,
+	contextFeedbacks []*feedback.Feedback,
+) (*external.AnalysisResult, error) {
+	ctx, spanLogger, span := c.logger.StartSpan(ctx, "llm.analyze")
+	defer span.End()
+
+	span.SetAttributes(
+		trace.Attribute{Key: "llm.model", Value: c.model},
+		trace.Attribute{Key: "llm.api_style", Value: string(c.apiStyle)},
+		trace.Attribute{Key: "llm.feedback_count", Value: len(feedbacks)},
+		trace.Attribute{Key: "llm.context_feedback_count", Value: len(contextFeedbacks)},
+	)
+
+	startTime := time.Now()
+	result, err := c.analyzeFeedbacks(ctx, feedbacks, previousAnalysis, previousTopics, contextFeedbacks)
+	span.SetAttributes(trace.Attribute{Key: "llm.duration_ms", Value: time.Since(startTime).Milliseconds()})
+	if err != nil {
+		span.SetStatus(trace.StatusError, err.Error())
+		spanLogger.RecordSpanError(ctx, err)
+		return nil, err
+	}
+
+	span.SetStatus(trace.StatusOK, "Successfully analyzed feedbacks")
+	return result, nil
+}
+
+// analyzeFeedbacks sends the analysis request to the API and parses its response.
+// HTTP status and token usage are recorded on the span carried by ctx.
+func (c *OpenAIClient) analyzeFeedbacks(
+	ctx context.Context,
+	feedbacks []*feedback.Feedback,
+	previousAnalysis *analysis.Analysis,
+	previousTopics []*analysis.TopicAnalysis,
+	contextFeedbacks []*feedback.Feedback,
 ) (*external.AnalysisResult, error) {
 	// Build the user payload with feedback data
-	userPayload := c.buildUserPayload(feedbacks, previousAnalysis)
+	userPayload := c.buildUserPayload(feedbacks, previousAnalysis, previousTopics, contextFeedbacks)
 
 	// Build the request body
-	requestBody, err := c.buildRequestBody(userPayload)
+	requestBody, err := c.buildRequestBody(userPayload, external.AnalysisIDFromContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to build request body: %w", err)
 	}
 
-	// Make the HTTP request
+	outputText, usage, err := c.complete(ctx, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the structured JSON response
+	var analysisResp AnalysisResponse
+	if err := json.Unmarshal([]byte(outputText), &analysisResp); err != nil {
+		return nil, withUsage(
+			usage, &external.InvalidOutputError{
+				RawOutput: c.rawOutput(outputText),
+				Err:       fmt.Errorf("model returned invalid JSON or schema mismatch: %w (raw: %s)", err, outputText),
+			},
+		)
+	}
+
+	c.logger.Debug("parsed analysis response", "topics_count", len(analysisResp.Topics))
+
+	// Convert to external.AnalysisResult
+	convertedTopics := c.convertTopics(ctx, analysisResp.Topics)
+	c.logger.Debug("converted topics", "topics_count", len(convertedTopics))
+
+	result := &external.AnalysisResult{
+		OverallSummary: c.truncateSummary(analysisResp.OverallSummary, "overall"),
+		Sentiment:      c.sentimentOrFallback(analysisResp.Sentiment, "overall"),
+		KeyInsights:    c.truncateKeyInsights(ctx, analysisResp.KeyInsights),
+		TokensUsed:     usage.TotalTokens,
+		Topics:         convertedTopics,
+		RawOutput:      c.rawOutput(outputText),
+		Usage:          usage.toExternal(),
+	}
+
+	return result, nil
+}
+
+// complete sends a request body built for the configured API style and returns the model output text
+// and its token usage. HTTP status and token usage are recorded on the span carried by ctx.
+func (c *OpenAIClient) complete(ctx context.Context, requestBody []byte) (string, Usage, error) {
 	httpReq, err := http.NewRequestWithContext(
 		ctx,
 		"POST",
-		"https://api.openai.com/v1/responses",
+		c.endpoint(),
 		bytes.NewReader(requestBody),
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return "", Usage{}, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	httpReq.Header.Set("Authorization", "Bearer "+c.apiKey)
 	httpReq.Header.Set("Content-Type", "application/json")
+	c.setCorrelationHeaders(ctx, httpReq)
 
-	resp, err := http.DefaultClient.Do(httpReq)
+	if !c.breaker.Allow() {
+		return "", Usage{}, external.ErrCircuitOpen
+	}
+	statusCode, rawBody, err := c.send(ctx, httpReq)
+	c.recordOutcome(ctx, statusCode, err)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return "", Usage{}, err
 	}
-	defer func(Body io.ReadCloser) {
-		if err := Body.Close(); err != nil {
-			c.logger.RecordSpanError(ctx, fmt.Errorf("failed to close response body: %w", err))
-		}
-	}(resp.Body)
 
-	rawBody, err := io.ReadAll(resp.Body)
+	c.logger.SetSpanAttributes(ctx, trace.Attribute{Key: "http.status_code", Value: statusCode})
+
+	if statusCode < 200 || statusCode >= 300 {
+		return "", Usage{}, fmt.Errorf("OpenAI API error (HTTP %d): %s", statusCode, string(rawBody))
+	}
+
+	// Parse the API response according to the configured API style
+	outputText, usage, err := c.parseResponse(rawBody)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return "", usage, withUsage(usage, err)
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("OpenAI API error (HTTP %d): %s", resp.StatusCode, string(rawBody))
+	c.logger.SetSpanAttributes(
+		ctx,
+		trace.Attribute{Key: "llm.input_tokens", Value: usage.InputTokens},
+		trace.Attribute{Key: "llm.output_tokens", Value: usage.OutputTokens},
+		trace.Attribute{Key: "llm.total_tokens", Value: usage.TotalTokens},
+	)
+
+	return outputText, usage, nil
+}
+
+// recordOutcome reports the outcome of a request to the circuit breaker. Transport errors, server errors and
+// rate limiting count as failures. Other responses, including client errors, show that the provider is up.
+// A request abandoned because ctx is done counts as neither.
+func (c *OpenAIClient) recordOutcome(ctx context.Context, statusCode int, err error) {
+	switch {
+	case err != nil && ctx.Err() != nil:
+		c.breaker.Abort()
+	case err != nil, statusCode >= 500, statusCode == http.StatusTooManyRequests:
+		if c.breaker.Failure() {
+			c.logger.Warning(
+				"LLM circuit breaker opened, requests are rejected until the cooldown has passed",
+				"status_code", statusCode,
+			)
+		}
+	default:
+		c.breaker.Success()
 	}
+}
 
-	// Parse the API response
-	var apiResp APIResponse
-	if err := json.Unmarshal(rawBody, &apiResp); err != nil {
-		return nil, fmt.Errorf("failed to parse API response: %w", err)
+// withUsage wraps err in an external.UsageError if the provider reported token usage for the failed request.
+func withUsage(usage Usage, err error) error {
+	if usage.TotalTokens == 0 {
+		return err
 	}
+	return &external.UsageError{Usage: usage.toExternal(), Err: err}
+}
 
-	// Check for API-level errors
-	if apiResp.Error != nil {
-		return nil, fmt.Errorf("OpenAI API error: %s (type: %s)", apiResp.Error.Message, apiResp.Error.Type)
+// send executes the request once a slot of the concurrency limiter is free, and returns the status code
+// and body of the response. The slot is held until the body is read. The time spent waiting for the slot
+// and the number of requests in flight are recorded on the span carried by ctx.
+func (c *OpenAIClient) send(ctx context.Context, httpReq *http.Request) (int, []byte, error) {
+	waitStart := time.Now()
+	if err := c.limiter.Acquire(ctx); err != nil {
+		return 0, nil, fmt.Errorf("failed to wait for a free request slot: %w", err)
 	}
+	defer c.limiter.Release()
 
-	// Extract the output text from the response
-	outputText, err := c.extractOutputText(apiResp)
+	c.logger.SetSpanAttributes(
+		ctx,
+		trace.Attribute{Key: "llm.concurrency_wait_ms", Value: time.Since(waitStart).Milliseconds()},
+		trace.Attribute{Key: "llm.in_flight", Value: c.limiter.InFlight()},
+	)
+
+	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("failed to extract output text: %w", err)
+		return 0, nil, fmt.Errorf("failed to execute request: %w", err)
 	}
+	defer func(Body io.ReadCloser) {
+		if err := Body.Close(); err != nil {
+			c.logger.RecordSpanError(ctx, fmt.Errorf("failed to close response body: %w", err))
+		}
+	}(resp.Body)
 
-	// Parse the structured JSON response
-	var analysisResp AnalysisResponse
-	if err := json.Unmarshal([]byte(outputText), &analysisResp); err != nil {
-		return nil, fmt.Errorf("model returned invalid JSON or schema mismatch: %w (raw: %s)", err, outputText)
+	rawBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to read response body: %w", err)
 	}
 
-	c.logger.Debug("parsed analysis response", "topics_count", len(analysisResp.Topics))
-
-	// Convert to external.AnalysisResult
-	convertedTopics := c.convertTopics(ctx, analysisResp.Topics)
-	c.logger.Debug("converted topics", "topics_count", len(convertedTopics))
+	return resp.StatusCode, rawBody, nil
+}
 
-	result := &external.AnalysisResult{
-		OverallSummary: analysisResp.OverallSummary,
-		Sentiment:      analysis.Sentiment(analysisResp.Sentiment),
-		KeyInsights:    analysisResp.KeyInsights,
-		TokensUsed:     apiResp.Usage.TotalTokens,
-		Topics:         convertedTopics,
+// rawOutput returns the model output text with PII masked, or an empty string if capture is disabled.
+func (c *OpenAIClient) rawOutput(outputText string) string {
+	if !c.captureRawOutput {
+		return ""
 	}
-
-	return result, nil
+	scrubbed, _ := c.rawOutputScrubber.Scrub(outputText)
+	return scrubbed
 }
 
 // buildUserPayload creates the user payload with feedback data.
-func (c *OpenAIClient) buildUserPayload(feedbacks []*feedback.Feedback, previousAnalysis *analysis.Analysis) Map {
+// Context feedbacks are sent without IDs so that they cannot be assigned to topics.
+func (c *OpenAIClient) buildUserPayload(
+	feedbacks []*feedback.Feedback,
+	previousAnalysis *analysis.Analysis,
+	previousTopics []*analysis.TopicAnalysis,
+	contextFeedbacks []*feedback.Feedback,
+) Map {
+	scrubbedComments := 0
+
 	feedbackItems := make([]Map, 0, len(feedbacks))
 	for _, fb := range feedbacks {
-		feedbackItems = append(
-			feedbackItems, Map{
-				"id":      fb.ID().String(),
-				"rating":  fb.Rating().Value(),
-				"comment": fb.Comment().Value(),
-			},
-		)
+		item, scrubbed := c.buildFeedbackItem(fb)
+		item["id"] = fb.ID().String()
+		if scrubbed {
+			scrubbedComments++
+		}
+		feedbackItems = append(feedbackItems, item)
+	}
+
+	contextItems := make([]Map, 0, len(contextFeedbacks))
+	for _, fb := range contextFeedbacks {
+		item, scrubbed := c.buildFeedbackItem(fb)
+		if scrubbed {
+			scrubbedComments++
+		}
+		contextItems = append(contextItems, item)
+	}
+
+	if scrubbedComments > 0 {
+		c.logger.Info("pii scrubbed from comments sent to the llm", "comments", scrubbedComments)
 	}
 
 	payload := Map{
 		"feedbacks": feedbackItems,
 	}
+	if len(contextItems) > 0 {
+		payload["context_feedbacks"] = contextItems
+	}
+
+	// The rating scale is only meaningful if ratings are sent
+	if c.sendsField(PayloadFieldRating) {
+		scale := feedback.CurrentRatingScale()
+		payload["rating_scale"] = Map{
+			"min": scale.Min,
+			"max": scale.Max,
+		}
+	}
 
 	// Include previous analysis summary if available
 	if previousAnalysis != nil {
-		payload["previous_analysis"] = Map{
-			"overall_summary": previousAnalysis.OverallSummary(),
+		previous := Map{
+			"overall_summary": previousAnalysis.OverallSummary().UnwrapOr(""),
 			"sentiment":       string(previousAnalysis.Sentiment()),
 			"key_insights":    previousAnalysis.KeyInsights(),
 		}
+
+		// Include the previous topic breakdown for topic continuity
+		if len(previousTopics) > 0 {
+			topicItems := make([]Map, 0, len(previousTopics))
+			for _, topic := range previousTopics {
+				topicItems = append(
+					topicItems, Map{
+						"topic_enum":     string(topic.Topic()),
+						"summary":        topic.Summary(),
+						"sentiment":      string(topic.Sentiment()),
+						"feedback_count": topic.FeedbackCount(),
+					},
+				)
+			}
+			previous["topics"] = topicItems
+		}
+
+		payload["previous_analysis"] = previous
 	}
 
 	return payload
 }
 
-// buildRequestBody builds the request body for the OpenAI API.
-func (c *OpenAIClient) buildRequestBody(userPayload Map) ([]byte, error) {
+// buildFeedbackItem builds the payload entry of a feedback with its comment and enabled metadata fields.
+// Translated comments are sent in their translation. It also reports whether PII was scrubbed from the comment.
+func (c *OpenAIClient) buildFeedbackItem(fb *feedback.Feedback) (Map, bool) {
+	comment, findings := c.scrubber.Scrub(fb.AnalysisComment())
+
+	item := Map{
+		"comment": comment,
+	}
+	if c.sendsField(PayloadFieldRating) {
+		item["rating"] = fb.Rating().Value()
+	}
+	if c.sendsField(PayloadFieldSource) && fb.Source() != "" {
+		item["source"] = fb.Source().String()
+	}
+	if c.sendsField(PayloadFieldCreatedAt) {
+		item["created_at"] = fb.CreatedAt().UTC().Format(time.RFC3339)
+	}
+	if c.sendsField(PayloadFieldMetadata) {
+		if metadata := buildMetadataItem(fb.Metadata()); len(metadata) > 0 {
+			item["metadata"] = metadata
+		}
+	}
+
+	return item, findings.Total() > 0
+}
+
+// buildMetadataItem builds the payload entry of the feedback metadata, omitting unset fields.
+func buildMetadataItem(metadata feedback.Metadata) Map {
+	item := Map{}
+	if metadata.AppVersion() != "" {
+		item["app_version"] = metadata.AppVersion()
+	}
+	if metadata.Platform() != "" {
+		item["platform"] = metadata.Platform().String()
+	}
+	if metadata.OS() != "" {
+		item["os"] = metadata.OS()
+	}
+	return item
+}
+
+// buildRequestBody builds the request body of an analysis for the OpenAI API.
+// The analysis ID is sent as metadata unless it is uuid.Nil.
+func (c *OpenAIClient) buildRequestBody(userPayload Map, analysisID uuid.UUID) ([]byte, error) {
+	return c.buildStructuredRequestBody(
+		c.buildSystemPrompt(),
+		userPayload,
+		"feedback_analysis",
+		AnalysisSchema(c.enabledTopics, c.topicRecommendations, c.topicConfidence),
+		analysisID,
+	)
+}
+
+// buildStructuredRequestBody builds a request body for the OpenAI API whose output must match the given
+// JSON schema.
+func (c *OpenAIClient) buildStructuredRequestBody(
+	systemPrompt string,
+	userPayload Map,
+	schemaName string,
+	schema Map,
+	analysisID uuid.UUID,
+) ([]byte, error) {
 	userJSON, err := json.Marshal(userPayload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal user payload: %w", err)
 	}
 
-	systemPrompt := c.buildSystemPrompt()
+	if c.apiStyle == APIStyleChatCompletions {
+		requestBody := c.buildChatCompletionsRequestBody(systemPrompt, string(userJSON), schemaName, schema)
+		c.sampling.apply(requestBody, c.apiStyle)
+		c.audit.apply(requestBody, analysisID)
+		return json.Marshal(requestBody)
+	}
 
 	requestBody := Map{
 		"model": c.model,
@@ -224,12 +546,14 @@ func (c *OpenAIClient) buildRequestBody(userPayload Map) ([]byte, error) {
 		"text": Map{
 			"format": Map{
 				"type":   "json_schema",
-				"name":   "feedback_analysis",
+				"name":   schemaName,
 				"strict": true,
-				"schema": AnalysisSchema(),
+				"schema": schema,
 			},
 		},
 	}
+	c.sampling.apply(requestBody, c.apiStyle)
+	c.audit.apply(requestBody, analysisID)
 
 	return json.Marshal(requestBody)
 }
@@ -238,13 +562,52 @@ func (c *OpenAIClient) buildRequestBody(userPayload Map) ([]byte, error) {
 func (c *OpenAIClient) buildSystemPrompt() string {
 	// Build the topics list with descriptions for the prompt
 	topicsList := ""
-	for i, topic := range analysis.AllTopics() {
+	for i, topic := range c.enabledTopics {
 		if i > 0 {
 			topicsList += "\n\n"
 		}
 		topicsList += fmt.Sprintf("%d. %s (%s)\n%s", i+1, topic.DisplayName(), string(topic), topic.Description())
 	}
 
+	limitRules := ""
+	if c.maxTopics > 0 {
+		limitRules = fmt.Sprintf(
+			"\n   - Return at most %d topics, choosing the ones that cover the most feedbacks",
+			c.maxTopics,
+		)
+	}
+
+	if c.maxKeyInsights > 0 {
+		limitRules += fmt.Sprintf(
+			"\n   - Return at most %d key insights, choosing the most important ones and listing them first",
+			c.maxKeyInsights,
+		)
+	}
+
+	metadataRule := ""
+	if len(c.payloadFields) > 0 {
+		names := make([]string, len(c.payloadFields))
+		for i, field := range c.payloadFields {
+			names[i] = string(field)
+		}
+		metadataRule = fmt.Sprintf(
+			"\n   - Use the feedback metadata (%s) as context, e.g. to spot issues specific to a source",
+			strings.Join(names, ", "),
+		)
+	}
+
+	recommendationsRule := ""
+	if c.topicRecommendations {
+		recommendationsRule = "\n     * Concrete, actionable recommendations for the team as recommendations, " +
+			"e.g. \"Add an export to CSV on the reports page\"; null if the feedback does not suggest any action"
+	}
+
+	confidenceRule := ""
+	if c.topicConfidence {
+		confidenceRule = "\n     * How confident you are that the feedbacks belong to this topic as confidence, " +
+			"between 0 and 1 and calibrated, i.e. assignments with a confidence of 0.7 are right about 70% of the time"
+	}
+
 	return fmt.Sprintf(
 		`Your task is to analyze customer feedback and categorize it into predefined business topics.
 
@@ -264,16 +627,55 @@ INSTRUCTIONS:
      * The topic_enum value (one of the predefined values)
      * A summary explaining why this feedback belongs to this topic and what specific aspects it addresses
      * The feedback IDs that belong to this topic
-     * The sentiment for this specific topic
+     * The sentiment for this specific topic, i.e. the dominant one
+     * The number of the topic's feedbacks that are positive, mixed and negative as sentiment_distribution%s%s
 
 3. Important rules:
    - DO NOT create new topic names - only use the predefined topic enum values
    - Group similar feedback together under the most appropriate topic(s)
    - Be specific about which feedback IDs map to which topics
-   - Provide clear, actionable insights`, topicsList,
+   - Provide clear, actionable insights
+   - If previous_analysis is provided, treat it as context from the last run: evolve its summaries and
+     topic summaries with the new feedback instead of rewriting them from scratch
+   - If context_feedbacks are provided, they were already analyzed and are included for context only:
+     use them to enrich the summaries, but never assign them to topics or count them%s%s`,
+		topicsList,
+		recommendationsRule,
+		confidenceRule,
+		limitRules,
+		metadataRule,
 	)
 }
 
+// parseResponse extracts the model output text and token usage from a raw API response body.
+func (c *OpenAIClient) parseResponse(rawBody []byte) (string, Usage, error) {
+	if c.apiStyle == APIStyleChatCompletions {
+		return c.parseChatCompletionsResponse(rawBody)
+	}
+
+	var apiResp APIResponse
+	if err := json.Unmarshal(rawBody, &apiResp); err != nil {
+		return "", Usage{}, fmt.Errorf("failed to parse API response: %w", err)
+	}
+
+	// Check for API-level errors
+	if apiResp.Error != nil {
+		return "", apiResp.Usage, fmt.Errorf(
+			"OpenAI API error: %s (type: %s)",
+			apiResp.Error.Message,
+			apiResp.Error.Type,
+		)
+	}
+
+	// Extract the output text from the response
+	outputText, err := c.extractOutputText(apiResp)
+	if err != nil {
+		return "", apiResp.Usage, fmt.Errorf("failed to extract output text: %w", err)
+	}
+
+	return outputText, apiResp.Usage, nil
+}
+
 // extractOutputText extracts the output text from the API response.
 func (c *OpenAIClient) extractOutputText(apiResp APIResponse) (string, error) {
 	for _, item := range apiResp.Output {
@@ -288,6 +690,87 @@ func (c *OpenAIClient) extractOutputText(apiResp APIResponse) (string, error) {
 	return "", errors.New("no output_text found in API response")
 }
 
+// sentimentOrFallback converts a sentiment returned by the model, replacing an empty or unknown one
+// with the fallback sentiment, so that a single malformed value does not fail the whole analysis.
+// scope names what the sentiment belongs to in the warning: "overall" or a topic enum.
+func (c *OpenAIClient) sentimentOrFallback(value string, scope string) analysis.Sentiment {
+	sentiment := analysis.Sentiment(value)
+	if sentiment.IsValid() {
+		return sentiment
+	}
+
+	c.logger.Warning(
+		"invalid sentiment from LLM, using fallback",
+		"sentiment",
+		value,
+		"scope",
+		scope,
+		"fallback",
+		string(c.fallbackSentiment),
+	)
+	return c.fallbackSentiment
+}
+
+// convertSentimentDistribution converts the sentiment distribution of a topic returned by the model.
+// A missing or invalid distribution yields None, so that the topic keeps just its sentiment label.
+func (c *OpenAIClient) convertSentimentDistribution(
+	distribution *SentimentDistributionResponse,
+	topicEnum string,
+) optional.Optional[analysis.SentimentDistribution] {
+	if distribution == nil {
+		return optional.None[analysis.SentimentDistribution]()
+	}
+
+	converted := analysis.SentimentDistribution{
+		Positive: distribution.Positive,
+		Mixed:    distribution.Mixed,
+		Negative: distribution.Negative,
+	}
+	if !converted.IsValid() {
+		c.logger.Warning(
+			"invalid sentiment distribution from LLM, ignoring it",
+			"topic",
+			topicEnum,
+			"distribution",
+			fmt.Sprintf("%+v", *distribution),
+		)
+		return optional.None[analysis.SentimentDistribution]()
+	}
+
+	return optional.Some(converted)
+}
+
+// convertConfidence converts the confidence returned for a topic, ignoring a value outside [0, 1].
+// A null confidence yields None.
+func (c *OpenAIClient) convertConfidence(confidence *float64, topicEnum string) optional.Optional[float64] {
+	if confidence == nil {
+		return optional.None[float64]()
+	}
+
+	if *confidence < 0 || *confidence > 1 {
+		c.logger.Warning("invalid confidence from LLM, ignoring it", "topic", topicEnum, "confidence", *confidence)
+		return optional.None[float64]()
+	}
+
+	return optional.Some(*confidence)
+}
+
+// convertRecommendations trims the recommendations returned for a topic, dropping blank and repeated ones.
+// A null list yields no recommendations.
+func convertRecommendations(recommendations []string) []string {
+	result := make([]string, 0, len(recommendations))
+	seen := make(map[string]bool, len(recommendations))
+	for _, recommendation := range recommendations {
+		recommendation = strings.TrimSpace(recommendation)
+		if recommendation == "" || seen[recommendation] {
+			continue
+		}
+		seen[recommendation] = true
+		result = append(result, recommendation)
+	}
+	return result
+}
+
 // convertTopics converts TopicResponse to external.Topic.
 func (c *OpenAIClient) convertTopics(ctx context.Context, topics []TopicResponse) []external.Topic {
 	if len(topics) == 0 {
@@ -295,6 +778,7 @@ func (c *OpenAIClient) convertTopics(ctx context.Context, topics []TopicResponse
 	}
 
 	result := make([]external.Topic, 0, len(topics))
+	var remapped []external.Topic
 	for i, topic := range topics {
 		feedbackIDs := make([]uuid.UUID, 0, len(topic.FeedbackIDs))
 		for _, idStr := range topic.FeedbackIDs {
@@ -329,14 +813,38 @@ func (c *OpenAIClient) convertTopics(ctx context.Context, topics []TopicResponse
 			continue
 		}
 
-		result = append(
-			result, external.Topic{
-				Topic:       topicValue,
-				Summary:     topic.Summary,
-				FeedbackIDs: feedbackIDs,
-				Sentiment:   analysis.Sentiment(topic.Sentiment),
-			},
-		)
+		converted := external.Topic{
+			Topic:                 topicValue,
+			Summary:               c.truncateSummary(topic.Summary, topic.TopicEnum),
+			FeedbackIDs:           feedbackIDs,
+			Sentiment:             c.sentimentOrFallback(topic.Sentiment, topic.TopicEnum),
+			SentimentDistribution: c.convertSentimentDistribution(topic.SentimentDistribution, topic.TopicEnum),
+			Recommendations:       convertRecommendations(topic.Recommendations),
+			Confidence:            c.convertConfidence(topic.Confidence, topic.TopicEnum),
+		}
+
+		if !c.isEnabled(topicValue) {
+			remappedTopic, ok := c.remapDisabledTopic(ctx, topicValue)
+			if !ok {
+				continue
+			}
+			converted.Topic = remappedTopic
+			remapped = append(remapped, converted)
+			continue
+		}
+
+		if c.mergeDuplicateTopics && mergeDuplicateTopic(result, converted, c.truncateSummary) {
+			c.logger.Warning(
+				"duplicate topic from LLM merged",
+				"topic_enum",
+				topic.TopicEnum,
+				"index",
+				i,
+			)
+			continue
+		}
+
+		result = append(result, converted)
 		c.logger.Debug(
 			"converted topic",
 			"index",
@@ -347,5 +855,117 @@ func (c *OpenAIClient) convertTopics(ctx context.Context, topics []TopicResponse
 			len(feedbackIDs),
 		)
 	}
-	return result
+
+	result = mergeRemappedTopics(result, remapped)
+
+	return c.truncateTopics(ctx, result)
+}
+
+// remapDisabledTopic returns the closest enabled related topic of a valid topic that is not enabled, if the
+// remap policy applies. It reports false if the topic is dropped instead.
+// The schema only offers enabled topics, so this only guards against providers not strictly honoring it.
+func (c *OpenAIClient) remapDisabledTopic(ctx context.Context, topic analysis.Topic) (analysis.Topic, bool) {
+	if c.disabledTopicPolicy == DisabledTopicRemap {
+		for _, related := range topic.RelatedTopics() {
+			if c.isEnabled(related) {
+				c.logger.Warning(
+					"disabled topic from LLM remapped to closest enabled topic",
+					"topic_enum",
+					string(topic),
+					"remapped_to",
+					string(related),
+				)
+				return related, true
+			}
+		}
+	}
+
+	c.logger.Warning("disabled topic from LLM dropped", "topic_enum", string(topic))
+	c.logger.RecordSpanError(ctx, fmt.Errorf("disabled topic '%s' from LLM response", topic))
+	return "", false
+}
+
+// mergeRemappedTopics adds remapped topics to the converted ones. The feedbacks of a remapped topic that
+// coincides with a topic already present are merged into it, keeping the summary, sentiment and recommendations
+// of the latter.
+// Merging clears the sentiment distribution, as it no longer covers all feedbacks of the topic.
+func mergeRemappedTopics(topics []external.Topic, remapped []external.Topic) []external.Topic {
+	for _, topic := range remapped {
+		existing := -1
+		for i := range topics {
+			if topics[i].Topic == topic.Topic {
+				existing = i
+				break
+			}
+		}
+		if existing < 0 {
+			topics = append(topics, topic)
+			continue
+		}
+
+		assigned := make(map[uuid.UUID]bool, len(topics[existing].FeedbackIDs))
+		for _, id := range topics[existing].FeedbackIDs {
+			assigned[id] = true
+		}
+		for _, id := range topic.FeedbackIDs {
+			if !assigned[id] {
+				assigned[id] = true
+				topics[existing].FeedbackIDs = append(topics[existing].FeedbackIDs, id)
+				topics[existing].SentimentDistribution = optional.None[analysis.SentimentDistribution]()
+			}
+		}
+	}
+	return topics
+}
+
+// truncateTopics keeps at most maxTopics topics, preferring the ones with the most feedbacks.
+// The model is instructed to respect the limit, so this only guards against it overshooting.
+func (c *OpenAIClient) truncateTopics(ctx context.Context, topics []external.Topic) []external.Topic {
+	if c.maxTopics <= 0 || len(topics) <= c.maxTopics {
+		return topics
+	}
+
+	sort.SliceStable(
+		topics, func(i, j int) bool {
+			return len(topics[i].FeedbackIDs) > len(topics[j].FeedbackIDs)
+		},
+	)
+
+	c.logger.Warning(
+		"LLM returned more topics than allowed, truncating",
+		"topics_count",
+		len(topics),
+		"max_topics",
+		c.maxTopics,
+	)
+	c.logger.SetSpanAttributes(
+		ctx,
+		trace.Attribute{Key: "llm.topics_returned", Value: len(topics)},
+		trace.Attribute{Key: "llm.topics_truncated", Value: len(topics) - c.maxTopics},
+	)
+
+	return topics[:c.maxTopics]
+}
+
+// truncateKeyInsights keeps the first maxKeyInsights key insights, which the model is asked to list
+// in order of importance.
+func (c *OpenAIClient) truncateKeyInsights(ctx context.Context, insights []string) []string {
+	if c.maxKeyInsights <= 0 || len(insights) <= c.maxKeyInsights {
+		return insights
+	}
+
+	c.logger.Warning(
+		"LLM returned more key insights than allowed, truncating",
+		"key_insights_count",
+		len(insights),
+		"max_key_insights",
+		c.maxKeyInsights,
+	)
+	c.logger.SetSpanAttributes(
+		ctx,
+		trace.Attribute{Key: "llm.key_insights_returned", Value: len(insights)},
+		trace.Attribute{Key: "llm.key_insights_truncated", Value: len(insights) - c.maxKeyInsights},
+	)
+
+	return insights[:c.maxKeyInsights]
 }
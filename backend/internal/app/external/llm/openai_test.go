@@ -0,0 +1,1171 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/http/requestid"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/log"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/pii"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const testModel = "gpt-test"
+
+func newTestLogger(t *testing.T) tracelog.TraceLogger {
+	t.Helper()
+
+	tracer, err := trace.NewTracer(trace.Config{ServiceName: "llm-test"})
+	if err != nil {
+		t.Fatalf("Failed to create tracer: %v", err)
+	}
+
+	return tracelog.NewTraceLogger(log.NewLogger("development"), tracer)
+}
+
+// newTestClient starts a server answering every request with handler and returns a client pointed at it.
+func newTestClient(t *testing.T, handler http.HandlerFunc, opts ...ClientOption) *OpenAIClient {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	opts = append([]ClientOption{WithBaseURL(srv.URL), WithHTTPClient(srv.Client())}, opts...)
+	return NewOpenAIClient("test-key", testModel, 0, newTestLogger(t), opts...)
+}
+
+func newTestFeedback(t *testing.T, comment string) *feedback.Feedback {
+	t.Helper()
+
+	fb, err := feedback.NewBuilder().BuildNew(uuid.New(), 4, comment)
+	if err != nil {
+		t.Fatalf("Failed to build feedback: %v", err)
+	}
+	return fb
+}
+
+// responsesBody wraps the model output text in a Responses API envelope.
+func responsesBody(t *testing.T, outputText string) []byte {
+	t.Helper()
+
+	body, err := json.Marshal(
+		APIResponse{
+			ID:     "resp_test",
+			Status: "completed",
+			Output: []OutputItem{
+				{
+					Type:    "message",
+					Content: []ContentItem{{Type: "output_text", Text: outputText}},
+				},
+			},
+			Usage: Usage{InputTokens: 100, OutputTokens: 50, TotalTokens: 150},
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to marshal API response: %v", err)
+	}
+	return body
+}
+
+func analysisOutput(t *testing.T, topics []TopicResponse) string {
+	t.Helper()
+
+	output, err := json.Marshal(
+		AnalysisResponse{
+			OverallSummary: "Users like the product",
+			Sentiment:      "positive",
+			KeyInsights:    []string{"Fast onboarding"},
+			Topics:         topics,
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to marshal analysis output: %v", err)
+	}
+	return string(output)
+}
+
+func respondWith(status int, body []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write(body)
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_Success(t *testing.T) {
+	fb := newTestFeedback(t, "Onboarding was quick")
+	output := analysisOutput(
+		t, []TopicResponse{
+			{
+				TopicEnum:   string(analysis.TopicUIUX),
+				Summary:     "Onboarding flow is smooth",
+				FeedbackIDs: []string{fb.ID().String()},
+				Sentiment:   "positive",
+			},
+		},
+	)
+
+	var gotRequest map[string]any
+	client := newTestClient(
+		t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				t.Errorf("Expected POST request, got %s", r.Method)
+			}
+			if r.URL.Path != "/responses" {
+				t.Errorf("Expected request to /responses, got %s", r.URL.Path)
+			}
+			if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+				t.Errorf("Expected bearer authorization header, got %q", got)
+			}
+
+			raw, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(raw, &gotRequest); err != nil {
+				t.Errorf("Expected JSON request body, got error: %v", err)
+			}
+
+			respondWith(http.StatusOK, responsesBody(t, output))(w, r)
+		},
+	)
+
+	result, err := client.AnalyzeFeedbacks(context.Background(), []*feedback.Feedback{fb}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if gotRequest["model"] != testModel {
+		t.Errorf("Expected request for model %q, got %v", testModel, gotRequest["model"])
+	}
+	if result.OverallSummary != "Users like the product" {
+		t.Errorf("Unexpected overall summary: %q", result.OverallSummary)
+	}
+	if result.Sentiment != analysis.SentimentPositive {
+		t.Errorf("Expected positive sentiment, got %q", result.Sentiment)
+	}
+	if result.TokensUsed != 150 {
+		t.Errorf("Expected 150 tokens used, got %d", result.TokensUsed)
+	}
+	if result.Usage.InputTokens != 100 || result.Usage.OutputTokens != 50 {
+		t.Errorf("Expected 100 input and 50 output tokens, got %+v", result.Usage)
+	}
+	if len(result.Topics) != 1 {
+		t.Fatalf("Expected 1 topic, got %d", len(result.Topics))
+	}
+	if got := result.Topics[0].FeedbackIDs; len(got) != 1 || got[0] != fb.ID() {
+		t.Errorf("Expected topic to reference feedback %s, got %v", fb.ID(), got)
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_ChatCompletions(t *testing.T) {
+	fb := newTestFeedback(t, "Works well")
+	output := analysisOutput(t, nil)
+
+	client := newTestClient(
+		t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/chat/completions" {
+				t.Errorf("Expected request to /chat/completions, got %s", r.URL.Path)
+			}
+
+			body, _ := json.Marshal(
+				ChatCompletionsResponse{
+					Choices: []ChatCompletionChoice{{Message: ChatCompletionMessage{Role: "assistant", Content: output}}},
+					Usage:   ChatCompletionsUsage{PromptTokens: 80, CompletionTokens: 20, TotalTokens: 100},
+				},
+			)
+			respondWith(http.StatusOK, body)(w, r)
+		},
+		WithAPIStyle(APIStyleChatCompletions),
+	)
+
+	result, err := client.AnalyzeFeedbacks(context.Background(), []*feedback.Feedback{fb}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.TokensUsed != 100 {
+		t.Errorf("Expected 100 tokens used, got %d", result.TokensUsed)
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_HTTPError(t *testing.T) {
+	client := newTestClient(
+		t,
+		respondWith(http.StatusTooManyRequests, []byte(`{"error":{"message":"rate limited","type":"rate_limit"}}`)),
+	)
+
+	_, err := client.AnalyzeFeedbacks(
+		context.Background(),
+		[]*feedback.Feedback{newTestFeedback(t, "Slow")},
+		nil,
+		nil,
+		nil,
+	)
+	if err == nil {
+		t.Fatal("Expected error for non-2xx response")
+	}
+	if !strings.Contains(err.Error(), "HTTP 429") {
+		t.Errorf("Expected error to contain the HTTP status, got: %v", err)
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_APIErrorInBody(t *testing.T) {
+	client := newTestClient(
+		t,
+		respondWith(http.StatusOK, []byte(`{"error":{"message":"model overloaded","type":"server_error"}}`)),
+	)
+
+	_, err := client.AnalyzeFeedbacks(
+		context.Background(),
+		[]*feedback.Feedback{newTestFeedback(t, "Slow")},
+		nil,
+		nil,
+		nil,
+	)
+	if err == nil {
+		t.Fatal("Expected error for API-level error")
+	}
+	if !strings.Contains(err.Error(), "model overloaded") {
+		t.Errorf("Expected error to contain the API error message, got: %v", err)
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_MalformedEnvelope(t *testing.T) {
+	client := newTestClient(t, respondWith(http.StatusOK, []byte(`{"output": [`)))
+
+	_, err := client.AnalyzeFeedbacks(
+		context.Background(),
+		[]*feedback.Feedback{newTestFeedback(t, "Slow")},
+		nil,
+		nil,
+		nil,
+	)
+	if err == nil {
+		t.Fatal("Expected error for malformed API response")
+	}
+	if !strings.Contains(err.Error(), "failed to parse API response") {
+		t.Errorf("Expected parse error, got: %v", err)
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_MalformedModelOutput(t *testing.T) {
+	client := newTestClient(t, respondWith(http.StatusOK, responsesBody(t, "this is not json")))
+
+	_, err := client.AnalyzeFeedbacks(
+		context.Background(),
+		[]*feedback.Feedback{newTestFeedback(t, "Slow")},
+		nil,
+		nil,
+		nil,
+	)
+	if err == nil {
+		t.Fatal("Expected error for malformed model output")
+	}
+	if !strings.Contains(err.Error(), "invalid JSON") {
+		t.Errorf("Expected invalid JSON error, got: %v", err)
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_RawOutputCapture(t *testing.T) {
+	scrubber, err := pii.NewScrubber(nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create scrubber: %v", err)
+	}
+
+	output := `{"overall_summary":"Contact jane@example.com","sentiment":"mixed","key_insights":[],"topics":[]}`
+	client := newTestClient(
+		t,
+		respondWith(http.StatusOK, responsesBody(t, output)),
+		WithRawOutputCapture(scrubber),
+	)
+
+	result, err := client.AnalyzeFeedbacks(
+		context.Background(),
+		[]*feedback.Feedback{newTestFeedback(t, "Slow")},
+		nil,
+		nil,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if strings.Contains(result.RawOutput, "jane@example.com") {
+		t.Errorf("Expected PII to be masked in raw output, got: %s", result.RawOutput)
+	}
+	if !strings.Contains(result.RawOutput, "[EMAIL]") {
+		t.Errorf("Expected masked raw output, got: %s", result.RawOutput)
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_RawOutputOfMalformedModelOutput(t *testing.T) {
+	client := newTestClient(
+		t,
+		respondWith(http.StatusOK, responsesBody(t, "this is not json")),
+		WithRawOutputCapture(nil),
+	)
+
+	_, err := client.AnalyzeFeedbacks(
+		context.Background(),
+		[]*feedback.Feedback{newTestFeedback(t, "Slow")},
+		nil,
+		nil,
+		nil,
+	)
+	var invalidOutput *external.InvalidOutputError
+	if !errors.As(err, &invalidOutput) {
+		t.Fatalf("Expected InvalidOutputError, got: %v", err)
+	}
+	if invalidOutput.RawOutput != "this is not json" {
+		t.Errorf("Expected raw output to be kept, got: %q", invalidOutput.RawOutput)
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_UsageOfMalformedModelOutput(t *testing.T) {
+	client := newTestClient(t, respondWith(http.StatusOK, responsesBody(t, "this is not json")))
+
+	_, err := client.AnalyzeFeedbacks(
+		context.Background(),
+		[]*feedback.Feedback{newTestFeedback(t, "Slow")},
+		nil,
+		nil,
+		nil,
+	)
+	var usageErr *external.UsageError
+	if !errors.As(err, &usageErr) {
+		t.Fatalf("Expected UsageError, got: %v", err)
+	}
+	want := external.TokenUsage{InputTokens: 100, OutputTokens: 50, TotalTokens: 150}
+	if usageErr.Usage != want {
+		t.Errorf("Expected usage %+v, got %+v", want, usageErr.Usage)
+	}
+	var invalidOutput *external.InvalidOutputError
+	if !errors.As(err, &invalidOutput) {
+		t.Errorf("Expected the InvalidOutputError to stay reachable, got: %v", err)
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_InvalidTopicEnumDropped(t *testing.T) {
+	fb := newTestFeedback(t, "Pricing is confusing")
+	output := analysisOutput(
+		t, []TopicResponse{
+			{TopicEnum: "not_a_topic", Summary: "Unknown", FeedbackIDs: []string{fb.ID().String()}, Sentiment: "mixed"},
+			{
+				TopicEnum:   string(analysis.TopicPricingLicensing),
+				Summary:     "Pricing tiers are unclear",
+				FeedbackIDs: []string{fb.ID().String()},
+				Sentiment:   "negative",
+			},
+		},
+	)
+	client := newTestClient(t, respondWith(http.StatusOK, responsesBody(t, output)))
+
+	result, err := client.AnalyzeFeedbacks(context.Background(), []*feedback.Feedback{fb}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(result.Topics) != 1 {
+		t.Fatalf("Expected the invalid topic to be dropped, got %d topics", len(result.Topics))
+	}
+	if result.Topics[0].Topic != analysis.TopicPricingLicensing {
+		t.Errorf("Expected remaining topic %q, got %q", analysis.TopicPricingLicensing, result.Topics[0].Topic)
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_DisabledTopics(t *testing.T) {
+	fb := newTestFeedback(t, "Pricing is confusing and the docs are outdated")
+	other := newTestFeedback(t, "The free tier is too small")
+	output := analysisOutput(
+		t, []TopicResponse{
+			{
+				TopicEnum:   string(analysis.TopicProductStrategyRoadmap),
+				Summary:     "Users question the roadmap",
+				FeedbackIDs: []string{fb.ID().String()},
+				Sentiment:   "mixed",
+			},
+			{
+				TopicEnum:   string(analysis.TopicPricingLicensing),
+				Summary:     "Pricing tiers are unclear",
+				FeedbackIDs: []string{other.ID().String()},
+				Sentiment:   "negative",
+				SentimentDistribution: &SentimentDistributionResponse{
+					Negative: 1,
+				},
+			},
+			{
+				TopicEnum:   string(analysis.TopicLocalizationInternationalization),
+				Summary:     "Translations are missing",
+				FeedbackIDs: []string{other.ID().String()},
+				Sentiment:   "negative",
+			},
+		},
+	)
+	enabled := []analysis.Topic{analysis.TopicPricingLicensing, analysis.TopicDeveloperExperience}
+
+	tests := []struct {
+		name   string
+		policy DisabledTopicPolicy
+		// wantFeedbacks is the number of feedbacks of the pricing topic
+		wantFeedbacks    int
+		wantDistribution bool
+	}{
+		{name: "drop", policy: DisabledTopicDrop, wantFeedbacks: 1, wantDistribution: true},
+		// The roadmap topic is merged into the pricing topic, which no longer matches its distribution.
+		// The localization topic has no enabled related topic and is dropped
+		{name: "remap", policy: DisabledTopicRemap, wantFeedbacks: 2, wantDistribution: false},
+	}
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				client := newTestClient(
+					t,
+					respondWith(http.StatusOK, responsesBody(t, output)),
+					WithEnabledTopics(enabled, tt.policy),
+				)
+
+				result, err := client.AnalyzeFeedbacks(
+					context.Background(), []*feedback.Feedback{fb, other}, nil, nil, nil,
+				)
+				if err != nil {
+					t.Fatalf("Expected no error, got: %v", err)
+				}
+				if len(result.Topics) != 1 || result.Topics[0].Topic != analysis.TopicPricingLicensing {
+					t.Fatalf("Expected only the enabled pricing topic, got %+v", result.Topics)
+				}
+				if got := len(result.Topics[0].FeedbackIDs); got != tt.wantFeedbacks {
+					t.Errorf("Expected %d feedbacks, got %d", tt.wantFeedbacks, got)
+				}
+				if got := result.Topics[0].SentimentDistribution.IsSome(); got != tt.wantDistribution {
+					t.Errorf("Expected sentiment distribution present to be %v, got %v", tt.wantDistribution, got)
+				}
+			},
+		)
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_DisabledTopicsRemapped(t *testing.T) {
+	fb := newTestFeedback(t, "Setting up the SDK took a day")
+	other := newTestFeedback(t, "Support never answered")
+	output := analysisOutput(
+		t, []TopicResponse{
+			{
+				TopicEnum:   string(analysis.TopicCustomerSupportCommunity),
+				Summary:     "Support is slow",
+				FeedbackIDs: []string{other.ID().String()},
+				Sentiment:   "negative",
+			},
+			{
+				TopicEnum:   string(analysis.TopicInstallationSetupDeployment),
+				Summary:     "Setup is hard",
+				FeedbackIDs: []string{fb.ID().String()},
+				Sentiment:   "negative",
+			},
+			{
+				TopicEnum:   string(analysis.TopicDeveloperExperience),
+				Summary:     "The SDK is hard to use",
+				FeedbackIDs: []string{fb.ID().String()},
+				Sentiment:   "mixed",
+			},
+		},
+	)
+	client := newTestClient(
+		t,
+		respondWith(http.StatusOK, responsesBody(t, output)),
+		WithEnabledTopics(
+			[]analysis.Topic{analysis.TopicCompatibilityIntegration, analysis.TopicDeveloperExperience},
+			DisabledTopicRemap,
+		),
+	)
+
+	result, err := client.AnalyzeFeedbacks(context.Background(), []*feedback.Feedback{fb, other}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Support is merged into the developer experience topic returned by the model, installation has no
+	// counterpart in the response and becomes a compatibility topic of its own
+	if len(result.Topics) != 2 {
+		t.Fatalf("Expected 2 topics, got %+v", result.Topics)
+	}
+	dx := result.Topics[0]
+	if dx.Topic != analysis.TopicDeveloperExperience || dx.Summary != "The SDK is hard to use" ||
+		dx.Sentiment != analysis.SentimentMixed {
+		t.Errorf("Expected the developer experience topic to keep its summary and sentiment, got %+v", dx)
+	}
+	if len(dx.FeedbackIDs) != 2 {
+		t.Errorf("Expected the support feedback to be merged into developer experience, got %v", dx.FeedbackIDs)
+	}
+	compatibility := result.Topics[1]
+	if compatibility.Topic != analysis.TopicCompatibilityIntegration || compatibility.Summary != "Setup is hard" {
+		t.Errorf("Expected installation to be remapped to compatibility, got %+v", compatibility)
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_DuplicateTopics(t *testing.T) {
+	fb := newTestFeedback(t, "Checkout is slow")
+	other := newTestFeedback(t, "Search times out")
+	output := analysisOutput(
+		t, []TopicResponse{
+			{
+				TopicEnum:   string(analysis.TopicPerformanceReliability),
+				Summary:     "Checkout is slow.",
+				FeedbackIDs: []string{fb.ID().String()},
+				Sentiment:   "negative",
+			},
+			{
+				TopicEnum:   string(analysis.TopicPerformanceReliability),
+				Summary:     "Search times out.",
+				FeedbackIDs: []string{other.ID().String(), fb.ID().String()},
+				Sentiment:   "mixed",
+			},
+		},
+	)
+	feedbacks := []*feedback.Feedback{fb, other}
+
+	t.Run(
+		"merged", func(t *testing.T) {
+			client := newTestClient(
+				t,
+				respondWith(http.StatusOK, responsesBody(t, output)),
+				WithDuplicateTopicMerging(true),
+			)
+
+			result, err := client.AnalyzeFeedbacks(context.Background(), feedbacks, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(result.Topics) != 1 {
+				t.Fatalf("Expected the duplicates to be merged into 1 topic, got %+v", result.Topics)
+			}
+			topic := result.Topics[0]
+			if topic.Summary != "Checkout is slow. Search times out." {
+				t.Errorf("Expected the summaries to be joined, got %q", topic.Summary)
+			}
+			if len(topic.FeedbackIDs) != 2 {
+				t.Errorf("Expected the union of 2 feedback IDs, got %v", topic.FeedbackIDs)
+			}
+			if topic.Sentiment != analysis.SentimentMixed {
+				t.Errorf("Expected disagreeing sentiments to combine to mixed, got %s", topic.Sentiment)
+			}
+		},
+	)
+
+	t.Run(
+		"kept", func(t *testing.T) {
+			client := newTestClient(t, respondWith(http.StatusOK, responsesBody(t, output)))
+
+			result, err := client.AnalyzeFeedbacks(context.Background(), feedbacks, nil, nil, nil)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if len(result.Topics) != 2 {
+				t.Errorf("Expected the duplicates to be kept without merging, got %d topics", len(result.Topics))
+			}
+		},
+	)
+}
+
+func TestAnalysisSchema_EnabledTopics(t *testing.T) {
+	schema := AnalysisSchema([]analysis.Topic{analysis.TopicUIUX, analysis.TopicPricingLicensing}, false, false)
+
+	topics := schema["properties"].(Map)["topics"].(Map)["items"].(Map)["properties"].(Map)
+	enum := topics["topic_enum"].(Map)["enum"].([]any)
+	if len(enum) != 2 || enum[0] != "ui_ux" || enum[1] != "pricing_licensing" {
+		t.Errorf("Expected the topic enum to only hold the enabled topics, got %v", enum)
+	}
+}
+
+func TestAnalysisSchema_Recommendations(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		items := AnalysisSchema(analysis.AllTopics(), enabled, false)["properties"].(Map)["topics"].(Map)["items"].(Map)
+
+		_, hasProperty := items["properties"].(Map)["recommendations"]
+		hasRequired := false
+		for _, name := range items["required"].([]any) {
+			if name == "recommendations" {
+				hasRequired = true
+			}
+		}
+		if hasProperty != enabled || hasRequired != enabled {
+			t.Errorf(
+				"Recommendations enabled %t: expected property and required entry %t, got %t and %t",
+				enabled, enabled, hasProperty, hasRequired,
+			)
+		}
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_Recommendations(t *testing.T) {
+	fb := newTestFeedback(t, "Exports are missing")
+	other := newTestFeedback(t, "The UI is great")
+	output := analysisOutput(
+		t, []TopicResponse{
+			{
+				TopicEnum:       string(analysis.TopicProductFunctionalityFeatures),
+				Summary:         "Users want exports",
+				FeedbackIDs:     []string{fb.ID().String()},
+				Sentiment:       "negative",
+				Recommendations: []string{" Add a CSV export ", "", "Add a CSV export"},
+			},
+			{
+				TopicEnum:   string(analysis.TopicUIUX),
+				Summary:     "Users like the UI",
+				FeedbackIDs: []string{other.ID().String()},
+				Sentiment:   "positive",
+			},
+		},
+	)
+	client := newTestClient(
+		t,
+		respondWith(http.StatusOK, responsesBody(t, output)),
+		WithTopicRecommendations(true),
+	)
+
+	result, err := client.AnalyzeFeedbacks(context.Background(), []*feedback.Feedback{fb, other}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(result.Topics) != 2 {
+		t.Fatalf("Expected 2 topics, got %d", len(result.Topics))
+	}
+	if got := result.Topics[0].Recommendations; len(got) != 1 || got[0] != "Add a CSV export" {
+		t.Errorf("Expected the recommendations to be trimmed and deduplicated, got %q", got)
+	}
+	if got := result.Topics[1].Recommendations; len(got) != 0 {
+		t.Errorf("Expected no recommendations for a null list, got %q", got)
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_Confidence(t *testing.T) {
+	confident, outOfRange := 0.85, 1.5
+	fb := newTestFeedback(t, "Exports are missing")
+	other := newTestFeedback(t, "The UI is great")
+	output := analysisOutput(
+		t, []TopicResponse{
+			{
+				TopicEnum:   string(analysis.TopicProductFunctionalityFeatures),
+				Summary:     "Users want exports",
+				FeedbackIDs: []string{fb.ID().String()},
+				Sentiment:   "negative",
+				Confidence:  &confident,
+			},
+			{
+				TopicEnum:   string(analysis.TopicUIUX),
+				Summary:     "Users like the UI",
+				FeedbackIDs: []string{other.ID().String()},
+				Sentiment:   "positive",
+				Confidence:  &outOfRange,
+			},
+		},
+	)
+
+	var gotRequest map[string]any
+	client := newTestClient(
+		t, func(w http.ResponseWriter, r *http.Request) {
+			raw, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(raw, &gotRequest)
+			respondWith(http.StatusOK, responsesBody(t, output))(w, r)
+		},
+		WithTopicConfidence(true),
+	)
+
+	result, err := client.AnalyzeFeedbacks(context.Background(), []*feedback.Feedback{fb, other}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	topicSchema := gotRequest["text"].(map[string]any)["format"].(map[string]any)["schema"].(map[string]any)
+	items := topicSchema["properties"].(map[string]any)["topics"].(map[string]any)["items"].(map[string]any)
+	if _, ok := items["properties"].(map[string]any)["confidence"]; !ok {
+		t.Errorf("Expected the schema to request a confidence per topic")
+	}
+	if len(result.Topics) != 2 {
+		t.Fatalf("Expected 2 topics, got %d", len(result.Topics))
+	}
+	if got := result.Topics[0].Confidence; got.IsNone() || got.Unwrap() != confident {
+		t.Errorf("Expected confidence %v, got %+v", confident, got)
+	}
+	if got := result.Topics[1].Confidence; got.IsSome() {
+		t.Errorf("Expected an out of range confidence to be ignored, got %v", got.Unwrap())
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_InvalidFeedbackIDSkipped(t *testing.T) {
+	fb := newTestFeedback(t, "Pricing is confusing")
+	output := analysisOutput(
+		t, []TopicResponse{
+			{
+				TopicEnum:   string(analysis.TopicPricingLicensing),
+				Summary:     "Pricing tiers are unclear",
+				FeedbackIDs: []string{"not-a-uuid", fb.ID().String()},
+				Sentiment:   "negative",
+			},
+		},
+	)
+	client := newTestClient(t, respondWith(http.StatusOK, responsesBody(t, output)))
+
+	result, err := client.AnalyzeFeedbacks(context.Background(), []*feedback.Feedback{fb}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(result.Topics) != 1 {
+		t.Fatalf("Expected 1 topic, got %d", len(result.Topics))
+	}
+	if got := result.Topics[0].FeedbackIDs; len(got) != 1 || got[0] != fb.ID() {
+		t.Errorf("Expected only the valid feedback ID to be kept, got %v", got)
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_InvalidSentimentFallback(t *testing.T) {
+	fb := newTestFeedback(t, "Pricing is confusing")
+	output, err := json.Marshal(
+		AnalysisResponse{
+			OverallSummary: "Users dislike the pricing",
+			Sentiment:      "",
+			KeyInsights:    []string{},
+			Topics: []TopicResponse{
+				{
+					TopicEnum:   string(analysis.TopicPricingLicensing),
+					Summary:     "Pricing tiers are unclear",
+					FeedbackIDs: []string{fb.ID().String()},
+					Sentiment:   "angry",
+				},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to marshal analysis output: %v", err)
+	}
+	client := newTestClient(
+		t,
+		respondWith(http.StatusOK, responsesBody(t, string(output))),
+		WithFallbackSentiment(analysis.SentimentNegative),
+	)
+
+	result, err := client.AnalyzeFeedbacks(context.Background(), []*feedback.Feedback{fb}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if result.Sentiment != analysis.SentimentNegative {
+		t.Errorf("Expected the empty overall sentiment to fall back to %q, got %q", analysis.SentimentNegative, result.Sentiment)
+	}
+	if len(result.Topics) != 1 || result.Topics[0].Sentiment != analysis.SentimentNegative {
+		t.Errorf("Expected the unknown topic sentiment to fall back to %q, got %+v", analysis.SentimentNegative, result.Topics)
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_SentimentDistribution(t *testing.T) {
+	fb := newTestFeedback(t, "Pricing is confusing")
+	topic := func(topicEnum analysis.Topic, distribution *SentimentDistributionResponse) TopicResponse {
+		return TopicResponse{
+			TopicEnum:             string(topicEnum),
+			Summary:               "Summary",
+			FeedbackIDs:           []string{fb.ID().String()},
+			Sentiment:             "negative",
+			SentimentDistribution: distribution,
+		}
+	}
+	output, err := json.Marshal(
+		AnalysisResponse{
+			OverallSummary: "Users dislike the pricing",
+			Sentiment:      "negative",
+			KeyInsights:    []string{},
+			Topics: []TopicResponse{
+				topic(analysis.TopicPricingLicensing, &SentimentDistributionResponse{Positive: 1, Mixed: 2, Negative: 3}),
+				topic(analysis.TopicUIUX, nil),
+				topic(analysis.TopicSecurityPrivacy, &SentimentDistributionResponse{Positive: -1, Negative: 2}),
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to marshal analysis output: %v", err)
+	}
+	client := newTestClient(t, respondWith(http.StatusOK, responsesBody(t, string(output))))
+
+	result, err := client.AnalyzeFeedbacks(context.Background(), []*feedback.Feedback{fb}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(result.Topics) != 3 {
+		t.Fatalf("Expected 3 topics, got %d", len(result.Topics))
+	}
+
+	want := analysis.SentimentDistribution{Positive: 1, Mixed: 2, Negative: 3}
+	if got := result.Topics[0].SentimentDistribution; got.IsNone() || got.Unwrap() != want {
+		t.Errorf("Expected sentiment distribution %+v, got %+v", want, got)
+	}
+	for _, topic := range result.Topics[1:] {
+		if topic.SentimentDistribution.IsSome() {
+			t.Errorf("Expected a missing or invalid distribution to be dropped for %s, got %+v", topic.Topic, topic.SentimentDistribution.Unwrap())
+		}
+		if topic.Sentiment != analysis.SentimentNegative {
+			t.Errorf("Expected topic %s to keep its sentiment label, got %q", topic.Topic, topic.Sentiment)
+		}
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_CorrelationHeaders(t *testing.T) {
+	fb := newTestFeedback(t, "Onboarding was quick")
+	output := analysisOutput(t, nil)
+
+	var received http.Header
+	client := newTestClient(
+		t,
+		func(w http.ResponseWriter, r *http.Request) {
+			received = r.Header.Clone()
+			respondWith(http.StatusOK, responsesBody(t, output))(w, r)
+		},
+		WithCorrelationHeaders(CorrelationHeaders{TraceID: "X-Trace-ID", RequestID: "X-Request-ID"}),
+	)
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("llm-test").Start(context.Background(), "analysis")
+	defer span.End()
+	ctx = requestid.NewContext(ctx, "req-123")
+
+	if _, err := client.AnalyzeFeedbacks(ctx, []*feedback.Feedback{fb}, nil, nil, nil); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got, want := received.Get("X-Trace-ID"), span.SpanContext().TraceID().String(); got != want {
+		t.Errorf("Expected trace ID header %q, got %q", want, got)
+	}
+	if got := received.Get("X-Request-ID"); got != "req-123" {
+		t.Errorf("Expected request ID header %q, got %q", "req-123", got)
+	}
+
+	// Scheduled analyses have no request ID
+	if _, err := client.AnalyzeFeedbacks(context.Background(), []*feedback.Feedback{fb}, nil, nil, nil); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if got := received.Get("X-Request-ID"); got != "" {
+		t.Errorf("Expected no request ID header without a request ID, got %q", got)
+	}
+}
+
+func TestOpenAIClient_ExtractOutputText_NoOutput(t *testing.T) {
+	client := NewOpenAIClient("test-key", testModel, 0, newTestLogger(t))
+
+	_, err := client.extractOutputText(APIResponse{Output: []OutputItem{{Type: "reasoning"}}})
+	if err == nil {
+		t.Error("Expected error when the response has no output_text")
+	}
+}
+
+func TestOpenAIClient_BuildRequestBody(t *testing.T) {
+	client := NewOpenAIClient("test-key", testModel, 0, newTestLogger(t))
+
+	raw, err := client.buildRequestBody(Map{"feedbacks": []Map{}}, uuid.Nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	var body struct {
+		Model string `json:"model"`
+		Input []struct {
+			Role    string `json:"role"`
+			Content string `json:"content"`
+		} `json:"input"`
+		Text struct {
+			Format struct {
+				Type   string `json:"type"`
+				Strict bool   `json:"strict"`
+			} `json:"format"`
+		} `json:"text"`
+	}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		t.Fatalf("Expected request body to be valid JSON, got: %v", err)
+	}
+
+	if body.Model != testModel {
+		t.Errorf("Expected model %q, got %q", testModel, body.Model)
+	}
+	if len(body.Input) != 2 || body.Input[0].Role != "system" || body.Input[1].Role != "user" {
+		t.Errorf("Expected system and user input messages, got %+v", body.Input)
+	}
+	if body.Input[1].Content != `{"feedbacks":[]}` {
+		t.Errorf("Expected user message to carry the payload, got %q", body.Input[1].Content)
+	}
+	if body.Text.Format.Type != "json_schema" || !body.Text.Format.Strict {
+		t.Errorf("Expected strict json_schema output format, got %+v", body.Text.Format)
+	}
+}
+
+func TestOpenAIClient_BuildRequestBody_Sampling(t *testing.T) {
+	temperature, topP, seed := 0.0, 0.9, int64(42)
+	sampling := Sampling{Temperature: &temperature, TopP: &topP, Seed: &seed}
+
+	tests := []struct {
+		name     string
+		opts     []ClientOption
+		expected map[string]any
+		absent   []string
+	}{
+		{
+			name:   "unset parameters are omitted",
+			absent: []string{"temperature", "top_p", "seed"},
+		},
+		{
+			name:     "responses api has no seed",
+			opts:     []ClientOption{WithSampling(sampling)},
+			expected: map[string]any{"temperature": 0.0, "top_p": 0.9},
+			absent:   []string{"seed"},
+		},
+		{
+			name:     "chat completions api",
+			opts:     []ClientOption{WithSampling(sampling), WithAPIStyle(APIStyleChatCompletions)},
+			expected: map[string]any{"temperature": 0.0, "top_p": 0.9, "seed": 42.0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				client := NewOpenAIClient("test-key", testModel, 0, newTestLogger(t), tt.opts...)
+
+				raw, err := client.buildRequestBody(Map{"feedbacks": []Map{}}, uuid.Nil)
+				if err != nil {
+					t.Fatalf("Expected no error, got: %v", err)
+				}
+				var body map[string]any
+				if err := json.Unmarshal(raw, &body); err != nil {
+					t.Fatalf("Expected request body to be valid JSON, got: %v", err)
+				}
+
+				for key, value := range tt.expected {
+					if body[key] != value {
+						t.Errorf("Expected %s %v, got %v", key, value, body[key])
+					}
+				}
+				for _, key := range tt.absent {
+					if _, ok := body[key]; ok {
+						t.Errorf("Expected %s to be omitted, got %v", key, body[key])
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestOpenAIClient_BuildRequestBody_Audit(t *testing.T) {
+	store := false
+	analysisID := uuid.New()
+
+	for _, style := range []APIStyle{APIStyleResponses, APIStyleChatCompletions} {
+		t.Run(
+			string(style), func(t *testing.T) {
+				client := NewOpenAIClient(
+					"test-key", testModel, 0, newTestLogger(t),
+					WithAPIStyle(style),
+					WithAudit(Audit{Store: &store, Metadata: map[string]string{"environment": "staging"}}),
+				)
+
+				raw, err := client.buildRequestBody(Map{"feedbacks": []Map{}}, analysisID)
+				if err != nil {
+					t.Fatalf("Expected no error, got: %v", err)
+				}
+				var body struct {
+					Store    *bool             `json:"store"`
+					Metadata map[string]string `json:"metadata"`
+				}
+				if err := json.Unmarshal(raw, &body); err != nil {
+					t.Fatalf("Expected request body to be valid JSON, got: %v", err)
+				}
+
+				if body.Store == nil || *body.Store {
+					t.Errorf("Expected store to be false, got %v", body.Store)
+				}
+				if body.Metadata["environment"] != "staging" || body.Metadata["analysis_id"] != analysisID.String() {
+					t.Errorf("Expected the configured metadata and the analysis ID, got %v", body.Metadata)
+				}
+			},
+		)
+	}
+}
+
+func TestOpenAIClient_BuildRequestBody_NoAudit(t *testing.T) {
+	client := NewOpenAIClient("test-key", testModel, 0, newTestLogger(t))
+
+	raw, err := client.buildRequestBody(Map{"feedbacks": []Map{}}, uuid.Nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(raw, &body); err != nil {
+		t.Fatalf("Expected request body to be valid JSON, got: %v", err)
+	}
+
+	for _, key := range []string{"store", "metadata"} {
+		if _, ok := body[key]; ok {
+			t.Errorf("Expected %s to be omitted, got %v", key, body[key])
+		}
+	}
+}
+
+func TestOpenAIClient_BuildUserPayload_ScrubsComments(t *testing.T) {
+	scrubber, err := pii.NewScrubber(nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to create scrubber: %v", err)
+	}
+	client := NewOpenAIClient("test-key", testModel, 0, newTestLogger(t), WithCommentScrubber(scrubber))
+
+	payload := client.buildUserPayload(
+		[]*feedback.Feedback{newTestFeedback(t, "Reach me at jane@example.com")},
+		nil,
+		nil,
+		nil,
+	)
+
+	items := payload["feedbacks"].([]Map)
+	if got := items[0]["comment"]; got != "Reach me at [EMAIL]" {
+		t.Errorf("Expected the email to be masked, got %q", got)
+	}
+}
+
+func TestOpenAIClient_BuildUserPayload_PayloadFields(t *testing.T) {
+	fb := newTestFeedback(t, "Crashes on my phone")
+
+	defaultClient := NewOpenAIClient("test-key", testModel, 0, newTestLogger(t))
+	payload := defaultClient.buildUserPayload([]*feedback.Feedback{fb}, nil, nil, nil)
+	item := payload["feedbacks"].([]Map)[0]
+	if _, ok := item["rating"]; !ok {
+		t.Error("Expected rating to be sent by default")
+	}
+	if _, ok := item["created_at"]; ok {
+		t.Error("Expected created_at not to be sent by default")
+	}
+
+	client := NewOpenAIClient(
+		"test-key", testModel, 0, newTestLogger(t),
+		WithPayloadFields([]PayloadField{PayloadFieldCreatedAt}),
+	)
+	payload = client.buildUserPayload([]*feedback.Feedback{fb}, nil, nil, nil)
+	item = payload["feedbacks"].([]Map)[0]
+	if _, ok := item["rating"]; ok {
+		t.Error("Expected rating not to be sent when it is not configured")
+	}
+	if _, ok := payload["rating_scale"]; ok {
+		t.Error("Expected rating scale not to be sent without ratings")
+	}
+	if _, ok := item["created_at"]; !ok {
+		t.Error("Expected created_at to be sent when configured")
+	}
+	if item["id"] != fb.ID().String() || item["comment"] != "Crashes on my phone" {
+		t.Errorf("Expected id and comment to always be sent, got %+v", item)
+	}
+}
+
+func TestOpenAIClient_BuildUserPayload_Metadata(t *testing.T) {
+	fb, err := feedback.NewBuilder().
+		WithMetadataValues("2.4.1", "android", "").
+		BuildNew(uuid.New(), 2, "App crashes after the update")
+	if err != nil {
+		t.Fatalf("Failed to build feedback: %v", err)
+	}
+
+	defaultClient := NewOpenAIClient("test-key", testModel, 0, newTestLogger(t))
+	item := defaultClient.buildUserPayload([]*feedback.Feedback{fb}, nil, nil, nil)["feedbacks"].([]Map)[0]
+	if _, ok := item["metadata"]; ok {
+		t.Error("Expected metadata not to be sent by default")
+	}
+
+	client := NewOpenAIClient(
+		"test-key", testModel, 0, newTestLogger(t),
+		WithPayloadFields([]PayloadField{PayloadFieldMetadata}),
+	)
+	item = client.buildUserPayload([]*feedback.Feedback{fb}, nil, nil, nil)["feedbacks"].([]Map)[0]
+	metadata, ok := item["metadata"].(Map)
+	if !ok {
+		t.Fatalf("Expected metadata to be sent when configured, got %+v", item)
+	}
+	if metadata["app_version"] != "2.4.1" || metadata["platform"] != "android" {
+		t.Errorf("Unexpected metadata payload: %+v", metadata)
+	}
+	if _, ok := metadata["os"]; ok {
+		t.Error("Expected unset os to be omitted")
+	}
+}
+
+func TestOpenAIClient_BuildUserPayload_TranslatedComment(t *testing.T) {
+	fb, err := feedback.NewBuilder().
+		WithTranslation("The export is far too slow", "de").
+		BuildNew(uuid.New(), 2, "Der Export ist viel zu langsam")
+	if err != nil {
+		t.Fatalf("Failed to build feedback: %v", err)
+	}
+
+	client := NewOpenAIClient("test-key", testModel, 0, newTestLogger(t))
+	item := client.buildUserPayload([]*feedback.Feedback{fb}, nil, nil, nil)["feedbacks"].([]Map)[0]
+	if got := item["comment"]; got != "The export is far too slow" {
+		t.Errorf("Expected the translated comment to be sent, got %q", got)
+	}
+}
+
+func TestParsePayloadFields(t *testing.T) {
+	fields, err := ParsePayloadFields(nil)
+	if err != nil || len(fields) != len(DefaultPayloadFields) {
+		t.Errorf("Expected default payload fields for an empty list, got %v (%v)", fields, err)
+	}
+
+	if _, err := ParsePayloadFields([]string{"language"}); err == nil {
+		t.Error("Expected error for an unknown payload field")
+	}
+}
+
+func TestOpenAIClient_BuildUserPayload_ContextFeedbacks(t *testing.T) {
+	client := NewOpenAIClient("test-key", testModel, 0, newTestLogger(t))
+
+	payload := client.buildUserPayload(
+		[]*feedback.Feedback{newTestFeedback(t, "New")},
+		nil,
+		nil,
+		[]*feedback.Feedback{newTestFeedback(t, "Earlier")},
+	)
+
+	contextItems, ok := payload["context_feedbacks"].([]Map)
+	if !ok || len(contextItems) != 1 {
+		t.Fatalf("Expected one context feedback, got %v", payload["context_feedbacks"])
+	}
+	if _, hasID := contextItems[0]["id"]; hasID {
+		t.Error("Expected context feedbacks to be sent without IDs so they cannot be assigned to topics")
+	}
+	if contextItems[0]["comment"] != "Earlier" {
+		t.Errorf("Expected the context comment to be sent, got %v", contextItems[0]["comment"])
+	}
+}
+
+func TestOpenAIClient_AnalyzeFeedbacks_MaxKeyInsights(t *testing.T) {
+	fb := newTestFeedback(t, "Search is slow and export is missing")
+	output, err := json.Marshal(
+		AnalysisResponse{
+			OverallSummary: "Users want faster search and exports",
+			Sentiment:      "mixed",
+			KeyInsights:    []string{"Search is slow", "Export is missing", "Onboarding is fine"},
+			Topics:         []TopicResponse{},
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to marshal analysis output: %v", err)
+	}
+	client := newTestClient(t, respondWith(http.StatusOK, responsesBody(t, string(output))), WithMaxKeyInsights(2))
+
+	if prompt := client.buildSystemPrompt(); !strings.Contains(prompt, "Return at most 2 key insights") {
+		t.Errorf("Expected the system prompt to limit key insights, got:\n%s", prompt)
+	}
+
+	result, err := client.AnalyzeFeedbacks(context.Background(), []*feedback.Feedback{fb}, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	want := []string{"Search is slow", "Export is missing"}
+	if !slices.Equal(result.KeyInsights, want) {
+		t.Errorf("Expected the first key insights %v, got %v", want, result.KeyInsights)
+	}
+}
@@ -0,0 +1,392 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/http/requestid"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/pii"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+)
+
+// APIStyle selects which OpenAI API the client talks to.
+type APIStyle string
+
+const (
+	// APIStyleResponses targets the Responses API (POST /responses).
+	APIStyleResponses APIStyle = "responses"
+	// APIStyleChatCompletions targets the Chat Completions API (POST /chat/completions),
+	// for deployments that do not expose the Responses API.
+	APIStyleChatCompletions APIStyle = "chat_completions"
+)
+
+const (
+	// DefaultBaseURL is the public OpenAI API root.
+	DefaultBaseURL = "https://api.openai.com/v1"
+	// DefaultHTTPTimeout bounds a whole analysis request. Reasoning models can take minutes on large batches.
+	DefaultHTTPTimeout = 5 * time.Minute
+	// DefaultFallbackSentiment replaces empty or unknown sentiments returned by the model.
+	DefaultFallbackSentiment = analysis.SentimentMixed
+)
+
+// ParseAPIStyle converts a configuration value to an APIStyle.
+// An empty value resolves to APIStyleResponses.
+func ParseAPIStyle(value string) (APIStyle, error) {
+	switch APIStyle(value) {
+	case "", APIStyleResponses:
+		return APIStyleResponses, nil
+	case APIStyleChatCompletions:
+		return APIStyleChatCompletions, nil
+	default:
+		return "", fmt.Errorf("unknown api style: %q (supported: responses, chat_completions)", value)
+	}
+}
+
+// PayloadField is a feedback metadata field that can be sent to the LLM next to the id and comment.
+type PayloadField string
+
+const (
+	// PayloadFieldRating sends the feedback rating, together with the rating scale.
+	PayloadFieldRating PayloadField = "rating"
+	// PayloadFieldSource sends the channel the feedback was submitted through.
+	PayloadFieldSource PayloadField = "source"
+	// PayloadFieldCreatedAt sends the submission time of the feedback.
+	PayloadFieldCreatedAt PayloadField = "created_at"
+	// PayloadFieldMetadata sends the reporter's app version, platform and OS, where known.
+	PayloadFieldMetadata PayloadField = "metadata"
+)
+
+// DefaultPayloadFields are sent if no payload fields are configured.
+var DefaultPayloadFields = []PayloadField{PayloadFieldRating, PayloadFieldSource}
+
+// ParsePayloadFields converts configuration values to payload fields.
+// An empty list resolves to DefaultPayloadFields.
+func ParsePayloadFields(values []string) ([]PayloadField, error) {
+	if len(values) == 0 {
+		return DefaultPayloadFields, nil
+	}
+
+	fields := make([]PayloadField, 0, len(values))
+	for _, value := range values {
+		switch field := PayloadField(strings.TrimSpace(value)); field {
+		case PayloadFieldRating, PayloadFieldSource, PayloadFieldCreatedAt, PayloadFieldMetadata:
+			fields = append(fields, field)
+		default:
+			return nil, fmt.Errorf(
+				"unknown payload field: %q (supported: rating, source, created_at, metadata)", value,
+			)
+		}
+	}
+	return fields, nil
+}
+
+// DisabledTopicPolicy decides what happens to a topic returned by the model that is valid but not enabled.
+type DisabledTopicPolicy string
+
+const (
+	// DisabledTopicDrop drops the topic, logging a warning.
+	DisabledTopicDrop DisabledTopicPolicy = "drop"
+	// DisabledTopicRemap reassigns the feedbacks of the topic to its closest enabled related topic,
+	// falling back to dropping if none of its related topics is enabled.
+	DisabledTopicRemap DisabledTopicPolicy = "remap"
+)
+
+// ParseDisabledTopicPolicy converts a configuration value to a DisabledTopicPolicy.
+// An empty value resolves to DisabledTopicDrop.
+func ParseDisabledTopicPolicy(value string) (DisabledTopicPolicy, error) {
+	switch DisabledTopicPolicy(value) {
+	case "", DisabledTopicDrop:
+		return DisabledTopicDrop, nil
+	case DisabledTopicRemap:
+		return DisabledTopicRemap, nil
+	default:
+		return "", fmt.Errorf("unknown disabled topic policy: %q (supported: drop, remap)", value)
+	}
+}
+
+// ParseEnabledTopics converts configuration values to topics. An empty list resolves to all topics.
+func ParseEnabledTopics(values []string) ([]analysis.Topic, error) {
+	if len(values) == 0 {
+		return analysis.AllTopics(), nil
+	}
+
+	topics := make([]analysis.Topic, 0, len(values))
+	for _, value := range values {
+		topic := analysis.Topic(strings.TrimSpace(value))
+		if !topic.IsValid() {
+			return nil, fmt.Errorf("unknown topic: %q", value)
+		}
+		topics = append(topics, topic)
+	}
+	return topics, nil
+}
+
+// Sampling holds the optional sampling parameters of a request. Unset (nil) parameters are omitted
+// from the request body, so the model defaults apply.
+type Sampling struct {
+	Temperature *float64
+	TopP        *float64
+	// Seed is only sent to the Chat Completions API; the Responses API has no seed parameter.
+	Seed *int64
+}
+
+// apply adds the set sampling parameters to a request body built for the given API style.
+func (s Sampling) apply(body Map, style APIStyle) {
+	if s.Temperature != nil {
+		body["temperature"] = *s.Temperature
+	}
+	if s.TopP != nil {
+		body["top_p"] = *s.TopP
+	}
+	if s.Seed != nil && style == APIStyleChatCompletions {
+		body["seed"] = *s.Seed
+	}
+}
+
+// AnalysisIDMetadataKey is the metadata key the ID of the analysis a request is made for is sent under.
+const AnalysisIDMetadataKey = "analysis_id"
+
+// Audit holds the fields that make requests traceable in the provider's dashboard and logs.
+type Audit struct {
+	// Store sets whether the provider stores the requests and their responses. Nil keeps the provider default.
+	Store *bool
+	// Metadata is sent with every request, e.g. the environment, next to the ID of the analysis.
+	Metadata map[string]string
+}
+
+// apply adds the store flag and the metadata to a request body. The analysis ID is omitted if unknown.
+func (a Audit) apply(body Map, analysisID uuid.UUID) {
+	if a.Store != nil {
+		body["store"] = *a.Store
+	}
+
+	metadata := make(map[string]string, len(a.Metadata)+1)
+	for key, value := range a.Metadata {
+		metadata[key] = value
+	}
+	if analysisID != uuid.Nil {
+		metadata[AnalysisIDMetadataKey] = analysisID.String()
+	}
+	if len(metadata) > 0 {
+		body["metadata"] = metadata
+	}
+}
+
+// ClientOption configures an OpenAIClient.
+type ClientOption func(*OpenAIClient)
+
+// WithAPIStyle selects the API the client builds requests for and parses responses from.
+func WithAPIStyle(style APIStyle) ClientOption {
+	return func(c *OpenAIClient) {
+		c.apiStyle = style
+	}
+}
+
+// WithBaseURL overrides the API root, e.g. for proxies or Azure OpenAI deployments.
+// An empty value keeps DefaultBaseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *OpenAIClient) {
+		if baseURL != "" {
+			c.baseURL = strings.TrimRight(baseURL, "/")
+		}
+	}
+}
+
+// WithHTTPClient sets the HTTP client used to call the API, e.g. to customize its transport or timeout.
+// A nil client keeps the default one.
+func WithHTTPClient(client *http.Client) ClientOption {
+	return func(c *OpenAIClient) {
+		if client != nil {
+			c.httpClient = client
+		}
+	}
+}
+
+// WithConcurrencyLimiter bounds the number of concurrent API requests. Pass the same limiter to several
+// clients to share the bound between them. A nil limiter keeps the default, unbounded one.
+func WithConcurrencyLimiter(limiter *ConcurrencyLimiter) ClientOption {
+	return func(c *OpenAIClient) {
+		if limiter != nil {
+			c.limiter = limiter
+		}
+	}
+}
+
+// WithCircuitBreaker stops sending requests while the provider keeps failing, see CircuitBreaker.
+// A nil breaker keeps the default, disabled one.
+func WithCircuitBreaker(breaker *CircuitBreaker) ClientOption {
+	return func(c *OpenAIClient) {
+		if breaker != nil {
+			c.breaker = breaker
+		}
+	}
+}
+
+// WithPayloadFields selects the feedback metadata sent to the LLM. An empty list keeps DefaultPayloadFields.
+func WithPayloadFields(fields []PayloadField) ClientOption {
+	return func(c *OpenAIClient) {
+		if len(fields) > 0 {
+			c.payloadFields = fields
+		}
+	}
+}
+
+// WithFallbackSentiment sets the sentiment used when the model returns an empty or unknown sentiment
+// for the analysis or a topic. An invalid sentiment keeps DefaultFallbackSentiment.
+func WithFallbackSentiment(sentiment analysis.Sentiment) ClientOption {
+	return func(c *OpenAIClient) {
+		if sentiment.IsValid() {
+			c.fallbackSentiment = sentiment
+		}
+	}
+}
+
+// WithEnabledTopics restricts the topics offered to the model in the prompt and the output schema.
+// Valid topics outside the set that the model returns anyway are handled according to the policy,
+// for providers that do not strictly honor the schema. An empty set keeps all topics enabled.
+func WithEnabledTopics(topics []analysis.Topic, policy DisabledTopicPolicy) ClientOption {
+	return func(c *OpenAIClient) {
+		if len(topics) > 0 {
+			c.enabledTopics = topics
+		}
+		c.disabledTopicPolicy = policy
+	}
+}
+
+// WithMaxSummaryLength sets the number of characters overall and topic summaries are truncated to,
+// at a sentence boundary where possible. A non-positive length keeps DefaultMaxSummaryLength.
+func WithMaxSummaryLength(length int) ClientOption {
+	return func(c *OpenAIClient) {
+		if length > 0 {
+			c.maxSummaryLength = length
+		}
+	}
+}
+
+// WithMaxKeyInsights sets the number of key insights the model is asked for. Responses with more insights are
+// truncated to the first ones. A non-positive number keeps all insights.
+func WithMaxKeyInsights(count int) ClientOption {
+	return func(c *OpenAIClient) {
+		c.maxKeyInsights = count
+	}
+}
+
+// WithTopicRecommendations asks the model for concrete recommended actions per topic.
+// The field is nullable in the output schema, so responses without recommendations still validate.
+func WithTopicRecommendations(enabled bool) ClientOption {
+	return func(c *OpenAIClient) {
+		c.topicRecommendations = enabled
+	}
+}
+
+// WithTopicConfidence asks the model for a calibrated confidence between 0 and 1 per topic.
+// Like recommendations, the field is nullable in the output schema.
+func WithTopicConfidence(enabled bool) ClientOption {
+	return func(c *OpenAIClient) {
+		c.topicConfidence = enabled
+	}
+}
+
+// WithDuplicateTopicMerging merges topics the model returns more than once in a response, so that an analysis
+// never gets two topics of the same enum.
+func WithDuplicateTopicMerging(enabled bool) ClientOption {
+	return func(c *OpenAIClient) {
+		c.mergeDuplicateTopics = enabled
+	}
+}
+
+// WithSampling sets the temperature, top_p and seed sent with every request.
+func WithSampling(sampling Sampling) ClientOption {
+	return func(c *OpenAIClient) {
+		c.sampling = sampling
+	}
+}
+
+// WithAudit sets the store flag and the metadata sent with every request.
+func WithAudit(audit Audit) ClientOption {
+	return func(c *OpenAIClient) {
+		c.audit = audit
+	}
+}
+
+// WithCommentScrubber masks PII in comments before they are placed in the request payload.
+// This also covers comments stored before ingestion-time scrubbing was enabled. A nil scrubber disables it.
+func WithCommentScrubber(scrubber *pii.Scrubber) ClientOption {
+	return func(c *OpenAIClient) {
+		c.scrubber = scrubber
+	}
+}
+
+// WithRawOutputCapture returns the model output text in the analysis result, e.g. to store it for debugging.
+// The scrubber masks PII in the captured output, a nil scrubber keeps it unchanged.
+func WithRawOutputCapture(scrubber *pii.Scrubber) ClientOption {
+	return func(c *OpenAIClient) {
+		c.captureRawOutput = true
+		c.rawOutputScrubber = scrubber
+	}
+}
+
+// CorrelationHeaders names the headers of the outbound API requests that carry the current trace ID and
+// request ID, so that requests logged by an LLM gateway or proxy can be correlated with our traces.
+// An empty name disables the header.
+type CorrelationHeaders struct {
+	// TraceID names the header carrying the trace ID of the span in the request context.
+	TraceID string
+	// RequestID names the header carrying the ID of the HTTP request that started the analysis, if any.
+	RequestID string
+}
+
+// WithCorrelationHeaders adds the trace ID and request ID to every API request under the given header names.
+func WithCorrelationHeaders(headers CorrelationHeaders) ClientOption {
+	return func(c *OpenAIClient) {
+		c.correlationHeaders = headers
+	}
+}
+
+// setCorrelationHeaders sets the configured correlation headers on an API request.
+// Headers are omitted if the context has no recording span or no request ID, e.g. for scheduled analyses.
+func (c *OpenAIClient) setCorrelationHeaders(ctx context.Context, req *http.Request) {
+	if name := c.correlationHeaders.TraceID; name != "" {
+		if span := trace.SpanFromContext(ctx); span != nil && span.SpanContext().IsValid() {
+			req.Header.Set(name, span.SpanContext().TraceID())
+		}
+	}
+	if name := c.correlationHeaders.RequestID; name != "" {
+		if requestID := requestid.FromContext(ctx); requestID != "" {
+			req.Header.Set(name, requestID)
+		}
+	}
+}
+
+// endpoint returns the full URL of the analysis endpoint for the configured API style.
+func (c *OpenAIClient) endpoint() string {
+	if c.apiStyle == APIStyleChatCompletions {
+		return c.baseURL + "/chat/completions"
+	}
+	return c.baseURL + "/responses"
+}
+
+// isEnabled reports whether the topic is offered to the model.
+func (c *OpenAIClient) isEnabled(topic analysis.Topic) bool {
+	for _, t := range c.enabledTopics {
+		if t == topic {
+			return true
+		}
+	}
+	return false
+}
+
+// sendsField reports whether the given metadata field is included in the request payload.
+func (c *OpenAIClient) sendsField(field PayloadField) bool {
+	for _, f := range c.payloadFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+)
+
+// reduceSystemPrompt instructs the model to combine the partial analyses of one feedback batch.
+const reduceSystemPrompt = `Your task is to combine partial analyses of customer feedback into a single analysis.
+
+The feedback batch was too large for a single request, so it was split into chunks that were analyzed
+one after the other. Each entry of partial_analyses is the analysis of one chunk.
+
+INSTRUCTIONS:
+1. Provide for the whole batch:
+   - An overall summary combining the overall summaries of all partial analyses
+   - The overall sentiment (positive, mixed, or negative), weighing the partial analyses by their feedback_count
+   - Key insights as bullet points, merging insights that say the same thing
+
+2. For every topic_enum present in any partial analysis, provide exactly one topic with:
+   * The topic_enum value
+   * A summary combining the partial summaries of the topic
+   * The dominant sentiment of the topic, weighing the partial topics by their feedback_count
+
+3. Important rules:
+   - DO NOT add topics that are not present in the partial analyses
+   - Do not invent facts that are not in the partial analyses`
+
+// ReduceResponse represents the structured JSON response of the call combining chunk analyses.
+type ReduceResponse struct {
+	OverallSummary string                `json:"overall_summary"`
+	Sentiment      string                `json:"sentiment"`
+	KeyInsights    []string              `json:"key_insights"`
+	Topics         []ReduceTopicResponse `json:"topics"`
+}
+
+// ReduceTopicResponse represents a combined topic in the reduce response.
+type ReduceTopicResponse struct {
+	TopicEnum string `json:"topic_enum"`
+	Summary   string `json:"summary"`
+	Sentiment string `json:"sentiment"`
+}
+
+// ReduceAnalyses combines the results of analyses over consecutive chunks of one feedback batch.
+func (c *OpenAIClient) ReduceAnalyses(
+	ctx context.Context,
+	partials []*external.AnalysisResult,
+) (*external.AnalysisResult, error) {
+	ctx, spanLogger, span := c.logger.StartSpan(ctx, "llm.reduce")
+	defer span.End()
+
+	span.SetAttributes(
+		trace.Attribute{Key: "llm.model", Value: c.model},
+		trace.Attribute{Key: "llm.api_style", Value: string(c.apiStyle)},
+		trace.Attribute{Key: "llm.partial_count", Value: len(partials)},
+	)
+
+	startTime := time.Now()
+	result, err := c.reduceAnalyses(ctx, partials)
+	span.SetAttributes(trace.Attribute{Key: "llm.duration_ms", Value: time.Since(startTime).Milliseconds()})
+	if err != nil {
+		span.SetStatus(trace.StatusError, err.Error())
+		spanLogger.RecordSpanError(ctx, err)
+		return nil, err
+	}
+
+	span.SetStatus(trace.StatusOK, "Successfully reduced analyses")
+	return result, nil
+}
+
+// reduceAnalyses sends the reduce request to the API and parses its response.
+func (c *OpenAIClient) reduceAnalyses(
+	ctx context.Context,
+	partials []*external.AnalysisResult,
+) (*external.AnalysisResult, error) {
+	requestBody, err := c.buildStructuredRequestBody(
+		reduceSystemPrompt,
+		buildReducePayload(partials),
+		"feedback_analysis_reduce",
+		ReduceSchema(c.enabledTopics),
+		external.AnalysisIDFromContext(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request body: %w", err)
+	}
+
+	outputText, usage, err := c.complete(ctx, requestBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var reduceResp ReduceResponse
+	if err := json.Unmarshal([]byte(outputText), &reduceResp); err != nil {
+		return nil, withUsage(
+			usage, &external.InvalidOutputError{
+				RawOutput: c.rawOutput(outputText),
+				Err:       fmt.Errorf("model returned invalid JSON or schema mismatch: %w (raw: %s)", err, outputText),
+			},
+		)
+	}
+
+	// Only topics of the partial analyses can be combined, anything else has no feedbacks to back it
+	partialTopics := make(map[analysis.Topic]bool)
+	for _, partial := range partials {
+		for _, topic := range partial.Topics {
+			partialTopics[topic.Topic] = true
+		}
+	}
+
+	topics := make([]external.Topic, 0, len(reduceResp.Topics))
+	for _, t := range reduceResp.Topics {
+		topic := analysis.Topic(t.TopicEnum)
+		if !partialTopics[topic] {
+			c.logger.Warning("reduced topic not present in partial analyses, dropping", "topic_enum", t.TopicEnum)
+			continue
+		}
+		topics = append(
+			topics, external.Topic{
+				Topic:     topic,
+				Summary:   c.truncateSummary(t.Summary, t.TopicEnum),
+				Sentiment: c.sentimentOrFallback(t.Sentiment, t.TopicEnum),
+			},
+		)
+	}
+
+	return &external.AnalysisResult{
+		OverallSummary: c.truncateSummary(reduceResp.OverallSummary, "overall"),
+		Sentiment:      c.sentimentOrFallback(reduceResp.Sentiment, "overall"),
+		KeyInsights:    c.truncateKeyInsights(ctx, reduceResp.KeyInsights),
+		TokensUsed:     usage.TotalTokens,
+		Topics:         topics,
+		RawOutput:      c.rawOutput(outputText),
+		Usage:          usage.toExternal(),
+	}, nil
+}
+
+// buildReducePayload builds the user payload of the reduce request. Partial topics are sent with their
+// feedback count instead of their feedback IDs, which the model does not need to combine summaries.
+func buildReducePayload(partials []*external.AnalysisResult) Map {
+	items := make([]Map, len(partials))
+	for i, partial := range partials {
+		feedbackIDs := make(map[string]bool)
+		topics := make([]Map, len(partial.Topics))
+		for j, topic := range partial.Topics {
+			for _, id := range topic.FeedbackIDs {
+				feedbackIDs[id.String()] = true
+			}
+			topics[j] = Map{
+				"topic_enum":     string(topic.Topic),
+				"summary":        topic.Summary,
+				"sentiment":      string(topic.Sentiment),
+				"feedback_count": len(topic.FeedbackIDs),
+			}
+		}
+		items[i] = Map{
+			"overall_summary": partial.OverallSummary,
+			"sentiment":       string(partial.Sentiment),
+			"key_insights":    partial.KeyInsights,
+			"feedback_count":  len(feedbackIDs),
+			"topics":          topics,
+		}
+	}
+	return Map{"partial_analyses": items}
+}
@@ -0,0 +1,95 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+)
+
+func TestOpenAIClient_ReduceAnalyses(t *testing.T) {
+	partials := []*external.AnalysisResult{
+		{
+			OverallSummary: "Onboarding is smooth",
+			Sentiment:      analysis.SentimentPositive,
+			Topics: []external.Topic{
+				{
+					Topic:       analysis.TopicUIUX,
+					Summary:     "Onboarding flow is smooth",
+					Sentiment:   analysis.SentimentPositive,
+					FeedbackIDs: []uuid.UUID{uuid.New(), uuid.New()},
+				},
+			},
+		},
+		{
+			OverallSummary: "Exports are slow",
+			Sentiment:      analysis.SentimentNegative,
+			Topics: []external.Topic{
+				{
+					Topic:       analysis.TopicUIUX,
+					Summary:     "Export button is hard to find",
+					Sentiment:   analysis.SentimentNegative,
+					FeedbackIDs: []uuid.UUID{uuid.New()},
+				},
+			},
+		},
+	}
+	output, err := json.Marshal(
+		ReduceResponse{
+			OverallSummary: "Onboarding is smooth, exports are slow",
+			Sentiment:      "mixed",
+			KeyInsights:    []string{"Exports need work"},
+			Topics: []ReduceTopicResponse{
+				{TopicEnum: string(analysis.TopicUIUX), Summary: "Onboarding is smooth, exports are hidden", Sentiment: "mixed"},
+				// Not present in any partial analysis
+				{TopicEnum: string(analysis.TopicSecurityPrivacy), Summary: "Invented", Sentiment: "negative"},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("Failed to marshal reduce output: %v", err)
+	}
+
+	var gotPayload map[string][]map[string]any
+	client := newTestClient(
+		t, func(w http.ResponseWriter, r *http.Request) {
+			raw, _ := io.ReadAll(r.Body)
+			var request struct {
+				Input []struct {
+					Role    string `json:"role"`
+					Content string `json:"content"`
+				} `json:"input"`
+			}
+			if err := json.Unmarshal(raw, &request); err != nil || len(request.Input) != 2 {
+				t.Errorf("Expected a system and a user message, got error: %v", err)
+			} else if err := json.Unmarshal([]byte(request.Input[1].Content), &gotPayload); err != nil {
+				t.Errorf("Expected a JSON user payload, got error: %v", err)
+			}
+
+			respondWith(http.StatusOK, responsesBody(t, string(output)))(w, r)
+		},
+	)
+
+	result, err := client.ReduceAnalyses(context.Background(), partials)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if got := gotPayload["partial_analyses"]; len(got) != 2 || got[0]["feedback_count"] != float64(2) {
+		t.Errorf("Expected 2 partial analyses with their feedback counts, got %v", got)
+	}
+	if result.Sentiment != analysis.SentimentMixed {
+		t.Errorf("Expected mixed sentiment, got %q", result.Sentiment)
+	}
+	if result.TokensUsed != 150 {
+		t.Errorf("Expected 150 tokens used, got %d", result.TokensUsed)
+	}
+	if len(result.Topics) != 1 || result.Topics[0].Topic != analysis.TopicUIUX {
+		t.Fatalf("Expected only the topic of the partial analyses, got %+v", result.Topics)
+	}
+}
@@ -1,10 +1,80 @@
 package llm
 
+import "github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+
 type Map = map[string]any
 
 // AnalysisSchema creates a JSON schema for structured output from the LLM analysis.
-// The schema defines the expected structure of the analysis response.
-func AnalysisSchema() Map {
+// The schema defines the expected structure of the analysis response, restricting topic_enum to the given topics.
+// With recommendations, topics carry a nullable array of recommended actions, with confidence a nullable number.
+func AnalysisSchema(topics []analysis.Topic, recommendations, confidence bool) Map {
+	topicEnum := make([]any, len(topics))
+	for i, topic := range topics {
+		topicEnum[i] = string(topic)
+	}
+
+	topicProperties := Map{
+		"topic_enum": Map{
+			"type":        "string",
+			"enum":        topicEnum,
+			"description": "The predefined topic enum value that best categorizes this feedback",
+		},
+		"summary": Map{
+			"type":        "string",
+			"description": "Summary of the analysis for this topic - explaining why this feedback belongs to this topic and what specific aspects it addresses",
+		},
+		"feedback_ids": Map{
+			"type":        "array",
+			"description": "Array of feedback IDs that belong to this topic",
+			"items": Map{
+				"type": "string",
+			},
+		},
+		"sentiment": Map{
+			"type":        "string",
+			"enum":        []any{"positive", "mixed", "negative"},
+			"description": "Sentiment for this specific topic",
+		},
+		"sentiment_distribution": Map{
+			"type":        []any{"object", "null"},
+			"description": "Number of this topic's feedbacks per sentiment, or null if not counted",
+			"properties": Map{
+				"positive": Map{"type": "integer", "minimum": 0},
+				"mixed":    Map{"type": "integer", "minimum": 0},
+				"negative": Map{"type": "integer", "minimum": 0},
+			},
+			"required":             []any{"positive", "mixed", "negative"},
+			"additionalProperties": false,
+		},
+	}
+	topicRequired := []any{
+		"topic_enum",
+		"summary",
+		"feedback_ids",
+		"sentiment",
+		"sentiment_distribution",
+	}
+	if recommendations {
+		// Strict mode requires every property, so the field is nullable rather than optional
+		topicProperties["recommendations"] = Map{
+			"type":        []any{"array", "null"},
+			"description": "Concrete actions the team should take to address this topic's feedback, or null if none",
+			"items": Map{
+				"type": "string",
+			},
+		}
+		topicRequired = append(topicRequired, "recommendations")
+	}
+	if confidence {
+		topicProperties["confidence"] = Map{
+			"type":        []any{"number", "null"},
+			"description": "Calibrated confidence between 0 and 1 that the feedbacks belong to this topic, or null if unsure",
+			"minimum":     0,
+			"maximum":     1,
+		}
+		topicRequired = append(topicRequired, "confidence")
+	}
+
 	return Map{
 		"type": "object",
 		"properties": Map{
@@ -27,46 +97,68 @@ func AnalysisSchema() Map {
 			"topics": Map{
 				"type":        "array",
 				"description": "Array of topics/themes identified in the feedback. You MUST use one of the predefined topic enum values.",
+				"items": Map{
+					"type":                 "object",
+					"properties":           topicProperties,
+					"required":             topicRequired,
+					"additionalProperties": false,
+				},
+			},
+		},
+		"required":             []any{"overall_summary", "sentiment", "key_insights", "topics"},
+		"additionalProperties": false,
+	}
+}
+
+// ReduceSchema creates a JSON schema for the structured output of the call combining chunk analyses.
+// It has the shape of AnalysisSchema without feedback assignments, restricting topic_enum to the given topics.
+func ReduceSchema(topics []analysis.Topic) Map {
+	topicEnum := make([]any, len(topics))
+	for i, topic := range topics {
+		topicEnum[i] = string(topic)
+	}
+
+	return Map{
+		"type": "object",
+		"properties": Map{
+			"overall_summary": Map{
+				"type":        "string",
+				"description": "Human-readable summary combining the summaries of all partial analyses",
+			},
+			"sentiment": Map{
+				"type":        "string",
+				"enum":        []any{"positive", "mixed", "negative"},
+				"description": "Overall sentiment across all partial analyses",
+			},
+			"key_insights": Map{
+				"type":        "array",
+				"description": "Array of the most important insights across all partial analyses, without duplicates",
+				"items": Map{
+					"type": "string",
+				},
+			},
+			"topics": Map{
+				"type":        "array",
+				"description": "One entry per topic present in any partial analysis",
 				"items": Map{
 					"type": "object",
 					"properties": Map{
 						"topic_enum": Map{
-							"type": "string",
-							"enum": []any{
-								"product_functionality_features",
-								"ui_ux",
-								"performance_reliability",
-								"usability_productivity",
-								"security_privacy",
-								"compatibility_integration",
-								"developer_experience",
-								"pricing_licensing",
-								"customer_support_community",
-								"installation_setup_deployment",
-								"data_analytics_reporting",
-								"localization_internationalization",
-								"product_strategy_roadmap",
-							},
-							"description": "The predefined topic enum value that best categorizes this feedback",
+							"type":        "string",
+							"enum":        topicEnum,
+							"description": "The topic enum value of the partial topics being combined",
 						},
 						"summary": Map{
 							"type":        "string",
-							"description": "Summary of the analysis for this topic - explaining why this feedback belongs to this topic and what specific aspects it addresses",
-						},
-						"feedback_ids": Map{
-							"type":        "array",
-							"description": "Array of feedback IDs that belong to this topic",
-							"items": Map{
-								"type": "string",
-							},
+							"description": "Summary combining the partial summaries of this topic",
 						},
 						"sentiment": Map{
 							"type":        "string",
 							"enum":        []any{"positive", "mixed", "negative"},
-							"description": "Sentiment for this specific topic",
+							"description": "Dominant sentiment of this topic across all partial analyses",
 						},
 					},
-					"required":             []any{"topic_enum", "summary", "feedback_ids", "sentiment"},
+					"required":             []any{"topic_enum", "summary", "sentiment"},
 					"additionalProperties": false,
 				},
 			},
@@ -0,0 +1,65 @@
+package llm
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultMaxSummaryLength is the number of characters an overall or topic summary is truncated to by default.
+// It is generous on purpose, so that it only guards against pathological outputs.
+const DefaultMaxSummaryLength = 4000
+
+// summaryEllipsis is appended to truncated summaries.
+const summaryEllipsis = "…"
+
+// truncateSummary shortens a summary longer than maxSummaryLength characters, logging that it did.
+// The scope names the summary in the log, "overall" or the topic enum.
+func (c *OpenAIClient) truncateSummary(summary, scope string) string {
+	truncated, ok := truncateAtSentence(summary, c.maxSummaryLength)
+	if !ok {
+		return summary
+	}
+
+	c.logger.Warning(
+		"summary from LLM too long, truncating",
+		"scope",
+		scope,
+		"length",
+		len([]rune(summary)),
+		"max_summary_length",
+		c.maxSummaryLength,
+	)
+	return truncated
+}
+
+// truncateAtSentence cuts s to at most maxLength characters including the ellipsis, preferring the end of
+// the last complete sentence, then the last word. It reports false if s already fits.
+func truncateAtSentence(s string, maxLength int) (string, bool) {
+	runes := []rune(s)
+	if maxLength <= 0 || len(runes) <= maxLength {
+		return s, false
+	}
+
+	limit := maxLength - len([]rune(summaryEllipsis))
+	if limit <= 0 {
+		return summaryEllipsis, true
+	}
+
+	cut := -1
+	// A sentence ends with a terminal punctuation mark followed by whitespace
+	for i := limit - 1; i >= 0 && cut < 0; i-- {
+		if strings.ContainsRune(".!?", runes[i]) && unicode.IsSpace(runes[i+1]) {
+			cut = i + 1
+		}
+	}
+	for i := limit; i > 0 && cut < 0; i-- {
+		if unicode.IsSpace(runes[i]) {
+			cut = i
+		}
+	}
+	if cut < 0 {
+		cut = limit
+	}
+
+	return strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace) + summaryEllipsis, true
+}
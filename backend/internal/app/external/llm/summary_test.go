@@ -0,0 +1,62 @@
+package llm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTruncateAtSentence(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		maxLength int
+		want      string
+		truncated bool
+	}{
+		{name: "fits", input: "Short summary.", maxLength: 20, want: "Short summary."},
+		{name: "exact length", input: "Exactly.", maxLength: 8, want: "Exactly."},
+		{
+			name:      "sentence boundary",
+			input:     "Users love it. Checkout is slow! Search fails often.",
+			maxLength: 40,
+			want:      "Users love it. Checkout is slow!…",
+			truncated: true,
+		},
+		{
+			name:      "word boundary without sentence end",
+			input:     "users love the new dashboard layout a lot",
+			maxLength: 20,
+			want:      "users love the new…",
+			truncated: true,
+		},
+		{
+			name:      "no boundary",
+			input:     strings.Repeat("a", 30),
+			maxLength: 10,
+			want:      strings.Repeat("a", 9) + "…",
+			truncated: true,
+		},
+		{
+			name:      "multibyte characters",
+			input:     "Très bien. Ça marche très bien.",
+			maxLength: 15,
+			want:      "Très bien.…",
+			truncated: true,
+		},
+		{name: "no limit", input: "Anything goes.", maxLength: 0, want: "Anything goes."},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				got, truncated := truncateAtSentence(tt.input, tt.maxLength)
+				if got != tt.want || truncated != tt.truncated {
+					t.Errorf("Expected (%q, %v), got (%q, %v)", tt.want, tt.truncated, got, truncated)
+				}
+				if tt.maxLength > 0 && len([]rune(got)) > tt.maxLength {
+					t.Errorf("Expected at most %d characters, got %d", tt.maxLength, len([]rune(got)))
+				}
+			},
+		)
+	}
+}
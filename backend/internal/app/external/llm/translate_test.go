@@ -0,0 +1,74 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestOpenAIClient_Translate(t *testing.T) {
+	tests := []struct {
+		name           string
+		text           string
+		output         TranslateResponse
+		wantText       string
+		wantLanguage   string
+		wantTranslated bool
+	}{
+		{
+			name:           "other language",
+			text:           "Der Export ist viel zu langsam",
+			output:         TranslateResponse{SourceLanguage: "DE", Translation: "The export is far too slow"},
+			wantText:       "The export is far too slow",
+			wantLanguage:   "de",
+			wantTranslated: true,
+		},
+		{
+			name:         "target language",
+			text:         "The export is slow",
+			output:       TranslateResponse{SourceLanguage: "en", Translation: "Export is slow"},
+			wantText:     "The export is slow",
+			wantLanguage: "en",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				output, err := json.Marshal(tt.output)
+				if err != nil {
+					t.Fatalf("Failed to marshal translate output: %v", err)
+				}
+
+				var gotBody string
+				client := newTestClient(
+					t, func(w http.ResponseWriter, r *http.Request) {
+						raw, _ := io.ReadAll(r.Body)
+						gotBody = string(raw)
+						respondWith(http.StatusOK, responsesBody(t, string(output)))(w, r)
+					},
+				)
+
+				translation, err := client.Translate(context.Background(), tt.text, "en")
+				if err != nil {
+					t.Fatalf("Expected no error, got: %v", err)
+				}
+				if !strings.Contains(gotBody, "feedback_translation") ||
+					!strings.Contains(gotBody, `\"target_language\":\"en\"`) {
+					t.Errorf("Expected a translation request into en, got body: %s", gotBody)
+				}
+				if translation.Text != tt.wantText || translation.SourceLanguage != tt.wantLanguage ||
+					translation.Translated != tt.wantTranslated {
+					t.Errorf(
+						"Expected %q (%s, translated: %v), got %q (%s, translated: %v)",
+						tt.wantText, tt.wantLanguage, tt.wantTranslated,
+						translation.Text, translation.SourceLanguage, translation.Translated,
+					)
+				}
+			},
+		)
+	}
+}
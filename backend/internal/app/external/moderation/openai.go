@@ -0,0 +1,148 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+const (
+	// DefaultBaseURL is the public OpenAI API root.
+	DefaultBaseURL = "https://api.openai.com/v1"
+	// DefaultModel is the moderation model used when none is configured.
+	DefaultModel = "omni-moderation-latest"
+	// DefaultHTTPTimeout bounds a moderation request, which runs while the client waits for its feedback to be created.
+	DefaultHTTPTimeout = 10 * time.Second
+)
+
+// OpenAIModerator implements the external.Moderator interface using OpenAI's moderation endpoint.
+type OpenAIModerator struct {
+	apiKey     string
+	model      string
+	baseURL    string
+	httpClient *http.Client
+	logger     tracelog.TraceLogger
+}
+
+// NewOpenAIModerator creates a moderator calling POST /moderations below baseURL.
+// An empty model or baseURL falls back to DefaultModel and DefaultBaseURL.
+func NewOpenAIModerator(apiKey, model, baseURL string, logger tracelog.TraceLogger) *OpenAIModerator {
+	if model == "" {
+		model = DefaultModel
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	return &OpenAIModerator{
+		apiKey:     apiKey,
+		model:      model,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: DefaultHTTPTimeout},
+		logger:     logger.NewGroup("openai_moderator"),
+	}
+}
+
+// moderationRequest is the request body of the moderation endpoint.
+type moderationRequest struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
+}
+
+// moderationResponse is the response body of the moderation endpoint, one result per input.
+type moderationResponse struct {
+	Results []struct {
+		Flagged    bool            `json:"flagged"`
+		Categories map[string]bool `json:"categories"`
+	} `json:"results"`
+}
+
+// Moderate sends the text to the moderation endpoint. The text itself is neither logged nor recorded on the span.
+func (m *OpenAIModerator) Moderate(ctx context.Context, text string) (*external.ModerationResult, error) {
+	ctx, spanLogger, span := m.logger.StartSpan(ctx, "moderation.moderate")
+	defer span.End()
+
+	span.SetAttributes(
+		trace.Attribute{Key: "moderation.model", Value: m.model},
+		trace.Attribute{Key: "moderation.input_length", Value: len(text)},
+	)
+
+	result, err := m.moderate(ctx, text)
+	if err != nil {
+		span.SetStatus(trace.StatusError, err.Error())
+		spanLogger.RecordSpanError(ctx, err)
+		return nil, err
+	}
+
+	span.SetAttributes(
+		trace.Attribute{Key: "moderation.flagged", Value: result.Flagged},
+		trace.Attribute{Key: "moderation.categories", Value: strings.Join(result.Categories, ",")},
+	)
+	span.SetStatus(trace.StatusOK, "Successfully moderated text")
+	return result, nil
+}
+
+func (m *OpenAIModerator) moderate(ctx context.Context, text string) (*external.ModerationResult, error) {
+	body, err := json.Marshal(moderationRequest{Model: m.model, Input: text})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal moderation request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", m.baseURL+"/moderations", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+m.apiKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		if err := Body.Close(); err != nil {
+			m.logger.RecordSpanError(ctx, fmt.Errorf("failed to close response body: %w", err))
+		}
+	}(resp.Body)
+
+	rawBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	m.logger.SetSpanAttributes(ctx, trace.Attribute{Key: "http.status_code", Value: resp.StatusCode})
+
+	// The error body only describes the request, it never echoes the input
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("OpenAI moderation API error (HTTP %d): %s", resp.StatusCode, string(rawBody))
+	}
+
+	var moderationResp moderationResponse
+	if err := json.Unmarshal(rawBody, &moderationResp); err != nil {
+		return nil, fmt.Errorf("failed to parse moderation response: %w", err)
+	}
+	if len(moderationResp.Results) == 0 {
+		return nil, fmt.Errorf("moderation response contains no result")
+	}
+
+	res := moderationResp.Results[0]
+	result := &external.ModerationResult{Flagged: res.Flagged}
+	for category, violated := range res.Categories {
+		if violated {
+			result.Categories = append(result.Categories, category)
+		}
+	}
+	sort.Strings(result.Categories)
+
+	return result, nil
+}
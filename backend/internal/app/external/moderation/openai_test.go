@@ -0,0 +1,68 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ktruedat/llm-feedback-analysis/pkg/log"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+func newTestModerator(t *testing.T, handler http.HandlerFunc) *OpenAIModerator {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	logger := tracelog.NewTraceLogger(log.NewLogger("development"), trace.NewNoopTracer())
+	return NewOpenAIModerator("sk-test", "", srv.URL+"/", logger)
+}
+
+func TestOpenAIModerator_Moderate_Flagged(t *testing.T) {
+	var req moderationRequest
+	m := newTestModerator(
+		t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/moderations" {
+				t.Errorf("Expected request to /moderations, got %s", r.URL.Path)
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Errorf("Failed to decode request: %v", err)
+			}
+			_, _ = w.Write(
+				[]byte(`{"results":[{"flagged":true,"categories":{"violence":true,"hate":true,"sexual":false}}]}`),
+			)
+		},
+	)
+
+	result, err := m.Moderate(context.Background(), "some comment")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if req.Model != DefaultModel || req.Input != "some comment" {
+		t.Errorf("Expected model %q and the comment as input, got %+v", DefaultModel, req)
+	}
+	if !result.Flagged {
+		t.Error("Expected the comment to be flagged")
+	}
+	if strings.Join(result.Categories, ",") != "hate,violence" {
+		t.Errorf("Expected the violated categories in alphabetical order, got %v", result.Categories)
+	}
+}
+
+func TestOpenAIModerator_Moderate_APIError(t *testing.T) {
+	m := newTestModerator(
+		t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		},
+	)
+
+	if _, err := m.Moderate(context.Background(), "some comment"); err == nil {
+		t.Fatal("Expected an error for a failed moderation request")
+	}
+}
@@ -0,0 +1,154 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+const (
+	// SignatureHeader contains the hex encoded HMAC-SHA256 of "<timestamp>.<body>", prefixed with "sha256=".
+	SignatureHeader = "X-Webhook-Signature"
+	// TimestampHeader contains the unix timestamp used when computing the signature.
+	TimestampHeader = "X-Webhook-Timestamp"
+	// EventHeader contains the event type.
+	EventHeader = "X-Webhook-Event"
+)
+
+// Sink implements the external.EventSink interface by POSTing events to an HTTP endpoint.
+type Sink struct {
+	url            string
+	secret         string
+	maxRetries     int
+	initialBackoff time.Duration
+	httpClient     *http.Client
+	clock          clock.Clock
+	logger         tracelog.TraceLogger
+}
+
+// NewSink creates a new webhook sink delivering events to the given URL.
+// Deliveries are signed with the secret when it is not empty and retried with exponential backoff.
+// The clock provides the signature timestamps, the system clock if nil.
+func NewSink(
+	url string,
+	secret string,
+	maxRetries int,
+	initialBackoff time.Duration,
+	timeout time.Duration,
+	clk clock.Clock,
+	logger tracelog.TraceLogger,
+) *Sink {
+	if clk == nil {
+		clk = clock.New()
+	}
+	return &Sink{
+		url:            url,
+		secret:         secret,
+		maxRetries:     maxRetries,
+		initialBackoff: initialBackoff,
+		httpClient:     &http.Client{Timeout: timeout},
+		clock:          clk,
+		logger:         logger.NewGroup("webhook_sink"),
+	}
+}
+
+// Name returns the sink identifier.
+func (s *Sink) Name() string {
+	return "webhook:" + s.url
+}
+
+// Send delivers the event to the webhook endpoint, retrying on network errors, 429 and 5xx responses.
+func (s *Sink) Send(ctx context.Context, event *external.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	backoff := s.initialBackoff
+	var lastErr error
+	for attempt := 0; attempt <= s.maxRetries; attempt++ {
+		if attempt > 0 {
+			s.logger.Warning(
+				"retrying webhook delivery",
+				"url", s.url,
+				"event_id", event.ID.String(),
+				"attempt", attempt,
+				"error", lastErr.Error(),
+			)
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("webhook delivery cancelled: %w (last error: %v)", ctx.Err(), lastErr)
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		retryable, err := s.deliver(ctx, event, body)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retryable {
+			break
+		}
+	}
+
+	return fmt.Errorf("failed to deliver webhook to %s: %w", s.url, lastErr)
+}
+
+// deliver performs a single delivery attempt and reports whether a failure is worth retrying.
+func (s *Sink) deliver(ctx context.Context, event *external.Event, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(s.clock.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(event.Type))
+	req.Header.Set(TimestampHeader, timestamp)
+	if s.secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(s.secret, timestamp, body))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		if err := Body.Close(); err != nil {
+			s.logger.RecordSpanError(ctx, fmt.Errorf("failed to close response body: %w", err))
+		}
+	}(resp.Body)
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retryable, fmt.Errorf("webhook endpoint responded with HTTP %d", resp.StatusCode)
+}
+
+// Sign computes the hex encoded HMAC-SHA256 signature of "<timestamp>.<body>".
+// Receivers can use it to verify that a delivery originates from this service.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
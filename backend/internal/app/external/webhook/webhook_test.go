@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/log"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+func newTestLogger(t *testing.T) tracelog.TraceLogger {
+	t.Helper()
+
+	tracer, err := trace.NewTracer(trace.Config{ServiceName: "webhook-test"})
+	if err != nil {
+		t.Fatalf("Failed to create tracer: %v", err)
+	}
+
+	return tracelog.NewTraceLogger(log.NewLogger("development"), tracer)
+}
+
+func TestSink_Send_SignsEveryAttemptWithClockTime(t *testing.T) {
+	now := time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC)
+	mockClock := clock.NewMock(now)
+
+	// The endpoint fails the first attempt, a minute passes before the retry
+	var (
+		mu         sync.Mutex
+		timestamps []string
+	)
+	srv := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				timestamp := r.Header.Get(TimestampHeader)
+				if got, want := r.Header.Get(SignatureHeader), "sha256="+Sign("secret", timestamp, body); got != want {
+					t.Errorf("Expected signature %q, got %q", want, got)
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				timestamps = append(timestamps, timestamp)
+				if len(timestamps) == 1 {
+					mockClock.Advance(time.Minute)
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			},
+		),
+	)
+	t.Cleanup(srv.Close)
+
+	sink := NewSink(srv.URL, "secret", 1, time.Millisecond, time.Second, mockClock, newTestLogger(t))
+	event := &external.Event{ID: uuid.New(), Type: external.EventFeedbackCreated, OccurredAt: now}
+	if err := sink.Send(context.Background(), event); err != nil {
+		t.Fatalf("Expected the retry to be delivered, got: %v", err)
+	}
+
+	want := []string{
+		strconv.FormatInt(now.Unix(), 10),
+		strconv.FormatInt(now.Add(time.Minute).Unix(), 10),
+	}
+	if len(timestamps) != len(want) || timestamps[0] != want[0] || timestamps[1] != want[1] {
+		t.Errorf("Expected timestamps %v, got %v", want, timestamps)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strings"
 
+	appjwt "github.com/ktruedat/llm-feedback-analysis/internal/app/infrastructure/jwt"
 	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/http/responder"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
@@ -41,19 +42,7 @@ func RequireRole(
 					return
 				}
 
-				// Check if user has the required role
-				hasRole := false
-				if claims.Roles != nil {
-					for _, role := range claims.Roles {
-						// Case-insensitive comparison (industry standard)
-						if strings.EqualFold(strings.TrimSpace(role), strings.TrimSpace(requiredRole)) {
-							hasRole = true
-							break
-						}
-					}
-				}
-
-				if !hasRole {
+				if !HasRole(claims, requiredRole) {
 					userID := claims.UserID
 					logger.Warning(
 						"access denied - insufficient permissions",
@@ -143,3 +132,17 @@ func RequireAnyRole(
 		)
 	}
 }
+
+// HasRole reports whether the claims carry the given role. Roles are compared case-insensitively.
+// It is meant for handlers that stay open to every authenticated user but unlock extra behaviour for a role.
+func HasRole(claims *appjwt.Claims, role string) bool {
+	if claims == nil {
+		return false
+	}
+	for _, userRole := range claims.Roles {
+		if strings.EqualFold(strings.TrimSpace(userRole), strings.TrimSpace(role)) {
+			return true
+		}
+	}
+	return false
+}
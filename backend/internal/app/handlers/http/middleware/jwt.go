@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -20,22 +21,59 @@ const (
 	UserClaimsContextKey ContextKey = "user_claims"
 )
 
+// errorCodeTokenExpired tells clients to refresh their token instead of logging in again.
+var errorCodeTokenExpired = ce.NewDomainErrorCode("token_expired", ce.CategoryUnauthorized)
+
+// DefaultPublicPaths are the path prefixes served without authentication.
+var DefaultPublicPaths = []string{
+	"/api/auth/register",
+	"/api/auth/login",
+	"/swagger/",
+	"/health",
+}
+
+// PublicPaths is a set of path prefixes that bypass JWT validation.
+// A prefix matches the path itself and everything below it, e.g. "/health" matches
+// "/health" and "/health/ready" but not "/healthz".
+type PublicPaths []string
+
+// NewPublicPaths combines DefaultPublicPaths with the given additional prefixes.
+func NewPublicPaths(additional ...string) PublicPaths {
+	paths := make(PublicPaths, 0, len(DefaultPublicPaths)+len(additional))
+	paths = append(paths, DefaultPublicPaths...)
+	for _, prefix := range additional {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			paths = append(paths, prefix)
+		}
+	}
+	return paths
+}
+
+// Matches reports whether the path is public.
+func (p PublicPaths) Matches(path string) bool {
+	for _, prefix := range p {
+		trimmed := strings.TrimSuffix(prefix, "/")
+		if path == trimmed || strings.HasPrefix(path, trimmed+"/") {
+			return true
+		}
+	}
+	return false
+}
+
 // JWTMiddleware creates a middleware that validates JWT bearer tokens
-// according to RFC 6750 (OAuth 2.0 Bearer Token Usage)
-// It skips authentication for public routes like /auth/register and /auth/login
+// according to RFC 6750 (OAuth 2.0 Bearer Token Usage).
+// Requests to DefaultPublicPaths and the public paths of the configuration skip authentication.
 func JWTMiddleware(
 	cfg *config.JWT,
 	logger tracelog.TraceLogger,
 	responder responder.RestResponder,
 ) func(http.Handler) http.Handler {
+	publicPaths := NewPublicPaths(cfg.PublicPaths...)
+
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(
 			func(w http.ResponseWriter, r *http.Request) {
-				// Skip JWT validation for auth endpoints and Swagger UI
-				path := r.URL.Path
-				if path == "/auth/register" || path == "/auth/login" ||
-					path == "/api/auth/register" || path == "/api/auth/login" ||
-					strings.HasPrefix(path, "/swagger/") {
+				if publicPaths.Matches(r.URL.Path) {
 					next.ServeHTTP(w, r)
 					return
 				}
@@ -48,15 +86,20 @@ func JWTMiddleware(
 				tokenString, err := appjwt.ExtractBearerToken(authHeader)
 				if err != nil {
 					logger.Warning("failed to extract bearer token", "error", err)
-					responder.RespondContent(w, ce.ErrUnauthorized(err.Error()))
+					respondUnauthorized(w, responder, "missing or malformed bearer token", err)
 					return
 				}
 
 				// Parse and validate the token
 				claims, err := appjwt.ParseToken(tokenString, cfg)
+				if errors.Is(err, appjwt.ErrTokenExpired) {
+					logger.Warning("jwt expired", "error", err)
+					respondTokenExpired(w, responder, err)
+					return
+				}
 				if err != nil {
 					logger.Warning("jwt validation failed", "error", err)
-					responder.RespondContent(w, ce.ErrUnauthorized("invalid or expired token", ce.WithCauseError(err)))
+					respondUnauthorized(w, responder, "invalid token", err)
 					return
 				}
 
@@ -68,6 +111,27 @@ func JWTMiddleware(
 	}
 }
 
+// respondUnauthorized sends the 401 response shared by all authentication failures,
+// with the WWW-Authenticate challenge required by RFC 6750.
+func respondUnauthorized(w http.ResponseWriter, responder responder.RestResponder, msg string, cause error) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+	responder.RespondContent(w, ce.ErrUnauthorized(msg, ce.WithCauseError(cause)))
+}
+
+// respondTokenExpired sends a 401 with the token_expired code and the RFC 6750 invalid_token
+// challenge, so clients can tell an expired token apart from an invalid one.
+func respondTokenExpired(w http.ResponseWriter, responder responder.RestResponder, cause error) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="api", error="invalid_token", error_description="token expired"`)
+	responder.RespondContent(
+		w, &ce.GenericError{
+			Code:       errorCodeTokenExpired,
+			Message:    "Unauthorized: token expired",
+			Cause:      cause,
+			UserFacing: true,
+		},
+	)
+}
+
 // GetUserClaims extracts user claims from the request context
 // Returns nil if no claims are found in the context
 func GetUserClaims(r *http.Request) *appjwt.Claims {
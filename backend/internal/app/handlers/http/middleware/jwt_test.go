@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"testing"
+)
+
+func TestPublicPaths_Matches(t *testing.T) {
+	paths := NewPublicPaths("/metrics", " ", "/version/")
+
+	tests := []struct {
+		path   string
+		public bool
+	}{
+		{"/api/auth/login", true},
+		{"/api/auth/register", true},
+		{"/swagger/index.html", true},
+		{"/health", true},
+		{"/health/ready", true},
+		{"/healthz", false},
+		{"/metrics", true},
+		{"/version", true},
+		{"/version/build", true},
+		{"/api/feedbacks", false},
+		{"/api/auth/loginx", false},
+	}
+
+	for _, tt := range tests {
+		if got := paths.Matches(tt.path); got != tt.public {
+			t.Errorf("Matches(%q) = %v, expected %v", tt.path, got, tt.public)
+		}
+	}
+}
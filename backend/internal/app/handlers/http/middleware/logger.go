@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/http/responder"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
 )
 
@@ -28,6 +29,7 @@ func LoggerMiddleware(logger tracelog.TraceLogger) func(http.Handler) http.Handl
 					"bytes", ww.BytesWritten(),
 					"duration_ms", duration.Milliseconds(),
 					"remote_addr", r.RemoteAddr,
+					"request_id", w.Header().Get(responder.RequestIDHeader),
 				)
 			},
 		)
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/http/requestid"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/http/responder"
+)
+
+// validRequestID restricts client-provided request IDs to a safe length and character set.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestIDMiddleware assigns every request an ID, sent back in the X-Request-ID header and
+// included in error responses. A valid X-Request-ID sent by the client is reused.
+// The ID is also stored in the request context, see requestid.FromContext.
+func RequestIDMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				requestID := r.Header.Get(responder.RequestIDHeader)
+				if !validRequestID.MatchString(requestID) {
+					requestID = uuid.NewString()
+				}
+				w.Header().Set(responder.RequestIDHeader, requestID)
+
+				next.ServeHTTP(w, r.WithContext(requestid.NewContext(r.Context(), requestID)))
+			},
+		)
+	}
+}
@@ -2,13 +2,18 @@ package v1
 
 import (
 	"net/http"
+	"sort"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/handlers/http/middleware"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/transport/requests"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/transport/responses"
-	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
 	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/http/responder"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
 )
 
@@ -16,14 +21,49 @@ func (h *Handlers) registerAnalysisRoutes(router chi.Router) {
 	router.Route(
 		"/analyses", func(r chi.Router) {
 			r.Get("/latest", trace.InstrumentHandlerFunc(h.GetLatestAnalysis, "GET /analyses/latest", h))
+			r.Get("/estimate", trace.InstrumentHandlerFunc(h.EstimateAnalysis, "GET /analyses/estimate", h))
 			r.Get("/", trace.InstrumentHandlerFunc(h.ListAnalyses, "GET /analyses", h))
 			r.Get("/{id}", trace.InstrumentHandlerFunc(h.GetAnalysisByID, "GET /analyses/{id}", h))
+			r.Get("/{id}/status", trace.InstrumentHandlerFunc(h.GetAnalysisStatus, "GET /analyses/{id}/status", h))
+			r.Get("/{id}/report", trace.InstrumentHandlerFunc(h.GetAnalysisReport, "GET /analyses/{id}/report", h))
+			r.With(middleware.RequireRole("admin", h.logger, h.responder)).
+				Get("/{id}/raw", trace.InstrumentHandlerFunc(h.GetAnalysisRawOutput, "GET /analyses/{id}/raw", h))
+			r.With(middleware.RequireRole("admin", h.logger, h.responder)).
+				Get("/{id}/events", trace.InstrumentHandlerFunc(h.GetAnalysisEvents, "GET /analyses/{id}/events", h))
+			// Admin-only route: only users with "admin" role can trigger ad-hoc analyses
+			r.With(middleware.RequireRole("admin", h.logger, h.responder)).
+				Post("/adhoc", trace.InstrumentHandlerFunc(h.CreateAdhocAnalysis, "POST /analyses/adhoc", h))
+			r.With(middleware.RequireRole("admin", h.logger, h.responder)).
+				Post("/trigger", trace.InstrumentHandlerFunc(h.TriggerAnalysis, "POST /analyses/trigger", h))
+			r.With(middleware.RequireRole("admin", h.logger, h.responder)).
+				Post(
+					"/reprocess-unanalyzed",
+					trace.InstrumentHandlerFunc(h.ReprocessUnanalyzed, "POST /analyses/reprocess-unanalyzed", h),
+				)
 		},
 	)
 	router.Route(
 		"/topics", func(r chi.Router) {
 			r.Get("/", trace.InstrumentHandlerFunc(h.GetTopicsWithStats, "GET /topics", h))
+			r.With(middleware.RequireRole("admin", h.logger, h.responder)).
+				Post(
+					"/recompute-stats",
+					trace.InstrumentHandlerFunc(h.RecomputeTopicStats, "POST /topics/recompute-stats", h),
+				)
 			r.Get("/{topic_enum}", trace.InstrumentHandlerFunc(h.GetTopicDetails, "GET /topics/{topic_enum}", h))
+			r.Get(
+				"/{topic_enum}/history",
+				trace.InstrumentHandlerFunc(h.GetTopicHistory, "GET /topics/{topic_enum}/history", h),
+			)
+			r.Get(
+				"/{topic_enum}/sentiment-trend",
+				trace.InstrumentHandlerFunc(h.GetTopicSentimentTrend, "GET /topics/{topic_enum}/sentiment-trend", h),
+			)
+		},
+	)
+	router.Route(
+		"/topic-analyses", func(r chi.Router) {
+			r.Get("/{id}", trace.InstrumentHandlerFunc(h.GetTopicAnalysisByID, "GET /topic-analyses/{id}", h))
 		},
 	)
 }
@@ -36,17 +76,31 @@ func (h *Handlers) registerAnalysisRoutes(router chi.Router) {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Success		200	{object}	responses.AnalysisResponse	"Latest analysis retrieved successfully"
-//	@Success		204	{object}	nil							"No analysis found"
-//	@Failure		401	{object}	map[string]interface{}		"Unauthorized - invalid or missing JWT token"
-//	@Failure		500	{object}	map[string]interface{}		"Internal server error"
+//	@Param			representative_only	query		bool						false	"Skip analyses over fewer feedbacks than configured to be representative"
+//	@Success		200					{object}	responses.AnalysisResponse	"Latest analysis retrieved successfully"
+//	@Success		204					{object}	nil							"No analysis found"
+//	@Failure		400					{object}	responder.ErrorResponse		"Bad request - representative_only is not a boolean"
+//	@Failure		401					{object}	responder.ErrorResponse		"Unauthorized - invalid or missing JWT token"
+//	@Failure		500					{object}	responder.ErrorResponse		"Internal server error"
 //	@Router			/analyses/latest [get]
 func (h *Handlers) GetLatestAnalysis(resp http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	logger := h.logger.WithSpan(ctx)
 
-	logger.Info("getting latest analysis")
-	analysis, err := h.feedbackSummaryService.GetLatestAnalysis(ctx)
+	representativeOnly := false
+	if value := r.URL.Query().Get("representative_only"); value != "" {
+		var err error
+		if representativeOnly, err = strconv.ParseBool(value); err != nil {
+			h.responder.RespondContent(
+				resp,
+				ce.ErrBadRequest("representative_only must be a boolean", ce.WithCauseError(err)),
+			)
+			return
+		}
+	}
+
+	logger.Info("getting latest analysis", "representative_only", representativeOnly)
+	analysis, err := h.feedbackSummaryService.GetLatestAnalysis(ctx, representativeOnly)
 	if err != nil {
 		logger.RecordSpanError(ctx, err)
 		logger.Error("error getting latest analysis", err)
@@ -63,24 +117,157 @@ func (h *Handlers) GetLatestAnalysis(resp http.ResponseWriter, r *http.Request)
 	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
 }
 
-// ListAnalyses retrieves all analyses ordered by creation date (newest first)
+// GetAnalysisStatus retrieves only the status of an analysis
+//
+//	@Summary		Get analysis status
+//	@Description	Retrieve the status and timestamps of an analysis. Cheap enough to poll until the analysis completes
+//	@Tags			analyses
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Analysis ID"	example(550e8400-e29b-41d4-a716-446655440000)
+//	@Success		200	{object}	responses.AnalysisStatusResponse	"Analysis status retrieved successfully"
+//	@Failure		400	{object}	responder.ErrorResponse			"Bad request - invalid analysis ID format"
+//	@Failure		401	{object}	responder.ErrorResponse			"Unauthorized - invalid or missing JWT token"
+//	@Failure		404	{object}	responder.ErrorResponse			"Analysis not found"
+//	@Failure		500	{object}	responder.ErrorResponse			"Internal server error"
+//	@Router			/analyses/{id}/status [get]
+func (h *Handlers) GetAnalysisStatus(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	analysisID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.responder.RespondContent(resp, ce.ErrBadRequest("invalid analysis ID format"))
+		return
+	}
+
+	analysisEntity, err := h.feedbackSummaryService.GetAnalysisStatus(ctx, analysisID)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting analysis status", err, "analysis_id", analysisID)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	response := responses.AnalysisStatusResponseFromDomain(analysisEntity)
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
+}
+
+// GetAnalysisRawOutput retrieves the raw model output of an analysis
+//
+//	@Summary		Get raw analysis output
+//	@Description	Retrieve the output text exactly as returned by the model, with PII masked, to debug schema
+//	@Description	mismatches and prompt issues. Only available if llm_analysis.store_raw_output is enabled. Requires admin role
+//	@Tags			analyses
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Analysis ID"	example(550e8400-e29b-41d4-a716-446655440000)
+//	@Success		200	{object}	responses.AnalysisRawOutputResponse	"Raw output retrieved successfully"
+//	@Failure		400	{object}	responder.ErrorResponse				"Bad request - invalid analysis ID format"
+//	@Failure		401	{object}	responder.ErrorResponse				"Unauthorized - invalid or missing JWT token"
+//	@Failure		403	{object}	responder.ErrorResponse				"Forbidden - admin role required"
+//	@Failure		404	{object}	responder.ErrorResponse				"Analysis not found or no raw output stored"
+//	@Failure		500	{object}	responder.ErrorResponse				"Internal server error"
+//	@Router			/analyses/{id}/raw [get]
+func (h *Handlers) GetAnalysisRawOutput(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	analysisID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.responder.RespondContent(resp, ce.ErrBadRequest("invalid analysis ID format"))
+		return
+	}
+
+	rawOutput, err := h.feedbackSummaryService.GetAnalysisRawOutput(ctx, analysisID)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting analysis raw output", err, "analysis_id", analysisID)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	response := responses.AnalysisRawOutputResponseFromDomain(rawOutput)
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
+}
+
+// GetAnalysisEvents retrieves the lifecycle timeline of an analysis
+//
+//	@Summary		Get analysis events
+//	@Description	Retrieve the steps of an analysis (created, llm_called, llm_returned, topics_created, completed,
+//	@Description	failed) with their timestamps and durations, oldest first. Unlike traces, events are stored for
+//	@Description	every analysis. Requires admin role
+//	@Tags			analyses
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Analysis ID"	example(550e8400-e29b-41d4-a716-446655440000)
+//	@Success		200	{object}	responses.AnalysisEventsResponse	"Events retrieved successfully"
+//	@Failure		400	{object}	responder.ErrorResponse			"Bad request - invalid analysis ID format"
+//	@Failure		401	{object}	responder.ErrorResponse			"Unauthorized - invalid or missing JWT token"
+//	@Failure		403	{object}	responder.ErrorResponse			"Forbidden - admin role required"
+//	@Failure		404	{object}	responder.ErrorResponse			"Analysis not found"
+//	@Failure		500	{object}	responder.ErrorResponse			"Internal server error"
+//	@Router			/analyses/{id}/events [get]
+func (h *Handlers) GetAnalysisEvents(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	analysisID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.responder.RespondContent(resp, ce.ErrBadRequest("invalid analysis ID format"))
+		return
+	}
+
+	events, err := h.feedbackSummaryService.GetAnalysisEvents(ctx, analysisID)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting analysis events", err, "analysis_id", analysisID)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	response := responses.AnalysisEventsResponseFromDomain(analysisID, events)
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
+}
+
+// ListAnalyses retrieves analyses ordered by creation date (newest first) with pagination
 //
-//	@Summary		List all analyses
-//	@Description	Retrieve all analyses ordered by creation date (newest first) for the history page
+//	@Summary		List analyses
+//	@Description	Retrieve analyses ordered by creation date (newest first) for the history page, with pagination
 //	@Tags			analyses
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Success		200	{object}	responses.AnalysisListResponse	"Analyses retrieved successfully"
-//	@Failure		401	{object}	map[string]interface{}			"Unauthorized - invalid or missing JWT token"
-//	@Failure		500	{object}	map[string]interface{}			"Internal server error"
+//	@Param			limit	query		int	false	"Maximum number of analyses to return (default: 100)"	example(10)
+//	@Param			offset	query		int	false	"Number of analyses to skip (default: 0)"	example(0)
+//	@Success		200		{object}	responses.Paginated{items=[]responses.AnalysisResponse}	"Analyses retrieved successfully"
+//	@Failure		400		{object}	responder.ErrorResponse			"Bad request - invalid query parameters"
+//	@Failure		401		{object}	responder.ErrorResponse			"Unauthorized - invalid or missing JWT token"
+//	@Failure		500		{object}	responder.ErrorResponse			"Internal server error"
+//	@Failure		504		{object}	responder.ErrorResponse			"Database operation timed out"
 //	@Router			/analyses [get]
 func (h *Handlers) ListAnalyses(resp http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	logger := h.logger.WithSpan(ctx)
 
-	logger.Info("listing all analyses")
-	analyses, err := h.feedbackSummaryService.GetAllAnalyses(ctx)
+	var limit, offset int
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsedLimit, err := parseInt(limitStr); err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if parsedOffset, err := parseInt(offsetStr); err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	logger.Info("listing analyses", "limit", limit, "offset", offset)
+	page, err := h.feedbackSummaryService.ListAnalyses(ctx, limit, offset)
 	if err != nil {
 		logger.RecordSpanError(ctx, err)
 		logger.Error("error listing analyses", err)
@@ -89,14 +276,165 @@ func (h *Handlers) ListAnalyses(resp http.ResponseWriter, r *http.Request) {
 	}
 
 	// Convert to response format
-	analysisResponses := make([]responses.AnalysisResponse, len(analyses))
-	for i, a := range analyses {
+	analysisResponses := make([]responses.AnalysisResponse, len(page.Items))
+	for i, a := range page.Items {
 		analysisResponses[i] = *responses.AnalysisResponseFromDomain(a)
 	}
 
-	response := responses.AnalysisListResponse{
-		Analyses: analysisResponses,
-		Total:    len(analysisResponses),
+	response := responses.NewPaginated(analysisResponses, page.Total, page.Limit, page.Offset)
+
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
+}
+
+// CreateAdhocAnalysis runs an analysis over an explicit set of feedbacks
+//
+//	@Summary		Run ad-hoc analysis
+//	@Description	Analyze exactly the given feedbacks, bypassing the pending analysis queue. Requires admin role
+//	@Tags			analyses
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		requests.AdhocAnalysisRequest	true	"Ad-hoc analysis request"
+//	@Success		201		{object}	responses.AnalysisResponse		"Analysis completed successfully"
+//	@Failure		400		{object}	responder.ErrorResponse			"Bad request - invalid, duplicate, unknown or too many feedback IDs"
+//	@Failure		401		{object}	responder.ErrorResponse			"Unauthorized - invalid or missing JWT token"
+//	@Failure		403		{object}	responder.ErrorResponse			"Forbidden - admin role required"
+//	@Failure		409		{object}	responder.ErrorResponse			"Conflict - the analyzer is shutting down"
+//	@Failure		500		{object}	responder.ErrorResponse			"Internal server error"
+//	@Router			/analyses/adhoc [post]
+func (h *Handlers) CreateAdhocAnalysis(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	req, err := parsePayloadData[requests.AdhocAnalysisRequest](r, r.Body)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		h.responder.RespondContent(resp, ce.ErrBadRequest("invalid request body", ce.WithCauseError(err)))
+		return
+	}
+
+	logger.Info("running ad-hoc analysis", "feedback_count", len(req.Data.FeedbackIDs))
+	analysisEntity, err := h.analyzerService.AnalyzeAdhoc(ctx, req.Data.FeedbackIDs)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error running ad-hoc analysis", err)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	response := responses.AnalysisResponseFromDomain(analysisEntity)
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusCreated, response))
+}
+
+// TriggerAnalysis analyzes the pending feedback queue immediately
+//
+//	@Summary		Trigger analysis
+//	@Description	Analyze the pending feedback queue now, regardless of the minimum feedback threshold and debounce. This is the way to run analyses in on-demand mode. The queue is held in memory by each replica, so only the feedbacks queued on the replica receiving the request are analyzed. Requires admin role
+//	@Tags			analyses
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		201	{object}	responses.AnalysisResponse	"Analysis completed successfully"
+//	@Failure		400	{object}	responder.ErrorResponse		"Bad request - no pending feedbacks to analyze"
+//	@Failure		401	{object}	responder.ErrorResponse		"Unauthorized - invalid or missing JWT token"
+//	@Failure		403	{object}	responder.ErrorResponse		"Forbidden - admin role required"
+//	@Failure		409	{object}	responder.ErrorResponse		"Conflict - an analysis is already running on another instance, or the analyzer is shutting down"
+//	@Failure		429	{object}	responder.ErrorResponse		"Too many requests - the LLM circuit breaker is open"
+//	@Failure		500	{object}	responder.ErrorResponse		"Internal server error"
+//	@Router			/analyses/trigger [post]
+func (h *Handlers) TriggerAnalysis(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	logger.Info("triggering analysis of pending feedbacks")
+	analysisEntity, err := h.analyzerService.TriggerAnalysis(ctx)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error triggering analysis", err)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	response := responses.AnalysisResponseFromDomain(analysisEntity)
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusCreated, response))
+}
+
+// ReprocessUnanalyzed adds the feedbacks that were never successfully analyzed back to the pending queue
+//
+//	@Summary		Reprocess unanalyzed feedbacks
+//	@Description	Add the feedbacks listed by GET /feedbacks/unanalyzed back to the pending queue, so feedbacks of failed analyses are analyzed again. Feedbacks already queued or outside the rating filter are skipped. The queue is analyzed as usual afterwards, or with POST /analyses/trigger in on-demand mode. Requires admin role
+//	@Tags			analyses
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		202	{object}	responses.ReprocessUnanalyzedResponse	"Unanalyzed feedbacks added to the pending queue"
+//	@Failure		401	{object}	responder.ErrorResponse					"Unauthorized - invalid or missing JWT token"
+//	@Failure		403	{object}	responder.ErrorResponse					"Forbidden - admin role required"
+//	@Failure		500	{object}	responder.ErrorResponse					"Internal server error"
+//	@Router			/analyses/reprocess-unanalyzed [post]
+func (h *Handlers) ReprocessUnanalyzed(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	logger.Info("reprocessing unanalyzed feedbacks")
+	reprocessed, err := h.analyzerService.ReprocessUnanalyzed(ctx)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error reprocessing unanalyzed feedbacks", err)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	response := responses.ReprocessUnanalyzedResponse{ReprocessedCount: reprocessed}
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusAccepted, response))
+}
+
+// EstimateAnalysis estimates the token usage and cost of the next analysis
+//
+//	@Summary		Estimate analysis cost
+//	@Description	Estimate tokens per component and the dollar cost of analyzing the pending feedback queue, or the given feedbacks. Nothing is analyzed and the queue is not modified
+//	@Tags			analyses
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			feedback_ids	query		string	false	"Comma-separated feedback IDs to estimate as an ad-hoc analysis (default: the pending queue)"
+//	@Success		200				{object}	responses.AnalysisEstimateResponse	"Estimate computed successfully"
+//	@Failure		400				{object}	responder.ErrorResponse				"Bad request - invalid, duplicate or too many feedback IDs"
+//	@Failure		401				{object}	responder.ErrorResponse				"Unauthorized - invalid or missing JWT token"
+//	@Failure		500				{object}	responder.ErrorResponse				"Internal server error"
+//	@Router			/analyses/estimate [get]
+func (h *Handlers) EstimateAnalysis(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	feedbackIDs, err := parseUUIDList(r.URL.Query().Get("feedback_ids"))
+	if err != nil {
+		h.responder.RespondContent(resp, ce.ErrBadRequest("invalid feedback_ids", ce.WithCauseError(err)))
+		return
+	}
+
+	logger.Info("estimating analysis", "feedback_ids_count", len(feedbackIDs))
+	estimate, err := h.analyzerService.EstimateAnalysis(ctx, feedbackIDs)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error estimating analysis", err)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	response := responses.AnalysisEstimateResponse{
+		CandidateCount: estimate.CandidateCount,
+		FeedbackCount:  estimate.FeedbackCount,
+		Tokens: responses.TokenEstimateResponse{
+			SystemPrompt:     estimate.Tokens.SystemPrompt,
+			PreviousAnalysis: estimate.Tokens.PreviousAnalysis,
+			Feedbacks:        estimate.Tokens.Feedbacks,
+			PayloadOverhead:  estimate.Tokens.PayloadOverhead,
+			Response:         estimate.Tokens.Response,
+			Input:            estimate.Tokens.Input(),
+			Total:            estimate.Tokens.Total(),
+		},
+		EstimatedCostUSD: estimate.EstimatedCostUSD,
 	}
 
 	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
@@ -105,17 +443,20 @@ func (h *Handlers) ListAnalyses(resp http.ResponseWriter, r *http.Request) {
 // GetAnalysisByID retrieves an analysis by ID with its topics and analyzed feedbacks
 //
 //	@Summary		Get analysis by ID
-//	@Description	Retrieve a specific analysis with its topics and analyzed feedbacks with their associated topics
+//	@Description	Retrieve a specific analysis with its topics, analyzed feedbacks with their associated topics
+//	@Description	and the change in feedbacks per topic versus the previous analysis (topic_deltas).
+//	@Description	While the status is "processing" the summary is null, the key insights are empty and no topics are returned yet
 //	@Tags			analyses
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			id	path		string	true	"Analysis ID"	example(550e8400-e29b-41d4-a716-446655440000)
 //	@Success		200	{object}	responses.AnalysisDetailResponse	"Analysis retrieved successfully"
-//	@Failure		400	{object}	map[string]interface{}			"Bad request - invalid analysis ID format"
-//	@Failure		401	{object}	map[string]interface{}			"Unauthorized - invalid or missing JWT token"
-//	@Failure		404	{object}	map[string]interface{}			"Analysis not found"
-//	@Failure		500	{object}	map[string]interface{}			"Internal server error"
+//	@Failure		400	{object}	responder.ErrorResponse			"Bad request - invalid analysis ID format"
+//	@Failure		401	{object}	responder.ErrorResponse			"Unauthorized - invalid or missing JWT token"
+//	@Failure		404	{object}	responder.ErrorResponse			"Analysis not found"
+//	@Failure		500	{object}	responder.ErrorResponse			"Internal server error"
+//	@Failure		504	{object}	responder.ErrorResponse			"Database operation timed out"
 //	@Router			/analyses/{id} [get]
 func (h *Handlers) GetAnalysisByID(resp http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -129,7 +470,7 @@ func (h *Handlers) GetAnalysisByID(resp http.ResponseWriter, r *http.Request) {
 	}
 
 	logger.Info("getting analysis by ID", "analysis_id", analysisID)
-	analysisEntity, topics, feedbackTopics, err := h.feedbackSummaryService.GetAnalysisByID(ctx, analysisID)
+	analysisEntity, topics, feedbackTopics, topicDeltas, err := h.feedbackSummaryService.GetAnalysisByID(ctx, analysisID)
 	if err != nil {
 		logger.RecordSpanError(ctx, err)
 		logger.Error("error getting analysis", err, "analysis_id", analysisID)
@@ -146,7 +487,7 @@ func (h *Handlers) GetAnalysisByID(resp http.ResponseWriter, r *http.Request) {
 	// Get all feedbacks and build response with topics
 	feedbackResponses := make([]responses.FeedbackWithTopicsResponse, 0)
 	for feedbackID, associatedTopics := range feedbackTopics {
-		feedback, err := h.feedbackService.GetFeedbackByID(ctx, feedbackID)
+		feedback, err := h.feedbackService.GetFeedbackByID(ctx, feedbackID, false)
 		if err != nil {
 			logger.Warning("error getting feedback", "feedback_id", feedbackID, "error", err.Error())
 			continue
@@ -169,10 +510,21 @@ func (h *Handlers) GetAnalysisByID(resp http.ResponseWriter, r *http.Request) {
 		)
 	}
 
+	// Feedbacks come from a map, so order them newest first to keep the response stable
+	sort.SliceStable(
+		feedbackResponses, func(i, j int) bool {
+			if !feedbackResponses[i].CreatedAt.Equal(feedbackResponses[j].CreatedAt) {
+				return feedbackResponses[i].CreatedAt.After(feedbackResponses[j].CreatedAt)
+			}
+			return feedbackResponses[i].ID < feedbackResponses[j].ID
+		},
+	)
+
 	response := responses.AnalysisDetailResponse{
-		Analysis:  responses.AnalysisResponseFromDomain(analysisEntity),
-		Topics:    topicResponses,
-		Feedbacks: feedbackResponses,
+		Analysis:    responses.AnalysisResponseFromDomain(analysisEntity),
+		Topics:      topicResponses,
+		Feedbacks:   feedbackResponses,
+		TopicDeltas: responses.TopicDeltaResponsesFromDomain(topicDeltas),
 	}
 
 	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
@@ -181,21 +533,21 @@ func (h *Handlers) GetAnalysisByID(resp http.ResponseWriter, r *http.Request) {
 // GetTopicsWithStats retrieves all predefined topics with their statistics from the latest analysis
 //
 //	@Summary		Get topics with statistics
-//	@Description	Retrieve all predefined topics with feedback count and average rating from the latest analysis
+//	@Description	Retrieve all predefined topics with feedback count and average rating from the latest analysis. no_topics_identified is true when the latest analysis succeeded but the model found no topics
 //	@Tags			topics
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Success		200	{object}	responses.TopicStatsListResponse	"Topics with stats retrieved successfully"
-//	@Failure		401	{object}	map[string]interface{}			"Unauthorized - invalid or missing JWT token"
-//	@Failure		500	{object}	map[string]interface{}			"Internal server error"
+//	@Success		200	{object}	responses.TopicStatsListResponse{items=[]responses.TopicStatsResponse}	"Topics with stats retrieved successfully"
+//	@Failure		401	{object}	responder.ErrorResponse			"Unauthorized - invalid or missing JWT token"
+//	@Failure		500	{object}	responder.ErrorResponse			"Internal server error"
 //	@Router			/topics [get]
 func (h *Handlers) GetTopicsWithStats(resp http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	logger := h.logger.WithSpan(ctx)
 
 	logger.Info("getting topics with stats")
-	stats, err := h.feedbackSummaryService.GetTopicsWithStats(ctx)
+	overview, err := h.feedbackSummaryService.GetTopicsWithStats(ctx)
 	if err != nil {
 		logger.RecordSpanError(ctx, err)
 		logger.Error("error getting topics with stats", err)
@@ -203,9 +555,42 @@ func (h *Handlers) GetTopicsWithStats(resp http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// Convert to response format
-	topicResponses := make([]responses.TopicStatsResponse, len(stats))
-	for i, stat := range stats {
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, topicStatsListResponse(overview)))
+}
+
+// RecomputeTopicStats rebuilds the materialized topic statistics of the latest analysis
+//
+//	@Summary		Recompute topic statistics
+//	@Description	Rebuild the feedback count and average rating of every topic of the latest analysis from the current feedbacks, e.g. after feedbacks were deleted, without calling the LLM. Returns the recomputed statistics like GET /topics. Requires admin role
+//	@Tags			topics
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	responses.TopicStatsListResponse{items=[]responses.TopicStatsResponse}	"Topic stats recomputed successfully"
+//	@Failure		401	{object}	responder.ErrorResponse			"Unauthorized - invalid or missing JWT token"
+//	@Failure		403	{object}	responder.ErrorResponse			"Forbidden - admin role required"
+//	@Failure		500	{object}	responder.ErrorResponse			"Internal server error"
+//	@Router			/topics/recompute-stats [post]
+func (h *Handlers) RecomputeTopicStats(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	logger.Info("recomputing topic stats")
+	overview, err := h.feedbackSummaryService.RecomputeTopicStats(ctx)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error recomputing topic stats", err)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, topicStatsListResponse(overview)))
+}
+
+// topicStatsListResponse converts topic statistics to their response format.
+func topicStatsListResponse(overview *services.TopicStatsOverview) responses.TopicStatsListResponse {
+	topicResponses := make([]responses.TopicStatsResponse, len(overview.Topics))
+	for i, stat := range overview.Topics {
 		topicResponses[i] = responses.TopicStatsResponse{
 			Topic:         string(stat.Topic),
 			TopicName:     stat.Topic.DisplayName(),
@@ -214,12 +599,11 @@ func (h *Handlers) GetTopicsWithStats(resp http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	response := responses.TopicStatsListResponse{
-		Topics: topicResponses,
-		Total:  len(topicResponses),
+	// Every predefined topic is listed, so the list is a single page
+	return responses.TopicStatsListResponse{
+		Paginated:          *responses.NewPaginated(topicResponses, len(topicResponses), len(topicResponses), 0),
+		NoTopicsIdentified: overview.NoTopicsIdentified,
 	}
-
-	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
 }
 
 // GetTopicDetails retrieves detailed information about a specific topic with all associated feedbacks
@@ -230,29 +614,28 @@ func (h *Handlers) GetTopicsWithStats(resp http.ResponseWriter, r *http.Request)
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			topic_enum	path		string	true	"Topic enum value"	example(product_functionality_features)
+//	@Param			topic_enum	path		string	true	"Topic enum value, case-insensitive"	example(product_functionality_features)
 //	@Success		200			{object}	responses.TopicDetailsResponse	"Topic details retrieved successfully"
-//	@Failure		400			{object}	map[string]interface{}			"Bad request - invalid topic enum"
-//	@Failure		401			{object}	map[string]interface{}			"Unauthorized - invalid or missing JWT token"
-//	@Failure		404			{object}	map[string]interface{}			"Topic not found in latest analysis"
-//	@Failure		500			{object}	map[string]interface{}			"Internal server error"
+//	@Failure		400			{object}	responder.ErrorResponse			"Bad request - invalid topic enum"
+//	@Failure		401			{object}	responder.ErrorResponse			"Unauthorized - invalid or missing JWT token"
+//	@Failure		404			{object}	responder.ErrorResponse			"Topic not found in latest analysis"
+//	@Failure		500			{object}	responder.ErrorResponse			"Internal server error"
 //	@Router			/topics/{topic_enum} [get]
 func (h *Handlers) GetTopicDetails(resp http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	logger := h.logger.WithSpan(ctx)
 
-	topicEnumStr := chi.URLParam(r, "topic_enum")
-	topicEnum := analysis.Topic(topicEnumStr)
-	if !topicEnum.IsValid() {
-		h.responder.RespondContent(resp, ce.ErrBadRequest("invalid topic enum"))
+	topicEnum, appErr := parseTopicParam(r)
+	if appErr != nil {
+		h.responder.RespondContent(resp, appErr)
 		return
 	}
 
-	logger.Info("getting topic details", "topic_enum", topicEnumStr)
+	logger.Info("getting topic details", "topic_enum", topicEnum)
 	details, err := h.feedbackSummaryService.GetTopicDetails(ctx, topicEnum)
 	if err != nil {
 		logger.RecordSpanError(ctx, err)
-		logger.Error("error getting topic details", err, "topic_enum", topicEnumStr)
+		logger.Error("error getting topic details", err, "topic_enum", topicEnum)
 		h.handleSvcError(resp, err)
 		return
 	}
@@ -271,7 +654,166 @@ func (h *Handlers) GetTopicDetails(resp http.ResponseWriter, r *http.Request) {
 		FeedbackCount:    details.FeedbackCount,
 		AverageRating:    details.AverageRating,
 		Sentiment:        string(details.Sentiment),
-		Feedbacks:        feedbackResponses,
+		RatingAlignment: responses.SentimentAlignmentResponse{
+			Status:           string(details.Alignment.Status),
+			Mismatch:         details.Alignment.IsMismatch(),
+			ImpliedSentiment: string(details.Alignment.ImpliedSentiment),
+			LowRatingShare:   details.Alignment.LowRatingShare,
+			HighRatingShare:  details.Alignment.HighRatingShare,
+		},
+		Feedbacks: feedbackResponses,
+	}
+
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
+}
+
+// GetTopicHistory retrieves the results of a topic across all analyses
+//
+//	@Summary		Get topic history
+//	@Description	Retrieve the topic analyses of a topic enum across all successful analyses, oldest first.
+//	@Description	Analyses that did not identify the topic are not listed
+//	@Tags			topics
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			topic_enum	path		string	true	"Topic enum value, case-insensitive"	example(product_functionality_features)
+//	@Success		200			{object}	responses.List{items=[]responses.TopicHistoryEntryResponse}	"Topic history retrieved successfully"
+//	@Failure		400			{object}	responder.ErrorResponse									"Bad request - invalid topic enum"
+//	@Failure		401			{object}	responder.ErrorResponse									"Unauthorized - invalid or missing JWT token"
+//	@Failure		500			{object}	responder.ErrorResponse									"Internal server error"
+//	@Router			/topics/{topic_enum}/history [get]
+func (h *Handlers) GetTopicHistory(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	topicEnum, appErr := parseTopicParam(r)
+	if appErr != nil {
+		h.responder.RespondContent(resp, appErr)
+		return
+	}
+
+	logger.Info("getting topic history", "topic_enum", topicEnum)
+	history, err := h.feedbackSummaryService.GetTopicHistory(ctx, topicEnum)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting topic history", err, "topic_enum", topicEnum)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	entries := make([]responses.TopicHistoryEntryResponse, len(history))
+	for i, entry := range history {
+		entries[i] = responses.TopicHistoryEntryResponse{
+			TopicAnalysisID: entry.TopicAnalysis.ID().String(),
+			AnalysisID:      entry.TopicAnalysis.AnalysisID().String(),
+			PeriodStart:     entry.PeriodStart,
+			PeriodEnd:       entry.PeriodEnd,
+			AnalyzedAt:      entry.AnalyzedAt,
+			Summary:         entry.TopicAnalysis.Summary(),
+			Sentiment:       string(entry.TopicAnalysis.Sentiment()),
+			FeedbackCount:   entry.TopicAnalysis.FeedbackCount(),
+		}
+	}
+
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, responses.NewList(entries)))
+}
+
+// GetTopicSentimentTrend retrieves the sentiment of a topic over time as a chart series
+//
+//	@Summary		Get topic sentiment trend
+//	@Description	Retrieve the sentiment of a topic enum in every successful analysis, ordered by period end,
+//	@Description	encoded as sentiment_score (positive=1, mixed=0, negative=-1) next to the feedback count.
+//	@Description	Analyses that did not identify the topic are listed with null sentiment, score and count
+//	@Tags			topics
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			topic_enum	path		string	true	"Topic enum value, case-insensitive"	example(product_functionality_features)
+//	@Success		200			{object}	responses.List{items=[]responses.TopicSentimentTrendPointResponse}	"Topic sentiment trend retrieved successfully"
+//	@Failure		400			{object}	responder.ErrorResponse											"Bad request - invalid topic enum"
+//	@Failure		401			{object}	responder.ErrorResponse											"Unauthorized - invalid or missing JWT token"
+//	@Failure		500			{object}	responder.ErrorResponse											"Internal server error"
+//	@Router			/topics/{topic_enum}/sentiment-trend [get]
+func (h *Handlers) GetTopicSentimentTrend(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	topicEnum, appErr := parseTopicParam(r)
+	if appErr != nil {
+		h.responder.RespondContent(resp, appErr)
+		return
+	}
+
+	logger.Info("getting topic sentiment trend", "topic_enum", topicEnum)
+	trend, err := h.feedbackSummaryService.GetTopicSentimentTrend(ctx, topicEnum)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting topic sentiment trend", err, "topic_enum", topicEnum)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	points := make([]responses.TopicSentimentTrendPointResponse, len(trend))
+	for i, point := range trend {
+		points[i] = responses.TopicSentimentTrendPointResponse{
+			AnalysisID:    point.AnalysisID.String(),
+			PeriodEnd:     point.PeriodEnd,
+			FeedbackCount: point.FeedbackCount,
+		}
+		if point.Sentiment.IsSome() {
+			sentiment := point.Sentiment.Unwrap()
+			points[i].Sentiment = optional.Some(string(sentiment))
+			points[i].SentimentScore = optional.Some(sentiment.Score())
+		}
+	}
+
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, responses.NewList(points)))
+}
+
+// GetTopicAnalysisByID retrieves a single topic analysis with its assigned feedbacks
+//
+//	@Summary		Get topic analysis by ID
+//	@Description	Retrieve a topic analysis of any analysis, not only the latest, with its assigned feedbacks (newest first).
+//	@Description	Use it to link to a specific topic result within a historical analysis
+//	@Tags			topics
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Topic analysis ID"	example(550e8400-e29b-41d4-a716-446655440000)
+//	@Success		200	{object}	responses.TopicAnalysisDetailResponse	"Topic analysis retrieved successfully"
+//	@Failure		400	{object}	responder.ErrorResponse				"Bad request - invalid topic analysis ID format"
+//	@Failure		401	{object}	responder.ErrorResponse				"Unauthorized - invalid or missing JWT token"
+//	@Failure		404	{object}	responder.ErrorResponse				"Topic analysis not found"
+//	@Failure		500	{object}	responder.ErrorResponse				"Internal server error"
+//	@Router			/topic-analyses/{id} [get]
+func (h *Handlers) GetTopicAnalysisByID(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	topicID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.responder.RespondContent(resp, ce.ErrBadRequest("invalid topic analysis ID format"))
+		return
+	}
+
+	logger.Info("getting topic analysis by ID", "topic_id", topicID)
+	topicAnalysis, feedbacks, err := h.feedbackSummaryService.GetTopicAnalysisByID(ctx, topicID)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting topic analysis", err, "topic_id", topicID)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	feedbackResponses := make([]responses.FeedbackResponse, len(feedbacks))
+	for i, fb := range feedbacks {
+		feedbackResponses[i] = *responses.FeedbackResponseFromDomain(fb)
+	}
+
+	response := responses.TopicAnalysisDetailResponse{
+		TopicAnalysisResponse: *responses.TopicAnalysisResponseFromDomain(topicAnalysis),
+		AnalysisID:            topicAnalysis.AnalysisID().String(),
+		Feedbacks:             feedbackResponses,
 	}
 
 	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
@@ -0,0 +1,382 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/transport/responses"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/http/responder"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
+	"go.uber.org/mock/gomock"
+)
+
+func newAnalysisRequest(analysisID string) *http.Request {
+	return withURLParam(httptest.NewRequest(http.MethodGet, "/analyses/x", nil), "id", analysisID)
+}
+
+func TestHandlers_GetAnalysisByID(t *testing.T) {
+	th := newTestHandlers(t)
+
+	now := time.Now().UTC()
+	analysisEntity, err := analysis.NewBuilder().
+		WithPeriod(now.Add(-time.Hour), now).
+		WithFeedbackCount(1).
+		WithOverallSummary("Users like the product").
+		WithSentiment(analysis.SentimentPositive).
+		WithKeyInsights([]string{"Fast onboarding"}).
+		WithModel("gpt-test").
+		WithStatus(analysis.StatusSuccess).
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to build analysis: %v", err)
+	}
+	topic := analysis.NewTopicAnalysisBuilder().
+		WithAnalysisID(analysisEntity.ID()).
+		WithTopic(analysis.TopicUIUX).
+		WithSummary("The interface is clean").
+		WithFeedbackCount(1).
+		WithSentiment(analysis.SentimentPositive).
+		BuildUnchecked()
+	fb, err := feedback.NewBuilder().BuildNew(uuid.New(), 5, "Clean interface")
+	if err != nil {
+		t.Fatalf("Failed to build feedback: %v", err)
+	}
+	deltas := []analysis.TopicDelta{{Topic: analysis.TopicUIUX, PreviousCount: 0, CurrentCount: 1}}
+
+	th.feedbackSummaryService.EXPECT().
+		GetAnalysisByID(gomock.Any(), analysisEntity.ID()).
+		Return(
+			analysisEntity,
+			[]*analysis.TopicAnalysis{topic},
+			map[uuid.UUID][]*analysis.TopicAnalysis{fb.ID(): {topic}},
+			deltas,
+			nil,
+		)
+	th.feedbackService.EXPECT().GetFeedbackByID(gomock.Any(), fb.ID(), false).Return(fb, nil)
+
+	rec := httptest.NewRecorder()
+	th.GetAnalysisByID(rec, newAnalysisRequest(analysisEntity.ID().String()))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	body := decodeBody[responses.AnalysisDetailResponse](t, rec)
+	if body.Analysis == nil || body.Analysis.ID != analysisEntity.ID().String() {
+		t.Fatalf("Expected analysis %s, got %+v", analysisEntity.ID(), body.Analysis)
+	}
+	if body.Analysis.OverallSummary.UnwrapOr("") != "Users like the product" {
+		t.Errorf("Expected the overall summary, got %+v", body.Analysis.OverallSummary)
+	}
+	if len(body.Topics) != 1 || body.Topics[0].Topic != string(analysis.TopicUIUX) {
+		t.Errorf("Expected the ui_ux topic, got %+v", body.Topics)
+	}
+	if len(body.Feedbacks) != 1 || body.Feedbacks[0].ID != fb.ID().String() {
+		t.Fatalf("Expected feedback %s, got %+v", fb.ID(), body.Feedbacks)
+	}
+	if got := body.Feedbacks[0].Topics; len(got) != 1 || got[0] != string(analysis.TopicUIUX) {
+		t.Errorf("Expected the feedback to carry its topic, got %v", got)
+	}
+	if len(body.TopicDeltas) != 1 || body.TopicDeltas[0].Delta != 1 {
+		t.Errorf("Expected a topic delta of 1, got %+v", body.TopicDeltas)
+	}
+}
+
+func TestHandlers_GetAnalysisByID_InvalidID(t *testing.T) {
+	th := newTestHandlers(t)
+
+	rec := httptest.NewRecorder()
+	th.GetAnalysisByID(rec, newAnalysisRequest("not-a-uuid"))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", rec.Code)
+	}
+	if body := decodeBody[responder.ErrorResponse](t, rec); body.Code != ce.ErrorCodeBadRequest.Code {
+		t.Errorf("Expected code %q, got %q", ce.ErrorCodeBadRequest.Code, body.Code)
+	}
+}
+
+func TestHandlers_GetAnalysisByID_NotFound(t *testing.T) {
+	th := newTestHandlers(t)
+
+	analysisID := uuid.New()
+	th.feedbackSummaryService.EXPECT().
+		GetAnalysisByID(gomock.Any(), analysisID).
+		Return(
+			nil, nil, nil, nil, &ce.GenericError{
+				Code:       ce.ErrorCodeNotFound,
+				Message:    "Analysis not found",
+				UserFacing: true,
+			},
+		)
+
+	rec := httptest.NewRecorder()
+	th.GetAnalysisByID(rec, newAnalysisRequest(analysisID.String()))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("Expected status 404, got %d", rec.Code)
+	}
+	if body := decodeBody[responder.ErrorResponse](t, rec); body.Code != ce.ErrorCodeNotFound.Code {
+		t.Errorf("Expected code %q, got %q", ce.ErrorCodeNotFound.Code, body.Code)
+	}
+}
+
+func TestHandlers_GetAnalysisEvents(t *testing.T) {
+	th := newTestHandlers(t)
+
+	analysisID := uuid.New()
+	now := time.Now().UTC()
+	events := []*analysis.Event{
+		{
+			AnalysisID: analysisID,
+			Type:       analysis.EventCreated,
+			Details:    map[string]any{"feedback_count": 3},
+			OccurredAt: now,
+		},
+		{
+			AnalysisID: analysisID,
+			Type:       analysis.EventLLMReturned,
+			Duration:   optional.Some(1250 * time.Millisecond),
+			OccurredAt: now.Add(time.Second),
+		},
+	}
+	th.feedbackSummaryService.EXPECT().GetAnalysisEvents(gomock.Any(), analysisID).Return(events, nil)
+
+	rec := httptest.NewRecorder()
+	th.GetAnalysisEvents(rec, newAnalysisRequest(analysisID.String()))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	body := decodeBody[responses.AnalysisEventsResponse](t, rec)
+	if body.AnalysisID != analysisID.String() || len(body.Events) != 2 {
+		t.Fatalf("Expected 2 events of analysis %s, got %+v", analysisID, body)
+	}
+	if body.Events[0].Type != "created" || body.Events[0].DurationMs.IsSome() {
+		t.Errorf("Expected a created event without duration first, got %+v", body.Events[0])
+	}
+	if body.Events[1].Type != "llm_returned" || body.Events[1].DurationMs.UnwrapOr(0) != 1250 {
+		t.Errorf("Expected an llm_returned event of 1250 ms second, got %+v", body.Events[1])
+	}
+}
+
+func TestHandlers_GetLatestAnalysis_RepresentativeOnly(t *testing.T) {
+	th := newTestHandlers(t)
+
+	now := time.Now().UTC()
+	analysisEntity := analysis.NewBuilder().
+		WithPeriod(now.Add(-time.Hour), now).
+		WithFeedbackCount(20).
+		WithModel("gpt-test").
+		WithStatus(analysis.StatusProcessing).
+		BuildUnchecked()
+	th.feedbackSummaryService.EXPECT().GetLatestAnalysis(gomock.Any(), true).Return(analysisEntity, nil)
+
+	rec := httptest.NewRecorder()
+	th.GetLatestAnalysis(rec, httptest.NewRequest(http.MethodGet, "/analyses/latest?representative_only=true", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if body := decodeBody[responses.AnalysisResponse](t, rec); !body.Representative {
+		t.Errorf("Expected the analysis to be representative, got %+v", body)
+	}
+}
+
+func TestHandlers_GetLatestAnalysis_InvalidRepresentativeOnly(t *testing.T) {
+	th := newTestHandlers(t)
+
+	rec := httptest.NewRecorder()
+	th.GetLatestAnalysis(rec, httptest.NewRequest(http.MethodGet, "/analyses/latest?representative_only=maybe", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", rec.Code)
+	}
+}
+
+func TestHandlers_ListAnalyses(t *testing.T) {
+	th := newTestHandlers(t)
+
+	now := time.Now().UTC()
+	analysisEntity := analysis.NewBuilder().
+		WithPeriod(now.Add(-time.Hour), now).
+		WithFeedbackCount(3).
+		WithModel("gpt-test").
+		WithStatus(analysis.StatusSuccess).
+		BuildUnchecked()
+	// Total comes from counting the analyses, not from the number of items returned
+	th.feedbackSummaryService.EXPECT().
+		ListAnalyses(gomock.Any(), 1, 2).
+		Return(
+			&services.Page[*analysis.Analysis]{
+				Items:  []*analysis.Analysis{analysisEntity},
+				Total:  5,
+				Limit:  1,
+				Offset: 2,
+			},
+			nil,
+		)
+
+	rec := httptest.NewRecorder()
+	th.ListAnalyses(rec, httptest.NewRequest(http.MethodGet, "/analyses?limit=1&offset=2", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	body := decodeBody[responses.Paginated[responses.AnalysisResponse]](t, rec)
+	if len(body.Items) != 1 || body.Items[0].ID != analysisEntity.ID().String() {
+		t.Fatalf("Expected the listed analysis under items, got %+v", body.Items)
+	}
+	want := responses.Pagination{Total: 5, Limit: 1, Offset: 2, HasMore: true}
+	if body.Pagination != want {
+		t.Errorf("Expected pagination %+v, got %+v", want, body.Pagination)
+	}
+}
+
+func TestHandlers_GetTopicsWithStats(t *testing.T) {
+	th := newTestHandlers(t)
+
+	th.feedbackSummaryService.EXPECT().
+		GetTopicsWithStats(gomock.Any()).
+		Return(
+			&services.TopicStatsOverview{
+				Topics: []services.TopicStats{
+					{Topic: analysis.TopicPricingLicensing, FeedbackCount: 2, AverageRating: 2.5},
+					{Topic: analysis.TopicUIUX},
+				},
+				NoTopicsIdentified: true,
+			},
+			nil,
+		)
+
+	rec := httptest.NewRecorder()
+	th.GetTopicsWithStats(rec, httptest.NewRequest(http.MethodGet, "/topics", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	body := decodeBody[responses.TopicStatsListResponse](t, rec)
+	if len(body.Items) != 2 || body.Items[0].Topic != string(analysis.TopicPricingLicensing) {
+		t.Fatalf("Expected the topics under items in service order, got %+v", body.Items)
+	}
+	// The complete list of topics is a single page
+	want := responses.Pagination{Total: 2, Limit: 2, Offset: 0, HasMore: false}
+	if body.Pagination != want {
+		t.Errorf("Expected pagination %+v, got %+v", want, body.Pagination)
+	}
+	if !body.NoTopicsIdentified {
+		t.Error("Expected no_topics_identified to be set")
+	}
+}
+
+func TestHandlers_GetAnalysisReport(t *testing.T) {
+	now := time.Date(2026, 3, 8, 12, 0, 0, 0, time.UTC)
+	analysisEntity, err := analysis.NewBuilder().
+		WithPeriod(now.AddDate(0, 0, -7), now).
+		WithFeedbackCount(3).
+		WithOverallSummary("Users like the product").
+		WithSentiment(analysis.SentimentPositive).
+		WithKeyInsights([]string{"Fast onboarding"}).
+		WithModel("gpt-test").
+		WithStatus(analysis.StatusSuccess).
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to build analysis: %v", err)
+	}
+	// Topics arrive in the order the service returns them, most feedbacks first
+	topics := []*analysis.TopicAnalysis{
+		analysis.NewTopicAnalysisBuilder().
+			WithAnalysisID(analysisEntity.ID()).
+			WithTopic(analysis.TopicPricingLicensing).
+			WithSummary("Pricing is confusing").
+			WithFeedbackCount(2).
+			WithSentiment(analysis.SentimentNegative).
+			BuildUnchecked(),
+		analysis.NewTopicAnalysisBuilder().
+			WithAnalysisID(analysisEntity.ID()).
+			WithTopic(analysis.TopicUIUX).
+			WithSummary("The interface is <clean>").
+			WithFeedbackCount(1).
+			WithSentiment(analysis.SentimentPositive).
+			BuildUnchecked(),
+	}
+
+	tests := []struct {
+		format      string
+		contentType string
+		want        []string
+	}{
+		{
+			format:      "",
+			contentType: "text/markdown; charset=utf-8",
+			want: []string{
+				"# Feedback analysis 2026-03-01 to 2026-03-08",
+				"- Fast onboarding",
+				"### " + analysis.TopicPricingLicensing.DisplayName(),
+				"The interface is <clean>",
+			},
+		},
+		{
+			format:      "html",
+			contentType: "text/html; charset=utf-8",
+			want: []string{
+				"<h1>Feedback analysis 2026-03-01 to 2026-03-08</h1>",
+				"<li>Fast onboarding</li>",
+				"The interface is &lt;clean&gt;",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			"format "+tt.format, func(t *testing.T) {
+				th := newTestHandlers(t)
+				th.feedbackSummaryService.EXPECT().
+					GetAnalysisByID(gomock.Any(), analysisEntity.ID()).
+					Return(analysisEntity, topics, nil, nil, nil)
+
+				req := newAnalysisRequest(analysisEntity.ID().String())
+				req.URL.RawQuery = "format=" + tt.format
+				rec := httptest.NewRecorder()
+				th.GetAnalysisReport(rec, req)
+
+				if rec.Code != http.StatusOK {
+					t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+				}
+				if got := rec.Header().Get("Content-Type"); got != tt.contentType {
+					t.Errorf("Expected content type %q, got %q", tt.contentType, got)
+				}
+				body := rec.Body.String()
+				for _, want := range tt.want {
+					if !strings.Contains(body, want) {
+						t.Errorf("Expected the report to contain %q, got:\n%s", want, body)
+					}
+				}
+				// The report keeps the topic order returned by the service
+				if strings.Index(body, "Pricing is confusing") > strings.Index(body, "The interface is") {
+					t.Errorf("Expected the pricing topic before the ui_ux topic, got:\n%s", body)
+				}
+			},
+		)
+	}
+}
+
+func TestHandlers_GetAnalysisReport_InvalidFormat(t *testing.T) {
+	th := newTestHandlers(t)
+
+	req := newAnalysisRequest(uuid.New().String())
+	req.URL.RawQuery = "format=pdf"
+	rec := httptest.NewRecorder()
+	th.GetAnalysisReport(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", rec.Code)
+	}
+}
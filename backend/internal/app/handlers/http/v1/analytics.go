@@ -0,0 +1,124 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/handlers/http/middleware"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/transport/responses"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/http/responder"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+)
+
+func (h *Handlers) registerAnalyticsRoutes(router chi.Router) {
+	router.Route(
+		"/analytics", func(r chi.Router) {
+			r.Use(middleware.RequireRole("admin", h.logger, h.responder))
+			r.Get(
+				"/tag-topic-agreement",
+				trace.InstrumentHandlerFunc(h.GetTagTopicAgreement, "GET /analytics/tag-topic-agreement", h),
+			)
+			r.Get(
+				"/overview",
+				trace.InstrumentHandlerFunc(h.GetAnalyticsOverview, "GET /analytics/overview", h),
+			)
+			r.Get(
+				"/token-accuracy",
+				trace.InstrumentHandlerFunc(h.GetTokenAccuracy, "GET /analytics/token-accuracy", h),
+			)
+		},
+	)
+}
+
+// GetTagTopicAgreement compares the tags attached by submitters with the topics assigned by the LLM
+//
+//	@Summary		Get tag/topic agreement
+//	@Description	For feedbacks with user tags, report per tag how often the LLM assigned each topic (based on the
+//	@Description	latest successful analysis of every feedback) and how often it agrees on the dominant topic of the tag.
+//	@Description	Low agreement rates point at categories the topic taxonomy does not capture. Requires admin role
+//	@Tags			analytics
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	responses.TagTopicAgreementResponse	"Tag/topic agreement retrieved successfully"
+//	@Failure		401	{object}	responder.ErrorResponse				"Unauthorized - invalid or missing JWT token"
+//	@Failure		403	{object}	responder.ErrorResponse				"Forbidden - admin role required"
+//	@Failure		500	{object}	responder.ErrorResponse				"Internal server error"
+//	@Router			/analytics/tag-topic-agreement [get]
+func (h *Handlers) GetTagTopicAgreement(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	agreement, err := h.feedbackSummaryService.GetTagTopicAgreement(ctx)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting tag topic agreement", err)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	response := responses.TagTopicAgreementResponseFromService(agreement)
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
+}
+
+// GetAnalyticsOverview returns totals across all analyses
+//
+//	@Summary		Get analytics overview
+//	@Description	Report totals across all analyses: number of analyses, distinct analyzed feedbacks, tokens and
+//	@Description	their estimated cost, average feedbacks per analysis and the most common topic (based on the
+//	@Description	latest successful analysis of every feedback). Requires admin role
+//	@Tags			analytics
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	responses.AnalyticsOverviewResponse	"Analytics overview retrieved successfully"
+//	@Failure		401	{object}	responder.ErrorResponse				"Unauthorized - invalid or missing JWT token"
+//	@Failure		403	{object}	responder.ErrorResponse				"Forbidden - admin role required"
+//	@Failure		500	{object}	responder.ErrorResponse				"Internal server error"
+//	@Router			/analytics/overview [get]
+func (h *Handlers) GetAnalyticsOverview(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	overview, err := h.feedbackSummaryService.GetAnalyticsOverview(ctx)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting analytics overview", err)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	response := responses.AnalyticsOverviewResponseFromService(overview)
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
+}
+
+// GetTokenAccuracy returns how well the token estimator matches the token usage reported by the provider
+//
+//	@Summary		Get token estimator accuracy
+//	@Description	Report the distribution of the ratio of estimated to actual total tokens over the analyses with
+//	@Description	recorded token usage, including failed analyses for which the provider reported usage. Usage is
+//	@Description	only recorded while record_token_accuracy is enabled. Requires admin role
+//	@Tags			analytics
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	responses.TokenAccuracyResponse	"Token accuracy retrieved successfully"
+//	@Failure		401	{object}	responder.ErrorResponse			"Unauthorized - invalid or missing JWT token"
+//	@Failure		403	{object}	responder.ErrorResponse			"Forbidden - admin role required"
+//	@Failure		500	{object}	responder.ErrorResponse			"Internal server error"
+//	@Router			/analytics/token-accuracy [get]
+func (h *Handlers) GetTokenAccuracy(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	accuracy, err := h.feedbackSummaryService.GetTokenAccuracy(ctx)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting token accuracy", err)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	response := responses.TokenAccuracyResponseFromService(accuracy)
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
+}
@@ -30,9 +30,10 @@ func (h *Handlers) registerAuthRoutes(router chi.Router) {
 //	@Produce		json
 //	@Param			request	body		requests.RegisterUserRequest	true	"User registration request"
 //	@Success		201		{object}	responses.RegisterUserResponse	"User registered successfully"
-//	@Failure		400		{object}	map[string]interface{}			"Bad request - invalid request body or validation error"
-//	@Failure		409		{object}	map[string]interface{}			"Conflict - email already exists"
-//	@Failure		500		{object}	map[string]interface{}			"Internal server error"
+//	@Failure		400		{object}	responder.ErrorResponse			"Bad request - invalid request body or validation error"
+//	@Failure		403		{object}	responder.ErrorResponse			"Forbidden - registration is disabled"
+//	@Failure		409		{object}	responder.ErrorResponse			"Conflict - email already exists"
+//	@Failure		500		{object}	responder.ErrorResponse			"Internal server error"
 //	@Router			/auth/register [post]
 func (h *Handlers) RegisterUser(resp http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -67,9 +68,9 @@ func (h *Handlers) RegisterUser(resp http.ResponseWriter, r *http.Request) {
 //	@Produce		json
 //	@Param			request	body		requests.LoginUserRequest	true	"User login request"
 //	@Success		200		{object}	responses.LoginUserResponse	"User authenticated successfully"
-//	@Failure		400		{object}	map[string]interface{}		"Bad request - invalid request body"
-//	@Failure		401		{object}	map[string]interface{}		"Unauthorized - invalid credentials"
-//	@Failure		500		{object}	map[string]interface{}		"Internal server error"
+//	@Failure		400		{object}	responder.ErrorResponse		"Bad request - invalid request body"
+//	@Failure		401		{object}	responder.ErrorResponse		"Unauthorized - invalid credentials"
+//	@Failure		500		{object}	responder.ErrorResponse		"Internal server error"
 //	@Router			/auth/login [post]
 func (h *Handlers) LoginUser(resp http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -0,0 +1,163 @@
+package v1
+
+import (
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
+)
+
+// exportFlushRows is the number of CSV rows after which the export is flushed to the client.
+const exportFlushRows = 100
+
+// feedbackCSVHeader lists the columns of the feedback CSV export.
+var feedbackCSVHeader = []string{"id", "user_id", "rating", "comment", "created_at"}
+
+// feedbackCSVWriter writes feedbacks as CSV rows to the response, flushing every exportFlushRows rows.
+// The response headers and the CSV header row are written along with the first row.
+type feedbackCSVWriter struct {
+	resp    http.ResponseWriter
+	csv     *csv.Writer
+	started bool
+	rows    int
+}
+
+func newFeedbackCSVWriter(resp http.ResponseWriter) *feedbackCSVWriter {
+	return &feedbackCSVWriter{resp: resp, csv: csv.NewWriter(resp)}
+}
+
+// write appends a feedback to the export. Quoting and escaping of the comment is left to encoding/csv, the comment
+// is only neutralized against formula injection, see csvSafeCell.
+func (w *feedbackCSVWriter) write(fb *feedback.Feedback) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	if err := w.csv.Write(
+		[]string{
+			fb.ID().String(),
+			fb.UserID().String(),
+			strconv.Itoa(fb.Rating().Value()),
+			csvSafeCell(fb.Comment().String()),
+			fb.CreatedAt().UTC().Format(time.RFC3339),
+		},
+	); err != nil {
+		return err
+	}
+
+	w.rows++
+	if w.rows%exportFlushRows == 0 {
+		return w.flush()
+	}
+	return nil
+}
+
+// csvSafeCell prefixes a value starting with a character that spreadsheet applications interpret as the start of
+// a formula with a single quote, so that a submitted comment cannot run a formula when the export is opened.
+func csvSafeCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// close completes the export, writing the header row if no feedback was exported.
+func (w *feedbackCSVWriter) close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	return w.flush()
+}
+
+func (w *feedbackCSVWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+
+	w.resp.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.resp.Header().Set("Content-Disposition", `attachment; filename="feedbacks.csv"`)
+	w.resp.WriteHeader(http.StatusOK)
+	return w.csv.Write(feedbackCSVHeader)
+}
+
+func (w *feedbackCSVWriter) flush() error {
+	w.csv.Flush()
+	if err := w.csv.Error(); err != nil {
+		return err
+	}
+
+	if err := http.NewResponseController(w.resp).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// parseExportFilter reads the filters of the feedback export from the query parameters.
+func parseExportFilter(r *http.Request) (services.FeedbackExportFilter, ce.ApplicationError) {
+	query := r.URL.Query()
+	filter := services.FeedbackExportFilter{
+		Source:     query.Get("source"),
+		Tag:        query.Get("tag"),
+		Platform:   query.Get("platform"),
+		AppVersion: query.Get("app_version"),
+	}
+
+	var appErr ce.ApplicationError
+	if filter.CreatedFrom, appErr = parseTimeParam(r, "created_from"); appErr != nil {
+		return filter, appErr
+	}
+	if filter.CreatedTo, appErr = parseTimeParam(r, "created_to"); appErr != nil {
+		return filter, appErr
+	}
+	if filter.MinRating, appErr = parseIntParam(r, "min_rating"); appErr != nil {
+		return filter, appErr
+	}
+	if filter.MaxRating, appErr = parseIntParam(r, "max_rating"); appErr != nil {
+		return filter, appErr
+	}
+
+	return filter, nil
+}
+
+// parseTimeParam reads a query parameter holding an RFC 3339 timestamp or a YYYY-MM-DD date, taken as midnight UTC.
+// A missing parameter yields the zero time.
+func parseTimeParam(r *http.Request, name string) (time.Time, ce.ApplicationError) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, ce.ErrBadRequest(
+			name+" must be an RFC 3339 timestamp or a YYYY-MM-DD date",
+			ce.WithCauseError(err),
+		)
+	}
+	return t, nil
+}
+
+// parseIntParam reads an optional integer query parameter.
+func parseIntParam(r *http.Request, name string) (optional.Optional[int], ce.ApplicationError) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return optional.None[int](), nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return optional.None[int](), ce.ErrBadRequest(name+" must be an integer", ce.WithCauseError(err))
+	}
+	return optional.Some(parsed), nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/handlers/http/middleware"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/transport/requests"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/transport/responses"
 	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
@@ -17,7 +18,23 @@ func (h *Handlers) registerFeedbackRoutes(router chi.Router) {
 	router.Route(
 		"/feedbacks", func(r chi.Router) {
 			r.Post("/", trace.InstrumentHandlerFunc(h.CreateFeedback, "POST /feedbacks", h))
+			// Admin-only route: only users with "admin" role can delete feedbacks
+			r.With(middleware.RequireRole("admin", h.logger, h.responder)).
+				Post(
+					"/batch-delete",
+					trace.InstrumentHandlerFunc(h.BatchDeleteFeedbacks, "POST /feedbacks/batch-delete", h),
+				)
+			// Admin-only route: only users with "admin" role can export all feedbacks
+			r.With(middleware.RequireRole("admin", h.logger, h.responder)).
+				Get("/export", trace.InstrumentHandlerFunc(h.ExportFeedbacks, "GET /feedbacks/export", h))
+			// Admin-only route: only users with "admin" role can list feedbacks left out of analysis
+			r.With(middleware.RequireRole("admin", h.logger, h.responder)).
+				Get("/unanalyzed", trace.InstrumentHandlerFunc(h.ListUnanalyzedFeedbacks, "GET /feedbacks/unanalyzed", h))
 			r.Get("/{id}", trace.InstrumentHandlerFunc(h.GetFeedbackByID, "GET /feedbacks/{id}", h))
+			r.Get(
+				"/{id}/analyses",
+				trace.InstrumentHandlerFunc(h.GetFeedbackAnalyses, "GET /feedbacks/{id}/analyses", h),
+			)
 			r.Get("/", trace.InstrumentHandlerFunc(h.ListFeedbacks, "GET /feedbacks", h))
 			// Admin-only route: only users with "admin" role can delete feedbacks
 			r.With(middleware.RequireRole("admin", h.logger, h.responder)).
@@ -29,7 +46,7 @@ func (h *Handlers) registerFeedbackRoutes(router chi.Router) {
 // CreateFeedback creates a new feedback submission
 //
 //	@Summary		Create a new feedback
-//	@Description	Create a new feedback submission with rating and comment
+//	@Description	Create a new feedback submission with rating and comment. Users other than admins can submit at most daily_feedback_quota feedbacks per UTC day, if configured
 //	@Tags			feedbacks
 //	@Accept			json
 //	@Produce		json
@@ -42,9 +59,11 @@ func (h *Handlers) registerFeedbackRoutes(router chi.Router) {
 //	@example		empty_comment	file://examples/feedback/create_empty_comment.json
 //	@example		comment_too_long	file://examples/feedback/create_comment_too_long.json
 //	@Success		201		{object}	responses.FeedbackResponse		"Feedback created successfully"
-//	@Failure		400		{object}	map[string]interface{}			"Bad request - invalid request body"
-//	@Failure		401		{object}	map[string]interface{}			"Unauthorized - invalid or missing JWT token"
-//	@Failure		500		{object}	map[string]interface{}			"Internal server error"
+//	@Failure		400		{object}	responder.ErrorResponse			"Bad request - invalid request body, or comment language not accepted (details.language, details.accepted_languages)"
+//	@Failure		401		{object}	responder.ErrorResponse			"Unauthorized - invalid or missing JWT token"
+//	@Failure		422		{object}	responder.ErrorResponse			"Unprocessable entity - comment flagged by content moderation (details.categories)"
+//	@Failure		429		{object}	responder.ErrorResponse			"Too many requests - submission cooldown is active or daily feedback quota reached (details.reset_at)"
+//	@Failure		500		{object}	responder.ErrorResponse			"Internal server error"
 //	@Router			/feedbacks [post]
 func (h *Handlers) CreateFeedback(resp http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -65,7 +84,9 @@ func (h *Handlers) CreateFeedback(resp http.ResponseWriter, r *http.Request) {
 	}
 
 	logger.Info("creating feedback", "rating", req.Data.Rating)
-	feedback, err := h.feedbackService.CreateFeedback(ctx, userID, &req.Data)
+	// Admins are not subject to the daily feedback quota
+	exemptFromQuota := middleware.HasRole(middleware.GetUserClaims(r), "admin")
+	feedback, err := h.feedbackService.CreateFeedback(ctx, userID, &req.Data, exemptFromQuota)
 	if err != nil {
 		logger.RecordSpanError(ctx, err)
 		logger.Error("error creating feedback", err)
@@ -85,12 +106,14 @@ func (h *Handlers) CreateFeedback(resp http.ResponseWriter, r *http.Request) {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			id	path		string	true	"Feedback ID"	example(550e8400-e29b-41d4-a716-446655440000)
+//	@Param			id				path		string	true	"Feedback ID"	example(550e8400-e29b-41d4-a716-446655440000)
+//	@Param			include_deleted	query		bool	false	"Also return the feedback if it was soft-deleted (admin only)"	default(false)
 //	@Success		200	{object}	responses.FeedbackResponse	"Feedback retrieved successfully"
-//	@Failure		400	{object}	map[string]interface{}		"Bad request - invalid feedback ID format"
-//	@Failure		401	{object}	map[string]interface{}		"Unauthorized - invalid or missing JWT token"
-//	@Failure		404	{object}	map[string]interface{}		"Feedback not found"
-//	@Failure		500	{object}	map[string]interface{}		"Internal server error"
+//	@Failure		400	{object}	responder.ErrorResponse		"Bad request - invalid feedback ID format or include_deleted value"
+//	@Failure		401	{object}	responder.ErrorResponse		"Unauthorized - invalid or missing JWT token"
+//	@Failure		403	{object}	responder.ErrorResponse		"Forbidden - include_deleted requires the admin role"
+//	@Failure		404	{object}	responder.ErrorResponse		"Feedback not found"
+//	@Failure		500	{object}	responder.ErrorResponse		"Internal server error"
 //	@Router			/feedbacks/{id} [get]
 func (h *Handlers) GetFeedbackByID(resp http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -103,8 +126,14 @@ func (h *Handlers) GetFeedbackByID(resp http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	logger.Info("getting feedback by ID", "feedback_id", feedbackID)
-	feedback, err := h.feedbackService.GetFeedbackByID(ctx, feedbackID)
+	includeDeleted, appErr := parseIncludeDeleted(r)
+	if appErr != nil {
+		h.responder.RespondContent(resp, appErr)
+		return
+	}
+
+	logger.Info("getting feedback by ID", "feedback_id", feedbackID, "include_deleted", includeDeleted)
+	feedback, err := h.feedbackService.GetFeedbackByID(ctx, feedbackID, includeDeleted)
 	if err != nil {
 		logger.RecordSpanError(ctx, err)
 		logger.Error("error getting feedback", err, "feedback_id", feedbackID)
@@ -116,6 +145,56 @@ func (h *Handlers) GetFeedbackByID(resp http.ResponseWriter, r *http.Request) {
 	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
 }
 
+// GetFeedbackAnalyses retrieves the analyses a feedback contributed to
+//
+//	@Summary		Get analyses of a feedback
+//	@Description	Retrieve the analyses of any status that included a feedback, newest first, each with the topics the feedback was assigned to.
+//	@Description	Traces a single feedback back to the aggregate insights it informed
+//	@Tags			feedbacks
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string	true	"Feedback ID"	example(550e8400-e29b-41d4-a716-446655440000)
+//	@Success		200	{object}	responses.List{items=[]responses.FeedbackAnalysisResponse}	"Analyses of the feedback retrieved successfully"
+//	@Failure		400	{object}	responder.ErrorResponse		"Bad request - invalid feedback ID format"
+//	@Failure		401	{object}	responder.ErrorResponse		"Unauthorized - invalid or missing JWT token"
+//	@Failure		404	{object}	responder.ErrorResponse		"Feedback not found"
+//	@Failure		500	{object}	responder.ErrorResponse		"Internal server error"
+//	@Router			/feedbacks/{id}/analyses [get]
+func (h *Handlers) GetFeedbackAnalyses(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	feedbackID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.responder.RespondContent(resp, ce.ErrBadRequest("invalid feedback ID format"))
+		return
+	}
+
+	logger.Info("getting analyses of feedback", "feedback_id", feedbackID)
+	entries, err := h.feedbackSummaryService.GetFeedbackAnalyses(ctx, feedbackID)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting analyses of feedback", err, "feedback_id", feedbackID)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	items := make([]responses.FeedbackAnalysisResponse, len(entries))
+	for i, entry := range entries {
+		topics := make([]responses.TopicAnalysisResponse, len(entry.Topics))
+		for j, topic := range entry.Topics {
+			topics[j] = *responses.TopicAnalysisResponseFromDomain(topic)
+		}
+		items[i] = responses.FeedbackAnalysisResponse{
+			Analysis: responses.AnalysisResponseFromDomain(entry.Analysis),
+			Topics:   topics,
+		}
+	}
+
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, responses.NewList(items)))
+}
+
 // ListFeedbacks retrieves a list of feedback entries
 //
 //	@Summary		List feedbacks
@@ -126,10 +205,17 @@ func (h *Handlers) GetFeedbackByID(resp http.ResponseWriter, r *http.Request) {
 //	@Security		BearerAuth
 //	@Param			limit	query		int		false	"Maximum number of feedbacks to return (default: 100)"	example(10)
 //	@Param			offset	query		int		false	"Number of feedbacks to skip (default: 0)"	example(0)
-//	@Success		200		{object}	responses.FeedbackListResponse	"Feedbacks retrieved successfully"
-//	@Failure		400		{object}	map[string]interface{}			"Bad request - invalid query parameters"
-//	@Failure		401		{object}	map[string]interface{}			"Unauthorized - invalid or missing JWT token"
-//	@Failure		500		{object}	map[string]interface{}			"Internal server error"
+//	@Param			source	query		string	false	"Only return feedbacks submitted through this channel"	Enums(web, mobile, api, email)
+//	@Param			tag		query		string	false	"Only return feedbacks the submitter tagged with this tag (case-insensitive)"	example(bug)
+//	@Param			platform	query	string	false	"Only return feedbacks reported from this platform"	Enums(web, ios, android, desktop)
+//	@Param			app_version	query	string	false	"Only return feedbacks reported from this app version"	example(2.4.1)
+//	@Param			include_deleted	query	bool	false	"Also return soft-deleted feedbacks, with deleted_at populated (admin only)"	default(false)
+//	@Success		200		{object}	responses.Paginated{items=[]responses.FeedbackResponse}	"Feedbacks retrieved successfully"
+//	@Failure		400		{object}	responder.ErrorResponse			"Bad request - invalid query parameters"
+//	@Failure		401		{object}	responder.ErrorResponse			"Unauthorized - invalid or missing JWT token"
+//	@Failure		403		{object}	responder.ErrorResponse			"Forbidden - include_deleted requires the admin role"
+//	@Failure		500		{object}	responder.ErrorResponse			"Internal server error"
+//	@Failure		504		{object}	responder.ErrorResponse			"Database operation timed out"
 //	@Router			/feedbacks [get]
 func (h *Handlers) ListFeedbacks(resp http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -148,8 +234,31 @@ func (h *Handlers) ListFeedbacks(resp http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	logger.Info("listing feedbacks", "limit", limit, "offset", offset)
-	feedbacks, err := h.feedbackService.ListFeedbacks(ctx, limit, offset)
+	includeDeleted, appErr := parseIncludeDeleted(r)
+	if appErr != nil {
+		h.responder.RespondContent(resp, appErr)
+		return
+	}
+
+	filter := services.FeedbackFilter{
+		Source:         r.URL.Query().Get("source"),
+		Tag:            r.URL.Query().Get("tag"),
+		Platform:       r.URL.Query().Get("platform"),
+		AppVersion:     r.URL.Query().Get("app_version"),
+		IncludeDeleted: includeDeleted,
+	}
+
+	logger.Info(
+		"listing feedbacks",
+		"limit", limit,
+		"offset", offset,
+		"source", filter.Source,
+		"tag", filter.Tag,
+		"platform", filter.Platform,
+		"app_version", filter.AppVersion,
+		"include_deleted", filter.IncludeDeleted,
+	)
+	page, err := h.feedbackService.ListFeedbacks(ctx, limit, offset, filter)
 	if err != nil {
 		logger.RecordSpanError(ctx, err)
 		logger.Error("error listing feedbacks", err)
@@ -158,19 +267,131 @@ func (h *Handlers) ListFeedbacks(resp http.ResponseWriter, r *http.Request) {
 	}
 
 	// Convert to response format
-	feedbackResponses := make([]responses.FeedbackResponse, len(feedbacks))
-	for i, fb := range feedbacks {
+	feedbackResponses := make([]responses.FeedbackResponse, len(page.Items))
+	for i, fb := range page.Items {
 		feedbackResponses[i] = *responses.FeedbackResponseFromDomain(fb)
 	}
 
-	response := responses.FeedbackListResponse{
-		Feedbacks: feedbackResponses,
-		Total:     len(feedbackResponses),
+	response := responses.NewPaginated(feedbackResponses, page.Total, page.Limit, page.Offset)
+
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
+}
+
+// ListUnanalyzedFeedbacks retrieves the feedback entries that were never successfully analyzed
+//
+//	@Summary		List unanalyzed feedbacks
+//	@Description	Retrieve the feedback entries with a comment that were never included in a successful analysis, oldest first, such as feedbacks of failed analyses. Feedbacks of an analysis that is still processing and feedbacks excluded from analysis are omitted. Requires admin role
+//	@Tags			feedbacks
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			limit	query		int	false	"Maximum number of feedbacks to return (default: 100)"	example(10)
+//	@Param			offset	query		int	false	"Number of feedbacks to skip (default: 0)"	example(0)
+//	@Success		200		{object}	responses.Paginated{items=[]responses.FeedbackResponse}	"Unanalyzed feedbacks retrieved successfully"
+//	@Failure		400		{object}	responder.ErrorResponse			"Bad request - invalid query parameters"
+//	@Failure		401		{object}	responder.ErrorResponse			"Unauthorized - invalid or missing JWT token"
+//	@Failure		403		{object}	responder.ErrorResponse			"Forbidden - admin role required"
+//	@Failure		500		{object}	responder.ErrorResponse			"Internal server error"
+//	@Router			/feedbacks/unanalyzed [get]
+func (h *Handlers) ListUnanalyzedFeedbacks(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	var limit, offset int
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsedLimit, err := parseInt(limitStr); err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
 	}
 
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if parsedOffset, err := parseInt(offsetStr); err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	logger.Info("listing unanalyzed feedbacks", "limit", limit, "offset", offset)
+	page, err := h.feedbackService.ListUnanalyzedFeedbacks(ctx, limit, offset)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error listing unanalyzed feedbacks", err)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	feedbackResponses := make([]responses.FeedbackResponse, len(page.Items))
+	for i, fb := range page.Items {
+		feedbackResponses[i] = *responses.FeedbackResponseFromDomain(fb)
+	}
+
+	response := responses.NewPaginated(feedbackResponses, page.Total, page.Limit, page.Offset)
+
 	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
 }
 
+// ExportFeedbacks streams all non-deleted feedbacks as CSV
+//
+//	@Summary		Export feedbacks as CSV (Admin only)
+//	@Description	Stream all non-deleted feedbacks matching the filters as CSV, oldest first, for use in external BI tools. The columns are id, user_id, rating, comment and created_at. Comments starting with =, +, -, @, a tab or a carriage return are prefixed with a single quote, so that spreadsheets do not evaluate them as formulas. Rows are written as they are read, so the export is never held in memory. Requires admin role
+//	@Tags			feedbacks
+//	@Produce		text/csv
+//	@Security		BearerAuth
+//	@Param			source			query	string	false	"Only export feedbacks submitted through this channel"	Enums(web, mobile, email, api)
+//	@Param			tag				query	string	false	"Only export feedbacks tagged with this tag"	example(checkout)
+//	@Param			platform		query	string	false	"Only export feedbacks reported from this platform"	Enums(web, ios, android, desktop)
+//	@Param			app_version		query	string	false	"Only export feedbacks reported from this app version"	example(2.4.1)
+//	@Param			created_from	query	string	false	"Only export feedbacks created at or after this time (RFC 3339 or YYYY-MM-DD)"	example(2024-01-01)
+//	@Param			created_to		query	string	false	"Only export feedbacks created before this time (RFC 3339 or YYYY-MM-DD)"	example(2024-02-01T00:00:00Z)
+//	@Param			min_rating		query	int		false	"Only export feedbacks rated at least this value"	example(1)
+//	@Param			max_rating		query	int		false	"Only export feedbacks rated at most this value"	example(3)
+//	@Success		200	{string}	string					"CSV export of the matching feedbacks"
+//	@Failure		400	{object}	responder.ErrorResponse	"Bad request - invalid query parameters"
+//	@Failure		401	{object}	responder.ErrorResponse	"Unauthorized - invalid or missing JWT token"
+//	@Failure		403	{object}	responder.ErrorResponse	"Forbidden - admin role required"
+//	@Failure		500	{object}	responder.ErrorResponse	"Internal server error"
+//	@Router			/feedbacks/export [get]
+func (h *Handlers) ExportFeedbacks(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	filter, appErr := parseExportFilter(r)
+	if appErr != nil {
+		h.responder.RespondContent(resp, appErr)
+		return
+	}
+
+	logger.Info(
+		"exporting feedbacks",
+		"source", filter.Source,
+		"tag", filter.Tag,
+		"platform", filter.Platform,
+		"app_version", filter.AppVersion,
+		"created_from", filter.CreatedFrom,
+		"created_to", filter.CreatedTo,
+	)
+
+	// The response is only started with the first row, so that errors raised before, such as an invalid
+	// filter, are still reported as regular error responses
+	csvWriter := newFeedbackCSVWriter(resp)
+	err := h.feedbackService.ExportFeedbacks(ctx, filter, csvWriter.write)
+	if err == nil {
+		err = csvWriter.close()
+	}
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error exporting feedbacks", err)
+		if !csvWriter.started {
+			h.handleSvcError(resp, err)
+			return
+		}
+		// The status was already sent, so abort the connection to keep the client from taking a partial
+		// export for a complete one
+		panic(http.ErrAbortHandler)
+	}
+
+	logger.Info("feedbacks exported successfully", "count", csvWriter.rows)
+}
+
 // DeleteFeedback performs a soft delete on a feedback entry
 //
 //	@Summary		Delete feedback (Admin only)
@@ -181,11 +402,11 @@ func (h *Handlers) ListFeedbacks(resp http.ResponseWriter, r *http.Request) {
 //	@Security		BearerAuth
 //	@Param			id	path		string	true	"Feedback ID"	example(550e8400-e29b-41d4-a716-446655440000)
 //	@Success		204	{object}	nil		"Feedback deleted successfully"
-//	@Failure		400	{object}	map[string]interface{}	"Bad request - invalid feedback ID format"
-//	@Failure		401	{object}	map[string]interface{}	"Unauthorized - invalid or missing JWT token"
-//	@Failure		403	{object}	map[string]interface{}	"Forbidden - admin role required"
-//	@Failure		404	{object}	map[string]interface{}	"Feedback not found"
-//	@Failure		500	{object}	map[string]interface{}	"Internal server error"
+//	@Failure		400	{object}	responder.ErrorResponse	"Bad request - invalid feedback ID format"
+//	@Failure		401	{object}	responder.ErrorResponse	"Unauthorized - invalid or missing JWT token"
+//	@Failure		403	{object}	responder.ErrorResponse	"Forbidden - admin role required"
+//	@Failure		404	{object}	responder.ErrorResponse	"Feedback not found"
+//	@Failure		500	{object}	responder.ErrorResponse	"Internal server error"
 //	@Router			/feedbacks/{id} [delete]
 func (h *Handlers) DeleteFeedback(resp http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -209,3 +430,42 @@ func (h *Handlers) DeleteFeedback(resp http.ResponseWriter, r *http.Request) {
 
 	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusNoContent, nil))
 }
+
+// BatchDeleteFeedbacks performs a soft delete on several feedback entries
+//
+//	@Summary		Delete feedbacks in batch (Admin only)
+//	@Description	Soft delete the given feedbacks in a single transaction and report the outcome per ID: deleted, not_found or already_deleted. The batch size is capped by max_batch_delete_size. Requires admin role.
+//	@Tags			feedbacks
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		requests.BatchDeleteFeedbacksRequest	true	"Batch delete request"
+//	@Success		200		{object}	responses.BatchDeleteFeedbacksResponse	"Batch processed successfully"
+//	@Failure		400		{object}	responder.ErrorResponse					"Bad request - invalid, duplicate or too many feedback IDs"
+//	@Failure		401		{object}	responder.ErrorResponse					"Unauthorized - invalid or missing JWT token"
+//	@Failure		403		{object}	responder.ErrorResponse					"Forbidden - admin role required"
+//	@Failure		500		{object}	responder.ErrorResponse					"Internal server error"
+//	@Router			/feedbacks/batch-delete [post]
+func (h *Handlers) BatchDeleteFeedbacks(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	req, err := parsePayloadData[requests.BatchDeleteFeedbacksRequest](r, r.Body)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		h.responder.RespondContent(resp, ce.ErrBadRequest("invalid request body", ce.WithCauseError(err)))
+		return
+	}
+
+	logger.Info("deleting feedbacks in batch", "feedback_count", len(req.Data.FeedbackIDs))
+	results, err := h.feedbackService.DeleteFeedbacks(ctx, req.Data.FeedbackIDs)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error deleting feedbacks in batch", err)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	response := responses.BatchDeleteFeedbacksResponseFromResults(results)
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
+}
@@ -0,0 +1,353 @@
+package v1
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/infrastructure/jwt"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/transport/requests"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/transport/responses"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/http/responder"
+	"go.uber.org/mock/gomock"
+)
+
+func newCreateFeedbackRequest(body string, userID uuid.UUID) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/feedbacks", strings.NewReader(body))
+	return withClaims(r, &jwt.Claims{UserID: userID.String(), Roles: []string{"user"}})
+}
+
+func TestHandlers_CreateFeedback(t *testing.T) {
+	th := newTestHandlers(t)
+
+	userID := uuid.New()
+	fb, err := feedback.NewBuilder().BuildNew(userID, 4, "Really nice!")
+	if err != nil {
+		t.Fatalf("Failed to build feedback: %v", err)
+	}
+	th.feedbackService.EXPECT().
+		CreateFeedback(gomock.Any(), userID, &requests.CreateFeedbackRequest{Rating: 4, Comment: "Really nice!"}, false).
+		Return(fb, nil)
+
+	rec := httptest.NewRecorder()
+	th.CreateFeedback(rec, newCreateFeedbackRequest(`{"rating": 4, "comment": "Really nice!"}`, userID))
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	body := decodeBody[responses.FeedbackResponse](t, rec)
+	if body.ID != fb.ID().String() || body.Rating != 4 || body.Comment != "Really nice!" {
+		t.Errorf("Unexpected feedback response: %+v", body)
+	}
+}
+
+func TestHandlers_CreateFeedback_AdminExemptFromQuota(t *testing.T) {
+	th := newTestHandlers(t)
+
+	userID := uuid.New()
+	fb, err := feedback.NewBuilder().BuildNew(userID, 4, "Really nice!")
+	if err != nil {
+		t.Fatalf("Failed to build feedback: %v", err)
+	}
+	th.feedbackService.EXPECT().
+		CreateFeedback(gomock.Any(), userID, gomock.Any(), true).
+		Return(fb, nil)
+
+	r := httptest.NewRequest(http.MethodPost, "/feedbacks", strings.NewReader(`{"rating": 4, "comment": "Really nice!"}`))
+	r = withClaims(r, &jwt.Claims{UserID: userID.String(), Roles: []string{"user", "admin"}})
+
+	rec := httptest.NewRecorder()
+	th.CreateFeedback(rec, r)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestHandlers_CreateFeedback_InvalidBody(t *testing.T) {
+	th := newTestHandlers(t)
+
+	rec := httptest.NewRecorder()
+	th.CreateFeedback(rec, newCreateFeedbackRequest(`{"rating": "five"}`, uuid.New()))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", rec.Code)
+	}
+}
+
+func TestHandlers_CreateFeedback_InvalidUserID(t *testing.T) {
+	th := newTestHandlers(t)
+
+	r := httptest.NewRequest(http.MethodPost, "/feedbacks", strings.NewReader(`{"rating": 4, "comment": "Hi"}`))
+	r = withClaims(r, &jwt.Claims{UserID: "not-a-uuid"})
+
+	rec := httptest.NewRecorder()
+	th.CreateFeedback(rec, r)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("Expected status 401, got %d", rec.Code)
+	}
+}
+
+func TestHandlers_CreateFeedback_ServiceError(t *testing.T) {
+	th := newTestHandlers(t)
+
+	th.feedbackService.EXPECT().
+		CreateFeedback(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("connection refused"))
+
+	rec := httptest.NewRecorder()
+	th.CreateFeedback(rec, newCreateFeedbackRequest(`{"rating": 4, "comment": "Really nice!"}`, uuid.New()))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("Expected status 500, got %d", rec.Code)
+	}
+	if body := decodeBody[responder.ErrorResponse](t, rec); strings.Contains(body.Message, "connection refused") {
+		t.Errorf("Expected internal errors not to leak, got %q", body.Message)
+	}
+}
+
+func TestHandlers_BatchDeleteFeedbacks(t *testing.T) {
+	th := newTestHandlers(t)
+
+	deletedID, missingID := uuid.New(), uuid.New()
+	th.feedbackService.EXPECT().
+		DeleteFeedbacks(gomock.Any(), []uuid.UUID{deletedID, missingID}).
+		Return(
+			[]services.FeedbackDeleteResult{
+				{FeedbackID: deletedID, Status: services.FeedbackDeleted},
+				{FeedbackID: missingID, Status: services.FeedbackNotFound},
+			}, nil,
+		)
+
+	body := `{"feedback_ids": ["` + deletedID.String() + `", "` + missingID.String() + `"]}`
+	r := httptest.NewRequest(http.MethodPost, "/feedbacks/batch-delete", strings.NewReader(body))
+	r = withClaims(r, &jwt.Claims{UserID: uuid.NewString(), Roles: []string{"admin"}})
+
+	rec := httptest.NewRecorder()
+	th.BatchDeleteFeedbacks(rec, r)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	resp := decodeBody[responses.BatchDeleteFeedbacksResponse](t, rec)
+	if resp.DeletedCount != 1 || len(resp.Results) != 2 {
+		t.Fatalf("Unexpected batch delete response: %+v", resp)
+	}
+	if resp.Results[1].FeedbackID != missingID.String() || resp.Results[1].Status != "not_found" {
+		t.Errorf("Expected %s to be reported as not_found, got %+v", missingID, resp.Results[1])
+	}
+}
+
+func newExportFeedbacksRequest(query string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/feedbacks/export?"+query, nil)
+	return withClaims(r, &jwt.Claims{UserID: uuid.NewString(), Roles: []string{"admin"}})
+}
+
+func TestHandlers_ListFeedbacks(t *testing.T) {
+	th := newTestHandlers(t)
+
+	fb, err := feedback.NewBuilder().BuildNew(uuid.New(), 4, "Really nice!")
+	if err != nil {
+		t.Fatalf("Failed to build feedback: %v", err)
+	}
+	th.feedbackService.EXPECT().
+		ListFeedbacks(gomock.Any(), 1, 2, gomock.Any()).
+		Return(&services.Page[*feedback.Feedback]{Items: []*feedback.Feedback{fb}, Total: 5, Limit: 1, Offset: 2}, nil)
+
+	rec := httptest.NewRecorder()
+	th.ListFeedbacks(rec, httptest.NewRequest(http.MethodGet, "/feedbacks?limit=1&offset=2", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	body := decodeBody[responses.Paginated[responses.FeedbackResponse]](t, rec)
+	if len(body.Items) != 1 || body.Items[0].ID != fb.ID().String() {
+		t.Fatalf("Expected the listed feedback under items, got %+v", body.Items)
+	}
+	want := responses.Pagination{Total: 5, Limit: 1, Offset: 2, HasMore: true}
+	if body.Pagination != want {
+		t.Errorf("Expected pagination %+v, got %+v", want, body.Pagination)
+	}
+}
+
+func TestHandlers_ExportFeedbacks(t *testing.T) {
+	th := newTestHandlers(t)
+
+	fb, err := feedback.NewBuilder().BuildNew(uuid.New(), 2, "Slow, \"very\" slow\nat checkout")
+	if err != nil {
+		t.Fatalf("Failed to build feedback: %v", err)
+	}
+	th.feedbackService.EXPECT().
+		ExportFeedbacks(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(_ context.Context, filter services.FeedbackExportFilter, fn func(*feedback.Feedback) error) error {
+				wantFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+				if !filter.CreatedFrom.Equal(wantFrom) || filter.MaxRating.UnwrapOr(0) != 3 {
+					t.Errorf("Unexpected export filter: %+v", filter)
+				}
+				return fn(fb)
+			},
+		)
+
+	rec := httptest.NewRecorder()
+	th.ExportFeedbacks(rec, newExportFeedbacksRequest("created_from=2024-01-01&max_rating=3"))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if contentType := rec.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/csv") {
+		t.Errorf("Expected a CSV content type, got %q", contentType)
+	}
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV export: %v", err)
+	}
+	if len(records) != 2 || strings.Join(records[0], ",") != "id,user_id,rating,comment,created_at" {
+		t.Fatalf("Unexpected CSV export: %v", records)
+	}
+	if records[1][0] != fb.ID().String() || records[1][2] != "2" || records[1][3] != fb.Comment().String() {
+		t.Errorf("Unexpected CSV row: %v", records[1])
+	}
+}
+
+func TestHandlers_ExportFeedbacks_FormulaInjection(t *testing.T) {
+	th := newTestHandlers(t)
+
+	fb, err := feedback.NewBuilder().BuildNew(uuid.New(), 1, `=HYPERLINK("http://example.com","Click")`)
+	if err != nil {
+		t.Fatalf("Failed to build feedback: %v", err)
+	}
+	th.feedbackService.EXPECT().
+		ExportFeedbacks(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(
+			func(_ context.Context, _ services.FeedbackExportFilter, fn func(*feedback.Feedback) error) error {
+				return fn(fb)
+			},
+		)
+
+	rec := httptest.NewRecorder()
+	th.ExportFeedbacks(rec, newExportFeedbacksRequest(""))
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV export: %v", err)
+	}
+	if len(records) != 2 || records[1][3] != "'"+fb.Comment().String() {
+		t.Errorf("Expected the comment to be prefixed with a single quote, got %v", records)
+	}
+}
+
+func TestCSVSafeCell(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "=1+1", want: "'=1+1"},
+		{value: "+1", want: "'+1"},
+		{value: "-1", want: "'-1"},
+		{value: "@SUM(A1)", want: "'@SUM(A1)"},
+		{value: "\tcmd", want: "'\tcmd"},
+		{value: "\rcmd", want: "'\rcmd"},
+		{value: "Great app = fast", want: "Great app = fast"},
+		{value: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := csvSafeCell(tt.value); got != tt.want {
+			t.Errorf("csvSafeCell(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestHandlers_ExportFeedbacks_Errors(t *testing.T) {
+	th := newTestHandlers(t)
+
+	rec := httptest.NewRecorder()
+	th.ExportFeedbacks(rec, newExportFeedbacksRequest("created_to=yesterday"))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid date, got %d", rec.Code)
+	}
+
+	// Errors raised before the first row are reported as regular error responses
+	th.feedbackService.EXPECT().
+		ExportFeedbacks(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(ce.ErrBadRequest("min_rating cannot be greater than max_rating"))
+
+	rec = httptest.NewRecorder()
+	th.ExportFeedbacks(rec, newExportFeedbacksRequest("min_rating=4&max_rating=2"))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 from the service error, got %d", rec.Code)
+	}
+}
+
+func TestHandlers_GetFeedbackAnalyses(t *testing.T) {
+	th := newTestHandlers(t)
+
+	feedbackID := uuid.New()
+	now := time.Now().UTC()
+	analysisEntity, err := analysis.NewBuilder().
+		WithPeriod(now.Add(-time.Hour), now).
+		WithFeedbackCount(1).
+		WithOverallSummary("Users like the product").
+		WithSentiment(analysis.SentimentPositive).
+		WithModel("gpt-test").
+		WithStatus(analysis.StatusSuccess).
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to build analysis: %v", err)
+	}
+	topic := analysis.NewTopicAnalysisBuilder().
+		WithAnalysisID(analysisEntity.ID()).
+		WithTopic(analysis.TopicUIUX).
+		WithSummary("The interface is clean").
+		WithFeedbackCount(1).
+		WithSentiment(analysis.SentimentPositive).
+		BuildUnchecked()
+
+	th.feedbackSummaryService.EXPECT().
+		GetFeedbackAnalyses(gomock.Any(), feedbackID).
+		Return([]services.FeedbackAnalysis{{Analysis: analysisEntity, Topics: []*analysis.TopicAnalysis{topic}}}, nil)
+
+	rec := httptest.NewRecorder()
+	th.GetFeedbackAnalyses(
+		rec,
+		withURLParam(httptest.NewRequest(http.MethodGet, "/feedbacks/x/analyses", nil), "id", feedbackID.String()),
+	)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	body := decodeBody[responses.List[responses.FeedbackAnalysisResponse]](t, rec)
+	if len(body.Items) != 1 || body.Items[0].Analysis.ID != analysisEntity.ID().String() {
+		t.Fatalf("Expected the analysis of the feedback, got %+v", body.Items)
+	}
+	if topics := body.Items[0].Topics; len(topics) != 1 || topics[0].Topic != string(analysis.TopicUIUX) {
+		t.Errorf("Expected the topic the feedback was assigned to, got %+v", topics)
+	}
+}
+
+func TestHandlers_GetFeedbackAnalyses_InvalidID(t *testing.T) {
+	th := newTestHandlers(t)
+
+	rec := httptest.NewRecorder()
+	th.GetFeedbackAnalyses(
+		rec,
+		withURLParam(httptest.NewRequest(http.MethodGet, "/feedbacks/x/analyses", nil), "id", "not-a-uuid"),
+	)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", rec.Code)
+	}
+}
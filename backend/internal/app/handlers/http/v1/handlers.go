@@ -17,6 +17,8 @@ type Handlers struct {
 	feedbackService        services.FeedbackService
 	userService            services.UserService
 	feedbackSummaryService services.FeedbackSummaryService
+	analyzerService        services.AnalyzerService
+	eventPublisher         services.EventPublisher
 	jwtCfg                 *config.JWT
 	tracingEnabled         bool
 }
@@ -28,6 +30,8 @@ func NewHandlers(
 	feedbackService services.FeedbackService,
 	userService services.UserService,
 	feedbackSummaryService services.FeedbackSummaryService,
+	analyzerService services.AnalyzerService,
+	eventPublisher services.EventPublisher,
 	jwtCfg *config.JWT,
 	opts ...trace.InstrumentationOption,
 ) handlers.Handlers {
@@ -38,6 +42,8 @@ func NewHandlers(
 		feedbackService:        feedbackService,
 		userService:            userService,
 		feedbackSummaryService: feedbackSummaryService,
+		analyzerService:        analyzerService,
+		eventPublisher:         eventPublisher,
 		jwtCfg:                 jwtCfg,
 		tracingEnabled:         false,
 	}
@@ -51,12 +57,17 @@ func NewHandlers(
 }
 
 func (h *Handlers) RegisterRoutes() {
+	h.registerHealthRoutes(h.r)
+
 	// Register routes hierarchically under /api
 	h.r.Route(
 		"/api", func(r chi.Router) {
 			h.registerAuthRoutes(r)
 			h.registerFeedbackRoutes(r)
 			h.registerAnalysisRoutes(r)
+			h.registerAnalyticsRoutes(r)
+			h.registerWebhookRoutes(r)
+			h.registerUserRoutes(r)
 		},
 	)
 }
@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/handlers/http/middleware"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/infrastructure/jwt"
+	"github.com/ktruedat/llm-feedback-analysis/mocking"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/http/responder"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/log"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+	"go.uber.org/mock/gomock"
+)
+
+// testHandlers holds handlers wired to mocked services.
+type testHandlers struct {
+	*Handlers
+	feedbackService        *mocking.MockFeedbackService
+	userService            *mocking.MockUserService
+	feedbackSummaryService *mocking.MockFeedbackSummaryService
+	analyzerService        *mocking.MockAnalyzerService
+	eventPublisher         *mocking.MockEventPublisher
+}
+
+func newTestHandlers(t *testing.T) *testHandlers {
+	t.Helper()
+
+	tracer, err := trace.NewTracer(trace.Config{ServiceName: "handlers-test"})
+	if err != nil {
+		t.Fatalf("Failed to create tracer: %v", err)
+	}
+	baseLogger := log.NewLogger("development")
+
+	ctrl := gomock.NewController(t)
+	th := &testHandlers{
+		feedbackService:        mocking.NewMockFeedbackService(ctrl),
+		userService:            mocking.NewMockUserService(ctrl),
+		feedbackSummaryService: mocking.NewMockFeedbackSummaryService(ctrl),
+		analyzerService:        mocking.NewMockAnalyzerService(ctrl),
+		eventPublisher:         mocking.NewMockEventPublisher(ctrl),
+	}
+	th.Handlers = &Handlers{
+		r:                      chi.NewRouter(),
+		logger:                 tracelog.NewTraceLogger(baseLogger, tracer),
+		responder:              responder.NewRestResponder(baseLogger),
+		feedbackService:        th.feedbackService,
+		userService:            th.userService,
+		feedbackSummaryService: th.feedbackSummaryService,
+		analyzerService:        th.analyzerService,
+		eventPublisher:         th.eventPublisher,
+		jwtCfg:                 &config.JWT{},
+	}
+	return th
+}
+
+// withURLParam sets a chi route parameter on the request, as the router does.
+func withURLParam(r *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+// withClaims authenticates the request, as the JWT middleware does.
+func withClaims(r *http.Request, claims *jwt.Claims) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), middleware.UserClaimsContextKey, claims))
+}
+
+// decodeBody decodes the JSON response body into T.
+func decodeBody[T any](t *testing.T, rec *httptest.ResponseRecorder) T {
+	t.Helper()
+
+	var body T
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response body %q: %v", rec.Body.String(), err)
+	}
+	return body
+}
@@ -0,0 +1,47 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/transport/responses"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/http/responder"
+)
+
+// registerHealthRoutes registers the probes outside /api, where they are served without authentication.
+// They are not traced, so that frequent probing does not flood the traces.
+func (h *Handlers) registerHealthRoutes(router chi.Router) {
+	router.Route(
+		"/health", func(r chi.Router) {
+			r.Get("/", h.GetHealth)
+			r.Get("/ready", h.GetReadiness)
+		},
+	)
+}
+
+// GetHealth reports that the server is up, for liveness probes.
+func (h *Handlers) GetHealth(resp http.ResponseWriter, _ *http.Request) {
+	h.responder.RespondContent(
+		resp,
+		responder.NewGenericResponse(http.StatusOK, responses.HealthResponse{Status: "ok"}),
+	)
+}
+
+// GetReadiness reports whether the instance should receive traffic, for readiness probes.
+// It answers 503 until the analyzer completed its initial setup, and again once it is stopping.
+// An open LLM circuit breaker is reported but does not make the instance unready, the API keeps serving.
+func (h *Handlers) GetReadiness(resp http.ResponseWriter, _ *http.Request) {
+	readiness := responses.ReadinessResponse{
+		Status:        responses.ReadinessReady,
+		AnalyzerReady: h.analyzerService.IsReady(),
+		LLMCircuit:    string(h.analyzerService.LLMCircuitState()),
+	}
+
+	status := http.StatusOK
+	if !readiness.AnalyzerReady {
+		readiness.Status = responses.ReadinessNotReady
+		status = http.StatusServiceUnavailable
+	}
+
+	h.responder.RespondContent(resp, responder.NewGenericResponse(status, readiness))
+}
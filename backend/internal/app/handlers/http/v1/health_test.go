@@ -0,0 +1,65 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/transport/responses"
+)
+
+func TestHandlers_GetReadiness(t *testing.T) {
+	tests := []struct {
+		name          string
+		analyzerReady bool
+		circuit       external.CircuitState
+		wantStatus    int
+		wantReadiness responses.ReadinessStatus
+	}{
+		{
+			name:          "analyzer ready",
+			analyzerReady: true,
+			circuit:       external.CircuitClosed,
+			wantStatus:    http.StatusOK,
+			wantReadiness: responses.ReadinessReady,
+		},
+		{
+			name:          "analyzer initializing",
+			circuit:       external.CircuitClosed,
+			wantStatus:    http.StatusServiceUnavailable,
+			wantReadiness: responses.ReadinessNotReady,
+		},
+		{
+			name:          "LLM circuit open",
+			analyzerReady: true,
+			circuit:       external.CircuitOpen,
+			wantStatus:    http.StatusOK,
+			wantReadiness: responses.ReadinessReady,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				th := newTestHandlers(t)
+				th.analyzerService.EXPECT().IsReady().Return(tt.analyzerReady)
+				th.analyzerService.EXPECT().LLMCircuitState().Return(tt.circuit)
+
+				rec := httptest.NewRecorder()
+				th.GetReadiness(rec, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+				if rec.Code != tt.wantStatus {
+					t.Fatalf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+				}
+				body := decodeBody[responses.ReadinessResponse](t, rec)
+				if body.Status != tt.wantReadiness || body.AnalyzerReady != tt.analyzerReady {
+					t.Errorf("Expected readiness %s with analyzer ready %t, got %+v", tt.wantReadiness, tt.analyzerReady, body)
+				}
+				if body.LLMCircuit != string(tt.circuit) {
+					t.Errorf("Expected LLM circuit %s, got %s", tt.circuit, body.LLMCircuit)
+				}
+			},
+		)
+	}
+}
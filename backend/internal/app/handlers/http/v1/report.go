@@ -0,0 +1,232 @@
+package v1
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"net/http"
+	"text/template"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+)
+
+// Formats of the analysis report.
+const (
+	reportFormatMarkdown = "markdown"
+	reportFormatHTML     = "html"
+)
+
+// reportDateLayout formats the period of the report.
+const reportDateLayout = "2006-01-02"
+
+// analysisReport is the view of an analysis rendered by the report templates.
+type analysisReport struct {
+	PeriodStart    string
+	PeriodEnd      string
+	Status         string
+	Sentiment      string
+	FeedbackCount  int
+	Model          string
+	OverallSummary string
+	KeyInsights    []string
+	Topics         []topicReport
+}
+
+// topicReport is the view of a topic analysis rendered by the report templates.
+type topicReport struct {
+	Name            string
+	Topic           string
+	Summary         string
+	Sentiment       string
+	FeedbackCount   int
+	Recommendations []string
+}
+
+const markdownReportTemplate = `# Feedback analysis {{.PeriodStart}} to {{.PeriodEnd}}
+
+**Sentiment:** {{.Sentiment}} | **Feedbacks:** {{.FeedbackCount}} | **Model:** {{.Model}}
+
+## Overall summary
+
+{{if .OverallSummary}}{{.OverallSummary}}{{else}}_No summary available (status: {{.Status}})._{{end}}
+{{- if .KeyInsights}}
+
+## Key insights
+{{range .KeyInsights}}
+- {{.}}
+{{- end}}
+{{- end}}
+{{- if .Topics}}
+
+## Topics
+{{- range .Topics}}
+
+### {{.Name}}
+
+**Sentiment:** {{.Sentiment}} | **Feedbacks:** {{.FeedbackCount}}
+
+{{.Summary}}
+{{- if .Recommendations}}
+
+Recommendations:
+{{range .Recommendations}}
+- {{.}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+`
+
+const htmlReportTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Feedback analysis {{.PeriodStart}} to {{.PeriodEnd}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; max-width: 48rem; margin: 2rem auto; padding: 0 1rem; color: #1f2933; line-height: 1.5; }
+h1 { font-size: 1.75rem; margin-bottom: 0.25rem; }
+h2 { border-bottom: 1px solid #e4e7eb; padding-bottom: 0.25rem; margin-top: 2rem; }
+.meta { color: #52606d; }
+.topic { border: 1px solid #e4e7eb; border-radius: 0.5rem; padding: 0 1rem 0.5rem; margin: 1rem 0; }
+.sentiment { display: inline-block; border-radius: 999px; padding: 0 0.6rem; font-size: 0.85rem; }
+.sentiment-positive { background: #e3f9e5; color: #207227; }
+.sentiment-mixed { background: #fff3c4; color: #8d6708; }
+.sentiment-negative { background: #ffe3e3; color: #ab091e; }
+</style>
+</head>
+<body>
+<h1>Feedback analysis {{.PeriodStart}} to {{.PeriodEnd}}</h1>
+<p class="meta"><span class="sentiment sentiment-{{.Sentiment}}">{{.Sentiment}}</span> {{.FeedbackCount}} feedbacks, analyzed by {{.Model}}</p>
+<h2>Overall summary</h2>
+{{if .OverallSummary}}<p>{{.OverallSummary}}</p>{{else}}<p><em>No summary available (status: {{.Status}}).</em></p>{{end}}
+{{- if .KeyInsights}}
+<h2>Key insights</h2>
+<ul>
+{{- range .KeyInsights}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Topics}}
+<h2>Topics</h2>
+{{- range .Topics}}
+<section class="topic">
+<h3>{{.Name}}</h3>
+<p class="meta"><span class="sentiment sentiment-{{.Sentiment}}">{{.Sentiment}}</span> {{.FeedbackCount}} feedbacks</p>
+<p>{{.Summary}}</p>
+{{- if .Recommendations}}
+<p>Recommendations:</p>
+<ul>
+{{- range .Recommendations}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+</section>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`
+
+var (
+	markdownReport = template.Must(template.New("markdown_report").Parse(markdownReportTemplate))
+	htmlReport     = htmltemplate.Must(htmltemplate.New("html_report").Parse(htmlReportTemplate))
+)
+
+// GetAnalysisReport renders an analysis as a human-readable report
+//
+//	@Summary		Get analysis report
+//	@Description	Render an analysis as a shareable report: the period, overall summary, key insights and a section
+//	@Description	per topic with its summary, sentiment and feedback count, topics with the most feedbacks first.
+//	@Description	format is "markdown" (default) or "html" for a styled page
+//	@Tags			analyses
+//	@Produce		text/markdown
+//	@Produce		html
+//	@Security		BearerAuth
+//	@Param			id		path		string	true	"Analysis ID"	example(550e8400-e29b-41d4-a716-446655440000)
+//	@Param			format	query		string	false	"Report format"	Enums(markdown, html)	default(markdown)
+//	@Success		200		{string}	string					"Report rendered successfully"
+//	@Failure		400		{object}	responder.ErrorResponse	"Bad request - invalid analysis ID format or report format"
+//	@Failure		401		{object}	responder.ErrorResponse	"Unauthorized - invalid or missing JWT token"
+//	@Failure		404		{object}	responder.ErrorResponse	"Analysis not found"
+//	@Failure		500		{object}	responder.ErrorResponse	"Internal server error"
+//	@Router			/analyses/{id}/report [get]
+func (h *Handlers) GetAnalysisReport(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	analysisID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.responder.RespondContent(resp, ce.ErrBadRequest("invalid analysis ID format"))
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = reportFormatMarkdown
+	}
+	if format != reportFormatMarkdown && format != reportFormatHTML {
+		h.responder.RespondContent(resp, ce.ErrBadRequest("invalid format, must be markdown or html"))
+		return
+	}
+
+	analysisEntity, topics, _, _, err := h.feedbackSummaryService.GetAnalysisByID(ctx, analysisID)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting analysis for report", err, "analysis_id", analysisID)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	report := newAnalysisReport(analysisEntity, topics)
+	var buf bytes.Buffer
+	contentType := "text/markdown; charset=utf-8"
+	if format == reportFormatHTML {
+		contentType = "text/html; charset=utf-8"
+		err = htmlReport.Execute(&buf, report)
+	} else {
+		err = markdownReport.Execute(&buf, report)
+	}
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error rendering analysis report", err, "analysis_id", analysisID, "format", format)
+		h.responder.RespondContent(resp, ce.ErrInternal(err))
+		return
+	}
+
+	resp.Header().Set("Content-Type", contentType)
+	resp.WriteHeader(http.StatusOK)
+	if _, err := resp.Write(buf.Bytes()); err != nil {
+		logger.Warning("error writing analysis report", "analysis_id", analysisID, "error", err.Error())
+	}
+}
+
+// newAnalysisReport builds the report view of an analysis, keeping the topic order returned by the service.
+func newAnalysisReport(a *analysis.Analysis, topics []*analysis.TopicAnalysis) analysisReport {
+	report := analysisReport{
+		PeriodStart:    a.PeriodStart().Format(reportDateLayout),
+		PeriodEnd:      a.PeriodEnd().Format(reportDateLayout),
+		Status:         string(a.Status()),
+		Sentiment:      string(a.Sentiment()),
+		FeedbackCount:  a.FeedbackCount(),
+		Model:          a.QualifiedModel(),
+		OverallSummary: a.OverallSummary().UnwrapOr(""),
+		KeyInsights:    a.KeyInsights(),
+		Topics:         make([]topicReport, len(topics)),
+	}
+
+	for i, topic := range topics {
+		report.Topics[i] = topicReport{
+			Name:            topic.TopicName(),
+			Topic:           string(topic.Topic()),
+			Summary:         topic.Summary(),
+			Sentiment:       string(topic.Sentiment()),
+			FeedbackCount:   topic.FeedbackCount(),
+			Recommendations: topic.Recommendations(),
+		}
+	}
+	return report
+}
@@ -0,0 +1,125 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/handlers/http/middleware"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/transport/responses"
+	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/http/responder"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+)
+
+func (h *Handlers) registerUserRoutes(router chi.Router) {
+	router.Route(
+		"/users", func(r chi.Router) {
+			// Admin-only routes: only users with "admin" role can manage accounts
+			r.With(middleware.RequireRole("admin", h.logger, h.responder)).
+				Get("/", trace.InstrumentHandlerFunc(h.ListUsers, "GET /users", h))
+			r.With(middleware.RequireRole("admin", h.logger, h.responder)).
+				Post("/{id}/restore", trace.InstrumentHandlerFunc(h.RestoreUser, "POST /users/{id}/restore", h))
+		},
+	)
+}
+
+// RestoreUser handles restoring a soft-deleted user
+//
+//	@Summary		Restore a deleted user (Admin only)
+//	@Description	Reactivate a soft-deleted user account. Requires admin role
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"User ID (UUID)"
+//	@Success		200	{object}	responses.UserResponse	"User restored successfully"
+//	@Failure		400	{object}	responder.ErrorResponse	"Bad request - invalid user ID"
+//	@Failure		401	{object}	responder.ErrorResponse	"Unauthorized - invalid or missing JWT token"
+//	@Failure		403	{object}	responder.ErrorResponse	"Forbidden - admin role required"
+//	@Failure		404	{object}	responder.ErrorResponse	"User not found"
+//	@Failure		409	{object}	responder.ErrorResponse	"Conflict - user is not deleted"
+//	@Failure		500	{object}	responder.ErrorResponse	"Internal server error"
+//	@Router			/users/{id}/restore [post]
+func (h *Handlers) RestoreUser(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.responder.RespondContent(resp, ce.ErrBadRequest("invalid user ID format"))
+		return
+	}
+
+	logger.Info("restoring user", "user_id", userID.String())
+	u, err := h.userService.RestoreUser(ctx, userID)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error restoring user", err, "user_id", userID.String())
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	response := responses.UserResponseFromDomain(u)
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
+}
+
+// ListUsers handles listing user accounts
+//
+//	@Summary		List users (Admin only)
+//	@Description	Retrieve non-deleted user accounts, newest first, with optional pagination and filtering. Requires admin role
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			limit	query		int		false	"Maximum number of users to return (default: 100)"	example(10)
+//	@Param			offset	query		int		false	"Number of users to skip (default: 0)"	example(0)
+//	@Param			status	query		string	false	"Only return accounts in this status"	Enums(active, inactive, suspended)
+//	@Param			role	query		string	false	"Only return accounts holding this role"	Enums(user, admin)
+//	@Success		200		{object}	responses.Paginated{items=[]responses.UserResponse}	"Users retrieved successfully"
+//	@Failure		400		{object}	responder.ErrorResponse	"Bad request - invalid query parameters"
+//	@Failure		401		{object}	responder.ErrorResponse	"Unauthorized - invalid or missing JWT token"
+//	@Failure		403		{object}	responder.ErrorResponse	"Forbidden - admin role required"
+//	@Failure		500		{object}	responder.ErrorResponse	"Internal server error"
+//	@Router			/users [get]
+func (h *Handlers) ListUsers(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	var limit, offset int
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsedLimit, err := parseInt(limitStr); err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if parsedOffset, err := parseInt(offsetStr); err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	filter := services.UserFilter{
+		Status: r.URL.Query().Get("status"),
+		Role:   r.URL.Query().Get("role"),
+	}
+
+	logger.Info("listing users", "limit", limit, "offset", offset, "status", filter.Status, "role", filter.Role)
+	page, err := h.userService.ListUsers(ctx, limit, offset, filter)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error listing users", err)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	userResponses := make([]responses.UserResponse, len(page.Items))
+	for i, u := range page.Items {
+		userResponses[i] = *responses.UserResponseFromDomain(u)
+	}
+
+	response := responses.NewPaginated(userResponses, page.Total, page.Limit, page.Offset)
+
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
+}
@@ -6,15 +6,21 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/handlers/http/middleware"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/infrastructure/jwt"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/transport/requests"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
 	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
 )
 
 type requestConstraint interface {
-	requests.CreateFeedbackRequest
+	requests.CreateFeedbackRequest | requests.AdhocAnalysisRequest | requests.BatchDeleteFeedbacksRequest
 }
 
 type request[T requestConstraint] struct {
@@ -52,9 +58,71 @@ func (h *Handlers) handleSvcError(resp http.ResponseWriter, err error) {
 	h.responder.RespondContent(resp, ce.ErrInternal(err))
 }
 
+// parseTopicParam reads the topic_enum path parameter, trimmed and lowercased, so that every topic-scoped
+// endpoint accepts and rejects the same values. Unknown topics yield a bad request listing the valid enums.
+func parseTopicParam(r *http.Request) (analysis.Topic, ce.ApplicationError) {
+	value := chi.URLParam(r, "topic_enum")
+	if unescaped, err := url.PathUnescape(value); err == nil {
+		value = unescaped
+	}
+
+	topic := analysis.Topic(strings.ToLower(strings.TrimSpace(value)))
+	if topic.IsValid() {
+		return topic, nil
+	}
+
+	allTopics := analysis.AllTopics()
+	validTopics := make([]string, len(allTopics))
+	for i, t := range allTopics {
+		validTopics[i] = t.String()
+	}
+	return "", ce.ErrBadRequest(
+		fmt.Sprintf("invalid topic enum %q, expected one of: %s", value, strings.Join(validTopics, ", ")),
+		ce.WithDetails(map[string]any{"valid_topics": validTopics}),
+	)
+}
+
+// parseIncludeDeleted reads the include_deleted query parameter. Asking for soft-deleted feedback is an admin-only
+// capability, so any other caller setting it to true is refused rather than silently served the filtered view.
+func parseIncludeDeleted(r *http.Request) (bool, ce.ApplicationError) {
+	value := r.URL.Query().Get("include_deleted")
+	if value == "" {
+		return false, nil
+	}
+
+	includeDeleted, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, ce.ErrBadRequest("include_deleted must be a boolean", ce.WithCauseError(err))
+	}
+	if includeDeleted && !middleware.HasRole(middleware.GetUserClaims(r), "admin") {
+		return false, ce.ErrForbidden("insufficient permissions - admin role required to include deleted feedback")
+	}
+
+	return includeDeleted, nil
+}
+
 // parseInt is a helper function to parse integer from string.
 func parseInt(s string) (int, error) {
 	var result int
 	_, err := fmt.Sscanf(s, "%d", &result)
 	return result, err
 }
+
+// parseUUIDList parses a comma-separated list of UUIDs. An empty string yields an empty list.
+func parseUUIDList(s string) ([]uuid.UUID, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(s, ",")
+	ids := make([]uuid.UUID, 0, len(parts))
+	for _, part := range parts {
+		id, err := uuid.Parse(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("invalid UUID %q: %w", part, err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
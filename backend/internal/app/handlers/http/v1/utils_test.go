@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/handlers/http/middleware"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/infrastructure/jwt"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+)
+
+func newTopicRequest(topicEnum string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("topic_enum", topicEnum)
+	r := httptest.NewRequest(http.MethodGet, "/topics/x", nil)
+	return r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestParseTopicParam(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    analysis.Topic
+		wantErr bool
+	}{
+		{name: "valid", value: "ui_ux", want: analysis.TopicUIUX},
+		{name: "uppercase", value: "UI_UX", want: analysis.TopicUIUX},
+		{name: "surrounding whitespace", value: "%20performance_reliability%20", want: analysis.TopicPerformanceReliability},
+		{name: "unknown", value: "billing", wantErr: true},
+		{name: "empty", value: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTopicParam(newTopicRequest(tt.value))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Expected an error for %q, got topic %q", tt.value, got)
+				}
+				if err.HTTPCode() != http.StatusBadRequest {
+					t.Errorf("Expected status 400, got %d", err.HTTPCode())
+				}
+				validTopics, ok := err.ErrDetails()["valid_topics"].([]string)
+				if !ok || len(validTopics) != len(analysis.AllTopics()) {
+					t.Errorf("Expected the valid topics in the error details, got %v", err.ErrDetails())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected topic %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseIncludeDeleted(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		roles      []string
+		want       bool
+		wantStatus int
+	}{
+		{name: "absent", query: "", roles: []string{"user"}},
+		{name: "false for user", query: "false", roles: []string{"user"}},
+		{name: "true for admin", query: "true", roles: []string{"user", "Admin"}, want: true},
+		{name: "true for user", query: "true", roles: []string{"user"}, wantStatus: http.StatusForbidden},
+		{name: "true without claims", query: "true", wantStatus: http.StatusForbidden},
+		{name: "not a boolean", query: "maybe", roles: []string{"admin"}, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/feedbacks?include_deleted="+tt.query, nil)
+			if tt.roles != nil {
+				claims := &jwt.Claims{Roles: tt.roles}
+				r = r.WithContext(context.WithValue(r.Context(), middleware.UserClaimsContextKey, claims))
+			}
+
+			got, err := parseIncludeDeleted(r)
+			if tt.wantStatus != 0 {
+				if err == nil {
+					t.Fatalf("Expected status %d, got no error", tt.wantStatus)
+				}
+				if err.HTTPCode() != tt.wantStatus {
+					t.Errorf("Expected status %d, got %d", tt.wantStatus, err.HTTPCode())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
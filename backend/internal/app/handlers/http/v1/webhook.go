@@ -0,0 +1,73 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/handlers/http/middleware"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/transport/responses"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/http/responder"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+)
+
+func (h *Handlers) registerWebhookRoutes(router chi.Router) {
+	router.Route(
+		"/webhooks", func(r chi.Router) {
+			// Admin-only route: dead letters contain the full event payloads
+			r.With(middleware.RequireRole("admin", h.logger, h.responder)).
+				Get("/dead-letters", trace.InstrumentHandlerFunc(h.ListDeadLetters, "GET /webhooks/dead-letters", h))
+		},
+	)
+}
+
+// ListDeadLetters retrieves events that permanently failed to be delivered
+//
+//	@Summary		List dead-lettered events (Admin only)
+//	@Description	Retrieve events that could not be delivered to a webhook within the maximum number of attempts, newest first. Requires admin role and the event outbox to be enabled.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			limit	query		int		false	"Maximum number of dead letters to return (default: 100)"	example(10)
+//	@Param			offset	query		int		false	"Number of dead letters to skip (default: 0)"	example(0)
+//	@Success		200		{object}	responses.Paginated{items=[]responses.DeadLetterResponse}	"Dead letters retrieved successfully"
+//	@Failure		400		{object}	responder.ErrorResponse	"Bad request - invalid query parameters or the event outbox is disabled"
+//	@Failure		401		{object}	responder.ErrorResponse	"Unauthorized - invalid or missing JWT token"
+//	@Failure		403		{object}	responder.ErrorResponse	"Forbidden - admin role required"
+//	@Failure		500		{object}	responder.ErrorResponse	"Internal server error"
+//	@Router			/webhooks/dead-letters [get]
+func (h *Handlers) ListDeadLetters(resp http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := h.logger.WithSpan(ctx)
+
+	var limit, offset int
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if parsedLimit, err := parseInt(limitStr); err == nil && parsedLimit > 0 {
+			limit = parsedLimit
+		}
+	}
+
+	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
+		if parsedOffset, err := parseInt(offsetStr); err == nil && parsedOffset >= 0 {
+			offset = parsedOffset
+		}
+	}
+
+	logger.Info("listing dead letters", "limit", limit, "offset", offset)
+	page, err := h.eventPublisher.ListDeadLetters(ctx, limit, offset)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error listing dead letters", err)
+		h.handleSvcError(resp, err)
+		return
+	}
+
+	deadLetterResponses := make([]responses.DeadLetterResponse, len(page.Items))
+	for i, deadLetter := range page.Items {
+		deadLetterResponses[i] = responses.DeadLetterResponseFromExternal(deadLetter)
+	}
+
+	response := responses.NewPaginated(deadLetterResponses, page.Total, page.Limit, page.Offset)
+
+	h.responder.RespondContent(resp, responder.NewGenericResponse(http.StatusOK, response))
+}
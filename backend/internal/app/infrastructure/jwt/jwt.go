@@ -1,12 +1,14 @@
 package jwt
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
 )
 
 const (
@@ -14,6 +16,11 @@ const (
 	defaultAlgorithm       = "HS256"
 )
 
+// ErrTokenExpired is returned by ParseToken when the token is well-formed and correctly signed
+// but its exp claim lies in the past, beyond the configured leeway. Clients should refresh the
+// token rather than re-authenticate with credentials.
+var ErrTokenExpired = errors.New("token expired")
+
 // Claims represents the JWT claims structure used in the application.
 // It includes standard registered claims plus custom application claims.
 type Claims struct {
@@ -28,20 +35,48 @@ type Claims struct {
 	Roles []string `json:"roles,omitempty"`
 }
 
+// Option configures optional dependencies of the token functions.
+type Option func(*options)
+
+type options struct {
+	clock clock.Clock
+}
+
+// WithClock sets the clock used for issuing and validating token timestamps.
+// Defaults to the system clock.
+func WithClock(c clock.Clock) Option {
+	return func(o *options) {
+		if c != nil {
+			o.clock = c
+		}
+	}
+}
+
+func buildOptions(opts []Option) *options {
+	o := &options{clock: clock.New()}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
 // NewClaims creates a new Claims struct with the provided user information.
 // The expiration time is calculated from the JWT config's ExpirationHours.
-func NewClaims(userID uuid.UUID, email string, roles []string, cfg *config.JWT) *Claims {
+func NewClaims(userID uuid.UUID, email string, roles []string, cfg *config.JWT, opts ...Option) *Claims {
+	o := buildOptions(opts)
+
 	expirationHours := cfg.ExpirationHours
 	if expirationHours <= 0 {
 		expirationHours = defaultExpirationHours
 	}
-	expirationTime := time.Now().Add(time.Duration(expirationHours) * time.Hour)
+	now := o.clock.Now()
+	expirationTime := now.Add(time.Duration(expirationHours) * time.Hour)
 
 	claims := &Claims{
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   userID.String(),
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
 		UserID: userID.String(),
 		Email:  email,
@@ -75,32 +110,38 @@ func GenerateToken(claims *Claims, cfg *config.JWT) (string, error) {
 }
 
 // ParseToken parses and validates a JWT token string.
+// The signature is verified with the JWT config's Secret, then with each of its PreviousSecrets in order,
+// so that tokens signed before a secret rotation stay valid until they expire.
+// The exp and iat claims are required and validated, tolerating the clock skew configured
+// by the JWT config's LeewaySeconds. An expired token yields an error wrapping ErrTokenExpired.
 // Returns the claims if the token is valid, otherwise returns an error.
-func ParseToken(tokenString string, cfg *config.JWT) (*Claims, error) {
+func ParseToken(tokenString string, cfg *config.JWT, opts ...Option) (*Claims, error) {
+	o := buildOptions(opts)
+
 	algorithm := cfg.Algorithm
 	if algorithm == "" {
 		algorithm = defaultAlgorithm
 	}
 
-	// Parse token with claims
-	claims := &Claims{}
-	token, err := jwt.ParseWithClaims(
-		tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			// Validate signing algorithm
-			expectedAlg := jwt.GetSigningMethod(algorithm)
-			if expectedAlg == nil {
-				return nil, jwt.ErrSignatureInvalid
-			}
-
-			if token.Method != expectedAlg {
-				return nil, jwt.ErrSignatureInvalid
-			}
+	secrets := append([]string{cfg.Secret}, cfg.PreviousSecrets...)
 
-			// Return the secret key for validation
-			return []byte(cfg.Secret), nil
-		},
+	var (
+		claims *Claims
+		token  *jwt.Token
+		err    error
 	)
+	for _, secret := range secrets {
+		claims = &Claims{}
+		token, err = parseWithSecret(tokenString, claims, algorithm, secret, cfg.LeewaySeconds, o)
+		// Only a signature mismatch may be caused by the secret, anything else fails with every secret
+		if !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+			break
+		}
+	}
 	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, fmt.Errorf("failed to parse token: %w", ErrTokenExpired)
+		}
 		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
 
@@ -116,6 +157,37 @@ func ParseToken(tokenString string, cfg *config.JWT) (*Claims, error) {
 	return claims, nil
 }
 
+// parseWithSecret parses the token into claims, verifying its signature with the given secret.
+func parseWithSecret(
+	tokenString string,
+	claims *Claims,
+	algorithm string,
+	secret string,
+	leewaySeconds int,
+	o *options,
+) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(
+		tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+			// Validate signing algorithm
+			expectedAlg := jwt.GetSigningMethod(algorithm)
+			if expectedAlg == nil {
+				return nil, jwt.ErrSignatureInvalid
+			}
+
+			if token.Method != expectedAlg {
+				return nil, jwt.ErrSignatureInvalid
+			}
+
+			// Return the secret key for validation
+			return []byte(secret), nil
+		},
+		jwt.WithTimeFunc(o.clock.Now),
+		jwt.WithLeeway(time.Duration(leewaySeconds)*time.Second),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+}
+
 // ExtractBearerToken extracts the bearer token from the Authorization header.
 // Returns the token string if found, otherwise returns an error.
 func ExtractBearerToken(authHeader string) (string, error) {
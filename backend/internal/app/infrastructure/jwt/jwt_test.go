@@ -0,0 +1,207 @@
+package jwt
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
+)
+
+func testJWTConfig() *config.JWT {
+	return &config.JWT{
+		Secret:          "test-secret-that-is-at-least-32-characters",
+		Algorithm:       "HS256",
+		ExpirationHours: 1,
+	}
+}
+
+func TestParseToken_BeforeExpiry(t *testing.T) {
+	cfg := testJWTConfig()
+	mockClock := clock.NewMock(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	claims := NewClaims(uuid.New(), "user@example.com", []string{"user"}, cfg, WithClock(mockClock))
+	token, err := GenerateToken(claims, cfg)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	mockClock.Advance(59 * time.Minute)
+
+	parsed, err := ParseToken(token, cfg, WithClock(mockClock))
+	if err != nil {
+		t.Fatalf("Expected token to be valid before expiry, got error: %v", err)
+	}
+	if parsed.UserID != claims.UserID {
+		t.Errorf("Expected user ID '%s', got '%s'", claims.UserID, parsed.UserID)
+	}
+}
+
+func TestParseToken_AfterExpiry(t *testing.T) {
+	cfg := testJWTConfig()
+	mockClock := clock.NewMock(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	claims := NewClaims(uuid.New(), "user@example.com", []string{"user"}, cfg, WithClock(mockClock))
+	token, err := GenerateToken(claims, cfg)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	mockClock.Advance(61 * time.Minute)
+
+	_, err = ParseToken(token, cfg, WithClock(mockClock))
+	if !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("Expected ErrTokenExpired after expiry, got: %v", err)
+	}
+}
+
+func TestParseToken_LeewayToleratesSkew(t *testing.T) {
+	cfg := testJWTConfig()
+	cfg.LeewaySeconds = 30
+	mockClock := clock.NewMock(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	claims := NewClaims(uuid.New(), "user@example.com", []string{"user"}, cfg, WithClock(mockClock))
+	token, err := GenerateToken(claims, cfg)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	// A verifier whose clock lags behind the issuer sees iat in the future.
+	behind := clock.NewMock(time.Date(2026, 1, 1, 11, 59, 40, 0, time.UTC))
+	if _, err := ParseToken(token, cfg, WithClock(behind)); err != nil {
+		t.Errorf("Expected token issued within leeway to be valid, got error: %v", err)
+	}
+
+	mockClock.Advance(time.Hour + 20*time.Second)
+	if _, err := ParseToken(token, cfg, WithClock(mockClock)); err != nil {
+		t.Errorf("Expected token expired within leeway to be valid, got error: %v", err)
+	}
+
+	mockClock.Advance(20 * time.Second)
+	if _, err := ParseToken(token, cfg, WithClock(mockClock)); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("Expected ErrTokenExpired beyond leeway, got: %v", err)
+	}
+}
+
+func TestParseToken_IssuedInFuture(t *testing.T) {
+	cfg := testJWTConfig()
+	mockClock := clock.NewMock(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	claims := NewClaims(uuid.New(), "user@example.com", []string{"user"}, cfg, WithClock(mockClock))
+	token, err := GenerateToken(claims, cfg)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	behind := clock.NewMock(time.Date(2026, 1, 1, 11, 59, 0, 0, time.UTC))
+	_, err = ParseToken(token, cfg, WithClock(behind))
+	if err == nil {
+		t.Fatal("Expected token issued in the future to be rejected")
+	}
+	if errors.Is(err, ErrTokenExpired) {
+		t.Errorf("Expected an invalid-token error rather than ErrTokenExpired, got: %v", err)
+	}
+}
+
+func TestParseToken_RequiresExpiration(t *testing.T) {
+	cfg := testJWTConfig()
+	claims := NewClaims(uuid.New(), "user@example.com", []string{"user"}, cfg)
+	claims.ExpiresAt = nil
+	token, err := GenerateToken(claims, cfg)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	_, err = ParseToken(token, cfg)
+	if !errors.Is(err, jwt.ErrTokenRequiredClaimMissing) {
+		t.Errorf("Expected missing exp claim to be rejected, got: %v", err)
+	}
+}
+
+func TestNewClaims_ExpirationFromClock(t *testing.T) {
+	cfg := testJWTConfig()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	claims := NewClaims(uuid.New(), "user@example.com", []string{"user"}, cfg, WithClock(clock.NewMock(now)))
+
+	if !claims.IssuedAt.Time.Equal(now) {
+		t.Errorf("Expected issued at %v, got %v", now, claims.IssuedAt.Time)
+	}
+	if expected := now.Add(time.Hour); !claims.ExpiresAt.Time.Equal(expected) {
+		t.Errorf("Expected expires at %v, got %v", expected, claims.ExpiresAt.Time)
+	}
+}
+
+func TestParseToken_PreviousSecret(t *testing.T) {
+	oldCfg := testJWTConfig()
+	mockClock := clock.NewMock(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	claims := NewClaims(uuid.New(), "user@example.com", []string{"user"}, oldCfg, WithClock(mockClock))
+	token, err := GenerateToken(claims, oldCfg)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	// Rotate the secret, keeping the old one for verification
+	rotatedCfg := testJWTConfig()
+	rotatedCfg.Secret = "rotated-secret-that-is-at-least-32-characters"
+	rotatedCfg.PreviousSecrets = []string{"unrelated-secret-that-is-at-least-32-chars", oldCfg.Secret}
+
+	parsed, err := ParseToken(token, rotatedCfg, WithClock(mockClock))
+	if err != nil {
+		t.Fatalf("Expected token signed with a previous secret to be valid, got error: %v", err)
+	}
+	if parsed.UserID != claims.UserID {
+		t.Errorf("Expected user ID '%s', got '%s'", claims.UserID, parsed.UserID)
+	}
+
+	newToken, err := GenerateToken(claims, rotatedCfg)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	if _, err := ParseToken(newToken, oldCfg, WithClock(mockClock)); !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+		t.Errorf("Expected new tokens to be signed with the current secret, got: %v", err)
+	}
+}
+
+func TestParseToken_PreviousSecretExpired(t *testing.T) {
+	oldCfg := testJWTConfig()
+	mockClock := clock.NewMock(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	token, err := GenerateToken(
+		NewClaims(uuid.New(), "user@example.com", []string{"user"}, oldCfg, WithClock(mockClock)),
+		oldCfg,
+	)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	rotatedCfg := testJWTConfig()
+	rotatedCfg.Secret = "rotated-secret-that-is-at-least-32-characters"
+	rotatedCfg.PreviousSecrets = []string{oldCfg.Secret}
+
+	mockClock.Advance(61 * time.Minute)
+
+	if _, err := ParseToken(token, rotatedCfg, WithClock(mockClock)); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("Expected ErrTokenExpired for an expired token signed with a previous secret, got: %v", err)
+	}
+}
+
+func TestParseToken_UnknownSecret(t *testing.T) {
+	cfg := testJWTConfig()
+	otherCfg := testJWTConfig()
+	otherCfg.Secret = "another-secret-that-is-at-least-32-characters"
+
+	token, err := GenerateToken(NewClaims(uuid.New(), "user@example.com", []string{"user"}, otherCfg), otherCfg)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	cfg.PreviousSecrets = []string{"unrelated-secret-that-is-at-least-32-chars"}
+	if _, err := ParseToken(token, cfg); !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+		t.Errorf("Expected a signature error for a token signed with an unknown secret, got: %v", err)
+	}
+}
@@ -0,0 +1,174 @@
+package analysis
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// recordingQuerier records the statements and COPY operations issued through it.
+type recordingQuerier struct {
+	execs  int
+	copies []pgx.Identifier
+	rows   int
+}
+
+func (q *recordingQuerier) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	q.execs++
+	return pgconn.CommandTag{}, nil
+}
+
+func (q *recordingQuerier) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	panic("unexpected query")
+}
+
+func (q *recordingQuerier) QueryRow(context.Context, string, ...any) pgx.Row {
+	panic("unexpected query")
+}
+
+func (q *recordingQuerier) SendBatch(context.Context, *pgx.Batch) pgx.BatchResults {
+	panic("unexpected batch")
+}
+
+func (q *recordingQuerier) CopyFrom(
+	_ context.Context,
+	tableName pgx.Identifier,
+	_ []string,
+	rowSrc pgx.CopyFromSource,
+) (int64, error) {
+	q.copies = append(q.copies, tableName)
+	for rowSrc.Next() {
+		if _, err := rowSrc.Values(); err != nil {
+			return 0, err
+		}
+		q.rows++
+	}
+	return int64(q.rows), nil
+}
+
+func newIDs(n int) []uuid.UUID {
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+	return ids
+}
+
+func TestRepo_CreateTopicAssignments_CopyAboveThreshold(t *testing.T) {
+	tests := []struct {
+		name      string
+		ids       []uuid.UUID
+		wantExecs int
+		wantRows  int
+	}{
+		{name: "empty", ids: nil},
+		{name: "at threshold", ids: newIDs(3), wantExecs: 1},
+		{name: "above threshold", ids: newIDs(4), wantRows: 4},
+		{name: "duplicates counted once", ids: append(newIDs(3), uuid.Nil, uuid.Nil), wantRows: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				q := &recordingQuerier{}
+				r, err := NewAnalysisRepository(q, WithCopyThreshold(3))
+				if err != nil {
+					t.Fatalf("Failed to create repository: %v", err)
+				}
+
+				if err := r.CreateTopicAssignments(context.Background(), uuid.New(), uuid.New(), tt.ids); err != nil {
+					t.Fatalf("Expected no error, got: %v", err)
+				}
+				if q.execs != tt.wantExecs || q.rows != tt.wantRows {
+					t.Errorf(
+						"Expected %d INSERTs and %d copied rows, got %d and %d",
+						tt.wantExecs,
+						tt.wantRows,
+						q.execs,
+						q.rows,
+					)
+				}
+			},
+		)
+	}
+}
+
+func TestRepo_CreateAnalyzedFeedbacks_CopyAboveThreshold(t *testing.T) {
+	q := &recordingQuerier{}
+	r, err := NewAnalysisRepository(q, WithCopyThreshold(3))
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	if err := r.CreateAnalyzedFeedbacks(context.Background(), uuid.New(), newIDs(3)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if err := r.CreateAnalyzedFeedbacks(context.Background(), uuid.New(), newIDs(5)); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if q.execs != 1 {
+		t.Errorf("Expected 1 INSERT, got %d", q.execs)
+	}
+	if len(q.copies) != 1 || q.copies[0].Sanitize() != `"feedback"."analyzed_feedbacks"` || q.rows != 5 {
+		t.Errorf("Expected 5 rows copied into feedback.analyzed_feedbacks, got %d into %v", q.rows, q.copies)
+	}
+}
+
+// BenchmarkRepo_CreateTopicAssignments compares the single INSERT with COPY against the database of
+// BENCH_DATABASE_DSN, which must have the migrations applied. Each insert runs in a transaction that is
+// rolled back, with foreign key checks disabled so that no analysis, topic or feedbacks have to exist.
+func BenchmarkRepo_CreateTopicAssignments(b *testing.B) {
+	dsn := os.Getenv("BENCH_DATABASE_DSN")
+	if dsn == "" {
+		b.Skip("BENCH_DATABASE_DSN not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		b.Fatalf("Failed to connect: %v", err)
+	}
+	defer pool.Close()
+
+	for _, size := range []int{10, 100, 1000, 10000} {
+		feedbackIDs := newIDs(size)
+		for _, bm := range []struct {
+			name      string
+			threshold int
+		}{
+			{name: "insert", threshold: size},
+			{name: "copy", threshold: size - 1},
+		} {
+			b.Run(
+				bm.name+"/"+strconv.Itoa(size), func(b *testing.B) {
+					for b.Loop() {
+						tx, err := pool.Begin(ctx)
+						if err != nil {
+							b.Fatalf("Failed to begin transaction: %v", err)
+						}
+						if _, err := tx.Exec(ctx, "SET LOCAL session_replication_role = replica"); err != nil {
+							b.Fatalf("Failed to disable foreign key checks: %v", err)
+						}
+
+						r, err := NewAnalysisRepository(tx, WithCopyThreshold(bm.threshold))
+						if err != nil {
+							b.Fatalf("Failed to create repository: %v", err)
+						}
+						if err := r.CreateTopicAssignments(ctx, uuid.New(), uuid.New(), feedbackIDs); err != nil {
+							b.Fatalf("Failed to create topic assignments: %v", err)
+						}
+
+						_ = tx.Rollback(ctx)
+					}
+				},
+			)
+		}
+	}
+}
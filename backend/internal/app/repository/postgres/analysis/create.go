@@ -33,12 +33,24 @@ func (r *repo) Create(
 		newFeedbackCount = &count
 	}
 
+	var overallSummary *string
+	if a.OverallSummary().IsSome() {
+		summary := a.OverallSummary().Unwrap()
+		overallSummary = &summary
+	}
+
 	var failureReason *string
 	if a.FailureReason().IsSome() {
 		reason := a.FailureReason().Unwrap()
 		failureReason = &reason
 	}
 
+	var provider *string
+	if a.Provider().IsSome() {
+		p := a.Provider().Unwrap()
+		provider = &p
+	}
+
 	var completedAt *time.Time
 	if a.CompletedAt().IsSome() {
 		completed := a.CompletedAt().Unwrap()
@@ -53,7 +65,7 @@ func (r *repo) Create(
 			PeriodEnd:          a.PeriodEnd(),
 			FeedbackCount:      int32(a.FeedbackCount()),
 			NewFeedbackCount:   newFeedbackCount,
-			OverallSummary:     a.OverallSummary(),
+			OverallSummary:     overallSummary,
 			Sentiment:          sqlc.FeedbackSentiment(a.Sentiment()),
 			KeyInsights:        a.KeyInsights(),
 			Model:              a.Model(),
@@ -63,6 +75,10 @@ func (r *repo) Create(
 			FailureReason:      failureReason,
 			CreatedAt:          a.CreatedAt(),
 			CompletedAt:        completedAt,
+			DeduplicatedCount:  int32(a.DeduplicatedCount()),
+			PeriodSemantics:    a.PeriodSemantics().String(),
+			Provider:           provider,
+			Representative:     a.IsRepresentative(),
 		},
 	)
 	if err != nil {
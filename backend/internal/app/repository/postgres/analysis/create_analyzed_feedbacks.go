@@ -12,27 +12,47 @@ import (
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
 )
 
+// CreateAnalyzedFeedbacks writes the records with COPY when there are more than the copy threshold,
+// and with a single INSERT otherwise.
 func (r *repo) CreateAnalyzedFeedbacks(
 	ctx context.Context,
 	analysisID uuid.UUID,
 	feedbackIDs []uuid.UUID,
 	opts ...repository.RepoOption[apprepo.Options],
 ) error {
+	feedbackIDs = uniqueIDs(feedbackIDs)
+	if len(feedbackIDs) == 0 {
+		return nil
+	}
+
 	q := utils.GetQuerier(opts, r.defaultQuerier)
 	queries := newSQLCQueries(q)
 
 	now := time.Now().UTC()
-	for _, feedbackID := range feedbackIDs {
-		err := queries.CreateAnalyzedFeedback(
-			ctx, sqlc.CreateAnalyzedFeedbackParams{
+	if len(feedbackIDs) > r.copyThreshold {
+		rows := make([]sqlc.CopyAnalyzedFeedbacksParams, len(feedbackIDs))
+		for i, feedbackID := range feedbackIDs {
+			rows[i] = sqlc.CopyAnalyzedFeedbacksParams{
 				AnalysisID: analysisID,
 				FeedbackID: feedbackID,
 				CreatedAt:  now,
-			},
-		)
-		if err != nil {
-			return fmt.Errorf("failed to create analyzed feedback record for feedback %s: %w", feedbackID.String(), err)
+			}
+		}
+		if _, err := queries.CopyAnalyzedFeedbacks(ctx, rows); err != nil {
+			return fmt.Errorf("failed to copy %d analyzed feedback records: %w", len(rows), err)
 		}
+		return nil
+	}
+
+	err := queries.CreateAnalyzedFeedbacks(
+		ctx, sqlc.CreateAnalyzedFeedbacksParams{
+			AnalysisID:  analysisID,
+			FeedbackIds: feedbackIDs,
+			CreatedAt:   now,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create %d analyzed feedback records: %w", len(feedbackIDs), err)
 	}
 
 	return nil
@@ -19,16 +19,36 @@ func (r *repo) CreateTopicAnalysis(
 	q := utils.GetQuerier(opts, r.defaultQuerier)
 	queries := newSQLCQueries(q)
 
+	// The sentiment distribution is stored as all or none of its counts
+	var positiveCount, mixedCount, negativeCount *int32
+	if distribution := topicAnalysis.SentimentDistribution(); distribution.IsSome() {
+		counts := distribution.Unwrap()
+		positive, mixed, negative := int32(counts.Positive), int32(counts.Mixed), int32(counts.Negative)
+		positiveCount, mixedCount, negativeCount = &positive, &mixed, &negative
+	}
+
+	var confidence *float64
+	if topicAnalysis.Confidence().IsSome() {
+		value := topicAnalysis.Confidence().Unwrap()
+		confidence = &value
+	}
+
 	if _, err := queries.CreateTopicAnalysis(
 		ctx, sqlc.CreateTopicAnalysisParams{
-			ID:            topicAnalysis.ID(),
-			AnalysisID:    topicAnalysis.AnalysisID(),
-			TopicEnum:     sqlc.FeedbackTopicEnum(topicAnalysis.Topic()),
-			Summary:       topicAnalysis.Summary(),
-			FeedbackCount: int32(topicAnalysis.FeedbackCount()),
-			Sentiment:     sqlc.FeedbackSentiment(topicAnalysis.Sentiment()),
-			CreatedAt:     topicAnalysis.CreatedAt(),
-			UpdatedAt:     topicAnalysis.UpdatedAt(),
+			ID:                topicAnalysis.ID(),
+			AnalysisID:        topicAnalysis.AnalysisID(),
+			TopicEnum:         sqlc.FeedbackTopicEnum(topicAnalysis.Topic()),
+			Summary:           topicAnalysis.Summary(),
+			FeedbackCount:     int32(topicAnalysis.FeedbackCount()),
+			Sentiment:         sqlc.FeedbackSentiment(topicAnalysis.Sentiment()),
+			CreatedAt:         topicAnalysis.CreatedAt(),
+			UpdatedAt:         topicAnalysis.UpdatedAt(),
+			PositiveCount:     positiveCount,
+			MixedCount:        mixedCount,
+			NegativeCount:     negativeCount,
+			Recommendations:   topicAnalysis.Recommendations(),
+			Confidence:        confidence,
+			SentimentConflict: topicAnalysis.SentimentConflict(),
 		},
 	); err != nil {
 		return fmt.Errorf("failed to create topic analysis: %w", err)
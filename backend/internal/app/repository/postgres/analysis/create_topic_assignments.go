@@ -12,6 +12,8 @@ import (
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
 )
 
+// CreateTopicAssignments writes the assignments with COPY when there are more than the copy threshold,
+// and with a single INSERT otherwise.
 func (r *repo) CreateTopicAssignments(
 	ctx context.Context,
 	analysisID uuid.UUID,
@@ -19,24 +21,62 @@ func (r *repo) CreateTopicAssignments(
 	feedbackIDs []uuid.UUID,
 	opts ...repository.RepoOption[apprepo.Options],
 ) error {
+	// COPY fails on duplicate rows instead of skipping them
+	feedbackIDs = uniqueIDs(feedbackIDs)
+	if len(feedbackIDs) == 0 {
+		return nil
+	}
+
 	q := utils.GetQuerier(opts, r.defaultQuerier)
 	queries := newSQLCQueries(q)
 
 	now := time.Now().UTC()
-	for _, feedbackID := range feedbackIDs {
-		err := queries.CreateTopicAssignment(
-			ctx, sqlc.CreateTopicAssignmentParams{
+	if len(feedbackIDs) > r.copyThreshold {
+		rows := make([]sqlc.CopyTopicAssignmentsParams, len(feedbackIDs))
+		for i, feedbackID := range feedbackIDs {
+			rows[i] = sqlc.CopyTopicAssignmentsParams{
 				ID:         uuid.New(),
 				AnalysisID: analysisID,
 				FeedbackID: feedbackID,
 				TopicID:    topicID,
 				CreatedAt:  now,
-			},
-		)
-		if err != nil {
-			return fmt.Errorf("failed to create topic assignment for feedback %s: %w", feedbackID.String(), err)
+			}
+		}
+		if _, err := queries.CopyTopicAssignments(ctx, rows); err != nil {
+			return fmt.Errorf("failed to copy %d topic assignments: %w", len(rows), err)
 		}
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(feedbackIDs))
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+	err := queries.CreateTopicAssignments(
+		ctx, sqlc.CreateTopicAssignmentsParams{
+			AnalysisID:  analysisID,
+			TopicID:     topicID,
+			CreatedAt:   now,
+			Ids:         ids,
+			FeedbackIds: feedbackIDs,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create %d topic assignments: %w", len(feedbackIDs), err)
 	}
 
 	return nil
 }
+
+// uniqueIDs returns the IDs without duplicates, keeping the order of their first occurrence.
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,36 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/analysis/sqlc"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) SaveDeadLetter(
+	ctx context.Context,
+	feedbackID uuid.UUID,
+	analysisID uuid.UUID,
+	reason string,
+	opts ...repository.RepoOption[apprepo.Options],
+) error {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	err := queries.UpsertAnalysisDeadLetter(
+		ctx, sqlc.UpsertAnalysisDeadLetterParams{
+			FeedbackID: feedbackID,
+			AnalysisID: &analysisID,
+			Reason:     reason,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save dead letter: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/analysis/sqlc"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) CreateEvent(
+	ctx context.Context,
+	event *analysis.Event,
+	opts ...repository.RepoOption[apprepo.Options],
+) error {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	details := event.Details
+	if details == nil {
+		details = map[string]any{}
+	}
+	encodedDetails, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("failed to encode analysis event details: %w", err)
+	}
+
+	var durationMs *int64
+	if event.Duration.IsSome() {
+		ms := event.Duration.Unwrap().Milliseconds()
+		durationMs = &ms
+	}
+
+	err = queries.CreateAnalysisEvent(
+		ctx, sqlc.CreateAnalysisEventParams{
+			AnalysisID: event.AnalysisID,
+			EventType:  string(event.Type),
+			DurationMs: durationMs,
+			Details:    encodedDetails,
+			OccurredAt: event.OccurredAt,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create analysis event: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) ListEvents(
+	ctx context.Context,
+	analysisID uuid.UUID,
+	opts ...repository.RepoOption[apprepo.Options],
+) ([]*analysis.Event, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	sqlcEvents, err := queries.ListAnalysisEvents(ctx, analysisID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list analysis events: %w", err)
+	}
+
+	events := make([]*analysis.Event, len(sqlcEvents))
+	for i, sqlcEvent := range sqlcEvents {
+		events[i] = mapSQLCEventToDomain(sqlcEvent)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,49 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) GetAnalysesByFeedbackID(
+	ctx context.Context,
+	feedbackID uuid.UUID,
+	opts ...repository.RepoOption[apprepo.Options],
+) ([]apprepo.FeedbackAnalysis, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	sqlcAnalyses, err := queries.GetAnalysesByFeedbackID(ctx, feedbackID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get analyses by feedback ID: %w", err)
+	}
+
+	sqlcTopics, err := queries.GetTopicsByFeedbackID(ctx, feedbackID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get topic analyses by feedback ID: %w", err)
+	}
+
+	topicsByAnalysis := make(map[uuid.UUID][]*analysis.TopicAnalysis)
+	for _, sqlcTopic := range sqlcTopics {
+		topicsByAnalysis[sqlcTopic.AnalysisID] = append(
+			topicsByAnalysis[sqlcTopic.AnalysisID],
+			mapSQLCTopicToDomain(sqlcTopic),
+		)
+	}
+
+	result := make([]apprepo.FeedbackAnalysis, len(sqlcAnalyses))
+	for i, sqlcAnalysis := range sqlcAnalyses {
+		result[i] = apprepo.FeedbackAnalysis{
+			Analysis: mapSQLCAnalysisToDomain(sqlcAnalysis),
+			Topics:   topicsByAnalysis[sqlcAnalysis.ID],
+		}
+	}
+
+	return result, nil
+}
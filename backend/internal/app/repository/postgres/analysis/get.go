@@ -2,10 +2,11 @@ package analysis
 
 import (
 	"context"
-	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
@@ -22,8 +23,8 @@ func (r *repo) GetByID(
 
 	sqlcAnalysis, err := queries.GetAnalysisByID(ctx, analysisID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("analysis not found: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to get analysis: %w", err)
 	}
@@ -40,7 +41,7 @@ func (r *repo) GetLatest(
 
 	sqlcAnalysis, err := queries.GetLatestAnalysis(ctx)
 	if err != nil {
-		if err == sql.ErrNoRows {
+		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil // No previous analysis exists
 		}
 		return nil, fmt.Errorf("failed to get latest analysis: %w", err)
@@ -48,3 +49,21 @@ func (r *repo) GetLatest(
 
 	return mapSQLCAnalysisToDomain(sqlcAnalysis), nil
 }
+
+func (r *repo) GetLatestRepresentative(
+	ctx context.Context,
+	opts ...repository.RepoOption[apprepo.Options],
+) (*analysis.Analysis, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	sqlcAnalysis, err := queries.GetLatestRepresentativeAnalysis(ctx)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest representative analysis: %w", err)
+	}
+
+	return mapSQLCAnalysisToDomain(sqlcAnalysis), nil
+}
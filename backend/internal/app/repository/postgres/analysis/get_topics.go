@@ -2,10 +2,13 @@ package analysis
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/analysis/sqlc"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
@@ -32,6 +35,51 @@ func (r *repo) GetTopicsByAnalysisID(
 	return topicAnalyses, nil
 }
 
+func (r *repo) GetTopicAnalysesByEnum(
+	ctx context.Context,
+	topic analysis.Topic,
+	opts ...repository.RepoOption[apprepo.Options],
+) ([]apprepo.TopicHistoryEntry, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	rows, err := queries.GetTopicAnalysesByEnum(ctx, sqlc.FeedbackTopicEnum(topic))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get topic analyses by topic enum: %w", err)
+	}
+
+	entries := make([]apprepo.TopicHistoryEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = apprepo.TopicHistoryEntry{
+			TopicAnalysis:     mapSQLCTopicToDomain(row.Topic),
+			PeriodStart:       row.PeriodStart,
+			PeriodEnd:         row.PeriodEnd,
+			AnalysisCreatedAt: row.AnalysisCreatedAt,
+		}
+	}
+
+	return entries, nil
+}
+
+func (r *repo) GetTopicAnalysisByID(
+	ctx context.Context,
+	topicID uuid.UUID,
+	opts ...repository.RepoOption[apprepo.Options],
+) (*analysis.TopicAnalysis, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	sqlcTopic, err := queries.GetTopicAnalysisByID(ctx, topicID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get topic analysis: %w", err)
+	}
+
+	return mapSQLCTopicToDomain(sqlcTopic), nil
+}
+
 func (r *repo) GetFeedbackIDsByTopicID(
 	ctx context.Context,
 	topicID uuid.UUID,
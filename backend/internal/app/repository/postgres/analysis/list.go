@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/analysis/sqlc"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
@@ -17,7 +18,18 @@ func (r *repo) List(
 	q := utils.GetQuerier(opts, r.defaultQuerier)
 	queries := newSQLCQueries(q)
 
-	sqlcAnalyses, err := queries.ListAnalyses(ctx)
+	var params sqlc.ListAnalysesParams
+	if options := utils.BuildOpts(opts).Ext; options != nil {
+		if options.Limit > 0 {
+			limit := int32(options.Limit)
+			params.Limit = &limit
+		}
+		if options.Offset > 0 {
+			params.Offset = int32(options.Offset)
+		}
+	}
+
+	sqlcAnalyses, err := queries.ListAnalyses(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list analyses: %w", err)
 	}
@@ -29,3 +41,18 @@ func (r *repo) List(
 
 	return analyses, nil
 }
+
+func (r *repo) Count(
+	ctx context.Context,
+	opts ...repository.RepoOption[apprepo.Options],
+) (int, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	count, err := queries.CountAnalyses(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count analyses: %w", err)
+	}
+
+	return int(count), nil
+}
@@ -1,8 +1,12 @@
 package analysis
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/analysis/sqlc"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
 )
 
 // mapSQLCAnalysisToDomain maps a SQLC analysis model to a domain analysis entity.
@@ -11,16 +15,22 @@ func mapSQLCAnalysisToDomain(sqlcAnalysis sqlc.Analysis) *analysis.Analysis {
 		WithID(sqlcAnalysis.ID).
 		WithPeriod(sqlcAnalysis.PeriodStart, sqlcAnalysis.PeriodEnd).
 		WithFeedbackCount(int(sqlcAnalysis.FeedbackCount)).
-		WithOverallSummary(sqlcAnalysis.OverallSummary).
 		WithSentiment(analysis.Sentiment(sqlcAnalysis.Sentiment)).
 		WithKeyInsights(sqlcAnalysis.KeyInsights).
 		WithModel(sqlcAnalysis.Model).
 		WithTokens(int(sqlcAnalysis.Tokens)).
 		WithAnalysisDurationMs(int(sqlcAnalysis.AnalysisDurationMs)).
 		WithStatus(analysis.Status(sqlcAnalysis.Status)).
-		WithCreatedAt(sqlcAnalysis.CreatedAt)
+		WithCreatedAt(sqlcAnalysis.CreatedAt).
+		WithNoTopicsIdentified(sqlcAnalysis.NoTopicsIdentified).
+		WithDeduplicatedCount(int(sqlcAnalysis.DeduplicatedCount)).
+		WithRepresentative(sqlcAnalysis.Representative).
+		WithPeriodSemantics(analysis.PeriodSemantics(sqlcAnalysis.PeriodSemantics))
 
 	// Handle optional fields (nullable fields use pointers)
+	if sqlcAnalysis.OverallSummary != nil {
+		builder.WithOverallSummary(*sqlcAnalysis.OverallSummary)
+	}
 	if sqlcAnalysis.PreviousAnalysisID != nil {
 		builder.WithPreviousAnalysisID(*sqlcAnalysis.PreviousAnalysisID)
 	}
@@ -37,6 +47,10 @@ func mapSQLCAnalysisToDomain(sqlcAnalysis sqlc.Analysis) *analysis.Analysis {
 		builder.WithCompletedAt(*sqlcAnalysis.CompletedAt)
 	}
 
+	if sqlcAnalysis.Provider != nil {
+		builder.WithProvider(*sqlcAnalysis.Provider)
+	}
+
 	return builder.BuildUnchecked()
 }
 
@@ -49,8 +63,44 @@ func mapSQLCTopicToDomain(sqlcTopic sqlc.Topic) *analysis.TopicAnalysis {
 		WithSummary(sqlcTopic.Summary).
 		WithFeedbackCount(int(sqlcTopic.FeedbackCount)).
 		WithSentiment(analysis.Sentiment(sqlcTopic.Sentiment)).
+		WithRecommendations(sqlcTopic.Recommendations).
 		WithCreatedAt(sqlcTopic.CreatedAt).
 		WithUpdatedAt(sqlcTopic.UpdatedAt)
 
+	// Counts are either all set or all null
+	if sqlcTopic.PositiveCount != nil && sqlcTopic.MixedCount != nil && sqlcTopic.NegativeCount != nil {
+		builder.WithSentimentDistribution(
+			analysis.SentimentDistribution{
+				Positive: int(*sqlcTopic.PositiveCount),
+				Mixed:    int(*sqlcTopic.MixedCount),
+				Negative: int(*sqlcTopic.NegativeCount),
+			},
+		)
+	}
+
+	if sqlcTopic.Confidence != nil {
+		builder.WithConfidence(*sqlcTopic.Confidence)
+	}
+
+	builder.WithSentimentConflict(sqlcTopic.SentimentConflict)
+
 	return builder.BuildUnchecked()
 }
+
+// mapSQLCEventToDomain converts a stored analysis event. Details that cannot be decoded are left empty,
+// so that a malformed column never hides the event itself.
+func mapSQLCEventToDomain(e sqlc.AnalysisEvent) *analysis.Event {
+	event := &analysis.Event{
+		AnalysisID: e.AnalysisID,
+		Type:       analysis.EventType(e.EventType),
+		Duration:   optional.None[time.Duration](),
+		OccurredAt: e.OccurredAt,
+	}
+	if e.DurationMs != nil {
+		event.Duration = optional.Some(time.Duration(*e.DurationMs) * time.Millisecond)
+	}
+	if err := json.Unmarshal(e.Details, &event.Details); err != nil {
+		event.Details = nil
+	}
+	return event
+}
@@ -0,0 +1,51 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) AggregateOverview(
+	ctx context.Context,
+	opts ...repository.RepoOption[apprepo.Options],
+) (*apprepo.AnalysesOverview, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	totals, err := queries.GetAnalysesOverview(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate analyses: %w", err)
+	}
+
+	analyzedFeedbacks, err := queries.CountAnalyzedFeedbacks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count analyzed feedbacks: %w", err)
+	}
+
+	topicRows, err := queries.CountTopicAssignments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count topic assignments: %w", err)
+	}
+
+	overview := &apprepo.AnalysesOverview{
+		TotalAnalyses:               int(totals.TotalAnalyses),
+		SuccessfulAnalyses:          int(totals.SuccessfulAnalyses),
+		AnalyzedFeedbacks:           int(analyzedFeedbacks),
+		TotalTokens:                 totals.TotalTokens,
+		AverageFeedbacksPerAnalysis: totals.AverageFeedbacksPerAnalysis,
+		TopicFeedbacks:              make([]apprepo.TopicFeedbackCount, len(topicRows)),
+	}
+	for i, row := range topicRows {
+		overview.TopicFeedbacks[i] = apprepo.TopicFeedbackCount{
+			Topic:         analysis.Topic(row.TopicEnum),
+			FeedbackCount: int(row.FeedbackCount),
+		}
+	}
+
+	return overview, nil
+}
@@ -15,7 +15,11 @@ INSERT INTO feedback.analyses (
     status,
     failure_reason,
     created_at,
-    completed_at
+    completed_at,
+    deduplicated_count,
+    period_semantics,
+    provider,
+    representative
 ) VALUES (
     $1,  -- id
     $2,  -- previous_analysis_id (nullable)
@@ -32,6 +36,10 @@ INSERT INTO feedback.analyses (
     $13, -- status
     $14, -- failure_reason (nullable)
     $15, -- created_at
-    $16  -- completed_at (nullable)
+    $16, -- completed_at (nullable)
+    $17, -- deduplicated_count
+    $18, -- period_semantics
+    $19, -- provider (nullable)
+    $20  -- representative
 )
 RETURNING *;
@@ -1,4 +1,14 @@
--- name: CreateAnalyzedFeedback :exec
+-- name: CreateAnalyzedFeedbacks :exec
+-- Inserts the analyzed feedbacks of an analysis with a single statement, one row per element of feedback_ids.
+INSERT INTO feedback.analyzed_feedbacks (
+    analysis_id,
+    feedback_id,
+    created_at
+)
+SELECT @analysis_id::uuid, unnest(@feedback_ids::uuid[]), @created_at::timestamp
+ON CONFLICT (analysis_id, feedback_id) DO NOTHING;
+
+-- name: CopyAnalyzedFeedbacks :copyfrom
 INSERT INTO feedback.analyzed_feedbacks (
     analysis_id,
     feedback_id,
@@ -7,8 +17,7 @@ INSERT INTO feedback.analyzed_feedbacks (
     $1,  -- analysis_id
     $2,  -- feedback_id
     $3   -- created_at
-)
-ON CONFLICT (analysis_id, feedback_id) DO NOTHING;
+);
 
 -- name: GetFeedbackIDsByAnalysisID :many
 SELECT feedback_id FROM feedback.analyzed_feedbacks
@@ -7,7 +7,13 @@ INSERT INTO feedback.analysis_topics (
     feedback_count,
     sentiment,
     created_at,
-    updated_at
+    updated_at,
+    positive_count,
+    mixed_count,
+    negative_count,
+    recommendations,
+    confidence,
+    sentiment_conflict
 ) VALUES (
     $1,  -- id
     $2,  -- analysis_id
@@ -16,6 +22,12 @@ INSERT INTO feedback.analysis_topics (
     $5,  -- feedback_count
     $6,  -- sentiment
     $7,  -- created_at
-    $8   -- updated_at
+    $8,  -- updated_at
+    $9,  -- positive_count
+    $10, -- mixed_count
+    $11, -- negative_count
+    $12, -- recommendations
+    $13, -- confidence
+    $14  -- sentiment_conflict
 )
 RETURNING *;
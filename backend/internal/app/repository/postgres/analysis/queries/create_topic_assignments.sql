@@ -1,4 +1,17 @@
--- name: CreateTopicAssignment :exec
+-- name: CreateTopicAssignments :exec
+-- Inserts the assignments of a topic with a single statement, one row per pair of elements of ids and feedback_ids.
+INSERT INTO feedback.feedback_topic_assignments (
+    id,
+    analysis_id,
+    feedback_id,
+    topic_id,
+    created_at
+)
+SELECT a.id, @analysis_id::uuid, a.feedback_id, @topic_id::uuid, @created_at::timestamp
+FROM unnest(@ids::uuid[], @feedback_ids::uuid[]) AS a (id, feedback_id)
+ON CONFLICT (analysis_id, feedback_id, topic_id) DO NOTHING;
+
+-- name: CopyTopicAssignments :copyfrom
 INSERT INTO feedback.feedback_topic_assignments (
     id,
     analysis_id,
@@ -11,5 +24,4 @@ INSERT INTO feedback.feedback_topic_assignments (
     $3,  -- feedback_id
     $4,  -- topic_id
     $5   -- created_at
-)
-ON CONFLICT (analysis_id, feedback_id, topic_id) DO NOTHING;
+);
@@ -0,0 +1,15 @@
+-- name: UpsertAnalysisDeadLetter :exec
+INSERT INTO feedback.analysis_dead_letters (
+    feedback_id,
+    analysis_id,
+    reason
+) VALUES (
+    $1,  -- feedback_id
+    $2,  -- analysis_id
+    $3   -- reason
+)
+ON CONFLICT (feedback_id) DO UPDATE
+SET
+    analysis_id = EXCLUDED.analysis_id,
+    reason = EXCLUDED.reason,
+    created_at = NOW();
@@ -0,0 +1,19 @@
+-- name: CreateAnalysisEvent :exec
+INSERT INTO feedback.analysis_events (
+    analysis_id,
+    event_type,
+    duration_ms,
+    details,
+    occurred_at
+) VALUES (
+    $1,  -- analysis_id
+    $2,  -- event_type
+    $3,  -- duration_ms
+    $4,  -- details
+    $5   -- occurred_at
+);
+
+-- name: ListAnalysisEvents :many
+SELECT * FROM feedback.analysis_events
+WHERE analysis_id = $1
+ORDER BY occurred_at, id;
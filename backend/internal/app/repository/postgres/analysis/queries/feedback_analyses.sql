@@ -0,0 +1,13 @@
+-- name: GetAnalysesByFeedbackID :many
+-- Returns the analyses that included a feedback, newest first.
+SELECT a.* FROM feedback.analyses a
+JOIN feedback.analyzed_feedbacks af ON af.analysis_id = a.id
+WHERE af.feedback_id = $1
+ORDER BY a.created_at DESC;
+
+-- name: GetTopicsByFeedbackID :many
+-- Returns the topics a feedback was assigned to, across all analyses.
+SELECT t.* FROM feedback.analysis_topics t
+JOIN feedback.feedback_topic_assignments fta ON fta.topic_id = t.id
+WHERE fta.feedback_id = $1
+ORDER BY t.created_at DESC;
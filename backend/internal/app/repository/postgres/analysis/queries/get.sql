@@ -3,6 +3,16 @@ SELECT * FROM feedback.analyses
 WHERE id = $1;
 
 -- name: GetLatestAnalysis :one
+-- Returns the latest successful analysis.
 SELECT * FROM feedback.analyses
+WHERE status = 'success'
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: GetLatestRepresentativeAnalysis :one
+-- Returns the latest successful analysis that covered enough feedbacks to be considered representative.
+SELECT * FROM feedback.analyses
+WHERE representative
+  AND status = 'success'
 ORDER BY created_at DESC
 LIMIT 1;
@@ -3,6 +3,19 @@ SELECT * FROM feedback.analysis_topics
 WHERE analysis_id = $1
 ORDER BY created_at DESC;
 
+-- name: GetTopicAnalysesByEnum :many
+-- Returns the topic analyses of a topic across all successful analyses, with the period of each, oldest first.
+SELECT sqlc.embed(t), a.period_start, a.period_end, a.created_at AS analysis_created_at
+FROM feedback.analysis_topics t
+JOIN feedback.analyses a ON a.id = t.analysis_id
+WHERE t.topic_enum = $1
+  AND a.status = 'success'
+ORDER BY a.created_at ASC, t.created_at ASC;
+
+-- name: GetTopicAnalysisByID :one
+SELECT * FROM feedback.analysis_topics
+WHERE id = $1;
+
 -- name: GetFeedbackIDsByTopicID :many
 SELECT feedback_id FROM feedback.feedback_topic_assignments
 WHERE topic_id = $1;
@@ -1,3 +1,8 @@
 -- name: ListAnalyses :many
+-- Returns the analyses newest first, all of them when limit is NULL.
 SELECT * FROM feedback.analyses
-ORDER BY created_at DESC;
+ORDER BY created_at DESC
+LIMIT sqlc.narg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountAnalyses :one
+SELECT COUNT(*) FROM feedback.analyses;
@@ -0,0 +1,31 @@
+-- name: GetAnalysesOverview :one
+-- Aggregates the number of analyses, their tokens and the average batch size of successful analyses.
+SELECT
+    COUNT(*)::int AS total_analyses,
+    COUNT(*) FILTER (WHERE status = 'success')::int AS successful_analyses,
+    COALESCE(SUM(tokens), 0)::bigint AS total_tokens,
+    COALESCE(AVG(feedback_count) FILTER (WHERE status = 'success'), 0)::float8 AS average_feedbacks_per_analysis
+FROM feedback.analyses;
+
+-- name: CountAnalyzedFeedbacks :one
+-- Counts the distinct feedbacks included in at least one successful analysis.
+SELECT COUNT(DISTINCT af.feedback_id)::int AS feedback_count
+FROM feedback.analyzed_feedbacks af
+JOIN feedback.analyses a ON a.id = af.analysis_id
+WHERE a.status = 'success';
+
+-- name: CountTopicAssignments :many
+-- Counts, per LLM topic, the feedbacks assigned to the topic by their latest successful analysis, most common first.
+WITH latest_analyses AS (
+    SELECT DISTINCT ON (af.feedback_id) af.feedback_id, af.analysis_id
+    FROM feedback.analyzed_feedbacks af
+    JOIN feedback.analyses a ON a.id = af.analysis_id
+    WHERE a.status = 'success'
+    ORDER BY af.feedback_id, a.created_at DESC
+)
+SELECT t.topic_enum, COUNT(*)::int AS feedback_count
+FROM latest_analyses la
+JOIN feedback.feedback_topic_assignments fta ON fta.feedback_id = la.feedback_id AND fta.analysis_id = la.analysis_id
+JOIN feedback.analysis_topics t ON t.id = fta.topic_id
+GROUP BY t.topic_enum
+ORDER BY feedback_count DESC, t.topic_enum;
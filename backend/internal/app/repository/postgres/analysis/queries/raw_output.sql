@@ -0,0 +1,16 @@
+-- name: UpsertAnalysisRawOutput :exec
+INSERT INTO feedback.analysis_raw_outputs (
+    analysis_id,
+    output
+) VALUES (
+    $1,  -- analysis_id
+    $2   -- output
+)
+ON CONFLICT (analysis_id) DO UPDATE
+SET
+    output = EXCLUDED.output,
+    created_at = NOW();
+
+-- name: GetAnalysisRawOutput :one
+SELECT * FROM feedback.analysis_raw_outputs
+WHERE analysis_id = $1;
@@ -0,0 +1,32 @@
+-- name: CountTagTopicAssignments :many
+-- Counts, per user tag and LLM topic, the tagged feedbacks assigned to the topic by their latest successful analysis.
+WITH latest_analyses AS (
+    SELECT DISTINCT ON (af.feedback_id) af.feedback_id, af.analysis_id
+    FROM feedback.analyzed_feedbacks af
+    JOIN feedback.analyses a ON a.id = af.analysis_id
+    WHERE a.status = 'success'
+    ORDER BY af.feedback_id, a.created_at DESC
+)
+SELECT tag::text AS tag, t.topic_enum, COUNT(*)::int AS feedback_count
+FROM feedback.feedbacks f
+CROSS JOIN LATERAL unnest(f.tags) AS tag
+JOIN latest_analyses la ON la.feedback_id = f.id
+JOIN feedback.feedback_topic_assignments fta ON fta.feedback_id = f.id AND fta.analysis_id = la.analysis_id
+JOIN feedback.analysis_topics t ON t.id = fta.topic_id
+WHERE f.deleted_at IS NULL
+GROUP BY tag, t.topic_enum
+ORDER BY tag, t.topic_enum;
+
+-- name: CountTaggedAnalyzedFeedbacks :many
+-- Counts, per user tag, the tagged feedbacks included in at least one successful analysis.
+SELECT tag::text AS tag, COUNT(*)::int AS feedback_count
+FROM feedback.feedbacks f
+CROSS JOIN LATERAL unnest(f.tags) AS tag
+WHERE f.deleted_at IS NULL
+  AND EXISTS (
+    SELECT 1 FROM feedback.analyzed_feedbacks af
+    JOIN feedback.analyses a ON a.id = af.analysis_id
+    WHERE af.feedback_id = f.id AND a.status = 'success'
+  )
+GROUP BY tag
+ORDER BY tag;
@@ -0,0 +1,49 @@
+-- name: UpsertAnalysisTokenUsage :exec
+INSERT INTO feedback.analysis_token_usages (
+    analysis_id,
+    estimated_tokens,
+    input_tokens,
+    output_tokens,
+    total_tokens
+) VALUES (
+    $1,  -- analysis_id
+    $2,  -- estimated_tokens
+    $3,  -- input_tokens
+    $4,  -- output_tokens
+    $5   -- total_tokens
+)
+ON CONFLICT (analysis_id) DO UPDATE
+SET
+    estimated_tokens = EXCLUDED.estimated_tokens,
+    input_tokens = EXCLUDED.input_tokens,
+    output_tokens = EXCLUDED.output_tokens,
+    total_tokens = EXCLUDED.total_tokens,
+    created_at = NOW();
+
+-- name: GetTokenAccuracy :one
+-- Summarizes the ratio of estimated to actual total tokens over the analyses with reported usage.
+WITH ratios AS (
+    SELECT estimated_tokens::float8 / total_tokens AS ratio
+    FROM feedback.analysis_token_usages
+    WHERE total_tokens > 0
+)
+SELECT
+    COUNT(*)::int AS sample_count,
+    COALESCE(AVG(ratio), 0)::float8 AS mean_ratio,
+    COALESCE(MIN(ratio), 0)::float8 AS min_ratio,
+    COALESCE(MAX(ratio), 0)::float8 AS max_ratio,
+    COALESCE(percentile_cont(0.1) WITHIN GROUP (ORDER BY ratio), 0)::float8 AS p10_ratio,
+    COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY ratio), 0)::float8 AS median_ratio,
+    COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY ratio), 0)::float8 AS p90_ratio
+FROM ratios;
+
+-- name: CountTokenAccuracyBuckets :many
+-- Counts the analyses with reported usage per bucket of the ratio of estimated to actual total tokens.
+-- Bucket 0 holds ratios below the first boundary, bucket n ratios from the nth boundary up to the next one.
+SELECT
+    width_bucket(estimated_tokens::float8 / total_tokens, sqlc.arg(boundaries)::float8[])::int AS bucket,
+    COUNT(*)::int AS analysis_count
+FROM feedback.analysis_token_usages
+WHERE total_tokens > 0
+GROUP BY bucket
+ORDER BY bucket;
@@ -0,0 +1,21 @@
+-- name: CreateTopicDelta :exec
+INSERT INTO feedback.analysis_topic_deltas (
+    analysis_id,
+    topic_enum,
+    previous_count,
+    current_count
+) VALUES (
+    $1,  -- analysis_id
+    $2,  -- topic_enum
+    $3,  -- previous_count
+    $4   -- current_count
+)
+ON CONFLICT (analysis_id, topic_enum) DO UPDATE
+SET
+    previous_count = EXCLUDED.previous_count,
+    current_count = EXCLUDED.current_count;
+
+-- name: GetTopicDeltasByAnalysisID :many
+SELECT * FROM feedback.analysis_topic_deltas
+WHERE analysis_id = $1
+ORDER BY ABS(current_count - previous_count) DESC, topic_enum::text;
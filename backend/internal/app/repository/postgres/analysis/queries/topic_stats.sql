@@ -0,0 +1,35 @@
+-- name: UpsertTopicStats :exec
+-- Computes the statistics of every topic of an analysis from the assignments of its non-deleted feedbacks.
+INSERT INTO feedback.topic_stats (
+    topic_enum,
+    analysis_id,
+    feedback_count,
+    average_rating,
+    computed_at
+)
+SELECT
+    t.topic_enum,
+    t.analysis_id,
+    COUNT(DISTINCT f.id)::int AS feedback_count,
+    COALESCE(AVG(f.rating), 0)::float8 AS average_rating,
+    NOW()
+FROM feedback.analysis_topics t
+LEFT JOIN feedback.feedback_topic_assignments a ON a.topic_id = t.id
+LEFT JOIN feedback.feedbacks f ON f.id = a.feedback_id AND f.deleted_at IS NULL
+WHERE t.analysis_id = $1
+GROUP BY t.topic_enum, t.analysis_id
+ON CONFLICT (topic_enum) DO UPDATE
+SET
+    analysis_id = EXCLUDED.analysis_id,
+    feedback_count = EXCLUDED.feedback_count,
+    average_rating = EXCLUDED.average_rating,
+    computed_at = EXCLUDED.computed_at;
+
+-- name: DeleteStaleTopicStats :exec
+-- Removes the statistics of topics that were not identified by the given analysis.
+DELETE FROM feedback.topic_stats
+WHERE analysis_id <> $1;
+
+-- name: GetTopicStats :many
+SELECT * FROM feedback.topic_stats
+ORDER BY topic_enum;
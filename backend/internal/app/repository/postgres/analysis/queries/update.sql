@@ -7,5 +7,16 @@ SET
     tokens = $5,
     status = $6,
     failure_reason = $7,
-    completed_at = $8
+    completed_at = $8,
+    no_topics_identified = $9
 WHERE id = $1;
+
+-- name: FailStaleAnalyses :execrows
+-- Marks analyses still processing since before created_before as failed, e.g. after the process was interrupted.
+UPDATE feedback.analyses
+SET
+    status = 'failed',
+    failure_reason = sqlc.arg(failure_reason),
+    completed_at = sqlc.arg(completed_at)
+WHERE status = 'processing'
+  AND created_at < sqlc.arg(created_before);
@@ -0,0 +1,60 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/analysis/sqlc"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) SaveRawOutput(
+	ctx context.Context,
+	analysisID uuid.UUID,
+	output string,
+	opts ...repository.RepoOption[apprepo.Options],
+) error {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	err := queries.UpsertAnalysisRawOutput(
+		ctx, sqlc.UpsertAnalysisRawOutputParams{
+			AnalysisID: analysisID,
+			Output:     output,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save raw output: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) GetRawOutput(
+	ctx context.Context,
+	analysisID uuid.UUID,
+	opts ...repository.RepoOption[apprepo.Options],
+) (*analysis.RawOutput, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	rawOutput, err := queries.GetAnalysisRawOutput(ctx, analysisID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get raw output: %w", err)
+	}
+
+	return &analysis.RawOutput{
+		AnalysisID: rawOutput.AnalysisID,
+		Output:     rawOutput.Output,
+		CreatedAt:  rawOutput.CreatedAt,
+	}, nil
+}
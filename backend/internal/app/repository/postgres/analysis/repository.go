@@ -1,6 +1,9 @@
 package analysis
 
 import (
+	"fmt"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/analysis/sqlc"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/querier"
@@ -9,13 +12,36 @@ import (
 
 type repo struct {
 	defaultQuerier querier.PgxQuerier
+	// copyThreshold is the number of rows above which bulk inserts use COPY.
+	copyThreshold int
 }
 
-// NewAnalysisRepository creates a new analysis repository.
-func NewAnalysisRepository(q querier.PgxQuerier) repository.AnalysisRepository {
-	return &repo{
+// Option configures optional settings of the analysis repository.
+type Option func(*repo)
+
+// WithCopyThreshold sets the number of rows above which topic assignments and analyzed feedbacks
+// are written with COPY. Defaults to config.DefaultBulkInsertCopyThreshold.
+func WithCopyThreshold(n int) Option {
+	return func(r *repo) {
+		if n > 0 {
+			r.copyThreshold = n
+		}
+	}
+}
+
+// NewAnalysisRepository creates a new analysis repository. It fails if q is nil.
+func NewAnalysisRepository(q querier.PgxQuerier, opts ...Option) (repository.AnalysisRepository, error) {
+	if err := querier.Check(q); err != nil {
+		return nil, fmt.Errorf("failed to create analysis repository: %w", err)
+	}
+	r := &repo{
 		defaultQuerier: q,
+		copyThreshold:  config.DefaultBulkInsertCopyThreshold,
+	}
+	for _, opt := range opts {
+		opt(r)
 	}
+	return r, nil
 }
 
 var _ sqlc.DBTX = (*utils.QuerierAdapter)(nil)
@@ -0,0 +1,33 @@
+package analysis
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/querier"
+)
+
+func TestNewAnalysisRepository_NilQuerier(t *testing.T) {
+	tests := []struct {
+		name string
+		q    querier.PgxQuerier
+	}{
+		{name: "nil interface", q: nil},
+		{name: "nil pool wrapper", q: (*querier.PgxPool)(nil)},
+		{name: "wrapper without pool", q: &querier.PgxPool{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				r, err := NewAnalysisRepository(tt.q)
+				if !errors.Is(err, querier.ErrNilQuerier) {
+					t.Fatalf("Expected querier.ErrNilQuerier, got: %v", err)
+				}
+				if r != nil {
+					t.Errorf("Expected no repository, got %T", r)
+				}
+			},
+		)
+	}
+}
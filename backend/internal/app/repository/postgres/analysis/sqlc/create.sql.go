@@ -29,7 +29,11 @@ INSERT INTO feedback.analyses (
     status,
     failure_reason,
     created_at,
-    completed_at
+    completed_at,
+    deduplicated_count,
+    period_semantics,
+    provider,
+    representative
 ) VALUES (
     $1,  -- id
     $2,  -- previous_analysis_id (nullable)
@@ -46,9 +50,13 @@ INSERT INTO feedback.analyses (
     $13, -- status
     $14, -- failure_reason (nullable)
     $15, -- created_at
-    $16  -- completed_at (nullable)
+    $16, -- completed_at (nullable)
+    $17, -- deduplicated_count
+    $18, -- period_semantics
+    $19, -- provider (nullable)
+    $20  -- representative
 )
-RETURNING id, previous_analysis_id, period_start, period_end, feedback_count, new_feedback_count, overall_summary, sentiment, key_insights, model, tokens, analysis_duration_ms, status, failure_reason, created_at, completed_at
+RETURNING id, previous_analysis_id, period_start, period_end, feedback_count, new_feedback_count, overall_summary, sentiment, key_insights, model, tokens, analysis_duration_ms, status, failure_reason, created_at, completed_at, no_topics_identified, deduplicated_count, period_semantics, provider, representative
 `
 
 type CreateAnalysisParams struct {
@@ -58,7 +66,7 @@ type CreateAnalysisParams struct {
 	PeriodEnd          time.Time              `db:"period_end"`
 	FeedbackCount      int32                  `db:"feedback_count"`
 	NewFeedbackCount   *int32                 `db:"new_feedback_count"`
-	OverallSummary     string                 `db:"overall_summary"`
+	OverallSummary     *string                `db:"overall_summary"`
 	Sentiment          FeedbackSentiment      `db:"sentiment"`
 	KeyInsights        []string               `db:"key_insights"`
 	Model              string                 `db:"model"`
@@ -68,6 +76,10 @@ type CreateAnalysisParams struct {
 	FailureReason      *string                `db:"failure_reason"`
 	CreatedAt          time.Time              `db:"created_at"`
 	CompletedAt        *time.Time             `db:"completed_at"`
+	DeduplicatedCount  int32                  `db:"deduplicated_count"`
+	PeriodSemantics    string                 `db:"period_semantics"`
+	Provider           *string                `db:"provider"`
+	Representative     bool                   `db:"representative"`
 }
 
 func (q *Queries) CreateAnalysis(ctx context.Context, arg CreateAnalysisParams) (Analysis, error) {
@@ -88,6 +100,10 @@ func (q *Queries) CreateAnalysis(ctx context.Context, arg CreateAnalysisParams)
 		arg.FailureReason,
 		arg.CreatedAt,
 		arg.CompletedAt,
+		arg.DeduplicatedCount,
+		arg.PeriodSemantics,
+		arg.Provider,
+		arg.Representative,
 	)
 	var i Analysis
 	err := row.Scan(
@@ -107,6 +123,11 @@ func (q *Queries) CreateAnalysis(ctx context.Context, arg CreateAnalysisParams)
 		&i.FailureReason,
 		&i.CreatedAt,
 		&i.CompletedAt,
+		&i.NoTopicsIdentified,
+		&i.DeduplicatedCount,
+		&i.PeriodSemantics,
+		&i.Provider,
+		&i.Representative,
 	)
 	return i, err
 }
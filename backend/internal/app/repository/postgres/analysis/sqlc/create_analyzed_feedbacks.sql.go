@@ -12,30 +12,34 @@ import (
 	"github.com/google/uuid"
 )
 
-const createAnalyzedFeedback = `-- name: CreateAnalyzedFeedback :exec
+const createAnalyzedFeedbacks = `-- name: CreateAnalyzedFeedbacks :exec
 INSERT INTO feedback.analyzed_feedbacks (
     analysis_id,
     feedback_id,
     created_at
-) VALUES (
-    $1,  -- analysis_id
-    $2,  -- feedback_id
-    $3   -- created_at
 )
+SELECT $1::uuid, unnest($2::uuid[]), $3::timestamp
 ON CONFLICT (analysis_id, feedback_id) DO NOTHING
 `
 
-type CreateAnalyzedFeedbackParams struct {
-	AnalysisID uuid.UUID `db:"analysis_id"`
-	FeedbackID uuid.UUID `db:"feedback_id"`
-	CreatedAt  time.Time `db:"created_at"`
+type CreateAnalyzedFeedbacksParams struct {
+	AnalysisID  uuid.UUID   `db:"analysis_id"`
+	FeedbackIds []uuid.UUID `db:"feedback_ids"`
+	CreatedAt   time.Time   `db:"created_at"`
 }
 
-func (q *Queries) CreateAnalyzedFeedback(ctx context.Context, arg CreateAnalyzedFeedbackParams) error {
-	_, err := q.db.Exec(ctx, createAnalyzedFeedback, arg.AnalysisID, arg.FeedbackID, arg.CreatedAt)
+// Inserts the analyzed feedbacks of an analysis with a single statement, one row per element of feedback_ids.
+func (q *Queries) CreateAnalyzedFeedbacks(ctx context.Context, arg CreateAnalyzedFeedbacksParams) error {
+	_, err := q.db.Exec(ctx, createAnalyzedFeedbacks, arg.AnalysisID, arg.FeedbackIds, arg.CreatedAt)
 	return err
 }
 
+type CopyAnalyzedFeedbacksParams struct {
+	AnalysisID uuid.UUID `db:"analysis_id"`
+	FeedbackID uuid.UUID `db:"feedback_id"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
 const getFeedbackIDsByAnalysisID = `-- name: GetFeedbackIDsByAnalysisID :many
 SELECT feedback_id FROM feedback.analyzed_feedbacks
 WHERE analysis_id = $1
@@ -21,7 +21,13 @@ INSERT INTO feedback.analysis_topics (
     feedback_count,
     sentiment,
     created_at,
-    updated_at
+    updated_at,
+    positive_count,
+    mixed_count,
+    negative_count,
+    recommendations,
+    confidence,
+    sentiment_conflict
 ) VALUES (
     $1,  -- id
     $2,  -- analysis_id
@@ -30,20 +36,32 @@ INSERT INTO feedback.analysis_topics (
     $5,  -- feedback_count
     $6,  -- sentiment
     $7,  -- created_at
-    $8   -- updated_at
+    $8,  -- updated_at
+    $9,  -- positive_count
+    $10, -- mixed_count
+    $11, -- negative_count
+    $12, -- recommendations
+    $13, -- confidence
+    $14  -- sentiment_conflict
 )
-RETURNING id, analysis_id, feedback_count, sentiment, created_at, updated_at, topic_enum, summary
+RETURNING id, analysis_id, feedback_count, sentiment, created_at, updated_at, topic_enum, summary, positive_count, mixed_count, negative_count, recommendations, confidence, sentiment_conflict
 `
 
 type CreateTopicAnalysisParams struct {
-	ID            uuid.UUID         `db:"id"`
-	AnalysisID    uuid.UUID         `db:"analysis_id"`
-	TopicEnum     FeedbackTopicEnum `db:"topic_enum"`
-	Summary       string            `db:"summary"`
-	FeedbackCount int32             `db:"feedback_count"`
-	Sentiment     FeedbackSentiment `db:"sentiment"`
-	CreatedAt     time.Time         `db:"created_at"`
-	UpdatedAt     time.Time         `db:"updated_at"`
+	ID                uuid.UUID         `db:"id"`
+	AnalysisID        uuid.UUID         `db:"analysis_id"`
+	TopicEnum         FeedbackTopicEnum `db:"topic_enum"`
+	Summary           string            `db:"summary"`
+	FeedbackCount     int32             `db:"feedback_count"`
+	Sentiment         FeedbackSentiment `db:"sentiment"`
+	CreatedAt         time.Time         `db:"created_at"`
+	UpdatedAt         time.Time         `db:"updated_at"`
+	PositiveCount     *int32            `db:"positive_count"`
+	MixedCount        *int32            `db:"mixed_count"`
+	NegativeCount     *int32            `db:"negative_count"`
+	Recommendations   []string          `db:"recommendations"`
+	Confidence        *float64          `db:"confidence"`
+	SentimentConflict bool              `db:"sentiment_conflict"`
 }
 
 func (q *Queries) CreateTopicAnalysis(ctx context.Context, arg CreateTopicAnalysisParams) (Topic, error) {
@@ -56,6 +74,12 @@ func (q *Queries) CreateTopicAnalysis(ctx context.Context, arg CreateTopicAnalys
 		arg.Sentiment,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.PositiveCount,
+		arg.MixedCount,
+		arg.NegativeCount,
+		arg.Recommendations,
+		arg.Confidence,
+		arg.SentimentConflict,
 	)
 	var i Topic
 	err := row.Scan(
@@ -67,6 +91,12 @@ func (q *Queries) CreateTopicAnalysis(ctx context.Context, arg CreateTopicAnalys
 		&i.UpdatedAt,
 		&i.TopicEnum,
 		&i.Summary,
+		&i.PositiveCount,
+		&i.MixedCount,
+		&i.NegativeCount,
+		&i.Recommendations,
+		&i.Confidence,
+		&i.SentimentConflict,
 	)
 	return i, err
 }
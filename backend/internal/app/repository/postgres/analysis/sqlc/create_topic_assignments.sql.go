@@ -12,38 +12,43 @@ import (
 	"github.com/google/uuid"
 )
 
-const createTopicAssignment = `-- name: CreateTopicAssignment :exec
+const createTopicAssignments = `-- name: CreateTopicAssignments :exec
 INSERT INTO feedback.feedback_topic_assignments (
     id,
     analysis_id,
     feedback_id,
     topic_id,
     created_at
-) VALUES (
-    $1,  -- id
-    $2,  -- analysis_id
-    $3,  -- feedback_id
-    $4,  -- topic_id
-    $5   -- created_at
 )
+SELECT a.id, $1::uuid, a.feedback_id, $2::uuid, $3::timestamp
+FROM unnest($4::uuid[], $5::uuid[]) AS a (id, feedback_id)
 ON CONFLICT (analysis_id, feedback_id, topic_id) DO NOTHING
 `
 
-type CreateTopicAssignmentParams struct {
-	ID         uuid.UUID `db:"id"`
-	AnalysisID uuid.UUID `db:"analysis_id"`
-	FeedbackID uuid.UUID `db:"feedback_id"`
-	TopicID    uuid.UUID `db:"topic_id"`
-	CreatedAt  time.Time `db:"created_at"`
+type CreateTopicAssignmentsParams struct {
+	AnalysisID  uuid.UUID   `db:"analysis_id"`
+	TopicID     uuid.UUID   `db:"topic_id"`
+	CreatedAt   time.Time   `db:"created_at"`
+	Ids         []uuid.UUID `db:"ids"`
+	FeedbackIds []uuid.UUID `db:"feedback_ids"`
 }
 
-func (q *Queries) CreateTopicAssignment(ctx context.Context, arg CreateTopicAssignmentParams) error {
-	_, err := q.db.Exec(ctx, createTopicAssignment,
-		arg.ID,
+// Inserts the assignments of a topic with a single statement, one row per pair of elements of ids and feedback_ids.
+func (q *Queries) CreateTopicAssignments(ctx context.Context, arg CreateTopicAssignmentsParams) error {
+	_, err := q.db.Exec(ctx, createTopicAssignments,
 		arg.AnalysisID,
-		arg.FeedbackID,
 		arg.TopicID,
 		arg.CreatedAt,
+		arg.Ids,
+		arg.FeedbackIds,
 	)
 	return err
 }
+
+type CopyTopicAssignmentsParams struct {
+	ID         uuid.UUID `db:"id"`
+	AnalysisID uuid.UUID `db:"analysis_id"`
+	FeedbackID uuid.UUID `db:"feedback_id"`
+	TopicID    uuid.UUID `db:"topic_id"`
+	CreatedAt  time.Time `db:"created_at"`
+}
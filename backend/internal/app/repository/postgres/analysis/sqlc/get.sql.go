@@ -12,7 +12,7 @@ import (
 )
 
 const getAnalysisByID = `-- name: GetAnalysisByID :one
-SELECT id, previous_analysis_id, period_start, period_end, feedback_count, new_feedback_count, overall_summary, sentiment, key_insights, model, tokens, analysis_duration_ms, status, failure_reason, created_at, completed_at FROM feedback.analyses
+SELECT id, previous_analysis_id, period_start, period_end, feedback_count, new_feedback_count, overall_summary, sentiment, key_insights, model, tokens, analysis_duration_ms, status, failure_reason, created_at, completed_at, no_topics_identified, deduplicated_count, period_semantics, provider, representative FROM feedback.analyses
 WHERE id = $1
 `
 
@@ -36,16 +36,23 @@ func (q *Queries) GetAnalysisByID(ctx context.Context, id uuid.UUID) (Analysis,
 		&i.FailureReason,
 		&i.CreatedAt,
 		&i.CompletedAt,
+		&i.NoTopicsIdentified,
+		&i.DeduplicatedCount,
+		&i.PeriodSemantics,
+		&i.Provider,
+		&i.Representative,
 	)
 	return i, err
 }
 
 const getLatestAnalysis = `-- name: GetLatestAnalysis :one
-SELECT id, previous_analysis_id, period_start, period_end, feedback_count, new_feedback_count, overall_summary, sentiment, key_insights, model, tokens, analysis_duration_ms, status, failure_reason, created_at, completed_at FROM feedback.analyses
+SELECT id, previous_analysis_id, period_start, period_end, feedback_count, new_feedback_count, overall_summary, sentiment, key_insights, model, tokens, analysis_duration_ms, status, failure_reason, created_at, completed_at, no_topics_identified, deduplicated_count, period_semantics, provider, representative FROM feedback.analyses
+WHERE status = 'success'
 ORDER BY created_at DESC
 LIMIT 1
 `
 
+// Returns the latest successful analysis.
 func (q *Queries) GetLatestAnalysis(ctx context.Context) (Analysis, error) {
 	row := q.db.QueryRow(ctx, getLatestAnalysis)
 	var i Analysis
@@ -66,6 +73,49 @@ func (q *Queries) GetLatestAnalysis(ctx context.Context) (Analysis, error) {
 		&i.FailureReason,
 		&i.CreatedAt,
 		&i.CompletedAt,
+		&i.NoTopicsIdentified,
+		&i.DeduplicatedCount,
+		&i.PeriodSemantics,
+		&i.Provider,
+		&i.Representative,
+	)
+	return i, err
+}
+
+const getLatestRepresentativeAnalysis = `-- name: GetLatestRepresentativeAnalysis :one
+SELECT id, previous_analysis_id, period_start, period_end, feedback_count, new_feedback_count, overall_summary, sentiment, key_insights, model, tokens, analysis_duration_ms, status, failure_reason, created_at, completed_at, no_topics_identified, deduplicated_count, period_semantics, provider, representative FROM feedback.analyses
+WHERE representative
+  AND status = 'success'
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+// Returns the latest successful analysis that covered enough feedbacks to be considered representative.
+func (q *Queries) GetLatestRepresentativeAnalysis(ctx context.Context) (Analysis, error) {
+	row := q.db.QueryRow(ctx, getLatestRepresentativeAnalysis)
+	var i Analysis
+	err := row.Scan(
+		&i.ID,
+		&i.PreviousAnalysisID,
+		&i.PeriodStart,
+		&i.PeriodEnd,
+		&i.FeedbackCount,
+		&i.NewFeedbackCount,
+		&i.OverallSummary,
+		&i.Sentiment,
+		&i.KeyInsights,
+		&i.Model,
+		&i.Tokens,
+		&i.AnalysisDurationMs,
+		&i.Status,
+		&i.FailureReason,
+		&i.CreatedAt,
+		&i.CompletedAt,
+		&i.NoTopicsIdentified,
+		&i.DeduplicatedCount,
+		&i.PeriodSemantics,
+		&i.Provider,
+		&i.Representative,
 	)
 	return i, err
 }
@@ -7,6 +7,7 @@ package sqlc
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -36,8 +37,90 @@ func (q *Queries) GetFeedbackIDsByTopicID(ctx context.Context, topicID uuid.UUID
 	return items, nil
 }
 
+const getTopicAnalysesByEnum = `-- name: GetTopicAnalysesByEnum :many
+SELECT t.id, t.analysis_id, t.feedback_count, t.sentiment, t.created_at, t.updated_at, t.topic_enum, t.summary, t.positive_count, t.mixed_count, t.negative_count, t.recommendations, t.confidence, t.sentiment_conflict, a.period_start, a.period_end, a.created_at AS analysis_created_at
+FROM feedback.analysis_topics t
+JOIN feedback.analyses a ON a.id = t.analysis_id
+WHERE t.topic_enum = $1
+  AND a.status = 'success'
+ORDER BY a.created_at ASC, t.created_at ASC
+`
+
+type GetTopicAnalysesByEnumRow struct {
+	Topic             Topic     `db:"topic"`
+	PeriodStart       time.Time `db:"period_start"`
+	PeriodEnd         time.Time `db:"period_end"`
+	AnalysisCreatedAt time.Time `db:"analysis_created_at"`
+}
+
+// Returns the topic analyses of a topic across all successful analyses, with the period of each, oldest first.
+func (q *Queries) GetTopicAnalysesByEnum(ctx context.Context, topicEnum FeedbackTopicEnum) ([]GetTopicAnalysesByEnumRow, error) {
+	rows, err := q.db.Query(ctx, getTopicAnalysesByEnum, topicEnum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTopicAnalysesByEnumRow{}
+	for rows.Next() {
+		var i GetTopicAnalysesByEnumRow
+		if err := rows.Scan(
+			&i.Topic.ID,
+			&i.Topic.AnalysisID,
+			&i.Topic.FeedbackCount,
+			&i.Topic.Sentiment,
+			&i.Topic.CreatedAt,
+			&i.Topic.UpdatedAt,
+			&i.Topic.TopicEnum,
+			&i.Topic.Summary,
+			&i.Topic.PositiveCount,
+			&i.Topic.MixedCount,
+			&i.Topic.NegativeCount,
+			&i.Topic.Recommendations,
+			&i.Topic.Confidence,
+			&i.Topic.SentimentConflict,
+			&i.PeriodStart,
+			&i.PeriodEnd,
+			&i.AnalysisCreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTopicAnalysisByID = `-- name: GetTopicAnalysisByID :one
+SELECT id, analysis_id, feedback_count, sentiment, created_at, updated_at, topic_enum, summary, positive_count, mixed_count, negative_count, recommendations, confidence, sentiment_conflict FROM feedback.analysis_topics
+WHERE id = $1
+`
+
+func (q *Queries) GetTopicAnalysisByID(ctx context.Context, id uuid.UUID) (Topic, error) {
+	row := q.db.QueryRow(ctx, getTopicAnalysisByID, id)
+	var i Topic
+	err := row.Scan(
+		&i.ID,
+		&i.AnalysisID,
+		&i.FeedbackCount,
+		&i.Sentiment,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.TopicEnum,
+		&i.Summary,
+		&i.PositiveCount,
+		&i.MixedCount,
+		&i.NegativeCount,
+		&i.Recommendations,
+		&i.Confidence,
+		&i.SentimentConflict,
+	)
+	return i, err
+}
+
 const getTopicsByAnalysisID = `-- name: GetTopicsByAnalysisID :many
-SELECT id, analysis_id, feedback_count, sentiment, created_at, updated_at, topic_enum, summary FROM feedback.analysis_topics
+SELECT id, analysis_id, feedback_count, sentiment, created_at, updated_at, topic_enum, summary, positive_count, mixed_count, negative_count, recommendations, confidence, sentiment_conflict FROM feedback.analysis_topics
 WHERE analysis_id = $1
 ORDER BY created_at DESC
 `
@@ -60,6 +143,12 @@ func (q *Queries) GetTopicsByAnalysisID(ctx context.Context, analysisID uuid.UUI
 			&i.UpdatedAt,
 			&i.TopicEnum,
 			&i.Summary,
+			&i.PositiveCount,
+			&i.MixedCount,
+			&i.NegativeCount,
+			&i.Recommendations,
+			&i.Confidence,
+			&i.SentimentConflict,
 		); err != nil {
 			return nil, err
 		}
@@ -9,13 +9,31 @@ import (
 	"context"
 )
 
+const countAnalyses = `-- name: CountAnalyses :one
+SELECT COUNT(*) FROM feedback.analyses
+`
+
+func (q *Queries) CountAnalyses(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countAnalyses)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const listAnalyses = `-- name: ListAnalyses :many
-SELECT id, previous_analysis_id, period_start, period_end, feedback_count, new_feedback_count, overall_summary, sentiment, key_insights, model, tokens, analysis_duration_ms, status, failure_reason, created_at, completed_at FROM feedback.analyses
+SELECT id, previous_analysis_id, period_start, period_end, feedback_count, new_feedback_count, overall_summary, sentiment, key_insights, model, tokens, analysis_duration_ms, status, failure_reason, created_at, completed_at, no_topics_identified, deduplicated_count, period_semantics, provider, representative FROM feedback.analyses
 ORDER BY created_at DESC
+LIMIT $1 OFFSET $2
 `
 
-func (q *Queries) ListAnalyses(ctx context.Context) ([]Analysis, error) {
-	rows, err := q.db.Query(ctx, listAnalyses)
+type ListAnalysesParams struct {
+	Limit  *int32 `db:"limit"`
+	Offset int32  `db:"offset"`
+}
+
+// Returns the analyses newest first, all of them when limit is NULL.
+func (q *Queries) ListAnalyses(ctx context.Context, arg ListAnalysesParams) ([]Analysis, error) {
+	rows, err := q.db.Query(ctx, listAnalyses, arg.Limit, arg.Offset)
 	if err != nil {
 		return nil, err
 	}
@@ -40,6 +58,11 @@ func (q *Queries) ListAnalyses(ctx context.Context) ([]Analysis, error) {
 			&i.FailureReason,
 			&i.CreatedAt,
 			&i.CompletedAt,
+			&i.NoTopicsIdentified,
+			&i.DeduplicatedCount,
+			&i.PeriodSemantics,
+			&i.Provider,
+			&i.Representative,
 		); err != nil {
 			return nil, err
 		}
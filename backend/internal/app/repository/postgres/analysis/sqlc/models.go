@@ -240,8 +240,8 @@ type Analysis struct {
 	FeedbackCount int32 `db:"feedback_count"`
 	// Number of new feedbacks since the previous analysis
 	NewFeedbackCount *int32 `db:"new_feedback_count"`
-	// Human-readable summary of all feedback in this analysis
-	OverallSummary string `db:"overall_summary"`
+	// Human-readable summary of all feedback in this analysis, null until the analysis succeeded
+	OverallSummary *string `db:"overall_summary"`
 	// Overall sentiment analysis (positive/mixed/negative)
 	Sentiment FeedbackSentiment `db:"sentiment"`
 	// Array of key insights/takeaways from the analysis
@@ -260,6 +260,94 @@ type Analysis struct {
 	CreatedAt time.Time `db:"created_at"`
 	// Timestamp when the analysis was completed (NULL if not completed)
 	CompletedAt *time.Time `db:"completed_at"`
+	// Whether the model completed successfully but identified no topics
+	NoTopicsIdentified bool `db:"no_topics_identified"`
+	// Number of feedbacks collapsed into a representative with an identical comment before analysis
+	DeduplicatedCount int32 `db:"deduplicated_count"`
+	// Meaning of the period: feedback_span (creation times of the analyzed feedbacks) or analysis_window (fixed window ending when the analysis ran)
+	PeriodSemantics string `db:"period_semantics"`
+	// Provider the model was called through (e.g., openai), null for analyses recorded before providers were tracked
+	Provider *string `db:"provider"`
+	// Whether the analysis covered at least the configured minimum of feedbacks to be considered representative
+	Representative bool `db:"representative"`
+}
+
+// Feedbacks isolated as the cause of invalid model output, excluded from further analyses
+type AnalysisDeadLetter struct {
+	// Reference to the feedback that makes the model return invalid output
+	FeedbackID uuid.UUID `db:"feedback_id"`
+	// Reference to the failed analysis the feedback was isolated from
+	AnalysisID *uuid.UUID `db:"analysis_id"`
+	// Why the feedback was dead-lettered
+	Reason string `db:"reason"`
+	// Timestamp when the feedback was dead-lettered
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Lifecycle events of analyses, written as the analysis progresses
+type AnalysisEvent struct {
+	// Insertion order of the event, breaking ties between events of the same time
+	ID int64 `db:"id"`
+	// Reference to the analysis
+	AnalysisID uuid.UUID `db:"analysis_id"`
+	// Lifecycle step (e.g., created, llm_called, completed)
+	EventType string `db:"event_type"`
+	// Time the step took in milliseconds, for steps that measure one
+	DurationMs *int64 `db:"duration_ms"`
+	// Step specific information, e.g. the topic count or the failure reason
+	Details []byte `db:"details"`
+	// Timestamp when the step happened
+	OccurredAt time.Time `db:"occurred_at"`
+}
+
+// Feedbacks excluded from analysis at ingestion, never sent to the LLM
+type AnalysisExclusion struct {
+	// Reference to the excluded feedback
+	FeedbackID uuid.UUID `db:"feedback_id"`
+	// Why the feedback was excluded
+	Reason string `db:"reason"`
+	// Timestamp when the feedback was excluded
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Raw LLM output of analyses, kept separately since it can be large
+type AnalysisRawOutput struct {
+	// Reference to the analysis
+	AnalysisID uuid.UUID `db:"analysis_id"`
+	// Output text as returned by the model, with PII masked
+	Output string `db:"output"`
+	// Timestamp when the output was stored
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Estimated versus actual token usage of analyses, for estimator calibration
+type AnalysisTokenUsage struct {
+	// Reference to the analysis
+	AnalysisID uuid.UUID `db:"analysis_id"`
+	// Total tokens estimated before the LLM request
+	EstimatedTokens int32 `db:"estimated_tokens"`
+	// Input tokens reported by the provider
+	InputTokens int32 `db:"input_tokens"`
+	// Output tokens reported by the provider
+	OutputTokens int32 `db:"output_tokens"`
+	// Total tokens reported by the provider
+	TotalTokens int32 `db:"total_tokens"`
+	// Timestamp when the usage was recorded
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// Per-topic feedback count changes versus the previous analysis
+type AnalysisTopicDelta struct {
+	// Reference to the analysis
+	AnalysisID uuid.UUID `db:"analysis_id"`
+	// Predefined topic enum value
+	TopicEnum FeedbackTopicEnum `db:"topic_enum"`
+	// Number of feedbacks of the topic in the previous analysis (0 if not identified)
+	PreviousCount int32 `db:"previous_count"`
+	// Number of feedbacks of the topic in this analysis (0 if not identified anymore)
+	CurrentCount int32 `db:"current_count"`
+	// Timestamp when the delta was stored
+	CreatedAt time.Time `db:"created_at"`
 }
 
 // Maps feedbacks to analyses (many-to-many relationship)
@@ -272,13 +360,57 @@ type AnalyzedFeedback struct {
 	CreatedAt time.Time `db:"created_at"`
 }
 
+// Events that permanently failed to be delivered to a sink
+type FeedbackEventDeadLetter struct {
+	// Identifier of the outbox entry that failed
+	ID uuid.UUID `db:"id"`
+	// Identifier of the event
+	EventID uuid.UUID `db:"event_id"`
+	// Type of the event (e.g., feedback.created)
+	EventType string `db:"event_type"`
+	// Name of the sink the event could not be delivered to
+	Sink string `db:"sink"`
+	// JSON encoded event
+	Payload []byte `db:"payload"`
+	// Number of failed delivery attempts
+	Attempts int32 `db:"attempts"`
+	// Error of the last delivery attempt
+	LastError string `db:"last_error"`
+	// Timestamp when the event was stored in the outbox
+	CreatedAt time.Time `db:"created_at"`
+	// Timestamp when the event was moved to the dead-letter table
+	FailedAt time.Time `db:"failed_at"`
+}
+
+// Events waiting to be delivered to an external sink, one row per event and sink
+type FeedbackEventOutbox struct {
+	// Unique identifier for the outbox entry
+	ID uuid.UUID `db:"id"`
+	// Identifier of the event, sent to the sink
+	EventID uuid.UUID `db:"event_id"`
+	// Type of the event (e.g., feedback.created)
+	EventType string `db:"event_type"`
+	// Name of the sink the event is delivered to
+	Sink string `db:"sink"`
+	// JSON encoded event as delivered to the sink
+	Payload []byte `db:"payload"`
+	// Number of failed delivery attempts so far
+	Attempts int32 `db:"attempts"`
+	// Earliest time of the next delivery attempt
+	NextAttemptAt time.Time `db:"next_attempt_at"`
+	// Error of the last failed delivery attempt
+	LastError *string `db:"last_error"`
+	// Timestamp when the event was stored in the outbox
+	CreatedAt time.Time `db:"created_at"`
+}
+
 // Stores user feedback submissions with ratings and comments
 type FeedbackFeedback struct {
 	// Unique identifier for the feedback submission
 	ID uuid.UUID `db:"id"`
-	// Rating value from 1 to 5 stars
+	// Rating value within the configured rating scale (1 to 5 stars by default)
 	Rating int32 `db:"rating"`
-	// Free-text feedback comment (1-1000 characters)
+	// Free-text feedback comment (up to 1000 characters, empty for rating-only feedback)
 	Comment string `db:"comment"`
 	// Timestamp when the feedback was submitted
 	CreatedAt time.Time `db:"created_at"`
@@ -288,6 +420,18 @@ type FeedbackFeedback struct {
 	DeletedAt *time.Time `db:"deleted_at"`
 	// Reference to the user who submitted the feedback
 	UserID uuid.UUID `db:"user_id"`
+	// Channel the feedback was submitted through: web, mobile, api, or email
+	Source string `db:"source"`
+	// Lowercase labels attached by the submitter (e.g., ["bug", "feature-request"])
+	Tags []string `db:"tags"`
+	// Technical context of the reporter (e.g., {"app_version": "2.4.1", "platform": "ios", "os": "iOS 17.2"})
+	Metadata []byte `db:"metadata"`
+	// Comment translated into the analysis language (NULL if it was not translated)
+	TranslatedComment *string `db:"translated_comment"`
+	// Detected ISO 639-1 language of the comment (NULL if translation is disabled)
+	CommentLanguage *string `db:"comment_language"`
+	// Comment as submitted, before markup was stripped (NULL if it had no markup or was not preserved)
+	OriginalComment *string `db:"original_comment"`
 }
 
 // Maps feedbacks to topics (many-to-many relationship)
@@ -339,4 +483,30 @@ type Topic struct {
 	TopicEnum FeedbackTopicEnum `db:"topic_enum"`
 	// Summary of the analysis for this topic
 	Summary string `db:"summary"`
+	// Number of positive feedbacks of this topic (null if not counted)
+	PositiveCount *int32 `db:"positive_count"`
+	// Number of mixed feedbacks of this topic (null if not counted)
+	MixedCount *int32 `db:"mixed_count"`
+	// Number of negative feedbacks of this topic (null if not counted)
+	NegativeCount *int32 `db:"negative_count"`
+	// Array of recommended actions for this topic
+	Recommendations []string `db:"recommendations"`
+	// Confidence of the model in this topic assignment between 0 and 1 (null if not requested)
+	Confidence *float64 `db:"confidence"`
+	// True when the model sentiment of a single-feedback topic contradicted the feedback rating (the sentiment holds the rating sentiment if overridden)
+	SentimentConflict bool `db:"sentiment_conflict"`
+}
+
+// Materialized per-topic statistics of the latest analysis
+type TopicStat struct {
+	// Topic the statistics are computed for
+	TopicEnum FeedbackTopicEnum `db:"topic_enum"`
+	// Reference to the analysis the statistics are computed from
+	AnalysisID uuid.UUID `db:"analysis_id"`
+	// Number of non-deleted feedbacks assigned to the topic
+	FeedbackCount int32 `db:"feedback_count"`
+	// Average rating of the non-deleted feedbacks assigned to the topic (0 if none)
+	AverageRating float64 `db:"average_rating"`
+	// When the statistics were last computed
+	ComputedAt time.Time `db:"computed_at"`
 }
@@ -11,17 +11,61 @@ import (
 )
 
 type Querier interface {
+	CountAnalyses(ctx context.Context) (int64, error)
+	// Counts the distinct feedbacks included in at least one successful analysis.
+	CountAnalyzedFeedbacks(ctx context.Context) (int32, error)
+	// Counts, per user tag and LLM topic, the tagged feedbacks assigned to the topic by their latest successful analysis.
+	CountTagTopicAssignments(ctx context.Context) ([]CountTagTopicAssignmentsRow, error)
+	// Counts, per user tag, the tagged feedbacks included in at least one successful analysis.
+	CountTaggedAnalyzedFeedbacks(ctx context.Context) ([]CountTaggedAnalyzedFeedbacksRow, error)
+	// Counts, per LLM topic, the feedbacks assigned to the topic by their latest successful analysis, most common first.
+	CountTopicAssignments(ctx context.Context) ([]CountTopicAssignmentsRow, error)
+	// Counts the analyses with reported usage per bucket of the ratio of estimated to actual total tokens.
+	// Bucket 0 holds ratios below the first boundary, bucket n ratios from the nth boundary up to the next one.
+	CountTokenAccuracyBuckets(ctx context.Context, boundaries []float64) ([]CountTokenAccuracyBucketsRow, error)
 	CreateAnalysis(ctx context.Context, arg CreateAnalysisParams) (Analysis, error)
-	CreateAnalyzedFeedback(ctx context.Context, arg CreateAnalyzedFeedbackParams) error
+	CreateAnalysisEvent(ctx context.Context, arg CreateAnalysisEventParams) error
+	// Inserts the analyzed feedbacks of an analysis with a single statement, one row per element of feedback_ids.
+	CreateAnalyzedFeedbacks(ctx context.Context, arg CreateAnalyzedFeedbacksParams) error
 	CreateTopicAnalysis(ctx context.Context, arg CreateTopicAnalysisParams) (Topic, error)
-	CreateTopicAssignment(ctx context.Context, arg CreateTopicAssignmentParams) error
+	// Inserts the assignments of a topic with a single statement, one row per pair of elements of ids and feedback_ids.
+	CreateTopicAssignments(ctx context.Context, arg CreateTopicAssignmentsParams) error
+	CreateTopicDelta(ctx context.Context, arg CreateTopicDeltaParams) error
+	// Removes the statistics of topics that were not identified by the given analysis.
+	DeleteStaleTopicStats(ctx context.Context, analysisID uuid.UUID) error
+	// Marks analyses still processing since before created_before as failed, e.g. after the process was interrupted.
+	FailStaleAnalyses(ctx context.Context, arg FailStaleAnalysesParams) (int64, error)
+	// Aggregates the number of analyses, their tokens and the average batch size of successful analyses.
+	// Returns the analyses that included a feedback, newest first.
+	GetAnalysesByFeedbackID(ctx context.Context, feedbackID uuid.UUID) ([]Analysis, error)
+	GetAnalysesOverview(ctx context.Context) (GetAnalysesOverviewRow, error)
 	GetAnalysisByID(ctx context.Context, id uuid.UUID) (Analysis, error)
+	GetAnalysisRawOutput(ctx context.Context, analysisID uuid.UUID) (AnalysisRawOutput, error)
 	GetFeedbackIDsByAnalysisID(ctx context.Context, analysisID uuid.UUID) ([]uuid.UUID, error)
 	GetFeedbackIDsByTopicID(ctx context.Context, topicID uuid.UUID) ([]uuid.UUID, error)
+	// Returns the latest successful analysis.
 	GetLatestAnalysis(ctx context.Context) (Analysis, error)
+	// Returns the latest successful analysis that covered enough feedbacks to be considered representative.
+	GetLatestRepresentativeAnalysis(ctx context.Context) (Analysis, error)
+	// Summarizes the ratio of estimated to actual total tokens over the analyses with reported usage.
+	GetTokenAccuracy(ctx context.Context) (GetTokenAccuracyRow, error)
+	// Returns the topic analyses of a topic across all successful analyses, with the period of each, oldest first.
+	GetTopicAnalysesByEnum(ctx context.Context, topicEnum FeedbackTopicEnum) ([]GetTopicAnalysesByEnumRow, error)
+	GetTopicAnalysisByID(ctx context.Context, id uuid.UUID) (Topic, error)
+	GetTopicDeltasByAnalysisID(ctx context.Context, analysisID uuid.UUID) ([]AnalysisTopicDelta, error)
+	GetTopicStats(ctx context.Context) ([]TopicStat, error)
 	GetTopicsByAnalysisID(ctx context.Context, analysisID uuid.UUID) ([]Topic, error)
-	ListAnalyses(ctx context.Context) ([]Analysis, error)
+	// Returns the topics a feedback was assigned to, across all analyses.
+	GetTopicsByFeedbackID(ctx context.Context, feedbackID uuid.UUID) ([]Topic, error)
+	// Returns the analyses newest first, all of them when limit is NULL.
+	ListAnalyses(ctx context.Context, arg ListAnalysesParams) ([]Analysis, error)
+	ListAnalysisEvents(ctx context.Context, analysisID uuid.UUID) ([]AnalysisEvent, error)
 	UpdateAnalysis(ctx context.Context, arg UpdateAnalysisParams) error
+	UpsertAnalysisDeadLetter(ctx context.Context, arg UpsertAnalysisDeadLetterParams) error
+	UpsertAnalysisRawOutput(ctx context.Context, arg UpsertAnalysisRawOutputParams) error
+	UpsertAnalysisTokenUsage(ctx context.Context, arg UpsertAnalysisTokenUsageParams) error
+	// Computes the statistics of every topic of an analysis from the assignments of its non-deleted feedbacks.
+	UpsertTopicStats(ctx context.Context, analysisID uuid.UUID) error
 }
 
 var _ Querier = (*Queries)(nil)
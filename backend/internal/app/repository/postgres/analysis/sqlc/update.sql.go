@@ -12,6 +12,31 @@ import (
 	"github.com/google/uuid"
 )
 
+const failStaleAnalyses = `-- name: FailStaleAnalyses :execrows
+UPDATE feedback.analyses
+SET
+    status = 'failed',
+    failure_reason = $1,
+    completed_at = $2
+WHERE status = 'processing'
+  AND created_at < $3
+`
+
+type FailStaleAnalysesParams struct {
+	FailureReason *string    `db:"failure_reason"`
+	CompletedAt   *time.Time `db:"completed_at"`
+	CreatedBefore time.Time  `db:"created_before"`
+}
+
+// Marks analyses still processing since before created_before as failed, e.g. after the process was interrupted.
+func (q *Queries) FailStaleAnalyses(ctx context.Context, arg FailStaleAnalysesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, failStaleAnalyses, arg.FailureReason, arg.CompletedAt, arg.CreatedBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateAnalysis = `-- name: UpdateAnalysis :exec
 UPDATE feedback.analyses
 SET
@@ -21,19 +46,21 @@ SET
     tokens = $5,
     status = $6,
     failure_reason = $7,
-    completed_at = $8
+    completed_at = $8,
+    no_topics_identified = $9
 WHERE id = $1
 `
 
 type UpdateAnalysisParams struct {
-	ID             uuid.UUID              `db:"id"`
-	OverallSummary string                 `db:"overall_summary"`
-	Sentiment      FeedbackSentiment      `db:"sentiment"`
-	KeyInsights    []string               `db:"key_insights"`
-	Tokens         int32                  `db:"tokens"`
-	Status         FeedbackAnalysisStatus `db:"status"`
-	FailureReason  *string                `db:"failure_reason"`
-	CompletedAt    *time.Time             `db:"completed_at"`
+	ID                 uuid.UUID              `db:"id"`
+	OverallSummary     *string                `db:"overall_summary"`
+	Sentiment          FeedbackSentiment      `db:"sentiment"`
+	KeyInsights        []string               `db:"key_insights"`
+	Tokens             int32                  `db:"tokens"`
+	Status             FeedbackAnalysisStatus `db:"status"`
+	FailureReason      *string                `db:"failure_reason"`
+	CompletedAt        *time.Time             `db:"completed_at"`
+	NoTopicsIdentified bool                   `db:"no_topics_identified"`
 }
 
 func (q *Queries) UpdateAnalysis(ctx context.Context, arg UpdateAnalysisParams) error {
@@ -46,6 +73,7 @@ func (q *Queries) UpdateAnalysis(ctx context.Context, arg UpdateAnalysisParams)
 		arg.Status,
 		arg.FailureReason,
 		arg.CompletedAt,
+		arg.NoTopicsIdentified,
 	)
 	return err
 }
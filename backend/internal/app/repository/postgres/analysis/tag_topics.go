@@ -0,0 +1,47 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) CountTagTopics(
+	ctx context.Context,
+	opts ...repository.RepoOption[apprepo.Options],
+) (*apprepo.TagTopicCounts, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	tagRows, err := queries.CountTaggedAnalyzedFeedbacks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tagged analyzed feedbacks: %w", err)
+	}
+
+	assignmentRows, err := queries.CountTagTopicAssignments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tag topic assignments: %w", err)
+	}
+
+	counts := &apprepo.TagTopicCounts{
+		TaggedFeedbacks: make(map[feedback.Tag]int, len(tagRows)),
+		Assignments:     make(map[feedback.Tag]map[analysis.Topic]int, len(tagRows)),
+	}
+	for _, row := range tagRows {
+		counts.TaggedFeedbacks[feedback.Tag(row.Tag)] = int(row.FeedbackCount)
+	}
+	for _, row := range assignmentRows {
+		tag := feedback.Tag(row.Tag)
+		if counts.Assignments[tag] == nil {
+			counts.Assignments[tag] = make(map[analysis.Topic]int)
+		}
+		counts.Assignments[tag][analysis.Topic(row.TopicEnum)] = int(row.FeedbackCount)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/analysis/sqlc"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) SaveTokenUsage(
+	ctx context.Context,
+	usage *analysis.TokenUsage,
+	opts ...repository.RepoOption[apprepo.Options],
+) error {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	err := queries.UpsertAnalysisTokenUsage(
+		ctx, sqlc.UpsertAnalysisTokenUsageParams{
+			AnalysisID:      usage.AnalysisID,
+			EstimatedTokens: int32(usage.EstimatedTokens),
+			InputTokens:     int32(usage.InputTokens),
+			OutputTokens:    int32(usage.OutputTokens),
+			TotalTokens:     int32(usage.TotalTokens),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save token usage: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) AggregateTokenAccuracy(
+	ctx context.Context,
+	boundaries []float64,
+	opts ...repository.RepoOption[apprepo.Options],
+) (*apprepo.TokenAccuracy, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	summary, err := queries.GetTokenAccuracy(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate token accuracy: %w", err)
+	}
+
+	bucketRows, err := queries.CountTokenAccuracyBuckets(ctx, boundaries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count token accuracy buckets: %w", err)
+	}
+
+	accuracy := &apprepo.TokenAccuracy{
+		SampleCount:  int(summary.SampleCount),
+		MeanRatio:    summary.MeanRatio,
+		MinRatio:     summary.MinRatio,
+		MaxRatio:     summary.MaxRatio,
+		P10Ratio:     summary.P10Ratio,
+		MedianRatio:  summary.MedianRatio,
+		P90Ratio:     summary.P90Ratio,
+		BucketCounts: make([]int, len(boundaries)+1),
+	}
+	for _, row := range bucketRows {
+		if row.Bucket >= 0 && int(row.Bucket) < len(accuracy.BucketCounts) {
+			accuracy.BucketCounts[row.Bucket] = int(row.AnalysisCount)
+		}
+	}
+
+	return accuracy, nil
+}
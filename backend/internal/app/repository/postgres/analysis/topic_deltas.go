@@ -0,0 +1,64 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/analysis/sqlc"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) CreateTopicDeltas(
+	ctx context.Context,
+	analysisID uuid.UUID,
+	deltas []analysis.TopicDelta,
+	opts ...repository.RepoOption[apprepo.Options],
+) error {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	for _, delta := range deltas {
+		err := queries.CreateTopicDelta(
+			ctx, sqlc.CreateTopicDeltaParams{
+				AnalysisID:    analysisID,
+				TopicEnum:     sqlc.FeedbackTopicEnum(delta.Topic),
+				PreviousCount: int32(delta.PreviousCount),
+				CurrentCount:  int32(delta.CurrentCount),
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create topic delta for topic %s: %w", string(delta.Topic), err)
+		}
+	}
+
+	return nil
+}
+
+func (r *repo) GetTopicDeltasByAnalysisID(
+	ctx context.Context,
+	analysisID uuid.UUID,
+	opts ...repository.RepoOption[apprepo.Options],
+) ([]analysis.TopicDelta, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	sqlcDeltas, err := queries.GetTopicDeltasByAnalysisID(ctx, analysisID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get topic deltas by analysis ID: %w", err)
+	}
+
+	deltas := make([]analysis.TopicDelta, len(sqlcDeltas))
+	for i, sqlcDelta := range sqlcDeltas {
+		deltas[i] = analysis.TopicDelta{
+			Topic:         analysis.Topic(sqlcDelta.TopicEnum),
+			PreviousCount: int(sqlcDelta.PreviousCount),
+			CurrentCount:  int(sqlcDelta.CurrentCount),
+		}
+	}
+
+	return deltas, nil
+}
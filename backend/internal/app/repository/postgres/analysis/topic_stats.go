@@ -0,0 +1,57 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) RefreshTopicStats(
+	ctx context.Context,
+	analysisID uuid.UUID,
+	opts ...repository.RepoOption[apprepo.Options],
+) error {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	// Upserting first keeps the statistics of topics present in both analyses readable in between
+	if err := queries.UpsertTopicStats(ctx, analysisID); err != nil {
+		return fmt.Errorf("failed to upsert topic stats: %w", err)
+	}
+	if err := queries.DeleteStaleTopicStats(ctx, analysisID); err != nil {
+		return fmt.Errorf("failed to delete stale topic stats: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) GetTopicStats(
+	ctx context.Context,
+	opts ...repository.RepoOption[apprepo.Options],
+) ([]analysis.TopicStats, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	sqlcStats, err := queries.GetTopicStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get topic stats: %w", err)
+	}
+
+	stats := make([]analysis.TopicStats, len(sqlcStats))
+	for i, sqlcStat := range sqlcStats {
+		stats[i] = analysis.TopicStats{
+			Topic:         analysis.Topic(sqlcStat.TopicEnum),
+			AnalysisID:    sqlcStat.AnalysisID,
+			FeedbackCount: int(sqlcStat.FeedbackCount),
+			AverageRating: sqlcStat.AverageRating,
+			ComputedAt:    sqlcStat.ComputedAt,
+		}
+	}
+
+	return stats, nil
+}
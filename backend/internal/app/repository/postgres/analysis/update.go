@@ -29,20 +29,22 @@ func (r *repo) Update(
 	}
 
 	// If Results are present, update all LLM fields. Otherwise, keep current values.
-	var overallSummary string
+	var overallSummary *string
 	var sentiment sqlc.FeedbackSentiment
 	var keyInsights []string
 	var tokens int32
+	noTopicsIdentified := currentAnalysis.NoTopicsIdentified
 
 	if updates.Results.IsSome() {
 		// Success case: update all LLM fields from results
 		results := updates.Results.Unwrap()
-		overallSummary = results.OverallSummary
+		overallSummary = &results.OverallSummary
 		sentiment = sqlc.FeedbackSentiment(results.Sentiment)
 		keyInsights = results.KeyInsights
 		tokens = int32(results.Tokens)
+		noTopicsIdentified = results.NoTopicsIdentified
 	} else {
-		// Failure case: keep current LLM fields (they were set as placeholders during creation)
+		// Failure case: keep current LLM fields (placeholders set during creation, no summary)
 		overallSummary = currentAnalysis.OverallSummary
 		sentiment = currentAnalysis.Sentiment
 		keyInsights = currentAnalysis.KeyInsights
@@ -64,14 +66,15 @@ func (r *repo) Update(
 
 	err = queries.UpdateAnalysis(
 		ctx, sqlc.UpdateAnalysisParams{
-			ID:             id,
-			OverallSummary: overallSummary,
-			Sentiment:      sentiment,
-			KeyInsights:    keyInsights,
-			Tokens:         tokens,
-			Status:         sqlc.FeedbackAnalysisStatus(updates.Status),
-			FailureReason:  failureReason,
-			CompletedAt:    completedAt,
+			ID:                 id,
+			OverallSummary:     overallSummary,
+			Sentiment:          sentiment,
+			KeyInsights:        keyInsights,
+			Tokens:             tokens,
+			Status:             sqlc.FeedbackAnalysisStatus(updates.Status),
+			FailureReason:      failureReason,
+			CompletedAt:        completedAt,
+			NoTopicsIdentified: noTopicsIdentified,
 		},
 	)
 	if err != nil {
@@ -80,3 +83,27 @@ func (r *repo) Update(
 
 	return nil
 }
+
+func (r *repo) FailStaleProcessing(
+	ctx context.Context,
+	createdBefore time.Time,
+	reason string,
+	completedAt time.Time,
+	opts ...repository.RepoOption[apprepo.Options],
+) (int, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	affected, err := queries.FailStaleAnalyses(
+		ctx, sqlc.FailStaleAnalysesParams{
+			FailureReason: &reason,
+			CompletedAt:   &completedAt,
+			CreatedBefore: createdBefore,
+		},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail stale analyses: %w", err)
+	}
+
+	return int(affected), nil
+}
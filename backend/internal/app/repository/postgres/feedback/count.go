@@ -0,0 +1,85 @@
+package feedback
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/feedback/sqlc"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) Count(
+	ctx context.Context,
+	opts ...repository.RepoOption[apprepo.Options],
+) (int, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	wrapper := utils.BuildOpts(opts)
+	options := wrapper.Ext
+
+	// Limit and offset do not apply to counting, only the filters do
+	var source, tag, platform, appVersion *string
+	var includeDeleted bool
+	if options != nil {
+		includeDeleted = options.IncludeDeleted
+		if options.Source != "" {
+			s := options.Source
+			source = &s
+		}
+		if options.Tag != "" {
+			t := options.Tag
+			tag = &t
+		}
+		if options.Platform != "" {
+			p := options.Platform
+			platform = &p
+		}
+		if options.AppVersion != "" {
+			v := options.AppVersion
+			appVersion = &v
+		}
+	}
+
+	count, err := queries.CountFeedbacks(
+		ctx, sqlc.CountFeedbacksParams{
+			IncludeDeleted: includeDeleted,
+			Source:         source,
+			Tag:            tag,
+			Platform:       platform,
+			AppVersion:     appVersion,
+		},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count feedbacks: %w", err)
+	}
+
+	return int(count), nil
+}
+
+func (r *repo) CountByUserSince(
+	ctx context.Context,
+	userID uuid.UUID,
+	since time.Time,
+	opts ...repository.RepoOption[apprepo.Options],
+) (int, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	count, err := queries.CountFeedbacksByUserSince(
+		ctx, sqlc.CountFeedbacksByUserSinceParams{
+			UserID:    userID,
+			CreatedAt: since,
+		},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count feedbacks by user: %w", err)
+	}
+
+	return int(count), nil
+}
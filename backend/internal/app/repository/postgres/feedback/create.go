@@ -26,15 +26,39 @@ func (r *repo) Create(
 		deletedAt = &dt
 	}
 
+	metadata, err := marshalMetadata(fb.Metadata())
+	if err != nil {
+		return fmt.Errorf("failed to marshal feedback metadata: %w", err)
+	}
+
+	var translatedComment, commentLanguage, originalComment *string
+	if fb.TranslatedComment().IsSome() {
+		translated := fb.TranslatedComment().Unwrap()
+		translatedComment = &translated
+	}
+	if fb.OriginalComment().IsSome() {
+		original := fb.OriginalComment().Unwrap()
+		originalComment = &original
+	}
+	if language := fb.CommentLanguage(); language != "" {
+		commentLanguage = &language
+	}
+
 	if _, err := queries.CreateFeedback(
 		ctx, sqlc.CreateFeedbackParams{
-			ID:        fb.ID(),
-			UserID:    fb.UserID(),
-			Rating:    int32(fb.Rating().Value()),
-			Comment:   fb.Comment().Value(),
-			CreatedAt: fb.CreatedAt(),
-			UpdatedAt: fb.UpdatedAt(),
-			DeletedAt: deletedAt,
+			ID:                fb.ID(),
+			UserID:            fb.UserID(),
+			Rating:            int32(fb.Rating().Value()),
+			Comment:           fb.Comment().Value(),
+			CreatedAt:         fb.CreatedAt(),
+			UpdatedAt:         fb.UpdatedAt(),
+			DeletedAt:         deletedAt,
+			Source:            fb.Source().String(),
+			Tags:              fb.TagStrings(),
+			Metadata:          metadata,
+			TranslatedComment: translatedComment,
+			CommentLanguage:   commentLanguage,
+			OriginalComment:   originalComment,
 		},
 	); err != nil {
 		return fmt.Errorf("failed to create feedback: %w", err)
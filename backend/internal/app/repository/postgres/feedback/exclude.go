@@ -0,0 +1,33 @@
+package feedback
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/feedback/sqlc"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) ExcludeFromAnalysis(
+	ctx context.Context,
+	feedbackID uuid.UUID,
+	reason string,
+	opts ...repository.RepoOption[apprepo.Options],
+) error {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	if err := queries.ExcludeFeedbackFromAnalysis(
+		ctx, sqlc.ExcludeFeedbackFromAnalysisParams{
+			FeedbackID: feedbackID,
+			Reason:     reason,
+		},
+	); err != nil {
+		return fmt.Errorf("failed to exclude feedback from analysis: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package feedback
+
+import (
+	"context"
+	"fmt"
+
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/feedback/sqlc"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+// exportPageSize is the number of feedbacks read per query while exporting.
+const exportPageSize = 500
+
+func (r *repo) Export(
+	ctx context.Context,
+	fn func(*feedback.Feedback) error,
+	opts ...repository.RepoOption[apprepo.Options],
+) error {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	wrapper := utils.BuildOpts(opts)
+	params := exportParams(wrapper.Ext)
+	params.Limit = exportPageSize
+
+	for {
+		sqlcFeedbacks, err := queries.ExportFeedbacks(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to export feedbacks: %w", err)
+		}
+
+		for _, sqlcFeedback := range sqlcFeedbacks {
+			if err := fn(mapSQLCFeedbackToDomain(sqlcFeedback)); err != nil {
+				return err
+			}
+		}
+
+		if len(sqlcFeedbacks) < exportPageSize {
+			return nil
+		}
+
+		// Continue after the last feedback of the page
+		last := sqlcFeedbacks[len(sqlcFeedbacks)-1]
+		afterCreatedAt := last.CreatedAt
+		afterID := last.ID
+		params.AfterCreatedAt = &afterCreatedAt
+		params.AfterID = &afterID
+	}
+}
+
+// exportParams converts the filters of the options into export query parameters.
+func exportParams(options *apprepo.Options) sqlc.ExportFeedbacksParams {
+	var params sqlc.ExportFeedbacksParams
+	if options == nil {
+		return params
+	}
+
+	if options.Source != "" {
+		s := options.Source
+		params.Source = &s
+	}
+	if options.Tag != "" {
+		t := options.Tag
+		params.Tag = &t
+	}
+	if options.Platform != "" {
+		p := options.Platform
+		params.Platform = &p
+	}
+	if options.AppVersion != "" {
+		v := options.AppVersion
+		params.AppVersion = &v
+	}
+	if !options.CreatedFrom.IsZero() {
+		from := options.CreatedFrom
+		params.CreatedFrom = &from
+	}
+	if !options.CreatedTo.IsZero() {
+		to := options.CreatedTo
+		params.CreatedTo = &to
+	}
+	if options.MinRating.IsSome() {
+		minRating := int32(options.MinRating.Unwrap())
+		params.MinRating = &minRating
+	}
+	if options.MaxRating.IsSome() {
+		maxRating := int32(options.MaxRating.Unwrap())
+		params.MaxRating = &maxRating
+	}
+
+	return params
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/feedback/sqlc"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
@@ -19,7 +20,15 @@ func (r *repo) Get(
 	q := utils.GetQuerier(opts, r.defaultQuerier)
 	queries := newSQLCQueries(q)
 
-	sqlcFeedback, err := queries.GetFeedback(ctx, feedbackID)
+	wrapper := utils.BuildOpts(opts)
+	includeDeleted := wrapper.Ext != nil && wrapper.Ext.IncludeDeleted
+
+	sqlcFeedback, err := queries.GetFeedback(
+		ctx, sqlc.GetFeedbackParams{
+			ID:             feedbackID,
+			IncludeDeleted: includeDeleted,
+		},
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get feedback: %w", err)
 	}
@@ -0,0 +1,47 @@
+package feedback
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/feedback/sqlc"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) GetByIDs(
+	ctx context.Context,
+	feedbackIDs []uuid.UUID,
+	opts ...repository.RepoOption[apprepo.Options],
+) ([]*feedback.Feedback, error) {
+	if len(feedbackIDs) == 0 {
+		return []*feedback.Feedback{}, nil
+	}
+
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	wrapper := utils.BuildOpts(opts)
+	includeDeleted := wrapper.Ext != nil && wrapper.Ext.IncludeDeleted
+
+	sqlcFeedbacks, err := queries.GetFeedbacksByIDs(
+		ctx, sqlc.GetFeedbacksByIDsParams{
+			Ids:            feedbackIDs,
+			IncludeDeleted: includeDeleted,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feedbacks by ids: %w", err)
+	}
+
+	// Map to domain
+	feedbacks := make([]*feedback.Feedback, len(sqlcFeedbacks))
+	for i, sqlcFeedback := range sqlcFeedbacks {
+		feedbacks[i] = mapSQLCFeedbackToDomain(sqlcFeedback)
+	}
+
+	return feedbacks, nil
+}
@@ -0,0 +1,33 @@
+package feedback
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) LatestByUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	opts ...repository.RepoOption[apprepo.Options],
+) (*feedback.Feedback, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	sqlcFeedback, err := queries.GetLatestFeedbackByUser(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // User has not submitted any feedback yet
+		}
+		return nil, fmt.Errorf("failed to get latest feedback by user: %w", err)
+	}
+
+	return mapSQLCFeedbackToDomain(sqlcFeedback), nil
+}
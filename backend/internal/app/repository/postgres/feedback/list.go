@@ -23,7 +23,13 @@ func (r *repo) List(
 
 	var limit *int32
 	var offset *int32
+	var source *string
+	var tag *string
+	var platform *string
+	var appVersion *string
+	var includeDeleted bool
 	if options != nil {
+		includeDeleted = options.IncludeDeleted
 		if options.Limit > 0 {
 			l := int32(options.Limit)
 			limit = &l
@@ -32,6 +38,22 @@ func (r *repo) List(
 			o := int32(options.Offset)
 			offset = &o
 		}
+		if options.Source != "" {
+			s := options.Source
+			source = &s
+		}
+		if options.Tag != "" {
+			t := options.Tag
+			tag = &t
+		}
+		if options.Platform != "" {
+			p := options.Platform
+			platform = &p
+		}
+		if options.AppVersion != "" {
+			v := options.AppVersion
+			appVersion = &v
+		}
 	}
 
 	// Default limit if not specified
@@ -45,7 +67,17 @@ func (r *repo) List(
 	}
 
 	var sqlcFeedbacks []sqlc.Feedback
-	sqlcFeedbacks, err := queries.ListFeedbacks(ctx, *limit, *offset)
+	sqlcFeedbacks, err := queries.ListFeedbacks(
+		ctx, sqlc.ListFeedbacksParams{
+			IncludeDeleted: includeDeleted,
+			Source:         source,
+			Tag:            tag,
+			Platform:       platform,
+			AppVersion:     appVersion,
+			Limit:          *limit,
+			Offset:         *offset,
+		},
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list feedbacks: %w", err)
 	}
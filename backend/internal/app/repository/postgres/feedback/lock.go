@@ -0,0 +1,26 @@
+package feedback
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) LockSubmissions(
+	ctx context.Context,
+	userID uuid.UUID,
+	opts ...repository.RepoOption[apprepo.Options],
+) error {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	if err := queries.LockUserSubmissions(ctx, userID.String()); err != nil {
+		return fmt.Errorf("failed to lock feedback submissions of user: %w", err)
+	}
+
+	return nil
+}
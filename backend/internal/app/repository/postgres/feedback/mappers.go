@@ -1,17 +1,20 @@
 package feedback
 
 import (
+	"encoding/json"
+
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/feedback/sqlc"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
 )
 
 // mapSQLCFeedbackToDomain maps a SQLC feedback model to a domain feedback entity.
 func mapSQLCFeedbackToDomain(sqlcFeedback sqlc.Feedback) *feedback.Feedback {
-	// Build rating value object
-	rating, _ := feedback.NewRating(int(sqlcFeedback.Rating))
+	// Build rating value object without scale validation, so ratings stored under a
+	// previously configured scale are still reconstructed as is
+	rating := feedback.Rating(sqlcFeedback.Rating)
 
-	// Build comment value object
-	comment, _ := feedback.NewComment(sqlcFeedback.Comment)
+	// Build comment value object without length validation, for the same reason
+	comment := feedback.RestoreComment(sqlcFeedback.Comment)
 
 	// Build domain entity using builder
 	builder := feedback.NewBuilder().
@@ -19,6 +22,9 @@ func mapSQLCFeedbackToDomain(sqlcFeedback sqlc.Feedback) *feedback.Feedback {
 		WithUserID(sqlcFeedback.UserID).
 		WithRating(rating).
 		WithComment(comment).
+		WithSource(feedback.Source(sqlcFeedback.Source)).
+		WithTags(sqlcFeedback.Tags).
+		WithMetadata(unmarshalMetadata(sqlcFeedback.Metadata)).
 		WithCreatedAt(sqlcFeedback.CreatedAt).
 		WithUpdatedAt(sqlcFeedback.UpdatedAt)
 
@@ -27,5 +33,59 @@ func mapSQLCFeedbackToDomain(sqlcFeedback sqlc.Feedback) *feedback.Feedback {
 		builder.WithDeletedAt(*sqlcFeedback.DeletedAt)
 	}
 
+	// Handle translated_comment and comment_language (nullable, set only if translation was enabled)
+	if sqlcFeedback.TranslatedComment != nil || sqlcFeedback.CommentLanguage != nil {
+		var translatedComment, language string
+		if sqlcFeedback.TranslatedComment != nil {
+			translatedComment = *sqlcFeedback.TranslatedComment
+		}
+		if sqlcFeedback.CommentLanguage != nil {
+			language = *sqlcFeedback.CommentLanguage
+		}
+		builder.WithTranslation(translatedComment, language)
+	}
+
+	// Handle original_comment (nullable, set only if markup was stripped and the original preserved)
+	if sqlcFeedback.OriginalComment != nil {
+		builder.WithOriginalComment(*sqlcFeedback.OriginalComment)
+	}
+
 	return builder.BuildUnchecked()
 }
+
+// metadataJSON is the JSONB representation of feedback metadata. Unset fields are omitted.
+type metadataJSON struct {
+	AppVersion string `json:"app_version,omitempty"`
+	Platform   string `json:"platform,omitempty"`
+	OS         string `json:"os,omitempty"`
+}
+
+// marshalMetadata encodes feedback metadata for the metadata column.
+func marshalMetadata(metadata feedback.Metadata) ([]byte, error) {
+	return json.Marshal(
+		metadataJSON{
+			AppVersion: metadata.AppVersion(),
+			Platform:   metadata.Platform().String(),
+			OS:         metadata.OS(),
+		},
+	)
+}
+
+// unmarshalMetadata decodes the metadata column. Metadata that cannot be decoded or validated
+// is reconstructed as empty, so that a malformed column never hides the feedback itself.
+func unmarshalMetadata(raw []byte) feedback.Metadata {
+	if len(raw) == 0 {
+		return feedback.Metadata{}
+	}
+
+	var stored metadataJSON
+	if err := json.Unmarshal(raw, &stored); err != nil {
+		return feedback.Metadata{}
+	}
+
+	metadata, err := feedback.NewMetadata(stored.AppVersion, stored.Platform, stored.OS)
+	if err != nil {
+		return feedback.Metadata{}
+	}
+	return metadata
+}
@@ -0,0 +1,13 @@
+-- name: CountFeedbacks :one
+SELECT COUNT(*) FROM feedback.feedbacks
+WHERE (sqlc.arg(include_deleted)::boolean OR deleted_at IS NULL)
+  AND (sqlc.narg(source)::varchar IS NULL OR source = sqlc.narg(source)::varchar)
+  AND (sqlc.narg(tag)::text IS NULL OR sqlc.narg(tag)::text = ANY(tags))
+  AND (sqlc.narg(platform)::text IS NULL OR metadata ->> 'platform' = sqlc.narg(platform)::text)
+  AND (sqlc.narg(app_version)::text IS NULL OR metadata ->> 'app_version' = sqlc.narg(app_version)::text);
+
+-- name: CountFeedbacksByUserSince :one
+-- Counts the feedbacks a user submitted since the given time, including soft-deleted ones.
+SELECT COUNT(*) FROM feedback.feedbacks
+WHERE user_id = $1
+  AND created_at >= $2;
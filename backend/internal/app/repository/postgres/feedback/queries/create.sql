@@ -6,7 +6,13 @@ INSERT INTO feedback.feedbacks (
     comment,
     created_at,
     updated_at,
-    deleted_at
+    deleted_at,
+    source,
+    tags,
+    metadata,
+    translated_comment,
+    comment_language,
+    original_comment
 ) VALUES (
     $1, -- id
     $2, -- user_id
@@ -14,6 +20,12 @@ INSERT INTO feedback.feedbacks (
     $4, -- comment
     $5, -- created_at
     $6, -- updated_at
-    $7  -- deleted_at
+    $7, -- deleted_at
+    $8, -- source
+    $9, -- tags
+    $10, -- metadata
+    $11, -- translated_comment
+    $12, -- comment_language
+    $13 -- original_comment
 )
 RETURNING *;
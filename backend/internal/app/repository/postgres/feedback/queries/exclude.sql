@@ -0,0 +1,10 @@
+-- name: ExcludeFeedbackFromAnalysis :exec
+-- Excludes a feedback from analysis, so that it is never selected for analysis.
+INSERT INTO feedback.analysis_exclusions (
+    feedback_id,
+    reason
+) VALUES (
+    $1,  -- feedback_id
+    $2   -- reason
+)
+ON CONFLICT (feedback_id) DO NOTHING;
@@ -0,0 +1,17 @@
+-- name: ExportFeedbacks :many
+SELECT * FROM feedback.feedbacks
+WHERE deleted_at IS NULL
+  AND (sqlc.narg(source)::varchar IS NULL OR source = sqlc.narg(source)::varchar)
+  AND (sqlc.narg(tag)::text IS NULL OR sqlc.narg(tag)::text = ANY(tags))
+  AND (sqlc.narg(platform)::text IS NULL OR metadata ->> 'platform' = sqlc.narg(platform)::text)
+  AND (sqlc.narg(app_version)::text IS NULL OR metadata ->> 'app_version' = sqlc.narg(app_version)::text)
+  AND (sqlc.narg(created_from)::timestamptz IS NULL OR created_at >= sqlc.narg(created_from)::timestamptz)
+  AND (sqlc.narg(created_to)::timestamptz IS NULL OR created_at < sqlc.narg(created_to)::timestamptz)
+  AND (sqlc.narg(min_rating)::integer IS NULL OR rating >= sqlc.narg(min_rating)::integer)
+  AND (sqlc.narg(max_rating)::integer IS NULL OR rating <= sqlc.narg(max_rating)::integer)
+  AND (
+    sqlc.narg(after_created_at)::timestamptz IS NULL
+    OR (created_at, id) > (sqlc.narg(after_created_at)::timestamptz, sqlc.narg(after_id)::uuid)
+  )
+ORDER BY created_at ASC, id ASC
+LIMIT sqlc.arg('limit');
@@ -1,3 +1,4 @@
 -- name: GetFeedback :one
 SELECT * FROM feedback.feedbacks
-WHERE id = $1;
+WHERE id = sqlc.arg(id)
+  AND (sqlc.arg(include_deleted)::boolean OR deleted_at IS NULL);
@@ -0,0 +1,5 @@
+-- name: GetFeedbacksByIDs :many
+SELECT * FROM feedback.feedbacks
+WHERE id = ANY(sqlc.arg(ids)::uuid[])
+  AND (sqlc.arg(include_deleted)::boolean OR deleted_at IS NULL)
+ORDER BY created_at ASC;
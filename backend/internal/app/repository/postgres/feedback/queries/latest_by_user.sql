@@ -0,0 +1,6 @@
+-- name: GetLatestFeedbackByUser :one
+-- Retrieves the latest feedback of a user, including soft-deleted ones.
+SELECT * FROM feedback.feedbacks
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
@@ -1,5 +1,9 @@
 -- name: ListFeedbacks :many
 SELECT * FROM feedback.feedbacks
-WHERE deleted_at IS NULL
+WHERE (sqlc.arg(include_deleted)::boolean OR deleted_at IS NULL)
+  AND (sqlc.narg(source)::varchar IS NULL OR source = sqlc.narg(source)::varchar)
+  AND (sqlc.narg(tag)::text IS NULL OR sqlc.narg(tag)::text = ANY(tags))
+  AND (sqlc.narg(platform)::text IS NULL OR metadata ->> 'platform' = sqlc.narg(platform)::text)
+  AND (sqlc.narg(app_version)::text IS NULL OR metadata ->> 'app_version' = sqlc.narg(app_version)::text)
 ORDER BY created_at DESC
-LIMIT $1 OFFSET $2;
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -0,0 +1,3 @@
+-- name: LockUserSubmissions :exec
+-- Serializes the feedback submissions of a user until the current transaction ends.
+SELECT pg_advisory_xact_lock(hashtext('feedback.feedbacks.submission'), hashtext(sqlc.arg(user_id)::text));
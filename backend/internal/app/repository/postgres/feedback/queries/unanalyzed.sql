@@ -0,0 +1,39 @@
+-- name: ListUnanalyzedFeedbacks :many
+SELECT * FROM feedback.feedbacks f
+WHERE f.deleted_at IS NULL
+  AND f.comment <> ''
+  AND NOT EXISTS (
+    SELECT 1 FROM feedback.analyzed_feedbacks af
+    JOIN feedback.analyses a ON a.id = af.analysis_id
+    WHERE af.feedback_id = f.id
+      AND a.status IN ('success', 'processing')
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM feedback.analysis_exclusions ex
+    WHERE ex.feedback_id = f.id
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM feedback.analysis_dead_letters dl
+    WHERE dl.feedback_id = f.id
+  )
+ORDER BY f.created_at ASC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountUnanalyzedFeedbacks :one
+SELECT COUNT(*) FROM feedback.feedbacks f
+WHERE f.deleted_at IS NULL
+  AND f.comment <> ''
+  AND NOT EXISTS (
+    SELECT 1 FROM feedback.analyzed_feedbacks af
+    JOIN feedback.analyses a ON a.id = af.analysis_id
+    WHERE af.feedback_id = f.id
+      AND a.status IN ('success', 'processing')
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM feedback.analysis_exclusions ex
+    WHERE ex.feedback_id = f.id
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM feedback.analysis_dead_letters dl
+    WHERE dl.feedback_id = f.id
+  );
@@ -1,6 +1,8 @@
 package feedback
 
 import (
+	"fmt"
+
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/feedback/sqlc"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/querier"
@@ -11,11 +13,14 @@ type repo struct {
 	defaultQuerier querier.PgxQuerier
 }
 
-// NewFeedbackRepository creates a new feedback repository.
-func NewFeedbackRepository(q querier.PgxQuerier) repository.FeedbackRepository {
+// NewFeedbackRepository creates a new feedback repository. It fails if q is nil.
+func NewFeedbackRepository(q querier.PgxQuerier) (repository.FeedbackRepository, error) {
+	if err := querier.Check(q); err != nil {
+		return nil, fmt.Errorf("failed to create feedback repository: %w", err)
+	}
 	return &repo{
 		defaultQuerier: q,
-	}
+	}, nil
 }
 
 var _ sqlc.DBTX = (*utils.QuerierAdapter)(nil)
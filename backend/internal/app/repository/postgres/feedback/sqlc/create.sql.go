@@ -20,7 +20,13 @@ INSERT INTO feedback.feedbacks (
     comment,
     created_at,
     updated_at,
-    deleted_at
+    deleted_at,
+    source,
+    tags,
+    metadata,
+    translated_comment,
+    comment_language,
+    original_comment
 ) VALUES (
     $1, -- id
     $2, -- user_id
@@ -28,19 +34,31 @@ INSERT INTO feedback.feedbacks (
     $4, -- comment
     $5, -- created_at
     $6, -- updated_at
-    $7  -- deleted_at
+    $7, -- deleted_at
+    $8, -- source
+    $9, -- tags
+    $10, -- metadata
+    $11, -- translated_comment
+    $12, -- comment_language
+    $13 -- original_comment
 )
-RETURNING id, rating, comment, created_at, updated_at, deleted_at, user_id
+RETURNING id, rating, comment, created_at, updated_at, deleted_at, user_id, source, tags, metadata, translated_comment, comment_language, original_comment
 `
 
 type CreateFeedbackParams struct {
-	ID        uuid.UUID  `db:"id"`
-	UserID    uuid.UUID  `db:"user_id"`
-	Rating    int32      `db:"rating"`
-	Comment   string     `db:"comment"`
-	CreatedAt time.Time  `db:"created_at"`
-	UpdatedAt time.Time  `db:"updated_at"`
-	DeletedAt *time.Time `db:"deleted_at"`
+	ID                uuid.UUID  `db:"id"`
+	UserID            uuid.UUID  `db:"user_id"`
+	Rating            int32      `db:"rating"`
+	Comment           string     `db:"comment"`
+	CreatedAt         time.Time  `db:"created_at"`
+	UpdatedAt         time.Time  `db:"updated_at"`
+	DeletedAt         *time.Time `db:"deleted_at"`
+	Source            string     `db:"source"`
+	Tags              []string   `db:"tags"`
+	Metadata          []byte     `db:"metadata"`
+	TranslatedComment *string    `db:"translated_comment"`
+	CommentLanguage   *string    `db:"comment_language"`
+	OriginalComment   *string    `db:"original_comment"`
 }
 
 func (q *Queries) CreateFeedback(ctx context.Context, arg CreateFeedbackParams) (Feedback, error) {
@@ -52,6 +70,12 @@ func (q *Queries) CreateFeedback(ctx context.Context, arg CreateFeedbackParams)
 		arg.CreatedAt,
 		arg.UpdatedAt,
 		arg.DeletedAt,
+		arg.Source,
+		arg.Tags,
+		arg.Metadata,
+		arg.TranslatedComment,
+		arg.CommentLanguage,
+		arg.OriginalComment,
 	)
 	var i Feedback
 	err := row.Scan(
@@ -62,6 +86,12 @@ func (q *Queries) CreateFeedback(ctx context.Context, arg CreateFeedbackParams)
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.UserID,
+		&i.Source,
+		&i.Tags,
+		&i.Metadata,
+		&i.TranslatedComment,
+		&i.CommentLanguage,
+		&i.OriginalComment,
 	)
 	return i, err
 }
@@ -12,12 +12,18 @@ import (
 )
 
 const getFeedback = `-- name: GetFeedback :one
-SELECT id, rating, comment, created_at, updated_at, deleted_at, user_id FROM feedback.feedbacks
+SELECT id, rating, comment, created_at, updated_at, deleted_at, user_id, source, tags, metadata, translated_comment, comment_language, original_comment FROM feedback.feedbacks
 WHERE id = $1
+  AND ($2::boolean OR deleted_at IS NULL)
 `
 
-func (q *Queries) GetFeedback(ctx context.Context, id uuid.UUID) (Feedback, error) {
-	row := q.db.QueryRow(ctx, getFeedback, id)
+type GetFeedbackParams struct {
+	ID             uuid.UUID `db:"id"`
+	IncludeDeleted bool      `db:"include_deleted"`
+}
+
+func (q *Queries) GetFeedback(ctx context.Context, arg GetFeedbackParams) (Feedback, error) {
+	row := q.db.QueryRow(ctx, getFeedback, arg.ID, arg.IncludeDeleted)
 	var i Feedback
 	err := row.Scan(
 		&i.ID,
@@ -27,6 +33,12 @@ func (q *Queries) GetFeedback(ctx context.Context, id uuid.UUID) (Feedback, erro
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.UserID,
+		&i.Source,
+		&i.Tags,
+		&i.Metadata,
+		&i.TranslatedComment,
+		&i.CommentLanguage,
+		&i.OriginalComment,
 	)
 	return i, err
 }
@@ -10,14 +10,36 @@ import (
 )
 
 const listFeedbacks = `-- name: ListFeedbacks :many
-SELECT id, rating, comment, created_at, updated_at, deleted_at, user_id FROM feedback.feedbacks
-WHERE deleted_at IS NULL
+SELECT id, rating, comment, created_at, updated_at, deleted_at, user_id, source, tags, metadata, translated_comment, comment_language, original_comment FROM feedback.feedbacks
+WHERE ($1::boolean OR deleted_at IS NULL)
+  AND ($2::varchar IS NULL OR source = $2::varchar)
+  AND ($3::text IS NULL OR $3::text = ANY(tags))
+  AND ($4::text IS NULL OR metadata ->> 'platform' = $4::text)
+  AND ($5::text IS NULL OR metadata ->> 'app_version' = $5::text)
 ORDER BY created_at DESC
-LIMIT $1 OFFSET $2
+LIMIT $6 OFFSET $7
 `
 
-func (q *Queries) ListFeedbacks(ctx context.Context, limit int32, offset int32) ([]Feedback, error) {
-	rows, err := q.db.Query(ctx, listFeedbacks, limit, offset)
+type ListFeedbacksParams struct {
+	IncludeDeleted bool    `db:"include_deleted"`
+	Source         *string `db:"source"`
+	Tag            *string `db:"tag"`
+	Platform       *string `db:"platform"`
+	AppVersion     *string `db:"app_version"`
+	Limit          int32   `db:"limit"`
+	Offset         int32   `db:"offset"`
+}
+
+func (q *Queries) ListFeedbacks(ctx context.Context, arg ListFeedbacksParams) ([]Feedback, error) {
+	rows, err := q.db.Query(ctx, listFeedbacks,
+		arg.IncludeDeleted,
+		arg.Source,
+		arg.Tag,
+		arg.Platform,
+		arg.AppVersion,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -33,6 +55,12 @@ func (q *Queries) ListFeedbacks(ctx context.Context, limit int32, offset int32)
 			&i.UpdatedAt,
 			&i.DeletedAt,
 			&i.UserID,
+			&i.Source,
+			&i.Tags,
+			&i.Metadata,
+			&i.TranslatedComment,
+			&i.CommentLanguage,
+			&i.OriginalComment,
 		); err != nil {
 			return nil, err
 		}
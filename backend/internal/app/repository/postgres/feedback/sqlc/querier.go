@@ -11,10 +11,23 @@ import (
 )
 
 type Querier interface {
+	CountFeedbacks(ctx context.Context, arg CountFeedbacksParams) (int64, error)
+	// Counts the feedbacks a user submitted since the given time, including soft-deleted ones.
+	CountFeedbacksByUserSince(ctx context.Context, arg CountFeedbacksByUserSinceParams) (int64, error)
+	CountUnanalyzedFeedbacks(ctx context.Context) (int64, error)
 	CreateFeedback(ctx context.Context, arg CreateFeedbackParams) (Feedback, error)
 	DeleteFeedback(ctx context.Context, id uuid.UUID) (int64, error)
-	GetFeedback(ctx context.Context, id uuid.UUID) (Feedback, error)
-	ListFeedbacks(ctx context.Context, limit int32, offset int32) ([]Feedback, error)
+	// Excludes a feedback from analysis, so that it is never selected for analysis.
+	ExcludeFeedbackFromAnalysis(ctx context.Context, arg ExcludeFeedbackFromAnalysisParams) error
+	ExportFeedbacks(ctx context.Context, arg ExportFeedbacksParams) ([]Feedback, error)
+	GetFeedback(ctx context.Context, arg GetFeedbackParams) (Feedback, error)
+	GetFeedbacksByIDs(ctx context.Context, arg GetFeedbacksByIDsParams) ([]Feedback, error)
+	// Retrieves the latest feedback of a user, including soft-deleted ones.
+	GetLatestFeedbackByUser(ctx context.Context, userID uuid.UUID) (Feedback, error)
+	ListFeedbacks(ctx context.Context, arg ListFeedbacksParams) ([]Feedback, error)
+	ListUnanalyzedFeedbacks(ctx context.Context, arg ListUnanalyzedFeedbacksParams) ([]Feedback, error)
+	// Serializes the feedback submissions of a user until the current transaction ends.
+	LockUserSubmissions(ctx context.Context, userID string) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,67 @@
+package feedback
+
+import (
+	"context"
+	"fmt"
+
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/feedback/sqlc"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) ListUnanalyzed(
+	ctx context.Context,
+	opts ...repository.RepoOption[apprepo.Options],
+) ([]*feedback.Feedback, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	wrapper := utils.BuildOpts(opts)
+	options := wrapper.Ext
+
+	// Default limit if not specified
+	limit := int32(100)
+	var offset int32
+	if options != nil {
+		if options.Limit > 0 {
+			limit = int32(options.Limit)
+		}
+		if options.Offset > 0 {
+			offset = int32(options.Offset)
+		}
+	}
+
+	sqlcFeedbacks, err := queries.ListUnanalyzedFeedbacks(
+		ctx, sqlc.ListUnanalyzedFeedbacksParams{
+			Limit:  limit,
+			Offset: offset,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unanalyzed feedbacks: %w", err)
+	}
+
+	feedbacks := make([]*feedback.Feedback, len(sqlcFeedbacks))
+	for i, sqlcFeedback := range sqlcFeedbacks {
+		feedbacks[i] = mapSQLCFeedbackToDomain(sqlcFeedback)
+	}
+
+	return feedbacks, nil
+}
+
+func (r *repo) CountUnanalyzed(
+	ctx context.Context,
+	opts ...repository.RepoOption[apprepo.Options],
+) (int, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	count, err := queries.CountUnanalyzedFeedbacks(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unanalyzed feedbacks: %w", err)
+	}
+
+	return int(count), nil
+}
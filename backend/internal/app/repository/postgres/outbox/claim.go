@@ -0,0 +1,46 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/outbox/sqlc"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	leaseUntil time.Time,
+	limit int,
+	opts ...repository.RepoOption[apprepo.Options],
+) ([]*external.OutboxEntry, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	sqlcEntries, err := queries.ClaimDueOutboxEvents(
+		ctx, sqlc.ClaimDueOutboxEventsParams{
+			LeaseUntil: leaseUntil,
+			Now:        now,
+			BatchSize:  int32(limit),
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim due outbox events: %w", err)
+	}
+
+	entries := make([]*external.OutboxEntry, 0, len(sqlcEntries))
+	for _, sqlcEntry := range sqlcEntries {
+		entry, err := mapSQLCOutboxEventToEntry(sqlcEntry)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
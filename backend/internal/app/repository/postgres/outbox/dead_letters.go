@@ -0,0 +1,103 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/outbox/sqlc"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) CreateDeadLetter(
+	ctx context.Context,
+	deadLetter *external.DeadLetter,
+	opts ...repository.RepoOption[apprepo.Options],
+) error {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	payload, err := json.Marshal(deadLetter.Event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if err := queries.CreateDeadLetter(
+		ctx, sqlc.CreateDeadLetterParams{
+			ID:        deadLetter.ID,
+			EventID:   deadLetter.Event.ID,
+			EventType: string(deadLetter.Event.Type),
+			Sink:      deadLetter.Sink,
+			Payload:   payload,
+			Attempts:  int32(deadLetter.Attempts),
+			LastError: deadLetter.LastError,
+			CreatedAt: deadLetter.CreatedAt,
+			FailedAt:  deadLetter.FailedAt,
+		},
+	); err != nil {
+		return fmt.Errorf("failed to create dead letter: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) ListDeadLetters(
+	ctx context.Context,
+	opts ...repository.RepoOption[apprepo.Options],
+) ([]*external.DeadLetter, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	wrapper := utils.BuildOpts(opts)
+	options := wrapper.Ext
+
+	limit := int32(100)
+	offset := int32(0)
+	if options != nil {
+		if options.Limit > 0 {
+			limit = int32(options.Limit)
+		}
+		if options.Offset > 0 {
+			offset = int32(options.Offset)
+		}
+	}
+
+	sqlcDeadLetters, err := queries.ListDeadLetters(
+		ctx, sqlc.ListDeadLettersParams{
+			Limit:  limit,
+			Offset: offset,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+
+	deadLetters := make([]*external.DeadLetter, 0, len(sqlcDeadLetters))
+	for _, sqlcDeadLetter := range sqlcDeadLetters {
+		deadLetter, err := mapSQLCDeadLetterToExternal(sqlcDeadLetter)
+		if err != nil {
+			return nil, err
+		}
+		deadLetters = append(deadLetters, deadLetter)
+	}
+
+	return deadLetters, nil
+}
+
+func (r *repo) CountDeadLetters(
+	ctx context.Context,
+	opts ...repository.RepoOption[apprepo.Options],
+) (int, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	count, err := queries.CountDeadLetters(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count dead letters: %w", err)
+	}
+
+	return int(count), nil
+}
@@ -0,0 +1,43 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/outbox/sqlc"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) Enqueue(
+	ctx context.Context,
+	entry *external.OutboxEntry,
+	opts ...repository.RepoOption[apprepo.Options],
+) error {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	payload, err := json.Marshal(entry.Event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if err := queries.EnqueueOutboxEvent(
+		ctx, sqlc.EnqueueOutboxEventParams{
+			ID:            entry.ID,
+			EventID:       entry.Event.ID,
+			EventType:     string(entry.Event.Type),
+			Sink:          entry.Sink,
+			Payload:       payload,
+			NextAttemptAt: entry.NextAttemptAt,
+			CreatedAt:     entry.CreatedAt,
+		},
+	); err != nil {
+		return fmt.Errorf("failed to enqueue outbox event: %w", err)
+	}
+
+	return nil
+}
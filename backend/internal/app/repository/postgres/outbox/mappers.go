@@ -0,0 +1,65 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/outbox/sqlc"
+)
+
+// mapSQLCOutboxEventToEntry maps a SQLC outbox event model to an outbox entry.
+func mapSQLCOutboxEventToEntry(sqlcEntry sqlc.OutboxEvent) (*external.OutboxEntry, error) {
+	event, err := unmarshalEvent(sqlcEntry.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode outbox event %s: %w", sqlcEntry.ID, err)
+	}
+
+	entry := &external.OutboxEntry{
+		ID:            sqlcEntry.ID,
+		Event:         event,
+		Sink:          sqlcEntry.Sink,
+		Attempts:      int(sqlcEntry.Attempts),
+		NextAttemptAt: sqlcEntry.NextAttemptAt,
+		CreatedAt:     sqlcEntry.CreatedAt,
+	}
+	if sqlcEntry.LastError != nil {
+		entry.LastError = *sqlcEntry.LastError
+	}
+
+	return entry, nil
+}
+
+// mapSQLCDeadLetterToExternal maps a SQLC dead letter model to a dead letter.
+func mapSQLCDeadLetterToExternal(sqlcDeadLetter sqlc.DeadLetter) (*external.DeadLetter, error) {
+	event, err := unmarshalEvent(sqlcDeadLetter.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode dead letter %s: %w", sqlcDeadLetter.ID, err)
+	}
+
+	return &external.DeadLetter{
+		ID:        sqlcDeadLetter.ID,
+		Event:     event,
+		Sink:      sqlcDeadLetter.Sink,
+		Attempts:  int(sqlcDeadLetter.Attempts),
+		LastError: sqlcDeadLetter.LastError,
+		CreatedAt: sqlcDeadLetter.CreatedAt,
+		FailedAt:  sqlcDeadLetter.FailedAt,
+	}, nil
+}
+
+// unmarshalEvent decodes a stored event. The event data is kept as raw JSON so that it is
+// delivered exactly as it was stored.
+func unmarshalEvent(payload []byte) (*external.Event, error) {
+	var stored struct {
+		external.Event
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &stored); err != nil {
+		return nil, err
+	}
+
+	event := stored.Event
+	event.Data = stored.Data
+	return &event, nil
+}
@@ -0,0 +1,12 @@
+-- name: ClaimDueOutboxEvents :many
+-- Leases due entries by pushing next_attempt_at to lease_until so that other dispatchers skip them
+UPDATE feedback.event_outbox
+SET next_attempt_at = sqlc.arg(lease_until)
+WHERE id IN (
+    SELECT id FROM feedback.event_outbox
+    WHERE next_attempt_at <= sqlc.arg(now)
+    ORDER BY next_attempt_at
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
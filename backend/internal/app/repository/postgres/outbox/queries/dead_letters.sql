@@ -0,0 +1,30 @@
+-- name: CreateDeadLetter :exec
+INSERT INTO feedback.event_dead_letters (
+    id,
+    event_id,
+    event_type,
+    sink,
+    payload,
+    attempts,
+    last_error,
+    created_at,
+    failed_at
+) VALUES (
+    $1, -- id
+    $2, -- event_id
+    $3, -- event_type
+    $4, -- sink
+    $5, -- payload
+    $6, -- attempts
+    $7, -- last_error
+    $8, -- created_at
+    $9  -- failed_at
+);
+
+-- name: ListDeadLetters :many
+SELECT * FROM feedback.event_dead_letters
+ORDER BY failed_at DESC
+LIMIT $1 OFFSET $2;
+
+-- name: CountDeadLetters :one
+SELECT COUNT(*) FROM feedback.event_dead_letters;
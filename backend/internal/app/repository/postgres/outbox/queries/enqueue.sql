@@ -0,0 +1,19 @@
+-- name: EnqueueOutboxEvent :exec
+INSERT INTO feedback.event_outbox (
+    id,
+    event_id,
+    event_type,
+    sink,
+    payload,
+    next_attempt_at,
+    created_at
+) VALUES (
+    $1, -- id
+    $2, -- event_id
+    $3, -- event_type
+    $4, -- sink
+    $5, -- payload
+    $6, -- next_attempt_at
+    $7  -- created_at
+)
+ON CONFLICT (event_id, sink) DO NOTHING;
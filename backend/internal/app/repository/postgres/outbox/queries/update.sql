@@ -0,0 +1,10 @@
+-- name: RescheduleOutboxEvent :exec
+UPDATE feedback.event_outbox
+SET attempts = $2,
+    next_attempt_at = $3,
+    last_error = $4
+WHERE id = $1;
+
+-- name: DeleteOutboxEvent :exec
+DELETE FROM feedback.event_outbox
+WHERE id = $1;
@@ -0,0 +1,30 @@
+package outbox
+
+import (
+	"fmt"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/outbox/sqlc"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/querier"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+type repo struct {
+	defaultQuerier querier.PgxQuerier
+}
+
+// NewOutboxRepository creates a new event outbox repository. It fails if q is nil.
+func NewOutboxRepository(q querier.PgxQuerier) (repository.OutboxRepository, error) {
+	if err := querier.Check(q); err != nil {
+		return nil, fmt.Errorf("failed to create event outbox repository: %w", err)
+	}
+	return &repo{
+		defaultQuerier: q,
+	}, nil
+}
+
+var _ sqlc.DBTX = (*utils.QuerierAdapter)(nil)
+
+func newSQLCQueries(q querier.PgxQuerier) *sqlc.Queries {
+	return sqlc.New(utils.NewQuerierAdapter(q))
+}
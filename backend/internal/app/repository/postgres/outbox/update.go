@@ -0,0 +1,53 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/outbox/sqlc"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) Reschedule(
+	ctx context.Context,
+	entryID uuid.UUID,
+	attempts int,
+	nextAttemptAt time.Time,
+	lastError string,
+	opts ...repository.RepoOption[apprepo.Options],
+) error {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	if err := queries.RescheduleOutboxEvent(
+		ctx, sqlc.RescheduleOutboxEventParams{
+			ID:            entryID,
+			Attempts:      int32(attempts),
+			NextAttemptAt: nextAttemptAt,
+			LastError:     &lastError,
+		},
+	); err != nil {
+		return fmt.Errorf("failed to reschedule outbox event: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) Delete(
+	ctx context.Context,
+	entryID uuid.UUID,
+	opts ...repository.RepoOption[apprepo.Options],
+) error {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	if err := queries.DeleteOutboxEvent(ctx, entryID); err != nil {
+		return fmt.Errorf("failed to delete outbox event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/user/sqlc"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) Count(
+	ctx context.Context,
+	opts ...repository.RepoOption[apprepo.Options],
+) (int, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	wrapper := utils.BuildOpts(opts)
+	options := wrapper.Ext
+
+	// Limit and offset do not apply to counting, only the filters do
+	var status, role, excludeEmail *string
+	var includeDeleted bool
+	if options != nil {
+		includeDeleted = options.IncludeDeleted
+		if options.ExcludeEmail != "" {
+			e := options.ExcludeEmail
+			excludeEmail = &e
+		}
+		if options.Status != "" {
+			s := options.Status
+			status = &s
+		}
+		if options.Role != "" {
+			r := options.Role
+			role = &r
+		}
+	}
+
+	count, err := queries.CountUsers(
+		ctx, sqlc.CountUsersParams{
+			IncludeDeleted: includeDeleted,
+			Status:         status,
+			Role:           role,
+			ExcludeEmail:   excludeEmail,
+		},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	return int(count), nil
+}
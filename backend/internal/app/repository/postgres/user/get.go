@@ -2,10 +2,13 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/user/sqlc"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/user"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
@@ -21,6 +24,9 @@ func (r *repo) GetByID(
 
 	sqlcUser, err := queries.GetUserByID(ctx, userID)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // User does not exist
+		}
 		return nil, fmt.Errorf("failed to get user by ID: %w", err)
 	}
 
@@ -35,7 +41,15 @@ func (r *repo) GetByEmail(
 	q := utils.GetQuerier(opts, r.defaultQuerier)
 	queries := newSQLCQueries(q)
 
-	sqlcUser, err := queries.GetUserByEmail(ctx, email)
+	wrapper := utils.BuildOpts(opts)
+	includeDeleted := wrapper.Ext != nil && wrapper.Ext.IncludeDeleted
+
+	sqlcUser, err := queries.GetUserByEmail(
+		ctx, sqlc.GetUserByEmailParams{
+			Email:          email,
+			IncludeDeleted: includeDeleted,
+		},
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user by email: %w", err)
 	}
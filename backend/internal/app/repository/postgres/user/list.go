@@ -0,0 +1,63 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/user/sqlc"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/user"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) List(
+	ctx context.Context,
+	opts ...repository.RepoOption[apprepo.Options],
+) ([]*user.User, error) {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	wrapper := utils.BuildOpts(opts)
+	options := wrapper.Ext
+
+	// Default page if not specified
+	limit := int32(100)
+	offset := int32(0)
+	var status, role *string
+	if options != nil {
+		if options.Limit > 0 {
+			limit = int32(options.Limit)
+		}
+		if options.Offset > 0 {
+			offset = int32(options.Offset)
+		}
+		if options.Status != "" {
+			s := options.Status
+			status = &s
+		}
+		if options.Role != "" {
+			r := options.Role
+			role = &r
+		}
+	}
+
+	sqlcUsers, err := queries.ListUsers(
+		ctx, sqlc.ListUsersParams{
+			Status: status,
+			Role:   role,
+			Limit:  limit,
+			Offset: offset,
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	users := make([]*user.User, len(sqlcUsers))
+	for i, sqlcUser := range sqlcUsers {
+		users[i] = mapSQLCUserToDomain(sqlcUser)
+	}
+
+	return users, nil
+}
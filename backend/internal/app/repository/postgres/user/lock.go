@@ -0,0 +1,21 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) LockRegistrations(ctx context.Context, opts ...repository.RepoOption[apprepo.Options]) error {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	if err := queries.LockUserRegistrations(ctx); err != nil {
+		return fmt.Errorf("failed to lock user registrations: %w", err)
+	}
+
+	return nil
+}
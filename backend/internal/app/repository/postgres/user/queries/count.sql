@@ -0,0 +1,6 @@
+-- name: CountUsers :one
+SELECT COUNT(*) FROM feedback.users
+WHERE (sqlc.arg(include_deleted)::boolean OR deleted_at IS NULL)
+  AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status)::varchar)
+  AND (sqlc.narg(role)::text IS NULL OR sqlc.narg(role)::text = ANY(roles))
+  AND (sqlc.narg(exclude_email)::varchar IS NULL OR email <> sqlc.narg(exclude_email)::varchar);
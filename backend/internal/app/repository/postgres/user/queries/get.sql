@@ -4,4 +4,5 @@ WHERE id = $1;
 
 -- name: GetUserByEmail :one
 SELECT * FROM feedback.users
-WHERE email = $1;
+WHERE email = sqlc.arg(email)
+  AND (sqlc.arg(include_deleted)::boolean OR deleted_at IS NULL);
@@ -0,0 +1,7 @@
+-- name: ListUsers :many
+SELECT * FROM feedback.users
+WHERE deleted_at IS NULL
+  AND (sqlc.narg(status)::varchar IS NULL OR status = sqlc.narg(status)::varchar)
+  AND (sqlc.narg(role)::text IS NULL OR sqlc.narg(role)::text = ANY(roles))
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -0,0 +1,3 @@
+-- name: LockUserRegistrations :exec
+-- Serializes registrations until the current transaction ends.
+SELECT pg_advisory_xact_lock(hashtext('feedback.users.registration'));
@@ -0,0 +1,8 @@
+-- name: UpdateUser :execrows
+UPDATE feedback.users
+SET password_hash = $2,
+    roles = $3,
+    status = $4,
+    updated_at = $5,
+    deleted_at = $6
+WHERE id = $1;
@@ -1,6 +1,8 @@
 package user
 
 import (
+	"fmt"
+
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/user/sqlc"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/querier"
@@ -11,11 +13,14 @@ type repo struct {
 	defaultQuerier querier.PgxQuerier
 }
 
-// NewUserRepository creates a new user repository.
-func NewUserRepository(q querier.PgxQuerier) repository.UserRepository {
+// NewUserRepository creates a new user repository. It fails if q is nil.
+func NewUserRepository(q querier.PgxQuerier) (repository.UserRepository, error) {
+	if err := querier.Check(q); err != nil {
+		return nil, fmt.Errorf("failed to create user repository: %w", err)
+	}
 	return &repo{
 		defaultQuerier: q,
-	}
+	}, nil
 }
 
 var _ sqlc.DBTX = (*utils.QuerierAdapter)(nil)
@@ -14,10 +14,16 @@ import (
 const getUserByEmail = `-- name: GetUserByEmail :one
 SELECT id, email, password_hash, roles, status, created_at, updated_at, deleted_at FROM feedback.users
 WHERE email = $1
+  AND ($2::boolean OR deleted_at IS NULL)
 `
 
-func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error) {
-	row := q.db.QueryRow(ctx, getUserByEmail, email)
+type GetUserByEmailParams struct {
+	Email          string `db:"email"`
+	IncludeDeleted bool   `db:"include_deleted"`
+}
+
+func (q *Queries) GetUserByEmail(ctx context.Context, arg GetUserByEmailParams) (User, error) {
+	row := q.db.QueryRow(ctx, getUserByEmail, arg.Email, arg.IncludeDeleted)
 	var i User
 	err := row.Scan(
 		&i.ID,
@@ -0,0 +1,55 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/repository/postgres/user/sqlc"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/user"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+func (r *repo) Update(
+	ctx context.Context,
+	u *user.User,
+	opts ...repository.RepoOption[apprepo.Options],
+) error {
+	q := utils.GetQuerier(opts, r.defaultQuerier)
+	queries := newSQLCQueries(q)
+
+	// Convert roles to []string
+	roles := u.Roles()
+	roleStrings := make([]string, len(roles))
+	for i, role := range roles {
+		roleStrings[i] = role.String()
+	}
+
+	var deletedAt *time.Time
+	if u.DeletedAt().IsSome() {
+		dt := u.DeletedAt().Unwrap()
+		deletedAt = &dt
+	}
+
+	rowsAffected, err := queries.UpdateUser(
+		ctx, sqlc.UpdateUserParams{
+			ID:           u.ID(),
+			PasswordHash: u.PasswordHash().Value(),
+			Roles:        roleStrings,
+			Status:       u.Status().String(),
+			UpdatedAt:    u.UpdatedAt(),
+			DeletedAt:    deletedAt,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("user with ID %s not found", u.ID())
+	}
+
+	return nil
+}
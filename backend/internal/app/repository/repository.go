@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/user"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
 )
 
@@ -17,19 +20,87 @@ type FeedbackRepository interface {
 	Get(ctx context.Context, feedbackID uuid.UUID, opts ...repository.RepoOption[Options]) (*feedback.Feedback, error)
 	// List retrieves a list of feedback entries from the repository.
 	List(ctx context.Context, opts ...repository.RepoOption[Options]) ([]*feedback.Feedback, error)
+	// Count returns the number of non-deleted feedback entries matching the filters in the options.
+	// Limit and Offset are ignored.
+	Count(ctx context.Context, opts ...repository.RepoOption[Options]) (int, error)
+	// CountByUserSince returns the number of feedback entries a user submitted at or after since,
+	// including soft-deleted ones, so that deleting feedback does not free up submissions.
+	CountByUserSince(
+		ctx context.Context,
+		userID uuid.UUID,
+		since time.Time,
+		opts ...repository.RepoOption[Options],
+	) (int, error)
+	// GetByIDs retrieves the non-deleted feedback entries matching the given IDs.
+	// IDs that do not exist or are deleted are silently omitted from the result.
+	// Soft-deleted entries are only returned if IncludeDeleted is set in the options.
+	GetByIDs(ctx context.Context, feedbackIDs []uuid.UUID, opts ...repository.RepoOption[Options]) (
+		[]*feedback.Feedback,
+		error,
+	)
 	// Delete performs a soft delete on a feedback entry by setting deleted_at timestamp.
 	Delete(ctx context.Context, feedbackID uuid.UUID, opts ...repository.RepoOption[Options]) error
+	// LatestByUser retrieves the most recent feedback submitted by a user, including soft-deleted ones like
+	// CountByUserSince.
+	// Returns nil without an error if the user has not submitted any feedback.
+	LatestByUser(ctx context.Context, userID uuid.UUID, opts ...repository.RepoOption[Options]) (
+		*feedback.Feedback,
+		error,
+	)
+	// LockSubmissions takes a lock that serializes the submissions of a user until the transaction passed as the
+	// executor in the options ends, so that limits checked against the stored submissions cannot race.
+	LockSubmissions(ctx context.Context, userID uuid.UUID, opts ...repository.RepoOption[Options]) error
+	// ListUnanalyzed retrieves the non-deleted feedback entries with a comment that were never included in
+	// a successful or still processing analysis and are neither excluded from analysis nor dead-lettered,
+	// oldest first. Only Limit and Offset of the options apply.
+	ListUnanalyzed(ctx context.Context, opts ...repository.RepoOption[Options]) ([]*feedback.Feedback, error)
+	// CountUnanalyzed returns the number of feedback entries ListUnanalyzed would return without pagination.
+	CountUnanalyzed(ctx context.Context, opts ...repository.RepoOption[Options]) (int, error)
+	// ExcludeFromAnalysis records that a feedback entry is kept out of analysis, e.g. because it was flagged by
+	// content moderation or its comment is not in an accepted language, so that ListUnanalyzed never returns it.
+	// Does nothing if it is already excluded.
+	ExcludeFromAnalysis(
+		ctx context.Context,
+		feedbackID uuid.UUID,
+		reason string,
+		opts ...repository.RepoOption[Options],
+	) error
+	// Export calls fn for every non-deleted feedback entry matching the filters in the options, oldest first.
+	// Entries are read in pages, so the result set is never held in memory at once. Limit and Offset are ignored.
+	// Iteration stops at the first error returned by fn, which is returned as is.
+	Export(
+		ctx context.Context,
+		fn func(*feedback.Feedback) error,
+		opts ...repository.RepoOption[Options],
+	) error
 }
 
 type UserRepository interface {
 	// Create stores a new user in the repository.
 	Create(ctx context.Context, u *user.User, opts ...repository.RepoOption[Options]) error
-	// GetByID retrieves a user by its ID.
+	// GetByID retrieves a user by its ID, including soft-deleted users.
+	// Returns nil without an error if the user does not exist.
 	GetByID(ctx context.Context, userID uuid.UUID, opts ...repository.RepoOption[Options]) (*user.User, error)
-	// GetByEmail retrieves a user by email address.
+	// GetByEmail retrieves a non-deleted user by email address.
+	// Soft-deleted users are only returned if IncludeDeleted is set in the options.
 	GetByEmail(ctx context.Context, email string, opts ...repository.RepoOption[Options]) (*user.User, error)
+	// Update persists the mutable fields of a user (password, roles, status and deletion state).
+	Update(ctx context.Context, u *user.User, opts ...repository.RepoOption[Options]) error
+	// List retrieves non-deleted users ordered by creation date (newest first),
+	// optionally filtered by Status and Role.
+	List(ctx context.Context, opts ...repository.RepoOption[Options]) ([]*user.User, error)
+	// Count returns the number of users matching the Status and Role filters. Limit and Offset are ignored.
+	// Soft-deleted users are only counted if IncludeDeleted is set in the options, and the user with the
+	// ExcludeEmail is left out.
+	Count(ctx context.Context, opts ...repository.RepoOption[Options]) (int, error)
+	// LockRegistrations takes a lock that serializes registrations until the transaction passed as the executor
+	// in the options ends, so that decisions based on the registered users cannot race.
+	LockRegistrations(ctx context.Context, opts ...repository.RepoOption[Options]) error
 }
 
+// SeededAdminEmail is the email of the default admin account created by the migrations.
+const SeededAdminEmail = "admin@mail.com"
+
 type AnalysisRepository interface {
 	// Create stores a new analysis in the repository.
 	Create(ctx context.Context, analysis *analysis.Analysis, opts ...repository.RepoOption[Options]) error
@@ -40,15 +111,30 @@ type AnalysisRepository interface {
 		updates *analysis.UpdatableFields,
 		opts ...repository.RepoOption[Options],
 	) error
-	// GetByID retrieves an analysis by its ID.
+	// FailStaleProcessing marks every analysis still in processing status that was created before createdBefore
+	// as failed with the given reason and completion time. Returns the number of analyses updated.
+	FailStaleProcessing(
+		ctx context.Context,
+		createdBefore time.Time,
+		reason string,
+		completedAt time.Time,
+		opts ...repository.RepoOption[Options],
+	) (int, error)
+	// GetByID retrieves an analysis by its ID. Returns nil if the analysis does not exist.
 	GetByID(ctx context.Context, analysisID uuid.UUID, opts ...repository.RepoOption[Options]) (
 		*analysis.Analysis,
 		error,
 	)
-	// GetLatest retrieves the latest analysis.
+	// GetLatest retrieves the latest successful analysis. Returns nil if there is none.
 	GetLatest(ctx context.Context, opts ...repository.RepoOption[Options]) (*analysis.Analysis, error)
-	// List retrieves all analyses ordered by creation date (newest first).
+	// GetLatestRepresentative retrieves the latest successful analysis flagged as representative.
+	// Returns nil if there is none.
+	GetLatestRepresentative(ctx context.Context, opts ...repository.RepoOption[Options]) (*analysis.Analysis, error)
+	// List retrieves analyses ordered by creation date (newest first). Only Limit and Offset of the options apply;
+	// all analyses are returned without a Limit.
 	List(ctx context.Context, opts ...repository.RepoOption[Options]) ([]*analysis.Analysis, error)
+	// Count returns the number of analyses. Limit and Offset are ignored.
+	Count(ctx context.Context, opts ...repository.RepoOption[Options]) (int, error)
 	// CreateTopicAnalysis creates a topic analysis for an analysis.
 	CreateTopicAnalysis(
 		ctx context.Context,
@@ -69,12 +155,44 @@ type AnalysisRepository interface {
 		analysisID uuid.UUID,
 		opts ...repository.RepoOption[Options],
 	) ([]*analysis.TopicAnalysis, error)
+	// GetTopicAnalysisByID retrieves a topic analysis by its ID. Returns nil if the topic analysis does not exist.
+	GetTopicAnalysisByID(
+		ctx context.Context,
+		topicID uuid.UUID,
+		opts ...repository.RepoOption[Options],
+	) (*analysis.TopicAnalysis, error)
+	// GetTopicAnalysesByEnum retrieves the topic analyses of a topic across all successful analyses,
+	// with the period of their analysis, oldest analysis first.
+	GetTopicAnalysesByEnum(
+		ctx context.Context,
+		topic analysis.Topic,
+		opts ...repository.RepoOption[Options],
+	) ([]TopicHistoryEntry, error)
 	// GetFeedbackIDsByTopicID retrieves all feedback IDs assigned to a topic.
 	GetFeedbackIDsByTopicID(
 		ctx context.Context,
 		topicID uuid.UUID,
 		opts ...repository.RepoOption[Options],
 	) ([]uuid.UUID, error)
+	// CreateTopicDeltas stores the per-topic feedback count changes of an analysis versus its previous analysis.
+	CreateTopicDeltas(
+		ctx context.Context,
+		analysisID uuid.UUID,
+		deltas []analysis.TopicDelta,
+		opts ...repository.RepoOption[Options],
+	) error
+	// GetTopicDeltasByAnalysisID retrieves the stored topic deltas of an analysis, largest change first.
+	// Returns an empty list for analyses without a previous analysis.
+	GetTopicDeltasByAnalysisID(
+		ctx context.Context,
+		analysisID uuid.UUID,
+		opts ...repository.RepoOption[Options],
+	) ([]analysis.TopicDelta, error)
+	// RefreshTopicStats recomputes the materialized topic statistics from the topics of an analysis and the
+	// current ratings of their feedbacks, replacing the statistics of any other analysis.
+	RefreshTopicStats(ctx context.Context, analysisID uuid.UUID, opts ...repository.RepoOption[Options]) error
+	// GetTopicStats retrieves the materialized topic statistics, ordered by topic.
+	GetTopicStats(ctx context.Context, opts ...repository.RepoOption[Options]) ([]analysis.TopicStats, error)
 	// CreateAnalyzedFeedbacks creates analyzed feedback records (junction table).
 	CreateAnalyzedFeedbacks(
 		ctx context.Context,
@@ -88,11 +206,180 @@ type AnalysisRepository interface {
 		analysisID uuid.UUID,
 		opts ...repository.RepoOption[Options],
 	) ([]uuid.UUID, error)
+	// GetAnalysesByFeedbackID retrieves the analyses of any status that included a feedback, newest first,
+	// each with the topics the feedback was assigned to in it.
+	GetAnalysesByFeedbackID(
+		ctx context.Context,
+		feedbackID uuid.UUID,
+		opts ...repository.RepoOption[Options],
+	) ([]FeedbackAnalysis, error)
+	// CountTagTopics counts tagged, analyzed feedbacks per user tag and per pair of user tag
+	// and the topic assigned by the latest successful analysis of the feedback.
+	CountTagTopics(ctx context.Context, opts ...repository.RepoOption[Options]) (*TagTopicCounts, error)
+	// AggregateOverview computes totals across all analyses with aggregate queries.
+	AggregateOverview(ctx context.Context, opts ...repository.RepoOption[Options]) (*AnalysesOverview, error)
+	// SaveRawOutput stores the raw model output of an analysis, replacing a previously stored one.
+	SaveRawOutput(
+		ctx context.Context,
+		analysisID uuid.UUID,
+		output string,
+		opts ...repository.RepoOption[Options],
+	) error
+	// GetRawOutput retrieves the raw model output of an analysis. Returns nil if none was stored.
+	GetRawOutput(
+		ctx context.Context,
+		analysisID uuid.UUID,
+		opts ...repository.RepoOption[Options],
+	) (*analysis.RawOutput, error)
+	// CreateEvent appends an event to the lifecycle timeline of an analysis.
+	CreateEvent(ctx context.Context, event *analysis.Event, opts ...repository.RepoOption[Options]) error
+	// ListEvents retrieves the lifecycle timeline of an analysis, oldest event first.
+	ListEvents(
+		ctx context.Context,
+		analysisID uuid.UUID,
+		opts ...repository.RepoOption[Options],
+	) ([]*analysis.Event, error)
+	// SaveDeadLetter excludes a feedback from further analyses because it makes the model return invalid
+	// output, recording the failed analysis it was isolated from. Dead-lettering a feedback again replaces
+	// the previous record.
+	SaveDeadLetter(
+		ctx context.Context,
+		feedbackID uuid.UUID,
+		analysisID uuid.UUID,
+		reason string,
+		opts ...repository.RepoOption[Options],
+	) error
+	// SaveTokenUsage stores the estimated and actual token usage of an analysis, replacing a previously stored one.
+	SaveTokenUsage(ctx context.Context, usage *analysis.TokenUsage, opts ...repository.RepoOption[Options]) error
+	// AggregateTokenAccuracy summarizes the ratio of estimated to actual total tokens over the analyses with
+	// recorded usage, counting the analyses per ratio bucket delimited by the ascending boundaries.
+	AggregateTokenAccuracy(
+		ctx context.Context,
+		boundaries []float64,
+		opts ...repository.RepoOption[Options],
+	) (*TokenAccuracy, error)
+}
+
+// TagTopicCounts relates the tags attached by submitters to the topics assigned by the LLM.
+type TagTopicCounts struct {
+	// TaggedFeedbacks is the number of analyzed feedbacks per tag.
+	TaggedFeedbacks map[feedback.Tag]int
+	// Assignments is the number of feedbacks per tag assigned to each topic.
+	Assignments map[feedback.Tag]map[analysis.Topic]int
+}
+
+// AnalysesOverview holds totals across all analyses.
+type AnalysesOverview struct {
+	// TotalAnalyses is the number of analyses in any status.
+	TotalAnalyses int
+	// SuccessfulAnalyses is the number of successful analyses.
+	SuccessfulAnalyses int
+	// AnalyzedFeedbacks is the number of distinct feedbacks included in at least one successful analysis.
+	AnalyzedFeedbacks int
+	// TotalTokens is the number of tokens consumed by all analyses.
+	TotalTokens int64
+	// AverageFeedbacksPerAnalysis is the mean batch size of successful analyses.
+	AverageFeedbacksPerAnalysis float64
+	// TopicFeedbacks is the number of feedbacks per topic assigned by their latest successful analysis,
+	// most common first.
+	TopicFeedbacks []TopicFeedbackCount
+}
+
+// TopicHistoryEntry is a topic analysis together with the analysis it belongs to.
+type TopicHistoryEntry struct {
+	TopicAnalysis     *analysis.TopicAnalysis
+	PeriodStart       time.Time
+	PeriodEnd         time.Time
+	AnalysisCreatedAt time.Time
+}
+
+// FeedbackAnalysis is an analysis that included a feedback, together with the topics the feedback was assigned to.
+type FeedbackAnalysis struct {
+	Analysis *analysis.Analysis
+	// Topics are the topics of the analysis the feedback was assigned to, empty if it was assigned to none.
+	Topics []*analysis.TopicAnalysis
+}
+
+// TopicFeedbackCount is the number of feedbacks assigned to a topic.
+type TopicFeedbackCount struct {
+	Topic         analysis.Topic
+	FeedbackCount int
+}
+
+// TokenAccuracy summarizes the ratio of estimated to actual total tokens of analyses.
+// All ratios are 0 if no usage was recorded.
+type TokenAccuracy struct {
+	// SampleCount is the number of analyses with recorded usage.
+	SampleCount int
+	MeanRatio   float64
+	MinRatio    float64
+	MaxRatio    float64
+	P10Ratio    float64
+	MedianRatio float64
+	P90Ratio    float64
+	// BucketCounts is the number of analyses per ratio bucket. Bucket 0 holds ratios below the first
+	// boundary, bucket i ratios from boundary i-1 up to boundary i, and the last bucket ratios from the last boundary.
+	BucketCounts []int
+}
+
+type OutboxRepository interface {
+	// Enqueue stores an event for delivery to a sink.
+	// Enqueueing the same event for the same sink again is a no-op.
+	Enqueue(ctx context.Context, entry *external.OutboxEntry, opts ...repository.RepoOption[Options]) error
+	// ClaimDue leases up to limit entries due at now until leaseUntil, skipping entries leased by others.
+	ClaimDue(
+		ctx context.Context,
+		now time.Time,
+		leaseUntil time.Time,
+		limit int,
+		opts ...repository.RepoOption[Options],
+	) ([]*external.OutboxEntry, error)
+	// Reschedule records a failed delivery attempt and the time of the next one.
+	Reschedule(
+		ctx context.Context,
+		entryID uuid.UUID,
+		attempts int,
+		nextAttemptAt time.Time,
+		lastError string,
+		opts ...repository.RepoOption[Options],
+	) error
+	// Delete removes an entry from the outbox.
+	Delete(ctx context.Context, entryID uuid.UUID, opts ...repository.RepoOption[Options]) error
+	// CreateDeadLetter stores an event that permanently failed to be delivered.
+	CreateDeadLetter(ctx context.Context, deadLetter *external.DeadLetter, opts ...repository.RepoOption[Options]) error
+	// ListDeadLetters retrieves dead letters ordered by failure time (newest first).
+	ListDeadLetters(ctx context.Context, opts ...repository.RepoOption[Options]) ([]*external.DeadLetter, error)
+	// CountDeadLetters returns the number of dead letters. Limit and Offset are ignored.
+	CountDeadLetters(ctx context.Context, opts ...repository.RepoOption[Options]) (int, error)
 }
 
 type Options struct {
 	Limit  int
 	Offset int
+	// Source filters feedbacks by submission source when non-empty.
+	Source string
+	// Tag filters feedbacks by submitter tag when non-empty.
+	Tag string
+	// Platform filters feedbacks by reporter platform when non-empty.
+	Platform string
+	// AppVersion filters feedbacks by reporter app version when non-empty.
+	AppVersion string
+	// CreatedFrom filters feedbacks created at or after the given time when non-zero.
+	CreatedFrom time.Time
+	// CreatedTo filters feedbacks created before the given time when non-zero.
+	CreatedTo time.Time
+	// MinRating filters feedbacks rated at least the given value when set.
+	MinRating optional.Optional[int]
+	// MaxRating filters feedbacks rated at most the given value when set.
+	MaxRating optional.Optional[int]
+	// Status filters users by account status when non-empty.
+	Status string
+	// Role filters users holding the given role when non-empty.
+	Role string
+	// ExcludeEmail leaves out the user with the given email when non-empty.
+	ExcludeEmail string
+	// IncludeDeleted also returns soft-deleted entries, where supported.
+	IncludeDeleted bool
 }
 
 func WithOptions(opts *Options) repository.RepoOption[Options] {
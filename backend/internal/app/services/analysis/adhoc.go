@@ -0,0 +1,106 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+)
+
+// AnalyzeAdhoc runs an analysis synchronously over exactly the given feedbacks.
+// The pending queue and the debounce window are left untouched.
+func (a *analyzer) AnalyzeAdhoc(ctx context.Context, feedbackIDs []uuid.UUID) (*analysis.Analysis, error) {
+	logger := a.logger.WithSpan(ctx)
+	logger.Info("starting ad-hoc analysis", "feedback_count", len(feedbackIDs))
+
+	if err := a.validateAdhocFeedbackIDs(feedbackIDs); err != nil {
+		return nil, err
+	}
+
+	feedbacks, err := a.feedbackRepo.GetByIDs(ctx, feedbackIDs)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		return nil, fmt.Errorf("failed to get feedbacks for ad-hoc analysis: %w", err)
+	}
+
+	if len(feedbacks) != len(feedbackIDs) {
+		found := make(map[uuid.UUID]bool, len(feedbacks))
+		for _, fb := range feedbacks {
+			found[fb.ID()] = true
+		}
+
+		missing := make([]string, 0, len(feedbackIDs)-len(feedbacks))
+		for _, id := range feedbackIDs {
+			if !found[id] {
+				missing = append(missing, id.String())
+			}
+		}
+
+		return nil, ce.ErrBadRequest(
+			"feedbacks not found: "+strings.Join(missing, ", "),
+			ce.WithDetails(map[string]any{"missing_feedback_ids": missing}),
+		)
+	}
+
+	var ratingOnly []string
+	for _, fb := range feedbacks {
+		if fb.IsRatingOnly() {
+			ratingOnly = append(ratingOnly, fb.ID().String())
+		}
+	}
+	if len(ratingOnly) > 0 {
+		return nil, ce.ErrBadRequest(
+			"rating-only feedbacks have no comment to analyze: "+strings.Join(ratingOnly, ", "),
+			ce.WithDetails(map[string]any{"rating_only_feedback_ids": ratingOnly}),
+		)
+	}
+
+	// Track the run so that Stop waits for it, and detach it from the request
+	// so that a client disconnect does not leave a half-written analysis behind.
+	if !a.trackRun() {
+		return nil, ErrAnalyzerStopped
+	}
+	defer a.wg.Done()
+
+	result, err := a.performAnalysis(context.WithoutCancel(ctx), feedbacks)
+	if err != nil {
+		return nil, fmt.Errorf("ad-hoc analysis failed: %w", err)
+	}
+
+	logger.Info("ad-hoc analysis completed", "analysis_id", result.ID().String())
+	return result, nil
+}
+
+// validateAdhocFeedbackIDs checks that the requested feedback set is non-empty, unique
+// and fits within the configured context limit.
+func (a *analyzer) validateAdhocFeedbackIDs(feedbackIDs []uuid.UUID) error {
+	if len(feedbackIDs) == 0 {
+		return ce.ErrBadRequest("at least one feedback ID is required")
+	}
+
+	if len(feedbackIDs) > a.cfg.MaxFeedbacksInContext {
+		return ce.ErrBadRequest(
+			fmt.Sprintf(
+				"too many feedbacks: %d requested, at most %d allowed",
+				len(feedbackIDs),
+				a.cfg.MaxFeedbacksInContext,
+			),
+		)
+	}
+
+	seen := make(map[uuid.UUID]bool, len(feedbackIDs))
+	for _, id := range feedbackIDs {
+		if id == uuid.Nil {
+			return ce.ErrBadRequest("feedback ID must not be empty")
+		}
+		if seen[id] {
+			return ce.ErrBadRequest("duplicate feedback ID: " + id.String())
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+)
+
+// GetTagTopicAgreement compares the tags attached by submitters with the topics assigned by the LLM.
+func (s *service) GetTagTopicAgreement(ctx context.Context) (*services.TagTopicAgreement, error) {
+	logger := s.logger.WithSpan(ctx)
+	logger.Info("getting tag topic agreement")
+
+	counts, err := s.analysisRepo.CountTagTopics(ctx)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error counting tag topics", err)
+		return nil, fmt.Errorf("failed to count tag topics: %w", err)
+	}
+
+	agreement := buildTagTopicAgreement(counts)
+	logger.Info("tag topic agreement computed", "tags", len(agreement.Tags), "agreement_rate", agreement.AgreementRate)
+	return agreement, nil
+}
+
+// buildTagTopicAgreement derives the per-tag dominant topics and agreement rates from the raw counts.
+func buildTagTopicAgreement(counts *apprepo.TagTopicCounts) *services.TagTopicAgreement {
+	agreement := &services.TagTopicAgreement{
+		Tags: make([]services.TagAgreement, 0, len(counts.TaggedFeedbacks)),
+	}
+
+	var totalFeedbacks, totalAgreeing int
+	for tag, feedbackCount := range counts.TaggedFeedbacks {
+		if feedbackCount <= 0 {
+			continue
+		}
+
+		row := services.TagAgreement{
+			Tag:           tag,
+			FeedbackCount: feedbackCount,
+			Topics:        make([]services.TagTopicCount, 0, len(counts.Assignments[tag])),
+		}
+		for topic, count := range counts.Assignments[tag] {
+			row.Topics = append(row.Topics, services.TagTopicCount{Topic: topic, FeedbackCount: count})
+		}
+		sort.Slice(
+			row.Topics, func(i, j int) bool {
+				return topicLess(row.Topics[i].FeedbackCount, row.Topics[j].FeedbackCount, row.Topics[i].Topic, row.Topics[j].Topic)
+			},
+		)
+
+		if len(row.Topics) > 0 {
+			dominant := row.Topics[0]
+			row.DominantTopic = dominant.Topic
+			row.AgreementRate = float64(dominant.FeedbackCount) / float64(feedbackCount)
+			totalAgreeing += dominant.FeedbackCount
+		}
+		totalFeedbacks += feedbackCount
+
+		agreement.Tags = append(agreement.Tags, row)
+	}
+
+	sort.Slice(
+		agreement.Tags, func(i, j int) bool {
+			if agreement.Tags[i].FeedbackCount != agreement.Tags[j].FeedbackCount {
+				return agreement.Tags[i].FeedbackCount > agreement.Tags[j].FeedbackCount
+			}
+			return agreement.Tags[i].Tag < agreement.Tags[j].Tag
+		},
+	)
+
+	if totalFeedbacks > 0 {
+		agreement.AgreementRate = float64(totalAgreeing) / float64(totalFeedbacks)
+	}
+
+	return agreement
+}
@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+)
+
+func TestBuildTagTopicAgreement(t *testing.T) {
+	counts := &apprepo.TagTopicCounts{
+		TaggedFeedbacks: map[feedback.Tag]int{"bug": 10, "pricing": 4, "misc": 2},
+		Assignments: map[feedback.Tag]map[analysis.Topic]int{
+			"bug": {
+				analysis.TopicPerformanceReliability: 8,
+				analysis.TopicUIUX:                   3,
+			},
+			"pricing": {
+				analysis.TopicPricingLicensing: 2,
+				analysis.TopicUIUX:             2,
+			},
+		},
+	}
+
+	agreement := buildTagTopicAgreement(counts)
+
+	if len(agreement.Tags) != 3 {
+		t.Fatalf("Expected 3 tags, got %d", len(agreement.Tags))
+	}
+	for i, expected := range []feedback.Tag{"bug", "pricing", "misc"} {
+		if agreement.Tags[i].Tag != expected {
+			t.Errorf("Expected tag %d to be %q, got %q", i, expected, agreement.Tags[i].Tag)
+		}
+	}
+
+	bug := agreement.Tags[0]
+	if bug.DominantTopic != analysis.TopicPerformanceReliability || bug.AgreementRate != 0.8 {
+		t.Errorf("Expected bug to agree on performance_reliability at 0.8, got %s at %v", bug.DominantTopic, bug.AgreementRate)
+	}
+	if len(bug.Topics) != 2 || bug.Topics[0].Topic != analysis.TopicPerformanceReliability {
+		t.Errorf("Expected bug topics ordered by count, got %+v", bug.Topics)
+	}
+
+	// Ties are broken by topic enum
+	if pricing := agreement.Tags[1]; pricing.DominantTopic != analysis.TopicPricingLicensing {
+		t.Errorf("Expected pricing tie to resolve to pricing_licensing, got %s", pricing.DominantTopic)
+	}
+
+	misc := agreement.Tags[2]
+	if misc.DominantTopic != "" || misc.AgreementRate != 0 || len(misc.Topics) != 0 {
+		t.Errorf("Expected misc without assigned topics to have no agreement, got %+v", misc)
+	}
+
+	// (8 + 2 + 0) agreeing out of (10 + 4 + 2) tagged feedbacks
+	if expected := 10.0 / 16.0; math.Abs(agreement.AgreementRate-expected) > 1e-9 {
+		t.Errorf("Expected overall agreement rate %v, got %v", expected, agreement.AgreementRate)
+	}
+}
+
+func TestBuildTagTopicAgreement_Empty(t *testing.T) {
+	agreement := buildTagTopicAgreement(&apprepo.TagTopicCounts{})
+
+	if len(agreement.Tags) != 0 || agreement.AgreementRate != 0 {
+		t.Errorf("Expected empty agreement, got %+v", agreement)
+	}
+}
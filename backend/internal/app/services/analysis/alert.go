@@ -0,0 +1,158 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+// alertSentimentDecline publishes an EventSentimentDeclined event if the overall sentiment of the analysis turned
+// negative compared to the previous representative analysis, or, with SentimentAlertRatingDrop set, if the average
+// rating dropped by at least that much. Only successful, representative analyses are compared, so that a
+// single-feedback analysis neither raises an alert on its own nor suppresses the next one. Failures are only logged,
+// the analysis itself is already stored.
+func (a *analyzer) alertSentimentDecline(
+	ctx context.Context,
+	current *analysis.Analysis,
+	previous *analysis.Analysis,
+	feedbacks []*feedback.Feedback,
+	topics []external.Topic,
+	logger tracelog.TraceLogger,
+) {
+	if a.events == nil || previous == nil || previous.Status() != analysis.StatusSuccess {
+		return
+	}
+	if !current.IsRepresentative() || !previous.IsRepresentative() {
+		return
+	}
+
+	var reasons []string
+	if previous.Sentiment() != analysis.SentimentNegative && current.Sentiment() == analysis.SentimentNegative {
+		reasons = append(reasons, external.DeclineReasonSentimentNegative)
+	}
+
+	rating := averageRating(feedbacks)
+	var previousRating optional.Optional[float64]
+	if a.cfg.SentimentAlertRatingDrop > 0 {
+		prevRating, err := a.analysisAverageRating(ctx, previous.ID())
+		if err != nil {
+			logger.Warning(
+				"failed to get average rating of previous analysis, comparing sentiment only",
+				"previous_analysis_id",
+				previous.ID().String(),
+				"error",
+				err.Error(),
+			)
+		} else {
+			previousRating = optional.Some(prevRating)
+			if prevRating-rating >= a.cfg.SentimentAlertRatingDrop {
+				reasons = append(reasons, external.DeclineReasonRatingDrop)
+			}
+		}
+	}
+
+	if len(reasons) == 0 {
+		return
+	}
+
+	previousTopics, err := a.analysisRepo.GetTopicsByAnalysisID(ctx, previous.ID())
+	if err != nil {
+		logger.Warning(
+			"failed to get topics of previous analysis, alerting without previous topic counts",
+			"previous_analysis_id",
+			previous.ID().String(),
+			"error",
+			err.Error(),
+		)
+		previousTopics = nil
+	}
+
+	a.events.Publish(
+		ctx, &external.Event{
+			ID:         uuid.New(),
+			Type:       external.EventSentimentDeclined,
+			OccurredAt: a.clock.Now().UTC(),
+			Data: external.SentimentDeclinedData{
+				AnalysisID:            current.ID(),
+				PreviousAnalysisID:    previous.ID(),
+				Reasons:               reasons,
+				Sentiment:             current.Sentiment(),
+				PreviousSentiment:     previous.Sentiment(),
+				AverageRating:         rating,
+				PreviousAverageRating: previousRating,
+				Topics:                decliningTopics(previousTopics, topics),
+			},
+		},
+	)
+	logger.Warning(
+		"sentiment declined compared to the previous analysis, alert published",
+		"analysis_id",
+		current.ID().String(),
+		"previous_analysis_id",
+		previous.ID().String(),
+		"reasons",
+		reasons,
+	)
+}
+
+// analysisAverageRating returns the average rating of the feedbacks analyzed in an analysis, deleted ones included.
+func (a *analyzer) analysisAverageRating(ctx context.Context, analysisID uuid.UUID) (float64, error) {
+	feedbackIDs, err := a.analysisRepo.GetFeedbackIDsByAnalysisID(ctx, analysisID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get analyzed feedback IDs: %w", err)
+	}
+
+	feedbacks, err := a.feedbackRepo.GetByIDs(
+		ctx,
+		feedbackIDs,
+		apprepo.WithOptions(&apprepo.Options{IncludeDeleted: true}),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get analyzed feedbacks: %w", err)
+	}
+	return averageRating(feedbacks), nil
+}
+
+// decliningTopics returns the negative topics of an analysis, the ones with the most feedbacks first, together
+// with their feedback count and sentiment in the previous analysis.
+func decliningTopics(previousTopics []*analysis.TopicAnalysis, topics []external.Topic) []external.DecliningTopic {
+	previousCounts := analysis.TopicFeedbackCounts(previousTopics)
+	previousSentiments := make(map[analysis.Topic]analysis.Sentiment, len(previousTopics))
+	for _, topic := range previousTopics {
+		previousSentiments[topic.Topic()] = topic.Sentiment()
+	}
+
+	declining := make([]external.DecliningTopic, 0, len(topics))
+	for _, topic := range topics {
+		if topic.Sentiment != analysis.SentimentNegative {
+			continue
+		}
+		declining = append(
+			declining, external.DecliningTopic{
+				Topic:                 topic.Topic,
+				Summary:               topic.Summary,
+				FeedbackCount:         len(topic.FeedbackIDs),
+				PreviousFeedbackCount: previousCounts[topic.Topic],
+				PreviousSentiment:     previousSentiments[topic.Topic],
+			},
+		)
+	}
+
+	sort.SliceStable(
+		declining, func(i, j int) bool {
+			if declining[i].FeedbackCount != declining[j].FeedbackCount {
+				return declining[i].FeedbackCount > declining[j].FeedbackCount
+			}
+			return declining[i].Topic < declining[j].Topic
+		},
+	)
+	return declining
+}
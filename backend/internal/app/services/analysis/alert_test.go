@@ -0,0 +1,205 @@
+package analysis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+)
+
+// recordingPublisher records published events. Other methods are not used by the analyzer.
+type recordingPublisher struct {
+	services.EventPublisher
+	events []*external.Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event *external.Event) {
+	p.events = append(p.events, event)
+}
+
+// analyzedFeedbacksRepo returns the feedback IDs of the previous analysis, which has no topics.
+type analyzedFeedbacksRepo struct {
+	apprepo.AnalysisRepository
+	feedbackIDs []uuid.UUID
+}
+
+func (r *analyzedFeedbacksRepo) GetTopicsByAnalysisID(
+	context.Context,
+	uuid.UUID,
+	...repository.RepoOption[apprepo.Options],
+) ([]*analysis.TopicAnalysis, error) {
+	return nil, nil
+}
+
+func (r *analyzedFeedbacksRepo) GetFeedbackIDsByAnalysisID(
+	context.Context,
+	uuid.UUID,
+	...repository.RepoOption[apprepo.Options],
+) ([]uuid.UUID, error) {
+	return r.feedbackIDs, nil
+}
+
+// storedFeedbacksRepo returns fixed feedbacks by ID.
+type storedFeedbacksRepo struct {
+	apprepo.FeedbackRepository
+	feedbacks []*feedback.Feedback
+}
+
+func (r *storedFeedbacksRepo) GetByIDs(
+	context.Context,
+	[]uuid.UUID,
+	...repository.RepoOption[apprepo.Options],
+) ([]*feedback.Feedback, error) {
+	return r.feedbacks, nil
+}
+
+func alertTestAnalysis(sentiment analysis.Sentiment) *analysis.Analysis {
+	return analysis.NewBuilder().
+		WithID(uuid.New()).
+		WithSentiment(sentiment).
+		WithStatus(analysis.StatusSuccess).
+		BuildUnchecked()
+}
+
+func TestAnalyzer_AlertSentimentDecline(t *testing.T) {
+	previousFeedbacks := feedbacksWithRatings(t, 5, 4)
+	tests := []struct {
+		name        string
+		ratingDrop  float64
+		previous    analysis.Sentiment
+		current     analysis.Sentiment
+		ratings     []int
+		wantReasons []string
+	}{
+		{
+			name:        "turned negative",
+			previous:    analysis.SentimentMixed,
+			current:     analysis.SentimentNegative,
+			ratings:     []int{4},
+			wantReasons: []string{external.DeclineReasonSentimentNegative},
+		},
+		{
+			name:     "stayed negative",
+			previous: analysis.SentimentNegative,
+			current:  analysis.SentimentNegative,
+			ratings:  []int{1},
+		},
+		{
+			name:        "rating dropped",
+			ratingDrop:  1.5,
+			previous:    analysis.SentimentPositive,
+			current:     analysis.SentimentMixed,
+			ratings:     []int{3, 3},
+			wantReasons: []string{external.DeclineReasonRatingDrop},
+		},
+		{
+			name:       "rating dropped less than configured",
+			ratingDrop: 2,
+			previous:   analysis.SentimentPositive,
+			current:    analysis.SentimentMixed,
+			ratings:    []int{3, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				publisher := &recordingPublisher{}
+				a := &analyzer{
+					logger:       newTestLogger(t),
+					cfg:          &config.LLMAnalysis{SentimentAlertRatingDrop: tt.ratingDrop},
+					analysisRepo: &analyzedFeedbacksRepo{},
+					feedbackRepo: &storedFeedbacksRepo{feedbacks: previousFeedbacks},
+					events:       publisher,
+					clock:        clock.NewMock(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)),
+				}
+				previous := alertTestAnalysis(tt.previous)
+				current := alertTestAnalysis(tt.current)
+
+				a.alertSentimentDecline(
+					context.Background(), current, previous, feedbacksWithRatings(t, tt.ratings...), nil, a.logger,
+				)
+
+				if len(tt.wantReasons) == 0 {
+					if len(publisher.events) != 0 {
+						t.Fatalf("Expected no alert, got %+v", publisher.events[0].Data)
+					}
+					return
+				}
+				if len(publisher.events) != 1 || publisher.events[0].Type != external.EventSentimentDeclined {
+					t.Fatalf("Expected a single %s event, got %d events", external.EventSentimentDeclined, len(publisher.events))
+				}
+				data := publisher.events[0].Data.(external.SentimentDeclinedData)
+				if data.AnalysisID != current.ID() || data.PreviousAnalysisID != previous.ID() {
+					t.Errorf("Expected the alert to reference both analyses, got %+v", data)
+				}
+				if len(data.Reasons) != len(tt.wantReasons) || data.Reasons[0] != tt.wantReasons[0] {
+					t.Errorf("Expected reasons %v, got %v", tt.wantReasons, data.Reasons)
+				}
+			},
+		)
+	}
+}
+
+func TestAnalyzer_AlertSentimentDecline_SkipsUnrepresentative(t *testing.T) {
+	publisher := &recordingPublisher{}
+	a := &analyzer{logger: newTestLogger(t), cfg: &config.LLMAnalysis{}, events: publisher}
+	current := analysis.NewBuilder().
+		WithSentiment(analysis.SentimentNegative).
+		WithRepresentative(false).
+		BuildUnchecked()
+
+	a.alertSentimentDecline(
+		context.Background(), current, alertTestAnalysis(analysis.SentimentPositive), nil, nil, a.logger,
+	)
+
+	if len(publisher.events) != 0 {
+		t.Error("Expected no alert for an analysis that is not representative")
+	}
+}
+
+func TestDecliningTopics(t *testing.T) {
+	previousTopics := []*analysis.TopicAnalysis{
+		analysis.NewTopicAnalysisBuilder().
+			WithTopic(analysis.TopicPerformanceReliability).
+			WithSentiment(analysis.SentimentMixed).
+			WithFeedbackCount(1).
+			BuildUnchecked(),
+	}
+	topics := []external.Topic{
+		{Topic: analysis.TopicUIUX, Sentiment: analysis.SentimentNegative, FeedbackIDs: []uuid.UUID{uuid.New()}},
+		{
+			Topic:       analysis.TopicCustomerSupportCommunity,
+			Sentiment:   analysis.SentimentPositive,
+			FeedbackIDs: []uuid.UUID{uuid.New()},
+		},
+		{
+			Topic:       analysis.TopicPerformanceReliability,
+			Sentiment:   analysis.SentimentNegative,
+			FeedbackIDs: []uuid.UUID{uuid.New(), uuid.New(), uuid.New()},
+		},
+	}
+
+	declining := decliningTopics(previousTopics, topics)
+
+	if len(declining) != 2 {
+		t.Fatalf("Expected only the 2 negative topics, got %+v", declining)
+	}
+	first := declining[0]
+	if first.Topic != analysis.TopicPerformanceReliability || first.FeedbackCount != 3 ||
+		first.PreviousFeedbackCount != 1 || first.PreviousSentiment != analysis.SentimentMixed {
+		t.Errorf("Expected the topic with the most feedbacks first, compared to the previous analysis, got %+v", first)
+	}
+	if declining[1].Topic != analysis.TopicUIUX || declining[1].PreviousFeedbackCount != 0 {
+		t.Errorf("Expected the new negative topic second, got %+v", declining[1])
+	}
+}
@@ -0,0 +1,66 @@
+package analysis
+
+import (
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+)
+
+const (
+	// lowRatingThreshold and highRatingThreshold split the normalized rating scale (0..1) into
+	// low, neutral and high bands. On the default 1-5 scale this maps 1-2 to low and 4-5 to high.
+	lowRatingThreshold  = 0.4
+	highRatingThreshold = 0.6
+
+	// dominantShare is the fraction of low or high ratings needed to imply a positive or negative sentiment.
+	dominantShare = 0.6
+)
+
+// computeSentimentAlignment compares the topic sentiment reported by the LLM with the sentiment
+// implied by the ratings of its feedbacks.
+func computeSentimentAlignment(
+	sentiment analysis.Sentiment,
+	feedbacks []*feedback.Feedback,
+	scale feedback.RatingScale,
+) services.SentimentAlignment {
+	if len(feedbacks) == 0 || scale.Max <= scale.Min {
+		return services.SentimentAlignment{Status: services.AlignmentUnknown}
+	}
+
+	low, high := 0, 0
+	for _, fb := range feedbacks {
+		normalized := float64(fb.Rating().Value()-scale.Min) / float64(scale.Max-scale.Min)
+		switch {
+		case normalized < lowRatingThreshold:
+			low++
+		case normalized > highRatingThreshold:
+			high++
+		}
+	}
+
+	total := float64(len(feedbacks))
+	alignment := services.SentimentAlignment{
+		LowRatingShare:  float64(low) / total,
+		HighRatingShare: float64(high) / total,
+	}
+
+	switch {
+	case alignment.HighRatingShare >= dominantShare:
+		alignment.ImpliedSentiment = analysis.SentimentPositive
+	case alignment.LowRatingShare >= dominantShare:
+		alignment.ImpliedSentiment = analysis.SentimentNegative
+	default:
+		alignment.ImpliedSentiment = analysis.SentimentMixed
+	}
+
+	switch {
+	case sentiment == alignment.ImpliedSentiment:
+		alignment.Status = services.AlignmentAligned
+	case sentiment == analysis.SentimentMixed || alignment.ImpliedSentiment == analysis.SentimentMixed:
+		alignment.Status = services.AlignmentDivergent
+	default:
+		alignment.Status = services.AlignmentMismatched
+	}
+
+	return alignment
+}
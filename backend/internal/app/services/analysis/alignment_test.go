@@ -0,0 +1,60 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+)
+
+func feedbacksWithRatings(t *testing.T, ratings ...int) []*feedback.Feedback {
+	t.Helper()
+	feedbacks := make([]*feedback.Feedback, 0, len(ratings))
+	for _, rating := range ratings {
+		fb, err := feedback.NewBuilder().BuildNew(uuid.New(), rating, "some feedback comment")
+		if err != nil {
+			t.Fatalf("Failed to build feedback: %v", err)
+		}
+		feedbacks = append(feedbacks, fb)
+	}
+	return feedbacks
+}
+
+func TestComputeSentimentAlignment(t *testing.T) {
+	tests := []struct {
+		name      string
+		sentiment analysis.Sentiment
+		ratings   []int
+		status    services.AlignmentStatus
+		implied   analysis.Sentiment
+	}{
+		{"positive with high ratings", analysis.SentimentPositive, []int{5, 4, 5, 3}, services.AlignmentAligned, analysis.SentimentPositive},
+		{"positive with low ratings", analysis.SentimentPositive, []int{2, 1, 2, 5}, services.AlignmentMismatched, analysis.SentimentNegative},
+		{"negative with high ratings", analysis.SentimentNegative, []int{4, 5, 5}, services.AlignmentMismatched, analysis.SentimentPositive},
+		{"mixed with spread ratings", analysis.SentimentMixed, []int{1, 3, 5}, services.AlignmentAligned, analysis.SentimentMixed},
+		{"mixed with high ratings", analysis.SentimentMixed, []int{4, 5}, services.AlignmentDivergent, analysis.SentimentPositive},
+		{"negative with spread ratings", analysis.SentimentNegative, []int{1, 3, 5}, services.AlignmentDivergent, analysis.SentimentMixed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeSentimentAlignment(tt.sentiment, feedbacksWithRatings(t, tt.ratings...), feedback.DefaultRatingScale)
+			if got.Status != tt.status {
+				t.Errorf("Expected status %s, got %s", tt.status, got.Status)
+			}
+			if got.ImpliedSentiment != tt.implied {
+				t.Errorf("Expected implied sentiment %s, got %s", tt.implied, got.ImpliedSentiment)
+			}
+		})
+	}
+}
+
+func TestComputeSentimentAlignment_NoFeedbacks(t *testing.T) {
+	got := computeSentimentAlignment(analysis.SentimentPositive, nil, feedback.DefaultRatingScale)
+	if got.Status != services.AlignmentUnknown {
+		t.Errorf("Expected status %s, got %s", services.AlignmentUnknown, got.Status)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,21 +14,57 @@ import (
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/shared"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
+	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+	"github.com/robfig/cron/v3"
 )
 
 const (
 	defaultBufferSize    = 100
 	defaultCheckInterval = 2 * time.Second
+
+	// analysisLockKey identifies the advisory lock that serializes analyses across replicas.
+	analysisLockKey int64 = 0x6c6c6d5f616e616c // "llm_anal"
+
+	// Pending queue overflow policies, see config.LLMAnalysis.QueueOverflowPolicy.
+	queueOverflowDropOldest = "drop_oldest"
+	queueOverflowRejectNew  = "reject_new"
+
+	// Feedback selection orders, see config.LLMAnalysis.FeedbackSelectionOrder.
+	selectionOrderNewestFirst       = "newest_first"
+	selectionOrderLowestRatingFirst = "lowest_rating_first"
 )
 
+// ErrNoFeedbacks is returned when an analysis is requested for an empty feedback batch.
+// No analysis record is created in this case.
+var ErrNoFeedbacks ce.ApplicationError = &ce.GenericError{
+	Code:       ce.NewDomainErrorCode("no_feedbacks", ce.CategoryValidation),
+	Message:    "No feedbacks to analyze",
+	UserFacing: true,
+}
+
+// ErrAnalyzerStopped is returned when an analysis is requested while the analyzer is stopping.
+var ErrAnalyzerStopped ce.ApplicationError = &ce.GenericError{
+	Code:       ce.NewDomainErrorCode("analyzer_stopped", ce.CategoryConflict),
+	Message:    "The analyzer is shutting down, no analysis can be started",
+	UserFacing: true,
+}
+
 type analyzer struct {
 	logger       tracelog.TraceLogger
 	cfg          *config.LLMAnalysis
 	analysisRepo apprepo.AnalysisRepository
 	feedbackRepo apprepo.FeedbackRepository
 	llmClient    external.LLMClient
+	circuit      external.CircuitStateProvider // Circuit breaker of llmClient, nil if it has none
+	events       services.EventPublisher       // Publishes sentiment alerts, nil disables them
+	clock        clock.Clock
+	locker       repository.Locker // Distributed analysis lock, used if enabled in the configuration
 
 	// Channel for receiving feedbacks (buffered to avoid blocking)
 	feedbackChan chan *feedback.Feedback
@@ -40,10 +77,37 @@ type analyzer struct {
 	lastAnalysisTime  time.Time
 	lastAnalysisMutex sync.Mutex
 
+	// Last time the pending queue was drained by any trigger, for the interval trigger
+	lastTriggerTime  time.Time
+	lastTriggerMutex sync.Mutex
+
+	// Cron scheduler for time-based analyses, nil if no schedule is configured
+	scheduler *cron.Cron
+
+	// Number of analyses currently being performed, reported if shutdown times out
+	running atomic.Int64
+
+	// Number of feedbacks dropped because the pending queue was full
+	dropped atomic.Int64
+
+	// Set once Start completed its initial setup, cleared again on Stop
+	ready atomic.Bool
+
+	// Set while automatic analyses are skipped because the LLM circuit breaker is open
+	circuitSkipping atomic.Bool
+
+	// Set while an immediate single-feedback analysis is running
+	immediateRunning atomic.Bool
+
+	// Per-feedback token estimates, reused across selection ticks
+	tokenCache *feedbackTokenCache
+
 	// Context and cancellation
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
+	// Held while cancelling and while a run registers with wg, so that no run starts after Stop began waiting
+	stopMutex sync.Mutex
 }
 
 // NewAnalyzerService creates a new analyzer service. Rate limited by the configuration, for example
@@ -54,6 +118,10 @@ func NewAnalyzerService(
 	analysisRepo apprepo.AnalysisRepository,
 	feedbackRepo apprepo.FeedbackRepository,
 	llmClient external.LLMClient,
+	circuit external.CircuitStateProvider,
+	events services.EventPublisher,
+	locker repository.Locker,
+	clk clock.Clock,
 ) services.AnalyzerService {
 	// Buffered channel to avoid blocking feedback creation
 	// Buffer size should be large enough to handle bursts
@@ -62,14 +130,23 @@ func NewAnalyzerService(
 		bufferSize = defaultBufferSize
 	}
 
+	if clk == nil {
+		clk = clock.New()
+	}
+
 	return &analyzer{
 		logger:           logger.NewGroup("llm_analyzer"),
 		cfg:              cfg,
 		analysisRepo:     analysisRepo,
 		feedbackRepo:     feedbackRepo,
 		llmClient:        llmClient,
+		circuit:          circuit,
+		events:           events,
+		locker:           locker,
+		clock:            clk,
 		feedbackChan:     make(chan *feedback.Feedback, bufferSize),
 		pendingFeedbacks: make([]*feedback.Feedback, 0, bufferSize),
+		tokenCache:       newFeedbackTokenCache(),
 	}
 }
 
@@ -90,22 +167,59 @@ func (a *analyzer) EnqueueFeedback(ctx context.Context, fb *feedback.Feedback) {
 // Start starts the analyzer service in a background goroutine.
 func (a *analyzer) Start(ctx context.Context) error {
 	a.logger.Info("starting LLM analyzer service")
+	if a.cfg.OnDemandOnly {
+		a.logger.Info(
+			"analyzer running in on-demand mode: automatic analysis is disabled, " +
+				"feedbacks are queued until an analysis is triggered manually",
+		)
+	}
 
 	// Create a cancellable context from the provided context
 	a.ctx, a.cancel = context.WithCancel(ctx)
 
+	a.failStaleAnalyses(a.ctx)
+
+	// The analysis interval is measured from startup until the queue is first drained
+	a.markTriggered()
+
 	a.wg.Add(1)
 	go a.run(a.ctx)
 
+	if err := a.startScheduler(); err != nil {
+		a.cancel()
+		return err
+	}
+
+	a.ready.Store(true)
+	a.logger.Info("LLM analyzer service ready")
 	return nil
 }
 
+// IsReady reports whether the analyzer completed its initial setup and is not stopping.
+func (a *analyzer) IsReady() bool {
+	return a.ready.Load()
+}
+
+// LLMCircuitState returns the state of the circuit breaker of the LLM client, closed if it has none.
+func (a *analyzer) LLMCircuitState() external.CircuitState {
+	if a.circuit == nil {
+		return external.CircuitClosed
+	}
+	return a.circuit.CircuitState()
+}
+
 // run is the main loop that processes feedbacks and triggers analysis.
 func (a *analyzer) run(ctx context.Context) {
 	defer a.wg.Done()
 
-	ticker := time.NewTicker(defaultCheckInterval)
-	defer ticker.Stop()
+	// In on-demand mode the tick channel stays nil, so feedbacks are only queued
+	// and analyses run exclusively through TriggerAnalysis.
+	var tick <-chan time.Time
+	if !a.cfg.OnDemandOnly {
+		ticker := time.NewTicker(defaultCheckInterval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
 
 	for {
 		select {
@@ -114,58 +228,138 @@ func (a *analyzer) run(ctx context.Context) {
 			return
 		case fb := <-a.feedbackChan:
 			a.addFeedbackToQueue(fb)
-		case <-ticker.C:
+		case <-tick:
 			a.checkAndAnalyze(ctx)
 		}
 	}
 }
 
-// performAnalysis performs the actual LLM analysis.
-func (a *analyzer) performAnalysis(ctx context.Context, feedbacks []*feedback.Feedback) {
-	defer a.wg.Done()
+// performAnalysis performs the actual LLM analysis over the given feedbacks and returns the persisted analysis.
+// The returned analysis is nil only when the analysis record could not be created.
+func (a *analyzer) performAnalysis(ctx context.Context, feedbacks []*feedback.Feedback) (*analysis.Analysis, error) {
+	// An analysis must cover at least one feedback, so reject empty batches before anything is persisted
+	if len(feedbacks) == 0 {
+		return nil, ErrNoFeedbacks
+	}
+
+	a.running.Add(1)
+	defer a.running.Add(-1)
 
 	logger := a.logger.WithSpan(ctx)
+	ctx, spanLogger, span := logger.StartSpan(ctx, "analyzer.perform_analysis")
+	defer span.End()
+
+	span.SetAttributes(
+		trace.Attribute{Key: "feedback_count", Value: len(feedbacks)},
+		trace.Attribute{Key: "average_rating", Value: averageRating(feedbacks)},
+		trace.Attribute{Key: "model", Value: a.cfg.OpenAIModel},
+	)
+
+	result, topicsCount, err := a.runAnalysis(ctx, feedbacks, spanLogger)
+	if result != nil {
+		span.SetAttributes(
+			trace.Attribute{Key: "analysis_id", Value: result.ID().String()},
+			trace.Attribute{Key: "token_count", Value: result.Tokens()},
+			trace.Attribute{Key: "topics_count", Value: topicsCount},
+		)
+	}
+	if err != nil {
+		span.SetStatus(trace.StatusError, err.Error())
+		return result, err
+	}
+
+	span.SetStatus(trace.StatusOK, "Successfully performed analysis")
+	return result, nil
+}
+
+// averageRating returns the mean rating of the given feedbacks, or 0 for an empty set.
+func averageRating(feedbacks []*feedback.Feedback) float64 {
+	if len(feedbacks) == 0 {
+		return 0
+	}
+
+	total := 0
+	for _, fb := range feedbacks {
+		total += fb.Rating().Value()
+	}
+	return float64(total) / float64(len(feedbacks))
+}
+
+// runAnalysis creates the analysis record, calls the LLM and persists its results.
+// It also returns the number of topics the LLM produced.
+func (a *analyzer) runAnalysis(
+	ctx context.Context,
+	feedbacks []*feedback.Feedback,
+	logger tracelog.TraceLogger,
+) (*analysis.Analysis, int, error) {
 	logger.Info("starting analysis", "feedback_count", len(feedbacks))
 
-	// Get the latest analysis for incremental updates
-	previousAnalysis, err := a.analysisRepo.GetLatest(ctx)
+	// Get the latest representative analysis for incremental updates, so that a single-feedback analysis does not
+	// become the baseline of the next batch. Loaded before the new analysis is stored, so that it cannot be its own
+	// previous analysis
+	previousAnalysis, err := a.analysisRepo.GetLatestRepresentative(ctx)
 	if err != nil {
 		logger.Info("no previous analysis found, starting fresh")
 	}
 
-	// Determine period
-	periodStart := time.Now().UTC()
-	periodEnd := time.Now().UTC()
-	if len(feedbacks) > 0 {
-		// Use the earliest feedback creation time as period start
-		periodStart = feedbacks[0].CreatedAt()
-		for _, fb := range feedbacks {
-			if fb.CreatedAt().Before(periodStart) {
-				periodStart = fb.CreatedAt()
-			}
-			if fb.CreatedAt().After(periodEnd) {
-				periodEnd = fb.CreatedAt()
-			}
+	// Get the previous topic breakdown for topic continuity
+	var previousTopics []*analysis.TopicAnalysis
+	previousTopicsLoaded := previousAnalysis != nil
+	if previousAnalysis != nil {
+		previousTopics, err = a.analysisRepo.GetTopicsByAnalysisID(ctx, previousAnalysis.ID())
+		if err != nil {
+			logger.Warning(
+				"failed to get previous analysis topics, continuing without them",
+				"previous_analysis_id",
+				previousAnalysis.ID().String(),
+				"error",
+				err.Error(),
+			)
+			previousTopics = nil
+			previousTopicsLoaded = false
 		}
 	}
 
+	periodSemantics, periodStart, periodEnd := a.analysisPeriod(feedbacks)
+
 	// Collect feedback IDs
 	feedbackIDs := make([]uuid.UUID, len(feedbacks))
 	for i, fb := range feedbacks {
 		feedbackIDs[i] = fb.ID()
 	}
 
+	// Optionally send each distinct comment to the LLM only once, so that copy-pasted or
+	// templated feedback does not inflate topic counts. All feedbacks are still recorded.
+	llmFeedbacks := feedbacks
+	if a.cfg.DeduplicateComments {
+		var multiplicity map[uuid.UUID]int
+		llmFeedbacks, multiplicity = deduplicateFeedbacks(feedbacks)
+		for representativeID, count := range multiplicity {
+			logger.Info(
+				"duplicate comments collapsed",
+				"representative_feedback_id",
+				representativeID.String(),
+				"multiplicity",
+				count,
+			)
+		}
+	}
+	deduplicatedCount := len(feedbacks) - len(llmFeedbacks)
+
 	// Create analysis record with status 'processing'
-	// Provide placeholder values for required fields that will be updated after LLM analysis
-	analysisBuilder := analysis.NewBuilder().
+	// The summary stays unset, the other required fields get placeholders until the LLM result is stored
+	analysisBuilder := analysis.NewBuilder(shared.WithClock(a.clock)).
 		WithPeriod(periodStart, periodEnd).
+		WithPeriodSemantics(periodSemantics).
 		WithFeedbackCount(len(feedbacks)).
-		WithOverallSummary("Processing...").
 		WithSentiment(analysis.SentimentMixed).
 		WithKeyInsights([]string{}).
 		WithModel(a.cfg.OpenAIModel).
+		WithProvider(a.cfg.ProviderName()).
 		WithTokens(0).
 		WithAnalysisDurationMs(0).
+		WithDeduplicatedCount(deduplicatedCount).
+		WithRepresentative(a.cfg.IsRepresentative(len(feedbacks)) && !isImmediateAnalysis(ctx)).
 		WithStatus(analysis.StatusProcessing)
 
 	if previousAnalysis != nil {
@@ -176,20 +370,32 @@ func (a *analyzer) performAnalysis(ctx context.Context, feedbacks []*feedback.Fe
 
 	analysisEntity, err := analysisBuilder.Build()
 	if err != nil {
-		logger.RecordSpanError(ctx, fmt.Errorf("failed to build analysis: %w", err))
-		return
+		analysisErr := fmt.Errorf("failed to build analysis: %w", err)
+		logger.RecordSpanError(ctx, analysisErr)
+		return nil, 0, analysisErr
 	}
 
 	if err := a.analysisRepo.Create(ctx, analysisEntity); err != nil {
-		logger.RecordSpanError(ctx, fmt.Errorf("failed to create analysis record: %w", err))
-		return
+		analysisErr := fmt.Errorf("failed to create analysis record: %w", err)
+		logger.RecordSpanError(ctx, analysisErr)
+		return nil, 0, analysisErr
 	}
 	logger.Info("analysis record created", "analysis_id", analysisEntity.ID().String())
+	a.recordEvent(
+		ctx, analysisEntity.ID(), analysis.EventCreated, optional.None[time.Duration](), map[string]any{
+			"feedback_count":     len(feedbacks),
+			"deduplicated_count": deduplicatedCount,
+		}, logger,
+	)
 
 	// Create analyzed feedback records (junction table)
 	if err := a.analysisRepo.CreateAnalyzedFeedbacks(ctx, analysisEntity.ID(), feedbackIDs); err != nil {
-		logger.RecordSpanError(ctx, fmt.Errorf("failed to create analyzed feedback records: %w", err))
-		return
+		analysisErr := fmt.Errorf("failed to create analyzed feedback records: %w", err)
+		logger.RecordSpanError(ctx, analysisErr)
+		if failErr := a.failAnalysis(ctx, analysisEntity, analysisErr, logger); failErr != nil {
+			logger.RecordSpanError(ctx, failErr)
+		}
+		return analysisEntity, 0, analysisErr
 	}
 	logger.Info(
 		"analyzed feedback records created",
@@ -199,33 +405,69 @@ func (a *analyzer) performAnalysis(ctx context.Context, feedbacks []*feedback.Fe
 		len(feedbackIDs),
 	)
 
-	// Call LLM client
-	startTime := time.Now()
+	// Enrich sparse batches with previously analyzed feedbacks, sent as context only
+	contextFeedbacks := a.historicalContext(ctx, feedbacks, previousAnalysis, logger)
+
+	// With chunked analysis, a batch exceeding the limits of a single request is sent in consecutive chunks
+	var chunks [][]*feedback.Feedback
+	if a.cfg.EnableChunkedAnalysis {
+		chunks = a.chunkFeedbacks(llmFeedbacks, previousAnalysis)
+	}
+
+	estimatedTokens := a.estimateRequestTokens(llmFeedbacks, previousAnalysis, contextFeedbacks)
+	if len(chunks) > 1 {
+		// Every chunk request repeats the system prompt and the previous analysis
+		estimatedTokens = a.estimateRequestTokens(chunks[0], previousAnalysis, contextFeedbacks)
+		for _, chunk := range chunks[1:] {
+			estimatedTokens += a.estimateRequestTokens(chunk, previousAnalysis, nil)
+		}
+	}
+
+	// Call LLM client, the requests carry the analysis ID for auditing
+	ctx = external.ContextWithAnalysisID(ctx, analysisEntity.ID())
+	a.recordEvent(
+		ctx, analysisEntity.ID(), analysis.EventLLMCalled, optional.None[time.Duration](), map[string]any{
+			"feedback_count":   len(llmFeedbacks),
+			"context_count":    len(contextFeedbacks),
+			"chunk_count":      max(len(chunks), 1),
+			"estimated_tokens": estimatedTokens,
+		}, logger,
+	)
+	startTime := a.clock.Now()
 	var llmResult *external.AnalysisResult
-	if a.llmClient != nil {
-		llmResult, err = a.llmClient.AnalyzeFeedbacks(ctx, feedbacks, previousAnalysis)
-	} else {
+	switch {
+	case a.llmClient != nil && len(chunks) > 1:
+		logger.Info("analyzing feedbacks in chunks", "chunk_count", len(chunks))
+		llmResult, err = a.analyzeChunks(ctx, chunks, previousAnalysis, previousTopics, contextFeedbacks, logger)
+	case a.llmClient != nil:
+		llmResult, err = a.llmClient.AnalyzeFeedbacks(
+			ctx,
+			llmFeedbacks,
+			previousAnalysis,
+			previousTopics,
+			contextFeedbacks,
+		)
+	default:
 		// Stub implementation - return error for now
 		err = fmt.Errorf("LLM client not implemented yet")
 	}
-	duration := time.Since(startTime)
+	duration := a.clock.Since(startTime)
+	a.recordEvent(
+		ctx, analysisEntity.ID(), analysis.EventLLMReturned, optional.Some(duration),
+		llmEventDetails(llmResult, err), logger,
+	)
+
+	a.storeRawOutput(ctx, analysisEntity.ID(), llmResult, err, logger)
+	a.recordTokenUsage(ctx, analysisEntity.ID(), estimatedTokens, llmResult, err, logger)
 
 	if err != nil {
-		if err := analysisEntity.MarkFailed(err.Error()); err != nil {
-			logger.RecordSpanError(ctx, fmt.Errorf("failed to mark analysis as failed: %w", err))
-			return
-		}
-		if updateErr := a.analysisRepo.Update(
-			ctx, analysisEntity.ID(), &analysis.UpdatableFields{
-				FailureReason: analysisEntity.FailureReason(),
-				Status:        analysis.StatusFailed,
-				CompletedAt:   analysisEntity.CompletedAt().Unwrap(),
-			},
-		); updateErr != nil {
-			logger.RecordSpanError(ctx, fmt.Errorf("failed to update analysis with failure: %w", updateErr))
+		if failErr := a.failAnalysis(ctx, analysisEntity, err, logger); failErr != nil {
+			logger.RecordSpanError(ctx, failErr)
+			return analysisEntity, 0, failErr
 		}
-		logger.RecordSpanError(ctx, fmt.Errorf("LLM analysis failed: %w", err))
-		return
+		analysisErr := fmt.Errorf("LLM analysis failed: %w", err)
+		logger.RecordSpanError(ctx, analysisErr)
+		return analysisEntity, 0, analysisErr
 	}
 
 	// Log topics received from LLM
@@ -252,53 +494,73 @@ func (a *analyzer) performAnalysis(ctx context.Context, feedbacks []*feedback.Fe
 		logger.Info("No topics returned from LLM")
 	}
 
-	// Use topics directly from LLM result (already converted)
-	topics := llmResult.Topics
+	// Use topics directly from LLM result (already converted), without the ones below the confidence threshold
+	topics := a.confidentTopics(llmResult.Topics, logger)
+	topics = a.reconcileSentiments(topics, feedbacks, logger)
 	logger.Info("topics array prepared", "topics_count", len(topics))
 
-	// Update analysis with results
-	if err := analysisEntity.MarkSuccess(); err != nil {
-		logger.RecordSpanError(ctx, fmt.Errorf("failed to mark analysis as success: %w", err))
-		return
-	}
-	logger.Info("analysis marked as success")
+	// An empty topic list is a valid model outcome, so record it explicitly to tell it
+	// apart from an analysis whose topic creation failed.
+	noTopicsIdentified := len(topics) == 0
 
-	updateBuilder := analysis.BuilderFromExisting(analysisEntity).
+	// Update analysis with results. Marked on the updated copy, so that the stored analysis can still be failed
+	updateBuilder := analysis.BuilderFromExisting(analysisEntity, shared.WithClock(a.clock)).
 		WithOverallSummary(llmResult.OverallSummary).
 		WithSentiment(llmResult.Sentiment).
 		WithKeyInsights(llmResult.KeyInsights).
 		WithTokens(llmResult.TokensUsed).
-		WithAnalysisDurationMs(int(duration.Milliseconds()))
+		WithAnalysisDurationMs(int(duration.Milliseconds())).
+		WithNoTopicsIdentified(noTopicsIdentified)
 
 	updatedAnalysis, err := updateBuilder.Build()
 	if err != nil {
-		logger.RecordSpanError(ctx, fmt.Errorf("failed to build updated analysis: %w", err))
-		return
+		analysisErr := fmt.Errorf("failed to build updated analysis: %w", err)
+		logger.RecordSpanError(ctx, analysisErr)
+		if failErr := a.failAnalysis(ctx, analysisEntity, analysisErr, logger); failErr != nil {
+			logger.RecordSpanError(ctx, failErr)
+		}
+		return analysisEntity, len(topics), analysisErr
 	}
 	logger.Info("updated analysis built successfully")
 
+	if err := updatedAnalysis.MarkSuccess(); err != nil {
+		analysisErr := fmt.Errorf("failed to mark analysis as success: %w", err)
+		logger.RecordSpanError(ctx, analysisErr)
+		if failErr := a.failAnalysis(ctx, analysisEntity, analysisErr, logger); failErr != nil {
+			logger.RecordSpanError(ctx, failErr)
+		}
+		return analysisEntity, len(topics), analysisErr
+	}
+	logger.Info("analysis marked as success")
+
 	logger.Info("updating analysis in database", "analysis_id", analysisEntity.ID().String())
 	if err := a.analysisRepo.Update(
 		ctx, analysisEntity.ID(), &analysis.UpdatableFields{
 			Results: optional.Some(
 				&analysis.UpdatedResults{
-					OverallSummary: updatedAnalysis.OverallSummary(),
-					Sentiment:      updatedAnalysis.Sentiment(),
-					KeyInsights:    updatedAnalysis.KeyInsights(),
-					Tokens:         updatedAnalysis.Tokens(),
+					OverallSummary:     updatedAnalysis.OverallSummary().Unwrap(),
+					Sentiment:          updatedAnalysis.Sentiment(),
+					KeyInsights:        updatedAnalysis.KeyInsights(),
+					Tokens:             updatedAnalysis.Tokens(),
+					NoTopicsIdentified: updatedAnalysis.NoTopicsIdentified(),
 				},
 			),
 			Status:      analysis.StatusSuccess,
 			CompletedAt: updatedAnalysis.CompletedAt().Unwrap(),
 		},
 	); err != nil {
-		logger.RecordSpanError(ctx, fmt.Errorf("failed to update analysis with results: %w", err))
-		return
+		analysisErr := fmt.Errorf("failed to update analysis with results: %w", err)
+		logger.RecordSpanError(ctx, analysisErr)
+		if failErr := a.failAnalysis(ctx, analysisEntity, analysisErr, logger); failErr != nil {
+			logger.RecordSpanError(ctx, failErr)
+		}
+		return analysisEntity, len(topics), analysisErr
 	}
 	logger.Info("analysis updated in database successfully")
 
 	// Create topics and their assignments
 	logger.Info("creating topics", "topics_count", len(topics), "analysis_id", analysisEntity.ID().String())
+	topicsStart := a.clock.Now()
 	if err := a.createTopics(ctx, analysisEntity.ID(), topics, logger); err != nil {
 		logger.Error(
 			"failed to create topics",
@@ -312,16 +574,56 @@ func (a *analyzer) performAnalysis(ctx context.Context, feedbacks []*feedback.Fe
 		// Don't return - analysis is already marked as success, topics are supplementary
 	} else {
 		logger.Info("topics creation completed successfully", "topics_count", len(topics))
+		a.recordEvent(
+			ctx, analysisEntity.ID(), analysis.EventTopicsCreated, optional.Some(a.clock.Since(topicsStart)),
+			map[string]any{"topics_count": len(topics)}, logger,
+		)
 	}
 
+	// Precompute the trend signal against the previous analysis, so the history does not need to diff analyses
+	if previousTopicsLoaded {
+		a.storeTopicDeltas(ctx, analysisEntity.ID(), previousTopics, topics, logger)
+	}
+
+	// Materialize the topic statistics, so that reading them does not go through every assigned feedback
+	a.refreshTopicStats(ctx, analysisEntity.ID(), logger)
+
+	a.alertSentimentDecline(ctx, updatedAnalysis, previousAnalysis, feedbacks, topics, logger)
+
 	logger.Info("analysis completed successfully", "analysis_id", updatedAnalysis.ID().String())
+	a.recordEvent(
+		ctx, analysisEntity.ID(), analysis.EventCompleted, a.sinceCreated(analysisEntity),
+		map[string]any{"tokens": llmResult.TokensUsed, "topics_count": len(topics)}, logger,
+	)
 
 	// Note: Pending feedbacks are already managed in checkAndAnalyze
 	// We only clear the ones that were selected for analysis, which is already done there
 
-	a.lastAnalysisMutex.Lock()
-	a.lastAnalysisTime = time.Now()
-	a.lastAnalysisMutex.Unlock()
+	return updatedAnalysis, len(topics), nil
+}
+
+// confidentTopics returns the topics the model is at least MinTopicConfidence confident about, keeping their
+// order. Topics without a confidence are kept, and all topics are returned if no threshold is configured.
+func (a *analyzer) confidentTopics(topics []external.Topic, logger tracelog.TraceLogger) []external.Topic {
+	if a.cfg.MinTopicConfidence <= 0 {
+		return topics
+	}
+
+	confident := make([]external.Topic, 0, len(topics))
+	for _, topic := range topics {
+		if topic.Confidence.IsSome() && topic.Confidence.Unwrap() < a.cfg.MinTopicConfidence {
+			logger.Info(
+				"low-confidence topic discarded",
+				"topic_enum", string(topic.Topic),
+				"confidence", topic.Confidence.Unwrap(),
+				"min_topic_confidence", a.cfg.MinTopicConfidence,
+				"feedback_ids_count", len(topic.FeedbackIDs),
+			)
+			continue
+		}
+		confident = append(confident, topic)
+	}
+	return confident
 }
 
 // createTopics creates topics and their feedback assignments for an analysis.
@@ -349,12 +651,20 @@ func (a *analyzer) createTopics(
 		)
 
 		// Build topic analysis domain object
-		topicAnalysisBuilder := analysis.NewTopicAnalysisBuilder().
+		topicAnalysisBuilder := analysis.NewTopicAnalysisBuilder(shared.WithClock(a.clock)).
 			WithAnalysisID(analysisID).
 			WithTopic(llmTopic.Topic).
 			WithSummary(llmTopic.Summary).
 			WithSentiment(llmTopic.Sentiment).
-			WithFeedbackCount(len(llmTopic.FeedbackIDs))
+			WithFeedbackCount(len(llmTopic.FeedbackIDs)).
+			WithRecommendations(llmTopic.Recommendations)
+		if llmTopic.SentimentDistribution.IsSome() {
+			topicAnalysisBuilder.WithSentimentDistribution(llmTopic.SentimentDistribution.Unwrap())
+		}
+		if llmTopic.Confidence.IsSome() {
+			topicAnalysisBuilder.WithConfidence(llmTopic.Confidence.Unwrap())
+		}
+		topicAnalysisBuilder.WithSentimentConflict(llmTopic.SentimentConflict)
 
 		topicAnalysis, err := topicAnalysisBuilder.Build()
 		if err != nil {
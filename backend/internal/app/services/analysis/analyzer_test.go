@@ -0,0 +1,127 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
+)
+
+func TestAnalyzer_PerformAnalysis_EmptyBatch(t *testing.T) {
+	// No repositories are wired, so any attempt to persist an analysis would panic
+	a := &analyzer{}
+
+	result, err := a.performAnalysis(context.Background(), nil)
+	if !errors.Is(err, ErrNoFeedbacks) {
+		t.Fatalf("Expected ErrNoFeedbacks, got: %v", err)
+	}
+	if result != nil {
+		t.Error("Expected no analysis to be returned for an empty batch")
+	}
+}
+
+func TestAnalyzer_ConfidentTopics(t *testing.T) {
+	topics := []external.Topic{
+		{Topic: analysis.TopicUIUX, Confidence: optional.Some(0.9)},
+		{Topic: analysis.TopicPricingLicensing, Confidence: optional.Some(0.4)},
+		{Topic: analysis.TopicSecurityPrivacy, Confidence: optional.None[float64]()},
+	}
+
+	tests := []struct {
+		name          string
+		minConfidence float64
+		want          []analysis.Topic
+	}{
+		{
+			name: "no threshold",
+			want: []analysis.Topic{analysis.TopicUIUX, analysis.TopicPricingLicensing, analysis.TopicSecurityPrivacy},
+		},
+		{
+			name:          "below threshold discarded",
+			minConfidence: 0.5,
+			want:          []analysis.Topic{analysis.TopicUIUX, analysis.TopicSecurityPrivacy},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				a := &analyzer{cfg: &config.LLMAnalysis{MinTopicConfidence: tt.minConfidence}}
+
+				got := a.confidentTopics(topics, newTestLogger(t))
+
+				if len(got) != len(tt.want) {
+					t.Fatalf("Expected %d topics, got %d", len(tt.want), len(got))
+				}
+				for i, topic := range tt.want {
+					if got[i].Topic != topic {
+						t.Errorf("Expected topic %s at position %d, got %s", topic, i, got[i].Topic)
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestAnalyzer_ReconcileSentiments(t *testing.T) {
+	feedbacks := chunkTestFeedbacks(2) // both rated 2 on the default 1-5 scale
+	lowID, otherID := feedbacks[0].ID(), feedbacks[1].ID()
+	topics := []external.Topic{
+		{
+			Topic:                 analysis.TopicUIUX,
+			Sentiment:             analysis.SentimentPositive,
+			FeedbackIDs:           []uuid.UUID{lowID},
+			SentimentDistribution: optional.Some(analysis.SentimentDistribution{Positive: 1}),
+		},
+		{Topic: analysis.TopicPricingLicensing, Sentiment: analysis.SentimentMixed, FeedbackIDs: []uuid.UUID{lowID}},
+		{
+			Topic:       analysis.TopicSecurityPrivacy,
+			Sentiment:   analysis.SentimentPositive,
+			FeedbackIDs: []uuid.UUID{lowID, otherID},
+		},
+	}
+
+	tests := []struct {
+		mode          string
+		wantConflict  bool
+		wantSentiment analysis.Sentiment
+	}{
+		{mode: "off", wantSentiment: analysis.SentimentPositive},
+		{mode: "flag", wantConflict: true, wantSentiment: analysis.SentimentPositive},
+		{mode: "override", wantConflict: true, wantSentiment: analysis.SentimentNegative},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.mode, func(t *testing.T) {
+				a := &analyzer{cfg: &config.LLMAnalysis{SentimentReconciliation: tt.mode}}
+
+				got := a.reconcileSentiments(topics, feedbacks, newTestLogger(t))
+
+				if got[0].SentimentConflict != tt.wantConflict || got[0].Sentiment != tt.wantSentiment {
+					t.Errorf(
+						"Expected conflict %t with sentiment %s, got %t with %s",
+						tt.wantConflict,
+						tt.wantSentiment,
+						got[0].SentimentConflict,
+						got[0].Sentiment,
+					)
+				}
+				if tt.mode == "override" && got[0].SentimentDistribution.Unwrap().Negative != 1 {
+					t.Errorf("Expected the distribution to follow the overridden sentiment")
+				}
+				if got[1].SentimentConflict || got[2].SentimentConflict {
+					t.Error("Expected mixed sentiments and multi-feedback topics never to conflict")
+				}
+				if topics[0].Sentiment != analysis.SentimentPositive {
+					t.Error("Expected the input topics to be left untouched")
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,221 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+// defaultMaxBisectionCalls is the number of LLM calls spent bisecting a failed batch when none is configured.
+const defaultMaxBisectionCalls = 16
+
+// deadLetterReason is recorded with the feedbacks isolated by bisection.
+const deadLetterReason = "model returned invalid output for the feedback in its batch and when analyzed alone"
+
+// maxBisectionCalls returns the configured number of bisection calls, defaulting to defaultMaxBisectionCalls.
+func (a *analyzer) maxBisectionCalls() int {
+	if a.cfg.MaxBisectionCalls > 0 {
+		return a.cfg.MaxBisectionCalls
+	}
+	return defaultMaxBisectionCalls
+}
+
+// shouldBisect reports whether a failed analysis is bisected: only if enabled and the model output was invalid,
+// as other failures do not depend on the content of the feedbacks.
+func (a *analyzer) shouldBisect(failed *analysis.Analysis, err error) bool {
+	var invalidOutput *external.InvalidOutputError
+	return a.cfg.BisectInvalidOutput && a.llmClient != nil && failed != nil && errors.As(err, &invalidOutput)
+}
+
+// analyzeWithoutPoisonFeedbacks isolates the feedbacks of a batch that make the model return invalid output,
+// dead-letters them and analyzes the rest of the batch again. If no feedback could be isolated, the failed
+// analysis and its error are returned unchanged.
+func (a *analyzer) analyzeWithoutPoisonFeedbacks(
+	ctx context.Context,
+	failed *analysis.Analysis,
+	feedbacks []*feedback.Feedback,
+	analysisErr error,
+) (*analysis.Analysis, error) {
+	logger := a.logger.WithSpan(ctx)
+	ctx, spanLogger, span := logger.StartSpan(ctx, "analyzer.bisect")
+	defer span.End()
+
+	// Bisection requests are attributed to the failed analysis, the new analysis of the rest gets its own ID
+	ctx = external.ContextWithAnalysisID(ctx, failed.ID())
+
+	previousAnalysis, previousTopics := a.failedAnalysisContext(ctx, failed)
+	poison, calls := a.isolatePoisonFeedbacks(ctx, feedbacks, previousAnalysis, previousTopics, spanLogger)
+	span.SetAttributes(
+		trace.Attribute{Key: "analysis_id", Value: failed.ID().String()},
+		trace.Attribute{Key: "bisection_calls", Value: calls},
+		trace.Attribute{Key: "poison_count", Value: len(poison)},
+	)
+	if len(poison) == 0 {
+		spanLogger.Warning(
+			"bisection isolated no feedback causing invalid model output",
+			"analysis_id",
+			failed.ID().String(),
+			"bisection_calls",
+			calls,
+		)
+		return failed, analysisErr
+	}
+
+	isolated := make(map[*feedback.Feedback]bool, len(poison))
+	for _, fb := range poison {
+		isolated[fb] = true
+		spanLogger.Warning(
+			"feedback isolated as cause of invalid model output, dead-lettering",
+			"feedback_id",
+			fb.ID().String(),
+			"analysis_id",
+			failed.ID().String(),
+			"bisection_calls",
+			calls,
+		)
+		if err := a.analysisRepo.SaveDeadLetter(ctx, fb.ID(), failed.ID(), deadLetterReason); err != nil {
+			spanLogger.Error("failed to dead-letter feedback", err, "feedback_id", fb.ID().String())
+		}
+	}
+
+	rest := make([]*feedback.Feedback, 0, len(feedbacks)-len(poison))
+	for _, fb := range feedbacks {
+		if !isolated[fb] {
+			rest = append(rest, fb)
+		}
+	}
+	if len(rest) == 0 {
+		return failed, analysisErr
+	}
+
+	spanLogger.Info("analyzing batch again without dead-lettered feedbacks", "feedback_count", len(rest))
+	return a.performAnalysis(ctx, rest)
+}
+
+// failedAnalysisContext loads the previous analysis and topics the failed analysis was sent with, so that
+// bisection reproduces its requests. They are left out if they cannot be loaded.
+func (a *analyzer) failedAnalysisContext(
+	ctx context.Context,
+	failed *analysis.Analysis,
+) (*analysis.Analysis, []*analysis.TopicAnalysis) {
+	if failed.PreviousAnalysisID().IsNone() {
+		return nil, nil
+	}
+
+	previousAnalysis, err := a.analysisRepo.GetByID(ctx, failed.PreviousAnalysisID().Unwrap())
+	if err != nil || previousAnalysis == nil {
+		return nil, nil
+	}
+	previousTopics, err := a.analysisRepo.GetTopicsByAnalysisID(ctx, previousAnalysis.ID())
+	if err != nil {
+		return previousAnalysis, nil
+	}
+	return previousAnalysis, previousTopics
+}
+
+// isolatePoisonFeedbacks bisects a batch the model returned invalid output for: each half is analyzed on its own
+// and halves failing again are split further, until single feedbacks remain that fail when analyzed alone.
+// A batch of one feedback is retried once. The search stops after maxBisectionCalls calls or at the first
+// failure other than invalid output, returning the feedbacks isolated so far and the number of calls made.
+func (a *analyzer) isolatePoisonFeedbacks(
+	ctx context.Context,
+	feedbacks []*feedback.Feedback,
+	previousAnalysis *analysis.Analysis,
+	previousTopics []*analysis.TopicAnalysis,
+	logger tracelog.TraceLogger,
+) ([]*feedback.Feedback, int) {
+	b := &bisection{
+		analyzer:         a,
+		previousAnalysis: previousAnalysis,
+		previousTopics:   previousTopics,
+		maxCalls:         a.maxBisectionCalls(),
+		logger:           logger,
+	}
+
+	if len(feedbacks) == 1 {
+		b.bisect(ctx, feedbacks)
+	} else {
+		// The whole batch already failed, so the search starts with its halves
+		mid := len(feedbacks) / 2
+		b.bisect(ctx, feedbacks[:mid])
+		b.bisect(ctx, feedbacks[mid:])
+	}
+
+	logger.Info(
+		"bisection completed",
+		"feedback_count",
+		len(feedbacks),
+		"bisection_calls",
+		b.calls,
+		"tokens_used",
+		b.tokens,
+		"poison_count",
+		len(b.poison),
+	)
+	return b.poison, b.calls
+}
+
+// bisection holds the state of the search for the feedbacks causing invalid model output.
+type bisection struct {
+	analyzer         *analyzer
+	previousAnalysis *analysis.Analysis
+	previousTopics   []*analysis.TopicAnalysis
+	maxCalls         int
+	logger           tracelog.TraceLogger
+
+	calls   int
+	tokens  int
+	stopped bool
+	poison  []*feedback.Feedback
+}
+
+// bisect analyzes the feedbacks and, if the model output is invalid, splits them in halves searched in turn.
+func (b *bisection) bisect(ctx context.Context, feedbacks []*feedback.Feedback) {
+	if b.stopped || len(feedbacks) == 0 {
+		return
+	}
+	if b.calls >= b.maxCalls || ctx.Err() != nil {
+		b.stopped = true
+		b.logger.Warning("bisection stopped before completion", "bisection_calls", b.calls)
+		return
+	}
+
+	b.calls++
+	result, err := b.analyzer.llmClient.AnalyzeFeedbacks(ctx, feedbacks, b.previousAnalysis, b.previousTopics, nil)
+	b.tokens += callTokens(result, err)
+	if err == nil {
+		return
+	}
+
+	var invalidOutput *external.InvalidOutputError
+	if !errors.As(err, &invalidOutput) {
+		b.stopped = true
+		b.logger.Warning("bisection stopped by llm failure", "error", err.Error(), "bisection_calls", b.calls)
+		return
+	}
+
+	if len(feedbacks) == 1 {
+		b.poison = append(b.poison, feedbacks[0])
+		return
+	}
+	mid := len(feedbacks) / 2
+	b.bisect(ctx, feedbacks[:mid])
+	b.bisect(ctx, feedbacks[mid:])
+}
+
+// callTokens returns the tokens an LLM call consumed, including a failed call that reported its usage.
+func callTokens(result *external.AnalysisResult, err error) int {
+	if err == nil && result != nil {
+		return result.Usage.TotalTokens
+	}
+	var usageErr *external.UsageError
+	if errors.As(err, &usageErr) {
+		return usageErr.Usage.TotalTokens
+	}
+	return 0
+}
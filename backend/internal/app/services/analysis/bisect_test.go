@@ -0,0 +1,109 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+)
+
+// poisonLLMClient returns invalid output for every batch containing one of the poison feedbacks.
+type poisonLLMClient struct {
+	poison map[uuid.UUID]bool
+	calls  int
+}
+
+func (c *poisonLLMClient) AnalyzeFeedbacks(
+	_ context.Context,
+	feedbacks []*feedback.Feedback,
+	_ *analysis.Analysis,
+	_ []*analysis.TopicAnalysis,
+	_ []*feedback.Feedback,
+) (*external.AnalysisResult, error) {
+	c.calls++
+	for _, fb := range feedbacks {
+		if c.poison[fb.ID()] {
+			return nil, &external.InvalidOutputError{Err: errors.New("schema mismatch")}
+		}
+	}
+	return &external.AnalysisResult{Usage: external.TokenUsage{TotalTokens: 10}}, nil
+}
+
+func (c *poisonLLMClient) ReduceAnalyses(
+	context.Context,
+	[]*external.AnalysisResult,
+) (*external.AnalysisResult, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestAnalyzer_IsolatePoisonFeedbacks(t *testing.T) {
+	feedbacks := chunkTestFeedbacks(8)
+
+	tests := []struct {
+		name      string
+		poison    []int
+		maxCalls  int
+		want      []int
+		wantCalls int
+	}{
+		{name: "single poison", poison: []int{5}, want: []int{5}, wantCalls: 6},
+		{name: "two poisons", poison: []int{0, 7}, want: []int{0, 7}, wantCalls: 10},
+		{name: "call limit", poison: []int{5}, maxCalls: 2, want: nil, wantCalls: 2},
+		{name: "no reproducible failure", want: nil, wantCalls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				llmClient := &poisonLLMClient{poison: make(map[uuid.UUID]bool)}
+				for _, i := range tt.poison {
+					llmClient.poison[feedbacks[i].ID()] = true
+				}
+				a := &analyzer{
+					cfg:       &config.LLMAnalysis{BisectInvalidOutput: true, MaxBisectionCalls: tt.maxCalls},
+					llmClient: llmClient,
+				}
+
+				poison, calls := a.isolatePoisonFeedbacks(context.Background(), feedbacks, nil, nil, newTestLogger(t))
+
+				if len(poison) != len(tt.want) {
+					t.Fatalf("Expected %d isolated feedbacks, got %d", len(tt.want), len(poison))
+				}
+				for i, want := range tt.want {
+					if poison[i].ID() != feedbacks[want].ID() {
+						t.Errorf("Expected feedback %d to be isolated, got %s", want, poison[i].ID())
+					}
+				}
+				if calls != tt.wantCalls || llmClient.calls != tt.wantCalls {
+					t.Errorf("Expected %d bisection calls, got %d (%d sent)", tt.wantCalls, calls, llmClient.calls)
+				}
+			},
+		)
+	}
+}
+
+func TestAnalyzer_ShouldBisect(t *testing.T) {
+	failed := &analysis.Analysis{}
+	invalidOutput := &external.UsageError{Err: &external.InvalidOutputError{Err: errors.New("schema mismatch")}}
+
+	a := &analyzer{cfg: &config.LLMAnalysis{BisectInvalidOutput: true}, llmClient: &poisonLLMClient{}}
+	if !a.shouldBisect(failed, invalidOutput) {
+		t.Error("Expected invalid model output to be bisected")
+	}
+	if a.shouldBisect(failed, errors.New("connection refused")) {
+		t.Error("Expected failures other than invalid output not to be bisected")
+	}
+	if a.shouldBisect(nil, invalidOutput) {
+		t.Error("Expected no bisection without a failed analysis record")
+	}
+
+	a.cfg.BisectInvalidOutput = false
+	if a.shouldBisect(failed, invalidOutput) {
+		t.Error("Expected no bisection unless enabled")
+	}
+}
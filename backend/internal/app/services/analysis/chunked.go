@@ -0,0 +1,227 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+// defaultMaxChunksPerAnalysis is the number of chunks taken from the pending queue per trigger when none is configured.
+const defaultMaxChunksPerAnalysis = 3
+
+// maxChunksPerAnalysis returns the configured number of chunks per trigger, defaulting to defaultMaxChunksPerAnalysis.
+func (a *analyzer) maxChunksPerAnalysis() int {
+	if a.cfg.MaxChunksPerAnalysis > 0 {
+		return a.cfg.MaxChunksPerAnalysis
+	}
+	return defaultMaxChunksPerAnalysis
+}
+
+// chunkFeedbacks splits the feedbacks into consecutive chunks that each fit the token and count limits
+// of a single request, in selection order. A feedback too large for any request gets a chunk of its own,
+// so that every feedback is sent.
+func (a *analyzer) chunkFeedbacks(
+	feedbacks []*feedback.Feedback,
+	previousAnalysis *analysis.Analysis,
+) [][]*feedback.Feedback {
+	var chunks [][]*feedback.Feedback
+	remaining := feedbacks
+	for len(remaining) > 0 {
+		chunk, rest := a.selectFeedbacksForAnalysis(remaining, previousAnalysis)
+		if len(chunk) == 0 {
+			chunk, rest = rest[:1], rest[1:]
+		}
+		chunks = append(chunks, chunk)
+		remaining = rest
+	}
+	return chunks
+}
+
+// analyzeChunks runs one LLM call per chunk, one after the other, and merges their results with a final
+// reduce call combining the summaries. Context feedbacks are sent with the first chunk only.
+// If a call fails, the returned error carries the token usage of all calls made so far.
+func (a *analyzer) analyzeChunks(
+	ctx context.Context,
+	chunks [][]*feedback.Feedback,
+	previousAnalysis *analysis.Analysis,
+	previousTopics []*analysis.TopicAnalysis,
+	contextFeedbacks []*feedback.Feedback,
+	logger tracelog.TraceLogger,
+) (*external.AnalysisResult, error) {
+	var usage external.TokenUsage
+	partials := make([]*external.AnalysisResult, 0, len(chunks))
+	for i, chunk := range chunks {
+		var chunkContext []*feedback.Feedback
+		if i == 0 {
+			chunkContext = contextFeedbacks
+		}
+
+		partial, err := a.llmClient.AnalyzeFeedbacks(ctx, chunk, previousAnalysis, previousTopics, chunkContext)
+		if err != nil {
+			return nil, withChunkUsage(usage, fmt.Errorf("chunk %d of %d: %w", i+1, len(chunks), err))
+		}
+		usage = addUsage(usage, partial.Usage)
+		partials = append(partials, partial)
+
+		logger.Info(
+			"chunk analyzed",
+			"chunk", i+1,
+			"chunk_count", len(chunks),
+			"feedback_count", len(chunk),
+			"topics_count", len(partial.Topics),
+		)
+	}
+
+	reduced, err := a.llmClient.ReduceAnalyses(ctx, partials)
+	if err != nil {
+		return nil, withChunkUsage(usage, fmt.Errorf("failed to reduce chunk analyses: %w", err))
+	}
+	usage = addUsage(usage, reduced.Usage)
+
+	return a.mergeChunkResults(partials, reduced, usage), nil
+}
+
+// mergeChunkResults merges the chunk results into a single result. Summaries, sentiments and key insights
+// come from the reduce call, feedback IDs and recommendations of a topic are the union of its chunk topics
+// and its confidence the lowest of theirs. Chunks hold distinct feedbacks, so sentiment distributions add up,
+// unless a chunk topic has none.
+func (a *analyzer) mergeChunkResults(
+	partials []*external.AnalysisResult,
+	reduced *external.AnalysisResult,
+	usage external.TokenUsage,
+) *external.AnalysisResult {
+	var topics []external.Topic
+	index := make(map[analysis.Topic]int)
+	for _, partial := range partials {
+		for _, topic := range partial.Topics {
+			i, ok := index[topic.Topic]
+			if !ok {
+				index[topic.Topic] = len(topics)
+				topics = append(
+					topics, external.Topic{
+						Topic:                 topic.Topic,
+						Summary:               topic.Summary,
+						Sentiment:             topic.Sentiment,
+						FeedbackIDs:           append([]uuid.UUID(nil), topic.FeedbackIDs...),
+						SentimentDistribution: topic.SentimentDistribution,
+						Recommendations:       append([]string(nil), topic.Recommendations...),
+						Confidence:            topic.Confidence,
+					},
+				)
+				continue
+			}
+
+			merged := &topics[i]
+			merged.FeedbackIDs = appendMissing(merged.FeedbackIDs, topic.FeedbackIDs)
+			merged.Recommendations = appendMissing(merged.Recommendations, topic.Recommendations)
+			merged.SentimentDistribution = addDistributions(merged.SentimentDistribution, topic.SentimentDistribution)
+			merged.Confidence = minConfidence(merged.Confidence, topic.Confidence)
+		}
+	}
+
+	// Topics the reduce call left out keep the summary of the chunk they first appeared in
+	for _, topic := range reduced.Topics {
+		if i, ok := index[topic.Topic]; ok {
+			topics[i].Summary = topic.Summary
+			topics[i].Sentiment = topic.Sentiment
+		}
+	}
+
+	if limit := a.cfg.MaxTopicsPerAnalysis; limit > 0 && len(topics) > limit {
+		sort.SliceStable(
+			topics, func(i, j int) bool {
+				return topicLess(len(topics[i].FeedbackIDs), len(topics[j].FeedbackIDs), topics[i].Topic, topics[j].Topic)
+			},
+		)
+		topics = topics[:limit]
+	}
+
+	rawOutputs := make([]string, 0, len(partials)+1)
+	for _, result := range append(partials, reduced) {
+		if result.RawOutput != "" {
+			rawOutputs = append(rawOutputs, result.RawOutput)
+		}
+	}
+
+	return &external.AnalysisResult{
+		OverallSummary: reduced.OverallSummary,
+		Sentiment:      reduced.Sentiment,
+		KeyInsights:    reduced.KeyInsights,
+		TokensUsed:     usage.TotalTokens,
+		Topics:         topics,
+		// One output per line, the chunks in order followed by the reduce call
+		RawOutput: strings.Join(rawOutputs, "\n"),
+		Usage:     usage,
+	}
+}
+
+// appendMissing appends the values of add that dst does not contain yet, keeping their order.
+func appendMissing[T comparable](dst []T, add []T) []T {
+	seen := make(map[T]bool, len(dst))
+	for _, v := range dst {
+		seen[v] = true
+	}
+	for _, v := range add {
+		if !seen[v] {
+			seen[v] = true
+			dst = append(dst, v)
+		}
+	}
+	return dst
+}
+
+// addDistributions sums two sentiment distributions, None if either is None.
+func addDistributions(
+	a, b optional.Optional[analysis.SentimentDistribution],
+) optional.Optional[analysis.SentimentDistribution] {
+	if a.IsNone() || b.IsNone() {
+		return optional.None[analysis.SentimentDistribution]()
+	}
+	x, y := a.Unwrap(), b.Unwrap()
+	return optional.Some(
+		analysis.SentimentDistribution{
+			Positive: x.Positive + y.Positive,
+			Mixed:    x.Mixed + y.Mixed,
+			Negative: x.Negative + y.Negative,
+		},
+	)
+}
+
+// minConfidence returns the lower of two topic confidences, or the one that is set.
+func minConfidence(a, b optional.Optional[float64]) optional.Optional[float64] {
+	if a.IsNone() || (b.IsSome() && b.Unwrap() < a.Unwrap()) {
+		return b
+	}
+	return a
+}
+
+// addUsage sums the token usage of two requests.
+func addUsage(a, b external.TokenUsage) external.TokenUsage {
+	return external.TokenUsage{
+		InputTokens:  a.InputTokens + b.InputTokens,
+		OutputTokens: a.OutputTokens + b.OutputTokens,
+		TotalTokens:  a.TotalTokens + b.TotalTokens,
+	}
+}
+
+// withChunkUsage wraps the error of a failed chunked analysis in an external.UsageError carrying the usage
+// of the previous calls plus the usage the failed call reported, if any.
+func withChunkUsage(usage external.TokenUsage, err error) error {
+	var usageErr *external.UsageError
+	if errors.As(err, &usageErr) {
+		usage = addUsage(usage, usageErr.Usage)
+	}
+	if usage.TotalTokens == 0 {
+		return err
+	}
+	return &external.UsageError{Usage: usage, Err: err}
+}
@@ -0,0 +1,173 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
+)
+
+// chunkLLMClient assigns every feedback of a chunk to the same topic and fails the chunk at failAt (1-based).
+type chunkLLMClient struct {
+	failAt   int
+	calls    int
+	partials []*external.AnalysisResult
+}
+
+func (c *chunkLLMClient) AnalyzeFeedbacks(
+	_ context.Context,
+	feedbacks []*feedback.Feedback,
+	_ *analysis.Analysis,
+	_ []*analysis.TopicAnalysis,
+	_ []*feedback.Feedback,
+) (*external.AnalysisResult, error) {
+	c.calls++
+	if c.calls == c.failAt {
+		return nil, &external.UsageError{Usage: external.TokenUsage{TotalTokens: 5}, Err: errors.New("invalid output")}
+	}
+
+	ids := make([]uuid.UUID, len(feedbacks))
+	for i, fb := range feedbacks {
+		ids[i] = fb.ID()
+	}
+	return &external.AnalysisResult{
+		OverallSummary: "partial",
+		Sentiment:      analysis.SentimentNegative,
+		TokensUsed:     100,
+		Topics: []external.Topic{
+			{
+				Topic:                 analysis.TopicPerformanceReliability,
+				Summary:               "partial topic",
+				Sentiment:             analysis.SentimentNegative,
+				FeedbackIDs:           ids,
+				SentimentDistribution: optional.Some(analysis.SentimentDistribution{Negative: len(ids)}),
+				Recommendations:       []string{"Fix the crash"},
+			},
+		},
+		Usage: external.TokenUsage{InputTokens: 80, OutputTokens: 20, TotalTokens: 100},
+	}, nil
+}
+
+func (c *chunkLLMClient) ReduceAnalyses(
+	_ context.Context,
+	partials []*external.AnalysisResult,
+) (*external.AnalysisResult, error) {
+	c.partials = partials
+	return &external.AnalysisResult{
+		OverallSummary: "combined",
+		Sentiment:      analysis.SentimentMixed,
+		KeyInsights:    []string{"insight"},
+		Topics: []external.Topic{
+			{Topic: analysis.TopicPerformanceReliability, Summary: "combined topic", Sentiment: analysis.SentimentMixed},
+		},
+		Usage: external.TokenUsage{InputTokens: 40, OutputTokens: 10, TotalTokens: 50},
+	}, nil
+}
+
+func chunkTestFeedbacks(n int) []*feedback.Feedback {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	feedbacks := make([]*feedback.Feedback, n)
+	for i := range feedbacks {
+		feedbacks[i] = feedback.NewBuilder().
+			WithID(uuid.New()).
+			WithUserID(uuid.New()).
+			WithRatingValue(2).
+			WithCommentText("The app crashes on startup").
+			WithCreatedAt(base.Add(time.Duration(i) * time.Minute)).
+			BuildUnchecked()
+	}
+	return feedbacks
+}
+
+func TestAnalyzer_AnalyzeChunks(t *testing.T) {
+	llmClient := &chunkLLMClient{}
+	a := &analyzer{
+		cfg: &config.LLMAnalysis{
+			MaxFeedbacksInContext: 2,
+			MaxTokensPerRequest:   100000,
+			EnableChunkedAnalysis: true,
+		},
+		llmClient: llmClient,
+	}
+	feedbacks := chunkTestFeedbacks(5)
+
+	chunks := a.chunkFeedbacks(feedbacks, nil)
+	if len(chunks) != 3 || len(chunks[0]) != 2 || len(chunks[1]) != 2 || len(chunks[2]) != 1 {
+		t.Fatalf("Expected chunks of 2, 2 and 1 feedbacks, got %d chunks", len(chunks))
+	}
+
+	result, err := a.analyzeChunks(context.Background(), chunks, nil, nil, nil, newTestLogger(t))
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(llmClient.partials) != 3 {
+		t.Errorf("Expected the reduce call to combine 3 partial analyses, got %d", len(llmClient.partials))
+	}
+	if result.OverallSummary != "combined" || result.Sentiment != analysis.SentimentMixed {
+		t.Errorf(
+			"Expected the overall summary and sentiment of the reduce call, got %q, %s",
+			result.OverallSummary,
+			result.Sentiment,
+		)
+	}
+	if want := (external.TokenUsage{InputTokens: 280, OutputTokens: 70, TotalTokens: 350}); result.Usage != want {
+		t.Errorf("Expected usage %+v, got %+v", want, result.Usage)
+	}
+	if result.TokensUsed != 350 {
+		t.Errorf("Expected 350 tokens used, got %d", result.TokensUsed)
+	}
+
+	if len(result.Topics) != 1 {
+		t.Fatalf("Expected the chunk topics to be merged into 1 topic, got %d", len(result.Topics))
+	}
+	topic := result.Topics[0]
+	if topic.Summary != "combined topic" || topic.Sentiment != analysis.SentimentMixed {
+		t.Errorf(
+			"Expected the topic summary and sentiment of the reduce call, got %q, %s",
+			topic.Summary,
+			topic.Sentiment,
+		)
+	}
+	if len(topic.FeedbackIDs) != len(feedbacks) {
+		t.Errorf("Expected %d feedback IDs, got %d", len(feedbacks), len(topic.FeedbackIDs))
+	}
+	if topic.SentimentDistribution.IsNone() || topic.SentimentDistribution.Unwrap().Negative != len(feedbacks) {
+		t.Errorf("Expected the sentiment distributions to add up to %d negative feedbacks", len(feedbacks))
+	}
+	if len(topic.Recommendations) != 1 {
+		t.Errorf("Expected duplicate recommendations to be merged, got %v", topic.Recommendations)
+	}
+}
+
+func TestAnalyzer_AnalyzeChunks_FailedChunkKeepsUsage(t *testing.T) {
+	a := &analyzer{
+		cfg: &config.LLMAnalysis{
+			MaxFeedbacksInContext: 2,
+			MaxTokensPerRequest:   100000,
+			EnableChunkedAnalysis: true,
+		},
+		llmClient: &chunkLLMClient{failAt: 2},
+	}
+	chunks := a.chunkFeedbacks(chunkTestFeedbacks(4), nil)
+
+	_, err := a.analyzeChunks(context.Background(), chunks, nil, nil, nil, newTestLogger(t))
+
+	var usageErr *external.UsageError
+	if !errors.As(err, &usageErr) {
+		t.Fatalf("Expected a usage error, got: %v", err)
+	}
+	if usageErr.Usage.TotalTokens != 105 {
+		t.Errorf(
+			"Expected the usage of the first chunk and the failed one, got %d tokens",
+			usageErr.Usage.TotalTokens,
+		)
+	}
+}
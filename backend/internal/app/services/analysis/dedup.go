@@ -0,0 +1,42 @@
+package analysis
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+)
+
+// deduplicateFeedbacks collapses feedbacks with identical comments into the first of them,
+// preserving order. Comments are compared after trimming surrounding whitespace, and feedbacks
+// without a comment are never collapsed. The multiplicity map holds, for every representative
+// that absorbed duplicates, the number of feedbacks it stands for (itself included).
+func deduplicateFeedbacks(feedbacks []*feedback.Feedback) (
+	unique []*feedback.Feedback,
+	multiplicity map[uuid.UUID]int,
+) {
+	unique = make([]*feedback.Feedback, 0, len(feedbacks))
+	multiplicity = make(map[uuid.UUID]int)
+	representatives := make(map[string]*feedback.Feedback)
+
+	for _, fb := range feedbacks {
+		comment := strings.TrimSpace(fb.Comment().Value())
+		if comment == "" {
+			unique = append(unique, fb)
+			continue
+		}
+
+		if representative, ok := representatives[comment]; ok {
+			if multiplicity[representative.ID()] == 0 {
+				multiplicity[representative.ID()] = 1
+			}
+			multiplicity[representative.ID()]++
+			continue
+		}
+
+		representatives[comment] = fb
+		unique = append(unique, fb)
+	}
+
+	return unique, multiplicity
+}
@@ -0,0 +1,76 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+)
+
+const tokensPerMillion = 1_000_000
+
+// EstimateAnalysis estimates the next analysis without running it. Without feedback IDs it
+// mirrors the selection of the pending queue; with IDs it estimates the equivalent ad-hoc analysis.
+func (a *analyzer) EstimateAnalysis(ctx context.Context, feedbackIDs []uuid.UUID) (*services.AnalysisEstimate, error) {
+	logger := a.logger.WithSpan(ctx)
+
+	previousAnalysis, err := a.analysisRepo.GetLatestRepresentative(ctx)
+	if err != nil {
+		// No previous analysis, continue with nil
+		previousAnalysis = nil
+	}
+
+	var candidates, selected []*feedback.Feedback
+	if len(feedbackIDs) == 0 {
+		// Work on a snapshot so that the queue itself is never touched
+		a.pendingMutex.Lock()
+		candidates = make([]*feedback.Feedback, len(a.pendingFeedbacks))
+		copy(candidates, a.pendingFeedbacks)
+		a.pendingMutex.Unlock()
+
+		selected, _ = a.selectFeedbacksForAnalysis(a.pastGracePeriod(candidates), previousAnalysis)
+	} else {
+		if err := a.validateAdhocFeedbackIDs(feedbackIDs); err != nil {
+			return nil, err
+		}
+
+		candidates, err = a.feedbackRepo.GetByIDs(ctx, feedbackIDs)
+		if err != nil {
+			logger.RecordSpanError(ctx, err)
+			return nil, fmt.Errorf("failed to get feedbacks for estimate: %w", err)
+		}
+		// Ad-hoc analyses send every requested feedback
+		selected = candidates
+	}
+
+	// Only distinct comments reach the LLM when deduplication is enabled
+	llmFeedbacks := selected
+	if a.cfg.DeduplicateComments {
+		llmFeedbacks, _ = deduplicateFeedbacks(selected)
+	}
+
+	tokens := a.estimateTokenBreakdown(llmFeedbacks, previousAnalysis)
+	estimate := &services.AnalysisEstimate{
+		CandidateCount:   len(candidates),
+		FeedbackCount:    len(selected),
+		Tokens:           tokens,
+		EstimatedCostUSD: a.estimateCostUSD(tokens),
+	}
+
+	logger.Info(
+		"analysis estimated",
+		"candidate_count", estimate.CandidateCount,
+		"feedback_count", estimate.FeedbackCount,
+		"total_tokens", tokens.Total(),
+	)
+	return estimate, nil
+}
+
+// estimateCostUSD prices the estimated tokens with the configured per-million token prices.
+func (a *analyzer) estimateCostUSD(tokens services.TokenEstimate) float64 {
+	inputCost := float64(tokens.Input()) * a.cfg.InputTokenPricePerMillion / tokensPerMillion
+	outputCost := float64(tokens.Response) * a.cfg.OutputTokenPricePerMillion / tokensPerMillion
+	return inputCost + outputCost
+}
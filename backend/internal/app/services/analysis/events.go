@@ -0,0 +1,60 @@
+package analysis
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+// eventWriteTimeout bounds writing a lifecycle event, which also happens after the analysis context is done.
+const eventWriteTimeout = 5 * time.Second
+
+// recordEvent appends a step to the lifecycle timeline of an analysis. The timeline is an audit trail next
+// to the logs and traces, so failing to write it is only logged and never fails the analysis. It is written
+// even if ctx is done, so that interrupted analyses still show how far they got.
+func (a *analyzer) recordEvent(
+	ctx context.Context,
+	analysisID uuid.UUID,
+	eventType analysis.EventType,
+	duration optional.Optional[time.Duration],
+	details map[string]any,
+	logger tracelog.TraceLogger,
+) {
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), eventWriteTimeout)
+	defer cancel()
+
+	event := &analysis.Event{
+		AnalysisID: analysisID,
+		Type:       eventType,
+		Duration:   duration,
+		Details:    details,
+		OccurredAt: a.clock.Now().UTC(),
+	}
+	if err := a.analysisRepo.CreateEvent(writeCtx, event); err != nil {
+		logger.Error(
+			"failed to record analysis event",
+			err,
+			"analysis_id", analysisID.String(),
+			"event_type", string(eventType),
+		)
+	}
+}
+
+// sinceCreated returns the time elapsed since the analysis was created.
+func (a *analyzer) sinceCreated(analysisEntity *analysis.Analysis) optional.Optional[time.Duration] {
+	return optional.Some(a.clock.Since(analysisEntity.CreatedAt()))
+}
+
+// llmEventDetails returns the details of the llm_returned event: the token usage and the number of topics
+// of the result, or the error of a failed call.
+func llmEventDetails(result *external.AnalysisResult, err error) map[string]any {
+	if err != nil {
+		return map[string]any{"error": err.Error()}
+	}
+	return map[string]any{"tokens": result.TokensUsed, "topics_count": len(result.Topics)}
+}
@@ -0,0 +1,51 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+)
+
+// GetFeedbackAnalyses retrieves the analyses that included a feedback, newest first, with the topics it was
+// assigned to in each.
+func (s *service) GetFeedbackAnalyses(ctx context.Context, feedbackID uuid.UUID) ([]services.FeedbackAnalysis, error) {
+	logger := s.logger.WithSpan(ctx)
+	logger.Info("getting analyses of feedback", "feedback_id", feedbackID.String())
+
+	// GetByIDs omits unknown and deleted feedbacks instead of failing
+	feedbacks, err := s.feedbackRepo.GetByIDs(ctx, []uuid.UUID{feedbackID})
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting feedback", err, "feedback_id", feedbackID)
+		return nil, fmt.Errorf("failed to get feedback: %w", err)
+	}
+	if len(feedbacks) == 0 {
+		logger.Info("feedback not found", "feedback_id", feedbackID.String())
+		return nil, &ce.GenericError{
+			Code:       ce.ErrorCodeNotFound,
+			Message:    "Feedback not found",
+			UserFacing: true,
+		}
+	}
+
+	entries, err := s.analysisRepo.GetAnalysesByFeedbackID(ctx, feedbackID)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting analyses of feedback", err, "feedback_id", feedbackID)
+		return nil, fmt.Errorf("failed to get analyses of feedback: %w", err)
+	}
+
+	result := make([]services.FeedbackAnalysis, len(entries))
+	for i, entry := range entries {
+		result[i] = services.FeedbackAnalysis{
+			Analysis: entry.Analysis,
+			Topics:   entry.Topics,
+		}
+	}
+
+	logger.Info("analyses of feedback retrieved", "feedback_id", feedbackID.String(), "analyses_count", len(result))
+	return result, nil
+}
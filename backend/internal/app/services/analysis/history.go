@@ -0,0 +1,80 @@
+package analysis
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+// historicalContext returns recent feedbacks of the previous analysis to pad a batch smaller
+// than the configured threshold, newest first and within the remaining token budget.
+// The feedbacks are context for the LLM only: they are not counted in the analysis nor assigned to topics.
+// Padding is best effort, so lookup failures are logged and result in no padding.
+func (a *analyzer) historicalContext(
+	ctx context.Context,
+	batch []*feedback.Feedback,
+	previousAnalysis *analysis.Analysis,
+	logger tracelog.TraceLogger,
+) []*feedback.Feedback {
+	if a.cfg.HistoryPaddingThreshold <= 0 || len(batch) >= a.cfg.HistoryPaddingThreshold || previousAnalysis == nil {
+		return nil
+	}
+
+	previousIDs, err := a.analysisRepo.GetFeedbackIDsByAnalysisID(ctx, previousAnalysis.ID())
+	if err != nil {
+		logger.Warning("failed to get previously analyzed feedbacks, skipping history padding", "error", err.Error())
+		return nil
+	}
+
+	inBatch := make(map[uuid.UUID]bool, len(batch))
+	for _, fb := range batch {
+		inBatch[fb.ID()] = true
+	}
+
+	candidateIDs := make([]uuid.UUID, 0, len(previousIDs))
+	for _, id := range previousIDs {
+		if !inBatch[id] {
+			candidateIDs = append(candidateIDs, id)
+		}
+	}
+	if len(candidateIDs) == 0 {
+		return nil
+	}
+
+	candidates, err := a.feedbackRepo.GetByIDs(ctx, candidateIDs)
+	if err != nil {
+		logger.Warning("failed to load previously analyzed feedbacks, skipping history padding", "error", err.Error())
+		return nil
+	}
+	sortFeedbacksNewestFirst(candidates)
+
+	budget := a.cfg.MaxTokensPerRequest - a.estimateTotalTokens(batch, previousAnalysis)
+	padding := make([]*feedback.Feedback, 0, min(a.cfg.HistoryPaddingSize, len(candidates)))
+	for _, fb := range candidates {
+		if len(padding) >= a.cfg.HistoryPaddingSize {
+			break
+		}
+
+		tokens := a.tokenCache.tokens(fb)
+		if tokens > budget {
+			break
+		}
+		budget -= tokens
+		padding = append(padding, fb)
+	}
+
+	if len(padding) > 0 {
+		logger.Info(
+			"padding sparse analysis with previously analyzed feedbacks",
+			"new_feedback_count",
+			len(batch),
+			"context_feedback_count",
+			len(padding),
+		)
+	}
+
+	return padding
+}
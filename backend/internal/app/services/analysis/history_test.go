@@ -0,0 +1,105 @@
+package analysis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/shared"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+)
+
+type historyAnalysisRepo struct {
+	apprepo.AnalysisRepository
+	feedbackIDs []uuid.UUID
+}
+
+func (r *historyAnalysisRepo) GetFeedbackIDsByAnalysisID(
+	context.Context,
+	uuid.UUID,
+	...repository.RepoOption[apprepo.Options],
+) ([]uuid.UUID, error) {
+	return r.feedbackIDs, nil
+}
+
+type historyFeedbackRepo struct {
+	apprepo.FeedbackRepository
+	feedbacks map[uuid.UUID]*feedback.Feedback
+}
+
+func (r *historyFeedbackRepo) GetByIDs(
+	_ context.Context,
+	ids []uuid.UUID,
+	_ ...repository.RepoOption[apprepo.Options],
+) ([]*feedback.Feedback, error) {
+	result := make([]*feedback.Feedback, 0, len(ids))
+	for _, id := range ids {
+		if fb, ok := r.feedbacks[id]; ok {
+			result = append(result, fb)
+		}
+	}
+	return result, nil
+}
+
+func TestAnalyzer_HistoricalContext(t *testing.T) {
+	mockClock := clock.NewMock(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	analysisRepo := &historyAnalysisRepo{}
+	feedbackRepo := &historyFeedbackRepo{feedbacks: map[uuid.UUID]*feedback.Feedback{}}
+
+	// Previously analyzed feedbacks, one minute apart
+	var previous []*feedback.Feedback
+	for i := 0; i < 5; i++ {
+		fb, err := feedback.NewBuilder(shared.WithClock(mockClock)).BuildNew(uuid.New(), 3, "Earlier feedback")
+		if err != nil {
+			t.Fatalf("Failed to build feedback: %v", err)
+		}
+		previous = append(previous, fb)
+		analysisRepo.feedbackIDs = append(analysisRepo.feedbackIDs, fb.ID())
+		feedbackRepo.feedbacks[fb.ID()] = fb
+		mockClock.Advance(time.Minute)
+	}
+
+	newFeedback, err := feedback.NewBuilder(shared.WithClock(mockClock)).BuildNew(uuid.New(), 2, "New feedback")
+	if err != nil {
+		t.Fatalf("Failed to build feedback: %v", err)
+	}
+	batch := []*feedback.Feedback{newFeedback}
+	previousAnalysis := analysis.NewBuilder().WithID(uuid.New()).BuildUnchecked()
+
+	a := &analyzer{
+		logger: newTestLogger(t),
+		cfg: &config.LLMAnalysis{
+			MaxTokensPerRequest:     10000,
+			HistoryPaddingThreshold: 3,
+			HistoryPaddingSize:      2,
+		},
+		analysisRepo: analysisRepo,
+		feedbackRepo: feedbackRepo,
+	}
+
+	padding := a.historicalContext(context.Background(), batch, previousAnalysis, a.logger)
+	if len(padding) != 2 {
+		t.Fatalf("Expected 2 context feedbacks, got %d", len(padding))
+	}
+	if padding[0].ID() != previous[4].ID() || padding[1].ID() != previous[3].ID() {
+		t.Error("Expected the most recent previously analyzed feedbacks first")
+	}
+
+	// Batches at or above the threshold are not padded
+	a.cfg.HistoryPaddingThreshold = 1
+	if padding := a.historicalContext(context.Background(), batch, previousAnalysis, a.logger); len(padding) != 0 {
+		t.Errorf("Expected no padding at the threshold, got %d feedbacks", len(padding))
+	}
+
+	// Without a previous analysis there is nothing to pad with
+	a.cfg.HistoryPaddingThreshold = 3
+	if padding := a.historicalContext(context.Background(), batch, nil, a.logger); len(padding) != 0 {
+		t.Errorf("Expected no padding without a previous analysis, got %d feedbacks", len(padding))
+	}
+}
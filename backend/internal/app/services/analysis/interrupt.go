@@ -0,0 +1,79 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+const (
+	// interruptedFailureReason is recorded for analyses that stopped because the analyzer was shut down.
+	interruptedFailureReason = "interrupted"
+
+	// failureUpdateTimeout bounds the update that records a failure once the analysis context is gone.
+	failureUpdateTimeout = 5 * time.Second
+
+	// defaultStaleAnalysisTimeout is used when config.LLMAnalysis.StaleAnalysisTimeoutMinutes is zero.
+	defaultStaleAnalysisTimeout = 60 * time.Minute
+)
+
+// failAnalysis marks the analysis as failed because of cause and persists the failure. If ctx is already
+// done the analysis was interrupted, so the failure is recorded as such. The failure is always persisted on a
+// context detached from ctx, otherwise the record would be left in processing status forever.
+func (a *analyzer) failAnalysis(
+	ctx context.Context,
+	analysisEntity *analysis.Analysis,
+	cause error,
+	logger tracelog.TraceLogger,
+) error {
+	reason := cause.Error()
+	if ctx.Err() != nil {
+		reason = interruptedFailureReason
+	}
+	ctx = context.WithoutCancel(ctx)
+
+	if err := analysisEntity.MarkFailed(reason); err != nil {
+		return fmt.Errorf("failed to mark analysis as failed: %w", err)
+	}
+
+	updateCtx, cancel := context.WithTimeout(ctx, failureUpdateTimeout)
+	defer cancel()
+	if err := a.analysisRepo.Update(
+		updateCtx, analysisEntity.ID(), &analysis.UpdatableFields{
+			FailureReason: analysisEntity.FailureReason(),
+			Status:        analysis.StatusFailed,
+			CompletedAt:   analysisEntity.CompletedAt().Unwrap(),
+		},
+	); err != nil {
+		logger.RecordSpanError(ctx, fmt.Errorf("failed to update analysis with failure: %w", err))
+	}
+	a.recordEvent(
+		ctx, analysisEntity.ID(), analysis.EventFailed, a.sinceCreated(analysisEntity),
+		map[string]any{"reason": reason}, logger,
+	)
+
+	return nil
+}
+
+// failStaleAnalyses marks analyses left in processing status for longer than the configured timeout as
+// interrupted. Such records are left behind when a previous process was killed mid-analysis.
+// Errors are logged only, so that they do not prevent the analyzer from starting.
+func (a *analyzer) failStaleAnalyses(ctx context.Context) {
+	timeout := defaultStaleAnalysisTimeout
+	if a.cfg.StaleAnalysisTimeoutMinutes > 0 {
+		timeout = time.Duration(a.cfg.StaleAnalysisTimeoutMinutes) * time.Minute
+	}
+
+	now := a.clock.Now().UTC()
+	count, err := a.analysisRepo.FailStaleProcessing(ctx, now.Add(-timeout), interruptedFailureReason, now)
+	if err != nil {
+		a.logger.Error("failed to mark stale analyses as interrupted", err)
+		return
+	}
+	if count > 0 {
+		a.logger.Warning("marked stale analyses as interrupted", "count", count, "timeout", timeout.String())
+	}
+}
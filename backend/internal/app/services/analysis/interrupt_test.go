@@ -0,0 +1,206 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+)
+
+// failureRecordingRepo records the failure updates and the state of the context they were made with.
+type failureRecordingRepo struct {
+	apprepo.AnalysisRepository
+	updates    []*analysis.UpdatableFields
+	ctxErr     error
+	staleSince time.Time
+	events     []*analysis.Event
+}
+
+func (r *failureRecordingRepo) Update(
+	ctx context.Context,
+	_ uuid.UUID,
+	updates *analysis.UpdatableFields,
+	_ ...repository.RepoOption[apprepo.Options],
+) error {
+	r.updates = append(r.updates, updates)
+	r.ctxErr = ctx.Err()
+	return nil
+}
+
+func (r *failureRecordingRepo) CreateEvent(
+	_ context.Context,
+	event *analysis.Event,
+	_ ...repository.RepoOption[apprepo.Options],
+) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *failureRecordingRepo) FailStaleProcessing(
+	_ context.Context,
+	createdBefore time.Time,
+	_ string,
+	_ time.Time,
+	_ ...repository.RepoOption[apprepo.Options],
+) (int, error) {
+	r.staleSince = createdBefore
+	return 0, nil
+}
+
+func newProcessingAnalysis(t *testing.T) *analysis.Analysis {
+	t.Helper()
+
+	now := time.Now().UTC()
+	a, err := analysis.NewBuilder().
+		WithPeriod(now, now).
+		WithFeedbackCount(1).
+		WithSentiment(analysis.SentimentMixed).
+		WithKeyInsights([]string{}).
+		WithModel("gpt-test").
+		WithStatus(analysis.StatusProcessing).
+		Build()
+	if err != nil {
+		t.Fatalf("Failed to build analysis: %v", err)
+	}
+	return a
+}
+
+func TestAnalyzer_FailAnalysis(t *testing.T) {
+	cause := errors.New("LLM request failed")
+
+	tests := []struct {
+		name       string
+		cancelled  bool
+		wantReason string
+	}{
+		{name: "failure keeps cause as reason", wantReason: cause.Error()},
+		{name: "cancellation is recorded as interrupted", cancelled: true, wantReason: interruptedFailureReason},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				repo := &failureRecordingRepo{}
+				a := &analyzer{cfg: &config.LLMAnalysis{}, analysisRepo: repo, clock: clock.New()}
+
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				if tt.cancelled {
+					cancel()
+				}
+
+				entity := newProcessingAnalysis(t)
+				if err := a.failAnalysis(ctx, entity, cause, newTestLogger(t)); err != nil {
+					t.Fatalf("failAnalysis returned error: %v", err)
+				}
+
+				if len(repo.updates) != 1 {
+					t.Fatalf("Expected 1 update, got %d", len(repo.updates))
+				}
+				if repo.ctxErr != nil {
+					t.Errorf("Expected update on a live context, got %v", repo.ctxErr)
+				}
+				update := repo.updates[0]
+				if update.Status != analysis.StatusFailed {
+					t.Errorf("Expected status failed, got %s", update.Status)
+				}
+				if got := update.FailureReason.Unwrap(); got != tt.wantReason {
+					t.Errorf("Expected failure reason %q, got %q", tt.wantReason, got)
+				}
+
+				if len(repo.events) != 1 || repo.events[0].Type != analysis.EventFailed {
+					t.Fatalf("Expected a single failed event, got %+v", repo.events)
+				}
+				if got := repo.events[0].Details["reason"]; got != tt.wantReason {
+					t.Errorf("Expected the failed event to carry reason %q, got %v", tt.wantReason, got)
+				}
+			},
+		)
+	}
+}
+
+func TestAnalyzer_FailStaleAnalyses_Timeout(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		minutes int
+		want    time.Time
+	}{
+		{name: "default timeout", want: now.Add(-defaultStaleAnalysisTimeout)},
+		{name: "configured timeout", minutes: 15, want: now.Add(-15 * time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				repo := &failureRecordingRepo{}
+				a := &analyzer{
+					logger:       newTestLogger(t),
+					cfg:          &config.LLMAnalysis{StaleAnalysisTimeoutMinutes: tt.minutes},
+					analysisRepo: repo,
+					clock:        clock.NewMock(now),
+				}
+
+				a.failStaleAnalyses(context.Background())
+
+				if !repo.staleSince.Equal(tt.want) {
+					t.Errorf("Expected cutoff %v, got %v", tt.want, repo.staleSince)
+				}
+			},
+		)
+	}
+}
+
+// resultUpdateFailingRepo fails storing the results of an analysis and records the other updates.
+type resultUpdateFailingRepo struct {
+	*scheduledAnalysisRepo
+	updates []*analysis.UpdatableFields
+}
+
+func (r *resultUpdateFailingRepo) Update(
+	_ context.Context,
+	_ uuid.UUID,
+	updates *analysis.UpdatableFields,
+	_ ...repository.RepoOption[apprepo.Options],
+) error {
+	if updates.Results.IsSome() {
+		return errors.New("database unavailable")
+	}
+	r.updates = append(r.updates, updates)
+	return nil
+}
+
+func TestAnalyzer_RunAnalysis_FailsOnResultUpdateError(t *testing.T) {
+	repo := &resultUpdateFailingRepo{scheduledAnalysisRepo: &scheduledAnalysisRepo{}}
+	a := &analyzer{
+		cfg:          &config.LLMAnalysis{OpenAIModel: "gpt-test"},
+		analysisRepo: repo,
+		llmClient:    &chunkLLMClient{},
+		clock:        clock.New(),
+	}
+
+	result, _, err := a.runAnalysis(context.Background(), chunkTestFeedbacks(2), newTestLogger(t))
+	if err == nil {
+		t.Fatal("Expected the failed result update to fail the analysis")
+	}
+
+	if len(repo.updates) != 1 || repo.updates[0].Status != analysis.StatusFailed {
+		t.Fatalf("Expected the analysis to be marked failed, got %+v", repo.updates)
+	}
+	reason := repo.updates[0].FailureReason.UnwrapOr("")
+	if !strings.Contains(reason, "failed to update analysis with results") {
+		t.Errorf("Expected the update error as failure reason, got %q", reason)
+	}
+	if result == nil || result.Status() != analysis.StatusFailed {
+		t.Error("Expected the failed analysis to be returned")
+	}
+}
@@ -0,0 +1,84 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+)
+
+// Topics and feedbacks are returned in a deterministic order so that clients do not see
+// lists jump around between requests: topics by feedback count descending, then by enum value.
+
+// sortTopicAnalyses orders topics by feedback count descending, then by topic enum.
+func sortTopicAnalyses(topics []*analysis.TopicAnalysis) {
+	sort.SliceStable(
+		topics, func(i, j int) bool {
+			return topicLess(topics[i].FeedbackCount(), topics[j].FeedbackCount(), topics[i].Topic(), topics[j].Topic())
+		},
+	)
+}
+
+// sortTopicStats orders topic statistics by feedback count descending, then by topic enum.
+func sortTopicStats(stats []services.TopicStats) {
+	sort.SliceStable(
+		stats, func(i, j int) bool {
+			return topicLess(stats[i].FeedbackCount, stats[j].FeedbackCount, stats[i].Topic, stats[j].Topic)
+		},
+	)
+}
+
+// sortFeedbacksNewestFirst orders feedbacks by creation time descending, then by ID.
+func sortFeedbacksNewestFirst(feedbacks []*feedback.Feedback) {
+	sort.SliceStable(
+		feedbacks, func(i, j int) bool {
+			if !feedbacks[i].CreatedAt().Equal(feedbacks[j].CreatedAt()) {
+				return feedbacks[i].CreatedAt().After(feedbacks[j].CreatedAt())
+			}
+			return feedbacks[i].ID().String() < feedbacks[j].ID().String()
+		},
+	)
+}
+
+// sortFeedbacksOldestFirst orders feedbacks by creation time ascending, then by ID.
+func sortFeedbacksOldestFirst(feedbacks []*feedback.Feedback) {
+	sort.SliceStable(
+		feedbacks, func(i, j int) bool {
+			if !feedbacks[i].CreatedAt().Equal(feedbacks[j].CreatedAt()) {
+				return feedbacks[i].CreatedAt().Before(feedbacks[j].CreatedAt())
+			}
+			return feedbacks[i].ID().String() < feedbacks[j].ID().String()
+		},
+	)
+}
+
+// sortFeedbacksLowestRatingFirst orders feedbacks by rating ascending, then oldest first.
+func sortFeedbacksLowestRatingFirst(feedbacks []*feedback.Feedback) {
+	sortFeedbacksOldestFirst(feedbacks)
+	sort.SliceStable(
+		feedbacks, func(i, j int) bool {
+			return feedbacks[i].Rating().Value() < feedbacks[j].Rating().Value()
+		},
+	)
+}
+
+// sortFeedbacksForSelection orders feedback candidates by the priority in which they are sent to the LLM.
+// An empty order selects the oldest feedbacks first.
+func sortFeedbacksForSelection(feedbacks []*feedback.Feedback, order string) {
+	switch order {
+	case selectionOrderNewestFirst:
+		sortFeedbacksNewestFirst(feedbacks)
+	case selectionOrderLowestRatingFirst:
+		sortFeedbacksLowestRatingFirst(feedbacks)
+	default:
+		sortFeedbacksOldestFirst(feedbacks)
+	}
+}
+
+func topicLess(countI, countJ int, topicI, topicJ analysis.Topic) bool {
+	if countI != countJ {
+		return countI > countJ
+	}
+	return topicI < topicJ
+}
@@ -0,0 +1,180 @@
+package analysis
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/google/uuid"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+)
+
+// shufflingAnalysisRepo returns the topics of its analysis in a different order on every call,
+// like the map iteration order behind the real repository queries.
+type shufflingAnalysisRepo struct {
+	apprepo.AnalysisRepository
+	analysis *analysis.Analysis
+	topics   []*analysis.TopicAnalysis
+	rnd      *rand.Rand
+}
+
+func (r *shufflingAnalysisRepo) GetByID(
+	context.Context,
+	uuid.UUID,
+	...repository.RepoOption[apprepo.Options],
+) (*analysis.Analysis, error) {
+	return r.analysis, nil
+}
+
+func (r *shufflingAnalysisRepo) GetLatest(
+	context.Context,
+	...repository.RepoOption[apprepo.Options],
+) (*analysis.Analysis, error) {
+	return r.analysis, nil
+}
+
+func (r *shufflingAnalysisRepo) GetTopicsByAnalysisID(
+	context.Context,
+	uuid.UUID,
+	...repository.RepoOption[apprepo.Options],
+) ([]*analysis.TopicAnalysis, error) {
+	topics := make([]*analysis.TopicAnalysis, len(r.topics))
+	copy(topics, r.topics)
+	r.rnd.Shuffle(len(topics), func(i, j int) { topics[i], topics[j] = topics[j], topics[i] })
+	return topics, nil
+}
+
+func (r *shufflingAnalysisRepo) GetTopicStats(
+	context.Context,
+	...repository.RepoOption[apprepo.Options],
+) ([]analysis.TopicStats, error) {
+	stats := make([]analysis.TopicStats, len(r.topics))
+	for i, topic := range r.topics {
+		stats[i] = analysis.TopicStats{
+			Topic:         topic.Topic(),
+			AnalysisID:    r.analysis.ID(),
+			FeedbackCount: topic.FeedbackCount(),
+		}
+	}
+	r.rnd.Shuffle(len(stats), func(i, j int) { stats[i], stats[j] = stats[j], stats[i] })
+	return stats, nil
+}
+
+func (r *shufflingAnalysisRepo) GetFeedbackIDsByTopicID(
+	context.Context,
+	uuid.UUID,
+	...repository.RepoOption[apprepo.Options],
+) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (r *shufflingAnalysisRepo) GetFeedbackIDsByAnalysisID(
+	context.Context,
+	uuid.UUID,
+	...repository.RepoOption[apprepo.Options],
+) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func (r *shufflingAnalysisRepo) GetTopicDeltasByAnalysisID(
+	context.Context,
+	uuid.UUID,
+	...repository.RepoOption[apprepo.Options],
+) ([]analysis.TopicDelta, error) {
+	return nil, nil
+}
+
+func newShufflingAnalysisRepo(t *testing.T) *shufflingAnalysisRepo {
+	t.Helper()
+
+	analysisID := uuid.New()
+	counts := map[analysis.Topic]int{
+		analysis.TopicUIUX:                         3,
+		analysis.TopicPerformanceReliability:       7,
+		analysis.TopicProductFunctionalityFeatures: 3,
+		analysis.TopicUsabilityProductivity:        1,
+	}
+
+	repo := &shufflingAnalysisRepo{
+		analysis: analysis.NewBuilder().WithID(analysisID).BuildUnchecked(),
+		rnd:      rand.New(rand.NewSource(1)),
+	}
+	for topic, count := range counts {
+		repo.topics = append(
+			repo.topics,
+			analysis.NewTopicAnalysisBuilder().
+				WithAnalysisID(analysisID).
+				WithTopic(topic).
+				WithFeedbackCount(count).
+				BuildUnchecked(),
+		)
+	}
+	return repo
+}
+
+func TestService_GetAnalysisByID_StableTopicOrder(t *testing.T) {
+	repo := newShufflingAnalysisRepo(t)
+	s := &service{logger: newTestLogger(t), analysisRepo: repo}
+
+	// Equal feedback counts are ordered by enum value
+	expected := []analysis.Topic{
+		analysis.TopicPerformanceReliability,
+		analysis.TopicProductFunctionalityFeatures,
+		analysis.TopicUIUX,
+		analysis.TopicUsabilityProductivity,
+	}
+
+	for call := 0; call < 10; call++ {
+		_, topics, _, _, err := s.GetAnalysisByID(context.Background(), repo.analysis.ID())
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+		if len(topics) != len(expected) {
+			t.Fatalf("Expected %d topics, got %d", len(expected), len(topics))
+		}
+		for i, topic := range topics {
+			if topic.Topic() != expected[i] {
+				t.Fatalf("Call %d: expected topic %s at position %d, got %s", call, expected[i], i, topic.Topic())
+			}
+		}
+	}
+}
+
+func TestService_GetTopicsWithStats_StableOrder(t *testing.T) {
+	repo := newShufflingAnalysisRepo(t)
+	s := &service{logger: newTestLogger(t), analysisRepo: repo}
+
+	var first []analysis.Topic
+	for call := 0; call < 10; call++ {
+		overview, err := s.GetTopicsWithStats(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got: %v", err)
+		}
+
+		order := make([]analysis.Topic, len(overview.Topics))
+		for i, stat := range overview.Topics {
+			order[i] = stat.Topic
+			if i > 0 && !topicLess(
+				overview.Topics[i-1].FeedbackCount, stat.FeedbackCount, overview.Topics[i-1].Topic, stat.Topic,
+			) {
+				t.Fatalf("Call %d: topics %s and %s are out of order", call, overview.Topics[i-1].Topic, stat.Topic)
+			}
+		}
+
+		if first == nil {
+			first = order
+			continue
+		}
+		for i := range order {
+			if order[i] != first[i] {
+				t.Fatalf("Call %d: order changed at position %d: %s != %s", call, i, order[i], first[i])
+			}
+		}
+	}
+
+	if first[0] != analysis.TopicPerformanceReliability {
+		t.Errorf("Expected the topic with the most feedbacks first, got %s", first[0])
+	}
+}
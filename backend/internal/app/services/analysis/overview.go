@@ -0,0 +1,53 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+)
+
+// GetAnalyticsOverview computes totals across all analyses.
+func (s *service) GetAnalyticsOverview(ctx context.Context) (*services.AnalyticsOverview, error) {
+	logger := s.logger.WithSpan(ctx)
+	logger.Info("getting analytics overview")
+
+	totals, err := s.analysisRepo.AggregateOverview(ctx)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error aggregating analyses", err)
+		return nil, fmt.Errorf("failed to aggregate analyses: %w", err)
+	}
+
+	overview := s.buildAnalyticsOverview(totals)
+	logger.Info(
+		"analytics overview computed",
+		"total_analyses", overview.TotalAnalyses,
+		"analyzed_feedbacks", overview.AnalyzedFeedbacks,
+		"total_tokens", overview.TotalTokens,
+	)
+	return overview, nil
+}
+
+// buildAnalyticsOverview prices the aggregated tokens and picks the most common topic.
+func (s *service) buildAnalyticsOverview(totals *apprepo.AnalysesOverview) *services.AnalyticsOverview {
+	overview := &services.AnalyticsOverview{
+		TotalAnalyses:               totals.TotalAnalyses,
+		SuccessfulAnalyses:          totals.SuccessfulAnalyses,
+		AnalyzedFeedbacks:           totals.AnalyzedFeedbacks,
+		TotalTokens:                 totals.TotalTokens,
+		AverageFeedbacksPerAnalysis: totals.AverageFeedbacksPerAnalysis,
+	}
+	if s.cfg != nil {
+		overview.EstimatedCostUSD = float64(totals.TotalTokens) * s.cfg.InputTokenPricePerMillion / tokensPerMillion
+	}
+
+	// Topic counts are ordered most common first
+	if len(totals.TopicFeedbacks) > 0 {
+		overview.MostCommonTopic = totals.TopicFeedbacks[0].Topic
+		overview.MostCommonTopicFeedbackCount = totals.TopicFeedbacks[0].FeedbackCount
+	}
+
+	return overview
+}
@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+)
+
+func TestService_BuildAnalyticsOverview(t *testing.T) {
+	s := &service{cfg: &config.LLMAnalysis{InputTokenPricePerMillion: 0.25}}
+
+	overview := s.buildAnalyticsOverview(
+		&apprepo.AnalysesOverview{
+			TotalAnalyses:               3,
+			SuccessfulAnalyses:          2,
+			AnalyzedFeedbacks:           50,
+			TotalTokens:                 2_000_000,
+			AverageFeedbacksPerAnalysis: 25,
+			TopicFeedbacks: []apprepo.TopicFeedbackCount{
+				{Topic: analysis.TopicPerformanceReliability, FeedbackCount: 20},
+				{Topic: analysis.TopicUIUX, FeedbackCount: 5},
+			},
+		},
+	)
+
+	if math.Abs(overview.EstimatedCostUSD-0.5) > 1e-9 {
+		t.Errorf("Expected estimated cost 0.5, got %v", overview.EstimatedCostUSD)
+	}
+	if overview.MostCommonTopic != analysis.TopicPerformanceReliability || overview.MostCommonTopicFeedbackCount != 20 {
+		t.Errorf(
+			"Expected most common topic %s with 20 feedbacks, got %s with %d",
+			analysis.TopicPerformanceReliability, overview.MostCommonTopic, overview.MostCommonTopicFeedbackCount,
+		)
+	}
+
+	empty := s.buildAnalyticsOverview(&apprepo.AnalysesOverview{})
+	if empty.MostCommonTopic != "" || empty.EstimatedCostUSD != 0 {
+		t.Errorf("Expected an empty overview without topics, got %+v", empty)
+	}
+}
+
+func TestService_BuildTokenAccuracy(t *testing.T) {
+	s := &service{cfg: &config.LLMAnalysis{RecordTokenAccuracy: true}}
+
+	counts := make([]int, len(tokenAccuracyBoundaries)+1)
+	counts[0] = 2
+	counts[len(counts)-1] = 1
+	accuracy := s.buildTokenAccuracy(&apprepo.TokenAccuracy{SampleCount: 3, MedianRatio: 0.4, BucketCounts: counts})
+
+	if !accuracy.RecordingEnabled || accuracy.SampleCount != 3 {
+		t.Errorf("Unexpected token accuracy: %+v", accuracy)
+	}
+	if len(accuracy.Buckets) != len(tokenAccuracyBoundaries)+1 {
+		t.Fatalf("Expected %d buckets, got %d", len(tokenAccuracyBoundaries)+1, len(accuracy.Buckets))
+	}
+
+	first, last := accuracy.Buckets[0], accuracy.Buckets[len(accuracy.Buckets)-1]
+	if first.MinRatio != 0 || first.MaxRatio.Unwrap() != tokenAccuracyBoundaries[0] || first.AnalysisCount != 2 {
+		t.Errorf("Unexpected first bucket: %+v", first)
+	}
+	if last.MinRatio != tokenAccuracyBoundaries[len(tokenAccuracyBoundaries)-1] || last.MaxRatio.IsSome() ||
+		last.AnalysisCount != 1 {
+		t.Errorf("Unexpected open-ended last bucket: %+v", last)
+	}
+}
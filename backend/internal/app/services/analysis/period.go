@@ -0,0 +1,38 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+)
+
+// analysisPeriod determines the period recorded with an analysis of the given feedbacks, according to
+// the configured period semantics. With feedback_span the period runs from the oldest to the newest
+// feedback; with analysis_window it is the configured window ending now, regardless of when the
+// feedbacks were submitted.
+func (a *analyzer) analysisPeriod(feedbacks []*feedback.Feedback) (analysis.PeriodSemantics, time.Time, time.Time) {
+	now := a.clock.Now().UTC()
+
+	if analysis.PeriodSemantics(a.cfg.PeriodSemantics) == analysis.PeriodSemanticsAnalysisWindow {
+		window := time.Duration(a.cfg.AnalysisWindowMinutes) * time.Minute
+		return analysis.PeriodSemanticsAnalysisWindow, now.Add(-window), now
+	}
+
+	if len(feedbacks) == 0 {
+		return analysis.PeriodSemanticsFeedbackSpan, now, now
+	}
+
+	periodStart := feedbacks[0].CreatedAt()
+	periodEnd := feedbacks[0].CreatedAt()
+	for _, fb := range feedbacks[1:] {
+		if fb.CreatedAt().Before(periodStart) {
+			periodStart = fb.CreatedAt()
+		}
+		if fb.CreatedAt().After(periodEnd) {
+			periodEnd = fb.CreatedAt()
+		}
+	}
+
+	return analysis.PeriodSemanticsFeedbackSpan, periodStart, periodEnd
+}
@@ -0,0 +1,67 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
+)
+
+func TestAnalyzer_AnalysisPeriod(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	feedbacks := []*feedback.Feedback{
+		feedback.NewBuilder().WithID(uuid.New()).WithCreatedAt(now.Add(-2 * time.Hour)).BuildUnchecked(),
+		feedback.NewBuilder().WithID(uuid.New()).WithCreatedAt(now.Add(-26 * time.Hour)).BuildUnchecked(),
+		feedback.NewBuilder().WithID(uuid.New()).WithCreatedAt(now.Add(-5 * time.Hour)).BuildUnchecked(),
+	}
+
+	tests := []struct {
+		name          string
+		cfg           config.LLMAnalysis
+		feedbacks     []*feedback.Feedback
+		wantSemantics analysis.PeriodSemantics
+		wantStart     time.Time
+		wantEnd       time.Time
+	}{
+		{
+			name:          "feedback span by default",
+			feedbacks:     feedbacks,
+			wantSemantics: analysis.PeriodSemanticsFeedbackSpan,
+			wantStart:     now.Add(-26 * time.Hour),
+			wantEnd:       now.Add(-2 * time.Hour),
+		},
+		{
+			name:          "feedback span without feedbacks",
+			cfg:           config.LLMAnalysis{PeriodSemantics: "feedback_span"},
+			wantSemantics: analysis.PeriodSemanticsFeedbackSpan,
+			wantStart:     now,
+			wantEnd:       now,
+		},
+		{
+			name:          "analysis window ignores feedback times",
+			cfg:           config.LLMAnalysis{PeriodSemantics: "analysis_window", AnalysisWindowMinutes: 60},
+			feedbacks:     feedbacks,
+			wantSemantics: analysis.PeriodSemanticsAnalysisWindow,
+			wantStart:     now.Add(-time.Hour),
+			wantEnd:       now,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &analyzer{cfg: &tt.cfg, clock: clock.NewMock(now)}
+
+			semantics, start, end := a.analysisPeriod(tt.feedbacks)
+			if semantics != tt.wantSemantics {
+				t.Errorf("Expected semantics %q, got %q", tt.wantSemantics, semantics)
+			}
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("Expected period %s - %s, got %s - %s", tt.wantStart, tt.wantEnd, start, end)
+			}
+		})
+	}
+}
@@ -4,14 +4,61 @@ import (
 	"context"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
 )
 
+// ratingFilterExclusionReason is recorded with the feedbacks excluded from analysis by the rating filter.
+const ratingFilterExclusionReason = "rating outside the configured analysis rating filter"
+
+// ExclusionReason returns ratingFilterExclusionReason for feedbacks rejected by the rating filter.
+// Rating-only feedbacks have no reason, they are never listed as unanalyzed in the first place.
+func (a *analyzer) ExclusionReason(fb *feedback.Feedback) string {
+	if fb.IsRatingOnly() || a.cfg.InRatingFilter(fb.Rating().Value()) {
+		return ""
+	}
+	return ratingFilterExclusionReason
+}
+
 // addFeedbackToQueue adds a feedback to the pending queue.
+// Rating-only feedbacks and feedbacks rejected by the rating filter are dropped, so they are never analyzed.
+// The exclusion of the latter was already persisted with the feedback, see ExclusionReason.
+// Once the queue holds MaxPendingQueueSize feedbacks, either the oldest or the new feedback
+// is dropped, depending on the overflow policy.
 func (a *analyzer) addFeedbackToQueue(fb *feedback.Feedback) {
+	if fb.IsRatingOnly() {
+		a.logger.Debug("rating-only feedback excluded from analysis", "feedback_id", fb.ID().String())
+		return
+	}
+
+	if !a.cfg.InRatingFilter(fb.Rating().Value()) {
+		a.logger.Info(
+			"feedback excluded from analysis by rating filter",
+			"feedback_id",
+			fb.ID().String(),
+			"rating",
+			fb.Rating().Value(),
+		)
+		return
+	}
+
 	a.pendingMutex.Lock()
 	defer a.pendingMutex.Unlock()
 
+	if limit := a.cfg.MaxPendingQueueSize; limit > 0 && len(a.pendingFeedbacks) >= limit {
+		if a.cfg.QueueOverflowPolicy == queueOverflowRejectNew {
+			a.recordDroppedFeedback(fb)
+			return
+		}
+
+		a.recordDroppedFeedback(a.pendingFeedbacks[0])
+		a.tokenCache.invalidate(a.pendingFeedbacks[0])
+		a.pendingFeedbacks = append(a.pendingFeedbacks[:0], a.pendingFeedbacks[1:]...)
+	}
+
 	a.pendingFeedbacks = append(a.pendingFeedbacks, fb)
 	a.logger.Info(
 		"feedback added to pending queue",
@@ -22,31 +69,296 @@ func (a *analyzer) addFeedbackToQueue(fb *feedback.Feedback) {
 	)
 }
 
+// excludeByRatingFilter excludes a feedback rejected by the rating filter from analysis, so that it is no longer
+// listed as unanalyzed and reprocessing does not pick it up again. The feedback is not analyzed either way, so failing
+// to persist the exclusion is only logged. It reports whether the exclusion was persisted.
+func (a *analyzer) excludeByRatingFilter(ctx context.Context, fb *feedback.Feedback) bool {
+	if err := a.feedbackRepo.ExcludeFromAnalysis(ctx, fb.ID(), ratingFilterExclusionReason); err != nil {
+		a.logger.Error("failed to exclude feedback from analysis", err, "feedback_id", fb.ID().String())
+		return false
+	}
+	return true
+}
+
+// recordDroppedFeedback counts a feedback dropped because the pending queue is full.
+// The feedback itself stays stored, so it can still be analyzed ad hoc.
+func (a *analyzer) recordDroppedFeedback(fb *feedback.Feedback) {
+	dropped := a.dropped.Add(1)
+	a.logger.Warning(
+		"pending queue full, feedback dropped from analysis",
+		"feedback_id",
+		fb.ID().String(),
+		"policy",
+		a.queueOverflowPolicy(),
+		"max_pending_queue_size",
+		a.cfg.MaxPendingQueueSize,
+		"dropped_total",
+		dropped,
+	)
+}
+
+// queueOverflowPolicy returns the configured overflow policy, defaulting to drop_oldest.
+func (a *analyzer) queueOverflowPolicy() string {
+	if a.cfg.QueueOverflowPolicy == "" {
+		return queueOverflowDropOldest
+	}
+	return a.cfg.QueueOverflowPolicy
+}
+
+// isDebounced reports whether the debounce window since the last analysis is still active.
+// Always false when debounce is disabled.
+func (a *analyzer) isDebounced() bool {
+	if !a.cfg.EnableDebounce {
+		return false
+	}
+
+	a.lastAnalysisMutex.Lock()
+	timeSinceLastAnalysis := a.clock.Since(a.lastAnalysisTime)
+	a.lastAnalysisMutex.Unlock()
+
+	return timeSinceLastAnalysis < time.Duration(a.cfg.DebounceMinutes)*time.Minute
+}
+
+// intervalElapsed reports whether the analysis interval has passed since the pending queue was last drained.
+// Always false when the interval trigger is disabled.
+func (a *analyzer) intervalElapsed() bool {
+	if a.cfg.AnalysisIntervalMinutes <= 0 {
+		return false
+	}
+
+	a.lastTriggerMutex.Lock()
+	timeSinceLastTrigger := a.clock.Since(a.lastTriggerTime)
+	a.lastTriggerMutex.Unlock()
+
+	return timeSinceLastTrigger >= time.Duration(a.cfg.AnalysisIntervalMinutes)*time.Minute
+}
+
+// markTriggered records that the pending queue was drained, restarting the analysis interval.
+// Unlike the debounce window, it is restarted whether or not the analysis succeeds.
+func (a *analyzer) markTriggered() {
+	a.lastTriggerMutex.Lock()
+	a.lastTriggerTime = a.clock.Now()
+	a.lastTriggerMutex.Unlock()
+}
+
+// shouldTrigger reports whether an automatic analysis is due. That is the case once the pending queue
+// holds MinimumNewFeedbacksForAnalysis feedbacks or, with the interval trigger, holds any feedback and
+// the interval has elapsed. Neither trigger fires within the debounce window.
+func (a *analyzer) shouldTrigger() bool {
+	pendingCount := a.pendingCount()
+	if pendingCount == 0 {
+		return false
+	}
+
+	if pendingCount < a.cfg.MinimumNewFeedbacksForAnalysis && !a.intervalElapsed() {
+		return false
+	}
+
+	return !a.isDebounced()
+}
+
+// circuitOpen reports whether the LLM circuit breaker is open, in which case automatic analyses are skipped and
+// the feedbacks stay queued. The breaker half-opens on its own, the next trigger then sends the trial request.
+// Skipping is logged once when it starts and once when it ends, not on every tick.
+func (a *analyzer) circuitOpen() bool {
+	if a.LLMCircuitState() != external.CircuitOpen {
+		if a.circuitSkipping.Swap(false) {
+			a.logger.Info("LLM circuit breaker no longer open, resuming automatic analyses")
+		}
+		return false
+	}
+
+	if !a.circuitSkipping.Swap(true) {
+		a.logger.Warning(
+			"LLM circuit breaker open, skipping automatic analyses until it half-opens",
+			"pending_count", a.pendingCount(),
+		)
+	}
+	return true
+}
+
 // checkAndAnalyze checks if we should trigger an analysis based on configuration.
+// A strongly negative feedback is analyzed first, the batch is then checked on the next tick.
 func (a *analyzer) checkAndAnalyze(ctx context.Context) {
+	if a.analyzeImmediately(ctx) {
+		return
+	}
+
+	if !a.shouldTrigger() {
+		return
+	}
+
+	if a.circuitOpen() {
+		return
+	}
+
+	lock, ok := a.acquireAnalysisLock(ctx)
+	if !ok {
+		return
+	}
+
+	selectedFeedbacks := a.dequeueFeedbacksForAnalysis(ctx)
+	if len(selectedFeedbacks) == 0 {
+		a.releaseAnalysisLock(ctx, lock)
+		return
+	}
+
+	// Trigger analysis with selected feedbacks only
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		defer a.releaseAnalysisLock(ctx, lock)
+
+		_, _ = a.analyzeSelected(ctx, selectedFeedbacks)
+	}()
+}
+
+// immediateAnalysisKey marks the context of an immediate single-feedback analysis.
+type immediateAnalysisKey struct{}
+
+// withImmediateAnalysis returns a copy of the context marking the analysis run with it as immediate.
+func withImmediateAnalysis(ctx context.Context) context.Context {
+	return context.WithValue(ctx, immediateAnalysisKey{}, true)
+}
+
+// isImmediateAnalysis reports whether the context belongs to an immediate single-feedback analysis.
+func isImmediateAnalysis(ctx context.Context) bool {
+	immediate, _ := ctx.Value(immediateAnalysisKey{}).(bool)
+	return immediate
+}
+
+// analyzeImmediately starts a single-feedback analysis of the oldest queued feedback rated at or below
+// ImmediateAnalysisMaxRating, bypassing the minimum feedback threshold and the debounce window. The circuit
+// breaker, the analysis lock and the grace period still apply. It reports whether an analysis was started.
+// While the previous immediate analysis is still running none is started, so that immediate analyses do not
+// pile up when the analysis lock is disabled, but the regular batch is still checked.
+//
+// One feedback says nothing about the overall picture, so immediate analyses are never flagged representative,
+// and they are never used as the previous analysis of the next one.
+func (a *analyzer) analyzeImmediately(ctx context.Context) bool {
+	if a.cfg.ImmediateAnalysisMaxRating <= 0 {
+		return false
+	}
+
+	if a.immediateRunning.Load() {
+		a.logger.Debug("immediate analysis still running, checking the batch only")
+		return false
+	}
+
+	if !a.hasImmediateFeedback() {
+		return false
+	}
+
+	if a.circuitOpen() {
+		return false
+	}
+
+	lock, ok := a.acquireAnalysisLock(ctx)
+	if !ok {
+		return false
+	}
+
+	fb := a.dequeueImmediateFeedback(ctx)
+	if fb == nil {
+		a.releaseAnalysisLock(ctx, lock)
+		return false
+	}
+
+	a.logger.Info(
+		"analyzing strongly negative feedback immediately",
+		"feedback_id",
+		fb.ID().String(),
+		"rating",
+		fb.Rating().Value(),
+	)
+
+	a.immediateRunning.Store(true)
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		defer a.immediateRunning.Store(false)
+		defer a.releaseAnalysisLock(ctx, lock)
+
+		// The debounce window is left as is, so that the regular batch is not delayed by the immediate analysis
+		_, _ = a.analyzeWithBisection(withImmediateAnalysis(ctx), []*feedback.Feedback{fb})
+	}()
+	return true
+}
+
+// hasImmediateFeedback reports whether the pending queue holds a feedback to analyze immediately.
+func (a *analyzer) hasImmediateFeedback() bool {
+	a.pendingMutex.Lock()
+	defer a.pendingMutex.Unlock()
+
+	return a.immediateFeedbackIndex() >= 0
+}
+
+// immediateFeedbackIndex returns the queue position of the oldest feedback rated for immediate analysis that is
+// past the grace period, or -1 if there is none. The caller must hold pendingMutex.
+func (a *analyzer) immediateFeedbackIndex() int {
+	for i, fb := range a.pendingFeedbacks {
+		if a.cfg.IsImmediateAnalysisRating(fb.Rating().Value()) && a.isPastGracePeriod(fb) {
+			return i
+		}
+	}
+	return -1
+}
+
+// dequeueImmediateFeedback removes the oldest feedback to analyze immediately from the pending queue and
+// returns it, or nil if there is none or it was deleted while queued.
+func (a *analyzer) dequeueImmediateFeedback(ctx context.Context) *feedback.Feedback {
+	a.pendingMutex.Lock()
+	i := a.immediateFeedbackIndex()
+	if i < 0 {
+		a.pendingMutex.Unlock()
+		return nil
+	}
+	fb := a.pendingFeedbacks[i]
+	a.pendingFeedbacks = append(a.pendingFeedbacks[:i], a.pendingFeedbacks[i+1:]...)
+	a.pendingMutex.Unlock()
+
+	a.tokenCache.invalidate(fb)
+
+	if a.cfg.FeedbackGracePeriodSeconds > 0 {
+		refreshed := a.refreshFeedbacks(ctx, []*feedback.Feedback{fb})
+		if len(refreshed) == 0 {
+			return nil
+		}
+		fb = refreshed[0]
+	}
+	return fb
+}
+
+// pendingCount returns the number of feedbacks waiting in the pending queue.
+func (a *analyzer) pendingCount() int {
+	a.pendingMutex.Lock()
+	defer a.pendingMutex.Unlock()
+
+	return len(a.pendingFeedbacks)
+}
+
+// dequeueFeedbacksForAnalysis removes the feedbacks that fit within the token and count limits
+// from the pending queue and returns them. With chunked analysis, up to MaxChunksPerAnalysis
+// chunks that each fit the limits are removed. Feedbacks that do not fit stay queued.
+func (a *analyzer) dequeueFeedbacksForAnalysis(ctx context.Context) []*feedback.Feedback {
 	a.pendingMutex.Lock()
-	pendingCount := len(a.pendingFeedbacks)
 	pendingFeedbacks := make([]*feedback.Feedback, len(a.pendingFeedbacks))
 	copy(pendingFeedbacks, a.pendingFeedbacks)
 	a.pendingMutex.Unlock()
 
-	if pendingCount < a.cfg.MinimumNewFeedbacksForAnalysis {
-		return
+	if len(pendingFeedbacks) == 0 {
+		return nil
 	}
 
-	// Check debounce if enabled
-	if a.cfg.EnableDebounce {
-		a.lastAnalysisMutex.Lock()
-		timeSinceLastAnalysis := time.Since(a.lastAnalysisTime)
-		a.lastAnalysisMutex.Unlock()
-
-		if timeSinceLastAnalysis < time.Duration(a.cfg.DebounceMinutes)*time.Minute {
-			return
-		}
+	// Feedbacks within the grace period are not considered and stay queued
+	pendingFeedbacks = a.pastGracePeriod(pendingFeedbacks)
+	if len(pendingFeedbacks) == 0 {
+		a.logger.Debug("all pending feedbacks are within the grace period")
+		return nil
 	}
 
 	// Get previous analysis for token estimation
-	previousAnalysis, err := a.analysisRepo.GetLatest(ctx)
+	previousAnalysis, err := a.analysisRepo.GetLatestRepresentative(ctx)
 	if err != nil {
 		// No previous analysis, continue with nil
 		previousAnalysis = nil
@@ -55,31 +367,44 @@ func (a *analyzer) checkAndAnalyze(ctx context.Context) {
 	// Select feedbacks that fit within token and count limits
 	selectedFeedbacks, remainingFeedbacks := a.selectFeedbacksForAnalysis(pendingFeedbacks, previousAnalysis)
 
+	// With chunked analysis, further chunks that each fit the limits are analyzed with the same trigger
+	for chunks := 1; a.cfg.EnableChunkedAnalysis && chunks < a.maxChunksPerAnalysis(); chunks++ {
+		chunk, rest := a.selectFeedbacksForAnalysis(remainingFeedbacks, previousAnalysis)
+		if len(chunk) == 0 {
+			break
+		}
+		selectedFeedbacks = append(selectedFeedbacks, chunk...)
+		remainingFeedbacks = rest
+	}
+
 	if len(selectedFeedbacks) == 0 {
 		a.logger.Info("no feedbacks selected for analysis (token limit too restrictive)")
-		return
+		return nil
 	}
 
 	// Update pending queue: remove selected feedbacks, keep remaining ones
 	a.pendingMutex.Lock()
-	// Remove selected feedbacks from the queue
-	// We need to find and remove them
 	selectedIDs := make(map[string]bool)
 	for _, fb := range selectedFeedbacks {
 		selectedIDs[fb.ID().String()] = true
 	}
 
+	// Feedbacks that were not selected, including those queued meanwhile, keep their queue position
 	newPending := make([]*feedback.Feedback, 0, len(a.pendingFeedbacks))
 	for _, fb := range a.pendingFeedbacks {
 		if !selectedIDs[fb.ID().String()] {
 			newPending = append(newPending, fb)
 		}
 	}
-	// Add remaining feedbacks back to queue (they weren't selected due to limits)
-	newPending = append(newPending, remainingFeedbacks...)
 	a.pendingFeedbacks = newPending
 	a.pendingMutex.Unlock()
 
+	// Selected feedbacks leave the queue, so their estimates are not needed anymore
+	a.tokenCache.invalidate(selectedFeedbacks...)
+
+	// Any drain of the queue, automatic, scheduled or manual, restarts the analysis interval
+	a.markTriggered()
+
 	if len(remainingFeedbacks) > 0 {
 		a.logger.Info(
 			"feedbacks returned to queue due to token/limit constraints",
@@ -88,7 +413,112 @@ func (a *analyzer) checkAndAnalyze(ctx context.Context) {
 		)
 	}
 
-	// Trigger analysis with selected feedbacks only
-	a.wg.Add(1)
-	go a.performAnalysis(ctx, selectedFeedbacks)
+	if a.cfg.FeedbackGracePeriodSeconds > 0 {
+		selectedFeedbacks = a.refreshFeedbacks(ctx, selectedFeedbacks)
+	}
+
+	return selectedFeedbacks
+}
+
+// pastGracePeriod returns the feedbacks that were created at least FeedbackGracePeriodSeconds ago,
+// keeping their order. All feedbacks are returned when the grace period is disabled.
+func (a *analyzer) pastGracePeriod(feedbacks []*feedback.Feedback) []*feedback.Feedback {
+	if a.cfg.FeedbackGracePeriodSeconds <= 0 {
+		return feedbacks
+	}
+
+	mature := make([]*feedback.Feedback, 0, len(feedbacks))
+	for _, fb := range feedbacks {
+		if a.isPastGracePeriod(fb) {
+			mature = append(mature, fb)
+		}
+	}
+	return mature
+}
+
+// isPastGracePeriod reports whether the feedback was created at least FeedbackGracePeriodSeconds ago.
+func (a *analyzer) isPastGracePeriod(fb *feedback.Feedback) bool {
+	gracePeriod := time.Duration(a.cfg.FeedbackGracePeriodSeconds) * time.Second
+	return gracePeriod <= 0 || a.clock.Since(fb.CreatedAt()) >= gracePeriod
+}
+
+// refreshFeedbacks reloads the selected feedbacks, so that feedbacks deleted while they were queued
+// are not analyzed. If reloading fails, the queued feedbacks are analyzed as they are.
+func (a *analyzer) refreshFeedbacks(ctx context.Context, feedbacks []*feedback.Feedback) []*feedback.Feedback {
+	ids := make([]uuid.UUID, len(feedbacks))
+	for i, fb := range feedbacks {
+		ids[i] = fb.ID()
+	}
+
+	stored, err := a.feedbackRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		a.logger.Error("failed to refresh selected feedbacks, analyzing them as queued", err)
+		return feedbacks
+	}
+
+	if dropped := len(feedbacks) - len(stored); dropped > 0 {
+		a.logger.Info("feedbacks deleted while queued excluded from analysis", "deleted_count", dropped)
+	}
+	return stored
+}
+
+// analyzeSelected analyzes feedbacks taken from the pending queue and restarts the debounce window on success.
+func (a *analyzer) analyzeSelected(ctx context.Context, feedbacks []*feedback.Feedback) (*analysis.Analysis, error) {
+	result, err := a.analyzeWithBisection(ctx, feedbacks)
+	if err != nil {
+		return result, err
+	}
+
+	a.lastAnalysisMutex.Lock()
+	a.lastAnalysisTime = a.clock.Now()
+	a.lastAnalysisMutex.Unlock()
+
+	return result, nil
+}
+
+// analyzeWithBisection analyzes feedbacks taken from the pending queue and, if the model output was invalid,
+// bisects the batch to dead-letter the feedbacks causing it.
+func (a *analyzer) analyzeWithBisection(
+	ctx context.Context,
+	feedbacks []*feedback.Feedback,
+) (*analysis.Analysis, error) {
+	result, err := a.performAnalysis(ctx, feedbacks)
+	if err != nil && a.shouldBisect(result, err) {
+		result, err = a.analyzeWithoutPoisonFeedbacks(ctx, result, feedbacks, err)
+	}
+	return result, err
+}
+
+// acquireAnalysisLock takes the distributed analysis lock so that only one replica analyzes at a time.
+// It reports false if another replica holds the lock or the lock could not be requested, in which case
+// the current tick should be skipped. Without distributed locking it always succeeds with a nil lock.
+func (a *analyzer) acquireAnalysisLock(ctx context.Context) (repository.Lock, bool) {
+	if !a.cfg.EnableDistributedLock || a.locker == nil {
+		return nil, true
+	}
+
+	lock, acquired, err := a.locker.TryLock(ctx, analysisLockKey)
+	if err != nil {
+		a.logger.Error("failed to acquire analysis lock, skipping tick", err)
+		return nil, false
+	}
+
+	if !acquired {
+		a.logger.Debug("analysis lock held by another replica, skipping tick")
+		return nil, false
+	}
+
+	return lock, true
+}
+
+// releaseAnalysisLock releases a lock obtained from acquireAnalysisLock. A nil lock is ignored.
+func (a *analyzer) releaseAnalysisLock(ctx context.Context, lock repository.Lock) {
+	if lock == nil {
+		return
+	}
+
+	// Release even if the analyzer is stopping, so other replicas can take over.
+	if err := lock.Release(context.WithoutCancel(ctx)); err != nil {
+		a.logger.Error("failed to release analysis lock", err)
+	}
 }
@@ -0,0 +1,496 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+)
+
+func TestAnalyzer_IsDebounced_WithinWindow(t *testing.T) {
+	mockClock := clock.NewMock(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	a := &analyzer{
+		cfg:              &config.LLMAnalysis{EnableDebounce: true, DebounceMinutes: 5},
+		clock:            mockClock,
+		lastAnalysisTime: mockClock.Now(),
+	}
+
+	mockClock.Advance(4 * time.Minute)
+
+	if !a.isDebounced() {
+		t.Error("Expected analyzer to be debounced within the debounce window")
+	}
+}
+
+func TestAnalyzer_IsDebounced_AfterWindow(t *testing.T) {
+	mockClock := clock.NewMock(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	a := &analyzer{
+		cfg:              &config.LLMAnalysis{EnableDebounce: true, DebounceMinutes: 5},
+		clock:            mockClock,
+		lastAnalysisTime: mockClock.Now(),
+	}
+
+	mockClock.Advance(5 * time.Minute)
+
+	if a.isDebounced() {
+		t.Error("Expected analyzer not to be debounced once the debounce window has passed")
+	}
+}
+
+func TestAnalyzer_IsDebounced_Disabled(t *testing.T) {
+	mockClock := clock.NewMock(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	a := &analyzer{
+		cfg:              &config.LLMAnalysis{EnableDebounce: false, DebounceMinutes: 5},
+		clock:            mockClock,
+		lastAnalysisTime: mockClock.Now(),
+	}
+
+	if a.isDebounced() {
+		t.Error("Expected analyzer not to be debounced when debounce is disabled")
+	}
+}
+
+func TestAnalyzer_AddFeedbackToQueue_RatingFilter(t *testing.T) {
+	a := &analyzer{
+		logger: newTestLogger(t),
+		cfg:    &config.LLMAnalysis{RatingFilterEnabled: true, RatingFilterMin: 1, RatingFilterMax: 2},
+	}
+
+	for _, rating := range []int{1, 2, 3, 5} {
+		fb, err := feedback.NewBuilder().BuildNew(uuid.New(), rating, "Comment")
+		if err != nil {
+			t.Fatalf("Failed to build feedback: %v", err)
+		}
+		a.addFeedbackToQueue(fb)
+	}
+
+	if got := a.pendingCount(); got != 2 {
+		t.Fatalf("Expected only the 2 feedbacks within the rating filter to be queued, got %d", got)
+	}
+	for _, fb := range a.pendingFeedbacks {
+		if fb.Rating().Value() > 2 {
+			t.Errorf("Expected feedback rated %d to be filtered out", fb.Rating().Value())
+		}
+	}
+}
+
+func TestAnalyzer_ExclusionReason(t *testing.T) {
+	a := &analyzer{cfg: &config.LLMAnalysis{RatingFilterEnabled: true, RatingFilterMin: 1, RatingFilterMax: 2}}
+
+	tests := []struct {
+		name    string
+		rating  int
+		comment string
+		want    string
+	}{
+		{name: "within rating filter", rating: 2, comment: "Comment"},
+		{name: "outside rating filter", rating: 4, comment: "Comment", want: ratingFilterExclusionReason},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				fb, err := feedback.NewBuilder().BuildNew(uuid.New(), tt.rating, tt.comment)
+				if err != nil {
+					t.Fatalf("Failed to build feedback: %v", err)
+				}
+				if got := a.ExclusionReason(fb); got != tt.want {
+					t.Errorf("Expected exclusion reason %q, got %q", tt.want, got)
+				}
+			},
+		)
+	}
+}
+
+func TestAnalyzer_AddFeedbackToQueue_Overflow(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     string
+		wantQueued []int // indexes of the queued feedbacks, in queue order
+	}{
+		{name: "drop oldest by default", policy: "", wantQueued: []int{2, 3, 4}},
+		{name: "drop oldest", policy: "drop_oldest", wantQueued: []int{2, 3, 4}},
+		{name: "reject new", policy: "reject_new", wantQueued: []int{0, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &analyzer{
+				logger: newTestLogger(t),
+				cfg:    &config.LLMAnalysis{MaxPendingQueueSize: 3, QueueOverflowPolicy: tt.policy},
+			}
+
+			feedbacks := make([]*feedback.Feedback, 5)
+			for i := range feedbacks {
+				fb, err := feedback.NewBuilder().BuildNew(uuid.New(), 3, "Comment")
+				if err != nil {
+					t.Fatalf("Failed to build feedback: %v", err)
+				}
+				feedbacks[i] = fb
+				a.addFeedbackToQueue(fb)
+			}
+
+			if got := a.pendingCount(); got != len(tt.wantQueued) {
+				t.Fatalf("Expected %d queued feedbacks, got %d", len(tt.wantQueued), got)
+			}
+			for i, idx := range tt.wantQueued {
+				if a.pendingFeedbacks[i] != feedbacks[idx] {
+					t.Errorf("Expected feedback %d at queue position %d", idx, i)
+				}
+			}
+			if got := a.dropped.Load(); got != 2 {
+				t.Errorf("Expected 2 dropped feedbacks, got %d", got)
+			}
+		})
+	}
+}
+
+func TestAnalyzer_ShouldTrigger(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      config.LLMAnalysis
+		pending  int
+		elapsed  time.Duration // since the last trigger and the last analysis
+		expected bool
+	}{
+		{
+			name:     "count reached",
+			cfg:      config.LLMAnalysis{MinimumNewFeedbacksForAnalysis: 3},
+			pending:  3,
+			expected: true,
+		},
+		{
+			name:     "count not reached without interval",
+			cfg:      config.LLMAnalysis{MinimumNewFeedbacksForAnalysis: 3},
+			pending:  2,
+			elapsed:  24 * time.Hour,
+			expected: false,
+		},
+		{
+			name:     "count not reached within interval",
+			cfg:      config.LLMAnalysis{MinimumNewFeedbacksForAnalysis: 3, AnalysisIntervalMinutes: 60},
+			pending:  2,
+			elapsed:  59 * time.Minute,
+			expected: false,
+		},
+		{
+			name:     "interval elapsed",
+			cfg:      config.LLMAnalysis{MinimumNewFeedbacksForAnalysis: 3, AnalysisIntervalMinutes: 60},
+			pending:  1,
+			elapsed:  60 * time.Minute,
+			expected: true,
+		},
+		{
+			name:     "interval elapsed with empty queue",
+			cfg:      config.LLMAnalysis{MinimumNewFeedbacksForAnalysis: 3, AnalysisIntervalMinutes: 60},
+			elapsed:  2 * time.Hour,
+			expected: false,
+		},
+		{
+			name: "interval elapsed but debounced",
+			cfg: config.LLMAnalysis{
+				MinimumNewFeedbacksForAnalysis: 3,
+				AnalysisIntervalMinutes:        60,
+				EnableDebounce:                 true,
+				DebounceMinutes:                90,
+			},
+			pending:  1,
+			elapsed:  60 * time.Minute,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClock := clock.NewMock(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+			a := &analyzer{
+				logger:           newTestLogger(t),
+				cfg:              &tt.cfg,
+				clock:            mockClock,
+				lastAnalysisTime: mockClock.Now(),
+				lastTriggerTime:  mockClock.Now(),
+			}
+			for range tt.pending {
+				fb, err := feedback.NewBuilder().BuildNew(uuid.New(), 3, "Comment")
+				if err != nil {
+					t.Fatalf("Failed to build feedback: %v", err)
+				}
+				a.addFeedbackToQueue(fb)
+			}
+			mockClock.Advance(tt.elapsed)
+
+			if got := a.shouldTrigger(); got != tt.expected {
+				t.Errorf("Expected shouldTrigger to be %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAnalyzer_PastGracePeriod(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	feedbacks := []*feedback.Feedback{
+		feedback.NewBuilder().WithID(uuid.New()).WithCreatedAt(now.Add(-10 * time.Minute)).BuildUnchecked(),
+		feedback.NewBuilder().WithID(uuid.New()).WithCreatedAt(now.Add(-30 * time.Second)).BuildUnchecked(),
+		feedback.NewBuilder().WithID(uuid.New()).WithCreatedAt(now.Add(-time.Minute)).BuildUnchecked(),
+	}
+
+	tests := []struct {
+		name        string
+		gracePeriod int
+		want        []int
+	}{
+		{name: "disabled", gracePeriod: 0, want: []int{0, 1, 2}},
+		{name: "boundary is past the grace period", gracePeriod: 60, want: []int{0, 2}},
+		{name: "all within", gracePeriod: 3600, want: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				a := &analyzer{
+					cfg:   &config.LLMAnalysis{FeedbackGracePeriodSeconds: tt.gracePeriod},
+					clock: clock.NewMock(now),
+				}
+
+				assertFeedbackOrder(t, "mature", a.pastGracePeriod(feedbacks), feedbacks, tt.want)
+			},
+		)
+	}
+}
+
+// staticCircuit reports a fixed circuit breaker state.
+type staticCircuit external.CircuitState
+
+func (s staticCircuit) CircuitState() external.CircuitState {
+	return external.CircuitState(s)
+}
+
+func TestAnalyzer_CheckAndAnalyze_CircuitOpen(t *testing.T) {
+	a := &analyzer{
+		logger:  newTestLogger(t),
+		cfg:     &config.LLMAnalysis{MinimumNewFeedbacksForAnalysis: 1},
+		clock:   clock.New(),
+		circuit: staticCircuit(external.CircuitOpen),
+	}
+	a.pendingFeedbacks = chunkTestFeedbacks(3)
+
+	// No repository is set, so anything past the breaker check would panic
+	a.checkAndAnalyze(context.Background())
+
+	if got := a.pendingCount(); got != 3 {
+		t.Errorf("Expected the feedbacks to stay queued while the circuit is open, got %d pending", got)
+	}
+	if !a.circuitSkipping.Load() {
+		t.Error("Expected the analyzer to record that it is skipping analyses")
+	}
+
+	a.circuit = staticCircuit(external.CircuitHalfOpen)
+	if a.circuitOpen() || a.circuitSkipping.Load() {
+		t.Error("Expected automatic analyses to resume once the circuit half-opens")
+	}
+}
+
+func TestAnalyzer_DequeueImmediateFeedback(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	newFeedback := func(rating int, age time.Duration) *feedback.Feedback {
+		return feedback.NewBuilder().
+			WithID(uuid.New()).
+			WithRating(feedback.Rating(rating)).
+			WithCreatedAt(now.Add(-age)).
+			BuildUnchecked()
+	}
+	a := &analyzer{
+		logger: newTestLogger(t),
+		cfg:    &config.LLMAnalysis{ImmediateAnalysisMaxRating: 1},
+		clock:  clock.NewMock(now),
+	}
+	feedbacks := []*feedback.Feedback{
+		newFeedback(3, time.Hour),
+		newFeedback(1, time.Minute),
+		newFeedback(2, time.Hour),
+		newFeedback(1, time.Hour),
+	}
+	a.pendingFeedbacks = append([]*feedback.Feedback(nil), feedbacks...)
+
+	if got := a.dequeueImmediateFeedback(context.Background()); got != feedbacks[1] {
+		t.Fatalf("Expected the oldest queued feedback rated 1 to be dequeued, got %v", got)
+	}
+	assertFeedbackOrder(t, "pending", a.pendingFeedbacks, feedbacks, []int{0, 2, 3})
+
+	// The grace period still applies, the younger low-rated feedback is skipped
+	a.cfg.FeedbackGracePeriodSeconds = 1800
+	a.pendingFeedbacks = append([]*feedback.Feedback(nil), feedbacks...)
+	if !a.hasImmediateFeedback() {
+		t.Fatal("Expected a feedback past the grace period to be ready for immediate analysis")
+	}
+	a.pendingFeedbacks = a.pendingFeedbacks[:3]
+	if a.hasImmediateFeedback() {
+		t.Error("Expected feedbacks within the grace period not to be analyzed immediately")
+	}
+
+	a.cfg.ImmediateAnalysisMaxRating = 0
+	if a.analyzeImmediately(context.Background()) {
+		t.Error("Expected no immediate analysis when it is disabled")
+	}
+}
+
+// immediateAnalysisRepo blocks every analysis in GetLatestRepresentative until released, then records the
+// analysis record it is asked to create and fails it.
+type immediateAnalysisRepo struct {
+	apprepo.AnalysisRepository
+	started chan struct{}
+	release chan struct{}
+	created []*analysis.Analysis
+}
+
+func (r *immediateAnalysisRepo) GetLatestRepresentative(
+	context.Context,
+	...repository.RepoOption[apprepo.Options],
+) (*analysis.Analysis, error) {
+	r.started <- struct{}{}
+	<-r.release
+	return nil, errors.New("no previous analysis")
+}
+
+func (r *immediateAnalysisRepo) Create(
+	_ context.Context,
+	a *analysis.Analysis,
+	_ ...repository.RepoOption[apprepo.Options],
+) error {
+	r.created = append(r.created, a)
+	return errors.New("database unavailable")
+}
+
+func TestAnalyzer_AnalyzeImmediately_OneAtATime(t *testing.T) {
+	repo := &immediateAnalysisRepo{started: make(chan struct{}, 2), release: make(chan struct{})}
+	a := &analyzer{
+		logger:       newTestLogger(t),
+		cfg:          &config.LLMAnalysis{ImmediateAnalysisMaxRating: 1, OpenAIModel: "gpt-test"},
+		analysisRepo: repo,
+		clock:        clock.New(),
+	}
+	for range 2 {
+		fb, err := feedback.NewBuilder().BuildNew(uuid.New(), 1, "Checkout is broken")
+		if err != nil {
+			t.Fatalf("Failed to build feedback: %v", err)
+		}
+		a.pendingFeedbacks = append(a.pendingFeedbacks, fb)
+	}
+
+	if !a.analyzeImmediately(context.Background()) {
+		t.Fatal("Expected an immediate analysis to be started")
+	}
+	<-repo.started
+
+	// The second feedback waits until the first immediate analysis is done
+	if a.analyzeImmediately(context.Background()) {
+		t.Error("Expected no immediate analysis to be started while one is running")
+	}
+	if got := a.pendingCount(); got != 1 {
+		t.Errorf("Expected the second feedback to stay queued, got %d pending", got)
+	}
+
+	close(repo.release)
+	a.wg.Wait()
+	if a.immediateRunning.Load() {
+		t.Fatal("Expected the immediate analysis to be done")
+	}
+
+	if !a.analyzeImmediately(context.Background()) {
+		t.Fatal("Expected the next immediate analysis to be started once the previous one is done")
+	}
+	a.wg.Wait()
+
+	if len(repo.created) != 2 {
+		t.Fatalf("Expected 2 immediate analyses, got %d", len(repo.created))
+	}
+	for _, created := range repo.created {
+		if created.IsRepresentative() {
+			t.Error("Expected an immediate analysis not to be flagged representative")
+		}
+	}
+}
+
+// immediateBlockingRepo blocks immediate analyses in GetLatestRepresentative until released, and fails every
+// analysis when its record is created.
+type immediateBlockingRepo struct {
+	apprepo.AnalysisRepository
+	started chan struct{}
+	release chan struct{}
+	mu      sync.Mutex
+	created int
+}
+
+func (r *immediateBlockingRepo) GetLatestRepresentative(
+	ctx context.Context,
+	_ ...repository.RepoOption[apprepo.Options],
+) (*analysis.Analysis, error) {
+	if isImmediateAnalysis(ctx) {
+		close(r.started)
+		<-r.release
+	}
+	return nil, errors.New("no previous analysis")
+}
+
+func (r *immediateBlockingRepo) Create(
+	context.Context,
+	*analysis.Analysis,
+	...repository.RepoOption[apprepo.Options],
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.created++
+	return errors.New("database unavailable")
+}
+
+func TestAnalyzer_CheckAndAnalyze_BatchWhileImmediateRunning(t *testing.T) {
+	repo := &immediateBlockingRepo{started: make(chan struct{}), release: make(chan struct{})}
+	a := &analyzer{
+		logger: newTestLogger(t),
+		cfg: &config.LLMAnalysis{
+			ImmediateAnalysisMaxRating:     1,
+			MinimumNewFeedbacksForAnalysis: 1,
+			MaxTokensPerRequest:            100000,
+			MaxFeedbacksInContext:          10,
+			OpenAIModel:                    "gpt-test",
+		},
+		analysisRepo: repo,
+		clock:        clock.New(),
+	}
+	for _, rating := range []int{1, 4} {
+		fb, err := feedback.NewBuilder().BuildNew(uuid.New(), rating, "Checkout is broken")
+		if err != nil {
+			t.Fatalf("Failed to build feedback: %v", err)
+		}
+		a.pendingFeedbacks = append(a.pendingFeedbacks, fb)
+	}
+
+	a.checkAndAnalyze(context.Background())
+	<-repo.started
+	if got := a.pendingCount(); got != 1 {
+		t.Fatalf("Expected the strongly negative feedback to be analyzed immediately, got %d pending", got)
+	}
+
+	// The batch is not held up by the running immediate analysis
+	a.checkAndAnalyze(context.Background())
+	if got := a.pendingCount(); got != 0 {
+		t.Errorf("Expected the batch to be analyzed while the immediate analysis is running, got %d pending", got)
+	}
+
+	close(repo.release)
+	a.wg.Wait()
+	if repo.created != 2 {
+		t.Fatalf("Expected 2 analyses, got %d", repo.created)
+	}
+}
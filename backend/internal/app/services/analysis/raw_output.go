@@ -0,0 +1,42 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+// storeRawOutput stores the model output of an analysis if raw output storage is enabled, including
+// outputs that could not be parsed. The output is a debugging aid, so failing to store it is only logged.
+func (a *analyzer) storeRawOutput(
+	ctx context.Context,
+	analysisID uuid.UUID,
+	result *external.AnalysisResult,
+	llmErr error,
+	logger tracelog.TraceLogger,
+) {
+	if !a.cfg.StoreRawOutput {
+		return
+	}
+
+	var rawOutput string
+	var invalidOutput *external.InvalidOutputError
+	switch {
+	case llmErr == nil && result != nil:
+		rawOutput = result.RawOutput
+	case errors.As(llmErr, &invalidOutput):
+		rawOutput = invalidOutput.RawOutput
+	}
+	if rawOutput == "" {
+		return
+	}
+
+	if err := a.analysisRepo.SaveRawOutput(ctx, analysisID, rawOutput); err != nil {
+		logger.Error("failed to store raw llm output", err, "analysis_id", analysisID.String())
+		return
+	}
+	logger.Debug("raw llm output stored", "analysis_id", analysisID.String(), "length", len(rawOutput))
+}
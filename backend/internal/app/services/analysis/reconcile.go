@@ -0,0 +1,100 @@
+package analysis
+
+import (
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+// Sentiment reconciliation modes, see config.LLMAnalysis.SentimentReconciliation.
+const (
+	sentimentReconciliationFlag     = "flag"
+	sentimentReconciliationOverride = "override"
+)
+
+// reconcileSentiments compares the sentiment of every single-feedback topic with the rating of its feedback.
+// If they starkly disagree, e.g. a positive sentiment for a low rating, the topic is flagged and, in override
+// mode, given the sentiment of the rating. Topics of several feedbacks are left as is, since one rating
+// does not speak for the others.
+func (a *analyzer) reconcileSentiments(
+	topics []external.Topic,
+	feedbacks []*feedback.Feedback,
+	logger tracelog.TraceLogger,
+) []external.Topic {
+	mode := a.cfg.SentimentReconciliation
+	if mode != sentimentReconciliationFlag && mode != sentimentReconciliationOverride {
+		return topics
+	}
+
+	ratings := make(map[uuid.UUID]int, len(feedbacks))
+	for _, fb := range feedbacks {
+		ratings[fb.ID()] = fb.Rating().Value()
+	}
+
+	scale := feedback.CurrentRatingScale()
+	reconciled := make([]external.Topic, len(topics))
+	for i, topic := range topics {
+		reconciled[i] = topic
+		if len(topic.FeedbackIDs) != 1 {
+			continue
+		}
+		rating, ok := ratings[topic.FeedbackIDs[0]]
+		if !ok {
+			continue
+		}
+
+		ratingSentiment, conflict := ratingConflict(scale, rating, topic.Sentiment)
+		if !conflict {
+			continue
+		}
+
+		reconciled[i].SentimentConflict = true
+		if mode == sentimentReconciliationOverride {
+			reconciled[i].Sentiment = ratingSentiment
+			if topic.SentimentDistribution.IsSome() {
+				reconciled[i].SentimentDistribution = optional.Some(singleSentimentDistribution(ratingSentiment))
+			}
+		}
+
+		logger.Info(
+			"topic sentiment contradicts feedback rating",
+			"topic_enum", string(topic.Topic),
+			"feedback_id", topic.FeedbackIDs[0].String(),
+			"rating", rating,
+			"model_sentiment", string(topic.Sentiment),
+			"reconciled_sentiment", string(reconciled[i].Sentiment),
+			"mode", mode,
+		)
+	}
+	return reconciled
+}
+
+// ratingConflict reports whether the sentiment contradicts a rating at either end of the scale, together with
+// the sentiment the rating stands for. Mixed sentiments and ratings in the middle of the scale never conflict.
+func ratingConflict(scale feedback.RatingScale, rating int, sentiment analysis.Sentiment) (analysis.Sentiment, bool) {
+	switch {
+	case scale.IsLow(rating) && sentiment == analysis.SentimentPositive:
+		return analysis.SentimentNegative, true
+	case scale.IsHigh(rating) && sentiment == analysis.SentimentNegative:
+		return analysis.SentimentPositive, true
+	default:
+		return sentiment, false
+	}
+}
+
+// singleSentimentDistribution returns the distribution of a topic with one feedback of the given sentiment.
+func singleSentimentDistribution(sentiment analysis.Sentiment) analysis.SentimentDistribution {
+	var distribution analysis.SentimentDistribution
+	switch sentiment {
+	case analysis.SentimentPositive:
+		distribution.Positive = 1
+	case analysis.SentimentNegative:
+		distribution.Negative = 1
+	default:
+		distribution.Mixed = 1
+	}
+	return distribution
+}
@@ -0,0 +1,70 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+)
+
+// reprocessPageSize is the number of unanalyzed feedbacks loaded per query when reprocessing.
+const reprocessPageSize = 100
+
+// ReprocessUnanalyzed adds the feedbacks that were never included in a successful analysis back to the
+// pending queue, oldest first, so that feedbacks of failed analyses are not lost from analysis.
+// Feedbacks already queued or rejected by the rating filter are skipped, and the queue size limit applies
+// as for new feedbacks. The queue is analyzed as usual afterwards, or when triggered in on-demand mode.
+func (a *analyzer) ReprocessUnanalyzed(ctx context.Context) (int, error) {
+	logger := a.logger.WithSpan(ctx)
+	logger.Info("reprocessing unanalyzed feedbacks", "pending_count", a.pendingCount())
+
+	queued := a.pendingFeedbackIDs()
+	reprocessed := 0
+	// The queue is in memory, so the unanalyzed feedbacks only change between pages by the exclusions below
+	for offset := 0; ; {
+		feedbacks, err := a.feedbackRepo.ListUnanalyzed(
+			ctx,
+			apprepo.WithOptions(&apprepo.Options{Limit: reprocessPageSize, Offset: offset}),
+		)
+		if err != nil {
+			return reprocessed, fmt.Errorf("failed to list unanalyzed feedbacks: %w", err)
+		}
+
+		excluded := 0
+		for _, fb := range feedbacks {
+			if queued[fb.ID()] {
+				continue
+			}
+			if !a.cfg.InRatingFilter(fb.Rating().Value()) {
+				// E.g. stored before the rating filter was configured, so not excluded yet
+				if a.excludeByRatingFilter(ctx, fb) {
+					excluded++
+				}
+				continue
+			}
+			a.addFeedbackToQueue(fb)
+			reprocessed++
+		}
+
+		if len(feedbacks) < reprocessPageSize {
+			break
+		}
+		offset += reprocessPageSize - excluded
+	}
+
+	logger.Info("unanalyzed feedbacks added to pending queue", "reprocessed_count", reprocessed)
+	return reprocessed, nil
+}
+
+// pendingFeedbackIDs returns the IDs of the feedbacks waiting in the pending queue.
+func (a *analyzer) pendingFeedbackIDs() map[uuid.UUID]bool {
+	a.pendingMutex.Lock()
+	defer a.pendingMutex.Unlock()
+
+	ids := make(map[uuid.UUID]bool, len(a.pendingFeedbacks))
+	for _, fb := range a.pendingFeedbacks {
+		ids[fb.ID()] = true
+	}
+	return ids
+}
@@ -0,0 +1,97 @@
+package analysis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+)
+
+type unanalyzedFeedbackRepo struct {
+	apprepo.FeedbackRepository
+	feedbacks []*feedback.Feedback
+	excluded  map[uuid.UUID]string // feedback ID -> exclusion reason
+}
+
+func (r *unanalyzedFeedbackRepo) ExcludeFromAnalysis(
+	_ context.Context,
+	feedbackID uuid.UUID,
+	reason string,
+	_ ...repository.RepoOption[apprepo.Options],
+) error {
+	if r.excluded == nil {
+		r.excluded = make(map[uuid.UUID]string)
+	}
+	r.excluded[feedbackID] = reason
+	return nil
+}
+
+func (r *unanalyzedFeedbackRepo) ListUnanalyzed(
+	_ context.Context,
+	opts ...repository.RepoOption[apprepo.Options],
+) ([]*feedback.Feedback, error) {
+	options := utils.BuildOpts(opts).Ext
+	// Excluded feedbacks are no longer listed as unanalyzed
+	var unanalyzed []*feedback.Feedback
+	for _, fb := range r.feedbacks {
+		if _, ok := r.excluded[fb.ID()]; !ok {
+			unanalyzed = append(unanalyzed, fb)
+		}
+	}
+	start := min(options.Offset, len(unanalyzed))
+	end := min(options.Offset+options.Limit, len(unanalyzed))
+	return unanalyzed[start:end], nil
+}
+
+func TestAnalyzer_ReprocessUnanalyzed(t *testing.T) {
+	// More than one page, to cover paging through the unanalyzed feedbacks
+	feedbacks := make([]*feedback.Feedback, reprocessPageSize+5)
+	for i := range feedbacks {
+		fb, err := feedback.NewBuilder().BuildNew(uuid.New(), 1+i%5, "Comment")
+		if err != nil {
+			t.Fatalf("Failed to build feedback: %v", err)
+		}
+		feedbacks[i] = fb
+	}
+
+	repo := &unanalyzedFeedbackRepo{feedbacks: feedbacks}
+	a := &analyzer{
+		logger:       newTestLogger(t),
+		cfg:          &config.LLMAnalysis{RatingFilterEnabled: true, RatingFilterMin: 1, RatingFilterMax: 4},
+		feedbackRepo: repo,
+	}
+	// Already queued feedbacks must not be queued twice
+	a.addFeedbackToQueue(feedbacks[0])
+
+	reprocessed, err := a.ReprocessUnanalyzed(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	// Every fifth feedback is rated 5, outside the rating filter, and is excluded so it is not listed again
+	want, wantExcluded := 0, 0
+	for _, fb := range feedbacks[1:] {
+		if fb.Rating().Value() <= 4 {
+			want++
+			continue
+		}
+		wantExcluded++
+		if _, ok := repo.excluded[fb.ID()]; !ok {
+			t.Errorf("Expected feedback rated %d to be excluded from analysis", fb.Rating().Value())
+		}
+	}
+	if len(repo.excluded) != wantExcluded {
+		t.Errorf("Expected %d excluded feedbacks, got %d", wantExcluded, len(repo.excluded))
+	}
+	if reprocessed != want {
+		t.Errorf("Expected %d reprocessed feedbacks, got %d", want, reprocessed)
+	}
+	if got := a.pendingCount(); got != want+1 {
+		t.Errorf("Expected %d pending feedbacks, got %d", want+1, got)
+	}
+}
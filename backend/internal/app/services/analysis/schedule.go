@@ -0,0 +1,94 @@
+package analysis
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// startScheduler registers the configured cron schedule, if any, and starts it.
+// Schedules are evaluated in UTC unless the expression carries a CRON_TZ prefix.
+func (a *analyzer) startScheduler() error {
+	if a.cfg.AnalysisSchedule == "" {
+		return nil
+	}
+
+	// A slow analysis must not be stacked with the next scheduled run.
+	scheduler := cron.New(
+		cron.WithLocation(time.UTC),
+		cron.WithChain(cron.SkipIfStillRunning(cron.DiscardLogger)),
+	)
+	if _, err := scheduler.AddFunc(a.cfg.AnalysisSchedule, a.runScheduledAnalysis); err != nil {
+		return fmt.Errorf("invalid analysis schedule %q: %w", a.cfg.AnalysisSchedule, err)
+	}
+
+	a.scheduler = scheduler
+	a.scheduler.Start()
+	a.logger.Info("scheduled analysis enabled", "schedule", a.cfg.AnalysisSchedule)
+
+	return nil
+}
+
+// stopScheduler stops triggering scheduled analyses. Runs already in progress are tracked by the wait group.
+func (a *analyzer) stopScheduler() {
+	if a.scheduler == nil {
+		return
+	}
+
+	a.scheduler.Stop()
+}
+
+// runScheduledAnalysis drains the pending queue on schedule, even if it holds fewer feedbacks
+// than the minimum threshold. Debounce does not apply to scheduled runs. A queue that does not fit
+// within the token and count limits of one analysis is analyzed in consecutive analyses, until it is
+// empty, nothing more can be selected, an analysis fails or the analyzer stops.
+func (a *analyzer) runScheduledAnalysis() {
+	ctx := a.ctx
+	if ctx.Err() != nil {
+		return
+	}
+
+	pendingCount := a.pendingCount()
+	if pendingCount == 0 {
+		a.logger.Info("scheduled analysis skipped, no pending feedbacks")
+		return
+	}
+
+	if a.circuitOpen() {
+		return
+	}
+
+	a.logger.Info("scheduled analysis triggered", "pending_count", pendingCount)
+
+	if !a.trackRun() {
+		return
+	}
+	defer a.wg.Done()
+
+	lock, ok := a.acquireAnalysisLock(ctx)
+	if !ok {
+		return
+	}
+	defer a.releaseAnalysisLock(ctx, lock)
+
+	for analyses := 0; ctx.Err() == nil; analyses++ {
+		// The breaker may have opened on a previous analysis of this run
+		if analyses > 0 && a.circuitOpen() {
+			return
+		}
+
+		selectedFeedbacks := a.dequeueFeedbacksForAnalysis(ctx)
+		if len(selectedFeedbacks) == 0 {
+			if analyses > 0 {
+				a.logger.Info("scheduled analysis completed", "analysis_count", analyses)
+			}
+			return
+		}
+
+		if _, err := a.analyzeSelected(ctx, selectedFeedbacks); err != nil {
+			a.logger.Error("scheduled analysis failed", err, "pending_count", a.pendingCount())
+			return
+		}
+	}
+}
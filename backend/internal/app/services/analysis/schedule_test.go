@@ -0,0 +1,178 @@
+package analysis
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+)
+
+// scheduledAnalysisRepo accepts every write of an analysis and counts the analyses created.
+type scheduledAnalysisRepo struct {
+	apprepo.AnalysisRepository
+	created int
+}
+
+func (r *scheduledAnalysisRepo) GetLatestRepresentative(
+	context.Context,
+	...repository.RepoOption[apprepo.Options],
+) (*analysis.Analysis, error) {
+	return nil, nil
+}
+
+func (r *scheduledAnalysisRepo) Create(
+	context.Context,
+	*analysis.Analysis,
+	...repository.RepoOption[apprepo.Options],
+) error {
+	r.created++
+	return nil
+}
+
+func (r *scheduledAnalysisRepo) CreateAnalyzedFeedbacks(
+	context.Context,
+	uuid.UUID,
+	[]uuid.UUID,
+	...repository.RepoOption[apprepo.Options],
+) error {
+	return nil
+}
+
+func (r *scheduledAnalysisRepo) CreateEvent(
+	context.Context,
+	*analysis.Event,
+	...repository.RepoOption[apprepo.Options],
+) error {
+	return nil
+}
+
+func (r *scheduledAnalysisRepo) Update(
+	context.Context,
+	uuid.UUID,
+	*analysis.UpdatableFields,
+	...repository.RepoOption[apprepo.Options],
+) error {
+	return nil
+}
+
+func (r *scheduledAnalysisRepo) CreateTopicAnalysis(
+	context.Context,
+	*analysis.TopicAnalysis,
+	...repository.RepoOption[apprepo.Options],
+) error {
+	return nil
+}
+
+func (r *scheduledAnalysisRepo) CreateTopicAssignments(
+	context.Context,
+	uuid.UUID,
+	uuid.UUID,
+	[]uuid.UUID,
+	...repository.RepoOption[apprepo.Options],
+) error {
+	return nil
+}
+
+func (r *scheduledAnalysisRepo) RefreshTopicStats(
+	context.Context,
+	uuid.UUID,
+	...repository.RepoOption[apprepo.Options],
+) error {
+	return nil
+}
+
+func (r *scheduledAnalysisRepo) SaveTokenUsage(
+	context.Context,
+	*analysis.TokenUsage,
+	...repository.RepoOption[apprepo.Options],
+) error {
+	return nil
+}
+
+func newScheduleTestAnalyzer(t *testing.T, llmClient external.LLMClient) (*analyzer, *scheduledAnalysisRepo) {
+	t.Helper()
+
+	repo := &scheduledAnalysisRepo{}
+	a := &analyzer{
+		logger: newTestLogger(t),
+		cfg: &config.LLMAnalysis{
+			MaxTokensPerRequest:   100000,
+			MaxFeedbacksInContext: 2,
+			OpenAIModel:           "gpt-test",
+		},
+		analysisRepo: repo,
+		llmClient:    llmClient,
+		clock:        clock.New(),
+		ctx:          context.Background(),
+	}
+	a.pendingFeedbacks = chunkTestFeedbacks(5)
+	return a, repo
+}
+
+func TestAnalyzer_RunScheduledAnalysis_DrainsQueue(t *testing.T) {
+	a, repo := newScheduleTestAnalyzer(t, &chunkLLMClient{})
+
+	a.runScheduledAnalysis()
+
+	// The queue exceeds MaxFeedbacksInContext, so it is drained in consecutive analyses
+	if repo.created != 3 {
+		t.Errorf("Expected 3 analyses, got %d", repo.created)
+	}
+	if got := a.pendingCount(); got != 0 {
+		t.Errorf("Expected the pending queue to be drained, got %d pending", got)
+	}
+}
+
+func TestAnalyzer_RunScheduledAnalysis_StopsOnFailure(t *testing.T) {
+	llmClient := &chunkLLMClient{failAt: 2}
+	a, repo := newScheduleTestAnalyzer(t, llmClient)
+
+	a.runScheduledAnalysis()
+
+	if repo.created != 2 || llmClient.calls != 2 {
+		t.Errorf("Expected the run to stop after the failed second analysis, got %d analyses", repo.created)
+	}
+	if got := a.pendingCount(); got != 1 {
+		t.Errorf("Expected the feedback of the third analysis to stay queued, got %d pending", got)
+	}
+}
+
+// cancelingLLMClient stops the analyzer as soon as the first analysis reaches the LLM.
+type cancelingLLMClient struct {
+	*chunkLLMClient
+	cancel context.CancelFunc
+}
+
+func (c *cancelingLLMClient) AnalyzeFeedbacks(
+	ctx context.Context,
+	feedbacks []*feedback.Feedback,
+	previousAnalysis *analysis.Analysis,
+	previousTopics []*analysis.TopicAnalysis,
+	contextFeedbacks []*feedback.Feedback,
+) (*external.AnalysisResult, error) {
+	c.cancel()
+	return c.chunkLLMClient.AnalyzeFeedbacks(ctx, feedbacks, previousAnalysis, previousTopics, contextFeedbacks)
+}
+
+func TestAnalyzer_RunScheduledAnalysis_StopsWhenStopped(t *testing.T) {
+	a, repo := newScheduleTestAnalyzer(t, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	a.ctx = ctx
+	a.llmClient = &cancelingLLMClient{chunkLLMClient: &chunkLLMClient{}, cancel: cancel}
+
+	a.runScheduledAnalysis()
+
+	if repo.created != 1 {
+		t.Errorf("Expected no further analysis once the analyzer stopped, got %d analyses", repo.created)
+	}
+	if got := a.pendingCount(); got != 3 {
+		t.Errorf("Expected the remaining feedbacks to stay queued, got %d pending", got)
+	}
+}
@@ -0,0 +1,62 @@
+package analysis
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+)
+
+func TestBuildTopicSentimentTrend(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newAnalysis := func(day int, status analysis.Status) *analysis.Analysis {
+		return analysis.NewBuilder().
+			WithID(uuid.New()).
+			WithPeriod(start, start.AddDate(0, 0, day)).
+			WithStatus(status).
+			BuildUnchecked()
+	}
+	newEntry := func(a *analysis.Analysis, sentiment analysis.Sentiment, count int) apprepo.TopicHistoryEntry {
+		return apprepo.TopicHistoryEntry{
+			TopicAnalysis: analysis.NewTopicAnalysisBuilder().
+				WithAnalysisID(a.ID()).
+				WithTopic(analysis.TopicPricingLicensing).
+				WithSentiment(sentiment).
+				WithFeedbackCount(count).
+				BuildUnchecked(),
+		}
+	}
+
+	first := newAnalysis(1, analysis.StatusSuccess)
+	gap := newAnalysis(2, analysis.StatusSuccess)
+	failed := newAnalysis(3, analysis.StatusFailed)
+	last := newAnalysis(4, analysis.StatusSuccess)
+
+	// Analyses are listed newest first
+	trend := buildTopicSentimentTrend(
+		[]*analysis.Analysis{last, failed, gap, first},
+		[]apprepo.TopicHistoryEntry{
+			newEntry(first, analysis.SentimentPositive, 4),
+			newEntry(last, analysis.SentimentMixed, 1),
+			newEntry(last, analysis.SentimentNegative, 3),
+		},
+	)
+
+	if len(trend) != 3 {
+		t.Fatalf("Expected a point per successful analysis, got %d", len(trend))
+	}
+	if trend[0].AnalysisID != first.ID() || trend[1].AnalysisID != gap.ID() || trend[2].AnalysisID != last.ID() {
+		t.Errorf("Expected points ordered by period end, got %+v", trend)
+	}
+	if trend[0].Sentiment.UnwrapOr("") != analysis.SentimentPositive || trend[0].FeedbackCount.UnwrapOr(0) != 4 {
+		t.Errorf("Unexpected first point: %+v", trend[0])
+	}
+	if trend[1].Sentiment.IsSome() || trend[1].FeedbackCount.IsSome() {
+		t.Errorf("Expected an explicit gap for the analysis without the topic, got %+v", trend[1])
+	}
+	if trend[2].Sentiment.UnwrapOr("") != analysis.SentimentNegative || trend[2].FeedbackCount.UnwrapOr(0) != 4 {
+		t.Errorf("Expected duplicated topics to be merged into the largest sentiment, got %+v", trend[2])
+	}
+}
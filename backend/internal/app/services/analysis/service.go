@@ -3,19 +3,28 @@ package analysis
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
 	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/operations"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
 )
 
+// defaultAnalysesLimit is the page size used when no limit is requested.
+const defaultAnalysesLimit = 100
+
 // service provides read-only access to analysis data.
 // This is separate from AnalyzerService which performs the actual analysis.
 type service struct {
 	logger       tracelog.TraceLogger
+	cfg          *config.LLMAnalysis
+	dbCfg        *config.Database
 	analysisRepo apprepo.AnalysisRepository
 	feedbackRepo apprepo.FeedbackRepository
 }
@@ -23,22 +32,38 @@ type service struct {
 // NewFeedbackSummaryService creates a new analysis service.
 func NewFeedbackSummaryService(
 	logger tracelog.TraceLogger,
+	cfg *config.LLMAnalysis,
+	dbCfg *config.Database,
 	analysisRepo apprepo.AnalysisRepository,
 	feedbackRepo apprepo.FeedbackRepository,
 ) services.FeedbackSummaryService {
 	return &service{
 		logger:       logger.NewGroup("feedback_summary_service"),
+		cfg:          cfg,
+		dbCfg:        dbCfg,
 		analysisRepo: analysisRepo,
 		feedbackRepo: feedbackRepo,
 	}
 }
 
-// GetLatestAnalysis retrieves the latest completed analysis.
-func (s *service) GetLatestAnalysis(ctx context.Context) (*analysis.Analysis, error) {
+// operationTimeout returns the timeout of multi-query database operations, 0 (no timeout) without a database config.
+func (s *service) operationTimeout() time.Duration {
+	if s.dbCfg == nil {
+		return 0
+	}
+	return s.dbCfg.OperationTimeout()
+}
+
+// GetLatestAnalysis retrieves the latest completed analysis, the latest representative one with representativeOnly.
+func (s *service) GetLatestAnalysis(ctx context.Context, representativeOnly bool) (*analysis.Analysis, error) {
 	logger := s.logger.WithSpan(ctx)
-	logger.Info("getting latest analysis")
+	logger.Info("getting latest analysis", "representative_only", representativeOnly)
 
-	latestAnalysis, err := s.analysisRepo.GetLatest(ctx)
+	getLatest := s.analysisRepo.GetLatest
+	if representativeOnly {
+		getLatest = s.analysisRepo.GetLatestRepresentative
+	}
+	latestAnalysis, err := getLatest(ctx)
 	if err != nil {
 		logger.RecordSpanError(ctx, err)
 		logger.Error("error getting latest analysis", err)
@@ -54,27 +79,90 @@ func (s *service) GetLatestAnalysis(ctx context.Context) (*analysis.Analysis, er
 	return latestAnalysis, nil
 }
 
-// GetAllAnalyses retrieves all analyses ordered by creation date (newest first).
-func (s *service) GetAllAnalyses(ctx context.Context) ([]*analysis.Analysis, error) {
+// ListAnalyses retrieves a page of analyses ordered by creation date (newest first).
+func (s *service) ListAnalyses(ctx context.Context, limit, offset int) (*services.Page[*analysis.Analysis], error) {
 	logger := s.logger.WithSpan(ctx)
-	logger.Info("getting all analyses")
+	logger.Info("listing analyses", "limit", limit, "offset", offset)
 
-	analyses, err := s.analysisRepo.List(ctx)
-	if err != nil {
+	if limit <= 0 {
+		limit = defaultAnalysesLimit
+	}
+	if limit > 1000 {
+		return nil, ce.ErrBadRequest("limit cannot exceed 1000")
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	repoOpts := apprepo.WithOptions(&apprepo.Options{Limit: limit, Offset: offset})
+
+	var (
+		analyses []*analysis.Analysis
+		total    int
+	)
+	if err := operations.RunWithTimeout(
+		ctx,
+		s.operationTimeout(),
+		func(ctx context.Context) error {
+			var err error
+			if analyses, err = s.analysisRepo.List(ctx, repoOpts); err != nil {
+				return fmt.Errorf("failed to list analyses: %w", err)
+			}
+			if total, err = s.analysisRepo.Count(ctx, repoOpts); err != nil {
+				return fmt.Errorf("failed to count analyses: %w", err)
+			}
+			return nil
+		},
+	); err != nil {
 		logger.RecordSpanError(ctx, err)
-		logger.Error("error getting all analyses", err)
-		return nil, fmt.Errorf("failed to get analyses: %w", err)
+		logger.Error("error listing analyses", err)
+		return nil, err
 	}
 
-	logger.Info("all analyses retrieved", "count", len(analyses))
-	return analyses, nil
+	logger.Info("analyses listed", "count", len(analyses), "total", total)
+	return &services.Page[*analysis.Analysis]{
+		Items:  analyses,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
 }
 
-// GetAnalysisByID retrieves an analysis by ID with its topics and analyzed feedbacks with their topics.
+// GetAnalysisByID retrieves an analysis by ID with its topics, analyzed feedbacks with their topics
+// and the topic deltas versus the previous analysis.
+// The queries share the database operation timeout, so that large analyses cannot hang the request.
 func (s *service) GetAnalysisByID(ctx context.Context, analysisID uuid.UUID) (
 	*analysis.Analysis,
 	[]*analysis.TopicAnalysis,
 	map[uuid.UUID][]*analysis.TopicAnalysis, // feedback ID -> topics
+	[]analysis.TopicDelta,
+	error,
+) {
+	var (
+		analysisEntity *analysis.Analysis
+		topics         []*analysis.TopicAnalysis
+		feedbackTopics map[uuid.UUID][]*analysis.TopicAnalysis
+		topicDeltas    []analysis.TopicDelta
+	)
+	if err := operations.RunWithTimeout(
+		ctx,
+		s.operationTimeout(),
+		func(ctx context.Context) error {
+			var err error
+			analysisEntity, topics, feedbackTopics, topicDeltas, err = s.getAnalysisByID(ctx, analysisID)
+			return err
+		},
+	); err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	return analysisEntity, topics, feedbackTopics, topicDeltas, nil
+}
+
+func (s *service) getAnalysisByID(ctx context.Context, analysisID uuid.UUID) (
+	*analysis.Analysis,
+	[]*analysis.TopicAnalysis,
+	map[uuid.UUID][]*analysis.TopicAnalysis,
+	[]analysis.TopicDelta,
 	error,
 ) {
 	logger := s.logger.WithSpan(ctx)
@@ -85,7 +173,11 @@ func (s *service) GetAnalysisByID(ctx context.Context, analysisID uuid.UUID) (
 	if err != nil {
 		logger.RecordSpanError(ctx, err)
 		logger.Error("error getting analysis", err, "analysis_id", analysisID)
-		return nil, nil, nil, fmt.Errorf("failed to get analysis: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("failed to get analysis: %w", err)
+	}
+	if analysisEntity == nil {
+		logger.Info("analysis not found", "analysis_id", analysisID.String())
+		return nil, nil, nil, nil, errAnalysisNotFound()
 	}
 
 	// Get topics for this analysis
@@ -93,15 +185,17 @@ func (s *service) GetAnalysisByID(ctx context.Context, analysisID uuid.UUID) (
 	if err != nil {
 		logger.RecordSpanError(ctx, err)
 		logger.Error("error getting topics", err, "analysis_id", analysisID)
-		return nil, nil, nil, fmt.Errorf("failed to get topics: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("failed to get topics: %w", err)
 	}
+	// Sorting first also orders the topics of every feedback in the map built below
+	sortTopicAnalyses(topics)
 
 	// Get feedback IDs analyzed in this analysis
 	feedbackIDs, err := s.analysisRepo.GetFeedbackIDsByAnalysisID(ctx, analysisID)
 	if err != nil {
 		logger.RecordSpanError(ctx, err)
 		logger.Error("error getting feedback IDs", err, "analysis_id", analysisID)
-		return nil, nil, nil, fmt.Errorf("failed to get feedback IDs: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("failed to get feedback IDs: %w", err)
 	}
 
 	// Build map of feedback ID -> topics
@@ -123,6 +217,14 @@ func (s *service) GetAnalysisByID(ctx context.Context, analysisID uuid.UUID) (
 		}
 	}
 
+	// Get the topic deltas stored when the analysis completed
+	topicDeltas, err := s.analysisRepo.GetTopicDeltasByAnalysisID(ctx, analysisID)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting topic deltas", err, "analysis_id", analysisID)
+		return nil, nil, nil, nil, fmt.Errorf("failed to get topic deltas: %w", err)
+	}
+
 	logger.Info(
 		"analysis retrieved",
 		"analysis_id",
@@ -132,94 +234,176 @@ func (s *service) GetAnalysisByID(ctx context.Context, analysisID uuid.UUID) (
 		"feedbacks_count",
 		len(feedbackIDs),
 	)
-	return analysisEntity, topics, feedbackTopics, nil
+	return analysisEntity, topics, feedbackTopics, topicDeltas, nil
 }
 
-// GetTopicsWithStats retrieves all predefined topics with their statistics from the latest analysis.
-func (s *service) GetTopicsWithStats(ctx context.Context) ([]services.TopicStats, error) {
+// GetAnalysisStatus retrieves an analysis without its topics and feedbacks, for polling its progress.
+func (s *service) GetAnalysisStatus(ctx context.Context, analysisID uuid.UUID) (*analysis.Analysis, error) {
 	logger := s.logger.WithSpan(ctx)
-	logger.Info("getting topics with stats")
 
-	// Get latest analysis
-	latestAnalysis, err := s.analysisRepo.GetLatest(ctx)
+	analysisEntity, err := s.analysisRepo.GetByID(ctx, analysisID)
 	if err != nil {
 		logger.RecordSpanError(ctx, err)
-		logger.Error("error getting latest analysis", err)
-		return nil, fmt.Errorf("failed to get latest analysis: %w", err)
+		logger.Error("error getting analysis status", err, "analysis_id", analysisID)
+		return nil, fmt.Errorf("failed to get analysis: %w", err)
+	}
+	if analysisEntity == nil {
+		return nil, errAnalysisNotFound()
 	}
 
-	if latestAnalysis == nil {
-		logger.Info("no analysis found, returning empty topics")
-		// Return all predefined topics with zero stats
-		allTopics := analysis.AllTopics()
-		stats := make([]services.TopicStats, len(allTopics))
-		for i, topic := range allTopics {
-			stats[i] = services.TopicStats{
-				Topic:         topic,
-				FeedbackCount: 0,
-				AverageRating: 0,
-			}
+	return analysisEntity, nil
+}
+
+// GetAnalysisRawOutput retrieves the stored model output of an analysis.
+func (s *service) GetAnalysisRawOutput(ctx context.Context, analysisID uuid.UUID) (*analysis.RawOutput, error) {
+	logger := s.logger.WithSpan(ctx)
+
+	analysisEntity, err := s.analysisRepo.GetByID(ctx, analysisID)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting analysis", err, "analysis_id", analysisID)
+		return nil, fmt.Errorf("failed to get analysis: %w", err)
+	}
+	if analysisEntity == nil {
+		return nil, errAnalysisNotFound()
+	}
+
+	rawOutput, err := s.analysisRepo.GetRawOutput(ctx, analysisID)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting raw output", err, "analysis_id", analysisID)
+		return nil, fmt.Errorf("failed to get raw output: %w", err)
+	}
+	if rawOutput == nil {
+		// Storage is opt-in, and analyses that failed before the model answered have no output
+		return nil, &ce.GenericError{
+			Code:       ce.ErrorCodeNotFound,
+			Message:    "No raw output stored for this analysis",
+			UserFacing: true,
 		}
-		return stats, nil
 	}
 
-	// Get topics from latest analysis
-	topics, err := s.analysisRepo.GetTopicsByAnalysisID(ctx, latestAnalysis.ID())
+	return rawOutput, nil
+}
+
+// GetAnalysisEvents retrieves the lifecycle timeline of an analysis.
+func (s *service) GetAnalysisEvents(ctx context.Context, analysisID uuid.UUID) ([]*analysis.Event, error) {
+	logger := s.logger.WithSpan(ctx)
+
+	analysisEntity, err := s.analysisRepo.GetByID(ctx, analysisID)
 	if err != nil {
 		logger.RecordSpanError(ctx, err)
-		logger.Error("error getting topics", err, "analysis_id", latestAnalysis.ID())
-		return nil, fmt.Errorf("failed to get topics: %w", err)
+		logger.Error("error getting analysis", err, "analysis_id", analysisID)
+		return nil, fmt.Errorf("failed to get analysis: %w", err)
+	}
+	if analysisEntity == nil {
+		return nil, errAnalysisNotFound()
 	}
 
-	// Build map of topic enum -> topic analysis
-	topicMap := make(map[analysis.Topic]*analysis.TopicAnalysis)
-	for _, topic := range topics {
-		topicMap[topic.Topic()] = topic
+	// Analyses created before events were recorded have an empty timeline
+	events, err := s.analysisRepo.ListEvents(ctx, analysisID)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting analysis events", err, "analysis_id", analysisID)
+		return nil, fmt.Errorf("failed to get analysis events: %w", err)
 	}
 
-	// Build stats for all predefined topics
-	allTopics := analysis.AllTopics()
-	stats := make([]services.TopicStats, len(allTopics))
-	for i, topicEnum := range allTopics {
-		stats[i] = services.TopicStats{
-			Topic:         topicEnum,
-			FeedbackCount: 0,
-			AverageRating: 0,
+	return events, nil
+}
+
+// GetTopicAnalysisByID retrieves a topic analysis by its ID with its assigned feedbacks, newest first.
+func (s *service) GetTopicAnalysisByID(ctx context.Context, topicID uuid.UUID) (
+	*analysis.TopicAnalysis,
+	[]*feedback.Feedback,
+	error,
+) {
+	logger := s.logger.WithSpan(ctx)
+	logger.Info("getting topic analysis by ID", "topic_id", topicID.String())
+
+	topicAnalysis, err := s.analysisRepo.GetTopicAnalysisByID(ctx, topicID)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting topic analysis", err, "topic_id", topicID)
+		return nil, nil, fmt.Errorf("failed to get topic analysis: %w", err)
+	}
+	if topicAnalysis == nil {
+		logger.Info("topic analysis not found", "topic_id", topicID.String())
+		return nil, nil, &ce.GenericError{
+			Code:       ce.ErrorCodeNotFound,
+			Message:    "Topic analysis not found",
+			UserFacing: true,
 		}
+	}
 
-		// If this topic exists in the latest analysis, get its stats
-		if topicAnalysis, exists := topicMap[topicEnum]; exists {
-			stats[i].FeedbackCount = topicAnalysis.FeedbackCount()
+	feedbackIDs, err := s.analysisRepo.GetFeedbackIDsByTopicID(ctx, topicID)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting feedback IDs", err, "topic_id", topicID)
+		return nil, nil, fmt.Errorf("failed to get feedback IDs: %w", err)
+	}
 
-			// Get feedback IDs for this topic and calculate average rating
-			feedbackIDs, err := s.analysisRepo.GetFeedbackIDsByTopicID(ctx, topicAnalysis.ID())
-			if err != nil {
-				logger.Warning("error getting feedback IDs for topic", "topic_id", topicAnalysis.ID().String(), "error", err.Error())
-				continue
-			}
+	// A single batch query instead of one query per assigned feedback
+	feedbacks, err := s.feedbackRepo.GetByIDs(ctx, feedbackIDs)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting feedbacks", err, "topic_id", topicID)
+		return nil, nil, fmt.Errorf("failed to get feedbacks: %w", err)
+	}
+	sortFeedbacksNewestFirst(feedbacks)
 
-			if len(feedbackIDs) > 0 {
-				// Get feedbacks and calculate average rating
-				totalRating := 0
-				validFeedbacks := 0
-				for _, fbID := range feedbackIDs {
-					fb, err := s.feedbackRepo.Get(ctx, fbID)
-					if err != nil {
-						logger.Warning("error getting feedback", "feedback_id", fbID.String(), "error", err.Error())
-						continue
-					}
-					totalRating += fb.Rating().Value()
-					validFeedbacks++
-				}
-				if validFeedbacks > 0 {
-					stats[i].AverageRating = float64(totalRating) / float64(validFeedbacks)
-				}
-			}
-		}
+	logger.Info(
+		"topic analysis retrieved",
+		"topic_id",
+		topicID.String(),
+		"analysis_id",
+		topicAnalysis.AnalysisID().String(),
+		"feedbacks_count",
+		len(feedbacks),
+	)
+	return topicAnalysis, feedbacks, nil
+}
+
+// errAnalysisNotFound is the user-facing error for an unknown analysis ID.
+func errAnalysisNotFound() error {
+	return &ce.GenericError{
+		Code:       ce.ErrorCodeNotFound,
+		Message:    "Analysis not found",
+		UserFacing: true,
+	}
+}
+
+// GetTopicsWithStats retrieves all predefined topics with their materialized statistics from the latest analysis.
+func (s *service) GetTopicsWithStats(ctx context.Context) (*services.TopicStatsOverview, error) {
+	logger := s.logger.WithSpan(ctx)
+	logger.Info("getting topics with stats")
+
+	// Get latest analysis
+	latestAnalysis, err := s.analysisRepo.GetLatest(ctx)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting latest analysis", err)
+		return nil, fmt.Errorf("failed to get latest analysis: %w", err)
 	}
 
-	logger.Info("topics with stats retrieved", "topics_count", len(stats))
-	return stats, nil
+	if latestAnalysis == nil {
+		logger.Info("no analysis found, returning empty topics")
+	}
+
+	overview, err := s.topicStatsOverview(ctx, latestAnalysis)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting topic stats", err)
+		return nil, err
+	}
+
+	logger.Info(
+		"topics with stats retrieved",
+		"topics_count",
+		len(overview.Topics),
+		"no_topics_identified",
+		overview.NoTopicsIdentified,
+	)
+	return overview, nil
 }
 
 // GetTopicDetails retrieves details for a specific topic enum with all associated feedbacks.
@@ -281,6 +465,8 @@ func (s *service) GetTopicDetails(ctx context.Context, topicEnum analysis.Topic)
 		totalRating += fb.Rating().Value()
 	}
 
+	sortFeedbacksNewestFirst(feedbacks)
+
 	averageRating := 0.0
 	if len(feedbacks) > 0 {
 		averageRating = float64(totalRating) / float64(len(feedbacks))
@@ -292,9 +478,19 @@ func (s *service) GetTopicDetails(ctx context.Context, topicEnum analysis.Topic)
 		FeedbackCount: len(feedbacks),
 		AverageRating: averageRating,
 		Sentiment:     topicAnalysis.Sentiment(),
+		Alignment:     computeSentimentAlignment(topicAnalysis.Sentiment(), feedbacks, feedback.CurrentRatingScale()),
 		Feedbacks:     feedbacks,
 	}
 
+	if details.Alignment.IsMismatch() {
+		logger.Warning(
+			"topic sentiment contradicts feedback ratings",
+			"topic_enum", string(topicEnum),
+			"sentiment", string(details.Sentiment),
+			"implied_sentiment", string(details.Alignment.ImpliedSentiment),
+		)
+	}
+
 	logger.Info("topic details retrieved", "topic_enum", string(topicEnum), "feedbacks_count", len(feedbacks))
 	return details, nil
 }
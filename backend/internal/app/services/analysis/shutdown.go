@@ -6,10 +6,17 @@ import (
 )
 
 // Stop stops the analyzer service gracefully.
+// It waits for running analyses until ctx is done, so that an analysis blocked on a
+// non-cancellable operation cannot hang the shutdown past its deadline.
 func (a *analyzer) Stop(ctx context.Context) error {
 	a.logger.Info("stopping LLM analyzer service")
+	a.ready.Store(false)
 
-	a.cancel() // cancel the context to stop receiving new feedbacks and wait for other goroutines to finish
+	a.stopScheduler()
+	// Cancel the context to stop receiving new feedbacks and wait for other goroutines to finish
+	a.stopMutex.Lock()
+	a.cancel()
+	a.stopMutex.Unlock()
 	done := make(chan struct{})
 	go func() {
 		a.wg.Wait()
@@ -21,6 +28,22 @@ func (a *analyzer) Stop(ctx context.Context) error {
 		a.logger.Info("analyzer service stopped gracefully")
 		return nil
 	case <-ctx.Done():
-		return fmt.Errorf("timeout waiting for analyzer to stop")
+		running := a.running.Load()
+		a.logger.Warning("analyzer did not stop in time, forcing shutdown", "running_analyses", running)
+		return fmt.Errorf("forced shutdown, %d analyses still running: %w", running, ctx.Err())
 	}
 }
+
+// trackRun registers a run started outside the run loop for Stop to wait for, and reports false without registering
+// it once the analyzer is stopping. A run that was registered calls a.wg.Done when it finishes. Runs started by the
+// run loop add to a.wg directly, since the loop itself stays registered until they were added.
+func (a *analyzer) trackRun() bool {
+	a.stopMutex.Lock()
+	defer a.stopMutex.Unlock()
+
+	if a.ctx.Err() != nil {
+		return false
+	}
+	a.wg.Add(1)
+	return true
+}
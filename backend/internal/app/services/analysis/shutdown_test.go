@@ -0,0 +1,126 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/log"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+func newTestLogger(t *testing.T) tracelog.TraceLogger {
+	t.Helper()
+
+	tracer, err := trace.NewTracer(trace.Config{ServiceName: "analysis-test"})
+	if err != nil {
+		t.Fatalf("Failed to create tracer: %v", err)
+	}
+
+	return tracelog.NewTraceLogger(log.NewLogger("development"), tracer)
+}
+
+// slowAnalysisRepo blocks in GetLatestRepresentative until released, ignoring context cancellation,
+// and then fails the analysis before anything is persisted.
+type slowAnalysisRepo struct {
+	apprepo.AnalysisRepository
+	started chan struct{}
+	release chan struct{}
+}
+
+func (r *slowAnalysisRepo) GetLatestRepresentative(
+	context.Context,
+	...repository.RepoOption[apprepo.Options],
+) (*analysis.Analysis, error) {
+	close(r.started)
+	<-r.release
+	return nil, errors.New("no previous analysis")
+}
+
+func (r *slowAnalysisRepo) Create(
+	context.Context,
+	*analysis.Analysis,
+	...repository.RepoOption[apprepo.Options],
+) error {
+	return errors.New("database unavailable")
+}
+
+func TestAnalyzer_Stop_ForcedOnDeadline(t *testing.T) {
+	repo := &slowAnalysisRepo{started: make(chan struct{}), release: make(chan struct{})}
+	a := &analyzer{
+		logger:       newTestLogger(t),
+		cfg:          &config.LLMAnalysis{},
+		analysisRepo: repo,
+		clock:        clock.New(),
+	}
+	a.ctx, a.cancel = context.WithCancel(context.Background())
+
+	fb, err := feedback.NewBuilder().BuildNew(uuid.New(), 3, "Slow analysis")
+	if err != nil {
+		t.Fatalf("Failed to build feedback: %v", err)
+	}
+
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		_, _ = a.performAnalysis(a.ctx, []*feedback.Feedback{fb})
+	}()
+	<-repo.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err = a.Stop(ctx)
+	if err == nil {
+		t.Fatal("Expected an error when the analysis outlives the shutdown deadline")
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the error to wrap context.DeadlineExceeded, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "1 analyses still running") {
+		t.Errorf("Expected the error to report the running analysis, got: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected Stop to return at the deadline, took %s", elapsed)
+	}
+
+	close(repo.release)
+	a.wg.Wait()
+	if got := a.running.Load(); got != 0 {
+		t.Errorf("Expected no running analyses after release, got %d", got)
+	}
+}
+
+func TestAnalyzer_Stop_Graceful(t *testing.T) {
+	a := &analyzer{logger: newTestLogger(t)}
+	a.ctx, a.cancel = context.WithCancel(context.Background())
+
+	if err := a.Stop(context.Background()); err != nil {
+		t.Errorf("Expected graceful stop without running analyses, got: %v", err)
+	}
+}
+
+func TestAnalyzer_TriggerAnalysis_AfterStop(t *testing.T) {
+	a := &analyzer{logger: newTestLogger(t), cfg: &config.LLMAnalysis{}}
+	a.ctx, a.cancel = context.WithCancel(context.Background())
+
+	if err := a.Stop(context.Background()); err != nil {
+		t.Fatalf("Expected graceful stop without running analyses, got: %v", err)
+	}
+
+	// The pending queue is empty, so without the guard the trigger would fail with ErrNoFeedbacks instead
+	if _, err := a.TriggerAnalysis(context.Background()); !errors.Is(err, ErrAnalyzerStopped) {
+		t.Errorf("Expected ErrAnalyzerStopped after Stop, got: %v", err)
+	}
+}
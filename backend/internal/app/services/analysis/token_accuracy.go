@@ -0,0 +1,66 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
+)
+
+// tokenAccuracyBoundaries delimit the ratio buckets of the token accuracy distribution.
+// They are finer around 1, where a calibrated estimator should land.
+var tokenAccuracyBoundaries = []float64{0.5, 0.8, 0.95, 1.05, 1.25, 2}
+
+// GetTokenAccuracy computes the distribution of the ratio of estimated to actual tokens.
+func (s *service) GetTokenAccuracy(ctx context.Context) (*services.TokenAccuracy, error) {
+	logger := s.logger.WithSpan(ctx)
+	logger.Info("getting token accuracy")
+
+	totals, err := s.analysisRepo.AggregateTokenAccuracy(ctx, tokenAccuracyBoundaries)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error aggregating token usage", err)
+		return nil, fmt.Errorf("failed to aggregate token usage: %w", err)
+	}
+
+	accuracy := s.buildTokenAccuracy(totals)
+	logger.Info(
+		"token accuracy computed",
+		"sample_count", accuracy.SampleCount,
+		"median_ratio", accuracy.MedianRatio,
+	)
+	return accuracy, nil
+}
+
+// buildTokenAccuracy attaches the ratio range to every bucket count.
+func (s *service) buildTokenAccuracy(totals *apprepo.TokenAccuracy) *services.TokenAccuracy {
+	accuracy := &services.TokenAccuracy{
+		RecordingEnabled: s.cfg != nil && s.cfg.RecordTokenAccuracy,
+		SampleCount:      totals.SampleCount,
+		MeanRatio:        totals.MeanRatio,
+		MinRatio:         totals.MinRatio,
+		MaxRatio:         totals.MaxRatio,
+		P10Ratio:         totals.P10Ratio,
+		MedianRatio:      totals.MedianRatio,
+		P90Ratio:         totals.P90Ratio,
+		Buckets:          make([]services.TokenAccuracyBucket, len(tokenAccuracyBoundaries)+1),
+	}
+
+	for i := range accuracy.Buckets {
+		bucket := services.TokenAccuracyBucket{MaxRatio: optional.None[float64]()}
+		if i > 0 {
+			bucket.MinRatio = tokenAccuracyBoundaries[i-1]
+		}
+		if i < len(tokenAccuracyBoundaries) {
+			bucket.MaxRatio = optional.Some(tokenAccuracyBoundaries[i])
+		}
+		if i < len(totals.BucketCounts) {
+			bucket.AnalysisCount = totals.BucketCounts[i]
+		}
+		accuracy.Buckets[i] = bucket
+	}
+
+	return accuracy
+}
@@ -0,0 +1,125 @@
+package analysis
+
+import (
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+)
+
+const (
+	// maxTokenCacheEntries caps the token cache; it is cleared once full so that feedbacks estimated
+	// outside the pending queue (ad-hoc analyses, history padding) cannot grow it without bound.
+	maxTokenCacheEntries = 10000
+
+	// parallelEstimationThreshold is the batch size below which tokens are estimated serially,
+	// since spawning workers costs more than it saves for small batches.
+	parallelEstimationThreshold = 256
+)
+
+// cachedTokenCount is the token estimate of a feedback as of its last update.
+type cachedTokenCount struct {
+	updatedAt time.Time
+	tokens    int
+}
+
+// feedbackTokenCache caches per-feedback token estimates keyed by feedback ID, so that repeated
+// selection ticks over the same pending queue do not re-tokenize the same comments.
+// An entry is only used while the feedback's updated_at matches, so editing a feedback invalidates it.
+// A nil cache is valid and estimates every feedback.
+type feedbackTokenCache struct {
+	mu      sync.RWMutex
+	entries map[uuid.UUID]cachedTokenCount
+}
+
+func newFeedbackTokenCache() *feedbackTokenCache {
+	return &feedbackTokenCache{entries: make(map[uuid.UUID]cachedTokenCount)}
+}
+
+// tokens returns the token estimate of the feedback, estimating and caching it on a miss.
+func (c *feedbackTokenCache) tokens(fb *feedback.Feedback) int {
+	if c == nil {
+		return estimateFeedbackTokens(fb)
+	}
+
+	c.mu.RLock()
+	entry, ok := c.entries[fb.ID()]
+	c.mu.RUnlock()
+	if ok && entry.updatedAt.Equal(fb.UpdatedAt()) {
+		return entry.tokens
+	}
+
+	tokens := estimateFeedbackTokens(fb)
+
+	c.mu.Lock()
+	if len(c.entries) >= maxTokenCacheEntries {
+		clear(c.entries)
+	}
+	c.entries[fb.ID()] = cachedTokenCount{updatedAt: fb.UpdatedAt(), tokens: tokens}
+	c.mu.Unlock()
+
+	return tokens
+}
+
+// invalidate removes the cached estimates of the given feedbacks, e.g. once they left the pending queue.
+func (c *feedbackTokenCache) invalidate(feedbacks ...*feedback.Feedback) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, fb := range feedbacks {
+		delete(c.entries, fb.ID())
+	}
+}
+
+// estimateFeedbacksTokens returns the token estimate of every feedback, in the same order.
+// Large batches are estimated by a bounded pool of TokenEstimationWorkers workers.
+func (a *analyzer) estimateFeedbacksTokens(feedbacks []*feedback.Feedback) []int {
+	tokens := make([]int, len(feedbacks))
+
+	workers := a.tokenEstimationWorkers()
+	if workers <= 1 || len(feedbacks) < parallelEstimationThreshold {
+		for i, fb := range feedbacks {
+			tokens[i] = a.tokenCache.tokens(fb)
+		}
+		return tokens
+	}
+
+	// Each worker estimates a contiguous chunk and writes only its own indexes
+	chunkSize := (len(feedbacks) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < len(feedbacks); start += chunkSize {
+		end := min(start+chunkSize, len(feedbacks))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				tokens[i] = a.tokenCache.tokens(feedbacks[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	return tokens
+}
+
+// tokenEstimationWorkers returns the configured number of estimation workers, defaulting to GOMAXPROCS.
+func (a *analyzer) tokenEstimationWorkers() int {
+	if a.cfg.TokenEstimationWorkers > 0 {
+		return a.cfg.TokenEstimationWorkers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// sumTokens returns the sum of the given token estimates.
+func sumTokens(tokens []int) int {
+	total := 0
+	for _, t := range tokens {
+		total += t
+	}
+	return total
+}
@@ -1,6 +1,7 @@
 package analysis
 
 import (
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
 )
@@ -17,15 +18,17 @@ func estimateTokens(text string) int {
 // estimateFeedbackTokens estimates tokens for a single feedback.
 func estimateFeedbackTokens(fb *feedback.Feedback) int {
 	// Estimate tokens for feedback JSON representation
-	// Format: {"id": "...", "rating": 5, "comment": "..."}
+	// Format: {"id": "...", "rating": 5, "comment": "...", "source": "..."}
 	idTokens := estimateTokens(fb.ID().String())
 	commentTokens := estimateTokens(fb.Comment().Value())
-	// Rating is just a number, minimal tokens
-	ratingTokens := 1
+	// Rating is just a number, roughly one token per 4 digits
+	ratingTokens := len(fb.Rating().String())/4 + 1
+	// Source is a single short word
+	sourceTokens := 1
 	// JSON structure overhead
 	structureTokens := 20
 
-	return idTokens + commentTokens + ratingTokens + structureTokens
+	return idTokens + commentTokens + ratingTokens + sourceTokens + structureTokens
 }
 
 // estimateSystemPromptTokens estimates tokens for the system prompt.
@@ -56,7 +59,7 @@ func estimatePreviousAnalysisTokens(prevAnalysis *analysis.Analysis) int {
 	}
 
 	// Estimate tokens for previous analysis summary
-	summaryTokens := estimateTokens(prevAnalysis.OverallSummary())
+	summaryTokens := estimateTokens(prevAnalysis.OverallSummary().UnwrapOr(""))
 	insightsTokens := 0
 	for _, insight := range prevAnalysis.KeyInsights() {
 		insightsTokens += estimateTokens(insight)
@@ -80,26 +83,29 @@ func estimateResponseTokens(feedbackCount int) int {
 }
 
 // estimateTotalTokens estimates total tokens for an analysis request.
-func estimateTotalTokens(feedbacks []*feedback.Feedback, previousAnalysis *analysis.Analysis) int {
-	systemPromptTokens := estimateSystemPromptTokens()
-	previousAnalysisTokens := estimatePreviousAnalysisTokens(previousAnalysis)
-
-	feedbackTokens := 0
-	for _, fb := range feedbacks {
-		feedbackTokens += estimateFeedbackTokens(fb)
-	}
-
-	// User payload JSON structure overhead
-	userPayloadOverhead := 50
+func (a *analyzer) estimateTotalTokens(feedbacks []*feedback.Feedback, previousAnalysis *analysis.Analysis) int {
+	return a.estimateTokenBreakdown(feedbacks, previousAnalysis).Total()
+}
 
-	// Estimate response tokens
-	responseTokens := estimateResponseTokens(len(feedbacks))
+// estimateTokenBreakdown estimates the tokens of an analysis request per component.
+func (a *analyzer) estimateTokenBreakdown(
+	feedbacks []*feedback.Feedback,
+	previousAnalysis *analysis.Analysis,
+) services.TokenEstimate {
+	feedbackTokens := sumTokens(a.estimateFeedbacksTokens(feedbacks))
 
 	// Total: system prompt + user payload (previous analysis + feedbacks + overhead) + response
-	return systemPromptTokens + previousAnalysisTokens + feedbackTokens + userPayloadOverhead + responseTokens
+	return services.TokenEstimate{
+		SystemPrompt:     estimateSystemPromptTokens(),
+		PreviousAnalysis: estimatePreviousAnalysisTokens(previousAnalysis),
+		Feedbacks:        feedbackTokens,
+		PayloadOverhead:  50, // User payload JSON structure overhead
+		Response:         estimateResponseTokens(len(feedbacks)),
+	}
 }
 
 // selectFeedbacksForAnalysis selects feedbacks that fit within token and count limits.
+// Candidates are prioritized by the configured selection order before the limits are applied.
 // Returns the selected feedbacks and the remaining feedbacks that should stay in the queue.
 func (a *analyzer) selectFeedbacksForAnalysis(
 	pendingFeedbacks []*feedback.Feedback,
@@ -109,12 +115,16 @@ func (a *analyzer) selectFeedbacksForAnalysis(
 		return nil, nil
 	}
 
+	prioritized := make([]*feedback.Feedback, len(pendingFeedbacks))
+	copy(prioritized, pendingFeedbacks)
+	sortFeedbacksForSelection(prioritized, a.cfg.FeedbackSelectionOrder)
+
 	selected = make([]*feedback.Feedback, 0)
 	maxTokens := a.cfg.MaxTokensPerRequest
 	maxFeedbacks := a.cfg.MaxFeedbacksInContext
 
 	// First, apply max feedbacks limit (secondary constraint)
-	candidates := pendingFeedbacks
+	candidates := prioritized
 	if len(candidates) > maxFeedbacks {
 		candidates = candidates[:maxFeedbacks]
 	}
@@ -124,8 +134,9 @@ func (a *analyzer) selectFeedbacksForAnalysis(
 	userPayloadOverhead := 50
 	responseTokensEstimate := 200 // Base response tokens
 
+	candidateTokens := a.estimateFeedbacksTokens(candidates)
 	for i, fb := range candidates {
-		feedbackTokens := estimateFeedbackTokens(fb)
+		feedbackTokens := candidateTokens[i]
 		// Estimate response tokens for this feedback
 		estimatedResponseTokens := 100 // Per feedback in response
 
@@ -134,7 +145,6 @@ func (a *analyzer) selectFeedbacksForAnalysis(
 
 		if estimatedTotalTokens > maxTokens {
 			// This feedback would exceed token limit, stop here
-			remaining = candidates[i:]
 			break
 		}
 
@@ -143,15 +153,6 @@ func (a *analyzer) selectFeedbacksForAnalysis(
 		currentTokens += feedbackTokens
 	}
 
-	// If we didn't use all candidates, add the rest to remaining
-	if len(selected) < len(candidates) {
-		remaining = append(remaining, candidates[len(selected):]...)
-	}
-
-	// Add any feedbacks beyond maxFeedbacks to remaining
-	if len(pendingFeedbacks) > maxFeedbacks {
-		remaining = append(remaining, pendingFeedbacks[maxFeedbacks:]...)
-	}
-
-	return selected, remaining
+	// The selection is always a prefix of the prioritized feedbacks, everything after it stays queued
+	return selected, prioritized[len(selected):]
 }
@@ -0,0 +1,187 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+)
+
+func TestAnalyzer_SelectFeedbacksForAnalysis_Order(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	// Queue order differs from both creation time and rating order
+	specs := []struct {
+		rating int
+		age    time.Duration
+	}{
+		{rating: 4, age: 2 * time.Hour},
+		{rating: 1, age: 1 * time.Hour},
+		{rating: 5, age: 4 * time.Hour},
+		{rating: 2, age: 3 * time.Hour},
+		{rating: 3, age: 0},
+	}
+	pending := make([]*feedback.Feedback, len(specs))
+	for i, spec := range specs {
+		pending[i] = feedback.NewBuilder().
+			WithID(uuid.New()).
+			WithUserID(uuid.New()).
+			WithRatingValue(spec.rating).
+			WithCommentText("Comment").
+			WithCreatedAt(base.Add(-spec.age)).
+			BuildUnchecked()
+	}
+
+	tests := []struct {
+		name          string
+		order         string
+		wantSelected  []int // indexes into pending, in selection order
+		wantRemaining []int
+	}{
+		{name: "oldest first by default", order: "", wantSelected: []int{2, 3}, wantRemaining: []int{0, 1, 4}},
+		{name: "oldest first", order: "oldest_first", wantSelected: []int{2, 3}, wantRemaining: []int{0, 1, 4}},
+		{name: "newest first", order: "newest_first", wantSelected: []int{4, 1}, wantRemaining: []int{0, 3, 2}},
+		{
+			name:          "lowest rating first",
+			order:         "lowest_rating_first",
+			wantSelected:  []int{1, 3},
+			wantRemaining: []int{4, 0, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &analyzer{
+				cfg: &config.LLMAnalysis{
+					MaxFeedbacksInContext:  2,
+					MaxTokensPerRequest:    100000,
+					FeedbackSelectionOrder: tt.order,
+				},
+			}
+
+			selected, remaining := a.selectFeedbacksForAnalysis(pending, nil)
+
+			assertFeedbackOrder(t, "selected", selected, pending, tt.wantSelected)
+			assertFeedbackOrder(t, "remaining", remaining, pending, tt.wantRemaining)
+		})
+	}
+}
+
+func TestAnalyzer_SelectFeedbacksForAnalysis_DoesNotReorderQueue(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	pending := []*feedback.Feedback{
+		feedback.NewBuilder().WithID(uuid.New()).WithRatingValue(5).WithCreatedAt(base).BuildUnchecked(),
+		feedback.NewBuilder().WithID(uuid.New()).WithRatingValue(1).WithCreatedAt(base.Add(time.Hour)).BuildUnchecked(),
+	}
+	queued := []*feedback.Feedback{pending[0], pending[1]}
+
+	a := &analyzer{
+		cfg: &config.LLMAnalysis{
+			MaxFeedbacksInContext:  1,
+			MaxTokensPerRequest:    100000,
+			FeedbackSelectionOrder: "lowest_rating_first",
+		},
+	}
+	_, _ = a.selectFeedbacksForAnalysis(pending, nil)
+
+	for i := range queued {
+		if pending[i] != queued[i] {
+			t.Fatalf("Expected the pending feedbacks to keep their order")
+		}
+	}
+}
+
+func assertFeedbackOrder(t *testing.T, name string, got, pending []*feedback.Feedback, want []int) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d %s feedbacks, got %d", len(want), name, len(got))
+	}
+	for i, idx := range want {
+		if got[i] != pending[idx] {
+			t.Errorf("Expected pending feedback %d at %s position %d", idx, name, i)
+		}
+	}
+}
+
+func TestFeedbackTokenCache_InvalidatedByEdit(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	id := uuid.New()
+	original := feedback.NewBuilder().
+		WithID(id).
+		WithCommentText("Short").
+		WithCreatedAt(base).
+		WithUpdatedAt(base).
+		BuildUnchecked()
+	edited := feedback.NewBuilder().
+		WithID(id).
+		WithCommentText(strings.Repeat("A much longer comment after editing. ", 20)).
+		WithCreatedAt(base).
+		WithUpdatedAt(base.Add(time.Minute)).
+		BuildUnchecked()
+
+	cache := newFeedbackTokenCache()
+	if got, want := cache.tokens(original), estimateFeedbackTokens(original); got != want {
+		t.Fatalf("Expected %d tokens, got %d", want, got)
+	}
+	if got, want := cache.tokens(edited), estimateFeedbackTokens(edited); got != want {
+		t.Errorf("Expected edited feedback to be re-estimated to %d tokens, got %d", want, got)
+	}
+}
+
+func TestAnalyzer_EstimateFeedbacksTokens_Parallel(t *testing.T) {
+	feedbacks := benchmarkFeedbacks(parallelEstimationThreshold * 4)
+
+	serial := (&analyzer{cfg: &config.LLMAnalysis{TokenEstimationWorkers: 1}}).estimateFeedbacksTokens(feedbacks)
+	parallel := (&analyzer{cfg: &config.LLMAnalysis{TokenEstimationWorkers: 8}}).estimateFeedbacksTokens(feedbacks)
+
+	for i := range feedbacks {
+		if serial[i] != parallel[i] {
+			t.Fatalf("Feedback %d: expected %d tokens, got %d", i, serial[i], parallel[i])
+		}
+	}
+}
+
+func BenchmarkAnalyzer_EstimateFeedbacksTokens(b *testing.B) {
+	feedbacks := benchmarkFeedbacks(5000)
+
+	benchmarks := []struct {
+		name    string
+		workers int
+		cached  bool
+	}{
+		{name: "serial", workers: 1},
+		{name: "parallel", workers: 0},
+		{name: "parallel_cached", workers: 0, cached: true},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			a := &analyzer{cfg: &config.LLMAnalysis{TokenEstimationWorkers: bm.workers}}
+			if bm.cached {
+				a.tokenCache = newFeedbackTokenCache()
+			}
+
+			for b.Loop() {
+				_ = a.estimateFeedbacksTokens(feedbacks)
+			}
+		})
+	}
+}
+
+func benchmarkFeedbacks(n int) []*feedback.Feedback {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	feedbacks := make([]*feedback.Feedback, n)
+	for i := range feedbacks {
+		feedbacks[i] = feedback.NewBuilder().
+			WithID(uuid.New()).
+			WithRatingValue(i%5 + 1).
+			WithCommentText(strings.Repeat("The checkout flow is confusing. ", i%10+1)).
+			WithCreatedAt(base).
+			WithUpdatedAt(base).
+			BuildUnchecked()
+	}
+	return feedbacks
+}
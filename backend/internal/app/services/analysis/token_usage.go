@@ -0,0 +1,72 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+// estimateRequestTokens estimates the total tokens of the LLM request of an analysis as it is sent,
+// including the feedbacks added as context. Returns 0 if token accuracy is not recorded.
+func (a *analyzer) estimateRequestTokens(
+	feedbacks []*feedback.Feedback,
+	previousAnalysis *analysis.Analysis,
+	contextFeedbacks []*feedback.Feedback,
+) int {
+	if !a.cfg.RecordTokenAccuracy {
+		return 0
+	}
+	return a.estimateTotalTokens(feedbacks, previousAnalysis) + sumTokens(a.estimateFeedbacksTokens(contextFeedbacks))
+}
+
+// recordTokenUsage stores the estimated tokens of an analysis next to the usage reported by the provider,
+// if token accuracy recording is enabled. Failed requests are recorded if the provider reported their usage.
+// Failing to store the usage does not fail the analysis.
+func (a *analyzer) recordTokenUsage(
+	ctx context.Context,
+	analysisID uuid.UUID,
+	estimatedTokens int,
+	result *external.AnalysisResult,
+	llmErr error,
+	logger tracelog.TraceLogger,
+) {
+	if !a.cfg.RecordTokenAccuracy {
+		return
+	}
+
+	var usage external.TokenUsage
+	var usageErr *external.UsageError
+	switch {
+	case llmErr == nil && result != nil:
+		usage = result.Usage
+	case errors.As(llmErr, &usageErr):
+		usage = usageErr.Usage
+	}
+	if usage.TotalTokens == 0 {
+		return
+	}
+
+	if err := a.analysisRepo.SaveTokenUsage(
+		ctx, &analysis.TokenUsage{
+			AnalysisID:      analysisID,
+			EstimatedTokens: estimatedTokens,
+			InputTokens:     usage.InputTokens,
+			OutputTokens:    usage.OutputTokens,
+			TotalTokens:     usage.TotalTokens,
+		},
+	); err != nil {
+		logger.Error("failed to store token usage", err, "analysis_id", analysisID.String())
+		return
+	}
+	logger.Debug(
+		"token usage stored",
+		"analysis_id", analysisID.String(),
+		"estimated_tokens", estimatedTokens,
+		"total_tokens", usage.TotalTokens,
+	)
+}
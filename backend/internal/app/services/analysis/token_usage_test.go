@@ -0,0 +1,85 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+)
+
+type tokenUsageAnalysisRepo struct {
+	apprepo.AnalysisRepository
+	saved []*analysis.TokenUsage
+}
+
+func (r *tokenUsageAnalysisRepo) SaveTokenUsage(
+	_ context.Context,
+	usage *analysis.TokenUsage,
+	_ ...repository.RepoOption[apprepo.Options],
+) error {
+	r.saved = append(r.saved, usage)
+	return nil
+}
+
+func TestAnalyzer_RecordTokenUsage(t *testing.T) {
+	usage := external.TokenUsage{InputTokens: 900, OutputTokens: 300, TotalTokens: 1200}
+
+	tests := []struct {
+		name     string
+		disabled bool
+		result   *external.AnalysisResult
+		llmErr   error
+		wantSave bool
+	}{
+		{name: "success", result: &external.AnalysisResult{Usage: usage}, wantSave: true},
+		{
+			name:     "failure with reported usage",
+			llmErr:   fmt.Errorf("LLM analysis failed: %w", &external.UsageError{Usage: usage, Err: errors.New("bad output")}),
+			wantSave: true,
+		},
+		{name: "failure without usage", llmErr: errors.New("connection refused")},
+		{name: "disabled", disabled: true, result: &external.AnalysisResult{Usage: usage}},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				repo := &tokenUsageAnalysisRepo{}
+				a := &analyzer{
+					cfg:          &config.LLMAnalysis{RecordTokenAccuracy: !tt.disabled},
+					analysisRepo: repo,
+				}
+
+				analysisID := uuid.New()
+				a.recordTokenUsage(context.Background(), analysisID, 1500, tt.result, tt.llmErr, newTestLogger(t))
+
+				if !tt.wantSave {
+					if len(repo.saved) != 0 {
+						t.Fatalf("Expected no token usage to be stored, got %+v", repo.saved)
+					}
+					return
+				}
+				if len(repo.saved) != 1 {
+					t.Fatalf("Expected token usage to be stored once, got %d", len(repo.saved))
+				}
+				want := analysis.TokenUsage{
+					AnalysisID:      analysisID,
+					EstimatedTokens: 1500,
+					InputTokens:     900,
+					OutputTokens:    300,
+					TotalTokens:     1200,
+				}
+				if *repo.saved[0] != want {
+					t.Errorf("Expected stored usage %+v, got %+v", want, *repo.saved[0])
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,38 @@
+package analysis
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+// storeTopicDeltas stores the per-topic feedback count changes of an analysis versus its previous analysis.
+// Failures are only logged, since the deltas are supplementary like the topics themselves.
+func (a *analyzer) storeTopicDeltas(
+	ctx context.Context,
+	analysisID uuid.UUID,
+	previousTopics []*analysis.TopicAnalysis,
+	topics []external.Topic,
+	logger tracelog.TraceLogger,
+) {
+	current := make(map[analysis.Topic]int, len(topics))
+	for _, topic := range topics {
+		current[topic.Topic] += len(topic.FeedbackIDs)
+	}
+
+	deltas := analysis.ComputeTopicDeltas(analysis.TopicFeedbackCounts(previousTopics), current)
+	if len(deltas) == 0 {
+		return
+	}
+
+	if err := a.analysisRepo.CreateTopicDeltas(ctx, analysisID, deltas); err != nil {
+		logger.Error("failed to store topic deltas", err, "analysis_id", analysisID.String())
+		logger.RecordSpanError(ctx, err)
+		return
+	}
+
+	logger.Info("topic deltas stored", "analysis_id", analysisID.String(), "deltas_count", len(deltas))
+}
@@ -0,0 +1,104 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
+)
+
+// GetTopicHistory retrieves the topic analyses of a topic across all successful analyses, oldest first.
+func (s *service) GetTopicHistory(ctx context.Context, topicEnum analysis.Topic) ([]services.TopicHistoryEntry, error) {
+	logger := s.logger.WithSpan(ctx)
+	logger.Info("getting topic history", "topic_enum", string(topicEnum))
+
+	entries, err := s.analysisRepo.GetTopicAnalysesByEnum(ctx, topicEnum)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting topic analyses", err, "topic_enum", string(topicEnum))
+		return nil, fmt.Errorf("failed to get topic analyses: %w", err)
+	}
+
+	history := make([]services.TopicHistoryEntry, len(entries))
+	for i, entry := range entries {
+		history[i] = services.TopicHistoryEntry{
+			TopicAnalysis: entry.TopicAnalysis,
+			PeriodStart:   entry.PeriodStart,
+			PeriodEnd:     entry.PeriodEnd,
+			AnalyzedAt:    entry.AnalysisCreatedAt,
+		}
+	}
+
+	logger.Info("topic history retrieved", "topic_enum", string(topicEnum), "entries", len(history))
+	return history, nil
+}
+
+// GetTopicSentimentTrend retrieves the sentiment of a topic in every successful analysis, ordered by period end.
+// Analyses that did not identify the topic are included without sentiment and feedback count.
+func (s *service) GetTopicSentimentTrend(
+	ctx context.Context,
+	topicEnum analysis.Topic,
+) ([]services.TopicSentimentTrendPoint, error) {
+	logger := s.logger.WithSpan(ctx)
+	logger.Info("getting topic sentiment trend", "topic_enum", string(topicEnum))
+
+	entries, err := s.analysisRepo.GetTopicAnalysesByEnum(ctx, topicEnum)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting topic analyses", err, "topic_enum", string(topicEnum))
+		return nil, fmt.Errorf("failed to get topic analyses: %w", err)
+	}
+
+	analyses, err := s.analysisRepo.List(ctx)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error listing analyses", err)
+		return nil, fmt.Errorf("failed to list analyses: %w", err)
+	}
+
+	trend := buildTopicSentimentTrend(analyses, entries)
+	logger.Info("topic sentiment trend retrieved", "topic_enum", string(topicEnum), "points", len(trend))
+	return trend, nil
+}
+
+// buildTopicSentimentTrend returns a point per successful analysis, ordered by period end. A topic returned
+// more than once in an analysis counts all its feedbacks, with the sentiment of the entry with the most.
+func buildTopicSentimentTrend(
+	analyses []*analysis.Analysis,
+	entries []apprepo.TopicHistoryEntry,
+) []services.TopicSentimentTrendPoint {
+	topics := make(map[uuid.UUID]*analysis.TopicAnalysis, len(entries))
+	counts := make(map[uuid.UUID]int, len(entries))
+	for _, entry := range entries {
+		analysisID := entry.TopicAnalysis.AnalysisID()
+		counts[analysisID] += entry.TopicAnalysis.FeedbackCount()
+		if existing, ok := topics[analysisID]; !ok || entry.TopicAnalysis.FeedbackCount() > existing.FeedbackCount() {
+			topics[analysisID] = entry.TopicAnalysis
+		}
+	}
+
+	trend := make([]services.TopicSentimentTrendPoint, 0, len(analyses))
+	for _, a := range analyses {
+		if a.Status() != analysis.StatusSuccess {
+			continue
+		}
+		point := services.TopicSentimentTrendPoint{AnalysisID: a.ID(), PeriodEnd: a.PeriodEnd()}
+		if topic, ok := topics[a.ID()]; ok {
+			point.Sentiment = optional.Some(topic.Sentiment())
+			point.FeedbackCount = optional.Some(counts[a.ID()])
+		}
+		trend = append(trend, point)
+	}
+
+	sort.SliceStable(
+		trend, func(i, j int) bool {
+			return trend[i].PeriodEnd.Before(trend[j].PeriodEnd)
+		},
+	)
+	return trend
+}
@@ -0,0 +1,85 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+// refreshTopicStats recomputes the materialized topic statistics from a completed analysis.
+// Failures are only logged: the analysis stays successful and the statistics can be recomputed on demand.
+func (a *analyzer) refreshTopicStats(ctx context.Context, analysisID uuid.UUID, logger tracelog.TraceLogger) {
+	if err := a.analysisRepo.RefreshTopicStats(ctx, analysisID); err != nil {
+		logger.Error("failed to refresh topic stats", err, "analysis_id", analysisID.String())
+		logger.RecordSpanError(ctx, err)
+		return
+	}
+
+	logger.Info("topic stats refreshed", "analysis_id", analysisID.String())
+}
+
+// RecomputeTopicStats rebuilds the materialized topic statistics from the latest analysis and the current
+// ratings of its feedbacks, without calling the LLM.
+func (s *service) RecomputeTopicStats(ctx context.Context) (*services.TopicStatsOverview, error) {
+	logger := s.logger.WithSpan(ctx)
+	logger.Info("recomputing topic stats")
+
+	latestAnalysis, err := s.analysisRepo.GetLatest(ctx)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error getting latest analysis", err)
+		return nil, fmt.Errorf("failed to get latest analysis: %w", err)
+	}
+	if latestAnalysis == nil {
+		logger.Info("no analysis found, nothing to recompute")
+		return s.topicStatsOverview(ctx, nil)
+	}
+
+	if err := s.analysisRepo.RefreshTopicStats(ctx, latestAnalysis.ID()); err != nil {
+		logger.RecordSpanError(ctx, err)
+		logger.Error("error refreshing topic stats", err, "analysis_id", latestAnalysis.ID())
+		return nil, fmt.Errorf("failed to refresh topic stats: %w", err)
+	}
+
+	logger.Info("topic stats recomputed", "analysis_id", latestAnalysis.ID())
+	return s.topicStatsOverview(ctx, latestAnalysis)
+}
+
+// topicStatsOverview builds the statistics of all predefined topics from the materialized ones, with zero
+// stats for the topics that have none. latestAnalysis may be nil if there is no analysis yet.
+func (s *service) topicStatsOverview(
+	ctx context.Context,
+	latestAnalysis *analysis.Analysis,
+) (*services.TopicStatsOverview, error) {
+	materialized := make(map[analysis.Topic]analysis.TopicStats)
+	if latestAnalysis != nil {
+		topicStats, err := s.analysisRepo.GetTopicStats(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get topic stats: %w", err)
+		}
+		for _, stat := range topicStats {
+			materialized[stat.Topic] = stat
+		}
+	}
+
+	allTopics := analysis.AllTopics()
+	stats := make([]services.TopicStats, len(allTopics))
+	for i, topic := range allTopics {
+		stats[i] = services.TopicStats{
+			Topic:         topic,
+			FeedbackCount: materialized[topic].FeedbackCount,
+			AverageRating: materialized[topic].AverageRating,
+		}
+	}
+	sortTopicStats(stats)
+
+	overview := &services.TopicStatsOverview{Topics: stats}
+	if latestAnalysis != nil {
+		overview.NoTopicsIdentified = latestAnalysis.NoTopicsIdentified()
+	}
+	return overview, nil
+}
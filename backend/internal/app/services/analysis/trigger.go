@@ -0,0 +1,58 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
+	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+)
+
+// TriggerAnalysis analyzes the pending queue right away, ignoring the minimum feedback
+// threshold and the debounce window. Feedbacks that do not fit within the token and count
+// limits stay queued for the next run. The pending queue is held in memory by each replica,
+// so a trigger only analyzes the feedbacks queued on the replica that receives it.
+func (a *analyzer) TriggerAnalysis(ctx context.Context) (*analysis.Analysis, error) {
+	logger := a.logger.WithSpan(ctx)
+	logger.Info("manual analysis triggered", "pending_count", a.pendingCount())
+
+	// Draining the queue into a request the breaker rejects would only produce a failed analysis
+	if a.LLMCircuitState() == external.CircuitOpen {
+		return nil, &ce.GenericError{
+			Code:       ce.NewDomainErrorCode("llm_circuit_open", ce.CategoryRateLimited),
+			Message:    "The LLM provider is failing, analyses are paused until the circuit breaker half-opens",
+			UserFacing: true,
+		}
+	}
+
+	// Track the run before starting any work, so that Stop either waits for it or prevents it
+	if !a.trackRun() {
+		return nil, ErrAnalyzerStopped
+	}
+	defer a.wg.Done()
+
+	lock, ok := a.acquireAnalysisLock(ctx)
+	if !ok {
+		return nil, &ce.GenericError{
+			Code:       ce.NewDomainErrorCode("analysis_in_progress", ce.CategoryConflict),
+			Message:    "An analysis is already running on another instance",
+			UserFacing: true,
+		}
+	}
+	defer a.releaseAnalysisLock(ctx, lock)
+
+	selectedFeedbacks := a.dequeueFeedbacksForAnalysis(ctx)
+	if len(selectedFeedbacks) == 0 {
+		return nil, ErrNoFeedbacks
+	}
+
+	// Detach the run from the request so that a client disconnect does not leave a half-written analysis behind
+	result, err := a.analyzeSelected(context.WithoutCancel(ctx), selectedFeedbacks)
+	if err != nil {
+		return nil, fmt.Errorf("triggered analysis failed: %w", err)
+	}
+
+	logger.Info("triggered analysis completed", "analysis_id", result.ID().String())
+	return result, nil
+}
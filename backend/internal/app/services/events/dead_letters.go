@@ -0,0 +1,67 @@
+package events
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+)
+
+// defaultDeadLettersLimit is the page size used when no limit is requested.
+const defaultDeadLettersLimit = 100
+
+// ListDeadLetters retrieves events that permanently failed to be delivered, newest first.
+func (p *publisher) ListDeadLetters(ctx context.Context, limit, offset int) (
+	*services.Page[*external.DeadLetter],
+	error,
+) {
+	logger := p.logger.WithSpan(ctx)
+	ctx, spanLogger, span := logger.StartSpan(ctx, "event_publisher.list_dead_letters")
+	defer span.End()
+
+	span.SetAttributes(
+		trace.Attribute{Key: "limit", Value: limit},
+		trace.Attribute{Key: "offset", Value: offset},
+	)
+
+	if p.outbox == nil {
+		return nil, ce.ErrBadRequest("the event outbox is disabled")
+	}
+	if limit <= 0 {
+		limit = defaultDeadLettersLimit
+	}
+	if limit > 1000 {
+		return nil, ce.ErrBadRequest("limit cannot exceed 1000")
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	repoOpts := apprepo.WithOptions(&apprepo.Options{Limit: limit, Offset: offset})
+	deadLetters, err := p.outbox.repo.ListDeadLetters(ctx, repoOpts)
+	if err != nil {
+		span.SetStatus(trace.StatusError, err.Error())
+		spanLogger.RecordSpanError(ctx, err)
+		return nil, fmt.Errorf("failed to list dead letters: %w", err)
+	}
+
+	total, err := p.outbox.repo.CountDeadLetters(ctx, repoOpts)
+	if err != nil {
+		span.SetStatus(trace.StatusError, err.Error())
+		spanLogger.RecordSpanError(ctx, err)
+		return nil, fmt.Errorf("failed to count dead letters: %w", err)
+	}
+
+	span.SetStatus(trace.StatusOK, "Successfully listed dead letters")
+	spanLogger.Info("dead letters listed", "count", len(deadLetters), "total", total)
+	return &services.Page[*external.DeadLetter]{
+		Items:  deadLetters,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
@@ -0,0 +1,280 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/operations"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+)
+
+// maxOutboxBackoff caps the wait between two delivery attempts of an outbox event.
+const maxOutboxBackoff = time.Hour
+
+// outbox holds the dependencies and settings of the persistent event outbox.
+type outbox struct {
+	repo         apprepo.OutboxRepository
+	transactor   repository.Transactor
+	clock        clock.Clock
+	pollInterval time.Duration
+	batchSize    int
+	maxAttempts  int
+	retryBackoff time.Duration
+
+	// Signals the dispatcher that new events were published (buffered, never blocks)
+	wakeChan chan struct{}
+}
+
+// WithOutbox persists events in the outbox before delivery so that they survive restarts.
+// A background dispatcher delivers them at least once, retrying with exponential backoff,
+// and moves events that still fail after the configured attempts to the dead-letter table.
+func WithOutbox(
+	repo apprepo.OutboxRepository,
+	transactor repository.Transactor,
+	cfg *config.Webhooks,
+	clk clock.Clock,
+) PublisherOption {
+	return func(p *publisher) {
+		if clk == nil {
+			clk = clock.New()
+		}
+		p.outbox = &outbox{
+			repo:         repo,
+			transactor:   transactor,
+			clock:        clk,
+			pollInterval: time.Duration(cfg.OutboxPollIntervalSeconds) * time.Second,
+			batchSize:    cfg.OutboxBatchSize,
+			maxAttempts:  cfg.OutboxMaxAttempts,
+			retryBackoff: time.Duration(cfg.OutboxRetryBackoffSeconds) * time.Second,
+			wakeChan:     make(chan struct{}, 1),
+		}
+	}
+}
+
+// Stage stores the event in the outbox as part of the given transaction, so that it is delivered
+// if and only if the transaction commits. It is a no-op without an outbox.
+func (p *publisher) Stage(ctx context.Context, tx repository.Transaction, event *external.Event) error {
+	if p.outbox == nil {
+		return nil
+	}
+
+	if err := p.enqueue(ctx, event, repository.WithExecutor[apprepo.Options](tx)); err != nil {
+		return err
+	}
+	event.Staged = true
+	return nil
+}
+
+// enqueue stores one outbox entry per registered sink receiving the event.
+func (p *publisher) enqueue(
+	ctx context.Context,
+	event *external.Event,
+	opts ...repository.RepoOption[apprepo.Options],
+) error {
+	now := p.outbox.clock.Now().UTC()
+	for _, sink := range p.subscribedSinks(event.Type) {
+		entry := &external.OutboxEntry{
+			ID:            uuid.New(),
+			Event:         event,
+			Sink:          sink.Name(),
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+		if err := p.outbox.repo.Enqueue(ctx, entry, opts...); err != nil {
+			return fmt.Errorf("failed to store event in outbox for sink %s: %w", sink.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// publishToOutbox stores events that were not staged and wakes up the dispatcher.
+// Staged events are already stored, and may even be delivered and deleted by now, so they only wake it up.
+func (p *publisher) publishToOutbox(ctx context.Context, event *external.Event) {
+	logger := p.logger.WithSpan(ctx)
+	if !event.Staged {
+		if err := p.enqueue(ctx, event); err != nil {
+			logger.Error("failed to store event in outbox", err, "event_id", event.ID.String())
+			return
+		}
+	}
+
+	select {
+	case p.outbox.wakeChan <- struct{}{}:
+	default:
+	}
+}
+
+// runDispatcher delivers due outbox events on every poll interval or when woken up by Publish.
+func (p *publisher) runDispatcher(ctx context.Context) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.outbox.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			p.logger.Info("event outbox dispatcher context cancelled, stopping")
+			return
+		case <-ticker.C:
+		case <-p.outbox.wakeChan:
+		}
+
+		p.dispatchDue(ctx)
+	}
+}
+
+// dispatchDue claims and delivers up to a batch of due outbox entries, one at a time.
+func (p *publisher) dispatchDue(ctx context.Context) {
+	logger := p.logger.WithSpan(ctx)
+
+	for range p.outbox.batchSize {
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Each entry is leased right before its delivery, for longer than the delivery may take, so that other
+		// replicas do not pick it up while this one is still delivering
+		now := p.outbox.clock.Now().UTC()
+		entries, err := p.outbox.repo.ClaimDue(ctx, now, now.Add(2*p.deliveryTimeout), 1)
+		if err != nil {
+			logger.Error("failed to claim due outbox events", err)
+			return
+		}
+		if len(entries) == 0 {
+			return
+		}
+
+		p.dispatchEntry(ctx, entries[0])
+	}
+}
+
+// dispatchEntry delivers a single outbox entry and records the outcome.
+func (p *publisher) dispatchEntry(ctx context.Context, entry *external.OutboxEntry) {
+	logger := p.logger.WithSpan(ctx)
+	ctx, spanLogger, span := logger.StartSpan(ctx, "event_publisher.dispatch_outbox_entry")
+	defer span.End()
+
+	span.SetAttributes(
+		trace.Attribute{Key: "outbox_entry.id", Value: entry.ID.String()},
+		trace.Attribute{Key: "event.id", Value: entry.Event.ID.String()},
+		trace.Attribute{Key: "event.type", Value: string(entry.Event.Type)},
+		trace.Attribute{Key: "sink", Value: entry.Sink},
+		trace.Attribute{Key: "attempts", Value: entry.Attempts},
+	)
+
+	deliveryErr := p.sendToSink(ctx, entry)
+	if deliveryErr == nil {
+		if err := p.outbox.repo.Delete(ctx, entry.ID); err != nil {
+			// The event is delivered again once the lease expires, which at-least-once delivery allows
+			spanLogger.Error("failed to delete delivered outbox event", err, "outbox_entry_id", entry.ID.String())
+		}
+		span.SetStatus(trace.StatusOK, "Successfully delivered outbox event")
+		spanLogger.Info("outbox event delivered", "event_id", entry.Event.ID.String(), "sink", entry.Sink)
+		return
+	}
+
+	span.SetStatus(trace.StatusError, deliveryErr.Error())
+	attempts := entry.Attempts + 1
+	if attempts >= p.outbox.maxAttempts {
+		if err := p.moveToDeadLetter(ctx, entry, attempts, deliveryErr); err != nil {
+			spanLogger.Error("failed to move outbox event to dead letters", err, "outbox_entry_id", entry.ID.String())
+			return
+		}
+		spanLogger.Error(
+			"outbox event moved to dead letters",
+			deliveryErr,
+			"event_id",
+			entry.Event.ID.String(),
+			"sink",
+			entry.Sink,
+			"attempts",
+			attempts,
+		)
+		return
+	}
+
+	nextAttemptAt := p.outbox.clock.Now().UTC().Add(p.outboxBackoff(attempts))
+	if err := p.outbox.repo.Reschedule(ctx, entry.ID, attempts, nextAttemptAt, deliveryErr.Error()); err != nil {
+		spanLogger.Error("failed to reschedule outbox event", err, "outbox_entry_id", entry.ID.String())
+		return
+	}
+	spanLogger.Warning(
+		"outbox event delivery failed, rescheduled",
+		"event_id", entry.Event.ID.String(),
+		"sink", entry.Sink,
+		"attempts", attempts,
+		"next_attempt_at", nextAttemptAt,
+		"error", deliveryErr.Error(),
+	)
+}
+
+// sendToSink delivers the entry to its sink, bounded by the delivery timeout.
+func (p *publisher) sendToSink(ctx context.Context, entry *external.OutboxEntry) error {
+	var sink external.EventSink
+	for _, registered := range p.registeredSinks() {
+		if registered.Name() == entry.Sink {
+			sink = registered
+			break
+		}
+	}
+	if sink == nil {
+		return fmt.Errorf("sink %s is not registered", entry.Sink)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.deliveryTimeout)
+	defer cancel()
+
+	return sink.Send(ctx, entry.Event)
+}
+
+// outboxBackoff returns the wait before the next delivery attempt after the given number of failed attempts.
+func (p *publisher) outboxBackoff(attempts int) time.Duration {
+	backoff := p.outbox.retryBackoff
+	for i := 1; i < attempts && backoff < maxOutboxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxOutboxBackoff)
+}
+
+// moveToDeadLetter atomically replaces the outbox entry with a dead letter.
+func (p *publisher) moveToDeadLetter(
+	ctx context.Context,
+	entry *external.OutboxEntry,
+	attempts int,
+	deliveryErr error,
+) error {
+	deadLetter := &external.DeadLetter{
+		ID:        entry.ID,
+		Event:     entry.Event,
+		Sink:      entry.Sink,
+		Attempts:  attempts,
+		LastError: deliveryErr.Error(),
+		CreatedAt: entry.CreatedAt,
+		FailedAt:  p.outbox.clock.Now().UTC(),
+	}
+
+	return operations.RunGenericTransaction(
+		ctx,
+		p.outbox.transactor,
+		func(ctx context.Context, tx repository.Transaction) error {
+			if err := p.outbox.repo.CreateDeadLetter(
+				ctx,
+				deadLetter,
+				repository.WithExecutor[apprepo.Options](tx),
+			); err != nil {
+				return err
+			}
+			return p.outbox.repo.Delete(ctx, entry.ID, repository.WithExecutor[apprepo.Options](tx))
+		},
+	)
+}
@@ -0,0 +1,339 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/log"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+const testDeliveryTimeout = 10 * time.Second
+
+func newTestLogger(t *testing.T) tracelog.TraceLogger {
+	t.Helper()
+
+	tracer, err := trace.NewTracer(trace.Config{ServiceName: "events-test"})
+	if err != nil {
+		t.Fatalf("Failed to create tracer: %v", err)
+	}
+
+	return tracelog.NewTraceLogger(log.NewLogger("development"), tracer)
+}
+
+// memoryOutbox is an in-memory outbox that leases entries by pushing their next attempt to the lease end,
+// like the Postgres repository.
+type memoryOutbox struct {
+	apprepo.OutboxRepository
+	mu          sync.Mutex
+	entries     []*external.OutboxEntry
+	deadLetters []*external.DeadLetter
+	// leases records the lease end of the last claim per event ID
+	leases map[uuid.UUID]time.Time
+}
+
+func (o *memoryOutbox) Enqueue(
+	_ context.Context,
+	entry *external.OutboxEntry,
+	_ ...repository.RepoOption[apprepo.Options],
+) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, stored := range o.entries {
+		if stored.Event.ID == entry.Event.ID && stored.Sink == entry.Sink {
+			return nil
+		}
+	}
+	stored := *entry
+	o.entries = append(o.entries, &stored)
+	return nil
+}
+
+func (o *memoryOutbox) ClaimDue(
+	_ context.Context,
+	now time.Time,
+	leaseUntil time.Time,
+	limit int,
+	_ ...repository.RepoOption[apprepo.Options],
+) ([]*external.OutboxEntry, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.leases == nil {
+		o.leases = make(map[uuid.UUID]time.Time)
+	}
+	var claimed []*external.OutboxEntry
+	for _, entry := range o.entries {
+		if len(claimed) == limit {
+			break
+		}
+		if entry.NextAttemptAt.After(now) {
+			continue
+		}
+		entry.NextAttemptAt = leaseUntil
+		o.leases[entry.Event.ID] = leaseUntil
+		claim := *entry
+		claimed = append(claimed, &claim)
+	}
+	return claimed, nil
+}
+
+func (o *memoryOutbox) Reschedule(
+	_ context.Context,
+	entryID uuid.UUID,
+	attempts int,
+	nextAttemptAt time.Time,
+	lastError string,
+	_ ...repository.RepoOption[apprepo.Options],
+) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, entry := range o.entries {
+		if entry.ID == entryID {
+			entry.Attempts, entry.NextAttemptAt, entry.LastError = attempts, nextAttemptAt, lastError
+		}
+	}
+	return nil
+}
+
+func (o *memoryOutbox) Delete(_ context.Context, entryID uuid.UUID, _ ...repository.RepoOption[apprepo.Options]) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.entries = slices.DeleteFunc(
+		o.entries, func(entry *external.OutboxEntry) bool {
+			return entry.ID == entryID
+		},
+	)
+	return nil
+}
+
+func (o *memoryOutbox) CreateDeadLetter(
+	_ context.Context,
+	deadLetter *external.DeadLetter,
+	_ ...repository.RepoOption[apprepo.Options],
+) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.deadLetters = append(o.deadLetters, deadLetter)
+	return nil
+}
+
+func (o *memoryOutbox) entry(t *testing.T) *external.OutboxEntry {
+	t.Helper()
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if len(o.entries) != 1 {
+		t.Fatalf("Expected one outbox entry, got %d", len(o.entries))
+	}
+	return o.entries[0]
+}
+
+type noopTx struct {
+	repository.Transaction
+}
+
+func (noopTx) Commit(context.Context) error {
+	return nil
+}
+
+func (noopTx) Rollback(context.Context) error {
+	return nil
+}
+
+type noopTransactor struct{}
+
+func (noopTransactor) NewTransaction(context.Context, ...repository.TransactionOption) (
+	repository.Transaction,
+	error,
+) {
+	return noopTx{}, nil
+}
+
+// recordingSink records the delivered events and fails while err is set. Each delivery advances the clock by
+// delay, to simulate slow endpoints.
+type recordingSink struct {
+	clock     *clock.Mock
+	delay     time.Duration
+	err       error
+	delivered []uuid.UUID
+	// sentAt records the time every delivery started
+	sentAt map[uuid.UUID]time.Time
+}
+
+func (s *recordingSink) Name() string {
+	return "recording"
+}
+
+func (s *recordingSink) Send(_ context.Context, event *external.Event) error {
+	if s.sentAt == nil {
+		s.sentAt = make(map[uuid.UUID]time.Time)
+	}
+	s.sentAt[event.ID] = s.clock.Now()
+	s.clock.Advance(s.delay)
+	if s.err != nil {
+		return s.err
+	}
+	s.delivered = append(s.delivered, event.ID)
+	return nil
+}
+
+func newOutboxTestPublisher(
+	t *testing.T,
+	cfg *config.Webhooks,
+) (*publisher, *memoryOutbox, *recordingSink, *clock.Mock) {
+	t.Helper()
+
+	repo := &memoryOutbox{}
+	clk := clock.NewMock(time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC))
+	sink := &recordingSink{clock: clk}
+
+	p := NewEventPublisher(
+		newTestLogger(t),
+		testDeliveryTimeout,
+		WithOutbox(repo, noopTransactor{}, cfg, clk),
+	).(*publisher)
+	p.Register(sink)
+	return p, repo, sink, clk
+}
+
+func testEvent() *external.Event {
+	return &external.Event{ID: uuid.New(), Type: external.EventFeedbackCreated}
+}
+
+func TestPublisher_DispatchDue_LeasesEachEntry(t *testing.T) {
+	p, repo, sink, _ := newOutboxTestPublisher(t, &config.Webhooks{OutboxBatchSize: 3, OutboxMaxAttempts: 3})
+	// Every delivery takes as long as the delivery timeout, so a single lease for the batch would expire
+	sink.delay = testDeliveryTimeout
+
+	events := []*external.Event{testEvent(), testEvent(), testEvent()}
+	for _, event := range events {
+		p.Publish(context.Background(), event)
+	}
+
+	p.dispatchDue(context.Background())
+
+	if len(sink.delivered) != len(events) {
+		t.Fatalf("Expected %d delivered events, got %d", len(events), len(sink.delivered))
+	}
+	for _, event := range events {
+		want := sink.sentAt[event.ID].Add(2 * testDeliveryTimeout)
+		if got := repo.leases[event.ID]; !got.Equal(want) {
+			t.Errorf("Expected event %s to be leased until %s from its own claim, got %s", event.ID, want, got)
+		}
+	}
+	if len(repo.entries) != 0 {
+		t.Errorf("Expected delivered entries to be deleted, got %d", len(repo.entries))
+	}
+}
+
+func TestPublisher_DispatchDue_ReclaimsExpiredLease(t *testing.T) {
+	p, repo, sink, clk := newOutboxTestPublisher(t, &config.Webhooks{OutboxBatchSize: 10, OutboxMaxAttempts: 3})
+	event := testEvent()
+	p.Publish(context.Background(), event)
+
+	// Another replica claims the entry and stops before delivering it
+	now := clk.Now()
+	if claimed, _ := repo.ClaimDue(context.Background(), now, now.Add(2*testDeliveryTimeout), 10); len(claimed) != 1 {
+		t.Fatalf("Expected the entry to be claimed, got %d", len(claimed))
+	}
+
+	p.dispatchDue(context.Background())
+	if len(sink.delivered) != 0 {
+		t.Fatal("Expected a leased entry not to be delivered")
+	}
+
+	clk.Advance(2 * testDeliveryTimeout)
+	p.dispatchDue(context.Background())
+	if !slices.Equal(sink.delivered, []uuid.UUID{event.ID}) {
+		t.Errorf("Expected the entry to be delivered once its lease expired, got %v", sink.delivered)
+	}
+}
+
+func TestPublisher_DispatchDue_BacksOffAndDeadLetters(t *testing.T) {
+	cfg := &config.Webhooks{OutboxBatchSize: 10, OutboxMaxAttempts: 3, OutboxRetryBackoffSeconds: 30}
+	p, repo, sink, clk := newOutboxTestPublisher(t, cfg)
+	sink.err = errors.New("endpoint unavailable")
+	p.Publish(context.Background(), testEvent())
+
+	p.dispatchDue(context.Background())
+	entry := repo.entry(t)
+	if entry.Attempts != 1 || !entry.NextAttemptAt.Equal(clk.Now().Add(30*time.Second)) {
+		t.Fatalf("Expected a retry after 30s, got attempt %d at %s", entry.Attempts, entry.NextAttemptAt)
+	}
+
+	// Not due before the backoff elapsed
+	p.dispatchDue(context.Background())
+	if entry := repo.entry(t); entry.Attempts != 1 {
+		t.Fatalf("Expected no attempt before the backoff elapsed, got %d attempts", entry.Attempts)
+	}
+
+	clk.Advance(30 * time.Second)
+	p.dispatchDue(context.Background())
+	entry = repo.entry(t)
+	if entry.Attempts != 2 || !entry.NextAttemptAt.Equal(clk.Now().Add(time.Minute)) {
+		t.Fatalf("Expected the backoff to double to 1m, got attempt %d at %s", entry.Attempts, entry.NextAttemptAt)
+	}
+
+	clk.Advance(time.Minute)
+	p.dispatchDue(context.Background())
+	if len(repo.entries) != 0 {
+		t.Errorf("Expected the entry to leave the outbox after %d attempts, got %d entries", 3, len(repo.entries))
+	}
+	if len(repo.deadLetters) != 1 || repo.deadLetters[0].Attempts != 3 {
+		t.Fatalf("Expected one dead letter after 3 attempts, got %+v", repo.deadLetters)
+	}
+	if repo.deadLetters[0].LastError != "endpoint unavailable" {
+		t.Errorf("Expected the last delivery error in the dead letter, got %q", repo.deadLetters[0].LastError)
+	}
+}
+
+func TestPublisher_OutboxBackoff_Capped(t *testing.T) {
+	p, _, _, _ := newOutboxTestPublisher(t, &config.Webhooks{OutboxRetryBackoffSeconds: 60})
+
+	if got := p.outboxBackoff(1); got != time.Minute {
+		t.Errorf("Expected the configured backoff after the first attempt, got %s", got)
+	}
+	if got := p.outboxBackoff(3); got != 4*time.Minute {
+		t.Errorf("Expected the backoff to double per attempt, got %s", got)
+	}
+	if got := p.outboxBackoff(20); got != maxOutboxBackoff {
+		t.Errorf("Expected the backoff to be capped at %s, got %s", maxOutboxBackoff, got)
+	}
+}
+
+func TestPublisher_Publish_StagedEventNotEnqueuedAgain(t *testing.T) {
+	p, repo, sink, _ := newOutboxTestPublisher(t, &config.Webhooks{OutboxBatchSize: 10, OutboxMaxAttempts: 3})
+	event := testEvent()
+
+	if err := p.Stage(context.Background(), noopTx{}, event); err != nil {
+		t.Fatalf("Failed to stage event: %v", err)
+	}
+	// The dispatcher may deliver and delete the staged entry before the event is published
+	p.dispatchDue(context.Background())
+	p.Publish(context.Background(), event)
+	p.dispatchDue(context.Background())
+
+	if !slices.Equal(sink.delivered, []uuid.UUID{event.ID}) {
+		t.Errorf("Expected the staged event to be delivered once, got %v", sink.delivered)
+	}
+	if len(repo.entries) != 0 {
+		t.Errorf("Expected no outbox entry left, got %d", len(repo.entries))
+	}
+}
@@ -0,0 +1,190 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+type publisher struct {
+	logger          tracelog.TraceLogger
+	deliveryTimeout time.Duration
+
+	sinks      []subscription
+	sinksMutex sync.RWMutex
+
+	// Persistent outbox, nil if events are only delivered in memory
+	outbox *outbox
+
+	// Context and cancellation of the outbox dispatcher
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// subscription is a registered sink and the event types it receives, every type if empty.
+type subscription struct {
+	sink       external.EventSink
+	eventTypes []external.EventType
+}
+
+// receives reports whether the sink of the subscription receives events of the given type.
+func (s subscription) receives(eventType external.EventType) bool {
+	return len(s.eventTypes) == 0 || slices.Contains(s.eventTypes, eventType)
+}
+
+// PublisherOption configures optional publisher behaviour.
+type PublisherOption func(*publisher)
+
+// NewEventPublisher creates a publisher that fans out events to the registered sinks.
+// Each delivery runs in its own goroutine and is bounded by deliveryTimeout, including retries.
+func NewEventPublisher(
+	logger tracelog.TraceLogger,
+	deliveryTimeout time.Duration,
+	opts ...PublisherOption,
+) services.EventPublisher {
+	p := &publisher{
+		logger:          logger.NewGroup("event_publisher"),
+		deliveryTimeout: deliveryTimeout,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Start starts the outbox dispatcher in a background goroutine. It is a no-op without an outbox.
+func (p *publisher) Start(ctx context.Context) error {
+	p.ctx, p.cancel = context.WithCancel(ctx)
+	if p.outbox == nil {
+		return nil
+	}
+
+	p.logger.Info(
+		"starting event outbox dispatcher",
+		"poll_interval",
+		p.outbox.pollInterval.String(),
+		"max_attempts",
+		p.outbox.maxAttempts,
+	)
+	p.wg.Add(1)
+	go p.runDispatcher(p.ctx)
+	return nil
+}
+
+// Register adds a sink that receives all subsequently published events of the given types,
+// or of every type if none are given.
+func (p *publisher) Register(sink external.EventSink, eventTypes ...external.EventType) {
+	p.sinksMutex.Lock()
+	defer p.sinksMutex.Unlock()
+
+	p.sinks = append(p.sinks, subscription{sink: sink, eventTypes: eventTypes})
+	p.logger.Info("event sink registered", "sink", sink.Name(), "event_types", eventTypes)
+}
+
+// Publish delivers the event to all registered sinks asynchronously.
+// With the outbox enabled, the event is stored in the outbox (unless it was already staged)
+// and the dispatcher is woken up to deliver it.
+func (p *publisher) Publish(ctx context.Context, event *external.Event) {
+	if p.outbox != nil {
+		p.publishToOutbox(ctx, event)
+		return
+	}
+
+	sinks := p.subscribedSinks(event.Type)
+
+	// Deliveries must outlive the request that triggered them
+	deliveryCtx := context.WithoutCancel(ctx)
+	for _, sink := range sinks {
+		p.wg.Add(1)
+		go p.deliver(deliveryCtx, sink, event)
+	}
+}
+
+// registeredSinks returns a snapshot of the registered sinks.
+func (p *publisher) registeredSinks() []external.EventSink {
+	p.sinksMutex.RLock()
+	defer p.sinksMutex.RUnlock()
+
+	sinks := make([]external.EventSink, len(p.sinks))
+	for i, sub := range p.sinks {
+		sinks[i] = sub.sink
+	}
+	return sinks
+}
+
+// subscribedSinks returns a snapshot of the registered sinks receiving events of the given type.
+func (p *publisher) subscribedSinks(eventType external.EventType) []external.EventSink {
+	p.sinksMutex.RLock()
+	defer p.sinksMutex.RUnlock()
+
+	sinks := make([]external.EventSink, 0, len(p.sinks))
+	for _, sub := range p.sinks {
+		if sub.receives(eventType) {
+			sinks = append(sinks, sub.sink)
+		}
+	}
+	return sinks
+}
+
+func (p *publisher) deliver(ctx context.Context, sink external.EventSink, event *external.Event) {
+	defer p.wg.Done()
+
+	ctx, cancel := context.WithTimeout(ctx, p.deliveryTimeout)
+	defer cancel()
+
+	logger := p.logger.WithSpan(ctx)
+	ctx, spanLogger, span := logger.StartSpan(ctx, "event_publisher.deliver")
+	defer span.End()
+
+	span.SetAttributes(
+		trace.Attribute{Key: "event.id", Value: event.ID.String()},
+		trace.Attribute{Key: "event.type", Value: string(event.Type)},
+		trace.Attribute{Key: "sink", Value: sink.Name()},
+	)
+
+	if err := sink.Send(ctx, event); err != nil {
+		spanLogger.Error(
+			"failed to deliver event",
+			err,
+			"event_id",
+			event.ID.String(),
+			"event_type",
+			string(event.Type),
+			"sink",
+			sink.Name(),
+		)
+		return
+	}
+
+	span.SetStatus(trace.StatusOK, "Successfully delivered event")
+	spanLogger.Info("event delivered", "event_id", event.ID.String(), "sink", sink.Name())
+}
+
+// Stop stops the outbox dispatcher and waits for in-flight deliveries to finish.
+func (p *publisher) Stop(ctx context.Context) error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.logger.Info("event publisher stopped gracefully")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timeout waiting for event deliveries to finish")
+	}
+}
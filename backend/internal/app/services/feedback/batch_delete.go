@@ -0,0 +1,149 @@
+package feedback
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/operations"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+// defaultMaxBatchDeleteSize is the batch size limit used when none is configured.
+const defaultMaxBatchDeleteSize = 100
+
+func (s *svc) DeleteFeedbacks(ctx context.Context, feedbackIDs []uuid.UUID) ([]services.FeedbackDeleteResult, error) {
+	logger := s.logger.WithSpan(ctx)
+	ctx, spanLogger, span := logger.StartSpan(ctx, "feedback_service.delete_feedbacks")
+	defer span.End()
+
+	span.SetAttributes(trace.Attribute{Key: "feedback_count", Value: len(feedbackIDs)})
+
+	spanLogger.Info("deleting feedbacks", "feedback_count", len(feedbackIDs))
+
+	results, err := s.deleteFeedbacks(ctx, feedbackIDs, spanLogger)
+	if err != nil {
+		span.SetStatus(trace.StatusError, err.Error())
+		spanLogger.RecordSpanError(ctx, err)
+		return nil, s.errChecker.Check(err)
+	}
+
+	span.SetStatus(trace.StatusOK, "Successfully deleted feedbacks")
+	return results, nil
+}
+
+func (s *svc) deleteFeedbacks(
+	ctx context.Context,
+	feedbackIDs []uuid.UUID,
+	logger tracelog.TraceLogger,
+) ([]services.FeedbackDeleteResult, error) {
+	if err := s.validateBatchDeleteIDs(feedbackIDs); err != nil {
+		return nil, err
+	}
+
+	var results []services.FeedbackDeleteResult
+	if err := operations.RunGenericTransaction(
+		ctx,
+		s.transactor,
+		func(ctx context.Context, tx repository.Transaction) error {
+			var err error
+			results, err = s.deleteFeedbackRecords(ctx, tx, feedbackIDs, logger)
+			return err
+		},
+	); err != nil {
+		logger.RecordSpanError(ctx, err)
+		return nil, fmt.Errorf("failed to delete feedbacks in transaction: %w", err)
+	}
+
+	deleted := 0
+	for _, result := range results {
+		if result.Status == services.FeedbackDeleted {
+			deleted++
+		}
+	}
+	logger.Info(
+		"feedbacks deleted successfully",
+		"requested_count", len(feedbackIDs),
+		"deleted_count", deleted,
+	)
+	return results, nil
+}
+
+// deleteFeedbackRecords soft deletes the existing, not yet deleted feedbacks of the batch within the transaction.
+func (s *svc) deleteFeedbackRecords(
+	ctx context.Context,
+	tx repository.Transaction,
+	feedbackIDs []uuid.UUID,
+	logger tracelog.TraceLogger,
+) ([]services.FeedbackDeleteResult, error) {
+	stored, err := s.feedRepo.GetByIDs(
+		ctx,
+		feedbackIDs,
+		repository.WithExecutor[apprepo.Options](tx),
+		apprepo.WithOptions(&apprepo.Options{IncludeDeleted: true}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feedbacks: %w", err)
+	}
+
+	storedByID := make(map[uuid.UUID]*feedback.Feedback, len(stored))
+	for _, fb := range stored {
+		storedByID[fb.ID()] = fb
+	}
+
+	results := make([]services.FeedbackDeleteResult, len(feedbackIDs))
+	for i, feedbackID := range feedbackIDs {
+		results[i].FeedbackID = feedbackID
+
+		fb, ok := storedByID[feedbackID]
+		switch {
+		case !ok:
+			results[i].Status = services.FeedbackNotFound
+		case fb.DeletedAt().IsSome():
+			results[i].Status = services.FeedbackAlreadyDeleted
+		default:
+			if err := s.deleteFeedbackRecord(feedbackID, logger)(ctx, tx); err != nil {
+				return nil, err
+			}
+			results[i].Status = services.FeedbackDeleted
+		}
+	}
+
+	return results, nil
+}
+
+// validateBatchDeleteIDs checks that the batch is non-empty, unique and within the configured size limit.
+func (s *svc) validateBatchDeleteIDs(feedbackIDs []uuid.UUID) error {
+	if len(feedbackIDs) == 0 {
+		return errors.ErrBadRequest("at least one feedback ID is required")
+	}
+
+	maxSize := defaultMaxBatchDeleteSize
+	if s.feedbackCfg != nil && s.feedbackCfg.MaxBatchDeleteSize > 0 {
+		maxSize = s.feedbackCfg.MaxBatchDeleteSize
+	}
+	if len(feedbackIDs) > maxSize {
+		return errors.ErrBadRequest(
+			fmt.Sprintf("too many feedbacks: %d requested, at most %d allowed", len(feedbackIDs), maxSize),
+		)
+	}
+
+	seen := make(map[uuid.UUID]bool, len(feedbackIDs))
+	for _, id := range feedbackIDs {
+		if id == uuid.Nil {
+			return errors.ErrBadRequest("feedback ID must not be empty")
+		}
+		if seen[id] {
+			return errors.ErrBadRequest("duplicate feedback ID: " + id.String())
+		}
+		seen[id] = true
+	}
+
+	return nil
+}
@@ -3,20 +3,32 @@ package feedback
 import (
 	"context"
 	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
 	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/transport/requests"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/shared"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/language"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/operations"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
 )
 
-func (s *svc) CreateFeedback(ctx context.Context, userID uuid.UUID, req *requests.CreateFeedbackRequest) (*feedback.Feedback, error) {
+func (s *svc) CreateFeedback(
+	ctx context.Context,
+	userID uuid.UUID,
+	req *requests.CreateFeedbackRequest,
+	exemptFromQuota bool,
+) (*feedback.Feedback, error) {
 	logger := s.logger.WithSpan(ctx)
 	ctx, spanLogger, span := logger.StartSpan(ctx, "feedback_service.create_feedback")
 	defer span.End()
@@ -25,9 +37,11 @@ func (s *svc) CreateFeedback(ctx context.Context, userID uuid.UUID, req *request
 		trace.Attribute{Key: "user_id", Value: userID.String()},
 		trace.Attribute{Key: "rating", Value: req.Rating},
 		trace.Attribute{Key: "comment_length", Value: len(req.Comment)},
+		trace.Attribute{Key: "source", Value: req.Source},
+		trace.Attribute{Key: "tags_count", Value: len(req.Tags)},
 	)
 
-	fb, err := s.createFeedback(ctx, userID, req, spanLogger)
+	fb, err := s.createFeedback(ctx, userID, req, exemptFromQuota, spanLogger)
 	if err != nil {
 		span.SetStatus(trace.StatusError, err.Error())
 		spanLogger.RecordSpanError(ctx, err)
@@ -43,6 +57,7 @@ func (s *svc) createFeedback(
 	ctx context.Context,
 	userID uuid.UUID,
 	req *requests.CreateFeedbackRequest,
+	exemptFromQuota bool,
 	logger tracelog.TraceLogger,
 ) (*feedback.Feedback, error) {
 	// Validate userID
@@ -50,22 +65,82 @@ func (s *svc) createFeedback(
 		return nil, errors.ErrBadRequest("user ID is required")
 	}
 
+	// Checked again under the submission lock when the feedback is stored. Checking up front as well means a
+	// rejected submission costs no moderation or translation
+	if err := s.checkSubmissionCooldown(ctx, userID, logger); err != nil {
+		return nil, err
+	}
+
+	if !exemptFromQuota {
+		if err := s.checkDailyQuota(ctx, userID, logger); err != nil {
+			return nil, err
+		}
+	}
+
 	// Build domain value objects
 	rating, err := feedback.NewRating(req.Rating)
 	if err != nil {
 		return nil, errors.ErrBadRequest("invalid rating", errors.WithCauseError(err))
 	}
 
-	comment, err := feedback.NewComment(req.Comment)
+	// Stripped before validation, so that length limits apply to the text that is stored and analyzed
+	commentText, originalComment := s.stripMarkup(req.Comment, userID, logger)
+	comment, err := feedback.NewComment(commentText)
 	if err != nil {
 		return nil, errors.ErrBadRequest("invalid comment", errors.WithCauseError(err))
 	}
 
+	comment, err = s.scrubComment(comment, userID, logger)
+	if err != nil {
+		return nil, errors.ErrBadRequest("invalid comment", errors.WithCauseError(err))
+	}
+
+	exclusionReason, err := s.checkCommentLanguage(comment, userID, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// Moderated after scrubbing, so that no PII is sent to the moderation endpoint
+	if exclusionReason == "" {
+		exclusionReason, err = s.moderateComment(ctx, comment, userID, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	source, err := feedback.NewSource(req.Source)
+	if err != nil {
+		return nil, errors.ErrBadRequest("invalid source", errors.WithCauseError(err))
+	}
+
+	if _, err := feedback.NewTags(req.Tags); err != nil {
+		return nil, errors.ErrBadRequest("invalid tags", errors.WithCauseError(err))
+	}
+
+	var metadata feedback.Metadata
+	if req.Metadata != nil {
+		metadata, err = feedback.NewMetadata(req.Metadata.AppVersion, req.Metadata.Platform, req.Metadata.OS)
+		if err != nil {
+			return nil, errors.ErrBadRequest("invalid metadata", errors.WithCauseError(err))
+		}
+	}
+
 	// Build domain entity with userID
-	builder := feedback.NewBuilder().
+	builder := feedback.NewBuilder(shared.WithClock(s.clock)).
 		WithUserID(userID).
 		WithRating(rating).
-		WithComment(comment)
+		WithComment(comment).
+		WithSource(source).
+		WithTags(req.Tags).
+		WithMetadata(metadata).
+		WithOriginalComment(originalComment)
+
+	// Translated after moderation, so that excluded comments are not sent to the LLM
+	if exclusionReason == "" {
+		if translation := s.translateComment(ctx, comment, userID, logger); translation != nil {
+			builder.WithTranslation(translation.TranslatedText(), translation.SourceLanguage)
+		}
+	}
 
 	fb, err := builder.Build()
 	if err != nil {
@@ -73,11 +148,17 @@ func (s *svc) createFeedback(
 	}
 	logger.Info("feedback built and validated", "feedback_id", fb.ID().String(), "user_id", userID.String())
 
-	// Create feedback in transaction
+	// Excluded in the same transaction as the feedback, so a filtered feedback is never listed as unanalyzed
+	if exclusionReason == "" {
+		exclusionReason = s.analyzer.ExclusionReason(fb)
+	}
+
+	// Create feedback in transaction, together with its event when the outbox is enabled
+	event := external.NewFeedbackCreatedEvent(fb, s.clock.Now().UTC())
 	if err := operations.RunGenericTransaction(
 		ctx,
 		s.transactor,
-		s.createFeedbackRecord(fb, logger),
+		s.createFeedbackRecord(fb, event, exclusionReason, exemptFromQuota, logger),
 	); err != nil {
 		logger.RecordSpanError(ctx, err)
 		return nil, fmt.Errorf("failed to create feedback in transaction: %w", err)
@@ -85,15 +166,54 @@ func (s *svc) createFeedback(
 
 	logger.Info("feedback created successfully", "feedback_id", fb.ID().String())
 
-	s.analyzer.EnqueueFeedback(ctx, fb)
-	logger.Info("feedback sent to analyzer", "feedback_id", fb.ID().String())
+	if exclusionReason == "" {
+		s.analyzer.EnqueueFeedback(ctx, fb)
+		logger.Info("feedback sent to analyzer", "feedback_id", fb.ID().String())
+	}
+
+	s.events.Publish(ctx, event)
 
 	return fb, nil
 }
 
-func (s *svc) createFeedbackRecord(fb *feedback.Feedback, logger tracelog.TraceLogger) operations.TxExecFunc {
+// createFeedbackRecord stores the feedback and stages its event. A non-empty exclusion reason also excludes
+// the feedback from analysis. The submission cooldown and, unless the user is exempt, the daily quota are checked
+// under the submission lock of the user, so that concurrent submissions cannot bypass them.
+func (s *svc) createFeedbackRecord(
+	fb *feedback.Feedback,
+	event *external.Event,
+	exclusionReason string,
+	exemptFromQuota bool,
+	logger tracelog.TraceLogger,
+) operations.TxExecFunc {
 	return func(ctx context.Context, tx repository.Transaction) error {
 		logger := logger.WithSpan(ctx)
+
+		checkQuota := !exemptFromQuota && s.feedbackCfg.DailyFeedbackQuota > 0
+		if checkQuota || s.feedbackCfg.SubmissionCooldownEnabled {
+			if err := s.feedRepo.LockSubmissions(
+				ctx,
+				fb.UserID(),
+				repository.WithExecutor[apprepo.Options](tx),
+			); err != nil {
+				logger.RecordSpanError(ctx, err)
+				return fmt.Errorf("failed to lock feedback submissions: %w", err)
+			}
+		}
+		if err := s.checkSubmissionCooldown(
+			ctx,
+			fb.UserID(),
+			logger,
+			repository.WithExecutor[apprepo.Options](tx),
+		); err != nil {
+			return err
+		}
+		if checkQuota {
+			if err := s.checkDailyQuota(ctx, fb.UserID(), logger, repository.WithExecutor[apprepo.Options](tx)); err != nil {
+				return err
+			}
+		}
+
 		logger.Info("creating feedback record in database", "feedback_id", fb.ID().String())
 
 		if err := s.feedRepo.Create(ctx, fb, repository.WithExecutor[apprepo.Options](tx)); err != nil {
@@ -101,7 +221,304 @@ func (s *svc) createFeedbackRecord(fb *feedback.Feedback, logger tracelog.TraceL
 			return fmt.Errorf("failed to create feedback: %w", err)
 		}
 
+		if exclusionReason != "" {
+			if err := s.feedRepo.ExcludeFromAnalysis(
+				ctx,
+				fb.ID(),
+				exclusionReason,
+				repository.WithExecutor[apprepo.Options](tx),
+			); err != nil {
+				logger.RecordSpanError(ctx, err)
+				return fmt.Errorf("failed to exclude feedback from analysis: %w", err)
+			}
+		}
+
+		if err := s.events.Stage(ctx, tx, event); err != nil {
+			logger.RecordSpanError(ctx, err)
+			return fmt.Errorf("failed to stage feedback created event: %w", err)
+		}
+
 		logger.Info("feedback record created successfully")
 		return nil
 	}
 }
+
+// checkSubmissionCooldown rejects the submission if the user already submitted feedback within the configured cooldown.
+// Deleted feedbacks count as for the daily quota, so that deleting feedback does not lift the cooldown.
+func (s *svc) checkSubmissionCooldown(
+	ctx context.Context,
+	userID uuid.UUID,
+	logger tracelog.TraceLogger,
+	opts ...repository.RepoOption[apprepo.Options],
+) error {
+	if !s.feedbackCfg.SubmissionCooldownEnabled {
+		return nil
+	}
+
+	latest, err := s.feedRepo.LatestByUser(ctx, userID, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to get latest feedback of user: %w", err)
+	}
+	if latest == nil {
+		return nil
+	}
+
+	cooldown := time.Duration(s.feedbackCfg.SubmissionCooldownSeconds) * time.Second
+	remaining := cooldown - s.clock.Since(latest.CreatedAt())
+	if remaining <= 0 {
+		return nil
+	}
+
+	remainingSeconds := int(math.Ceil(remaining.Seconds()))
+	logger.Info(
+		"feedback submission rejected due to cooldown",
+		"user_id",
+		userID.String(),
+		"remaining_seconds",
+		remainingSeconds,
+	)
+
+	return errors.ErrTooManyRequests(
+		fmt.Sprintf("feedback was already submitted recently, try again in %d seconds", remainingSeconds),
+	)
+}
+
+// checkDailyQuota rejects the submission if the user already submitted the configured number of feedbacks
+// in the current UTC day. Deleted feedbacks still count, so that deleting feedback does not free up submissions.
+// The error details carry the quota and when it resets.
+func (s *svc) checkDailyQuota(
+	ctx context.Context,
+	userID uuid.UUID,
+	logger tracelog.TraceLogger,
+	opts ...repository.RepoOption[apprepo.Options],
+) error {
+	quota := s.feedbackCfg.DailyFeedbackQuota
+	if quota <= 0 {
+		return nil
+	}
+
+	dayStart := s.clock.Now().UTC().Truncate(24 * time.Hour)
+	count, err := s.feedRepo.CountByUserSince(ctx, userID, dayStart, opts...)
+	if err != nil {
+		return fmt.Errorf("failed to count feedbacks of user: %w", err)
+	}
+	if count < quota {
+		return nil
+	}
+
+	resetAt := dayStart.Add(24 * time.Hour)
+	logger.Info(
+		"feedback submission rejected due to daily quota",
+		"user_id",
+		userID.String(),
+		"daily_quota",
+		quota,
+		"reset_at",
+		resetAt.Format(time.RFC3339),
+	)
+
+	return errors.ErrTooManyRequests(
+		fmt.Sprintf("daily feedback quota of %d reached, try again after %s", quota, resetAt.Format(time.RFC3339)),
+		errors.WithDetails(
+			map[string]any{
+				"daily_quota": quota,
+				"reset_at":    resetAt.Format(time.RFC3339),
+			},
+		),
+	)
+}
+
+// stripMarkup removes markup from the submitted comment if stripping is enabled. It returns the stripped text and,
+// if markup was removed and the original is to be preserved, the submitted text with PII masked if scrubbing is
+// enabled. Otherwise the returned original is empty.
+func (s *svc) stripMarkup(text string, userID uuid.UUID, logger tracelog.TraceLogger) (string, string) {
+	stripped, changed := s.stripper.Strip(text)
+	if !changed {
+		return text, ""
+	}
+
+	logger.Info(
+		"markup stripped from feedback comment",
+		"user_id",
+		userID.String(),
+		"original_length",
+		len(text),
+		"stripped_length",
+		len(stripped),
+	)
+
+	if !s.feedbackCfg.PreserveOriginalComment {
+		return stripped, ""
+	}
+	// Invalid UTF-8 cannot be stored, the stripped text goes through the configured policy instead
+	original, _ := s.scrubber.Scrub(strings.ToValidUTF8(text, string(utf8.RuneError)))
+	return stripped, original
+}
+
+// scrubComment masks PII in the comment if scrubbing is enabled.
+// Only the kinds and number of matches are logged, never the matched text.
+func (s *svc) scrubComment(
+	comment feedback.Comment,
+	userID uuid.UUID,
+	logger tracelog.TraceLogger,
+) (feedback.Comment, error) {
+	if s.scrubber == nil {
+		return comment, nil
+	}
+
+	scrubbed, findings := s.scrubber.Scrub(comment.Value())
+	if findings.Total() == 0 {
+		return comment, nil
+	}
+
+	logger.Info(
+		"pii scrubbed from feedback comment",
+		"user_id",
+		userID.String(),
+		"matches",
+		findings.Total(),
+		"patterns",
+		findings.Patterns(),
+	)
+
+	return feedback.NewComment(scrubbed)
+}
+
+// checkCommentLanguage rejects the feedback, or returns the reason to exclude it from analysis, if its comment is
+// not in an accepted language. Empty comments are accepted. Comments whose language could not be detected are
+// handled like those in other languages, unless undetected languages are accepted.
+func (s *svc) checkCommentLanguage(
+	comment feedback.Comment,
+	userID uuid.UUID,
+	logger tracelog.TraceLogger,
+) (string, error) {
+	if comment.IsEmpty() || len(s.feedbackCfg.AcceptedLanguages) == 0 {
+		return "", nil
+	}
+
+	// An undetected language yields an empty code
+	code, _ := language.Detect(comment.Value())
+	if s.feedbackCfg.AcceptsLanguage(code) {
+		return "", nil
+	}
+
+	logger.Info(
+		"feedback comment language not accepted",
+		"user_id",
+		userID.String(),
+		"language",
+		code,
+		"excluded",
+		s.feedbackCfg.ExcludeUnacceptedLanguages(),
+	)
+
+	message, reason := fmt.Sprintf("comment language %q is not accepted", code), "language not accepted: "+code
+	if code == "" {
+		message, reason = "comment language could not be detected", "language not detected"
+	}
+
+	if !s.feedbackCfg.ExcludeUnacceptedLanguages() {
+		return "", errors.ErrBadRequest(
+			message,
+			errors.WithDetails(
+				map[string]any{
+					"language":           code,
+					"accepted_languages": s.feedbackCfg.AcceptedLanguages,
+				},
+			),
+		)
+	}
+
+	return reason, nil
+}
+
+// moderateComment checks the comment with the moderator. Flagged comments are rejected, or, if flagged content is
+// excluded instead, the returned reason marks the feedback for exclusion from analysis. Unflagged and empty comments
+// yield an empty reason. If moderation fails, the feedback is accepted so that submissions do not depend on the
+// moderation endpoint being available. Only the categories are logged, never the comment.
+func (s *svc) moderateComment(
+	ctx context.Context,
+	comment feedback.Comment,
+	userID uuid.UUID,
+	logger tracelog.TraceLogger,
+) (string, error) {
+	if comment.IsEmpty() {
+		return "", nil
+	}
+
+	result, err := s.moderator.Moderate(ctx, comment.Value())
+	if err != nil {
+		logger.Warning(
+			"feedback comment moderation failed, accepting it",
+			"user_id",
+			userID.String(),
+			"error",
+			err.Error(),
+		)
+		return "", nil
+	}
+	if !result.Flagged {
+		return "", nil
+	}
+
+	logger.Info(
+		"feedback comment flagged by moderation",
+		"user_id",
+		userID.String(),
+		"categories",
+		result.Categories,
+		"excluded",
+		s.feedbackCfg.ExcludeFlaggedContent(),
+	)
+
+	if !s.feedbackCfg.ExcludeFlaggedContent() {
+		return "", errors.ErrUnprocessable(
+			"comment was flagged by content moderation",
+			errors.WithDetails(map[string]any{"categories": result.Categories}),
+		)
+	}
+
+	return "moderation: " + strings.Join(result.Categories, ", "), nil
+}
+
+// translateComment translates the comment into the configured target language if translation is enabled.
+// It returns nil for empty comments, if translation is disabled, and if it fails or exceeds the translation timeout:
+// the feedback is then stored and analyzed untranslated, so that submissions do not depend on the LLM being available.
+func (s *svc) translateComment(
+	ctx context.Context,
+	comment feedback.Comment,
+	userID uuid.UUID,
+	logger tracelog.TraceLogger,
+) *external.Translation {
+	if comment.IsEmpty() || !s.feedbackCfg.TranslationEnabled {
+		return nil
+	}
+
+	translateCtx, cancel := context.WithTimeout(ctx, s.feedbackCfg.TranslationTimeout())
+	defer cancel()
+
+	translation, err := s.translator.Translate(translateCtx, comment.Value(), s.feedbackCfg.TranslationLanguage())
+	if err != nil {
+		logger.Warning(
+			"feedback comment translation failed, storing it untranslated",
+			"user_id",
+			userID.String(),
+			"error",
+			err.Error(),
+		)
+		return nil
+	}
+
+	if translation.Translated {
+		logger.Info(
+			"feedback comment translated",
+			"user_id",
+			userID.String(),
+			"source_language",
+			translation.SourceLanguage,
+			"target_language",
+			s.feedbackCfg.TranslationLanguage(),
+		)
+	}
+	return translation
+}
@@ -0,0 +1,430 @@
+package feedback
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/transport/requests"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
+	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/log"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+func newTestLogger(t *testing.T) tracelog.TraceLogger {
+	t.Helper()
+
+	tracer, err := trace.NewTracer(trace.Config{ServiceName: "feedback-test"})
+	if err != nil {
+		t.Fatalf("Failed to create tracer: %v", err)
+	}
+
+	return tracelog.NewTraceLogger(log.NewLogger("development"), tracer)
+}
+
+// testTx is a transaction that records whether it was committed and releases the locks taken in it when it ends.
+type testTx struct {
+	repository.Transaction
+	committed bool
+	unlock    func()
+}
+
+func (tx *testTx) Commit(context.Context) error {
+	tx.committed = true
+	tx.end()
+	return nil
+}
+
+func (tx *testTx) Rollback(context.Context) error {
+	tx.end()
+	return nil
+}
+
+func (tx *testTx) end() {
+	if tx.unlock != nil {
+		tx.unlock()
+	}
+}
+
+// testTransactor hands out a new testTx per transaction.
+type testTransactor struct {
+	mu  sync.Mutex
+	txs []*testTx
+}
+
+func (tr *testTransactor) NewTransaction(context.Context, ...repository.TransactionOption) (
+	repository.Transaction,
+	error,
+) {
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+
+	tx := &testTx{}
+	tr.txs = append(tr.txs, tx)
+	return tx, nil
+}
+
+// recordingFeedbackRepo stores created feedbacks and the reasons they were excluded from analysis.
+// LockSubmissions holds a lock until the transaction ends, like the transaction-level advisory lock of the
+// Postgres repository.
+type recordingFeedbackRepo struct {
+	apprepo.FeedbackRepository
+	submissions sync.Mutex
+	mu          sync.Mutex
+	created     []*feedback.Feedback
+	exclusions  map[uuid.UUID]string
+}
+
+func (r *recordingFeedbackRepo) Create(
+	_ context.Context,
+	fb *feedback.Feedback,
+	_ ...repository.RepoOption[apprepo.Options],
+) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.created = append(r.created, fb)
+	return nil
+}
+
+func (r *recordingFeedbackRepo) CountByUserSince(
+	_ context.Context,
+	userID uuid.UUID,
+	since time.Time,
+	_ ...repository.RepoOption[apprepo.Options],
+) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, fb := range r.created {
+		if fb.UserID() == userID && !fb.CreatedAt().Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *recordingFeedbackRepo) LatestByUser(
+	_ context.Context,
+	userID uuid.UUID,
+	_ ...repository.RepoOption[apprepo.Options],
+) (*feedback.Feedback, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var latest *feedback.Feedback
+	for _, fb := range r.created {
+		if fb.UserID() == userID && (latest == nil || fb.CreatedAt().After(latest.CreatedAt())) {
+			latest = fb
+		}
+	}
+	return latest, nil
+}
+
+func (r *recordingFeedbackRepo) LockSubmissions(
+	_ context.Context,
+	_ uuid.UUID,
+	opts ...repository.RepoOption[apprepo.Options],
+) error {
+	tx := utils.BuildOpts(opts).Ex.(*testTx)
+	r.submissions.Lock()
+	tx.unlock = r.submissions.Unlock
+	return nil
+}
+
+func (r *recordingFeedbackRepo) ExcludeFromAnalysis(
+	_ context.Context,
+	feedbackID uuid.UUID,
+	reason string,
+	_ ...repository.RepoOption[apprepo.Options],
+) error {
+	if r.exclusions == nil {
+		r.exclusions = make(map[uuid.UUID]string)
+	}
+	r.exclusions[feedbackID] = reason
+	return nil
+}
+
+// recordingAnalyzer records the feedbacks enqueued for analysis and excludes every feedback for reason, if set.
+type recordingAnalyzer struct {
+	services.AnalyzerService
+	reason   string
+	mu       sync.Mutex
+	enqueued []*feedback.Feedback
+}
+
+func (a *recordingAnalyzer) ExclusionReason(*feedback.Feedback) string {
+	return a.reason
+}
+
+func (a *recordingAnalyzer) EnqueueFeedback(_ context.Context, fb *feedback.Feedback) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.enqueued = append(a.enqueued, fb)
+}
+
+// discardingPublisher drops every event.
+type discardingPublisher struct {
+	services.EventPublisher
+}
+
+func (discardingPublisher) Stage(context.Context, repository.Transaction, *external.Event) error {
+	return nil
+}
+
+func (discardingPublisher) Publish(context.Context, *external.Event) {}
+
+func newCreateTestService(t *testing.T, cfg *config.Feedback) (*svc, *recordingFeedbackRepo, *recordingAnalyzer) {
+	t.Helper()
+
+	repo := &recordingFeedbackRepo{}
+	analyzer := &recordingAnalyzer{}
+	return &svc{
+		logger:      newTestLogger(t),
+		feedbackCfg: cfg,
+		feedRepo:    repo,
+		transactor:  &testTransactor{},
+		analyzer:    analyzer,
+		events:      discardingPublisher{},
+		clock:       clock.New(),
+		moderator:   external.NoopModerator{},
+		translator:  external.NoopTranslator{},
+	}, repo, analyzer
+}
+
+func TestService_CreateFeedback_LanguageRestriction(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        config.Feedback
+		comment    string
+		wantReject bool
+		wantReason string
+	}{
+		{
+			name:    "accepted language",
+			cfg:     config.Feedback{AcceptedLanguages: []string{"en"}},
+			comment: "The app is great but the search is very slow",
+		},
+		{
+			name:       "rejected language",
+			cfg:        config.Feedback{AcceptedLanguages: []string{"en"}},
+			comment:    "Die App ist sehr gut, aber leider nicht schnell genug",
+			wantReject: true,
+		},
+		{
+			name: "excluded language",
+			cfg: config.Feedback{
+				AcceptedLanguages:         []string{"en"},
+				LanguageRestrictionAction: config.LanguageRestrictionExclude,
+			},
+			comment:    "Die App ist sehr gut, aber leider nicht schnell genug",
+			wantReason: "language not accepted: de",
+		},
+		{
+			name:       "undetected language rejected",
+			cfg:        config.Feedback{AcceptedLanguages: []string{"en"}},
+			comment:    "Fantastic!",
+			wantReject: true,
+		},
+		{
+			name: "undetected language excluded",
+			cfg: config.Feedback{
+				AcceptedLanguages:         []string{"en"},
+				LanguageRestrictionAction: config.LanguageRestrictionExclude,
+			},
+			comment:    "Fantastic!",
+			wantReason: "language not detected",
+		},
+		{
+			name:    "undetected language accepted",
+			cfg:     config.Feedback{AcceptedLanguages: []string{"en"}, AcceptUndetectedLanguages: true},
+			comment: "Fantastic!",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				s, repo, analyzer := newCreateTestService(t, &tt.cfg)
+				req := &requests.CreateFeedbackRequest{Rating: 4, Comment: tt.comment, Source: "web"}
+
+				fb, err := s.createFeedback(context.Background(), uuid.New(), req, false, s.logger)
+				if tt.wantReject {
+					var appErr ce.ApplicationError
+					if !errors.As(err, &appErr) || appErr.HTTPCode() != http.StatusBadRequest {
+						t.Fatalf("Expected a bad request error, got: %v", err)
+					}
+					if _, ok := appErr.ErrDetails()["accepted_languages"]; !ok {
+						t.Errorf("Expected the accepted languages in the error details, got %v", appErr.ErrDetails())
+					}
+					if len(repo.created) != 0 {
+						t.Errorf("Expected no feedback to be stored, got %d", len(repo.created))
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("Expected the feedback to be created, got: %v", err)
+				}
+
+				if len(repo.created) != 1 {
+					t.Fatalf("Expected one stored feedback, got %d", len(repo.created))
+				}
+				if got := repo.exclusions[fb.ID()]; got != tt.wantReason {
+					t.Errorf("Expected exclusion reason %q, got %q", tt.wantReason, got)
+				}
+				if wantEnqueued := tt.wantReason == ""; (len(analyzer.enqueued) == 1) != wantEnqueued {
+					t.Errorf("Expected enqueued for analysis %v, got %d enqueued", wantEnqueued, len(analyzer.enqueued))
+				}
+			},
+		)
+	}
+}
+
+func TestService_CreateFeedback_ExcludedByAnalyzer(t *testing.T) {
+	s, repo, analyzer := newCreateTestService(t, &config.Feedback{})
+	analyzer.reason = "rating outside filter"
+	req := &requests.CreateFeedbackRequest{Rating: 5, Comment: "The app is great", Source: "web"}
+
+	fb, err := s.createFeedback(context.Background(), uuid.New(), req, false, s.logger)
+	if err != nil {
+		t.Fatalf("Expected the feedback to be created, got: %v", err)
+	}
+
+	if got := repo.exclusions[fb.ID()]; got != analyzer.reason {
+		t.Errorf("Expected exclusion reason %q, got %q", analyzer.reason, got)
+	}
+	if txs := s.transactor.(*testTransactor).txs; len(txs) != 1 || !txs[0].committed {
+		t.Error("Expected the feedback and its exclusion to be stored in one committed transaction")
+	}
+	if len(analyzer.enqueued) != 0 {
+		t.Errorf("Expected the excluded feedback not to be enqueued, got %d enqueued", len(analyzer.enqueued))
+	}
+}
+
+// barrierModerator holds every moderation until all expected submissions reached it, so that they all passed
+// the up-front checks before any of them is stored.
+type barrierModerator struct {
+	arrived sync.WaitGroup
+}
+
+func (m *barrierModerator) Moderate(context.Context, string) (*external.ModerationResult, error) {
+	m.arrived.Done()
+	m.arrived.Wait()
+	return &external.ModerationResult{}, nil
+}
+
+func TestService_CreateFeedback_ConcurrentSubmissionLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfg        config.Feedback
+		wantStored int
+	}{
+		{name: "daily quota", cfg: config.Feedback{DailyFeedbackQuota: 2}, wantStored: 2},
+		{
+			name:       "submission cooldown",
+			cfg:        config.Feedback{SubmissionCooldownEnabled: true, SubmissionCooldownSeconds: 300},
+			wantStored: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				s, repo, _ := newCreateTestService(t, &tt.cfg)
+				userID := uuid.New()
+
+				const submissions = 5
+				moderator := &barrierModerator{}
+				moderator.arrived.Add(submissions)
+				s.moderator = moderator
+
+				var wg sync.WaitGroup
+				for range submissions {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						req := &requests.CreateFeedbackRequest{Rating: 4, Comment: "The app is great", Source: "web"}
+						_, _ = s.createFeedback(context.Background(), userID, req, false, s.logger)
+					}()
+				}
+				wg.Wait()
+
+				if len(repo.created) != tt.wantStored {
+					t.Errorf("Expected %d stored feedbacks, got %d", tt.wantStored, len(repo.created))
+				}
+			},
+		)
+	}
+}
+
+// unavailableTranslator fails every translation, after waiting for the translation context to be done if slow.
+type unavailableTranslator struct {
+	slow bool
+}
+
+func (t unavailableTranslator) Translate(ctx context.Context, _, _ string) (*external.Translation, error) {
+	if t.slow {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return nil, errors.New("translation unavailable")
+}
+
+func TestService_CreateFeedback_TranslationUnavailable(t *testing.T) {
+	tests := []struct {
+		name       string
+		translator unavailableTranslator
+	}{
+		{name: "slow translator", translator: unavailableTranslator{slow: true}},
+		{name: "failing translator", translator: unavailableTranslator{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				cfg := &config.Feedback{TranslationEnabled: true, TranslationTimeoutSeconds: 1}
+				s, repo, analyzer := newCreateTestService(t, cfg)
+				s.translator = tt.translator
+				req := &requests.CreateFeedbackRequest{Rating: 4, Comment: "La aplicación es genial", Source: "web"}
+
+				start := time.Now()
+				fb, err := s.createFeedback(context.Background(), uuid.New(), req, false, s.logger)
+				elapsed := time.Since(start)
+				if err != nil {
+					t.Fatalf("Expected the feedback to be created, got: %v", err)
+				}
+
+				if limit := cfg.TranslationTimeout() + time.Second; elapsed > limit {
+					t.Errorf("Expected the feedback to be created within %v, took %v", limit, elapsed)
+				}
+				if len(repo.created) != 1 {
+					t.Fatalf("Expected one stored feedback, got %d", len(repo.created))
+				}
+				if got := fb.Comment().Value(); got != req.Comment {
+					t.Errorf("Expected the untranslated comment %q, got %q", req.Comment, got)
+				}
+				if fb.TranslatedComment().IsSome() {
+					t.Errorf("Expected no translated comment, got %q", fb.TranslatedComment().Unwrap())
+				}
+				if len(analyzer.enqueued) != 1 {
+					t.Errorf("Expected the untranslated feedback to be enqueued, got %d enqueued", len(analyzer.enqueued))
+				}
+			},
+		)
+	}
+}
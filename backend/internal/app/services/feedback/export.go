@@ -0,0 +1,130 @@
+package feedback
+
+import (
+	"context"
+	"fmt"
+
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+func (s *svc) ExportFeedbacks(
+	ctx context.Context,
+	filter services.FeedbackExportFilter,
+	fn func(*feedback.Feedback) error,
+) error {
+	logger := s.logger.WithSpan(ctx)
+	ctx, spanLogger, span := logger.StartSpan(ctx, "feedback_service.export_feedbacks")
+	defer span.End()
+
+	span.SetAttributes(
+		trace.Attribute{Key: "source", Value: filter.Source},
+		trace.Attribute{Key: "tag", Value: filter.Tag},
+		trace.Attribute{Key: "platform", Value: filter.Platform},
+		trace.Attribute{Key: "app_version", Value: filter.AppVersion},
+	)
+	spanLogger.Info(
+		"exporting feedbacks",
+		"source",
+		filter.Source,
+		"tag",
+		filter.Tag,
+		"platform",
+		filter.Platform,
+		"app_version",
+		filter.AppVersion,
+		"created_from",
+		filter.CreatedFrom,
+		"created_to",
+		filter.CreatedTo,
+	)
+
+	exported, err := s.exportFeedbacks(ctx, filter, fn, spanLogger)
+	if err != nil {
+		span.SetStatus(trace.StatusError, err.Error())
+		spanLogger.RecordSpanError(ctx, err)
+		return s.errChecker.Check(err)
+	}
+
+	span.SetStatus(trace.StatusOK, "Successfully exported feedbacks")
+	span.SetAttributes(trace.Attribute{Key: "count", Value: exported})
+	return nil
+}
+
+func (s *svc) exportFeedbacks(
+	ctx context.Context,
+	filter services.FeedbackExportFilter,
+	fn func(*feedback.Feedback) error,
+	logger tracelog.TraceLogger,
+) (int, error) {
+	options, err := exportOptions(filter)
+	if err != nil {
+		return 0, err
+	}
+
+	exported := 0
+	if err := s.feedRepo.Export(
+		ctx,
+		func(fb *feedback.Feedback) error {
+			exported++
+			return fn(fb)
+		},
+		apprepo.WithOptions(options),
+	); err != nil {
+		return exported, fmt.Errorf("failed to export feedbacks: %w", err)
+	}
+
+	logger.Info("feedbacks exported successfully", "count", exported)
+	return exported, nil
+}
+
+// exportOptions validates the export filter and converts it into repository options.
+func exportOptions(filter services.FeedbackExportFilter) (*apprepo.Options, error) {
+	if filter.Source != "" {
+		if _, err := feedback.NewSource(filter.Source); err != nil {
+			return nil, errors.ErrBadRequest("invalid source filter", errors.WithCauseError(err))
+		}
+	}
+	var tag feedback.Tag
+	if filter.Tag != "" {
+		var err error
+		if tag, err = feedback.NewTag(filter.Tag); err != nil {
+			return nil, errors.ErrBadRequest("invalid tag filter", errors.WithCauseError(err))
+		}
+	}
+	metadata, err := feedback.NewMetadata(filter.AppVersion, filter.Platform, "")
+	if err != nil {
+		return nil, errors.ErrBadRequest("invalid platform or app_version filter", errors.WithCauseError(err))
+	}
+
+	if !filter.CreatedFrom.IsZero() && !filter.CreatedTo.IsZero() && !filter.CreatedFrom.Before(filter.CreatedTo) {
+		return nil, errors.ErrBadRequest("created_from must be before created_to")
+	}
+	for _, rating := range []optional.Optional[int]{filter.MinRating, filter.MaxRating} {
+		if rating.IsNone() {
+			continue
+		}
+		if _, err := feedback.NewRating(rating.Unwrap()); err != nil {
+			return nil, errors.ErrBadRequest("invalid rating filter", errors.WithCauseError(err))
+		}
+	}
+	if filter.MinRating.IsSome() && filter.MaxRating.IsSome() && filter.MinRating.Unwrap() > filter.MaxRating.Unwrap() {
+		return nil, errors.ErrBadRequest("min_rating cannot be greater than max_rating")
+	}
+
+	return &apprepo.Options{
+		Source:      filter.Source,
+		Tag:         tag.String(),
+		Platform:    metadata.Platform().String(),
+		AppVersion:  metadata.AppVersion(),
+		CreatedFrom: filter.CreatedFrom,
+		CreatedTo:   filter.CreatedTo,
+		MinRating:   filter.MinRating,
+		MaxRating:   filter.MaxRating,
+	}, nil
+}
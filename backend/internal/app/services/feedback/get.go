@@ -4,16 +4,29 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
 )
 
-func (s *svc) GetFeedbackByID(ctx context.Context, feedbackID uuid.UUID) (*feedback.Feedback, error) {
+func (s *svc) GetFeedbackByID(
+	ctx context.Context,
+	feedbackID uuid.UUID,
+	includeDeleted bool,
+) (*feedback.Feedback, error) {
 	logger := s.logger.WithSpan(ctx)
-	logger.SetSpanAttributes(ctx, trace.Attribute{Key: "feedback_id", Value: feedbackID.String()})
-	logger.Info("getting feedback by id", "feedback_id", feedbackID.String())
+	logger.SetSpanAttributes(
+		ctx,
+		trace.Attribute{Key: "feedback_id", Value: feedbackID.String()},
+		trace.Attribute{Key: "include_deleted", Value: includeDeleted},
+	)
+	logger.Info("getting feedback by id", "feedback_id", feedbackID.String(), "include_deleted", includeDeleted)
 
-	fb, err := s.feedRepo.Get(ctx, feedbackID)
+	fb, err := s.feedRepo.Get(
+		ctx,
+		feedbackID,
+		apprepo.WithOptions(&apprepo.Options{IncludeDeleted: includeDeleted}),
+	)
 	if err != nil {
 		logger.RecordSpanError(ctx, err)
 		return nil, s.errChecker.Check(err)
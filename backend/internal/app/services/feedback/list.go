@@ -5,13 +5,19 @@ import (
 	"fmt"
 
 	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/operations"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
 )
 
-func (s *svc) ListFeedbacks(ctx context.Context, limit, offset int) ([]*feedback.Feedback, error) {
+func (s *svc) ListFeedbacks(
+	ctx context.Context,
+	limit, offset int,
+	filter services.FeedbackFilter,
+) (*services.Page[*feedback.Feedback], error) {
 	logger := s.logger.WithSpan(ctx)
 	ctx, spanLogger, span := logger.StartSpan(ctx, "feedback_service.list_feedbacks")
 	defer span.End()
@@ -19,10 +25,31 @@ func (s *svc) ListFeedbacks(ctx context.Context, limit, offset int) ([]*feedback
 	span.SetAttributes(
 		trace.Attribute{Key: "limit", Value: limit},
 		trace.Attribute{Key: "offset", Value: offset},
+		trace.Attribute{Key: "source", Value: filter.Source},
+		trace.Attribute{Key: "tag", Value: filter.Tag},
+		trace.Attribute{Key: "platform", Value: filter.Platform},
+		trace.Attribute{Key: "app_version", Value: filter.AppVersion},
+		trace.Attribute{Key: "include_deleted", Value: filter.IncludeDeleted},
+	)
+	spanLogger.Info(
+		"listing feedbacks",
+		"limit",
+		limit,
+		"offset",
+		offset,
+		"source",
+		filter.Source,
+		"tag",
+		filter.Tag,
+		"platform",
+		filter.Platform,
+		"app_version",
+		filter.AppVersion,
+		"include_deleted",
+		filter.IncludeDeleted,
 	)
-	spanLogger.Info("listing feedbacks", "limit", limit, "offset", offset)
 
-	feedbacks, err := s.listFeedbacks(ctx, limit, offset, spanLogger)
+	page, err := s.listFeedbacks(ctx, limit, offset, filter, spanLogger)
 	if err != nil {
 		span.SetStatus(trace.StatusError, err.Error())
 		spanLogger.RecordSpanError(ctx, err)
@@ -30,15 +57,19 @@ func (s *svc) ListFeedbacks(ctx context.Context, limit, offset int) ([]*feedback
 	}
 
 	span.SetStatus(trace.StatusOK, "Successfully listed feedbacks")
-	span.SetAttributes(trace.Attribute{Key: "count", Value: len(feedbacks)})
-	return feedbacks, nil
+	span.SetAttributes(
+		trace.Attribute{Key: "count", Value: len(page.Items)},
+		trace.Attribute{Key: "total", Value: page.Total},
+	)
+	return page, nil
 }
 
 func (s *svc) listFeedbacks(
 	ctx context.Context,
 	limit, offset int,
+	filter services.FeedbackFilter,
 	logger tracelog.TraceLogger,
-) ([]*feedback.Feedback, error) {
+) (*services.Page[*feedback.Feedback], error) {
 	if limit <= 0 {
 		limit = s.paginationCfg.Limit
 	}
@@ -48,20 +79,61 @@ func (s *svc) listFeedbacks(
 	if offset < 0 {
 		offset = s.paginationCfg.Offset
 	}
+	if filter.Source != "" {
+		if _, err := feedback.NewSource(filter.Source); err != nil {
+			return nil, errors.ErrBadRequest("invalid source filter", errors.WithCauseError(err))
+		}
+	}
+	var tag feedback.Tag
+	if filter.Tag != "" {
+		var err error
+		if tag, err = feedback.NewTag(filter.Tag); err != nil {
+			return nil, errors.ErrBadRequest("invalid tag filter", errors.WithCauseError(err))
+		}
+	}
+	metadata, err := feedback.NewMetadata(filter.AppVersion, filter.Platform, "")
+	if err != nil {
+		return nil, errors.ErrBadRequest("invalid platform or app_version filter", errors.WithCauseError(err))
+	}
 
-	feedbacks, err := s.feedRepo.List(
-		ctx,
-		apprepo.WithOptions(
-			&apprepo.Options{
-				Limit:  limit,
-				Offset: offset,
-			},
-		),
+	repoOpts := apprepo.WithOptions(
+		&apprepo.Options{
+			Limit:          limit,
+			Offset:         offset,
+			Source:         filter.Source,
+			Tag:            tag.String(),
+			Platform:       metadata.Platform().String(),
+			AppVersion:     metadata.AppVersion(),
+			IncludeDeleted: filter.IncludeDeleted,
+		},
 	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list feedbacks: %w", err)
+
+	var (
+		feedbacks []*feedback.Feedback
+		total     int
+	)
+	if err := operations.RunWithTimeout(
+		ctx,
+		s.operationTimeout(),
+		func(ctx context.Context) error {
+			var err error
+			if feedbacks, err = s.feedRepo.List(ctx, repoOpts); err != nil {
+				return fmt.Errorf("failed to list feedbacks: %w", err)
+			}
+			if total, err = s.feedRepo.Count(ctx, repoOpts); err != nil {
+				return fmt.Errorf("failed to count feedbacks: %w", err)
+			}
+			return nil
+		},
+	); err != nil {
+		return nil, err
 	}
 
-	logger.Info("feedbacks listed successfully", "count", len(feedbacks))
-	return feedbacks, nil
+	logger.Info("feedbacks listed successfully", "count", len(feedbacks), "total", total)
+	return &services.Page[*feedback.Feedback]{
+		Items:  feedbacks,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
 }
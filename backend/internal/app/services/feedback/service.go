@@ -1,10 +1,16 @@
 package feedback
 
 import (
+	"time"
+
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
 	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/markup"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/pii"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
 )
@@ -12,26 +18,72 @@ import (
 type svc struct {
 	logger        tracelog.TraceLogger
 	paginationCfg *config.Pagination
+	feedbackCfg   *config.Feedback
+	dbCfg         *config.Database
 	errChecker    errors.ErrorChecker
 	feedRepo      apprepo.FeedbackRepository
 	transactor    repository.Transactor
 	analyzer      services.AnalyzerService
+	events        services.EventPublisher
+	clock         clock.Clock
+	// scrubber masks PII in comments before they are stored, nil if scrubbing is disabled.
+	scrubber *pii.Scrubber
+	// moderator checks comments against the content policy before they are stored.
+	moderator external.Moderator
+	// translator translates comments into the analysis language before they are stored.
+	translator external.Translator
+	// stripper removes markup from comments before they are validated, nil if stripping is disabled.
+	stripper *markup.Stripper
 }
 
 func NewFeedbackService(
 	traceLogger tracelog.TraceLogger,
 	paginationCfg *config.Pagination,
+	feedbackCfg *config.Feedback,
+	dbCfg *config.Database,
 	errChecker errors.ErrorChecker,
 	feedRepo apprepo.FeedbackRepository,
 	transactor repository.Transactor,
 	analyzer services.AnalyzerService,
+	events services.EventPublisher,
+	clk clock.Clock,
+	scrubber *pii.Scrubber,
+	moderator external.Moderator,
+	translator external.Translator,
+	stripper *markup.Stripper,
 ) services.FeedbackService {
+	if clk == nil {
+		clk = clock.New()
+	}
+	if moderator == nil {
+		moderator = external.NoopModerator{}
+	}
+	if translator == nil {
+		translator = external.NoopTranslator{}
+	}
+
 	return &svc{
 		logger:        traceLogger.NewGroup("feedback_service"),
 		paginationCfg: paginationCfg,
+		feedbackCfg:   feedbackCfg,
+		dbCfg:         dbCfg,
 		errChecker:    errChecker,
 		feedRepo:      feedRepo,
 		transactor:    transactor,
 		analyzer:      analyzer,
+		events:        events,
+		clock:         clk,
+		scrubber:      scrubber,
+		moderator:     moderator,
+		translator:    translator,
+		stripper:      stripper,
+	}
+}
+
+// operationTimeout returns the timeout applied to feedback listing, or 0 (none) if no database config is set.
+func (s *svc) operationTimeout() time.Duration {
+	if s.dbCfg == nil {
+		return 0
 	}
+	return s.dbCfg.OperationTimeout()
 }
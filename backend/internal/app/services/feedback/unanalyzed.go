@@ -0,0 +1,78 @@
+package feedback
+
+import (
+	"context"
+	"fmt"
+
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+func (s *svc) ListUnanalyzedFeedbacks(
+	ctx context.Context,
+	limit, offset int,
+) (*services.Page[*feedback.Feedback], error) {
+	logger := s.logger.WithSpan(ctx)
+	ctx, spanLogger, span := logger.StartSpan(ctx, "feedback_service.list_unanalyzed_feedbacks")
+	defer span.End()
+
+	span.SetAttributes(
+		trace.Attribute{Key: "limit", Value: limit},
+		trace.Attribute{Key: "offset", Value: offset},
+	)
+	spanLogger.Info("listing unanalyzed feedbacks", "limit", limit, "offset", offset)
+
+	page, err := s.listUnanalyzedFeedbacks(ctx, limit, offset, spanLogger)
+	if err != nil {
+		span.SetStatus(trace.StatusError, err.Error())
+		spanLogger.RecordSpanError(ctx, err)
+		return nil, s.errChecker.Check(err)
+	}
+
+	span.SetStatus(trace.StatusOK, "Successfully listed unanalyzed feedbacks")
+	span.SetAttributes(
+		trace.Attribute{Key: "count", Value: len(page.Items)},
+		trace.Attribute{Key: "total", Value: page.Total},
+	)
+	return page, nil
+}
+
+func (s *svc) listUnanalyzedFeedbacks(
+	ctx context.Context,
+	limit, offset int,
+	logger tracelog.TraceLogger,
+) (*services.Page[*feedback.Feedback], error) {
+	if limit <= 0 {
+		limit = s.paginationCfg.Limit
+	}
+	if limit > 1000 {
+		return nil, errors.ErrBadRequest("limit cannot exceed 1000")
+	}
+	if offset < 0 {
+		offset = s.paginationCfg.Offset
+	}
+
+	repoOpts := apprepo.WithOptions(&apprepo.Options{Limit: limit, Offset: offset})
+
+	feedbacks, err := s.feedRepo.ListUnanalyzed(ctx, repoOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unanalyzed feedbacks: %w", err)
+	}
+
+	total, err := s.feedRepo.CountUnanalyzed(ctx, repoOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unanalyzed feedbacks: %w", err)
+	}
+
+	logger.Info("unanalyzed feedbacks listed successfully", "count", len(feedbacks), "total", total)
+	return &services.Page[*feedback.Feedback]{
+		Items:  feedbacks,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
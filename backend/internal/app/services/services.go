@@ -2,30 +2,54 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/transport/requests"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/user"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
 )
 
 // FeedbackService defines the interface for feedback business logic operations.
+//
+//go:generate mockgen -destination=../../../mocking/services_mock.go -package=mocking -source=services.go FeedbackService,UserService,AnalyzerService,EventPublisher,FeedbackSummaryService
 type FeedbackService interface {
 	// CreateFeedback creates a new feedback submission for the authenticated user.
-	CreateFeedback(ctx context.Context, userID uuid.UUID, req *requests.CreateFeedbackRequest) (
-		*feedback.Feedback,
-		error,
-	)
+	// The daily feedback quota is not enforced when exemptFromQuota is set; callers must restrict that to admins.
+	CreateFeedback(
+		ctx context.Context,
+		userID uuid.UUID,
+		req *requests.CreateFeedbackRequest,
+		exemptFromQuota bool,
+	) (*feedback.Feedback, error)
 
 	// GetFeedbackByID retrieves a feedback entry by its ID.
-	GetFeedbackByID(ctx context.Context, feedbackID uuid.UUID) (*feedback.Feedback, error)
+	// Soft-deleted feedback is only returned when includeDeleted is set; callers must restrict that to admins.
+	GetFeedbackByID(ctx context.Context, feedbackID uuid.UUID, includeDeleted bool) (*feedback.Feedback, error)
 
-	// ListFeedbacks retrieves a list of feedback entries with optional pagination.
-	ListFeedbacks(ctx context.Context, limit, offset int) ([]*feedback.Feedback, error)
+	// ListFeedbacks retrieves a list of feedback entries with optional pagination and filtering.
+	ListFeedbacks(ctx context.Context, limit, offset int, filter FeedbackFilter) (*Page[*feedback.Feedback], error)
 
 	// DeleteFeedback performs a soft delete on a feedback entry by its ID.
 	DeleteFeedback(ctx context.Context, feedbackID uuid.UUID) error
+
+	// DeleteFeedbacks soft deletes several feedback entries in a single transaction and reports the outcome
+	// per ID, in request order. Unknown and already deleted IDs do not fail the batch.
+	DeleteFeedbacks(ctx context.Context, feedbackIDs []uuid.UUID) ([]FeedbackDeleteResult, error)
+
+	// ListUnanalyzedFeedbacks retrieves the feedback entries with a comment that were never included in
+	// a successful analysis, oldest first. Feedbacks of an analysis that is still processing and feedbacks
+	// excluded from analysis are omitted.
+	ListUnanalyzedFeedbacks(ctx context.Context, limit, offset int) (*Page[*feedback.Feedback], error)
+
+	// ExportFeedbacks calls fn for every non-deleted feedback matching the filter, oldest first, without
+	// loading them all into memory. The filter is validated before fn is first called.
+	// Exporting stops at the first error returned by fn.
+	ExportFeedbacks(ctx context.Context, filter FeedbackExportFilter, fn func(*feedback.Feedback) error) error
 }
 
 // UserService defines the interface for user authentication and management operations.
@@ -38,6 +62,13 @@ type UserService interface {
 	// Returns a JWT token string and user info if authentication succeeds.
 	// Returns an error if credentials are invalid or user is inactive.
 	AuthenticateUser(ctx context.Context, req *requests.LoginUserRequest) (string, *user.User, error)
+
+	// RestoreUser reactivates a soft-deleted user and returns the restored user.
+	// Returns a not found error if the user does not exist and a conflict error if it is not deleted.
+	RestoreUser(ctx context.Context, userID uuid.UUID) (*user.User, error)
+
+	// ListUsers retrieves non-deleted user accounts, newest first, with pagination and optional filtering.
+	ListUsers(ctx context.Context, limit, offset int, filter UserFilter) (*Page[*user.User], error)
 }
 
 // AnalyzerService defines the interface for LLM analysis operations.
@@ -46,35 +77,229 @@ type AnalyzerService interface {
 	// This method is non-blocking and runs in a separate goroutine.
 	EnqueueFeedback(ctx context.Context, fb *feedback.Feedback)
 
+	// ExclusionReason returns why a new feedback is never analyzed automatically, such as a rating outside the
+	// rating filter, or an empty string if it is. Feedbacks with a reason are excluded from analysis when stored.
+	ExclusionReason(fb *feedback.Feedback) string
+
+	// AnalyzeAdhoc runs an analysis synchronously over exactly the given feedbacks,
+	// bypassing the pending queue, and returns the resulting analysis.
+	AnalyzeAdhoc(ctx context.Context, feedbackIDs []uuid.UUID) (*analysis.Analysis, error)
+
+	// TriggerAnalysis synchronously analyzes the pending queue regardless of the minimum
+	// feedback threshold and the debounce window, and returns the resulting analysis.
+	TriggerAnalysis(ctx context.Context) (*analysis.Analysis, error)
+
+	// ReprocessUnanalyzed adds the feedbacks that were never included in a successful analysis,
+	// such as those of failed analyses, back to the pending queue. Feedbacks already queued are skipped.
+	// Returns the number of feedbacks added.
+	ReprocessUnanalyzed(ctx context.Context) (int, error)
+
+	// EstimateAnalysis estimates the token usage and cost of analyzing the given feedbacks, or of the
+	// next analysis of the pending queue if no IDs are given. Neither the queue nor any data is modified.
+	EstimateAnalysis(ctx context.Context, feedbackIDs []uuid.UUID) (*AnalysisEstimate, error)
+
 	// Start starts the analyzer service in a background goroutine.
 	// It should be called once during application initialization.
 	Start(ctx context.Context) error
 
+	// IsReady reports whether Start completed the initial setup, such as failing stale analyses,
+	// and the analyzer has not been stopped since.
+	IsReady() bool
+
+	// LLMCircuitState returns the state of the circuit breaker guarding the LLM provider. While it is open,
+	// automatic and scheduled analyses are skipped and feedbacks stay queued.
+	LLMCircuitState() external.CircuitState
+
 	// Stop stops the analyzer service gracefully.
 	Stop(ctx context.Context) error
 }
 
+// EventPublisher defines the interface for emitting events to external systems.
+type EventPublisher interface {
+	// Register adds a sink that receives all subsequently published events of the given types,
+	// or of every type if none are given.
+	Register(sink external.EventSink, eventTypes ...external.EventType)
+
+	// Stage stores the event in the persistent outbox as part of the given transaction, so that it is
+	// delivered if and only if the transaction commits. It is a no-op if the outbox is disabled.
+	// Staged events must still be passed to Publish once the transaction is committed, which then only wakes up
+	// the delivery.
+	Stage(ctx context.Context, tx repository.Transaction, event *external.Event) error
+
+	// Publish delivers the event to all registered sinks asynchronously.
+	// It never blocks the caller and delivery failures are only logged.
+	Publish(ctx context.Context, event *external.Event)
+
+	// ListDeadLetters retrieves events that permanently failed to be delivered, newest first.
+	// Returns a bad request error if the outbox is disabled.
+	ListDeadLetters(ctx context.Context, limit, offset int) (*Page[*external.DeadLetter], error)
+
+	// Start starts the outbox dispatcher in a background goroutine.
+	// It should be called once during application initialization.
+	Start(ctx context.Context) error
+
+	// Stop stops the outbox dispatcher and waits for in-flight deliveries to finish.
+	Stop(ctx context.Context) error
+}
+
 // FeedbackSummaryService defines the interface for querying analysis data.
 // This is separate from AnalyzerService which only performs the analysis.
 type FeedbackSummaryService interface {
 	// GetLatestAnalysis retrieves the latest completed analysis.
-	GetLatestAnalysis(ctx context.Context) (*analysis.Analysis, error)
+	// With representativeOnly, analyses over fewer feedbacks than configured to be representative are skipped.
+	GetLatestAnalysis(ctx context.Context, representativeOnly bool) (*analysis.Analysis, error)
 
-	// GetAllAnalyses retrieves all analyses ordered by creation date (newest first).
-	GetAllAnalyses(ctx context.Context) ([]*analysis.Analysis, error)
+	// ListAnalyses retrieves analyses ordered by creation date (newest first), with pagination.
+	ListAnalyses(ctx context.Context, limit, offset int) (*Page[*analysis.Analysis], error)
 
-	// GetAnalysisByID retrieves an analysis by ID with its topics and analyzed feedbacks with their topics.
+	// GetAnalysisByID retrieves an analysis by ID with its topics, analyzed feedbacks with their topics
+	// and the topic deltas versus the previous analysis, which are empty for the first analysis.
 	GetAnalysisByID(ctx context.Context, analysisID uuid.UUID) (
 		*analysis.Analysis,
 		[]*analysis.TopicAnalysis,
 		map[uuid.UUID][]*analysis.TopicAnalysis, // feedback ID -> topics
+		[]analysis.TopicDelta,
+		error,
+	)
+	// GetAnalysisStatus retrieves an analysis without its topics and feedbacks, for polling its progress.
+	GetAnalysisStatus(ctx context.Context, analysisID uuid.UUID) (*analysis.Analysis, error)
+	// GetAnalysisRawOutput retrieves the stored model output of an analysis, for debugging.
+	GetAnalysisRawOutput(ctx context.Context, analysisID uuid.UUID) (*analysis.RawOutput, error)
+	// GetAnalysisEvents retrieves the lifecycle timeline of an analysis, oldest event first.
+	GetAnalysisEvents(ctx context.Context, analysisID uuid.UUID) ([]*analysis.Event, error)
+	// GetFeedbackAnalyses retrieves the analyses of any status that included a non-deleted feedback, newest first,
+	// each with the topics the feedback was assigned to in it.
+	GetFeedbackAnalyses(ctx context.Context, feedbackID uuid.UUID) ([]FeedbackAnalysis, error)
+	// GetTopicAnalysisByID retrieves a topic analysis of any analysis by its ID with its assigned feedbacks,
+	// newest first. Feedbacks deleted since the analysis are omitted.
+	GetTopicAnalysisByID(ctx context.Context, topicID uuid.UUID) (
+		*analysis.TopicAnalysis,
+		[]*feedback.Feedback,
 		error,
 	)
 	// GetTopicsWithStats retrieves all predefined topics with their statistics from the latest analysis.
 	// Returns topics with feedback count and average rating.
-	GetTopicsWithStats(ctx context.Context) ([]TopicStats, error)
+	GetTopicsWithStats(ctx context.Context) (*TopicStatsOverview, error)
+	// RecomputeTopicStats rebuilds the materialized topic statistics of the latest analysis from the current
+	// ratings of its feedbacks, without calling the LLM, and returns them like GetTopicsWithStats.
+	RecomputeTopicStats(ctx context.Context) (*TopicStatsOverview, error)
 	// GetTopicDetails retrieves details for a specific topic enum with all associated feedbacks.
 	GetTopicDetails(ctx context.Context, topicEnum analysis.Topic) (*TopicDetails, error)
+	// GetTopicHistory retrieves the topic analyses of a topic enum across all successful analyses, oldest first,
+	// so that the evolution of its summary can be followed. Topics absent from an analysis have no entry for it.
+	GetTopicHistory(ctx context.Context, topicEnum analysis.Topic) ([]TopicHistoryEntry, error)
+	// GetTopicSentimentTrend retrieves the sentiment and feedback count of a topic enum in every successful
+	// analysis, ordered by period end. Analyses without the topic are included with neither, so that gaps are
+	// explicit instead of being interpolated.
+	GetTopicSentimentTrend(ctx context.Context, topicEnum analysis.Topic) ([]TopicSentimentTrendPoint, error)
+	// GetTagTopicAgreement compares the tags attached by submitters with the topics assigned by the LLM.
+	GetTagTopicAgreement(ctx context.Context) (*TagTopicAgreement, error)
+	// GetAnalyticsOverview computes totals across all analyses.
+	GetAnalyticsOverview(ctx context.Context) (*AnalyticsOverview, error)
+	// GetTokenAccuracy computes the distribution of the ratio of estimated to actual tokens of the analyses
+	// with recorded token usage.
+	GetTokenAccuracy(ctx context.Context) (*TokenAccuracy, error)
+}
+
+// TokenEstimate is the estimated token usage of an analysis request, per component.
+type TokenEstimate struct {
+	SystemPrompt     int
+	PreviousAnalysis int // Context carried over from the previous analysis
+	Feedbacks        int
+	PayloadOverhead  int // JSON structure of the user payload
+	Response         int // Expected model output
+}
+
+// Input returns the estimated number of tokens sent to the model.
+func (e TokenEstimate) Input() int {
+	return e.SystemPrompt + e.PreviousAnalysis + e.Feedbacks + e.PayloadOverhead
+}
+
+// Total returns the estimated number of input and output tokens.
+func (e TokenEstimate) Total() int {
+	return e.Input() + e.Response
+}
+
+// AnalysisEstimate is the estimated cost of an analysis that has not been run.
+type AnalysisEstimate struct {
+	// CandidateCount is the number of feedbacks considered: the pending queue or the requested IDs.
+	CandidateCount int
+	// FeedbackCount is the number of feedbacks that would be sent after the token and count limits.
+	FeedbackCount int
+	Tokens        TokenEstimate
+	// EstimatedCostUSD is based on the configured token prices and is 0 if no prices are configured.
+	EstimatedCostUSD float64
+}
+
+// Page is a single page of a paginated result.
+type Page[T any] struct {
+	Items  []T
+	Total  int // Number of items matching the query across all pages
+	Limit  int // Effective page size after defaults were applied
+	Offset int
+}
+
+// FeedbackFilter narrows down the feedbacks returned by FeedbackService.ListFeedbacks.
+// Zero values mean no filtering.
+type FeedbackFilter struct {
+	// Source restricts the result to feedbacks submitted through the given channel.
+	Source string
+	// Tag restricts the result to feedbacks the submitter tagged with it.
+	Tag string
+	// Platform restricts the result to feedbacks reported from the given platform.
+	Platform string
+	// AppVersion restricts the result to feedbacks reported from the given app version.
+	AppVersion string
+	// IncludeDeleted also returns soft-deleted feedbacks. Callers must restrict it to admins.
+	IncludeDeleted bool
+}
+
+// FeedbackExportFilter narrows down the feedbacks exported by FeedbackService.ExportFeedbacks.
+// Zero values mean no filtering.
+type FeedbackExportFilter struct {
+	// Source restricts the export to feedbacks submitted through the given channel.
+	Source string
+	// Tag restricts the export to feedbacks the submitter tagged with it.
+	Tag string
+	// Platform restricts the export to feedbacks reported from the given platform.
+	Platform string
+	// AppVersion restricts the export to feedbacks reported from the given app version.
+	AppVersion string
+	// CreatedFrom restricts the export to feedbacks created at or after it.
+	CreatedFrom time.Time
+	// CreatedTo restricts the export to feedbacks created before it.
+	CreatedTo time.Time
+	// MinRating restricts the export to feedbacks rated at least it.
+	MinRating optional.Optional[int]
+	// MaxRating restricts the export to feedbacks rated at most it.
+	MaxRating optional.Optional[int]
+}
+
+// FeedbackDeleteStatus is the outcome of deleting a single feedback of a batch.
+type FeedbackDeleteStatus string
+
+const (
+	// FeedbackDeleted means the feedback was soft deleted by the batch.
+	FeedbackDeleted FeedbackDeleteStatus = "deleted"
+	// FeedbackNotFound means no feedback with the ID exists.
+	FeedbackNotFound FeedbackDeleteStatus = "not_found"
+	// FeedbackAlreadyDeleted means the feedback had been soft deleted before.
+	FeedbackAlreadyDeleted FeedbackDeleteStatus = "already_deleted"
+)
+
+// FeedbackDeleteResult is the outcome of deleting one feedback with FeedbackService.DeleteFeedbacks.
+type FeedbackDeleteResult struct {
+	FeedbackID uuid.UUID
+	Status     FeedbackDeleteStatus
+}
+
+// UserFilter narrows down the users returned by UserService.ListUsers.
+// Zero values mean no filtering.
+type UserFilter struct {
+	// Status restricts the result to accounts in the given status (active, inactive or suspended).
+	Status string
+	// Role restricts the result to accounts holding the given role.
+	Role string
 }
 
 // TopicStats represents statistics for a topic from the latest analysis.
@@ -84,6 +309,93 @@ type TopicStats struct {
 	AverageRating float64
 }
 
+// TopicStatsOverview is the set of topic statistics for the latest analysis.
+type TopicStatsOverview struct {
+	Topics []TopicStats
+	// NoTopicsIdentified is true when the latest analysis succeeded but the model found no topics.
+	NoTopicsIdentified bool
+}
+
+// TagTopicAgreement reports how the tags attached by submitters align with the topics assigned by the LLM,
+// based on the latest successful analysis of every tagged feedback.
+//
+// A tagged feedback agrees with the classifier when it was assigned the dominant topic of its tag,
+// i.e. the topic most feedbacks with that tag were assigned to. Low agreement points at tags the
+// topic taxonomy does not capture.
+type TagTopicAgreement struct {
+	// Tags holds one row of the tag/topic matrix per tag, ordered by feedback count descending, then by tag.
+	Tags []TagAgreement
+	// AgreementRate is the share of tagged feedbacks, over all tags, assigned the dominant topic of their tag.
+	AgreementRate float64
+}
+
+// TagAgreement is the agreement between a single user tag and the LLM topics.
+type TagAgreement struct {
+	Tag feedback.Tag
+	// FeedbackCount is the number of analyzed feedbacks with the tag.
+	FeedbackCount int
+	// DominantTopic is the topic most feedbacks with the tag were assigned to, empty if none was assigned a topic.
+	DominantTopic analysis.Topic
+	// AgreementRate is the share of feedbacks with the tag assigned the dominant topic.
+	AgreementRate float64
+	// Topics counts the feedbacks with the tag per assigned topic, ordered by count descending, then by topic.
+	// A feedback can be assigned several topics.
+	Topics []TagTopicCount
+}
+
+// TagTopicCount is a cell of the tag/topic matrix.
+type TagTopicCount struct {
+	Topic         analysis.Topic
+	FeedbackCount int
+}
+
+// AnalyticsOverview summarizes all analyses for reporting.
+type AnalyticsOverview struct {
+	// TotalAnalyses is the number of analyses in any status.
+	TotalAnalyses int
+	// SuccessfulAnalyses is the number of successful analyses.
+	SuccessfulAnalyses int
+	// AnalyzedFeedbacks is the number of distinct feedbacks included in at least one successful analysis.
+	AnalyzedFeedbacks int
+	// TotalTokens is the number of tokens consumed by all analyses.
+	TotalTokens int64
+	// EstimatedCostUSD prices TotalTokens at the configured input token price. Analyses only record their
+	// total tokens, so output tokens cannot be priced separately and the cost is a lower bound.
+	EstimatedCostUSD float64
+	// AverageFeedbacksPerAnalysis is the mean batch size of successful analyses.
+	AverageFeedbacksPerAnalysis float64
+	// MostCommonTopic is the topic assigned to the most feedbacks by their latest successful analysis,
+	// empty if no topic was assigned yet.
+	MostCommonTopic analysis.Topic
+	// MostCommonTopicFeedbackCount is the number of feedbacks assigned to MostCommonTopic.
+	MostCommonTopicFeedbackCount int
+}
+
+// TokenAccuracy is the distribution of the ratio of estimated to actual total tokens of analyses.
+// A ratio above 1 means the request was overestimated. All ratios are 0 without recorded usage.
+type TokenAccuracy struct {
+	// RecordingEnabled reports whether the token usage of new analyses is currently recorded.
+	RecordingEnabled bool
+	// SampleCount is the number of analyses with recorded token usage.
+	SampleCount int
+	MeanRatio   float64
+	MinRatio    float64
+	MaxRatio    float64
+	P10Ratio    float64
+	MedianRatio float64
+	P90Ratio    float64
+	// Buckets counts the analyses per ratio range, in ascending order.
+	Buckets []TokenAccuracyBucket
+}
+
+// TokenAccuracyBucket is the number of analyses whose ratio is at least MinRatio and below MaxRatio.
+// MaxRatio is unset for the last, open-ended bucket.
+type TokenAccuracyBucket struct {
+	MinRatio      float64
+	MaxRatio      optional.Optional[float64]
+	AnalysisCount int
+}
+
 // TopicDetails represents detailed information about a topic with all associated feedbacks.
 type TopicDetails struct {
 	Topic         analysis.Topic
@@ -91,5 +403,63 @@ type TopicDetails struct {
 	FeedbackCount int
 	AverageRating float64
 	Sentiment     analysis.Sentiment
+	Alignment     SentimentAlignment
 	Feedbacks     []*feedback.Feedback
 }
+
+// TopicHistoryEntry is the result of a topic in one analysis.
+type TopicHistoryEntry struct {
+	TopicAnalysis *analysis.TopicAnalysis
+	// PeriodStart and PeriodEnd are the period of the analysis the topic belongs to.
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	// AnalyzedAt is the creation time of the analysis.
+	AnalyzedAt time.Time
+}
+
+// TopicSentimentTrendPoint is the sentiment of a topic in one analysis.
+type TopicSentimentTrendPoint struct {
+	AnalysisID uuid.UUID
+	// PeriodEnd is the end of the period of the analysis.
+	PeriodEnd time.Time
+	// Sentiment and FeedbackCount are None if the analysis did not identify the topic.
+	Sentiment     optional.Optional[analysis.Sentiment]
+	FeedbackCount optional.Optional[int]
+}
+
+// FeedbackAnalysis is an analysis a feedback contributed to.
+type FeedbackAnalysis struct {
+	Analysis *analysis.Analysis
+	// Topics are the topics of the analysis the feedback was assigned to.
+	Topics []*analysis.TopicAnalysis
+}
+
+// AlignmentStatus describes how well a topic's LLM sentiment agrees with the ratings of its feedbacks.
+type AlignmentStatus string
+
+const (
+	// AlignmentAligned means the sentiment matches the sentiment implied by the ratings.
+	AlignmentAligned AlignmentStatus = "aligned"
+	// AlignmentDivergent means the sentiment is one step away from the implied sentiment (e.g. mixed vs positive).
+	AlignmentDivergent AlignmentStatus = "divergent"
+	// AlignmentMismatched means the sentiment contradicts the ratings (positive vs negative).
+	AlignmentMismatched AlignmentStatus = "mismatched"
+	// AlignmentUnknown means there were no rated feedbacks to compare against.
+	AlignmentUnknown AlignmentStatus = "unknown"
+)
+
+// SentimentAlignment compares a topic's sentiment against the rating distribution of its feedbacks.
+type SentimentAlignment struct {
+	Status AlignmentStatus
+	// ImpliedSentiment is the sentiment suggested by the rating distribution alone.
+	ImpliedSentiment analysis.Sentiment
+	// LowRatingShare is the fraction of feedbacks in the lower part of the rating scale.
+	LowRatingShare float64
+	// HighRatingShare is the fraction of feedbacks in the upper part of the rating scale.
+	HighRatingShare float64
+}
+
+// IsMismatch reports whether the sentiment contradicts the ratings.
+func (a SentimentAlignment) IsMismatch() bool {
+	return a.Status == AlignmentMismatched
+}
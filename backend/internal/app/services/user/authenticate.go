@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	appjwt "github.com/ktruedat/llm-feedback-analysis/internal/app/infrastructure/jwt"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
 	"github.com/ktruedat/llm-feedback-analysis/internal/app/transport/requests"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/user"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/errors"
@@ -43,7 +44,12 @@ func (s *svc) authenticateUser(
 		return "", nil, errors.ErrBadRequest("invalid email format", errors.WithCauseError(err))
 	}
 
-	u, err := s.userRepo.GetByEmail(ctx, email.Value())
+	// Soft-deleted users are included so they get a dedicated error message
+	u, err := s.userRepo.GetByEmail(
+		ctx,
+		email.Value(),
+		apprepo.WithOptions(&apprepo.Options{IncludeDeleted: true}),
+	)
 	if err != nil {
 		logger.Warning("user not found", "email", email.Value())
 		return "", nil, errors.ErrUnauthorized("invalid email or password")
@@ -0,0 +1,106 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/user"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+// defaultUsersLimit is the page size used when no limit is requested.
+const defaultUsersLimit = 100
+
+func (s *svc) ListUsers(
+	ctx context.Context,
+	limit, offset int,
+	filter services.UserFilter,
+) (*services.Page[*user.User], error) {
+	logger := s.logger.WithSpan(ctx)
+	ctx, spanLogger, span := logger.StartSpan(ctx, "user_service.list_users")
+	defer span.End()
+
+	span.SetAttributes(
+		trace.Attribute{Key: "limit", Value: limit},
+		trace.Attribute{Key: "offset", Value: offset},
+		trace.Attribute{Key: "status", Value: filter.Status},
+		trace.Attribute{Key: "role", Value: filter.Role},
+	)
+
+	page, err := s.listUsers(ctx, limit, offset, filter, spanLogger)
+	if err != nil {
+		span.SetStatus(trace.StatusError, err.Error())
+		spanLogger.RecordSpanError(ctx, err)
+		return nil, s.errChecker.Check(err)
+	}
+
+	span.SetStatus(trace.StatusOK, "Successfully listed users")
+	span.SetAttributes(
+		trace.Attribute{Key: "count", Value: len(page.Items)},
+		trace.Attribute{Key: "total", Value: page.Total},
+	)
+	return page, nil
+}
+
+func (s *svc) listUsers(
+	ctx context.Context,
+	limit, offset int,
+	filter services.UserFilter,
+	logger tracelog.TraceLogger,
+) (*services.Page[*user.User], error) {
+	if limit <= 0 {
+		limit = defaultUsersLimit
+	}
+	if limit > 1000 {
+		return nil, errors.ErrBadRequest("limit cannot exceed 1000")
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	var status user.UserStatus
+	if filter.Status != "" {
+		var err error
+		if status, err = user.NewUserStatus(filter.Status); err != nil {
+			return nil, errors.ErrBadRequest("invalid status filter", errors.WithCauseError(err))
+		}
+	}
+	var role user.Role
+	if filter.Role != "" {
+		var err error
+		if role, err = user.NewRole(filter.Role); err != nil {
+			return nil, errors.ErrBadRequest("invalid role filter", errors.WithCauseError(err))
+		}
+	}
+
+	repoOpts := apprepo.WithOptions(
+		&apprepo.Options{
+			Limit:  limit,
+			Offset: offset,
+			Status: status.String(),
+			Role:   role.String(),
+		},
+	)
+
+	users, err := s.userRepo.List(ctx, repoOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	total, err := s.userRepo.Count(ctx, repoOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	logger.Info("users listed successfully", "count", len(users), "total", total)
+	return &services.Page[*user.User]{
+		Items:  users,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
@@ -41,16 +41,26 @@ func (s *svc) registerUser(
 	req *requests.RegisterUserRequest,
 	logger tracelog.TraceLogger,
 ) (*user.User, error) {
+	if s.registrationCfg.Disabled {
+		return nil, errors.ErrForbidden("registration is disabled")
+	}
+
 	email, err := user.NewEmail(req.Email)
 	if err != nil {
 		return nil, errors.ErrBadRequest("invalid email format", errors.WithCauseError(err))
 	}
 
 	// Check if user already exists
-	existingUser, err := s.userRepo.GetByEmail(ctx, email.Value())
+	existingUser, err := s.userRepo.GetByEmail(
+		ctx,
+		email.Value(),
+		apprepo.WithOptions(&apprepo.Options{IncludeDeleted: true}),
+	)
 	if err == nil && existingUser != nil {
 		if existingUser.IsDeleted() {
-			return nil, errors.ErrBadRequest("account with this email was previously deleted")
+			return nil, errors.ErrBadRequest(
+				"account with this email was previously deleted; contact an administrator to restore it",
+			)
 		}
 		return nil, &errors.GenericError{
 			Code:       errors.NewDomainErrorCode("email_already_exists", errors.CategoryConflict),
@@ -73,7 +83,7 @@ func (s *svc) registerUser(
 	builder := user.NewBuilder().
 		WithEmail(email).
 		WithPasswordHash(passwordHashVO).
-		WithRole(user.RoleUser) // default role
+		WithRole(s.registrationCfg.Role())
 
 	u, err := builder.Build()
 	if err != nil {
@@ -99,6 +109,12 @@ func (s *svc) createUserRecord(u *user.User, logger tracelog.TraceLogger) operat
 		logger := logger.WithSpan(ctx)
 		logger.Info("creating user record in database", "user_id", u.ID().String())
 
+		if s.registrationCfg.BootstrapFirstUserAsAdmin {
+			if err := s.bootstrapFirstUserAsAdmin(ctx, u, tx, logger); err != nil {
+				return err
+			}
+		}
+
 		if err := s.userRepo.Create(ctx, u, repository.WithExecutor[apprepo.Options](tx)); err != nil {
 			logger.RecordSpanError(ctx, err)
 			return fmt.Errorf("failed to create user: %w", err)
@@ -108,3 +124,38 @@ func (s *svc) createUserRecord(u *user.User, logger tracelog.TraceLogger) operat
 		return nil
 	}
 }
+
+// bootstrapFirstUserAsAdmin grants the admin role to u when no user besides the admin seeded by the migrations has
+// been stored yet. Soft-deleted users count as well, so the promotion happens at most once per deployment.
+// Registrations are locked for the rest of the transaction, so that concurrent first registrations cannot both
+// see an empty table.
+func (s *svc) bootstrapFirstUserAsAdmin(
+	ctx context.Context,
+	u *user.User,
+	tx repository.Transaction,
+	logger tracelog.TraceLogger,
+) error {
+	if err := s.userRepo.LockRegistrations(ctx, repository.WithExecutor[apprepo.Options](tx)); err != nil {
+		logger.RecordSpanError(ctx, err)
+		return fmt.Errorf("failed to lock registrations: %w", err)
+	}
+
+	count, err := s.userRepo.Count(
+		ctx,
+		repository.WithExecutor[apprepo.Options](tx),
+		apprepo.WithOptions(&apprepo.Options{IncludeDeleted: true, ExcludeEmail: apprepo.SeededAdminEmail}),
+	)
+	if err != nil {
+		logger.RecordSpanError(ctx, err)
+		return fmt.Errorf("failed to count users: %w", err)
+	}
+	if count > 0 {
+		return nil
+	}
+
+	if err := u.AddRole(user.RoleAdmin); err != nil {
+		return fmt.Errorf("failed to grant admin role to first user: %w", err)
+	}
+	logger.Info("first registered user granted the admin role", "user_id", u.ID().String())
+	return nil
+}
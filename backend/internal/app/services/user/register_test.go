@@ -0,0 +1,222 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	apprepo "github.com/ktruedat/llm-feedback-analysis/internal/app/repository"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/transport/requests"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/user"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/log"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository/sql/utils"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+func newTestLogger(t *testing.T) tracelog.TraceLogger {
+	t.Helper()
+
+	tracer, err := trace.NewTracer(trace.Config{ServiceName: "user-test"})
+	if err != nil {
+		t.Fatalf("Failed to create tracer: %v", err)
+	}
+
+	return tracelog.NewTraceLogger(log.NewLogger("development"), tracer)
+}
+
+// storedPasswordHash is the bcrypt hash of "adminpass", as seeded by the migrations.
+const storedPasswordHash = "$2a$10$zwtqTvBhIFTY3HB53TEuCuCJ7/mpfmy7oL45IDJVb8pPetqycVEEu"
+
+// txUserRepo keeps users in memory. Users created in a transaction become visible when it commits, and
+// LockRegistrations holds a lock until then, like the transaction-level advisory lock of the Postgres repository.
+type txUserRepo struct {
+	apprepo.UserRepository
+	registrations sync.Mutex
+	mu            sync.Mutex
+	users         []*user.User
+}
+
+func (r *txUserRepo) GetByEmail(
+	context.Context,
+	string,
+	...repository.RepoOption[apprepo.Options],
+) (*user.User, error) {
+	return nil, nil
+}
+
+func (r *txUserRepo) Create(_ context.Context, u *user.User, opts ...repository.RepoOption[apprepo.Options]) error {
+	tx := utils.BuildOpts(opts).Ex.(*testTx)
+	tx.created = append(tx.created, u)
+	return nil
+}
+
+func (r *txUserRepo) Count(_ context.Context, opts ...repository.RepoOption[apprepo.Options]) (int, error) {
+	options := utils.BuildOpts(opts).Ext
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, u := range r.users {
+		if (options.IncludeDeleted || !u.IsDeleted()) && u.Email().Value() != options.ExcludeEmail {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *txUserRepo) LockRegistrations(_ context.Context, opts ...repository.RepoOption[apprepo.Options]) error {
+	tx := utils.BuildOpts(opts).Ex.(*testTx)
+	r.registrations.Lock()
+	tx.unlock = r.registrations.Unlock
+	return nil
+}
+
+func (r *txUserRepo) store(t *testing.T, email string, roles []user.Role, deleted bool) {
+	t.Helper()
+
+	builder := user.NewBuilder().WithEmailString(email).WithPasswordHashString(storedPasswordHash).WithRoles(roles)
+	if deleted {
+		builder.WithDeletedAt(time.Now())
+	}
+	u, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Failed to build user: %v", err)
+	}
+	r.users = append(r.users, u)
+}
+
+// testTx applies the users created in it to its repository on commit.
+type testTx struct {
+	repository.Transaction
+	repo    *txUserRepo
+	created []*user.User
+	unlock  func()
+}
+
+func (tx *testTx) Commit(context.Context) error {
+	tx.repo.mu.Lock()
+	tx.repo.users = append(tx.repo.users, tx.created...)
+	tx.repo.mu.Unlock()
+	tx.end()
+	return nil
+}
+
+func (tx *testTx) Rollback(context.Context) error {
+	tx.end()
+	return nil
+}
+
+func (tx *testTx) end() {
+	if tx.unlock != nil {
+		tx.unlock()
+	}
+}
+
+type testTransactor struct {
+	repo *txUserRepo
+}
+
+func (tr testTransactor) NewTransaction(context.Context, ...repository.TransactionOption) (
+	repository.Transaction,
+	error,
+) {
+	return &testTx{repo: tr.repo}, nil
+}
+
+func newRegisterTestService(t *testing.T) (*svc, *txUserRepo) {
+	t.Helper()
+
+	repo := &txUserRepo{}
+	// The admin account seeded by the migrations
+	repo.store(t, apprepo.SeededAdminEmail, []user.Role{user.RoleAdmin}, false)
+
+	return &svc{
+		logger:          newTestLogger(t),
+		userRepo:        repo,
+		registrationCfg: &config.Registration{BootstrapFirstUserAsAdmin: true},
+		transactor:      testTransactor{repo: repo},
+	}, repo
+}
+
+func register(t *testing.T, s *svc, email string) *user.User {
+	t.Helper()
+
+	u, err := s.registerUser(
+		context.Background(),
+		&requests.RegisterUserRequest{Email: email, Password: "SecurePassword123!"},
+		s.logger,
+	)
+	if err != nil {
+		t.Errorf("Failed to register %s: %v", email, err)
+		return nil
+	}
+	return u
+}
+
+func TestService_RegisterUser_BootstrapFirstUserAsAdmin(t *testing.T) {
+	s, _ := newRegisterTestService(t)
+
+	first := register(t, s, "first@example.com")
+	if first == nil || !first.IsAdmin() {
+		t.Fatal("Expected the first user registered besides the seeded admin to be granted the admin role")
+	}
+
+	second := register(t, s, "second@example.com")
+	if second == nil || second.IsAdmin() {
+		t.Error("Expected the second registered user not to be granted the admin role")
+	}
+}
+
+func TestService_RegisterUser_BootstrapCountsDeletedUsers(t *testing.T) {
+	s, repo := newRegisterTestService(t)
+	repo.store(t, "deleted@example.com", []user.Role{user.RoleUser}, true)
+
+	u := register(t, s, "first@example.com")
+	if u == nil || u.IsAdmin() {
+		t.Error("Expected no admin role once a user was registered, even if it was deleted since")
+	}
+}
+
+func TestService_RegisterUser_BootstrapDisabled(t *testing.T) {
+	s, _ := newRegisterTestService(t)
+	s.registrationCfg.BootstrapFirstUserAsAdmin = false
+
+	u := register(t, s, "first@example.com")
+	if u == nil || u.IsAdmin() {
+		t.Error("Expected no admin role without bootstrapping")
+	}
+}
+
+func TestService_RegisterUser_BootstrapConcurrentRegistrations(t *testing.T) {
+	s, repo := newRegisterTestService(t)
+
+	const registrations = 5
+	var wg sync.WaitGroup
+	for i := range registrations {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			register(t, s, fmt.Sprintf("user%d@example.com", i))
+		}()
+	}
+	wg.Wait()
+
+	admins := 0
+	for _, u := range repo.users {
+		if u.IsAdmin() && u.Email().Value() != apprepo.SeededAdminEmail {
+			admins++
+		}
+	}
+	if len(repo.users) != registrations+1 {
+		t.Fatalf("Expected %d stored users, got %d", registrations+1, len(repo.users))
+	}
+	if admins != 1 {
+		t.Errorf("Expected exactly one registered user to be granted the admin role, got %d", admins)
+	}
+}
@@ -0,0 +1,70 @@
+package user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/user"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+func (s *svc) RestoreUser(ctx context.Context, userID uuid.UUID) (*user.User, error) {
+	logger := s.logger.WithSpan(ctx)
+	ctx, spanLogger, span := logger.StartSpan(ctx, "user_service.restore_user")
+	defer span.End()
+
+	span.SetAttributes(
+		trace.Attribute{Key: "user_id", Value: userID.String()},
+	)
+
+	u, err := s.restoreUser(ctx, userID, spanLogger)
+	if err != nil {
+		span.SetStatus(trace.StatusError, err.Error())
+		spanLogger.RecordSpanError(ctx, err)
+		return nil, s.errChecker.Check(err)
+	}
+
+	span.SetStatus(trace.StatusOK, "Successfully restored user")
+	return u, nil
+}
+
+func (s *svc) restoreUser(
+	ctx context.Context,
+	userID uuid.UUID,
+	logger tracelog.TraceLogger,
+) (*user.User, error) {
+	u, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if u == nil {
+		return nil, &errors.GenericError{
+			Code:       errors.ErrorCodeNotFound,
+			Message:    "User not found",
+			UserFacing: true,
+		}
+	}
+
+	if !u.IsDeleted() {
+		return nil, &errors.GenericError{
+			Code:       errors.NewDomainErrorCode("user_not_deleted", errors.CategoryConflict),
+			Message:    "User is not deleted",
+			UserFacing: true,
+		}
+	}
+
+	if err := u.Restore(); err != nil {
+		return nil, fmt.Errorf("failed to restore user: %w", err)
+	}
+
+	if err := s.userRepo.Update(ctx, u); err != nil {
+		return nil, fmt.Errorf("failed to persist restored user: %w", err)
+	}
+
+	logger.Info("user restored", "user_id", u.ID().String(), "email", u.Email().Value())
+	return u, nil
+}
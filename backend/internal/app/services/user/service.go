@@ -10,11 +10,12 @@ import (
 )
 
 type svc struct {
-	logger     tracelog.TraceLogger
-	errChecker errors.ErrorChecker
-	userRepo   apprepo.UserRepository
-	jwtCfg     *config.JWT
-	transactor repository.Transactor
+	logger          tracelog.TraceLogger
+	errChecker      errors.ErrorChecker
+	userRepo        apprepo.UserRepository
+	jwtCfg          *config.JWT
+	registrationCfg *config.Registration
+	transactor      repository.Transactor
 }
 
 func NewUserService(
@@ -22,13 +23,15 @@ func NewUserService(
 	errChecker errors.ErrorChecker,
 	userRepo apprepo.UserRepository,
 	jwtCfg *config.JWT,
+	registrationCfg *config.Registration,
 	transactor repository.Transactor,
 ) services.UserService {
 	return &svc{
-		logger:     traceLogger.NewGroup("user_service"),
-		errChecker: errChecker,
-		userRepo:   userRepo,
-		jwtCfg:     jwtCfg,
-		transactor: transactor,
+		logger:          traceLogger.NewGroup("user_service"),
+		errChecker:      errChecker,
+		userRepo:        userRepo,
+		jwtCfg:          jwtCfg,
+		registrationCfg: registrationCfg,
+		transactor:      transactor,
 	}
 }
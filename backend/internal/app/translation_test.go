@@ -0,0 +1,95 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/config"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external/llm"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/log"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/tracelog"
+)
+
+func newTestLogger(t *testing.T) tracelog.TraceLogger {
+	t.Helper()
+
+	tracer, err := trace.NewTracer(trace.Config{ServiceName: "app-test"})
+	if err != nil {
+		t.Fatalf("Failed to create tracer: %v", err)
+	}
+
+	return tracelog.NewTraceLogger(log.NewLogger("development"), tracer)
+}
+
+func TestNewTranslationClient_IsolatedFromAnalysisClient(t *testing.T) {
+	// The provider holds every request until released, then fails it
+	entered := make(chan struct{}, 1)
+	release := make(chan struct{})
+	var requests atomic.Int32
+	srv := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, _ *http.Request) {
+				requests.Add(1)
+				entered <- struct{}{}
+				<-release
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+		),
+	)
+	t.Cleanup(srv.Close)
+
+	cfg := &config.LLMAnalysis{
+		OpenAIAPIKey:                  "test-key",
+		OpenAIModel:                   "test-model",
+		MaxConcurrentRequests:         1,
+		CircuitBreakerThreshold:       1,
+		CircuitBreakerCooldownSeconds: 60,
+	}
+	analysisLimiter := llm.NewConcurrencyLimiter(cfg.MaxConcurrentRequests)
+	analysisBreaker := llm.NewCircuitBreaker(cfg.CircuitBreakerThreshold, cfg.CircuitBreakerCooldown(), clock.New())
+	llmOptions := []llm.ClientOption{
+		llm.WithBaseURL(srv.URL),
+		llm.WithHTTPClient(srv.Client()),
+		llm.WithConcurrencyLimiter(analysisLimiter),
+		llm.WithCircuitBreaker(analysisBreaker),
+	}
+	translator := newTranslationClient(cfg, llmOptions, newTestLogger(t))
+
+	errs := make(chan error, 1)
+	go func() {
+		_, err := translator.Translate(context.Background(), "La aplicación es genial", "en")
+		errs <- err
+	}()
+
+	select {
+	case <-entered:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the translation request to reach the provider")
+	}
+	if inFlight := analysisLimiter.InFlight(); inFlight != 0 {
+		t.Errorf("Expected a translation not to take a slot of the analysis limiter, got %d in flight", inFlight)
+	}
+
+	close(release)
+	if err := <-errs; err == nil {
+		t.Fatal("Expected the translation to fail")
+	}
+
+	// The failure opened the breaker of the translation client only
+	if _, err := translator.Translate(context.Background(), "La aplicación es genial", "en"); err == nil {
+		t.Error("Expected the translation to be rejected by the open translation breaker")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("Expected the open translation breaker to reject the request before sending it, got %d requests", got)
+	}
+	if state := analysisBreaker.CircuitState(); state != external.CircuitClosed {
+		t.Errorf("Expected the analysis breaker to stay closed, got %s", state)
+	}
+}
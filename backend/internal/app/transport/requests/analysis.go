@@ -0,0 +1,11 @@
+//nolint:lll // cannot split tags
+package requests
+
+import "github.com/google/uuid"
+
+// AdhocAnalysisRequest represents the request payload for running an ad-hoc analysis
+//
+//	@Description	Request payload for analyzing an explicit set of feedbacks outside the pending queue.
+type AdhocAnalysisRequest struct {
+	FeedbackIDs []uuid.UUID `json:"feedback_ids" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required"` // IDs of the feedbacks to analyze, capped by max_feedbacks_in_context (required)
+}
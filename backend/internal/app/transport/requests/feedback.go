@@ -1,10 +1,31 @@
 //nolint:lll // cannot split tags
 package requests
 
+import "github.com/google/uuid"
+
 // CreateFeedbackRequest represents the request payload for creating a feedback
 //
 //	@Description	Request payload for creating a new feedback submission.
 type CreateFeedbackRequest struct {
-	Rating  int    `json:"rating" example:"5" binding:"required,min=1,max=5"` // Rating value from 1 to 5 (required)
-	Comment string `json:"comment" example:"Really nice!"`                    // Feedback comment text, 1-1000 characters (required)
+	Rating   int                      `json:"rating" example:"5" binding:"required"`        // Rating value within the configured scale, 1 to 5 by default (required)
+	Comment  string                   `json:"comment" example:"Really nice!"`               // Feedback comment text, between the configured minimum length (1 by default) and 1000 characters (required unless rating-only feedback is enabled)
+	Source   string                   `json:"source" example:"web"`                         // Channel the feedback was submitted through: web, mobile, api or email (optional, defaults to web)
+	Tags     []string                 `json:"tags,omitempty" example:"bug,feature-request"` // Labels chosen by the submitter: up to 10, each 1-32 letters, digits, '-' or '_', case-insensitive (optional)
+	Metadata *FeedbackMetadataRequest `json:"metadata,omitempty"`                           // Technical context of the reporter (optional)
+}
+
+// FeedbackMetadataRequest represents the technical context of a feedback
+//
+//	@Description	Technical context of the reporter; every field is optional.
+type FeedbackMetadataRequest struct {
+	AppVersion string `json:"app_version,omitempty" example:"2.4.1"` // App version, up to 32 letters, digits, '.', '-' or '+'
+	Platform   string `json:"platform,omitempty" example:"ios"`      // Client platform: web, ios, android or desktop
+	OS         string `json:"os,omitempty" example:"iOS 17.2"`       // Operating system, up to 64 characters
+}
+
+// BatchDeleteFeedbacksRequest represents the request payload for deleting several feedbacks at once
+//
+//	@Description	Request payload for soft deleting a batch of feedbacks in a single transaction.
+type BatchDeleteFeedbacksRequest struct {
+	FeedbackIDs []uuid.UUID `json:"feedback_ids" example:"550e8400-e29b-41d4-a716-446655440000" binding:"required"` // IDs of the feedbacks to delete, unique and capped by max_batch_delete_size (required)
 }
@@ -3,6 +3,7 @@ package responses
 import (
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/analysis"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
 )
@@ -17,16 +18,22 @@ type AnalysisResponse struct {
 	PeriodEnd          time.Time                    `json:"period_end" example:"2024-01-31T23:59:59Z"`
 	FeedbackCount      int                          `json:"feedback_count" example:"100"`
 	NewFeedbackCount   optional.Optional[int]       `json:"new_feedback_count,omitempty" swaggertype:"primitive,integer"`
-	OverallSummary     string                       `json:"overall_summary"`
+	OverallSummary     optional.Optional[string]    `json:"overall_summary" swaggertype:"primitive,string"` // Null while processing or if the analysis failed
 	Sentiment          string                       `json:"sentiment" example:"positive"`
 	KeyInsights        []string                     `json:"key_insights"`
 	Model              string                       `json:"model" example:"gpt-5-mini"`
+	Provider           optional.Optional[string]    `json:"provider,omitempty" swaggertype:"primitive,string" example:"openai"` // Absent for analyses recorded before providers were tracked
+	QualifiedModel     string                       `json:"qualified_model" example:"openai/gpt-5-mini"`                        // Provider-qualified model, only the model if the provider is unknown
 	Tokens             int                          `json:"tokens" example:"5000"`
 	AnalysisDurationMs int                          `json:"analysis_duration_ms" example:"5000"`
 	Status             string                       `json:"status" example:"success"`
 	FailureReason      optional.Optional[string]    `json:"failure_reason,omitempty" swaggertype:"primitive,string"`
 	CreatedAt          time.Time                    `json:"created_at" example:"2024-01-01T00:00:00Z"`
 	CompletedAt        optional.Optional[time.Time] `json:"completed_at,omitempty" swaggertype:"primitive,string"`
+	NoTopicsIdentified bool                         `json:"no_topics_identified" example:"false"`
+	DeduplicatedCount  int                          `json:"deduplicated_count" example:"0"`
+	Representative     bool                         `json:"representative" example:"true"`                                                  // False if fewer feedbacks were analyzed than configured to draw conclusions from
+	PeriodSemantics    string                       `json:"period_semantics" example:"feedback_span" enums:"feedback_span,analysis_window"` // What period_start and period_end represent
 }
 
 // AnalysisResponseFromDomain converts a domain Analysis entity to an AnalysisResponse.
@@ -40,10 +47,16 @@ func AnalysisResponseFromDomain(a *analysis.Analysis) *AnalysisResponse {
 		Sentiment:          string(a.Sentiment()),
 		KeyInsights:        a.KeyInsights(),
 		Model:              a.Model(),
+		Provider:           a.Provider(),
+		QualifiedModel:     a.QualifiedModel(),
 		Tokens:             a.Tokens(),
 		AnalysisDurationMs: a.AnalysisDurationMs(),
 		Status:             string(a.Status()),
 		CreatedAt:          a.CreatedAt(),
+		NoTopicsIdentified: a.NoTopicsIdentified(),
+		DeduplicatedCount:  a.DeduplicatedCount(),
+		Representative:     a.IsRepresentative(),
+		PeriodSemantics:    a.PeriodSemantics().String(),
 	}
 
 	if a.PreviousAnalysisID().IsSome() {
@@ -62,44 +75,229 @@ func AnalysisResponseFromDomain(a *analysis.Analysis) *AnalysisResponse {
 		resp.CompletedAt = optional.Some(a.CompletedAt().Unwrap())
 	}
 
+	// A processing analysis only holds placeholders until the LLM result is stored,
+	// so don't present them as results
+	if a.Status() == analysis.StatusProcessing {
+		resp.KeyInsights = []string{}
+	}
+
+	return resp
+}
+
+// AnalysisStatusResponse represents the progress of an analysis
+//
+//	@Description	Lightweight response payload for polling an analysis until it completes.
+type AnalysisStatusResponse struct {
+	Status      string                       `json:"status" example:"processing"`
+	CreatedAt   time.Time                    `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	CompletedAt optional.Optional[time.Time] `json:"completed_at,omitempty" swaggertype:"primitive,string"`
+}
+
+// AnalysisStatusResponseFromDomain converts a domain Analysis entity to an AnalysisStatusResponse.
+func AnalysisStatusResponseFromDomain(a *analysis.Analysis) *AnalysisStatusResponse {
+	resp := &AnalysisStatusResponse{
+		Status:    string(a.Status()),
+		CreatedAt: a.CreatedAt(),
+	}
+	if a.CompletedAt().IsSome() {
+		resp.CompletedAt = optional.Some(a.CompletedAt().Unwrap())
+	}
 	return resp
 }
 
+// AnalysisRawOutputResponse represents the raw model output of an analysis
+//
+//	@Description	Output text of the model as stored for debugging, with PII masked.
+type AnalysisRawOutputResponse struct {
+	AnalysisID string    `json:"analysis_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Output     string    `json:"output" example:"{\"overall_summary\":\"Users praise the new dashboard.\"}"`
+	CreatedAt  time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`
+}
+
+// AnalysisRawOutputResponseFromDomain converts a domain RawOutput to an AnalysisRawOutputResponse.
+func AnalysisRawOutputResponseFromDomain(r *analysis.RawOutput) *AnalysisRawOutputResponse {
+	return &AnalysisRawOutputResponse{
+		AnalysisID: r.AnalysisID.String(),
+		Output:     r.Output,
+		CreatedAt:  r.CreatedAt,
+	}
+}
+
+// AnalysisEventsResponse represents the lifecycle timeline of an analysis
+//
+//	@Description	Steps of an analysis in the order they happened, stored for every analysis.
+type AnalysisEventsResponse struct {
+	AnalysisID string                  `json:"analysis_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Events     []AnalysisEventResponse `json:"events"`
+}
+
+// AnalysisEventResponse represents a single step of an analysis
+type AnalysisEventResponse struct {
+	Type       string                   `json:"type" example:"llm_returned" enums:"created,llm_called,llm_returned,topics_created,completed,failed"`
+	OccurredAt time.Time                `json:"occurred_at" example:"2024-01-01T00:00:00Z"`
+	DurationMs optional.Optional[int64] `json:"duration_ms,omitempty" swaggertype:"primitive,integer" example:"1250"` // Time the step took, for llm_returned, topics_created, completed and failed
+	Details    map[string]any           `json:"details,omitempty"`                                                    // Step specific information, e.g. topics_count or the failure reason
+}
+
+// AnalysisEventsResponseFromDomain converts the domain events of an analysis to an AnalysisEventsResponse.
+func AnalysisEventsResponseFromDomain(analysisID uuid.UUID, events []*analysis.Event) *AnalysisEventsResponse {
+	resp := &AnalysisEventsResponse{
+		AnalysisID: analysisID.String(),
+		Events:     make([]AnalysisEventResponse, len(events)),
+	}
+	for i, e := range events {
+		resp.Events[i] = AnalysisEventResponse{
+			Type:       string(e.Type),
+			OccurredAt: e.OccurredAt,
+			Details:    e.Details,
+		}
+		if e.Duration.IsSome() {
+			resp.Events[i].DurationMs = optional.Some(e.Duration.Unwrap().Milliseconds())
+		}
+	}
+	return resp
+}
+
+// ReprocessUnanalyzedResponse represents the result of re-enqueueing unanalyzed feedbacks
+//
+//	@Description	Number of feedbacks that were never successfully analyzed and were added back to the pending queue.
+type ReprocessUnanalyzedResponse struct {
+	ReprocessedCount int `json:"reprocessed_count" example:"12"`
+}
+
+// TokenEstimateResponse represents the estimated token usage of an analysis request per component
+//
+//	@Description	Estimated tokens of an analysis request, broken into components.
+type TokenEstimateResponse struct {
+	SystemPrompt     int `json:"system_prompt" example:"250"`
+	PreviousAnalysis int `json:"previous_analysis" example:"120"`
+	Feedbacks        int `json:"feedbacks" example:"900"`
+	PayloadOverhead  int `json:"payload_overhead" example:"50"`
+	Response         int `json:"response" example:"1200"`
+	Input            int `json:"input" example:"1320"`
+	Total            int `json:"total" example:"2520"`
+}
+
+// AnalysisEstimateResponse represents the estimated cost of an analysis
+//
+//	@Description	Response payload containing the token and cost estimate of an analysis that has not been run.
+type AnalysisEstimateResponse struct {
+	CandidateCount   int                   `json:"candidate_count" example:"12"`
+	FeedbackCount    int                   `json:"feedback_count" example:"10"`
+	Tokens           TokenEstimateResponse `json:"tokens"`
+	EstimatedCostUSD float64               `json:"estimated_cost_usd" example:"0.0031"`
+}
+
 // TopicAnalysisResponse represents the response payload for a topic analysis
 //
 //	@Description	Response payload containing topic analysis details.
 type TopicAnalysisResponse struct {
-	ID            string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Topic         string    `json:"topic" example:"product_functionality_features"`
-	TopicName     string    `json:"topic_name" example:"Product Functionality & Features"`
-	Summary       string    `json:"summary"`
-	FeedbackCount int       `json:"feedback_count" example:"10"`
-	Sentiment     string    `json:"sentiment" example:"positive"`
-	CreatedAt     time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`
-	UpdatedAt     time.Time `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+	ID                    string                         `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Topic                 string                         `json:"topic" example:"product_functionality_features"`
+	TopicName             string                         `json:"topic_name" example:"Product Functionality & Features"`
+	Summary               string                         `json:"summary"`
+	FeedbackCount         int                            `json:"feedback_count" example:"10"`
+	Sentiment             string                         `json:"sentiment" example:"positive"`       // Dominant sentiment of the topic
+	SentimentDistribution *SentimentDistributionResponse `json:"sentiment_distribution"`             // Feedbacks per sentiment, null if not counted
+	Recommendations       []string                       `json:"recommendations"`                    // Recommended actions, empty unless enabled
+	Confidence            *float64                       `json:"confidence" example:"0.85"`          // Model confidence between 0 and 1, null unless enabled
+	SentimentConflict     bool                           `json:"sentiment_conflict" example:"false"` // The model sentiment contradicted the rating of the only feedback
+	CreatedAt             time.Time                      `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	UpdatedAt             time.Time                      `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+}
+
+// SentimentDistributionResponse represents the number of feedbacks per sentiment within a topic
+//
+//	@Description	Number of feedbacks of a topic that are positive, mixed and negative.
+type SentimentDistributionResponse struct {
+	Positive int `json:"positive" example:"6"`
+	Mixed    int `json:"mixed" example:"3"`
+	Negative int `json:"negative" example:"1"`
 }
 
 // TopicAnalysisResponseFromDomain converts a domain TopicAnalysis entity to a TopicAnalysisResponse.
 func TopicAnalysisResponseFromDomain(ta *analysis.TopicAnalysis) *TopicAnalysisResponse {
-	return &TopicAnalysisResponse{
-		ID:            ta.ID().String(),
-		Topic:         string(ta.Topic()),
-		TopicName:     ta.TopicName(),
-		Summary:       ta.Summary(),
-		FeedbackCount: ta.FeedbackCount(),
-		Sentiment:     string(ta.Sentiment()),
-		CreatedAt:     ta.CreatedAt(),
-		UpdatedAt:     ta.UpdatedAt(),
+	resp := &TopicAnalysisResponse{
+		ID:                ta.ID().String(),
+		Topic:             string(ta.Topic()),
+		TopicName:         ta.TopicName(),
+		Summary:           ta.Summary(),
+		FeedbackCount:     ta.FeedbackCount(),
+		Sentiment:         string(ta.Sentiment()),
+		Recommendations:   ta.Recommendations(),
+		SentimentConflict: ta.SentimentConflict(),
+		CreatedAt:         ta.CreatedAt(),
+		UpdatedAt:         ta.UpdatedAt(),
 	}
+	if resp.Recommendations == nil {
+		resp.Recommendations = []string{}
+	}
+	if ta.Confidence().IsSome() {
+		confidence := ta.Confidence().Unwrap()
+		resp.Confidence = &confidence
+	}
+	if ta.SentimentDistribution().IsSome() {
+		distribution := ta.SentimentDistribution().Unwrap()
+		resp.SentimentDistribution = &SentimentDistributionResponse{
+			Positive: distribution.Positive,
+			Mixed:    distribution.Mixed,
+			Negative: distribution.Negative,
+		}
+	}
+	return resp
+}
+
+// TopicAnalysisDetailResponse represents a single topic analysis with its assigned feedbacks
+//
+//	@Description	Response payload containing a topic analysis of any analysis with its assigned feedbacks.
+type TopicAnalysisDetailResponse struct {
+	TopicAnalysisResponse
+	AnalysisID string             `json:"analysis_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Feedbacks  []FeedbackResponse `json:"feedbacks"` // Newest first
+}
+
+// FeedbackAnalysisResponse represents an analysis a feedback contributed to
+//
+//	@Description	Analysis that included a feedback, with the topics the feedback was assigned to in it.
+type FeedbackAnalysisResponse struct {
+	Analysis *AnalysisResponse       `json:"analysis"`
+	Topics   []TopicAnalysisResponse `json:"topics"` // Empty if the feedback was assigned to no topic
 }
 
 // AnalysisDetailResponse represents the detailed response for an analysis with topics and feedbacks
 //
 //	@Description	Response payload containing detailed analysis with topics and feedback IDs.
 type AnalysisDetailResponse struct {
-	Analysis  *AnalysisResponse            `json:"analysis"`
-	Topics    []TopicAnalysisResponse      `json:"topics"`
-	Feedbacks []FeedbackWithTopicsResponse `json:"feedbacks"`
+	Analysis    *AnalysisResponse            `json:"analysis"`
+	Topics      []TopicAnalysisResponse      `json:"topics"`
+	Feedbacks   []FeedbackWithTopicsResponse `json:"feedbacks"`
+	TopicDeltas []TopicDeltaResponse         `json:"topic_deltas"` // Changes versus the previous analysis, largest first; empty for the first analysis
+}
+
+// TopicDeltaResponse represents the change of a topic versus the previous analysis
+//
+//	@Description	Change in the number of feedbacks of a topic versus the previous analysis, stored when the analysis completed.
+type TopicDeltaResponse struct {
+	Topic         string `json:"topic" example:"performance_reliability"`
+	TopicName     string `json:"topic_name" example:"Performance & Reliability"`
+	PreviousCount int    `json:"previous_count" example:"2"` // 0 if the previous analysis did not identify the topic
+	CurrentCount  int    `json:"current_count" example:"5"`  // 0 if the topic is not identified anymore
+	Delta         int    `json:"delta" example:"3"`          // current_count - previous_count
+}
+
+// TopicDeltaResponsesFromDomain converts domain topic deltas to TopicDeltaResponses.
+func TopicDeltaResponsesFromDomain(deltas []analysis.TopicDelta) []TopicDeltaResponse {
+	result := make([]TopicDeltaResponse, len(deltas))
+	for i, delta := range deltas {
+		result[i] = TopicDeltaResponse{
+			Topic:         string(delta.Topic),
+			TopicName:     delta.Topic.DisplayName(),
+			PreviousCount: delta.PreviousCount,
+			CurrentCount:  delta.CurrentCount,
+			Delta:         delta.Delta(),
+		}
+	}
+	return result
 }
 
 // FeedbackWithTopicsResponse represents a feedback with its associated topics
@@ -113,14 +311,6 @@ type FeedbackWithTopicsResponse struct {
 	Topics    []string  `json:"topics"` // Topic enum values
 }
 
-// AnalysisListResponse represents a list of analysis responses
-//
-//	@Description	Response payload containing a list of analyses.
-type AnalysisListResponse struct {
-	Analyses []AnalysisResponse `json:"analyses"`
-	Total    int                `json:"total" example:"10"`
-}
-
 // TopicStatsResponse represents statistics for a topic
 //
 //	@Description	Response payload containing topic statistics from the latest analysis.
@@ -131,24 +321,62 @@ type TopicStatsResponse struct {
 	AverageRating float64 `json:"average_rating" example:"4.5"`
 }
 
-// TopicStatsListResponse represents a list of topic statistics
+// TopicStatsListResponse is the topic statistics list, flagged when the latest analysis identified no topics
 //
-//	@Description	Response payload containing a list of topic statistics.
+//	@Description	Response payload containing topic statistics from the latest analysis.
 type TopicStatsListResponse struct {
-	Topics []TopicStatsResponse `json:"topics"`
-	Total  int                  `json:"total" example:"13"`
+	Paginated[TopicStatsResponse]
+	NoTopicsIdentified bool `json:"no_topics_identified" example:"false"`
 }
 
 // TopicDetailsResponse represents detailed information about a topic with all associated feedbacks
 //
 //	@Description	Response payload containing detailed topic information with feedbacks.
 type TopicDetailsResponse struct {
-	Topic            string             `json:"topic" example:"product_functionality_features"`
-	TopicName        string             `json:"topic_name" example:"Product Functionality & Features"`
-	TopicDescription string             `json:"topic_description"`
-	Summary          string             `json:"summary"`
-	FeedbackCount    int                `json:"feedback_count" example:"10"`
-	AverageRating    float64            `json:"average_rating" example:"4.5"`
-	Sentiment        string             `json:"sentiment" example:"positive"`
-	Feedbacks        []FeedbackResponse `json:"feedbacks"`
+	Topic            string                     `json:"topic" example:"product_functionality_features"`
+	TopicName        string                     `json:"topic_name" example:"Product Functionality & Features"`
+	TopicDescription string                     `json:"topic_description"`
+	Summary          string                     `json:"summary"`
+	FeedbackCount    int                        `json:"feedback_count" example:"10"`
+	AverageRating    float64                    `json:"average_rating" example:"4.5"`
+	Sentiment        string                     `json:"sentiment" example:"positive"`
+	RatingAlignment  SentimentAlignmentResponse `json:"rating_alignment"`
+	Feedbacks        []FeedbackResponse         `json:"feedbacks"`
+}
+
+// TopicHistoryEntryResponse represents a topic analysis of one analysis within the history of a topic
+//
+//	@Description	Result of a topic within a single analysis, used to follow the topic across analyses.
+type TopicHistoryEntryResponse struct {
+	TopicAnalysisID string    `json:"topic_analysis_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	AnalysisID      string    `json:"analysis_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	PeriodStart     time.Time `json:"period_start" example:"2024-01-01T00:00:00Z"`
+	PeriodEnd       time.Time `json:"period_end" example:"2024-01-02T00:00:00Z"`
+	AnalyzedAt      time.Time `json:"analyzed_at" example:"2024-01-02T00:00:00Z"` // Creation time of the analysis
+	Summary         string    `json:"summary"`
+	Sentiment       string    `json:"sentiment" example:"positive"`
+	FeedbackCount   int       `json:"feedback_count" example:"10"`
+}
+
+// TopicSentimentTrendPointResponse represents the sentiment of a topic in one analysis
+//
+//	@Description	Point of a topic sentiment chart. Sentiment, score and feedback count are null for analyses
+//	@Description	that did not identify the topic, so that gaps are not interpolated.
+type TopicSentimentTrendPointResponse struct {
+	AnalysisID     string                    `json:"analysis_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	PeriodEnd      time.Time                 `json:"period_end" example:"2024-01-02T00:00:00Z"`
+	Sentiment      optional.Optional[string] `json:"sentiment" swaggertype:"primitive,string" example:"negative"`
+	SentimentScore optional.Optional[int]    `json:"sentiment_score" swaggertype:"primitive,integer" example:"-1"` // 1 positive, 0 mixed, -1 negative
+	FeedbackCount  optional.Optional[int]    `json:"feedback_count" swaggertype:"primitive,integer" example:"10"`
+}
+
+// SentimentAlignmentResponse compares a topic's sentiment against the ratings of its feedbacks
+//
+//	@Description	How well the topic sentiment agrees with the rating distribution of its feedbacks.
+type SentimentAlignmentResponse struct {
+	Status           string  `json:"status" example:"aligned" enums:"aligned,divergent,mismatched,unknown"`
+	Mismatch         bool    `json:"mismatch" example:"false"`
+	ImpliedSentiment string  `json:"implied_sentiment,omitempty" example:"positive"`
+	LowRatingShare   float64 `json:"low_rating_share" example:"0.1"`
+	HighRatingShare  float64 `json:"high_rating_share" example:"0.8"`
 }
@@ -0,0 +1,152 @@
+package responses
+
+import (
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
+)
+
+// TagTopicCountResponse represents a cell of the tag/topic matrix
+//
+//	@Description	Number and share of the feedbacks with a tag that were assigned a topic.
+type TagTopicCountResponse struct {
+	Topic         string  `json:"topic" example:"performance_reliability"`
+	TopicName     string  `json:"topic_name" example:"Performance & Reliability"`
+	FeedbackCount int     `json:"feedback_count" example:"8"`
+	Share         float64 `json:"share" example:"0.8"`
+}
+
+// TagAgreementResponse represents a row of the tag/topic matrix
+//
+//	@Description	Agreement between a user tag and the topics assigned by the LLM.
+type TagAgreementResponse struct {
+	Tag           string                  `json:"tag" example:"bug"`
+	FeedbackCount int                     `json:"feedback_count" example:"10"`
+	DominantTopic string                  `json:"dominant_topic,omitempty" example:"performance_reliability"`
+	AgreementRate float64                 `json:"agreement_rate" example:"0.8"`
+	Topics        []TagTopicCountResponse `json:"topics"`
+}
+
+// TagTopicAgreementResponse represents the comparison of user tags and LLM topics
+//
+//	@Description	Correlation matrix of user tags and LLM-assigned topics, with the share of tagged feedbacks
+//	@Description	assigned the dominant topic of their tag as agreement rate.
+type TagTopicAgreementResponse struct {
+	AgreementRate float64                `json:"agreement_rate" example:"0.72"`
+	Tags          []TagAgreementResponse `json:"tags"`
+}
+
+// TagTopicAgreementResponseFromService converts a services.TagTopicAgreement to a TagTopicAgreementResponse.
+func TagTopicAgreementResponseFromService(agreement *services.TagTopicAgreement) *TagTopicAgreementResponse {
+	resp := &TagTopicAgreementResponse{
+		AgreementRate: agreement.AgreementRate,
+		Tags:          make([]TagAgreementResponse, len(agreement.Tags)),
+	}
+
+	for i, tag := range agreement.Tags {
+		row := TagAgreementResponse{
+			Tag:           tag.Tag.String(),
+			FeedbackCount: tag.FeedbackCount,
+			DominantTopic: string(tag.DominantTopic),
+			AgreementRate: tag.AgreementRate,
+			Topics:        make([]TagTopicCountResponse, len(tag.Topics)),
+		}
+		for j, cell := range tag.Topics {
+			row.Topics[j] = TagTopicCountResponse{
+				Topic:         string(cell.Topic),
+				TopicName:     cell.Topic.DisplayName(),
+				FeedbackCount: cell.FeedbackCount,
+				Share:         float64(cell.FeedbackCount) / float64(tag.FeedbackCount),
+			}
+		}
+		resp.Tags[i] = row
+	}
+
+	return resp
+}
+
+// AnalyticsOverviewResponse represents totals across all analyses
+//
+//	@Description	Totals across all analyses for executive reporting. The cost prices all tokens at the input
+//	@Description	token price, since analyses only record their total tokens, and is therefore a lower bound.
+type AnalyticsOverviewResponse struct {
+	TotalAnalyses                int     `json:"total_analyses" example:"42"`
+	SuccessfulAnalyses           int     `json:"successful_analyses" example:"40"`
+	AnalyzedFeedbacks            int     `json:"analyzed_feedbacks" example:"1250"`
+	TotalTokens                  int64   `json:"total_tokens" example:"850000"`
+	EstimatedCostUSD             float64 `json:"estimated_cost_usd" example:"0.21"`
+	AverageFeedbacksPerAnalysis  float64 `json:"average_feedbacks_per_analysis" example:"31.25"`
+	MostCommonTopic              string  `json:"most_common_topic,omitempty" example:"performance_reliability"`
+	MostCommonTopicName          string  `json:"most_common_topic_name,omitempty" example:"Performance & Reliability"`
+	MostCommonTopicFeedbackCount int     `json:"most_common_topic_feedback_count" example:"310"`
+}
+
+// AnalyticsOverviewResponseFromService converts a services.AnalyticsOverview to an AnalyticsOverviewResponse.
+func AnalyticsOverviewResponseFromService(overview *services.AnalyticsOverview) *AnalyticsOverviewResponse {
+	resp := &AnalyticsOverviewResponse{
+		TotalAnalyses:                overview.TotalAnalyses,
+		SuccessfulAnalyses:           overview.SuccessfulAnalyses,
+		AnalyzedFeedbacks:            overview.AnalyzedFeedbacks,
+		TotalTokens:                  overview.TotalTokens,
+		EstimatedCostUSD:             overview.EstimatedCostUSD,
+		AverageFeedbacksPerAnalysis:  overview.AverageFeedbacksPerAnalysis,
+		MostCommonTopicFeedbackCount: overview.MostCommonTopicFeedbackCount,
+	}
+	if overview.MostCommonTopic != "" {
+		resp.MostCommonTopic = string(overview.MostCommonTopic)
+		resp.MostCommonTopicName = overview.MostCommonTopic.DisplayName()
+	}
+
+	return resp
+}
+
+// TokenAccuracyResponse represents the accuracy of the token estimator
+//
+//	@Description	Distribution of the ratio of estimated to actual total tokens over the analyses with recorded
+//	@Description	token usage. A ratio above 1 means the estimator overestimated the request. Usage is only
+//	@Description	recorded while record_token_accuracy is enabled.
+type TokenAccuracyResponse struct {
+	RecordingEnabled bool                          `json:"recording_enabled" example:"true"`
+	SampleCount      int                           `json:"sample_count" example:"120"`
+	MeanRatio        float64                       `json:"mean_ratio" example:"1.12"`
+	MinRatio         float64                       `json:"min_ratio" example:"0.71"`
+	MaxRatio         float64                       `json:"max_ratio" example:"1.93"`
+	P10Ratio         float64                       `json:"p10_ratio" example:"0.88"`
+	MedianRatio      float64                       `json:"median_ratio" example:"1.09"`
+	P90Ratio         float64                       `json:"p90_ratio" example:"1.41"`
+	Buckets          []TokenAccuracyBucketResponse `json:"buckets"`
+}
+
+// TokenAccuracyBucketResponse represents the number of analyses within a ratio range
+//
+//	@Description	Number of analyses whose ratio is at least min_ratio and below max_ratio. The last bucket has no max_ratio.
+type TokenAccuracyBucketResponse struct {
+	MinRatio      float64  `json:"min_ratio" example:"0.95"`
+	MaxRatio      *float64 `json:"max_ratio,omitempty" example:"1.05"`
+	AnalysisCount int      `json:"analysis_count" example:"64"`
+}
+
+// TokenAccuracyResponseFromService converts a services.TokenAccuracy to a TokenAccuracyResponse.
+func TokenAccuracyResponseFromService(accuracy *services.TokenAccuracy) *TokenAccuracyResponse {
+	resp := &TokenAccuracyResponse{
+		RecordingEnabled: accuracy.RecordingEnabled,
+		SampleCount:      accuracy.SampleCount,
+		MeanRatio:        accuracy.MeanRatio,
+		MinRatio:         accuracy.MinRatio,
+		MaxRatio:         accuracy.MaxRatio,
+		P10Ratio:         accuracy.P10Ratio,
+		MedianRatio:      accuracy.MedianRatio,
+		P90Ratio:         accuracy.P90Ratio,
+		Buckets:          make([]TokenAccuracyBucketResponse, len(accuracy.Buckets)),
+	}
+	for i, bucket := range accuracy.Buckets {
+		resp.Buckets[i] = TokenAccuracyBucketResponse{
+			MinRatio:      bucket.MinRatio,
+			AnalysisCount: bucket.AnalysisCount,
+		}
+		if bucket.MaxRatio.IsSome() {
+			maxRatio := bucket.MaxRatio.Unwrap()
+			resp.Buckets[i].MaxRatio = &maxRatio
+		}
+	}
+
+	return resp
+}
@@ -4,6 +4,7 @@ package responses
 import (
 	"time"
 
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/services"
 	"github.com/ktruedat/llm-feedback-analysis/internal/domain/feedback"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
 )
@@ -13,31 +14,81 @@ import (
 //	@Description	Response payload containing feedback details.
 type FeedbackResponse struct {
 	ID        string                       `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`                                  // Feedback unique identifier
-	Rating    int                          `json:"rating" example:"5"`                                                                 // Rating value from 1 to 5
+	Rating    int                          `json:"rating" example:"5"`                                                                 // Rating value within the configured scale
 	Comment   string                       `json:"comment" example:"Great service!"`                                                   // Feedback comment text
+	Source    string                       `json:"source" example:"web"`                                                               // Channel the feedback was submitted through
+	Tags      []string                     `json:"tags" example:"bug,feature-request"`                                                 // Labels chosen by the submitter
+	Metadata  FeedbackMetadataResponse     `json:"metadata"`                                                                           // Technical context of the reporter
 	CreatedAt time.Time                    `json:"created_at" example:"2024-01-01T00:00:00Z"`                                          // Creation timestamp
 	UpdatedAt time.Time                    `json:"updated_at" example:"2024-01-01T00:00:00Z"`                                          // Last update timestamp
 	DeletedAt optional.Optional[time.Time] `json:"deleted_at,omitempty" swaggertype:"primitive,string" example:"2024-01-01T00:00:00Z"` // Deletion timestamp (if deleted)
+
+	TranslatedComment optional.Optional[string] `json:"translated_comment,omitempty" swaggertype:"primitive,string" example:"Great service!"`      // Comment translated into the analysis language (if translated)
+	CommentLanguage   string                    `json:"comment_language,omitempty" example:"de"`                                                   // Detected ISO 639-1 language of the comment (if translation is enabled)
+	OriginalComment   optional.Optional[string] `json:"original_comment,omitempty" swaggertype:"primitive,string" example:"<p>Great service!</p>"` // Comment as submitted, before markup was stripped (if preserved)
+}
+
+// FeedbackMetadataResponse represents the technical context of a feedback
+//
+//	@Description	Technical context of the reporter; unset fields are omitted.
+type FeedbackMetadataResponse struct {
+	AppVersion string `json:"app_version,omitempty" example:"2.4.1"` // App version
+	Platform   string `json:"platform,omitempty" example:"ios"`      // Client platform
+	OS         string `json:"os,omitempty" example:"iOS 17.2"`       // Operating system
 }
 
 // FeedbackResponseFromDomain converts a domain Feedback entity to a FeedbackResponse.
 func FeedbackResponseFromDomain(fb *feedback.Feedback) *FeedbackResponse {
 	resp := &FeedbackResponse{
-		ID:        fb.ID().String(),
-		Rating:    fb.Rating().Value(),
-		Comment:   fb.Comment().Value(),
+		ID:      fb.ID().String(),
+		Rating:  fb.Rating().Value(),
+		Comment: fb.Comment().Value(),
+		Source:  fb.Source().String(),
+		Tags:    fb.TagStrings(),
+		Metadata: FeedbackMetadataResponse{
+			AppVersion: fb.Metadata().AppVersion(),
+			Platform:   fb.Metadata().Platform().String(),
+			OS:         fb.Metadata().OS(),
+		},
 		CreatedAt: fb.CreatedAt(),
 		UpdatedAt: fb.UpdatedAt(),
 		DeletedAt: fb.DeletedAt(),
+
+		TranslatedComment: fb.TranslatedComment(),
+		CommentLanguage:   fb.CommentLanguage(),
+		OriginalComment:   fb.OriginalComment(),
 	}
 
 	return resp
 }
 
-// FeedbackListResponse represents a list of feedback responses
+// BatchDeleteFeedbacksResponse represents the outcome of a batch delete
 //
-//	@Description	Response payload containing a list of feedback entries.
-type FeedbackListResponse struct {
-	Feedbacks []FeedbackResponse `json:"feedbacks"`          // List of feedback entries
-	Total     int                `json:"total" example:"10"` // Total number of feedback entries
+//	@Description	Outcome of a batch delete per requested feedback, in request order.
+type BatchDeleteFeedbacksResponse struct {
+	Results      []FeedbackDeleteResultResponse `json:"results"`
+	DeletedCount int                            `json:"deleted_count" example:"2"` // Number of feedbacks deleted by this request
+}
+
+// FeedbackDeleteResultResponse represents the outcome of deleting a single feedback of a batch
+//
+//	@Description	Outcome of deleting a single feedback: deleted, not_found or already_deleted.
+type FeedbackDeleteResultResponse struct {
+	FeedbackID string `json:"feedback_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Status     string `json:"status" example:"deleted" enums:"deleted,not_found,already_deleted"`
+}
+
+// BatchDeleteFeedbacksResponseFromResults converts the per-feedback outcomes of a batch delete to a response.
+func BatchDeleteFeedbacksResponseFromResults(results []services.FeedbackDeleteResult) *BatchDeleteFeedbacksResponse {
+	response := &BatchDeleteFeedbacksResponse{Results: make([]FeedbackDeleteResultResponse, len(results))}
+	for i, result := range results {
+		response.Results[i] = FeedbackDeleteResultResponse{
+			FeedbackID: result.FeedbackID.String(),
+			Status:     string(result.Status),
+		}
+		if result.Status == services.FeedbackDeleted {
+			response.DeletedCount++
+		}
+	}
+	return response
 }
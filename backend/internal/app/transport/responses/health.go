@@ -0,0 +1,22 @@
+package responses
+
+// ReadinessStatus tells whether the instance can serve traffic.
+type ReadinessStatus string
+
+const (
+	ReadinessReady    ReadinessStatus = "ready"
+	ReadinessNotReady ReadinessStatus = "not_ready"
+)
+
+// HealthResponse represents the liveness of the instance.
+type HealthResponse struct {
+	Status string `json:"status" example:"ok"`
+}
+
+// ReadinessResponse represents the readiness of the instance and of the components it waits for.
+type ReadinessResponse struct {
+	Status        ReadinessStatus `json:"status" example:"ready" enums:"ready,not_ready"`
+	AnalyzerReady bool            `json:"analyzer_ready" example:"true"` // The analyzer completed its initial setup
+	// State of the circuit breaker guarding the LLM provider, automatic analyses are skipped while it is open
+	LLMCircuit string `json:"llm_circuit" example:"closed" enums:"closed,open,half_open"`
+}
@@ -0,0 +1,52 @@
+//nolint:lll // cannot split tags
+package responses
+
+// Pagination is the paging metadata shared by all paginated list endpoints.
+type Pagination struct {
+	Total   int  `json:"total" example:"42"`      // Number of items matching the query across all pages
+	Limit   int  `json:"limit" example:"10"`      // Maximum number of items in a page
+	Offset  int  `json:"offset" example:"0"`      // Number of items skipped before the current page
+	HasMore bool `json:"has_more" example:"true"` // Whether more items follow the current page
+}
+
+// Paginated is the common envelope returned by paginated list endpoints.
+//
+//	@Description	Paginated list envelope shared by all paginated list endpoints.
+type Paginated[T any] struct {
+	Items []T `json:"items" swaggertype:"array,object"` // Items of the current page, typed per endpoint in the API docs
+	Pagination
+}
+
+// NewPaginated builds a paginated envelope for a single page of items.
+func NewPaginated[T any](items []T, total, limit, offset int) *Paginated[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	return &Paginated[T]{
+		Items: items,
+		Pagination: Pagination{
+			Total:   total,
+			Limit:   limit,
+			Offset:  offset,
+			HasMore: offset+len(items) < total,
+		},
+	}
+}
+
+// List is the envelope returned by list endpoints that always return the complete list. It carries no paging
+// metadata, since there is no page to describe.
+//
+//	@Description	Envelope of a complete, unpaginated list.
+type List[T any] struct {
+	Items []T `json:"items" swaggertype:"array,object"` // All items of the list, typed per endpoint in the API docs
+}
+
+// NewList wraps a complete, unpaginated list.
+func NewList[T any](items []T) *List[T] {
+	if items == nil {
+		items = []T{}
+	}
+
+	return &List[T]{Items: items}
+}
@@ -56,3 +56,29 @@ func UserInfoFromDomain(u *user.User) UserInfo {
 		Roles: roleStrings,
 	}
 }
+
+// UserResponse represents a user account as seen by administrators
+//
+//	@Description	User account details including status.
+type UserResponse struct {
+	ID        string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"` // User unique identifier
+	Email     string    `json:"email" example:"user@example.com"`                  // User email address
+	Roles     []string  `json:"roles" example:"[\"user\"]"`                        // User roles
+	Status    string    `json:"status" example:"active"`                           // Account status
+	CreatedAt time.Time `json:"created_at" example:"2024-01-01T00:00:00Z"`         // Account creation timestamp
+	UpdatedAt time.Time `json:"updated_at" example:"2024-01-01T00:00:00Z"`         // Last update timestamp
+}
+
+// UserResponseFromDomain converts a domain User entity to a UserResponse.
+func UserResponseFromDomain(u *user.User) *UserResponse {
+	info := UserInfoFromDomain(u)
+
+	return &UserResponse{
+		ID:        info.ID,
+		Email:     info.Email,
+		Roles:     info.Roles,
+		Status:    u.Status().String(),
+		CreatedAt: u.CreatedAt(),
+		UpdatedAt: u.UpdatedAt(),
+	}
+}
@@ -0,0 +1,51 @@
+package responses
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ktruedat/llm-feedback-analysis/internal/app/external"
+)
+
+// DeadLetterResponse represents an event that permanently failed to be delivered
+//
+//	@Description	Event that could not be delivered to a sink within the maximum number of attempts.
+type DeadLetterResponse struct {
+	ID         string          `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	EventID    string          `json:"event_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	EventType  string          `json:"event_type" example:"feedback.created"`
+	OccurredAt time.Time       `json:"occurred_at" example:"2024-01-01T00:00:00Z"`
+	Data       json.RawMessage `json:"data" swaggertype:"object"`
+	Sink       string          `json:"sink" example:"webhook:https://example.com/hooks/feedback"`
+	Attempts   int             `json:"attempts" example:"10"`
+	LastError  string          `json:"last_error" example:"webhook endpoint responded with HTTP 503"`
+	CreatedAt  time.Time       `json:"created_at" example:"2024-01-01T00:00:00Z"`
+	FailedAt   time.Time       `json:"failed_at" example:"2024-01-02T00:00:00Z"`
+}
+
+// DeadLetterResponseFromExternal converts a dead letter to a DeadLetterResponse.
+func DeadLetterResponseFromExternal(deadLetter *external.DeadLetter) DeadLetterResponse {
+	resp := DeadLetterResponse{
+		ID:         deadLetter.ID.String(),
+		EventID:    deadLetter.Event.ID.String(),
+		EventType:  string(deadLetter.Event.Type),
+		OccurredAt: deadLetter.Event.OccurredAt,
+		Sink:       deadLetter.Sink,
+		Attempts:   deadLetter.Attempts,
+		LastError:  deadLetter.LastError,
+		CreatedAt:  deadLetter.CreatedAt,
+		FailedAt:   deadLetter.FailedAt,
+	}
+
+	// Stored events keep their data as raw JSON, other data is encoded as it would be on delivery
+	switch data := deadLetter.Event.Data.(type) {
+	case json.RawMessage:
+		resp.Data = data
+	default:
+		if encoded, err := json.Marshal(data); err == nil {
+			resp.Data = encoded
+		}
+	}
+
+	return resp
+}
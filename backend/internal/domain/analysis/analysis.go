@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
 )
 
@@ -26,16 +27,22 @@ type Analysis struct {
 	periodEnd          time.Time
 	feedbackCount      int
 	newFeedbackCount   optional.Optional[int]
-	overallSummary     string
+	overallSummary     optional.Optional[string] // None until the LLM result is stored
 	sentiment          Sentiment
 	keyInsights        []string
 	model              string
+	provider           optional.Optional[string] // None for analyses recorded before providers were tracked
 	tokens             int
 	analysisDurationMs int
 	status             Status
 	failureReason      optional.Optional[string]
 	createdAt          time.Time
 	completedAt        optional.Optional[time.Time]
+	noTopicsIdentified bool            // The model succeeded but reported no topics
+	deduplicatedCount  int             // Feedbacks collapsed into a representative with the same comment
+	representative     bool            // Enough feedbacks were analyzed to draw conclusions from
+	periodSemantics    PeriodSemantics // What periodStart and periodEnd represent
+	clock              clock.Clock     // Source of time for state changes
 }
 
 // IsValid validates the entire analysis entity state.
@@ -64,8 +71,9 @@ func (a *Analysis) IsValid() error {
 		return fmt.Errorf("at least one feedback must be analyzed")
 	}
 
-	if a.overallSummary == "" {
-		return fmt.Errorf("overall summary is required")
+	// A processing or failed analysis has no LLM result, so it has no summary yet
+	if a.status == StatusSuccess && a.overallSummary.IsNone() {
+		return fmt.Errorf("overall summary is required once the analysis succeeded")
 	}
 
 	if !a.sentiment.IsValid() {
@@ -88,6 +96,14 @@ func (a *Analysis) IsValid() error {
 		return fmt.Errorf("analysis duration cannot be negative")
 	}
 
+	if a.deduplicatedCount < 0 || a.deduplicatedCount >= a.feedbackCount {
+		return fmt.Errorf("deduplicated count must be non-negative and less than the feedback count")
+	}
+
+	if !a.periodSemantics.IsValid() {
+		return fmt.Errorf("invalid period semantics: %s", a.periodSemantics)
+	}
+
 	if a.createdAt.IsZero() {
 		return fmt.Errorf("created_at timestamp is required")
 	}
@@ -114,7 +130,7 @@ func (a *Analysis) MarkSuccess() error {
 	}
 
 	a.status = StatusSuccess
-	a.completedAt = optional.Some(time.Now().UTC())
+	a.completedAt = optional.Some(a.now().UTC())
 	return nil
 }
 
@@ -126,6 +142,14 @@ func (a *Analysis) MarkFailed(reason string) error {
 
 	a.status = StatusFailed
 	a.failureReason = optional.Some(reason)
-	a.completedAt = optional.Some(time.Now().UTC())
+	a.completedAt = optional.Some(a.now().UTC())
 	return nil
 }
+
+// now returns the current time from the entity clock, falling back to the system time.
+func (a *Analysis) now() time.Time {
+	if a.clock == nil {
+		return time.Now()
+	}
+	return a.clock.Now()
+}
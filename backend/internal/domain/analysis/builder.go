@@ -2,9 +2,11 @@ package analysis
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/shared"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
 )
 
@@ -15,8 +17,9 @@ type Builder struct {
 }
 
 // NewBuilder creates a builder for creating new analysis entities.
-func NewBuilder() *Builder {
-	now := time.Now().UTC()
+func NewBuilder(opts ...shared.BuilderOption) *Builder {
+	clk := shared.ResolveClock(nil, opts)
+	now := clk.Now().UTC()
 	return &Builder{
 		entity: &Analysis{
 			id:                 uuid.New(),
@@ -26,14 +29,18 @@ func NewBuilder() *Builder {
 			sentiment:          SentimentMixed, // Default sentiment
 			tokens:             0,              // Must be set explicitly
 			analysisDurationMs: 0,              // Must be set explicitly
+			periodSemantics:    PeriodSemanticsFeedbackSpan,
+			representative:     true,
+			clock:              clk,
 		},
 		validationErrors: make([]error, 0),
 	}
 }
 
 // BuilderFromExisting creates a builder from an existing analysis entity.
-func BuilderFromExisting(a *Analysis) *Builder {
+func BuilderFromExisting(a *Analysis, opts ...shared.BuilderOption) *Builder {
 	copied := *a
+	copied.clock = shared.ResolveClock(a.clock, opts)
 	return &Builder{
 		entity:           &copied,
 		validationErrors: make([]error, 0),
@@ -105,7 +112,7 @@ func (b *Builder) WithOverallSummary(summary string) *Builder {
 		b.validationErrors = append(b.validationErrors, fmt.Errorf("overall summary cannot be empty"))
 		return b
 	}
-	b.entity.overallSummary = summary
+	b.entity.overallSummary = optional.Some(summary)
 	return b
 }
 
@@ -139,6 +146,16 @@ func (b *Builder) WithModel(model string) *Builder {
 	return b
 }
 
+// WithProvider sets the provider the model was called through, e.g. "openai" or "azure".
+func (b *Builder) WithProvider(provider string) *Builder {
+	if provider == "" || strings.Contains(provider, "/") {
+		b.validationErrors = append(b.validationErrors, fmt.Errorf("invalid provider: %q", provider))
+		return b
+	}
+	b.entity.provider = optional.Some(provider)
+	return b
+}
+
 // WithTokens sets the tokens consumed.
 func (b *Builder) WithTokens(tokens int) *Builder {
 	if tokens < 0 {
@@ -195,6 +212,39 @@ func (b *Builder) WithCompletedAt(t time.Time) *Builder {
 	return b
 }
 
+// WithNoTopicsIdentified records whether the model reported no topics for the analysis.
+func (b *Builder) WithNoTopicsIdentified(noTopics bool) *Builder {
+	b.entity.noTopicsIdentified = noTopics
+	return b
+}
+
+// WithDeduplicatedCount sets the number of feedbacks collapsed into a representative before analysis.
+func (b *Builder) WithDeduplicatedCount(count int) *Builder {
+	if count < 0 {
+		b.validationErrors = append(b.validationErrors, fmt.Errorf("deduplicated count cannot be negative"))
+		return b
+	}
+	b.entity.deduplicatedCount = count
+	return b
+}
+
+// WithRepresentative records whether the analysis covered enough feedbacks to be considered representative.
+// Analyses are representative unless set otherwise.
+func (b *Builder) WithRepresentative(representative bool) *Builder {
+	b.entity.representative = representative
+	return b
+}
+
+// WithPeriodSemantics records what the period of the analysis represents.
+func (b *Builder) WithPeriodSemantics(semantics PeriodSemantics) *Builder {
+	if !semantics.IsValid() {
+		b.validationErrors = append(b.validationErrors, fmt.Errorf("invalid period semantics: %s", semantics))
+		return b
+	}
+	b.entity.periodSemantics = semantics
+	return b
+}
+
 // Build validates all accumulated data and returns the analysis entity.
 func (b *Builder) Build() (*Analysis, error) {
 	// Return accumulated validation errors first
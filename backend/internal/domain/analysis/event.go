@@ -0,0 +1,38 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
+)
+
+// EventType is a step in the lifecycle of an analysis.
+type EventType string
+
+const (
+	// EventCreated is recorded once the analysis record exists, before anything is sent to the LLM.
+	EventCreated EventType = "created"
+	// EventLLMCalled is recorded when the feedbacks are sent to the LLM.
+	EventLLMCalled EventType = "llm_called"
+	// EventLLMReturned is recorded when the LLM answered or the request failed, with the time the call took.
+	EventLLMReturned EventType = "llm_returned"
+	// EventTopicsCreated is recorded once the topics and their feedback assignments are stored.
+	EventTopicsCreated EventType = "topics_created"
+	// EventCompleted is recorded when the analysis succeeded, with the time since it was created.
+	EventCompleted EventType = "completed"
+	// EventFailed is recorded when the analysis failed, with the time since it was created and the reason.
+	EventFailed EventType = "failed"
+)
+
+// Event is an entry of the lifecycle timeline of an analysis. Unlike traces, which may be sampled away,
+// events are stored for every analysis.
+type Event struct {
+	AnalysisID uuid.UUID
+	Type       EventType
+	// Duration is the time the step took, for steps that measure one.
+	Duration optional.Optional[time.Duration]
+	// Details holds step specific information, e.g. the number of topics or the failure reason.
+	Details    map[string]any
+	OccurredAt time.Time
+}
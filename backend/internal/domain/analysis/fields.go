@@ -38,7 +38,8 @@ func (a *Analysis) NewFeedbackCount() optional.Optional[int] {
 }
 
 // OverallSummary returns the human-readable summary of all feedback.
-func (a *Analysis) OverallSummary() string {
+// It is None while the analysis is processing, and for analyses that failed before the LLM result was stored.
+func (a *Analysis) OverallSummary() optional.Optional[string] {
 	return a.overallSummary
 }
 
@@ -52,6 +53,21 @@ func (a *Analysis) KeyInsights() []string {
 	return a.keyInsights
 }
 
+// Provider returns the provider the model was called through, None for analyses recorded before
+// providers were tracked.
+func (a *Analysis) Provider() optional.Optional[string] {
+	return a.provider
+}
+
+// QualifiedModel returns the provider-qualified model identifier, e.g. "openai/gpt-5-mini", or only the model
+// name if the provider is unknown.
+func (a *Analysis) QualifiedModel() string {
+	if a.provider.IsNone() {
+		return a.model
+	}
+	return a.provider.Unwrap() + "/" + a.model
+}
+
 // Model returns the LLM model used for this analysis.
 func (a *Analysis) Model() string {
 	return a.model
@@ -86,3 +102,27 @@ func (a *Analysis) CreatedAt() time.Time {
 func (a *Analysis) CompletedAt() optional.Optional[time.Time] {
 	return a.completedAt
 }
+
+// NoTopicsIdentified reports whether the model completed successfully without identifying any topics.
+// This distinguishes an empty topic list from one where topic creation failed.
+func (a *Analysis) NoTopicsIdentified() bool {
+	return a.noTopicsIdentified
+}
+
+// DeduplicatedCount returns the number of feedbacks that were not sent to the model because another
+// feedback in the analysis had an identical comment. FeedbackCount still includes them.
+func (a *Analysis) DeduplicatedCount() int {
+	return a.deduplicatedCount
+}
+
+// IsRepresentative reports whether the analysis covered at least the configured minimum of feedbacks.
+// Analyses below it are still produced, but may be skipped by views that only show meaningful summaries.
+func (a *Analysis) IsRepresentative() bool {
+	return a.representative
+}
+
+// PeriodSemantics returns what PeriodStart and PeriodEnd represent: the span of the analyzed feedbacks
+// or the window ending when the analysis ran.
+func (a *Analysis) PeriodSemantics() PeriodSemantics {
+	return a.periodSemantics
+}
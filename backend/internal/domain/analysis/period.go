@@ -0,0 +1,26 @@
+package analysis
+
+// PeriodSemantics describes what the period of an analysis represents.
+type PeriodSemantics string
+
+const (
+	// PeriodSemanticsFeedbackSpan means the period spans the creation times of the analyzed feedbacks.
+	PeriodSemanticsFeedbackSpan PeriodSemantics = "feedback_span"
+	// PeriodSemanticsAnalysisWindow means the period is a fixed window ending when the analysis ran.
+	PeriodSemanticsAnalysisWindow PeriodSemantics = "analysis_window"
+)
+
+// String returns the string representation of the period semantics.
+func (p PeriodSemantics) String() string {
+	return string(p)
+}
+
+// IsValid checks if the period semantics are valid.
+func (p PeriodSemantics) IsValid() bool {
+	switch p {
+	case PeriodSemanticsFeedbackSpan, PeriodSemanticsAnalysisWindow:
+		return true
+	default:
+		return false
+	}
+}
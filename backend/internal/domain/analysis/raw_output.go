@@ -0,0 +1,15 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RawOutput is the output text of the model for an analysis, stored for debugging if enabled.
+// PII is masked before the output is stored.
+type RawOutput struct {
+	AnalysisID uuid.UUID
+	Output     string
+	CreatedAt  time.Time
+}
@@ -23,3 +23,33 @@ func (s Sentiment) IsValid() bool {
 		return false
 	}
 }
+
+// Score encodes the sentiment numerically for charts: 1 for positive, 0 for mixed and -1 for negative.
+// Invalid sentiments score 0.
+func (s Sentiment) Score() int {
+	switch s {
+	case SentimentPositive:
+		return 1
+	case SentimentNegative:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// SentimentDistribution counts the feedbacks of each sentiment within a topic.
+type SentimentDistribution struct {
+	Positive int
+	Mixed    int
+	Negative int
+}
+
+// Total returns the number of feedbacks counted in the distribution.
+func (d SentimentDistribution) Total() int {
+	return d.Positive + d.Mixed + d.Negative
+}
+
+// IsValid checks that no count is negative and that at least one feedback is counted.
+func (d SentimentDistribution) IsValid() bool {
+	return d.Positive >= 0 && d.Mixed >= 0 && d.Negative >= 0 && d.Total() > 0
+}
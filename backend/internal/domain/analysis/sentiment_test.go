@@ -0,0 +1,17 @@
+package analysis
+
+import "testing"
+
+func TestSentiment_Score(t *testing.T) {
+	scores := map[Sentiment]int{
+		SentimentPositive:  1,
+		SentimentMixed:     0,
+		SentimentNegative:  -1,
+		Sentiment("angry"): 0,
+	}
+	for sentiment, want := range scores {
+		if got := sentiment.Score(); got != want {
+			t.Errorf("Expected %q to score %d, got %d", sentiment, want, got)
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package analysis
+
+import (
+	"github.com/google/uuid"
+)
+
+// TokenUsage compares the tokens estimated for the LLM request of an analysis with the tokens reported
+// by the provider. It is recorded, if enabled, to calibrate the token estimator.
+type TokenUsage struct {
+	AnalysisID      uuid.UUID
+	EstimatedTokens int
+	InputTokens     int
+	OutputTokens    int
+	TotalTokens     int
+}
@@ -311,6 +311,41 @@ func AllTopics() []Topic {
 	}
 }
 
+// RelatedTopics returns the topics closest to the topic, closest first. It is used to reassign feedback
+// categorized under a topic that is not in use to a neighbouring one. Unknown topics have no related topics.
+func (t Topic) RelatedTopics() []Topic {
+	switch t {
+	case TopicProductFunctionalityFeatures:
+		return []Topic{TopicUsabilityProductivity, TopicProductStrategyRoadmap, TopicUIUX}
+	case TopicUIUX:
+		return []Topic{TopicUsabilityProductivity, TopicLocalizationInternationalization, TopicProductFunctionalityFeatures}
+	case TopicPerformanceReliability:
+		return []Topic{TopicProductFunctionalityFeatures, TopicInstallationSetupDeployment, TopicCompatibilityIntegration}
+	case TopicUsabilityProductivity:
+		return []Topic{TopicUIUX, TopicProductFunctionalityFeatures}
+	case TopicSecurityPrivacy:
+		return []Topic{TopicProductFunctionalityFeatures, TopicCompatibilityIntegration}
+	case TopicCompatibilityIntegration:
+		return []Topic{TopicInstallationSetupDeployment, TopicDeveloperExperience, TopicProductFunctionalityFeatures}
+	case TopicDeveloperExperience:
+		return []Topic{TopicCompatibilityIntegration, TopicInstallationSetupDeployment, TopicCustomerSupportCommunity}
+	case TopicPricingLicensing:
+		return []Topic{TopicProductStrategyRoadmap, TopicCustomerSupportCommunity}
+	case TopicCustomerSupportCommunity:
+		return []Topic{TopicDeveloperExperience, TopicProductStrategyRoadmap}
+	case TopicInstallationSetupDeployment:
+		return []Topic{TopicCompatibilityIntegration, TopicDeveloperExperience, TopicPerformanceReliability}
+	case TopicDataAnalyticsReporting:
+		return []Topic{TopicProductFunctionalityFeatures, TopicCompatibilityIntegration}
+	case TopicLocalizationInternationalization:
+		return []Topic{TopicUIUX, TopicCompatibilityIntegration}
+	case TopicProductStrategyRoadmap:
+		return []Topic{TopicProductFunctionalityFeatures, TopicPricingLicensing}
+	default:
+		return nil
+	}
+}
+
 // IsValid checks if the topic value is valid.
 func (t Topic) IsValid() bool {
 	switch t {
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
 )
 
 // TopicAnalysis represents the analysis of a specific topic within an analysis.
@@ -15,19 +16,26 @@ import (
 // - Must have a summary (analysis summary for this topic)
 // - Must have at least one feedback assigned
 // - Sentiment must be valid
+// - Sentiment distribution is optional, but must be valid if set
+// - Recommendations are optional
+// - Confidence is optional, but must be between 0 and 1 if set
 //
 // Relationships:
 // - Belongs to Analysis (many-to-one)
 // - Has many Feedbacks via FeedbackTopicAssignment (many-to-many)
 type TopicAnalysis struct {
-	id            uuid.UUID
-	analysisID    uuid.UUID
-	topic         Topic
-	summary       string
-	feedbackCount int
-	sentiment     Sentiment
-	createdAt     time.Time
-	updatedAt     time.Time
+	id                    uuid.UUID
+	analysisID            uuid.UUID
+	topic                 Topic
+	summary               string
+	feedbackCount         int
+	sentiment             Sentiment
+	sentimentDistribution optional.Optional[SentimentDistribution] // None if the model only labeled the topic
+	recommendations       []string                                 // Recommended actions, empty if none were requested
+	confidence            optional.Optional[float64]               // None if the model was not asked for it
+	sentimentConflict     bool                                     // The model sentiment contradicted the feedback rating
+	createdAt             time.Time
+	updatedAt             time.Time
 }
 
 // IsValid validates the entire topic analysis entity state.
@@ -60,6 +68,10 @@ func (t *TopicAnalysis) IsValid() error {
 		return fmt.Errorf("sentiment is required and must be valid")
 	}
 
+	if t.sentimentDistribution.IsSome() && !t.sentimentDistribution.Unwrap().IsValid() {
+		return fmt.Errorf("sentiment distribution must be valid")
+	}
+
 	if t.createdAt.IsZero() {
 		return fmt.Errorf("created_at timestamp is required")
 	}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/shared"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
 )
 
 // TopicAnalysisBuilder provides type-safe, fluent construction of TopicAnalysis entities.
@@ -14,22 +16,23 @@ type TopicAnalysisBuilder struct {
 }
 
 // NewTopicAnalysisBuilder creates a builder for creating new topic analysis entities.
-func NewTopicAnalysisBuilder() *TopicAnalysisBuilder {
-	now := time.Now().UTC()
+func NewTopicAnalysisBuilder(opts ...shared.BuilderOption) *TopicAnalysisBuilder {
+	now := shared.ResolveClock(nil, opts).Now().UTC()
 	return &TopicAnalysisBuilder{
 		entity: &TopicAnalysis{
-			id:        uuid.New(),
-			createdAt: now,
-			updatedAt: now,
+			id:              uuid.New(),
+			recommendations: []string{},
+			createdAt:       now,
+			updatedAt:       now,
 		},
 		validationErrors: make([]error, 0),
 	}
 }
 
 // BuilderFromExistingTopicAnalysis creates a builder from an existing topic analysis entity.
-func BuilderFromExistingTopicAnalysis(t *TopicAnalysis) *TopicAnalysisBuilder {
+func BuilderFromExistingTopicAnalysis(t *TopicAnalysis, opts ...shared.BuilderOption) *TopicAnalysisBuilder {
 	copied := *t
-	copied.updatedAt = time.Now().UTC()
+	copied.updatedAt = shared.ResolveClock(nil, opts).Now().UTC()
 	return &TopicAnalysisBuilder{
 		entity:           &copied,
 		validationErrors: make([]error, 0),
@@ -96,6 +99,49 @@ func (b *TopicAnalysisBuilder) WithSentiment(sentiment Sentiment) *TopicAnalysis
 	return b
 }
 
+// WithSentimentDistribution sets the number of feedbacks per sentiment within the topic.
+func (b *TopicAnalysisBuilder) WithSentimentDistribution(distribution SentimentDistribution) *TopicAnalysisBuilder {
+	if !distribution.IsValid() {
+		b.validationErrors = append(
+			b.validationErrors,
+			fmt.Errorf("invalid sentiment distribution: %+v", distribution),
+		)
+		return b
+	}
+	b.entity.sentimentDistribution = optional.Some(distribution)
+	return b
+}
+
+// WithRecommendations sets the recommended actions for the topic.
+func (b *TopicAnalysisBuilder) WithRecommendations(recommendations []string) *TopicAnalysisBuilder {
+	if recommendations == nil {
+		b.entity.recommendations = []string{}
+	} else {
+		b.entity.recommendations = recommendations
+	}
+	return b
+}
+
+// WithConfidence sets how confident the model is in the topic assignment, between 0 and 1.
+func (b *TopicAnalysisBuilder) WithConfidence(confidence float64) *TopicAnalysisBuilder {
+	if confidence < 0 || confidence > 1 {
+		b.validationErrors = append(
+			b.validationErrors,
+			fmt.Errorf("confidence must be between 0 and 1, got %v", confidence),
+		)
+		return b
+	}
+	b.entity.confidence = optional.Some(confidence)
+	return b
+}
+
+// WithSentimentConflict records whether the sentiment the model assigned contradicted the rating of the
+// topic's only feedback.
+func (b *TopicAnalysisBuilder) WithSentimentConflict(conflict bool) *TopicAnalysisBuilder {
+	b.entity.sentimentConflict = conflict
+	return b
+}
+
 // WithCreatedAt sets the creation timestamp.
 func (b *TopicAnalysisBuilder) WithCreatedAt(t time.Time) *TopicAnalysisBuilder {
 	if t.IsZero() {
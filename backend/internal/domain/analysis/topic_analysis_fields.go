@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
 )
 
 // ID returns the topic analysis ID.
@@ -41,6 +42,22 @@ func (t *TopicAnalysis) Sentiment() Sentiment {
 	return t.sentiment
 }
 
+// SentimentDistribution returns the number of feedbacks per sentiment within the topic.
+// None if the model only returned the dominant sentiment.
+func (t *TopicAnalysis) SentimentDistribution() optional.Optional[SentimentDistribution] {
+	return t.sentimentDistribution
+}
+
+// Recommendations returns the actions the model recommends for this topic.
+func (t *TopicAnalysis) Recommendations() []string {
+	return t.recommendations
+}
+
+// Confidence returns how confident the model is in the topic assignment, None if it was not requested.
+func (t *TopicAnalysis) Confidence() optional.Optional[float64] {
+	return t.confidence
+}
+
 // CreatedAt returns the creation timestamp.
 func (t *TopicAnalysis) CreatedAt() time.Time {
 	return t.createdAt
@@ -50,3 +67,9 @@ func (t *TopicAnalysis) CreatedAt() time.Time {
 func (t *TopicAnalysis) UpdatedAt() time.Time {
 	return t.updatedAt
 }
+
+// SentimentConflict reports whether the sentiment the model assigned contradicted the rating of the topic's
+// only feedback. Sentiment returns the sentiment of the rating instead if the conflict was overridden.
+func (t *TopicAnalysis) SentimentConflict() bool {
+	return t.sentimentConflict
+}
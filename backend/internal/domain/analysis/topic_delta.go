@@ -0,0 +1,59 @@
+package analysis
+
+import "sort"
+
+// TopicDelta is the change in the number of feedbacks assigned to a topic between an analysis
+// and its previous analysis. Deltas are computed and stored when the analysis completes.
+type TopicDelta struct {
+	Topic Topic
+	// PreviousCount is the number of feedbacks of the topic in the previous analysis, 0 if it was not identified.
+	PreviousCount int
+	// CurrentCount is the number of feedbacks of the topic in the analysis, 0 if it is not identified anymore.
+	CurrentCount int
+}
+
+// Delta returns the change in the number of feedbacks, negative if the topic shrank.
+func (d TopicDelta) Delta() int {
+	return d.CurrentCount - d.PreviousCount
+}
+
+// TopicFeedbackCounts sums the feedback counts of the given topic analyses per topic.
+func TopicFeedbackCounts(topics []*TopicAnalysis) map[Topic]int {
+	counts := make(map[Topic]int, len(topics))
+	for _, topic := range topics {
+		counts[topic.Topic()] += topic.FeedbackCount()
+	}
+	return counts
+}
+
+// ComputeTopicDeltas compares the per-topic feedback counts of an analysis with those of its previous analysis.
+// Every topic identified by either analysis is included, ordered by the size of the change (largest first)
+// and then by topic. Topics whose count did not change are included with a zero delta.
+func ComputeTopicDeltas(previous, current map[Topic]int) []TopicDelta {
+	deltas := make([]TopicDelta, 0, len(current))
+	for topic, count := range current {
+		deltas = append(deltas, TopicDelta{Topic: topic, PreviousCount: previous[topic], CurrentCount: count})
+	}
+	for topic, count := range previous {
+		if _, ok := current[topic]; !ok {
+			deltas = append(deltas, TopicDelta{Topic: topic, PreviousCount: count})
+		}
+	}
+
+	sort.Slice(
+		deltas, func(i, j int) bool {
+			if a, b := abs(deltas[i].Delta()), abs(deltas[j].Delta()); a != b {
+				return a > b
+			}
+			return deltas[i].Topic < deltas[j].Topic
+		},
+	)
+	return deltas
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
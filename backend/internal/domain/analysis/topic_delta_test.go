@@ -0,0 +1,39 @@
+package analysis
+
+import "testing"
+
+func TestComputeTopicDeltas(t *testing.T) {
+	previous := map[Topic]int{
+		TopicPerformanceReliability: 2,
+		TopicUIUX:                   4,
+		TopicPricingLicensing:       1,
+	}
+	current := map[Topic]int{
+		TopicPerformanceReliability: 5,
+		TopicUIUX:                   4,
+		TopicSecurityPrivacy:        1,
+	}
+
+	expected := []TopicDelta{
+		{Topic: TopicPerformanceReliability, PreviousCount: 2, CurrentCount: 5},
+		{Topic: TopicPricingLicensing, PreviousCount: 1, CurrentCount: 0},
+		{Topic: TopicSecurityPrivacy, PreviousCount: 0, CurrentCount: 1},
+		{Topic: TopicUIUX, PreviousCount: 4, CurrentCount: 4},
+	}
+
+	deltas := ComputeTopicDeltas(previous, current)
+	if len(deltas) != len(expected) {
+		t.Fatalf("Expected %d deltas, got %d: %+v", len(expected), len(deltas), deltas)
+	}
+	for i, delta := range deltas {
+		if delta != expected[i] {
+			t.Errorf("Expected delta %+v at position %d, got %+v", expected[i], i, delta)
+		}
+	}
+	if got := deltas[0].Delta(); got != 3 {
+		t.Errorf("Expected a delta of 3 for %s, got %d", deltas[0].Topic, got)
+	}
+	if got := deltas[1].Delta(); got != -1 {
+		t.Errorf("Expected a delta of -1 for %s, got %d", deltas[1].Topic, got)
+	}
+}
@@ -0,0 +1,20 @@
+package analysis
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TopicStats are the materialized statistics of a topic in the latest analysis. They are computed when
+// an analysis completes and can be recomputed, e.g. after feedbacks were deleted, without calling the LLM.
+type TopicStats struct {
+	Topic Topic
+	// AnalysisID is the analysis the statistics are computed from.
+	AnalysisID uuid.UUID
+	// FeedbackCount is the number of non-deleted feedbacks assigned to the topic.
+	FeedbackCount int
+	// AverageRating is the average rating of those feedbacks, 0 if there are none.
+	AverageRating float64
+	ComputedAt    time.Time
+}
@@ -19,4 +19,6 @@ type UpdatedResults struct {
 	Sentiment      Sentiment
 	KeyInsights    []string
 	Tokens         int
+	// NoTopicsIdentified is set when the model returned an empty topic list.
+	NoTopicsIdentified bool
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/shared"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
 )
 
@@ -17,13 +18,16 @@ type Builder struct {
 
 // NewBuilder creates a builder for creating new feedback entities.
 // Initialize with sensible defaults.
-func NewBuilder() *Builder {
-	now := time.Now().UTC()
+func NewBuilder(opts ...shared.BuilderOption) *Builder {
+	clk := shared.ResolveClock(nil, opts)
+	now := clk.Now().UTC()
 	return &Builder{
 		entity: &Feedback{
 			id:        uuid.New(),
+			source:    DefaultSource,
 			createdAt: now,
 			updatedAt: now,
+			clock:     clk,
 		},
 		validationErrors: make([]error, 0),
 	}
@@ -31,9 +35,10 @@ func NewBuilder() *Builder {
 
 // BuilderFromExisting creates a builder from an existing feedback entity.
 // Useful for update operations (though feedback is immutable, this can be used for reconstruction).
-func BuilderFromExisting(f *Feedback) *Builder {
+func BuilderFromExisting(f *Feedback, opts ...shared.BuilderOption) *Builder {
 	copied := *f
-	copied.updatedAt = time.Now()
+	copied.clock = shared.ResolveClock(f.clock, opts)
+	copied.updatedAt = copied.clock.Now()
 
 	return &Builder{
 		entity:           &copied,
@@ -64,7 +69,11 @@ func (b *Builder) WithUserID(userID uuid.UUID) *Builder {
 // WithRating sets the feedback rating with validation.
 func (b *Builder) WithRating(rating Rating) *Builder {
 	if !rating.IsValid() {
-		b.validationErrors = append(b.validationErrors, fmt.Errorf("invalid rating: %d (must be between 1 and 5)", rating))
+		scale := CurrentRatingScale()
+		b.validationErrors = append(
+			b.validationErrors,
+			fmt.Errorf("invalid rating: %d (must be between %d and %d)", rating, scale.Min, scale.Max),
+		)
 		return b
 	}
 	b.entity.rating = rating
@@ -84,7 +93,7 @@ func (b *Builder) WithRatingValue(ratingValue int) *Builder {
 
 // WithComment sets the feedback comment with validation.
 func (b *Builder) WithComment(comment Comment) *Builder {
-	if comment.Value() == "" {
+	if comment.IsEmpty() && !RatingOnlyAllowed() {
 		b.validationErrors = append(b.validationErrors, fmt.Errorf("comment cannot be empty"))
 		return b
 	}
@@ -103,6 +112,71 @@ func (b *Builder) WithCommentText(commentText string) *Builder {
 	return b
 }
 
+// WithSource sets the channel the feedback was submitted through.
+func (b *Builder) WithSource(source Source) *Builder {
+	if !source.IsValid() {
+		b.validationErrors = append(b.validationErrors, fmt.Errorf("invalid source: %s", source))
+		return b
+	}
+	b.entity.source = source
+	return b
+}
+
+// WithTags sets the submitter's tags from raw values, normalizing and validating them.
+func (b *Builder) WithTags(values []string) *Builder {
+	tags, err := NewTags(values)
+	if err != nil {
+		b.validationErrors = append(b.validationErrors, err)
+		return b
+	}
+	b.entity.tags = tags
+	return b
+}
+
+// WithMetadata sets the technical context the feedback was reported with.
+func (b *Builder) WithMetadata(metadata Metadata) *Builder {
+	if metadata.platform != "" && !metadata.platform.IsValid() {
+		b.validationErrors = append(b.validationErrors, fmt.Errorf("invalid platform: %s", metadata.platform))
+		return b
+	}
+	b.entity.metadata = metadata
+	return b
+}
+
+// WithMetadataValues sets the technical context from raw values with validation.
+func (b *Builder) WithMetadataValues(appVersion, platform, os string) *Builder {
+	metadata, err := NewMetadata(appVersion, platform, os)
+	if err != nil {
+		b.validationErrors = append(b.validationErrors, err)
+		return b
+	}
+	b.entity.metadata = metadata
+	return b
+}
+
+// WithTranslation sets the comment translated into the analysis language and the detected language of the
+// original comment. An empty translated comment leaves the comment untranslated.
+func (b *Builder) WithTranslation(translatedComment, language string) *Builder {
+	b.entity.commentLanguage = language
+	if translatedComment == "" {
+		b.entity.translatedComment = optional.None[string]()
+		return b
+	}
+	b.entity.translatedComment = optional.Some(translatedComment)
+	return b
+}
+
+// WithOriginalComment sets the comment as it was submitted, before markup was stripped from it.
+// An empty original comment means the comment is stored as submitted.
+func (b *Builder) WithOriginalComment(originalComment string) *Builder {
+	if originalComment == "" {
+		b.entity.originalComment = optional.None[string]()
+		return b
+	}
+	b.entity.originalComment = optional.Some(originalComment)
+	return b
+}
+
 // WithCreatedAt sets the creation timestamp (for database reconstruction).
 func (b *Builder) WithCreatedAt(t time.Time) *Builder {
 	if t.IsZero() {
@@ -147,10 +221,10 @@ func (b *Builder) Build() (*Feedback, error) {
 	}
 
 	if !b.entity.rating.IsValid() {
-		return nil, fmt.Errorf("rating is required and must be between 1 and 5")
+		return nil, fmt.Errorf("rating is required and must be within the %s scale", CurrentRatingScale())
 	}
 
-	if b.entity.comment.Value() == "" {
+	if b.entity.comment.IsEmpty() && !RatingOnlyAllowed() {
 		return nil, fmt.Errorf("comment is required")
 	}
 
@@ -5,17 +5,23 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
 )
 
 // Feedback represents a user feedback submission.
 //
 // Business Rules:
-// - Rating must be between 1 and 5 (enforced by Rating value object)
-// - Comment is required and must be between 1 and 1000 characters
+// - Rating must be within the configured rating scale (enforced by Rating value object)
+// - Comment must be between 1 and 1000 characters, or empty if rating-only feedback is allowed
 // - Cannot be edited once created (immutable after creation)
 // - Can be soft-deleted
 // - Must belong to a user (userID is required)
+// - Source must be one of the known channels (defaults to web)
+// - Tags are optional, lowercase and unique, at most MaxTagsPerFeedback
+// - Metadata (app version, platform, OS) is optional
+// - A translation of the comment is optional and never replaces the original comment
+// - The submitted comment is optional and only kept if markup was stripped from the comment
 //
 // Relationships:
 // - Belongs to User (many-to-one relationship).
@@ -24,9 +30,17 @@ type Feedback struct {
 	userID    uuid.UUID // User who submitted the feedback
 	rating    Rating
 	comment   Comment
+	source    Source   // Channel the feedback was submitted through
+	tags      []Tag    // Labels chosen by the submitter
+	metadata  Metadata // Technical context of the reporter
 	createdAt time.Time
 	updatedAt time.Time
 	deletedAt optional.Optional[time.Time]
+	clock     clock.Clock // Source of time for state changes
+
+	translatedComment optional.Optional[string] // Comment translated into the analysis language
+	commentLanguage   string                    // Detected ISO 639-1 language of the comment
+	originalComment   optional.Optional[string] // Comment as submitted, before markup was stripped
 }
 
 // IsValid validates the entire feedback entity state.
@@ -43,10 +57,18 @@ func (f *Feedback) IsValid() error {
 		return fmt.Errorf("invalid rating: %d", f.rating)
 	}
 
-	if f.comment.Value() == "" {
+	if f.comment.IsEmpty() && !RatingOnlyAllowed() {
 		return fmt.Errorf("comment is required")
 	}
 
+	if !f.source.IsValid() {
+		return fmt.Errorf("invalid source: %s", f.source)
+	}
+
+	if len(f.tags) > MaxTagsPerFeedback {
+		return fmt.Errorf("feedback cannot have more than %d tags", MaxTagsPerFeedback)
+	}
+
 	if f.createdAt.IsZero() {
 		return fmt.Errorf("createdAt timestamp is required")
 	}
@@ -75,7 +97,7 @@ func (f *Feedback) Delete() error {
 		return fmt.Errorf("feedback is already deleted")
 	}
 
-	now := time.Now()
+	now := f.now()
 	f.deletedAt = optional.Some(now)
 	f.updatedAt = now
 	return nil
@@ -88,6 +110,14 @@ func (f *Feedback) Restore() error {
 	}
 
 	f.deletedAt = optional.None[time.Time]()
-	f.updatedAt = time.Now()
+	f.updatedAt = f.now()
 	return nil
 }
+
+// now returns the current time from the entity clock, falling back to the system time.
+func (f *Feedback) now() time.Time {
+	if f.clock == nil {
+		return time.Now()
+	}
+	return f.clock.Now()
+}
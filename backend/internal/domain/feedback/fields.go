@@ -27,6 +27,57 @@ func (f *Feedback) Comment() Comment {
 	return f.comment
 }
 
+// TranslatedComment returns the comment translated into the analysis language, if it was translated.
+func (f *Feedback) TranslatedComment() optional.Optional[string] {
+	return f.translatedComment
+}
+
+// CommentLanguage returns the detected ISO 639-1 language of the comment, empty if it was not detected.
+func (f *Feedback) CommentLanguage() string {
+	return f.commentLanguage
+}
+
+// OriginalComment returns the comment as it was submitted, if markup was stripped from it and the
+// original was preserved.
+func (f *Feedback) OriginalComment() optional.Optional[string] {
+	return f.originalComment
+}
+
+// AnalysisComment returns the text of the comment to analyze: the translated comment if there is one,
+// otherwise the original comment.
+func (f *Feedback) AnalysisComment() string {
+	return f.translatedComment.UnwrapOr(f.comment.Value())
+}
+
+// IsRatingOnly reports whether the feedback consists of a rating without a comment.
+func (f *Feedback) IsRatingOnly() bool {
+	return f.comment.IsEmpty()
+}
+
+// Source returns the channel the feedback was submitted through.
+func (f *Feedback) Source() Source {
+	return f.source
+}
+
+// Tags returns the labels the submitter attached to the feedback.
+func (f *Feedback) Tags() []Tag {
+	return f.tags
+}
+
+// TagStrings returns the tags as plain strings.
+func (f *Feedback) TagStrings() []string {
+	tags := make([]string, len(f.tags))
+	for i, tag := range f.tags {
+		tags[i] = tag.String()
+	}
+	return tags
+}
+
+// Metadata returns the technical context the feedback was reported with.
+func (f *Feedback) Metadata() Metadata {
+	return f.metadata
+}
+
 // CreatedAt returns the creation timestamp.
 func (f *Feedback) CreatedAt() time.Time {
 	return f.createdAt
@@ -1,10 +1,74 @@
 package feedback
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
 
-// Rating represents a feedback rating on a scale of 1-5.
+	"golang.org/x/text/unicode/norm"
+)
+
+// RatingScale defines the inclusive range of valid rating values.
+type RatingScale struct {
+	Min int
+	Max int
+}
+
+// DefaultRatingScale is the 1-5 star scale used unless another scale is configured.
+var DefaultRatingScale = RatingScale{Min: 1, Max: 5}
+
+var (
+	ratingScale      = DefaultRatingScale
+	ratingScaleMutex sync.RWMutex
+)
+
+// ConfigureRatingScale sets the rating scale used to validate ratings.
+// It should be called once during application initialization, before any feedback is built.
+func ConfigureRatingScale(scale RatingScale) error {
+	if scale.Min >= scale.Max {
+		return fmt.Errorf("rating scale minimum (%d) must be lower than maximum (%d)", scale.Min, scale.Max)
+	}
+
+	ratingScaleMutex.Lock()
+	defer ratingScaleMutex.Unlock()
+	ratingScale = scale
+	return nil
+}
+
+// CurrentRatingScale returns the configured rating scale.
+func CurrentRatingScale() RatingScale {
+	ratingScaleMutex.RLock()
+	defer ratingScaleMutex.RUnlock()
+	return ratingScale
+}
+
+// Contains reports whether the value is within the scale.
+func (s RatingScale) Contains(value int) bool {
+	return value >= s.Min && value <= s.Max
+}
+
+// IsLow reports whether the value is in the bottom quarter of the scale, e.g. 1 or 2 on a 1-5 scale.
+func (s RatingScale) IsLow(value int) bool {
+	return (value-s.Min)*4 <= s.Max-s.Min
+}
+
+// IsHigh reports whether the value is in the top quarter of the scale, e.g. 4 or 5 on a 1-5 scale.
+func (s RatingScale) IsHigh(value int) bool {
+	return (s.Max-value)*4 <= s.Max-s.Min
+}
+
+// String returns the string representation of the scale, e.g. "1-5".
+func (s RatingScale) String() string {
+	return fmt.Sprintf("%d-%d", s.Min, s.Max)
+}
+
+// Rating represents a feedback rating on the configured scale (1-5 by default).
 type Rating int
 
+// Ratings of the default 1-5 scale.
 const (
 	Rating1 Rating = 1
 	Rating2 Rating = 2
@@ -18,9 +82,9 @@ func (r Rating) String() string {
 	return fmt.Sprintf("%d", r)
 }
 
-// IsValid validates that the rating is between 1 and 5.
+// IsValid validates that the rating is within the configured scale.
 func (r Rating) IsValid() bool {
-	return r >= Rating1 && r <= Rating5
+	return CurrentRatingScale().Contains(int(r))
 }
 
 // Value returns the integer value of the rating.
@@ -30,32 +94,133 @@ func (r Rating) Value() int {
 
 // NewRating creates a new Rating value object with validation.
 func NewRating(value int) (Rating, error) {
-	rating := Rating(value)
-	if !rating.IsValid() {
-		return 0, fmt.Errorf("rating must be between 1 and 5, got: %d", value)
+	scale := CurrentRatingScale()
+	if !scale.Contains(value) {
+		return 0, fmt.Errorf("rating must be between %d and %d, got: %d", scale.Min, scale.Max, value)
 	}
+	rating := Rating(value)
 	return rating, nil
 }
 
+// ratingOnlyAllowed reports whether feedback may consist of a rating without a comment.
+var ratingOnlyAllowed atomic.Bool
+
+// ConfigureRatingOnly sets whether feedback may be submitted with a rating only.
+// When allowed, empty and whitespace-only comments are accepted and stored as an empty comment.
+// It should be called once during application initialization, before any feedback is built.
+func ConfigureRatingOnly(allowed bool) {
+	ratingOnlyAllowed.Store(allowed)
+}
+
+// RatingOnlyAllowed reports whether feedback may be submitted with a rating only.
+func RatingOnlyAllowed() bool {
+	return ratingOnlyAllowed.Load()
+}
+
 // Comment represents validated feedback comment text.
 type Comment struct {
 	value string
 }
 
 const (
-	// MinCommentLength is the minimum length for a comment.
+	// MinCommentLength is the default minimum length for a comment.
 	MinCommentLength = 1
 	// MaxCommentLength is the maximum length for a comment.
 	MaxCommentLength = 1000
 )
 
+var minCommentLength atomic.Int64
+
+func init() {
+	minCommentLength.Store(MinCommentLength)
+}
+
+// ConfigureMinCommentLength sets the minimum length of a comment, not counting leading and trailing whitespace.
+// It should be called once during application initialization, before any feedback is built.
+func ConfigureMinCommentLength(length int) error {
+	if length < MinCommentLength || length > MaxCommentLength {
+		return fmt.Errorf(
+			"minimum comment length must be between %d and %d, got: %d",
+			MinCommentLength,
+			MaxCommentLength,
+			length,
+		)
+	}
+
+	minCommentLength.Store(int64(length))
+	return nil
+}
+
+// CurrentMinCommentLength returns the configured minimum comment length.
+func CurrentMinCommentLength() int {
+	return int(minCommentLength.Load())
+}
+
+// InvalidUTF8Policy is what happens to comments that are not valid UTF-8.
+type InvalidUTF8Policy string
+
+const (
+	// InvalidUTF8Reject refuses comments containing invalid UTF-8 sequences.
+	InvalidUTF8Reject InvalidUTF8Policy = "reject"
+	// InvalidUTF8Replace replaces invalid UTF-8 sequences with U+FFFD.
+	InvalidUTF8Replace InvalidUTF8Policy = "replace"
+)
+
+var replaceInvalidUTF8 atomic.Bool
+
+// ConfigureInvalidUTF8Policy sets how comments with invalid UTF-8 are handled. Defaults to InvalidUTF8Reject.
+// It should be called once during application initialization, before any feedback is built.
+func ConfigureInvalidUTF8Policy(policy InvalidUTF8Policy) error {
+	switch policy {
+	case InvalidUTF8Reject, InvalidUTF8Replace:
+	default:
+		return fmt.Errorf("invalid UTF-8 policy must be %q or %q, got: %q", InvalidUTF8Reject, InvalidUTF8Replace, policy)
+	}
+
+	replaceInvalidUTF8.Store(policy == InvalidUTF8Replace)
+	return nil
+}
+
+// normalizeCommentText applies the invalid UTF-8 policy, removes control characters other than newlines
+// and tabs, and normalizes the text to NFC so that equal comments are stored with equal bytes.
+func normalizeCommentText(text string) (string, error) {
+	if !utf8.ValidString(text) {
+		if !replaceInvalidUTF8.Load() {
+			return "", fmt.Errorf("comment is not valid UTF-8")
+		}
+		text = strings.ToValidUTF8(text, string(utf8.RuneError))
+	}
+
+	text = strings.Map(
+		func(r rune) rune {
+			if unicode.IsControl(r) && r != '\n' && r != '\t' {
+				return -1
+			}
+			return r
+		}, text,
+	)
+
+	return norm.NFC.String(text), nil
+}
+
 // NewComment creates a new Comment value object with validation.
+// The text is normalized first, see normalizeCommentText.
+// If rating-only feedback is allowed, empty and whitespace-only text yields an empty comment.
 func NewComment(text string) (Comment, error) {
+	text, err := normalizeCommentText(text)
+	if err != nil {
+		return Comment{}, err
+	}
+
+	trimmed := strings.TrimSpace(text)
+	if RatingOnlyAllowed() && trimmed == "" {
+		return Comment{}, nil
+	}
 	if text == "" {
 		return Comment{}, fmt.Errorf("comment cannot be empty")
 	}
-	if len(text) < MinCommentLength {
-		return Comment{}, fmt.Errorf("comment must be at least %d character(s)", MinCommentLength)
+	if minLength := CurrentMinCommentLength(); len(trimmed) < minLength {
+		return Comment{}, fmt.Errorf("comment must be at least %d character(s), got: %d", minLength, len(trimmed))
 	}
 	if len(text) > MaxCommentLength {
 		return Comment{}, fmt.Errorf("comment cannot exceed %d characters, got: %d", MaxCommentLength, len(text))
@@ -63,6 +228,12 @@ func NewComment(text string) (Comment, error) {
 	return Comment{value: text}, nil
 }
 
+// RestoreComment rebuilds a stored comment without validation, so comments accepted under
+// a previously configured minimum length are still reconstructed as is.
+func RestoreComment(text string) Comment {
+	return Comment{value: text}
+}
+
 // Value returns the comment text value.
 func (c Comment) Value() string {
 	return c.value
@@ -77,3 +248,218 @@ func (c Comment) String() string {
 func (c Comment) Length() int {
 	return len(c.value)
 }
+
+// IsEmpty reports whether the comment has no text, as for rating-only feedback.
+func (c Comment) IsEmpty() bool {
+	return c.value == ""
+}
+
+// Source represents the channel a feedback was submitted through.
+type Source string
+
+// Known feedback sources.
+const (
+	SourceWeb    Source = "web"
+	SourceMobile Source = "mobile"
+	SourceAPI    Source = "api"
+	SourceEmail  Source = "email"
+)
+
+// DefaultSource is used when a feedback is submitted without a source.
+const DefaultSource = SourceWeb
+
+// AllSources returns all known feedback sources.
+func AllSources() []Source {
+	return []Source{SourceWeb, SourceMobile, SourceAPI, SourceEmail}
+}
+
+// IsValid checks if the source is one of the known sources.
+func (s Source) IsValid() bool {
+	switch s {
+	case SourceWeb, SourceMobile, SourceAPI, SourceEmail:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of the source.
+func (s Source) String() string {
+	return string(s)
+}
+
+// NewSource creates a new Source value object with validation.
+// An empty value resolves to DefaultSource.
+func NewSource(value string) (Source, error) {
+	if value == "" {
+		return DefaultSource, nil
+	}
+
+	source := Source(value)
+	if !source.IsValid() {
+		return "", fmt.Errorf("unknown feedback source: %q (must be one of web, mobile, api, email)", value)
+	}
+	return source, nil
+}
+
+// Tag is a label attached by the submitter to categorize their feedback, e.g. "bug" or "feature-request".
+// Tags are normalized to lowercase.
+type Tag string
+
+const (
+	// MaxTagLength is the maximum length of a tag.
+	MaxTagLength = 32
+	// MaxTagsPerFeedback is the maximum number of tags attached to a feedback.
+	MaxTagsPerFeedback = 10
+)
+
+// NewTag creates a new Tag value object with validation.
+// The value is trimmed and lowercased; it may only contain letters, digits, '-' and '_'.
+func NewTag(value string) (Tag, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	if normalized == "" {
+		return "", fmt.Errorf("tag cannot be empty")
+	}
+	if len(normalized) > MaxTagLength {
+		return "", fmt.Errorf("tag cannot exceed %d characters, got: %d", MaxTagLength, len(normalized))
+	}
+	for _, r := range normalized {
+		if !(r >= 'a' && r <= 'z') && !(r >= '0' && r <= '9') && r != '-' && r != '_' {
+			return "", fmt.Errorf("tag %q may only contain letters, digits, '-' and '_'", value)
+		}
+	}
+	return Tag(normalized), nil
+}
+
+// NewTags validates and normalizes a list of tags, dropping duplicates while keeping their order.
+func NewTags(values []string) ([]Tag, error) {
+	tags := make([]Tag, 0, len(values))
+	seen := make(map[Tag]bool, len(values))
+	for _, value := range values {
+		tag, err := NewTag(value)
+		if err != nil {
+			return nil, err
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+	if len(tags) > MaxTagsPerFeedback {
+		return nil, fmt.Errorf("feedback cannot have more than %d tags, got: %d", MaxTagsPerFeedback, len(tags))
+	}
+	return tags, nil
+}
+
+// String returns the string representation of the tag.
+func (t Tag) String() string {
+	return string(t)
+}
+
+// Platform is the kind of client the feedback was reported from.
+type Platform string
+
+// Known client platforms.
+const (
+	PlatformWeb     Platform = "web"
+	PlatformIOS     Platform = "ios"
+	PlatformAndroid Platform = "android"
+	PlatformDesktop Platform = "desktop"
+)
+
+// IsValid checks if the platform is one of the known platforms.
+func (p Platform) IsValid() bool {
+	switch p {
+	case PlatformWeb, PlatformIOS, PlatformAndroid, PlatformDesktop:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of the platform.
+func (p Platform) String() string {
+	return string(p)
+}
+
+// NewPlatform creates a new Platform value object with validation. The value is lowercased.
+func NewPlatform(value string) (Platform, error) {
+	platform := Platform(strings.ToLower(strings.TrimSpace(value)))
+	if !platform.IsValid() {
+		return "", fmt.Errorf("unknown platform: %q (must be one of web, ios, android, desktop)", value)
+	}
+	return platform, nil
+}
+
+const (
+	// MaxAppVersionLength is the maximum length of the app version of a feedback.
+	MaxAppVersionLength = 32
+	// MaxOSLength is the maximum length of the operating system of a feedback.
+	MaxOSLength = 64
+)
+
+// Metadata is the optional technical context of a feedback: the reporter's app version, platform and
+// operating system. Every field may be empty.
+type Metadata struct {
+	appVersion string
+	platform   Platform
+	os         string
+}
+
+// NewMetadata creates a new Metadata value object with validation. Values are trimmed; the app version
+// may only contain letters, digits, '.', '-' and '+', e.g. "2.4.1" or "2.5.0-beta+42".
+func NewMetadata(appVersion, platform, os string) (Metadata, error) {
+	appVersion = strings.TrimSpace(appVersion)
+	if len(appVersion) > MaxAppVersionLength {
+		return Metadata{}, fmt.Errorf(
+			"app version cannot exceed %d characters, got: %d", MaxAppVersionLength, len(appVersion),
+		)
+	}
+	for _, r := range appVersion {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') &&
+			r != '.' && r != '-' && r != '+' {
+			return Metadata{}, fmt.Errorf(
+				"app version %q may only contain letters, digits, '.', '-' and '+'", appVersion,
+			)
+		}
+	}
+
+	var p Platform
+	if strings.TrimSpace(platform) != "" {
+		var err error
+		if p, err = NewPlatform(platform); err != nil {
+			return Metadata{}, err
+		}
+	}
+
+	os = strings.TrimSpace(os)
+	if len(os) > MaxOSLength {
+		return Metadata{}, fmt.Errorf("os cannot exceed %d characters, got: %d", MaxOSLength, len(os))
+	}
+	for _, r := range os {
+		if r < ' ' || r == 0x7f {
+			return Metadata{}, fmt.Errorf("os may not contain control characters")
+		}
+	}
+
+	return Metadata{appVersion: appVersion, platform: p, os: os}, nil
+}
+
+// AppVersion returns the version of the app the feedback was reported from, or an empty string.
+func (m Metadata) AppVersion() string {
+	return m.appVersion
+}
+
+// Platform returns the platform the feedback was reported from, or an empty platform.
+func (m Metadata) Platform() Platform {
+	return m.platform
+}
+
+// OS returns the operating system the feedback was reported from, or an empty string.
+func (m Metadata) OS() string {
+	return m.os
+}
+
+// IsEmpty reports whether no metadata field is set.
+func (m Metadata) IsEmpty() bool {
+	return m == Metadata{}
+}
@@ -0,0 +1,312 @@
+package feedback
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func setRatingScale(t *testing.T, scale RatingScale) {
+	t.Helper()
+	if err := ConfigureRatingScale(scale); err != nil {
+		t.Fatalf("Failed to configure rating scale: %v", err)
+	}
+	t.Cleanup(
+		func() {
+			if err := ConfigureRatingScale(DefaultRatingScale); err != nil {
+				t.Fatalf("Failed to restore default rating scale: %v", err)
+			}
+		},
+	)
+}
+
+func TestNewRating_Boundaries(t *testing.T) {
+	tests := []struct {
+		name    string
+		scale   RatingScale
+		value   int
+		wantErr bool
+	}{
+		{name: "default scale below minimum", scale: DefaultRatingScale, value: 0, wantErr: true},
+		{name: "default scale minimum", scale: DefaultRatingScale, value: 1, wantErr: false},
+		{name: "default scale maximum", scale: DefaultRatingScale, value: 5, wantErr: false},
+		{name: "default scale above maximum", scale: DefaultRatingScale, value: 6, wantErr: true},
+		{name: "1-10 scale below minimum", scale: RatingScale{Min: 1, Max: 10}, value: 0, wantErr: true},
+		{name: "1-10 scale minimum", scale: RatingScale{Min: 1, Max: 10}, value: 1, wantErr: false},
+		{name: "1-10 scale maximum", scale: RatingScale{Min: 1, Max: 10}, value: 10, wantErr: false},
+		{name: "1-10 scale above maximum", scale: RatingScale{Min: 1, Max: 10}, value: 11, wantErr: true},
+		{name: "0-100 scale below minimum", scale: RatingScale{Min: 0, Max: 100}, value: -1, wantErr: true},
+		{name: "0-100 scale minimum", scale: RatingScale{Min: 0, Max: 100}, value: 0, wantErr: false},
+		{name: "0-100 scale maximum", scale: RatingScale{Min: 0, Max: 100}, value: 100, wantErr: false},
+		{name: "0-100 scale above maximum", scale: RatingScale{Min: 0, Max: 100}, value: 101, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				setRatingScale(t, tt.scale)
+
+				rating, err := NewRating(tt.value)
+				if tt.wantErr {
+					if err == nil {
+						t.Errorf("Expected error for rating %d on scale %s, got none", tt.value, tt.scale)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("Expected rating %d to be valid on scale %s, got error: %v", tt.value, tt.scale, err)
+				}
+				if rating.Value() != tt.value {
+					t.Errorf("Expected rating value %d, got %d", tt.value, rating.Value())
+				}
+			},
+		)
+	}
+}
+
+func TestBuilder_RatingOnConfiguredScale(t *testing.T) {
+	setRatingScale(t, RatingScale{Min: 1, Max: 10})
+
+	if _, err := NewBuilder().BuildNew(uuid.New(), 10, "Great!"); err != nil {
+		t.Errorf("Expected rating 10 to be valid on 1-10 scale, got error: %v", err)
+	}
+	if _, err := NewBuilder().BuildNew(uuid.New(), 11, "Great!"); err == nil {
+		t.Error("Expected rating 11 to be rejected on 1-10 scale")
+	}
+}
+
+func TestConfigureRatingScale_Invalid(t *testing.T) {
+	if err := ConfigureRatingScale(RatingScale{Min: 5, Max: 5}); err == nil {
+		t.Error("Expected error when minimum equals maximum")
+	}
+	if err := ConfigureRatingScale(RatingScale{Min: 10, Max: 1}); err == nil {
+		t.Error("Expected error when minimum is greater than maximum")
+	}
+	if scale := CurrentRatingScale(); scale != DefaultRatingScale {
+		t.Errorf("Expected rating scale to remain %s after invalid configuration, got %s", DefaultRatingScale, scale)
+	}
+}
+
+func TestBuilder_RatingOnly(t *testing.T) {
+	if _, err := NewBuilder().BuildNew(uuid.New(), 4, ""); err == nil {
+		t.Error("Expected empty comment to be rejected by default")
+	}
+
+	ConfigureRatingOnly(true)
+	t.Cleanup(func() { ConfigureRatingOnly(false) })
+
+	for _, comment := range []string{"", "   \n\t"} {
+		fb, err := NewBuilder().BuildNew(uuid.New(), 4, comment)
+		if err != nil {
+			t.Fatalf("Expected comment %q to be accepted for rating-only feedback, got error: %v", comment, err)
+		}
+		if !fb.IsRatingOnly() || fb.Comment().Value() != "" {
+			t.Errorf("Expected comment %q to be stored as rating-only, got %q", comment, fb.Comment().Value())
+		}
+	}
+
+	fb, err := NewBuilder().BuildNew(uuid.New(), 4, "Great!")
+	if err != nil {
+		t.Fatalf("Expected feedback with comment to be valid, got error: %v", err)
+	}
+	if fb.IsRatingOnly() {
+		t.Error("Expected feedback with comment not to be rating-only")
+	}
+}
+
+func setMinCommentLength(t *testing.T, length int) {
+	t.Helper()
+	if err := ConfigureMinCommentLength(length); err != nil {
+		t.Fatalf("Failed to configure minimum comment length: %v", err)
+	}
+	t.Cleanup(
+		func() {
+			if err := ConfigureMinCommentLength(MinCommentLength); err != nil {
+				t.Fatalf("Failed to restore default minimum comment length: %v", err)
+			}
+		},
+	)
+}
+
+func TestNewComment_MinLength(t *testing.T) {
+	tests := []struct {
+		name      string
+		minLength int
+		text      string
+		wantErr   bool
+	}{
+		{name: "default minimum empty", minLength: MinCommentLength, text: "", wantErr: true},
+		{name: "default minimum single character", minLength: MinCommentLength, text: "a", wantErr: false},
+		{name: "below configured minimum", minLength: 10, text: "too short", wantErr: true},
+		{name: "at configured minimum", minLength: 10, text: "just right", wantErr: false},
+		{name: "above configured minimum", minLength: 10, text: "long enough!", wantErr: false},
+		{name: "surrounding whitespace not counted", minLength: 10, text: "  too short  ", wantErr: true},
+		{name: "maximum as minimum", minLength: MaxCommentLength, text: strings.Repeat("a", MaxCommentLength)},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				setMinCommentLength(t, tt.minLength)
+
+				comment, err := NewComment(tt.text)
+				if tt.wantErr {
+					if err == nil {
+						t.Errorf("Expected error for comment %q with minimum length %d, got none", tt.text, tt.minLength)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("Expected comment %q to be valid with minimum length %d, got error: %v", tt.text, tt.minLength, err)
+				}
+				if comment.Value() != tt.text {
+					t.Errorf("Expected comment value %q, got %q", tt.text, comment.Value())
+				}
+			},
+		)
+	}
+}
+
+func TestConfigureMinCommentLength_Invalid(t *testing.T) {
+	for _, length := range []int{0, -1, MaxCommentLength + 1} {
+		if err := ConfigureMinCommentLength(length); err == nil {
+			t.Errorf("Expected error for minimum comment length %d", length)
+		}
+	}
+	if got := CurrentMinCommentLength(); got != MinCommentLength {
+		t.Errorf("Expected minimum comment length to stay %d, got %d", MinCommentLength, got)
+	}
+}
+
+func setInvalidUTF8Policy(t *testing.T, policy InvalidUTF8Policy) {
+	t.Helper()
+	if err := ConfigureInvalidUTF8Policy(policy); err != nil {
+		t.Fatalf("Failed to configure invalid UTF-8 policy: %v", err)
+	}
+	t.Cleanup(
+		func() {
+			if err := ConfigureInvalidUTF8Policy(InvalidUTF8Reject); err != nil {
+				t.Fatalf("Failed to restore default invalid UTF-8 policy: %v", err)
+			}
+		},
+	)
+}
+
+func TestNewComment_Encoding(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  InvalidUTF8Policy
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{name: "ascii", policy: InvalidUTF8Reject, text: "Great app", want: "Great app"},
+		{name: "multibyte", policy: InvalidUTF8Reject, text: "Très bien 👍 良い", want: "Très bien 👍 良い"},
+		{name: "decomposed to NFC", policy: InvalidUTF8Reject, text: "Cafe\u0301", want: "Caf\u00e9"},
+		{name: "newlines and tabs kept", policy: InvalidUTF8Reject, text: "line one\n\tline two", want: "line one\n\tline two"},
+		{name: "control characters removed", policy: InvalidUTF8Reject, text: "bad\x00\x07\x1b[31m\u0085app\r\n", want: "bad[31mapp\n"},
+		{name: "invalid byte rejected", policy: InvalidUTF8Reject, text: "bad \xff app", wantErr: true},
+		{name: "truncated sequence rejected", policy: InvalidUTF8Reject, text: "bad \xe2\x82", wantErr: true},
+		{name: "surrogate rejected", policy: InvalidUTF8Reject, text: "bad \xed\xa0\x80", wantErr: true},
+		{name: "invalid byte replaced", policy: InvalidUTF8Replace, text: "bad \xff app", want: "bad \ufffd app"},
+		{name: "invalid run replaced once", policy: InvalidUTF8Replace, text: "bad \xff\xfe\xfd app", want: "bad \ufffd app"},
+		{name: "only control characters", policy: InvalidUTF8Reject, text: "\x00\x01", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				setInvalidUTF8Policy(t, tt.policy)
+
+				comment, err := NewComment(tt.text)
+				if tt.wantErr {
+					if err == nil {
+						t.Errorf("Expected error for comment %q, got %q", tt.text, comment.Value())
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("Expected comment %q to be valid, got error: %v", tt.text, err)
+				}
+				if comment.Value() != tt.want {
+					t.Errorf("Expected comment value %q, got %q", tt.want, comment.Value())
+				}
+			},
+		)
+	}
+}
+
+func TestConfigureInvalidUTF8Policy_Invalid(t *testing.T) {
+	if err := ConfigureInvalidUTF8Policy("ignore"); err == nil {
+		t.Error("Expected error for unknown invalid UTF-8 policy")
+	}
+	if _, err := NewComment("bad \xff app"); err == nil {
+		t.Error("Expected invalid UTF-8 to stay rejected after invalid configuration")
+	}
+}
+
+func TestRestoreComment_IgnoresMinLength(t *testing.T) {
+	setMinCommentLength(t, 10)
+
+	if comment := RestoreComment("ok"); comment.Value() != "ok" {
+		t.Errorf("Expected stored comment to be restored as is, got %q", comment.Value())
+	}
+}
+
+func TestNewTags(t *testing.T) {
+	tags, err := NewTags([]string{" Bug ", "feature-request", "bug", "ui_2"})
+	if err != nil {
+		t.Fatalf("Expected tags to be valid, got error: %v", err)
+	}
+	expected := []Tag{"bug", "feature-request", "ui_2"}
+	if len(tags) != len(expected) {
+		t.Fatalf("Expected tags %v, got %v", expected, tags)
+	}
+	for i := range expected {
+		if tags[i] != expected[i] {
+			t.Errorf("Expected tag %d to be %q, got %q", i, expected[i], tags[i])
+		}
+	}
+
+	invalid := [][]string{
+		{""},
+		{"has space"},
+		{"emoji🙂"},
+		{strings.Repeat("a", MaxTagLength+1)},
+		{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"},
+	}
+	for _, values := range invalid {
+		if _, err := NewTags(values); err == nil {
+			t.Errorf("Expected tags %q to be rejected", values)
+		}
+	}
+}
+
+func TestNewMetadata(t *testing.T) {
+	metadata, err := NewMetadata(" 2.5.0-beta+42 ", "iOS", "iOS 17.2")
+	if err != nil {
+		t.Fatalf("Expected metadata to be valid, got error: %v", err)
+	}
+	if metadata.AppVersion() != "2.5.0-beta+42" || metadata.Platform() != PlatformIOS || metadata.OS() != "iOS 17.2" {
+		t.Errorf("Unexpected metadata: %+v", metadata)
+	}
+
+	empty, err := NewMetadata("", "", "")
+	if err != nil || !empty.IsEmpty() {
+		t.Errorf("Expected empty metadata to be valid, got %+v, %v", empty, err)
+	}
+
+	invalid := []struct{ appVersion, platform, os string }{
+		{appVersion: "2.5 beta"},
+		{appVersion: strings.Repeat("1", MaxAppVersionLength+1)},
+		{platform: "blackberry"},
+		{os: strings.Repeat("a", MaxOSLength+1)},
+		{os: "Linux\t6.1"},
+	}
+	for _, values := range invalid {
+		if _, err := NewMetadata(values.appVersion, values.platform, values.os); err == nil {
+			t.Errorf("Expected metadata %+v to be rejected", values)
+		}
+	}
+}
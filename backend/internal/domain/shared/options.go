@@ -0,0 +1,35 @@
+// Package shared holds the building blocks common to the domain packages.
+package shared
+
+import (
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
+)
+
+// BuilderOption configures optional builder dependencies.
+type BuilderOption func(*builderOptions)
+
+type builderOptions struct {
+	clock clock.Clock
+}
+
+// WithClock sets the clock used for timestamps of built entities.
+// Defaults to the system clock.
+func WithClock(c clock.Clock) BuilderOption {
+	return func(o *builderOptions) {
+		if c != nil {
+			o.clock = c
+		}
+	}
+}
+
+// ResolveClock returns the clock from the options, or the fallback if none was provided.
+func ResolveClock(fallback clock.Clock, opts []BuilderOption) clock.Clock {
+	o := &builderOptions{clock: fallback}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.clock == nil {
+		return clock.New()
+	}
+	return o.clock
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/internal/domain/shared"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
 )
 
@@ -17,8 +18,9 @@ type Builder struct {
 
 // NewBuilder creates a builder for creating new user entities.
 // Initialize with sensible defaults.
-func NewBuilder() *Builder {
-	now := time.Now().UTC()
+func NewBuilder(opts ...shared.BuilderOption) *Builder {
+	clk := shared.ResolveClock(nil, opts)
+	now := clk.Now().UTC()
 	return &Builder{
 		entity: &User{
 			id:        uuid.New(),
@@ -26,6 +28,7 @@ func NewBuilder() *Builder {
 			status:    UserStatusActive, // Default status
 			createdAt: now,
 			updatedAt: now,
+			clock:     clk,
 		},
 		validationErrors: make([]error, 0),
 	}
@@ -33,12 +36,13 @@ func NewBuilder() *Builder {
 
 // BuilderFromExisting creates a builder from an existing user entity.
 // Useful for update operations.
-func BuilderFromExisting(u *User) *Builder {
+func BuilderFromExisting(u *User, opts ...shared.BuilderOption) *Builder {
 	copied := *u
+	copied.clock = shared.ResolveClock(u.clock, opts)
 	// Deep copy the roles slice
 	copied.roles = make([]Role, len(u.roles))
 	copy(copied.roles, u.roles)
-	copied.updatedAt = time.Now().UTC()
+	copied.updatedAt = copied.clock.Now().UTC()
 
 	return &Builder{
 		entity:           &copied,
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/clock"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/optional"
 )
 
@@ -28,6 +29,7 @@ type User struct {
 	createdAt time.Time
 	updatedAt time.Time
 	deletedAt optional.Optional[time.Time]
+	clock     clock.Clock // Source of time for state changes
 }
 
 // IsValid validates the entire user entity state.
@@ -110,7 +112,7 @@ func (u *User) ChangePassword(newPasswordHash PasswordHash) error {
 	}
 
 	u.password = newPasswordHash
-	u.updatedAt = time.Now()
+	u.updatedAt = u.now()
 	return nil
 }
 
@@ -130,7 +132,7 @@ func (u *User) AddRole(role Role) error {
 	}
 
 	u.roles = append(u.roles, role)
-	u.updatedAt = time.Now()
+	u.updatedAt = u.now()
 	return nil
 }
 
@@ -154,7 +156,7 @@ func (u *User) RemoveRole(role Role) error {
 	}
 
 	u.roles = newRoles
-	u.updatedAt = time.Now()
+	u.updatedAt = u.now()
 	return nil
 }
 
@@ -174,7 +176,7 @@ func (u *User) ChangeStatus(newStatus UserStatus) error {
 	}
 
 	u.status = newStatus
-	u.updatedAt = time.Now()
+	u.updatedAt = u.now()
 	return nil
 }
 
@@ -194,7 +196,7 @@ func (u *User) Delete() error {
 		return fmt.Errorf("user is already deleted")
 	}
 
-	now := time.Now()
+	now := u.now()
 	u.deletedAt = optional.Some(now)
 	u.status = UserStatusInactive // Set status to inactive when deleted
 	u.updatedAt = now
@@ -209,6 +211,14 @@ func (u *User) Restore() error {
 
 	u.deletedAt = optional.None[time.Time]()
 	u.status = UserStatusActive // Restore to active status
-	u.updatedAt = time.Now()
+	u.updatedAt = u.now()
 	return nil
 }
+
+// now returns the current time from the entity clock, falling back to the system time.
+func (u *User) now() time.Time {
+	if u.clock == nil {
+		return time.Now()
+	}
+	return u.clock.Now()
+}
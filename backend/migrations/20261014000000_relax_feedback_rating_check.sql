@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The valid rating range is configurable and validated by the application
+ALTER TABLE feedback.feedbacks
+    DROP CONSTRAINT IF EXISTS feedbacks_rating_check;
+
+COMMENT ON COLUMN feedback.feedbacks.rating IS 'Rating value within the configured rating scale (1 to 5 stars by default)';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE feedback.feedbacks
+    ADD CONSTRAINT feedbacks_rating_check CHECK (rating >= 1 AND rating <= 5);
+
+COMMENT ON COLUMN feedback.feedbacks.rating IS 'Rating value from 1 to 5 stars';
+
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Add source column to feedbacks table
+ALTER TABLE feedback.feedbacks
+    ADD COLUMN IF NOT EXISTS source VARCHAR(20) NOT NULL DEFAULT 'web'
+        CONSTRAINT feedbacks_source_check CHECK (source IN ('web', 'mobile', 'api', 'email'));
+
+-- Add index for filtering feedbacks by source
+CREATE INDEX IF NOT EXISTS feedback_feedbacks_source_idx ON feedback.feedbacks (source);
+COMMENT ON INDEX feedback.feedback_feedbacks_source_idx IS 'Index for filtering feedbacks by submission source';
+
+COMMENT ON COLUMN feedback.feedbacks.source IS 'Channel the feedback was submitted through: web, mobile, api, or email';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS feedback.feedback_feedbacks_source_idx;
+ALTER TABLE feedback.feedbacks
+    DROP COLUMN IF EXISTS source;
+
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Flag analyses where the model completed successfully but identified no topics
+ALTER TABLE feedback.analyses
+    ADD COLUMN IF NOT EXISTS no_topics_identified BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN feedback.analyses.no_topics_identified IS 'True when the model completed successfully but identified no topics (as opposed to topic creation failing)';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE feedback.analyses
+    DROP COLUMN IF EXISTS no_topics_identified;
+
+-- +goose StatementEnd
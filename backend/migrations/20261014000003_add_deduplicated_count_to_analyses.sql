@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Track how many feedbacks were collapsed into a representative with an identical comment
+ALTER TABLE feedback.analyses
+    ADD COLUMN IF NOT EXISTS deduplicated_count INTEGER NOT NULL DEFAULT 0
+        CONSTRAINT analyses_deduplicated_count_check CHECK (deduplicated_count >= 0);
+
+COMMENT ON COLUMN feedback.analyses.deduplicated_count IS 'Number of feedbacks not sent to the LLM because another feedback in the analysis had an identical comment (included in feedback_count)';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE feedback.analyses
+    DROP COLUMN IF EXISTS deduplicated_count;
+
+-- +goose StatementEnd
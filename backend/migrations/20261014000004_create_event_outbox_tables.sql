@@ -0,0 +1,68 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Events waiting to be delivered to an external sink (transactional outbox)
+CREATE TABLE IF NOT EXISTS feedback.event_outbox
+(
+    id              UUID PRIMARY KEY,
+    event_id        UUID         NOT NULL,
+    event_type      VARCHAR(100) NOT NULL,
+    sink            VARCHAR(500) NOT NULL,
+    payload         JSONB        NOT NULL,
+    attempts        INTEGER      NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP    NOT NULL,
+    last_error      TEXT         NULL,
+    created_at      TIMESTAMP    NOT NULL DEFAULT NOW(),
+    CONSTRAINT event_outbox_event_sink_unique UNIQUE (event_id, sink),
+    CONSTRAINT event_outbox_attempts_check CHECK (attempts >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_outbox_next_attempt_at ON feedback.event_outbox (next_attempt_at);
+
+COMMENT ON TABLE feedback.event_outbox IS 'Events waiting to be delivered to an external sink, one row per event and sink';
+COMMENT ON COLUMN feedback.event_outbox.id IS 'Unique identifier for the outbox entry';
+COMMENT ON COLUMN feedback.event_outbox.event_id IS 'Identifier of the event, sent to the sink';
+COMMENT ON COLUMN feedback.event_outbox.event_type IS 'Type of the event (e.g., feedback.created)';
+COMMENT ON COLUMN feedback.event_outbox.sink IS 'Name of the sink the event is delivered to';
+COMMENT ON COLUMN feedback.event_outbox.payload IS 'JSON encoded event as delivered to the sink';
+COMMENT ON COLUMN feedback.event_outbox.attempts IS 'Number of failed delivery attempts so far';
+COMMENT ON COLUMN feedback.event_outbox.next_attempt_at IS 'Earliest time of the next delivery attempt';
+COMMENT ON COLUMN feedback.event_outbox.last_error IS 'Error of the last failed delivery attempt';
+COMMENT ON COLUMN feedback.event_outbox.created_at IS 'Timestamp when the event was stored in the outbox';
+
+-- Events that could not be delivered within the maximum number of attempts
+CREATE TABLE IF NOT EXISTS feedback.event_dead_letters
+(
+    id         UUID PRIMARY KEY,
+    event_id   UUID         NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    sink       VARCHAR(500) NOT NULL,
+    payload    JSONB        NOT NULL,
+    attempts   INTEGER      NOT NULL,
+    last_error TEXT         NOT NULL,
+    created_at TIMESTAMP    NOT NULL,
+    failed_at  TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_dead_letters_failed_at ON feedback.event_dead_letters (failed_at DESC);
+
+COMMENT ON TABLE feedback.event_dead_letters IS 'Events that permanently failed to be delivered to a sink';
+COMMENT ON COLUMN feedback.event_dead_letters.id IS 'Identifier of the outbox entry that failed';
+COMMENT ON COLUMN feedback.event_dead_letters.event_id IS 'Identifier of the event';
+COMMENT ON COLUMN feedback.event_dead_letters.event_type IS 'Type of the event (e.g., feedback.created)';
+COMMENT ON COLUMN feedback.event_dead_letters.sink IS 'Name of the sink the event could not be delivered to';
+COMMENT ON COLUMN feedback.event_dead_letters.payload IS 'JSON encoded event';
+COMMENT ON COLUMN feedback.event_dead_letters.attempts IS 'Number of failed delivery attempts';
+COMMENT ON COLUMN feedback.event_dead_letters.last_error IS 'Error of the last delivery attempt';
+COMMENT ON COLUMN feedback.event_dead_letters.created_at IS 'Timestamp when the event was stored in the outbox';
+COMMENT ON COLUMN feedback.event_dead_letters.failed_at IS 'Timestamp when the event was moved to the dead-letter table';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS feedback.event_dead_letters;
+DROP TABLE IF EXISTS feedback.event_outbox;
+
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Add tags column to feedbacks table
+ALTER TABLE feedback.feedbacks
+    ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}'
+        CONSTRAINT feedbacks_tags_count_check CHECK (cardinality(tags) <= 10);
+
+-- Add index for filtering feedbacks by tag
+CREATE INDEX IF NOT EXISTS feedback_feedbacks_tags_idx ON feedback.feedbacks USING GIN (tags);
+COMMENT ON INDEX feedback.feedback_feedbacks_tags_idx IS 'Index for filtering feedbacks by submitter tag';
+
+COMMENT ON COLUMN feedback.feedbacks.tags IS 'Lowercase labels attached by the submitter (e.g., ["bug", "feature-request"])';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS feedback.feedback_feedbacks_tags_idx;
+ALTER TABLE feedback.feedbacks
+    DROP COLUMN IF EXISTS tags;
+
+-- +goose StatementEnd
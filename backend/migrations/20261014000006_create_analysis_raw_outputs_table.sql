@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Raw model outputs of analyses, stored on an opt-in basis for debugging
+CREATE TABLE IF NOT EXISTS feedback.analysis_raw_outputs
+(
+    analysis_id UUID PRIMARY KEY REFERENCES feedback.analyses (id) ON DELETE CASCADE,
+    output      TEXT      NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE feedback.analysis_raw_outputs IS 'Raw LLM output of analyses, kept separately since it can be large';
+COMMENT ON COLUMN feedback.analysis_raw_outputs.analysis_id IS 'Reference to the analysis';
+COMMENT ON COLUMN feedback.analysis_raw_outputs.output IS 'Output text as returned by the model, with PII masked';
+COMMENT ON COLUMN feedback.analysis_raw_outputs.created_at IS 'Timestamp when the output was stored';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS feedback.analysis_raw_outputs;
+
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Record what period_start and period_end mean, so that trends mixing both semantics can be told apart
+ALTER TABLE feedback.analyses
+    ADD COLUMN IF NOT EXISTS period_semantics VARCHAR(20) NOT NULL DEFAULT 'feedback_span'
+        CONSTRAINT analyses_period_semantics_check CHECK (period_semantics IN ('feedback_span', 'analysis_window'));
+
+COMMENT ON COLUMN feedback.analyses.period_semantics IS 'Meaning of the period: feedback_span (creation times of the analyzed feedbacks) or analysis_window (fixed window ending when the analysis ran)';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE feedback.analyses
+    DROP COLUMN IF EXISTS period_semantics;
+
+-- +goose StatementEnd
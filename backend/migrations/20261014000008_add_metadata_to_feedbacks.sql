@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Add metadata column to feedbacks table
+ALTER TABLE feedback.feedbacks
+    ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}'
+        CONSTRAINT feedbacks_metadata_object_check CHECK (jsonb_typeof(metadata) = 'object');
+
+-- Add indexes for filtering feedbacks by platform and app version
+CREATE INDEX IF NOT EXISTS feedback_feedbacks_metadata_platform_idx
+    ON feedback.feedbacks ((metadata ->> 'platform'));
+COMMENT ON INDEX feedback.feedback_feedbacks_metadata_platform_idx IS 'Index for filtering feedbacks by reporter platform';
+CREATE INDEX IF NOT EXISTS feedback_feedbacks_metadata_app_version_idx
+    ON feedback.feedbacks ((metadata ->> 'app_version'));
+COMMENT ON INDEX feedback.feedback_feedbacks_metadata_app_version_idx IS 'Index for filtering feedbacks by reporter app version';
+
+COMMENT ON COLUMN feedback.feedbacks.metadata IS 'Technical context of the reporter (e.g., {"app_version": "2.4.1", "platform": "ios", "os": "iOS 17.2"})';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS feedback.feedback_feedbacks_metadata_app_version_idx;
+DROP INDEX IF EXISTS feedback.feedback_feedbacks_metadata_platform_idx;
+ALTER TABLE feedback.feedbacks
+    DROP COLUMN IF EXISTS metadata;
+
+-- +goose StatementEnd
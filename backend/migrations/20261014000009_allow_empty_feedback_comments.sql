@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Rating-only feedback is stored with an empty comment when enabled by the application
+ALTER TABLE feedback.feedbacks
+    DROP CONSTRAINT IF EXISTS feedbacks_comment_check;
+
+ALTER TABLE feedback.feedbacks
+    ADD CONSTRAINT feedbacks_comment_check CHECK (LENGTH(comment) <= 1000);
+
+COMMENT ON COLUMN feedback.feedbacks.comment IS 'Free-text feedback comment (up to 1000 characters, empty for rating-only feedback)';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE feedback.feedbacks
+    DROP CONSTRAINT IF EXISTS feedbacks_comment_check;
+
+ALTER TABLE feedback.feedbacks
+    ADD CONSTRAINT feedbacks_comment_check CHECK (LENGTH(comment) >= 1 AND LENGTH(comment) <= 1000);
+
+COMMENT ON COLUMN feedback.feedbacks.comment IS 'Free-text feedback comment (1-1000 characters)';
+
+-- +goose StatementEnd
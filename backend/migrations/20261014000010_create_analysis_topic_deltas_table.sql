@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Per-topic feedback count changes of an analysis versus its previous analysis, stored when the analysis completes
+CREATE TABLE IF NOT EXISTS feedback.analysis_topic_deltas
+(
+    analysis_id    UUID                NOT NULL REFERENCES feedback.analyses (id) ON DELETE CASCADE,
+    topic_enum     feedback.topic_enum NOT NULL,
+    previous_count INTEGER             NOT NULL CHECK (previous_count >= 0),
+    current_count  INTEGER             NOT NULL CHECK (current_count >= 0),
+    created_at     TIMESTAMP           NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (analysis_id, topic_enum)
+);
+
+COMMENT ON TABLE feedback.analysis_topic_deltas IS 'Per-topic feedback count changes versus the previous analysis';
+COMMENT ON COLUMN feedback.analysis_topic_deltas.analysis_id IS 'Reference to the analysis';
+COMMENT ON COLUMN feedback.analysis_topic_deltas.topic_enum IS 'Predefined topic enum value';
+COMMENT ON COLUMN feedback.analysis_topic_deltas.previous_count IS 'Number of feedbacks of the topic in the previous analysis (0 if not identified)';
+COMMENT ON COLUMN feedback.analysis_topic_deltas.current_count IS 'Number of feedbacks of the topic in this analysis (0 if not identified anymore)';
+COMMENT ON COLUMN feedback.analysis_topic_deltas.created_at IS 'Timestamp when the delta was stored';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS feedback.analysis_topic_deltas;
+
+-- +goose StatementEnd
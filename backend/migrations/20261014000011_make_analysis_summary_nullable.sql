@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Analyses have no summary until the LLM result is stored, instead of a placeholder text
+ALTER TABLE feedback.analyses
+    ALTER COLUMN overall_summary DROP NOT NULL;
+
+UPDATE feedback.analyses
+SET overall_summary = NULL
+WHERE overall_summary = 'Processing...'
+  AND status <> 'success';
+
+COMMENT ON COLUMN feedback.analyses.overall_summary IS 'Human-readable summary of all feedback in this analysis, null until the analysis succeeded';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+UPDATE feedback.analyses
+SET overall_summary = 'Processing...'
+WHERE overall_summary IS NULL;
+
+ALTER TABLE feedback.analyses
+    ALTER COLUMN overall_summary SET NOT NULL;
+
+COMMENT ON COLUMN feedback.analyses.overall_summary IS 'Human-readable summary of all feedback in this analysis';
+
+-- +goose StatementEnd
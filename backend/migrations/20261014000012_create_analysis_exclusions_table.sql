@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Feedbacks stored but deliberately kept out of analysis, e.g. because their comment is in a language that is not accepted
+CREATE TABLE IF NOT EXISTS feedback.analysis_exclusions
+(
+    feedback_id UUID PRIMARY KEY REFERENCES feedback.feedbacks (id) ON DELETE CASCADE,
+    reason      TEXT      NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE feedback.analysis_exclusions IS 'Feedbacks excluded from analysis at ingestion, never sent to the LLM';
+COMMENT ON COLUMN feedback.analysis_exclusions.feedback_id IS 'Reference to the excluded feedback';
+COMMENT ON COLUMN feedback.analysis_exclusions.reason IS 'Why the feedback was excluded';
+COMMENT ON COLUMN feedback.analysis_exclusions.created_at IS 'Timestamp when the feedback was excluded';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS feedback.analysis_exclusions;
+
+-- +goose StatementEnd
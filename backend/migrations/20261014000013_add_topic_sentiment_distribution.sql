@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Number of a topic's feedbacks per sentiment, null for topics the model only labeled with a sentiment
+ALTER TABLE feedback.analysis_topics
+    ADD COLUMN positive_count INTEGER CHECK (positive_count >= 0),
+    ADD COLUMN mixed_count    INTEGER CHECK (mixed_count >= 0),
+    ADD COLUMN negative_count INTEGER CHECK (negative_count >= 0),
+    ADD CONSTRAINT analysis_topics_sentiment_distribution_complete CHECK (
+        (positive_count IS NULL) = (mixed_count IS NULL) AND (mixed_count IS NULL) = (negative_count IS NULL)
+    );
+
+COMMENT ON COLUMN feedback.analysis_topics.positive_count IS 'Number of positive feedbacks of this topic (null if not counted)';
+COMMENT ON COLUMN feedback.analysis_topics.mixed_count IS 'Number of mixed feedbacks of this topic (null if not counted)';
+COMMENT ON COLUMN feedback.analysis_topics.negative_count IS 'Number of negative feedbacks of this topic (null if not counted)';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE feedback.analysis_topics
+    DROP CONSTRAINT IF EXISTS analysis_topics_sentiment_distribution_complete,
+    DROP COLUMN IF EXISTS positive_count,
+    DROP COLUMN IF EXISTS mixed_count,
+    DROP COLUMN IF EXISTS negative_count;
+
+-- +goose StatementEnd
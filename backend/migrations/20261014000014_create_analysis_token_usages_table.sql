@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Estimated and actual token usage of analyses, recorded on an opt-in basis to calibrate the token estimator
+CREATE TABLE IF NOT EXISTS feedback.analysis_token_usages
+(
+    analysis_id      UUID PRIMARY KEY REFERENCES feedback.analyses (id) ON DELETE CASCADE,
+    estimated_tokens INTEGER   NOT NULL CHECK (estimated_tokens >= 0),
+    input_tokens     INTEGER   NOT NULL CHECK (input_tokens >= 0),
+    output_tokens    INTEGER   NOT NULL CHECK (output_tokens >= 0),
+    total_tokens     INTEGER   NOT NULL CHECK (total_tokens >= 0),
+    created_at       TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE feedback.analysis_token_usages IS 'Estimated versus actual token usage of analyses, for estimator calibration';
+COMMENT ON COLUMN feedback.analysis_token_usages.analysis_id IS 'Reference to the analysis';
+COMMENT ON COLUMN feedback.analysis_token_usages.estimated_tokens IS 'Total tokens estimated before the LLM request';
+COMMENT ON COLUMN feedback.analysis_token_usages.input_tokens IS 'Input tokens reported by the provider';
+COMMENT ON COLUMN feedback.analysis_token_usages.output_tokens IS 'Output tokens reported by the provider';
+COMMENT ON COLUMN feedback.analysis_token_usages.total_tokens IS 'Total tokens reported by the provider';
+COMMENT ON COLUMN feedback.analysis_token_usages.created_at IS 'Timestamp when the usage was recorded';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS feedback.analysis_token_usages;
+
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Concrete actions the model recommends per topic, empty if recommendations are disabled or none were returned
+ALTER TABLE feedback.analysis_topics
+    ADD COLUMN recommendations TEXT[] NOT NULL DEFAULT '{}';
+
+COMMENT ON COLUMN feedback.analysis_topics.recommendations IS 'Array of recommended actions for this topic';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE feedback.analysis_topics
+    DROP COLUMN IF EXISTS recommendations;
+
+-- +goose StatementEnd
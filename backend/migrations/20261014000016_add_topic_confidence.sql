@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- How confident the model is in a topic assignment, null if confidence was not requested
+ALTER TABLE feedback.analysis_topics
+    ADD COLUMN confidence DOUBLE PRECISION CHECK (confidence BETWEEN 0 AND 1);
+
+COMMENT ON COLUMN feedback.analysis_topics.confidence IS 'Confidence of the model in this topic assignment between 0 and 1 (null if not requested)';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE feedback.analysis_topics
+    DROP COLUMN IF EXISTS confidence;
+
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Per-topic statistics of the latest analysis, materialized so that reading them does not recompute
+-- the average rating feedback by feedback
+CREATE TABLE IF NOT EXISTS feedback.topic_stats
+(
+    topic_enum     feedback.topic_enum PRIMARY KEY,
+    analysis_id    UUID                NOT NULL REFERENCES feedback.analyses (id) ON DELETE CASCADE,
+    feedback_count INTEGER             NOT NULL CHECK (feedback_count >= 0),
+    average_rating DOUBLE PRECISION    NOT NULL,
+    computed_at    TIMESTAMP           NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE feedback.topic_stats IS 'Materialized per-topic statistics of the latest analysis';
+COMMENT ON COLUMN feedback.topic_stats.topic_enum IS 'Topic the statistics are computed for';
+COMMENT ON COLUMN feedback.topic_stats.analysis_id IS 'Reference to the analysis the statistics are computed from';
+COMMENT ON COLUMN feedback.topic_stats.feedback_count IS 'Number of non-deleted feedbacks assigned to the topic';
+COMMENT ON COLUMN feedback.topic_stats.average_rating IS 'Average rating of the non-deleted feedbacks assigned to the topic (0 if none)';
+COMMENT ON COLUMN feedback.topic_stats.computed_at IS 'When the statistics were last computed';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS feedback.topic_stats;
+
+-- +goose StatementEnd
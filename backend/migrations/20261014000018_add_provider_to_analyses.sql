@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Record the provider next to the model, so that analyses stay attributable once several providers are supported
+ALTER TABLE feedback.analyses
+    ADD COLUMN IF NOT EXISTS provider VARCHAR(50);
+
+COMMENT ON COLUMN feedback.analyses.provider IS 'Provider the model was called through (e.g., openai), null for analyses recorded before providers were tracked';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE feedback.analyses
+    DROP COLUMN IF EXISTS provider;
+
+-- +goose StatementEnd
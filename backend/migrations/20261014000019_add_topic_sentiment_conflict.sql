@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Whether the sentiment the model assigned to a single-feedback topic contradicted the rating of the feedback
+ALTER TABLE feedback.analysis_topics
+    ADD COLUMN sentiment_conflict BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN feedback.analysis_topics.sentiment_conflict IS 'True when the model sentiment of a single-feedback topic contradicted the feedback rating (the sentiment holds the rating sentiment if overridden)';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE feedback.analysis_topics
+    DROP COLUMN IF EXISTS sentiment_conflict;
+
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Feedbacks isolated as the cause of invalid model output, excluded from further analyses
+CREATE TABLE IF NOT EXISTS feedback.analysis_dead_letters
+(
+    feedback_id UUID PRIMARY KEY REFERENCES feedback.feedbacks (id) ON DELETE CASCADE,
+    analysis_id UUID      NULL REFERENCES feedback.analyses (id) ON DELETE SET NULL,
+    reason      TEXT      NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE feedback.analysis_dead_letters IS 'Feedbacks isolated as the cause of invalid model output, excluded from further analyses';
+COMMENT ON COLUMN feedback.analysis_dead_letters.feedback_id IS 'Reference to the feedback that makes the model return invalid output';
+COMMENT ON COLUMN feedback.analysis_dead_letters.analysis_id IS 'Reference to the failed analysis the feedback was isolated from';
+COMMENT ON COLUMN feedback.analysis_dead_letters.reason IS 'Why the feedback was dead-lettered';
+COMMENT ON COLUMN feedback.analysis_dead_letters.created_at IS 'Timestamp when the feedback was dead-lettered';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS feedback.analysis_dead_letters;
+
+-- +goose StatementEnd
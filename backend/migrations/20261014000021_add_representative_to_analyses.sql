@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Flag analyses over too few feedbacks to draw conclusions from, so that dashboards can skip them
+ALTER TABLE feedback.analyses
+    ADD COLUMN IF NOT EXISTS representative BOOLEAN NOT NULL DEFAULT TRUE;
+
+COMMENT ON COLUMN feedback.analyses.representative IS 'Whether the analysis covered at least the configured minimum of feedbacks to be considered representative';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE feedback.analyses
+    DROP COLUMN IF EXISTS representative;
+
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Lifecycle events of analyses, a durable timeline next to traces that may be sampled away
+CREATE TABLE IF NOT EXISTS feedback.analysis_events
+(
+    id          BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    analysis_id UUID        NOT NULL REFERENCES feedback.analyses (id) ON DELETE CASCADE,
+    event_type  VARCHAR(50) NOT NULL,
+    duration_ms BIGINT      NULL,
+    details     JSONB       NOT NULL DEFAULT '{}'
+        CONSTRAINT analysis_events_details_object_check CHECK (jsonb_typeof(details) = 'object'),
+    occurred_at TIMESTAMP   NOT NULL,
+    CONSTRAINT analysis_events_duration_ms_check CHECK (duration_ms IS NULL OR duration_ms >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_analysis_events_analysis_id ON feedback.analysis_events (analysis_id, occurred_at);
+
+COMMENT ON TABLE feedback.analysis_events IS 'Lifecycle events of analyses, written as the analysis progresses';
+COMMENT ON COLUMN feedback.analysis_events.id IS 'Insertion order of the event, breaking ties between events of the same time';
+COMMENT ON COLUMN feedback.analysis_events.analysis_id IS 'Reference to the analysis';
+COMMENT ON COLUMN feedback.analysis_events.event_type IS 'Lifecycle step (e.g., created, llm_called, completed)';
+COMMENT ON COLUMN feedback.analysis_events.duration_ms IS 'Time the step took in milliseconds, for steps that measure one';
+COMMENT ON COLUMN feedback.analysis_events.details IS 'Step specific information, e.g. the topic count or the failure reason';
+COMMENT ON COLUMN feedback.analysis_events.occurred_at IS 'Timestamp when the step happened';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS feedback.analysis_events;
+
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Keep the translation next to the original comment, the original is never overwritten
+ALTER TABLE feedback.feedbacks
+    ADD COLUMN IF NOT EXISTS translated_comment TEXT NULL,
+    ADD COLUMN IF NOT EXISTS comment_language VARCHAR(16) NULL;
+
+COMMENT ON COLUMN feedback.feedbacks.translated_comment IS 'Comment translated into the analysis language (NULL if it was not translated)';
+COMMENT ON COLUMN feedback.feedbacks.comment_language IS 'Detected ISO 639-1 language of the comment (NULL if translation is disabled)';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE feedback.feedbacks
+    DROP COLUMN IF EXISTS comment_language,
+    DROP COLUMN IF EXISTS translated_comment;
+
+-- +goose StatementEnd
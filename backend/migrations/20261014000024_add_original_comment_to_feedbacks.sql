@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Comments are stored with their markup stripped, the submitted text can be kept next to them
+ALTER TABLE feedback.feedbacks
+    ADD COLUMN IF NOT EXISTS original_comment TEXT NULL;
+
+COMMENT ON COLUMN feedback.feedbacks.original_comment IS 'Comment as submitted, before markup was stripped (NULL if it had no markup or was not preserved)';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE feedback.feedbacks
+    DROP COLUMN IF EXISTS original_comment;
+
+-- +goose StatementEnd
@@ -10,12 +10,15 @@ import (
 type ErrorCategory string
 
 const (
-	CategoryValidation   ErrorCategory = "ValidationError"
-	CategoryNotFound     ErrorCategory = "NotFoundError"
-	CategoryConflict     ErrorCategory = "ConflictError"
-	CategoryInternal     ErrorCategory = "InternalError"
-	CategoryUnauthorized ErrorCategory = "UnauthorizedError"
-	CategoryForbidden    ErrorCategory = "ForbiddenError"
+	CategoryValidation    ErrorCategory = "ValidationError"
+	CategoryNotFound      ErrorCategory = "NotFoundError"
+	CategoryConflict      ErrorCategory = "ConflictError"
+	CategoryInternal      ErrorCategory = "InternalError"
+	CategoryUnauthorized  ErrorCategory = "UnauthorizedError"
+	CategoryForbidden     ErrorCategory = "ForbiddenError"
+	CategoryRateLimited   ErrorCategory = "RateLimitedError"
+	CategoryTimeout       ErrorCategory = "TimeoutError"
+	CategoryUnprocessable ErrorCategory = "UnprocessableError"
 )
 
 func (c ErrorCategory) HTTPCode() int {
@@ -30,6 +33,12 @@ func (c ErrorCategory) HTTPCode() int {
 		return 401 // Unauthorized - Authentication failed
 	case CategoryForbidden:
 		return 403 // Forbidden - Authorization failed (authenticated but lacks permission)
+	case CategoryRateLimited:
+		return 429 // Too Many Requests
+	case CategoryTimeout:
+		return 504 // Gateway Timeout - a dependency such as the database did not answer in time
+	case CategoryUnprocessable:
+		return 422 // Unprocessable Entity
 	case CategoryInternal:
 		return 500 // Internal Server Error
 	default:
@@ -85,4 +94,16 @@ var (
 		Code:     "forbidden",
 		Category: CategoryForbidden,
 	}
+	ErrorCodeTooManyRequests = &ErrorCode{
+		Code:     "too_many_requests",
+		Category: CategoryRateLimited,
+	}
+	ErrorCodeTimeout = &ErrorCode{
+		Code:     "timeout",
+		Category: CategoryTimeout,
+	}
+	ErrorCodeUnprocessable = &ErrorCode{
+		Code:     "unprocessable_entity",
+		Category: CategoryUnprocessable,
+	}
 )
@@ -8,6 +8,13 @@ func WithCauseError(cause error) ErrorOpt {
 	}
 }
 
+// WithDetails attaches structured information for the client, e.g. the invalid fields of a request.
+func WithDetails(details map[string]any) ErrorOpt {
+	return func(e *GenericError) {
+		e.Details = details
+	}
+}
+
 func ErrInternal(err error) ApplicationError {
 	return &GenericError{
 		Code:    ErrorCodeInternal,
@@ -67,3 +74,42 @@ func ErrForbidden(msg string, opts ...ErrorOpt) ApplicationError {
 
 	return ge
 }
+
+func ErrTooManyRequests(msg string, opts ...ErrorOpt) ApplicationError {
+	ge := &GenericError{
+		Code:       ErrorCodeTooManyRequests,
+		Message:    "Too many requests: " + msg,
+		UserFacing: true,
+	}
+	for _, opt := range opts {
+		opt(ge)
+	}
+
+	return ge
+}
+
+func ErrTimeout(msg string, opts ...ErrorOpt) ApplicationError {
+	ge := &GenericError{
+		Code:       ErrorCodeTimeout,
+		Message:    "Timeout: " + msg,
+		UserFacing: true,
+	}
+	for _, opt := range opts {
+		opt(ge)
+	}
+
+	return ge
+}
+
+func ErrUnprocessable(msg string, opts ...ErrorOpt) ApplicationError {
+	ge := &GenericError{
+		Code:       ErrorCodeUnprocessable,
+		Message:    "Unprocessable entity: " + msg,
+		UserFacing: true,
+	}
+	for _, opt := range opts {
+		opt(ge)
+	}
+
+	return ge
+}
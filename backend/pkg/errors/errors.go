@@ -6,10 +6,11 @@ type ApplicationError interface {
 	error
 	httpResponse
 
-	ErrCode() *ErrorCode // Code represents the unique error code.
-	ErrMessage() string  // Message provides a human-readable description of the error.
-	ErrCause() error     // Cause holds the underlying error, if any.
-	IsUserFacing() bool  // IsUserFacing indicates if the error message is safe to show to end-users.
+	ErrCode() *ErrorCode        // Code represents the unique error code.
+	ErrMessage() string         // Message provides a human-readable description of the error.
+	ErrCause() error            // Cause holds the underlying error, if any.
+	ErrDetails() map[string]any // Details holds additional structured information for the client, if any.
+	IsUserFacing() bool         // IsUserFacing indicates if the error message is safe to show to end-users.
 }
 
 type httpResponse interface {
@@ -21,10 +22,11 @@ var _ ApplicationError = (*GenericError)(nil)
 
 // GenericError is a generic implementation of ApplicationError.
 type GenericError struct {
-	Code       *ErrorCode `json:"code"`
-	Message    string     `json:"message"`
-	Cause      error      `json:"-"`
-	UserFacing bool       `json:"-"`
+	Code       *ErrorCode     `json:"code"`
+	Message    string         `json:"message"`
+	Details    map[string]any `json:"details,omitempty"`
+	Cause      error          `json:"-"`
+	UserFacing bool           `json:"-"`
 }
 
 func (e *GenericError) Error() string {
@@ -47,6 +49,10 @@ func (e *GenericError) ErrCause() error {
 	return e.Cause
 }
 
+func (e *GenericError) ErrDetails() map[string]any {
+	return e.Details
+}
+
 func (e *GenericError) IsUserFacing() bool {
 	return e.UserFacing
 }
@@ -54,5 +60,8 @@ func (e *GenericError) IsUserFacing() bool {
 func (*GenericError) IsResponse() {}
 
 func (e *GenericError) HTTPCode() int {
+	if e.Code == nil {
+		return CategoryInternal.HTTPCode()
+	}
 	return e.Code.Category.HTTPCode()
 }
@@ -0,0 +1,18 @@
+// Package requestid carries the ID of the request being served through the context,
+// so that it can be forwarded to downstream services.
+package requestid
+
+import "context"
+
+type contextKey struct{}
+
+// NewContext returns a copy of the context carrying the request ID.
+func NewContext(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, contextKey{}, requestID)
+}
+
+// FromContext returns the request ID carried by the context, or an empty string if there is none.
+func FromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(contextKey{}).(string)
+	return requestID
+}
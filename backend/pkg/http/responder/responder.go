@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"net/http"
 
+	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/log"
 )
 
+// RequestIDHeader is the response header carrying the request ID, copied into error responses.
+const RequestIDHeader = "X-Request-ID"
+
 type RestResponder interface {
 	RespondContent(resp http.ResponseWriter, data Response, opts ...ResponseOption)
 }
@@ -24,6 +28,8 @@ func NewRestResponder(logger log.Logger) RestResponder {
 	return &responder{logger: logger.NewGroup("http_responder")}
 }
 
+// RespondContent writes data as JSON. Application errors are written as an ErrorResponse
+// with the HTTP status of their error category.
 func (r *responder) RespondContent(resp http.ResponseWriter, data Response, opts ...ResponseOption) {
 	resp.Header().Set("Content-Type", "application/json")
 	if data == nil {
@@ -32,6 +38,13 @@ func (r *responder) RespondContent(resp http.ResponseWriter, data Response, opts
 		return
 	}
 
+	if appErr, ok := data.(ce.ApplicationError); ok {
+		data = errorResponseFromApplicationError(appErr)
+	}
+	if errResp, ok := data.(*ErrorResponse); ok && errResp.RequestID == "" {
+		errResp.RequestID = resp.Header().Get(RequestIDHeader)
+	}
+
 	respOpts := &respOpts{
 		statusCode: data.HTTPCode(),
 	}
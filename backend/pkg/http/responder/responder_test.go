@@ -0,0 +1,95 @@
+package responder
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/log"
+)
+
+func TestResponder_RespondContent_ErrorEnvelope(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        ce.ApplicationError
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "bad request", err: ce.ErrBadRequest("invalid id"), wantStatus: http.StatusBadRequest, wantCode: "bad_request"},
+		{
+			name:       "unauthorized",
+			err:        ce.ErrUnauthorized("invalid token"),
+			wantStatus: http.StatusUnauthorized,
+			wantCode:   "unauthorized",
+		},
+		{name: "forbidden", err: ce.ErrForbidden("admin only"), wantStatus: http.StatusForbidden, wantCode: "forbidden"},
+		{name: "not found", err: ce.ErrNotFound, wantStatus: http.StatusNotFound, wantCode: "not_found"},
+		{
+			name: "conflict",
+			err: &ce.GenericError{
+				Code:    ce.NewDomainErrorCode("already_exists", ce.CategoryConflict),
+				Message: "Already exists",
+			},
+			wantStatus: http.StatusConflict,
+			wantCode:   "already_exists",
+		},
+		{name: "internal", err: ce.ErrInternal(nil), wantStatus: http.StatusInternalServerError, wantCode: "internal_error"},
+		{
+			name:       "missing code",
+			err:        &ce.GenericError{Message: "Something failed"},
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   "internal_error",
+		},
+	}
+
+	r := NewRestResponder(log.NewLogger("development"))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			rec.Header().Set(RequestIDHeader, "req-1")
+
+			r.RespondContent(rec, tt.err)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+
+			var body map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Failed to decode body: %v", err)
+			}
+			if body["code"] != tt.wantCode {
+				t.Errorf("Expected code %q, got %v", tt.wantCode, body["code"])
+			}
+			if body["message"] != tt.err.ErrMessage() {
+				t.Errorf("Expected message %q, got %v", tt.err.ErrMessage(), body["message"])
+			}
+			if body["request_id"] != "req-1" {
+				t.Errorf("Expected request_id req-1, got %v", body["request_id"])
+			}
+			if _, ok := body["details"]; ok {
+				t.Errorf("Expected details to be omitted, got %v", body["details"])
+			}
+		})
+	}
+}
+
+func TestResponder_RespondContent_ErrorDetails(t *testing.T) {
+	r := NewRestResponder(log.NewLogger("development"))
+	rec := httptest.NewRecorder()
+
+	r.RespondContent(rec, ce.ErrBadRequest("invalid ids", ce.WithDetails(map[string]any{"ids": []string{"a"}})))
+
+	var body ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Failed to decode body: %v", err)
+	}
+	if _, ok := body.Details["ids"]; !ok {
+		t.Errorf("Expected details to contain ids, got %v", body.Details)
+	}
+	if body.RequestID != "" {
+		t.Errorf("Expected no request_id without the header, got %q", body.RequestID)
+	}
+}
@@ -2,25 +2,50 @@ package responder
 
 import (
 	"encoding/json"
+
+	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
 )
 
+// ErrorResponse is the envelope of every error response.
 type ErrorResponse struct {
-	Status  string `json:"status"`
-	Code    int    `json:"code"`
-	Message string `json:"message"`
+	// Code is the machine-readable error code, e.g. not_found or token_expired.
+	Code string `json:"code" example:"not_found"`
+	// Message is a human-readable description of the error.
+	Message string `json:"message" example:"Analysis not found"`
+	// Details carries additional structured information about the error, if any.
+	Details map[string]any `json:"details,omitempty" swaggertype:"object"`
+	// RequestID identifies the request in the server logs, also sent in the X-Request-ID header.
+	RequestID  string `json:"request_id,omitempty" example:"1f0e3b8c-5c4e-4a43-9d59-0f4a0f7f1f5e"`
+	statusCode int
 }
 
 func (*ErrorResponse) IsResponse() {}
 
 func (r *ErrorResponse) HTTPCode() int {
-	return r.Code
+	return r.statusCode
+}
+
+// NewErrorResponse creates an error envelope for an error that is not an application error.
+func NewErrorResponse(statusCode int, code, message string) *ErrorResponse {
+	return &ErrorResponse{
+		Code:       code,
+		Message:    message,
+		statusCode: statusCode,
+	}
 }
 
-func NewErrorResponse(statusCode int, message string) Response {
+// errorResponseFromApplicationError wraps an application error in the error envelope.
+// The HTTP status is derived from the category of the error code.
+func errorResponseFromApplicationError(err ce.ApplicationError) *ErrorResponse {
+	code := err.ErrCode()
+	if code == nil {
+		code = ce.ErrorCodeInternal
+	}
 	return &ErrorResponse{
-		Status:  "error",
-		Code:    statusCode,
-		Message: message,
+		Code:       code.Code,
+		Message:    err.ErrMessage(),
+		Details:    err.ErrDetails(),
+		statusCode: code.Category.HTTPCode(),
 	}
 }
 
@@ -0,0 +1,219 @@
+// Package language detects the language of short free texts such as feedback comments.
+//
+// Detection is dictionary based and runs locally: texts in a script used by a single language (e.g. Greek or
+// Hangul) are detected by their script, Latin and Cyrillic texts by their most frequent words. Texts that are too
+// short or too ambiguous to tell are reported as undetected rather than guessed.
+package language
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// ISO 639-1 codes of the detected languages.
+const (
+	Arabic     = "ar"
+	Chinese    = "zh"
+	Dutch      = "nl"
+	English    = "en"
+	French     = "fr"
+	German     = "de"
+	Greek      = "el"
+	Hebrew     = "he"
+	Hindi      = "hi"
+	Italian    = "it"
+	Japanese   = "ja"
+	Korean     = "ko"
+	Polish     = "pl"
+	Portuguese = "pt"
+	Russian    = "ru"
+	Spanish    = "es"
+	Thai       = "th"
+	Turkish    = "tr"
+	Ukrainian  = "uk"
+)
+
+// scriptLanguages maps the scripts written by a single supported language to that language.
+var scriptLanguages = map[*unicode.RangeTable]string{
+	unicode.Arabic:     Arabic,
+	unicode.Devanagari: Hindi,
+	unicode.Greek:      Greek,
+	unicode.Hangul:     Korean,
+	unicode.Hebrew:     Hebrew,
+	unicode.Thai:       Thai,
+}
+
+// ukrainianLetters are Cyrillic letters used in Ukrainian but not in Russian.
+const ukrainianLetters = "ієїґ"
+
+// words lists frequent words of the languages written in Latin or Cyrillic script, mostly function words, that
+// tell the languages apart. Words shared by several languages count for each of them.
+var words = map[string][]string{
+	Dutch: {
+		"de", "het", "een", "en", "is", "niet", "van", "ik", "dat", "met", "voor", "maar", "ook", "zijn", "heel",
+		"geen", "wel", "nog", "goed", "erg", "mijn", "werkt", "deze", "bij", "wordt",
+	},
+	English: {
+		"the", "and", "is", "are", "was", "this", "that", "with", "for", "not", "have", "has", "you", "it", "my",
+		"very", "but", "of", "to", "i", "would", "can't", "don't", "it's", "i'm", "great", "good", "love", "works",
+		"easy", "slow", "bad", "nice", "when", "please",
+	},
+	French: {
+		"le", "la", "les", "et", "est", "un", "une", "des", "pas", "je", "que", "pour", "avec", "très", "mais",
+		"c'est", "ne", "du", "sur", "il", "bien", "vous", "j'ai", "mon", "trop",
+	},
+	German: {
+		"der", "die", "das", "und", "ist", "nicht", "ich", "mit", "für", "sehr", "aber", "ein", "eine", "auch",
+		"zu", "es", "sich", "gut", "kein", "wenn", "funktioniert", "mein", "leider", "schnell", "noch",
+	},
+	Italian: {
+		"il", "di", "che", "è", "non", "un", "una", "per", "con", "sono", "molto", "ma", "mi", "gli", "della",
+		"questo", "anche", "bene", "più", "ho", "funziona", "lo", "nel", "troppo",
+	},
+	Polish: {
+		"i", "nie", "jest", "się", "to", "na", "że", "w", "z", "bardzo", "ale", "jak", "mi", "dla", "działa",
+		"jestem", "tylko", "już", "dobrze", "aplikacja",
+	},
+	Portuguese: {
+		"o", "de", "que", "não", "é", "um", "uma", "para", "com", "muito", "mas", "os", "as", "do", "da", "em",
+		"está", "eu", "bom", "ótimo", "funciona", "isso", "você",
+	},
+	Russian: {
+		"и", "в", "не", "что", "на", "я", "с", "это", "очень", "но", "как", "все", "всё", "нет", "приложение",
+		"работает", "хорошо", "плохо", "мне", "так", "уже", "можно",
+	},
+	Spanish: {
+		"el", "la", "de", "que", "y", "es", "no", "en", "los", "las", "un", "una", "por", "con", "muy", "pero",
+		"para", "está", "me", "bueno", "funciona", "lo", "del", "también",
+	},
+	Turkish: {
+		"ve", "bir", "bu", "çok", "için", "ama", "değil", "ile", "da", "de", "ne", "gibi", "güzel", "iyi", "uygulama",
+		"çalışmıyor", "var", "yok", "daha", "olarak",
+	},
+	Ukrainian: {
+		"і", "в", "не", "що", "на", "я", "з", "це", "дуже", "але", "як", "все", "ні", "додаток", "працює", "добре",
+		"погано", "мені", "так", "вже", "можна",
+	},
+}
+
+// wordLanguages maps every word in words to the languages it belongs to.
+var wordLanguages = func() map[string][]string {
+	languages := make(map[string][]string)
+	for language, list := range words {
+		for _, word := range list {
+			languages[word] = append(languages[word], language)
+		}
+	}
+	return languages
+}()
+
+// Supported returns the ISO 639-1 codes of the languages Detect can detect, sorted.
+func Supported() []string {
+	supported := []string{Chinese, Japanese}
+	for _, language := range scriptLanguages {
+		supported = append(supported, language)
+	}
+	for language := range words {
+		supported = append(supported, language)
+	}
+	slices.Sort(supported)
+	return supported
+}
+
+// IsSupported reports whether Detect can detect the language with the given ISO 639-1 code.
+func IsSupported(code string) bool {
+	return slices.Contains(Supported(), code)
+}
+
+// Detect returns the ISO 639-1 code of the language the text is written in.
+// It returns false if the text contains no letters or the language cannot be told with confidence.
+func Detect(text string) (string, bool) {
+	var latin, cyrillic, han, kana, ukrainian int
+	scripts := make(map[string]int)
+	for _, r := range text {
+		switch {
+		case !unicode.IsLetter(r):
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+			if strings.ContainsRune(ukrainianLetters, unicode.ToLower(r)) {
+				ukrainian++
+			}
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+		default:
+			for table, language := range scriptLanguages {
+				if unicode.Is(table, r) {
+					scripts[language]++
+					break
+				}
+			}
+		}
+	}
+
+	// Japanese mixes kanji with kana, Chinese uses Han characters only
+	scripts[Japanese] = kana + han
+	if kana == 0 {
+		scripts[Japanese] = 0
+		scripts[Chinese] = han
+	}
+
+	dominant, letters := "", latin+cyrillic
+	for language, count := range scripts {
+		if count > letters || (count == letters && dominant != "" && language < dominant) {
+			dominant, letters = language, count
+		}
+	}
+	if letters == 0 {
+		return "", false
+	}
+	if dominant != "" {
+		return dominant, true
+	}
+
+	// Letters specific to Ukrainian outweigh the words it shares with Russian
+	language, ok := detectByWords(text)
+	if cyrillic > latin && ukrainian > 0 && (!ok || language == Russian) {
+		return Ukrainian, true
+	}
+	return language, ok
+}
+
+// detectByWords returns the language with the most words of the text in its word list.
+// It returns false if no word is known or several languages have the highest count.
+func detectByWords(text string) (string, bool) {
+	counts := make(map[string]int)
+	normalized := strings.ReplaceAll(strings.ToLower(text), "’", "'")
+	for _, word := range strings.FieldsFunc(normalized, isWordSeparator) {
+		languages, ok := wordLanguages[word]
+		if !ok {
+			languages = wordLanguages[strings.Trim(word, "'")]
+		}
+		for _, language := range languages {
+			counts[language]++
+		}
+	}
+
+	best, bestCount, tied := "", 0, false
+	for language, count := range counts {
+		switch {
+		case count > bestCount:
+			best, bestCount, tied = language, count, false
+		case count == bestCount:
+			tied = true
+		}
+	}
+	if bestCount == 0 || tied {
+		return "", false
+	}
+	return best, true
+}
+
+// isWordSeparator splits text into words, keeping apostrophes so that contractions such as "don't" stay whole.
+func isWordSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && r != '\''
+}
@@ -0,0 +1,126 @@
+package language
+
+import (
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		detected bool
+	}{
+		{
+			name:     "english",
+			input:    "The app is great but the search is very slow",
+			expected: English,
+			detected: true,
+		},
+		{
+			name:     "english contraction with typographic apostrophe",
+			input:    "I don’t like it",
+			expected: English,
+			detected: true,
+		},
+		{
+			name:     "german",
+			input:    "Die App ist sehr gut, aber leider nicht schnell genug",
+			expected: German,
+			detected: true,
+		},
+		{
+			name:     "french",
+			input:    "C'est très bien mais les notifications sont trop fréquentes",
+			expected: French,
+			detected: true,
+		},
+		{
+			name:     "spanish",
+			input:    "La aplicación es muy buena pero la búsqueda no funciona",
+			expected: Spanish,
+			detected: true,
+		},
+		{
+			name:     "portuguese",
+			input:    "O aplicativo não funciona e está muito lento",
+			expected: Portuguese,
+			detected: true,
+		},
+		{
+			name:     "russian",
+			input:    "Приложение очень хорошо работает, но уже надоела реклама",
+			expected: Russian,
+			detected: true,
+		},
+		{
+			name:     "ukrainian letters outweigh shared words",
+			input:    "Додаток не працює після оновлення, дуже прикро",
+			expected: Ukrainian,
+			detected: true,
+		},
+		{
+			name:     "japanese",
+			input:    "アプリはとても使いやすいです",
+			expected: Japanese,
+			detected: true,
+		},
+		{
+			name:     "chinese",
+			input:    "应用程序非常好用",
+			expected: Chinese,
+			detected: true,
+		},
+		{
+			name:     "korean",
+			input:    "앱이 너무 느려요",
+			expected: Korean,
+			detected: true,
+		},
+		{
+			name:     "greek",
+			input:    "Πολύ καλή εφαρμογή",
+			expected: Greek,
+			detected: true,
+		},
+		{
+			name:  "no known words",
+			input: "Fantastic!",
+		},
+		{
+			name:  "no letters",
+			input: "10/10 :)",
+		},
+		{
+			name:  "empty",
+			input: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				got, detected := Detect(tt.input)
+				if detected != tt.detected {
+					t.Fatalf("expected detected %v, got %v (%q)", tt.detected, detected, got)
+				}
+				if got != tt.expected {
+					t.Errorf("expected %q, got %q", tt.expected, got)
+				}
+			},
+		)
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	for _, code := range []string{English, Japanese, Chinese, Greek, Ukrainian} {
+		if !IsSupported(code) {
+			t.Errorf("expected %q to be supported", code)
+		}
+	}
+	for _, code := range []string{"", "xx", "EN", "english"} {
+		if IsSupported(code) {
+			t.Errorf("expected %q not to be supported", code)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Package markup strips HTML and markdown formatting from free text, keeping the readable text.
+package markup
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Supported formats.
+const (
+	FormatHTML     = "html"
+	FormatMarkdown = "markdown"
+)
+
+// rule replaces the matches of a regular expression.
+type rule struct {
+	re   *regexp.Regexp
+	repl string
+}
+
+// htmlRules are applied in this order, so that the content of scripts and styles is dropped with their tags
+// and block-level tags still separate the text they enclosed. Only tags starting with a letter are removed,
+// so that text such as "a < b" or "<3" is kept.
+var htmlRules = []rule{
+	{re: regexp.MustCompile(`<!--[\s\S]*?-->`)},
+	{re: regexp.MustCompile(`(?is)<script\b[^>]*>.*?</script\s*>`)},
+	{re: regexp.MustCompile(`(?is)<style\b[^>]*>.*?</style\s*>`)},
+	{
+		re:   regexp.MustCompile(`(?i)<(?:br|/?(?:p|div|li|ul|ol|h[1-6]|tr|table|blockquote|pre))\b[^>]*>`),
+		repl: "\n",
+	},
+	{re: regexp.MustCompile(`</?[a-zA-Z][^<>]*>`)},
+}
+
+// markdownRules are applied in this order, so that images are not mistaken for links and horizontal rules
+// are not mistaken for emphasis. Underscores only mark emphasis at word boundaries, so that identifiers
+// such as snake_case names are kept.
+var markdownRules = []rule{
+	{re: regexp.MustCompile("(?m)^[ \t]*(?:```|~~~)[^\n]*$")},
+	{re: regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`), repl: "$1"},
+	{re: regexp.MustCompile(`\[([^\]]+)\]\([^)]*\)`), repl: "$1"},
+	{re: regexp.MustCompile(`(?m)^[ \t]*(?:[-*_][ \t]*){3,}$`)},
+	{re: regexp.MustCompile(`(?m)^[ \t]{0,3}#{1,6}[ \t]+`)},
+	{re: regexp.MustCompile(`(?m)^[ \t]{0,3}(?:>[ \t]?)+`)},
+	{re: regexp.MustCompile(`\*\*(\S(?:[^*\n]*?\S)?)\*\*`), repl: "$1"},
+	{re: regexp.MustCompile(`(^|[^\w])__(\S(?:[^_\n]*?\S)?)__([^\w]|$)`), repl: "$1$2$3"},
+	{re: regexp.MustCompile(`(^|[^\w*])\*(\S(?:[^*\n]*?\S)?)\*([^\w*]|$)`), repl: "$1$2$3"},
+	{re: regexp.MustCompile(`(^|[^\w])_(\S(?:[^_\n]*?\S)?)_([^\w]|$)`), repl: "$1$2$3"},
+	{re: regexp.MustCompile(`~~(\S(?:[^~\n]*?\S)?)~~`), repl: "$1"},
+	{re: regexp.MustCompile("`{1,2}([^`\n]+?)`{1,2}"), repl: "$1"},
+}
+
+var (
+	horizontalSpace = regexp.MustCompile(`[ \t\x{00A0}]+`)
+	blankLines      = regexp.MustCompile(`\n{3,}`)
+)
+
+// Formats returns the supported format names.
+func Formats() []string {
+	return []string{FormatHTML, FormatMarkdown}
+}
+
+// Stripper removes markup from text. A nil Stripper leaves text unchanged.
+type Stripper struct {
+	html     bool
+	markdown bool
+}
+
+// NewStripper creates a Stripper removing the named formats. An empty list enables all formats.
+func NewStripper(formats []string) (*Stripper, error) {
+	s := &Stripper{}
+	for _, format := range formats {
+		switch strings.TrimSpace(format) {
+		case FormatHTML:
+			s.html = true
+		case FormatMarkdown:
+			s.markdown = true
+		default:
+			return nil, fmt.Errorf(
+				"unknown markup format: %q (supported: %s)",
+				format,
+				strings.Join(Formats(), ", "),
+			)
+		}
+	}
+
+	if len(formats) == 0 {
+		s.html, s.markdown = true, true
+	}
+	return s, nil
+}
+
+// Strip returns the text without markup, with HTML entities decoded and whitespace collapsed: runs of
+// spaces and tabs become a single space, lines are trimmed and at most one blank line separates paragraphs.
+// It reports whether the text changed.
+func (s *Stripper) Strip(text string) (string, bool) {
+	if s == nil {
+		return text, false
+	}
+
+	stripped := text
+	if s.html {
+		stripped = apply(htmlRules, stripped)
+	}
+	if s.markdown {
+		stripped = apply(markdownRules, stripped)
+	}
+	stripped = collapseWhitespace(html.UnescapeString(stripped))
+
+	return stripped, stripped != text
+}
+
+func apply(rules []rule, text string) string {
+	for _, r := range rules {
+		text = r.re.ReplaceAllString(text, r.repl)
+	}
+	return text
+}
+
+func collapseWhitespace(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(horizontalSpace.ReplaceAllString(line, " "))
+	}
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
@@ -0,0 +1,93 @@
+package markup
+
+import (
+	"testing"
+)
+
+func TestStripper_Strip(t *testing.T) {
+	stripper, err := NewStripper(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "plain text is kept",
+			input:    "The export is slow, a < b and <3",
+			expected: "The export is slow, a < b and <3",
+		},
+		{
+			name:     "html tags and entities",
+			input:    "<p>Great <b>app</b> &amp; support&nbsp;team</p><p>Thanks!</p>",
+			expected: "Great app & support team\n\nThanks!",
+		},
+		{
+			name:     "scripts, styles and comments are dropped",
+			input:    "<style>p{color:red}</style>Hello<!-- note --><script>alert(1)</script> world",
+			expected: "Hello world",
+		},
+		{
+			name:     "line breaks",
+			input:    "First line<br>Second line<br/>Third line",
+			expected: "First line\nSecond line\nThird line",
+		},
+		{
+			name:     "markdown emphasis and code",
+			input:    "**Bold**, *italic*, _also italic_, ~~gone~~ and `code` but snake_case_name stays",
+			expected: "Bold, italic, also italic, gone and code but snake_case_name stays",
+		},
+		{
+			name:     "markdown links, images and headings",
+			input:    "# Title\n\n> Quoted [docs](https://example.com) ![logo](logo.png)",
+			expected: "Title\n\nQuoted docs logo",
+		},
+		{
+			name:     "whitespace is collapsed",
+			input:    "  Too   many\tspaces \n\n\n\n and lines  ",
+			expected: "Too many spaces\n\nand lines",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				got, changed := stripper.Strip(tt.input)
+				if got != tt.expected {
+					t.Errorf("Strip(%q) = %q, want %q", tt.input, got, tt.expected)
+				}
+				if changed != (tt.input != tt.expected) {
+					t.Errorf("Strip(%q) reported changed = %v", tt.input, changed)
+				}
+			},
+		)
+	}
+}
+
+func TestStripper_SingleFormat(t *testing.T) {
+	stripper, err := NewStripper([]string{FormatHTML})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, _ := stripper.Strip("<em>**slow**</em>")
+	if got != "**slow**" {
+		t.Errorf("Expected markdown to be kept when only html is stripped, got %q", got)
+	}
+}
+
+func TestNewStripper_UnknownFormat(t *testing.T) {
+	if _, err := NewStripper([]string{"rtf"}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestStripper_Nil(t *testing.T) {
+	var stripper *Stripper
+	if got, changed := stripper.Strip("<b>text</b>"); got != "<b>text</b>" || changed {
+		t.Errorf("expected nil stripper to leave text unchanged, got %q", got)
+	}
+}
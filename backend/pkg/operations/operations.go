@@ -2,8 +2,11 @@ package operations
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
 	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
 )
 
@@ -31,6 +34,29 @@ func RunGenericTransaction(
 	return nil
 }
 
+// RunWithTimeout runs exec with a context that expires after timeout, so that slow repository calls cannot hang
+// the caller. If exec fails after the timeout expired, a timeout application error wrapping the failure is
+// returned. Cancellation or deadlines of the parent context are returned as they are.
+// A non-positive timeout runs exec with the parent context.
+func RunWithTimeout(ctx context.Context, timeout time.Duration, exec func(ctx context.Context) error) error {
+	if timeout <= 0 {
+		return exec(ctx)
+	}
+
+	opCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := exec(opCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(opCtx.Err(), context.DeadlineExceeded) {
+		return ce.ErrTimeout(
+			fmt.Sprintf("database operation did not complete within %s", timeout),
+			ce.WithCauseError(err),
+		)
+	}
+
+	return err
+}
+
 func deferRollbackOnError(ctx context.Context, tx repository.Transaction, errPtr *error) func() {
 	return func() {
 		if *errPtr != nil {
@@ -0,0 +1,82 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	ce "github.com/ktruedat/llm-feedback-analysis/pkg/errors"
+)
+
+func TestRunWithTimeout(t *testing.T) {
+	t.Run(
+		"timeout expired", func(t *testing.T) {
+			err := RunWithTimeout(
+				context.Background(),
+				10*time.Millisecond,
+				func(ctx context.Context) error {
+					<-ctx.Done()
+					return fmt.Errorf("failed to query: %w", ctx.Err())
+				},
+			)
+
+			var appErr ce.ApplicationError
+			if !errors.As(err, &appErr) {
+				t.Fatalf("Expected application error, got: %v", err)
+			}
+			if appErr.HTTPCode() != 504 {
+				t.Errorf("Expected HTTP code 504, got %d", appErr.HTTPCode())
+			}
+		},
+	)
+
+	t.Run(
+		"operation error", func(t *testing.T) {
+			want := errors.New("query failed")
+			err := RunWithTimeout(
+				context.Background(),
+				time.Minute,
+				func(context.Context) error { return want },
+			)
+			if !errors.Is(err, want) {
+				t.Fatalf("Expected operation error, got: %v", err)
+			}
+		},
+	)
+
+	t.Run(
+		"parent context canceled", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err := RunWithTimeout(
+				ctx,
+				time.Minute,
+				func(ctx context.Context) error { return ctx.Err() },
+			)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("Expected context canceled, got: %v", err)
+			}
+		},
+	)
+
+	t.Run(
+		"no timeout", func(t *testing.T) {
+			err := RunWithTimeout(
+				context.Background(),
+				0,
+				func(ctx context.Context) error {
+					if _, ok := ctx.Deadline(); ok {
+						return errors.New("unexpected deadline")
+					}
+					return nil
+				},
+			)
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+		},
+	)
+}
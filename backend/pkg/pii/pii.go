@@ -0,0 +1,199 @@
+// Package pii detects and masks personally identifiable information in free text.
+package pii
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Built-in pattern names.
+const (
+	PatternEmail      = "email"
+	PatternPhone      = "phone"
+	PatternCreditCard = "credit_card"
+)
+
+// CustomPatternMask replaces matches of custom patterns.
+const CustomPatternMask = "[REDACTED]"
+
+const (
+	// minPhoneDigits avoids masking dates, times and short numbers as phone numbers.
+	minPhoneDigits = 9
+	// maxPhoneDigits is the longest phone number allowed by E.164.
+	maxPhoneDigits = 15
+)
+
+// pattern is a single detection rule.
+type pattern struct {
+	name string
+	re   *regexp.Regexp
+	mask string
+	// valid filters out false positives of the regular expression, if set.
+	valid func(match string) bool
+}
+
+// builtins are applied in this order, so that card numbers are not mistaken for phone numbers.
+var builtins = []pattern{
+	{
+		name: PatternEmail,
+		re:   regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+		mask: "[EMAIL]",
+	},
+	{
+		name:  PatternCreditCard,
+		re:    regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		mask:  "[CREDIT_CARD]",
+		valid: luhnValid,
+	},
+	{
+		name: PatternPhone,
+		re:   regexp.MustCompile(`\+?\(?\d[\d\s().-]{6,}\d`),
+		mask: "[PHONE]",
+		valid: func(match string) bool {
+			digits := countDigits(match)
+			return digits >= minPhoneDigits && digits <= maxPhoneDigits
+		},
+	},
+}
+
+// BuiltinPatterns returns the names of the built-in patterns.
+func BuiltinPatterns() []string {
+	names := make([]string, len(builtins))
+	for i, p := range builtins {
+		names[i] = p.name
+	}
+	return names
+}
+
+// Findings counts the masked matches per pattern name.
+// Custom patterns are reported by their index, e.g. "custom_0".
+type Findings map[string]int
+
+// Total returns the number of masked matches across all patterns.
+func (f Findings) Total() int {
+	total := 0
+	for _, count := range f {
+		total += count
+	}
+	return total
+}
+
+// Patterns returns the names of the patterns that matched, sorted.
+func (f Findings) Patterns() []string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Scrubber masks PII in text. A nil Scrubber leaves text unchanged.
+type Scrubber struct {
+	patterns []pattern
+}
+
+// NewScrubber creates a Scrubber applying the named built-in patterns and the custom regular expressions.
+// An empty list of names enables all built-in patterns.
+func NewScrubber(names []string, customPatterns []string) (*Scrubber, error) {
+	enabled := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if !isBuiltin(name) {
+			return nil, fmt.Errorf(
+				"unknown pii pattern: %q (supported: %s)",
+				name,
+				strings.Join(BuiltinPatterns(), ", "),
+			)
+		}
+		enabled[name] = true
+	}
+
+	s := &Scrubber{}
+	for _, p := range builtins {
+		if len(enabled) == 0 || enabled[p.name] {
+			s.patterns = append(s.patterns, p)
+		}
+	}
+
+	for i, expr := range customPatterns {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid custom pii pattern %q: %w", expr, err)
+		}
+		s.patterns = append(
+			s.patterns, pattern{
+				name: fmt.Sprintf("custom_%d", i),
+				re:   re,
+				mask: CustomPatternMask,
+			},
+		)
+	}
+
+	return s, nil
+}
+
+// Scrub returns the text with all matches masked, together with what was found.
+func (s *Scrubber) Scrub(text string) (string, Findings) {
+	findings := Findings{}
+	if s == nil {
+		return text, findings
+	}
+
+	for _, p := range s.patterns {
+		text = p.re.ReplaceAllStringFunc(
+			text, func(match string) string {
+				if p.valid != nil && !p.valid(match) {
+					return match
+				}
+				findings[p.name]++
+				return p.mask
+			},
+		)
+	}
+
+	return text, findings
+}
+
+func isBuiltin(name string) bool {
+	for _, p := range builtins {
+		if p.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func countDigits(s string) int {
+	count := 0
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			count++
+		}
+	}
+	return count
+}
+
+// luhnValid reports whether the digits of s pass the Luhn checksum used by payment cards.
+func luhnValid(s string) bool {
+	sum := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		digit := int(c - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
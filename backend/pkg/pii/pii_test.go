@@ -0,0 +1,102 @@
+package pii
+
+import (
+	"testing"
+)
+
+func TestScrubber_Scrub(t *testing.T) {
+	scrubber, err := NewScrubber(nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		findings Findings
+	}{
+		{
+			name:     "email",
+			input:    "Contact me at john.doe+test@example.com please",
+			expected: "Contact me at [EMAIL] please",
+			findings: Findings{PatternEmail: 1},
+		},
+		{
+			name:     "phone",
+			input:    "Call +1 (555) 123-4567 tomorrow",
+			expected: "Call [PHONE] tomorrow",
+			findings: Findings{PatternPhone: 1},
+		},
+		{
+			name:     "credit card",
+			input:    "My card 4111 1111 1111 1111 was charged twice",
+			expected: "My card [CREDIT_CARD] was charged twice",
+			findings: Findings{PatternCreditCard: 1},
+		},
+		{
+			name:     "dates and short numbers are kept",
+			input:    "Ordered 3 items on 2024-01-01 at 10:30, order 12345",
+			expected: "Ordered 3 items on 2024-01-01 at 10:30, order 12345",
+			findings: Findings{},
+		},
+		{
+			name:     "number failing the luhn check is not a card",
+			input:    "Reference 1234 5678 9012 3456",
+			expected: "Reference 1234 5678 9012 3456",
+			findings: Findings{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				got, findings := scrubber.Scrub(tt.input)
+				if got != tt.expected {
+					t.Errorf("expected %q, got %q", tt.expected, got)
+				}
+				if findings.Total() != tt.findings.Total() {
+					t.Errorf("expected %d findings, got %d (%v)", tt.findings.Total(), findings.Total(), findings)
+				}
+				for name, count := range tt.findings {
+					if findings[name] != count {
+						t.Errorf("expected %d %s findings, got %d", count, name, findings[name])
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestScrubber_SelectedAndCustomPatterns(t *testing.T) {
+	scrubber, err := NewScrubber([]string{PatternEmail}, []string{`(?i)order\s*#\d+`})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, findings := scrubber.Scrub("mail a@b.io about Order #991, phone 555 123 4567")
+	expected := "mail [EMAIL] about [REDACTED], phone 555 123 4567"
+	if got != expected {
+		t.Errorf("expected %q, got %q", expected, got)
+	}
+	if findings["custom_0"] != 1 || findings[PatternEmail] != 1 {
+		t.Errorf("unexpected findings: %v", findings)
+	}
+}
+
+func TestNewScrubber_Invalid(t *testing.T) {
+	if _, err := NewScrubber([]string{"ssn"}, nil); err == nil {
+		t.Error("expected error for unknown pattern")
+	}
+	if _, err := NewScrubber(nil, []string{"("}); err == nil {
+		t.Error("expected error for invalid regular expression")
+	}
+}
+
+func TestScrubber_Nil(t *testing.T) {
+	var scrubber *Scrubber
+	got, findings := scrubber.Scrub("a@b.io")
+	if got != "a@b.io" || findings.Total() != 0 {
+		t.Errorf("nil scrubber must not change text, got %q", got)
+	}
+}
@@ -0,0 +1,16 @@
+package repository
+
+import "context"
+
+// Lock is a held distributed lock.
+type Lock interface {
+	// Release gives the lock up so that another holder can acquire it.
+	Release(ctx context.Context) error
+}
+
+// Locker acquires locks shared between all instances using the same database.
+type Locker interface {
+	// TryLock attempts to acquire the lock identified by key without waiting.
+	// The returned bool is false, with a nil Lock, if the lock is held by someone else.
+	TryLock(ctx context.Context, key int64) (Lock, bool, error)
+}
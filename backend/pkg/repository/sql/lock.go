@@ -0,0 +1,61 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/repository"
+)
+
+// advisoryLocker implements repository.Locker with Postgres session-level advisory locks.
+// Session locks belong to a connection, so each held lock pins one pool connection until released.
+type advisoryLocker struct {
+	pool *pgxpool.Pool
+}
+
+func NewAdvisoryLocker(pool *pgxpool.Pool) repository.Locker {
+	return &advisoryLocker{pool: pool}
+}
+
+func (l *advisoryLocker) TryLock(ctx context.Context, key int64) (repository.Lock, bool, error) {
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return nil, false, fmt.Errorf("connection for advisory lock could not be acquired: %w", err)
+	}
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		conn.Release()
+		return nil, false, fmt.Errorf("advisory lock could not be requested: %w", err)
+	}
+
+	if !acquired {
+		conn.Release()
+		return nil, false, nil
+	}
+
+	return &advisoryLock{conn: conn, key: key}, true, nil
+}
+
+type advisoryLock struct {
+	conn *pgxpool.Conn
+	key  int64
+}
+
+func (l *advisoryLock) Release(ctx context.Context) error {
+	defer l.conn.Release()
+
+	var released bool
+	if err := l.conn.QueryRow(ctx, "SELECT pg_advisory_unlock($1)", l.key).Scan(&released); err != nil {
+		// The session may still hold the lock, so close the connection instead of returning it to the pool.
+		_ = l.conn.Conn().Close(ctx)
+		return fmt.Errorf("advisory lock could not be released: %w", err)
+	}
+
+	if !released {
+		return fmt.Errorf("advisory lock %d was not held by this session", l.key)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package querier
 
 import (
 	"context"
+	"errors"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -29,12 +30,31 @@ var (
 	_ repository.Executor = (*PgxPool)(nil)
 )
 
+// ErrNilQuerier is returned when a querier is created or used without an initialized database pool.
+var ErrNilQuerier = errors.New("querier is nil, the database pool is not initialized")
+
 type PgxPool struct {
 	pool *pgxpool.Pool
 }
 
-func NewPgxPool(pool *pgxpool.Pool) *PgxPool {
-	return &PgxPool{pool: pool}
+// NewPgxPool wraps a pgx pool, failing with ErrNilQuerier if the pool is nil.
+func NewPgxPool(pool *pgxpool.Pool) (*PgxPool, error) {
+	if pool == nil {
+		return nil, ErrNilQuerier
+	}
+	return &PgxPool{pool: pool}, nil
+}
+
+// Check returns ErrNilQuerier if q is nil, including a nil *PgxPool or one without a pool, so that
+// repositories fail at construction instead of panicking on their first query.
+func Check(q PgxQuerier) error {
+	if q == nil {
+		return ErrNilQuerier
+	}
+	if p, ok := q.(*PgxPool); ok && (p == nil || p.pool == nil) {
+		return ErrNilQuerier
+	}
+	return nil
 }
 
 func (p *PgxPool) Exec(ctx context.Context, query string, args ...any) (pgconn.CommandTag, error) {
@@ -0,0 +1,12 @@
+package querier
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewPgxPool_NilPool(t *testing.T) {
+	if _, err := NewPgxPool(nil); !errors.Is(err, ErrNilQuerier) {
+		t.Errorf("Expected ErrNilQuerier, got: %v", err)
+	}
+}
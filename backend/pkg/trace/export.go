@@ -0,0 +1,68 @@
+package trace
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// defaultOTLPPort is the OTLP/HTTP port used when the endpoint does not specify one.
+	defaultOTLPPort = "4318"
+	// probeTimeout bounds the connectivity check of the endpoint at startup.
+	probeTimeout = 2 * time.Second
+)
+
+// ExportStatusHandler is notified when exporting spans starts failing, with the export error,
+// and when it succeeds again, with a nil error. It is not called for every failed export.
+type ExportStatusHandler func(err error)
+
+// statusReportingExporter reports changes between failing and succeeding exports of the wrapped exporter.
+// Spans are exported by the batch span processor in the background, so failures never block requests;
+// without reporting they would only be dropped silently.
+type statusReportingExporter struct {
+	sdktrace.SpanExporter
+	onStatusChange ExportStatusHandler
+
+	mu      sync.Mutex
+	failing bool
+}
+
+// ExportSpans exports spans with the wrapped exporter and reports a status change.
+func (e *statusReportingExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	err := e.SpanExporter.ExportSpans(ctx, spans)
+	e.setFailing(err)
+	return err
+}
+
+// setFailing records the result of an export, calling the handler if it differs from the previous one.
+func (e *statusReportingExporter) setFailing(err error) {
+	e.mu.Lock()
+	changed := e.failing != (err != nil)
+	e.failing = err != nil
+	e.mu.Unlock()
+
+	if changed {
+		e.onStatusChange(err)
+	}
+}
+
+// probeEndpoint checks that a TCP connection to the host:port of the OTLP endpoint can be opened.
+func probeEndpoint(ctx context.Context, hostPort string) error {
+	if _, _, err := net.SplitHostPort(hostPort); err != nil {
+		hostPort = net.JoinHostPort(hostPort, defaultOTLPPort)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", hostPort)
+	if err != nil {
+		return fmt.Errorf("tracing endpoint %s is unreachable: %w", hostPort, err)
+	}
+	return conn.Close()
+}
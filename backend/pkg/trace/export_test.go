@@ -0,0 +1,73 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// failingExporter fails every export while err is set.
+type failingExporter struct {
+	tracetest.NoopExporter
+	err error
+}
+
+func (e *failingExporter) ExportSpans(context.Context, []sdktrace.ReadOnlySpan) error {
+	return e.err
+}
+
+func TestStatusReportingExporter_ReportsChangesOnly(t *testing.T) {
+	inner := &failingExporter{err: errors.New("connection refused")}
+	var reported []error
+	exporter := &statusReportingExporter{
+		SpanExporter:   inner,
+		onStatusChange: func(err error) { reported = append(reported, err) },
+	}
+
+	for range 3 {
+		_ = exporter.ExportSpans(context.Background(), nil)
+	}
+	inner.err = nil
+	for range 2 {
+		_ = exporter.ExportSpans(context.Background(), nil)
+	}
+
+	if len(reported) != 2 || reported[0] == nil || reported[1] != nil {
+		t.Errorf("Expected one failure and one recovery to be reported, got %v", reported)
+	}
+}
+
+func TestNewTracer_FailFast(t *testing.T) {
+	// Reserve a port and close it again, so that nothing listens on it
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	endpoint := "http://" + listener.Addr().String()
+	_ = listener.Close()
+
+	if _, err := NewTracer(Config{ServiceName: "trace-test", Endpoint: endpoint, Insecure: true, FailFast: true}); err == nil {
+		t.Error("Expected an error for an unreachable endpoint with fail-fast")
+	}
+
+	var reported error
+	tracer, err := NewTracer(
+		Config{
+			ServiceName:          "trace-test",
+			Endpoint:             endpoint,
+			Insecure:             true,
+			OnExportStatusChange: func(err error) { reported = err },
+		},
+	)
+	if err != nil {
+		t.Fatalf("Expected the tracer to degrade gracefully, got: %v", err)
+	}
+	t.Cleanup(func() { _ = tracer.Shutdown(context.Background()) })
+	if reported == nil {
+		t.Error("Expected the unreachable endpoint to be reported")
+	}
+}
@@ -3,6 +3,7 @@ package trace
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/exaring/otelpgx"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -50,18 +51,54 @@ func InstrumentHandlerFunc(
 	return wrapped.ServeHTTP
 }
 
+// PoolLimits tunes the size and connection lifetimes of a pgxpool.Pool.
+// Zero values keep the pgxpool defaults.
+type PoolLimits struct {
+	MaxConns        int32
+	MinConns        int32
+	MaxConnLifetime time.Duration
+	MaxConnIdleTime time.Duration
+}
+
+// PoolOption configures a pgxpool.Config before the pool is created.
+type PoolOption func(*pgxpool.Config)
+
+// WithPoolLimits applies the non-zero limits to the pool config.
+func WithPoolLimits(limits PoolLimits) PoolOption {
+	return func(cfg *pgxpool.Config) {
+		if limits.MaxConns > 0 {
+			cfg.MaxConns = limits.MaxConns
+		}
+		if limits.MinConns > 0 {
+			cfg.MinConns = limits.MinConns
+		}
+		if limits.MaxConnLifetime > 0 {
+			cfg.MaxConnLifetime = limits.MaxConnLifetime
+		}
+		if limits.MaxConnIdleTime > 0 {
+			cfg.MaxConnIdleTime = limits.MaxConnIdleTime
+		}
+	}
+}
+
 // InstrumentPgxPoolConfig instruments a pgxpool.Config with OpenTelemetry tracing.
 // If instrumented is nil or IsTracingEnabled() returns false, the config is returned as-is.
 // This hides otelpgx implementation details from application code.
+// The options are applied on top of the settings parsed from connString.
 func InstrumentPgxPoolConfig(
 	connString string,
 	instrumented Instrumented,
+	opts ...PoolOption,
 ) (*pgxpool.Config, error) {
 	cfg, err := pgxpool.ParseConfig(connString)
 	if err != nil {
 		return nil, err
 	}
 
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
 	if instrumented != nil && instrumented.IsTracingEnabled() {
 		// Attach OpenTelemetry tracer to pgx connection config
 		// otelpgx uses the global tracer provider set by our trace package
@@ -77,8 +114,9 @@ func InstrumentPgxPool(
 	ctx context.Context,
 	connString string,
 	instrumented Instrumented,
+	opts ...PoolOption,
 ) (*pgxpool.Pool, error) {
-	cfg, err := InstrumentPgxPoolConfig(connString, instrumented)
+	cfg, err := InstrumentPgxPoolConfig(connString, instrumented, opts...)
 	if err != nil {
 		return nil, err
 	}
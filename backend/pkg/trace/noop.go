@@ -0,0 +1,52 @@
+package trace
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// noopTracer implements Tracer without recording anything.
+type noopTracer struct{}
+
+// noopSpan implements Span without recording anything. Its span context is invalid.
+type noopSpan struct{}
+
+// NewNoopTracer creates a tracer for when tracing is disabled. Unlike NewTracer without an endpoint, its spans
+// are not recording and have an invalid span context, and the global tracer provider is left untouched.
+func NewNoopTracer() Tracer {
+	return noopTracer{}
+}
+
+// NoopSpan returns a span that records nothing, for callers that need a Span but have none.
+func NoopSpan() Span {
+	return noopSpan{}
+}
+
+// Start returns the context unchanged and a span that records nothing.
+func (noopTracer) Start(ctx context.Context, _ string, _ ...SpanOption) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+// Shutdown does nothing, there are no pending spans to flush.
+func (noopTracer) Shutdown(context.Context) error {
+	return nil
+}
+
+func (noopSpan) End(...SpanEndOption) {}
+
+func (noopSpan) SetAttributes(...Attribute) {}
+
+func (noopSpan) AddEvent(string, ...EventOption) {}
+
+func (noopSpan) IsRecording() bool {
+	return false
+}
+
+func (noopSpan) SpanContext() SpanContext {
+	return &otelSpanContext{sc: trace.SpanContext{}}
+}
+
+func (noopSpan) SetStatus(StatusCode, string) {}
+
+func (noopSpan) RecordError(error, ...ErrorOption) {}
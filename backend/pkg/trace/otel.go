@@ -33,6 +33,14 @@ type Config struct {
 
 	// Environment is the deployment environment (e.g., "production", "development").
 	Environment string
+
+	// FailFast makes NewTracer return an error if the endpoint is unreachable at startup.
+	// Otherwise the tracer is created anyway and spans are dropped until the endpoint is reachable.
+	FailFast bool
+
+	// OnExportStatusChange is notified when exporting spans to the endpoint starts failing, including
+	// when the endpoint is unreachable at startup, and when it recovers. Optional.
+	OnExportStatusChange ExportStatusHandler
 }
 
 // otelTracer implements the Tracer interface using OpenTelemetry.
@@ -97,12 +105,29 @@ func NewTracer(cfg Config) (Tracer, error) {
 			opts = append(opts, otlptracehttp.WithInsecure())
 		}
 
-		exporter, err := otlptracehttp.New(context.Background(), opts...)
+		otlpExporter, err := otlptracehttp.New(context.Background(), opts...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
 		}
+		var exporter sdktrace.SpanExporter = otlpExporter
+		var reporter *statusReportingExporter
+		if cfg.OnExportStatusChange != nil {
+			reporter = &statusReportingExporter{SpanExporter: otlpExporter, onStatusChange: cfg.OnExportStatusChange}
+			exporter = reporter
+		}
+
+		// The exporter does not connect until the first batch is sent, so check the endpoint up front
+		if err := probeEndpoint(context.Background(), endpoint); err != nil {
+			if cfg.FailFast {
+				return nil, err
+			}
+			if reporter != nil {
+				reporter.setFailing(err)
+			}
+		}
 
-		// Create tracer provider with exporter
+		// Create tracer provider with exporter. The batcher exports in the background and drops spans
+		// once its queue is full, so an unreachable endpoint never blocks request handling.
 		tp = sdktrace.NewTracerProvider(
 			sdktrace.WithBatcher(exporter),
 			sdktrace.WithResource(res),
@@ -51,7 +51,11 @@ type traceLogger struct {
 }
 
 // NewTraceLogger creates a new TraceLogger that combines a Logger with a Tracer.
+// A nil tracer is treated as a no-op tracer.
 func NewTraceLogger(logger log.Logger, tracer trace.Tracer) TraceLogger {
+	if tracer == nil {
+		tracer = trace.NewNoopTracer()
+	}
 	return &traceLogger{
 		logger: logger,
 		tracer: tracer,
@@ -64,18 +68,13 @@ func (tl *traceLogger) StartSpan(ctx context.Context, name string, opts ...trace
 	TraceLogger,
 	trace.Span,
 ) {
-	ctx, span := tl.tracer.Start(ctx, name, opts...)
-	return ctx, &traceLoggerWithSpan{logger: tl.logger, tracer: tl.tracer, span: span}, span
+	return startSpan(ctx, tl.logger, tl.tracer, name, opts...)
 }
 
 // WithSpan returns a logger that operates within an existing span context.
+// If there is no recording span in the context, the logger falls back to standard logging.
 func (tl *traceLogger) WithSpan(ctx context.Context) TraceLogger {
-	span := trace.SpanFromContext(ctx)
-	if span == nil {
-		// No span in context, return standard logger wrapper
-		return &traceLoggerWithSpan{logger: tl.logger, tracer: tl.tracer, span: nil}
-	}
-	return &traceLoggerWithSpan{logger: tl.logger, tracer: tl.tracer, span: span}
+	return &traceLoggerWithSpan{logger: tl.logger, tracer: tl.tracer, span: trace.SpanFromContext(ctx)}
 }
 
 // SetSpanAttributes sets attributes on the current span in the context.
@@ -135,12 +134,49 @@ func (tl *traceLogger) With(args ...any) TraceLogger {
 }
 
 // traceLoggerWithSpan is a logger that has an associated span.
+// The span is nil unless it is recording, e.g. when tracing is disabled, so that a span-aware logger behaves
+// the same whether it was returned by StartSpan or by WithSpan, which only finds recording spans.
 type traceLoggerWithSpan struct {
 	logger log.Logger
 	tracer trace.Tracer
 	span   trace.Span
 }
 
+// startSpan starts a span with the tracer and returns it with a logger operating within it. The returned span
+// is never nil, so that callers can always end it.
+func startSpan(
+	ctx context.Context,
+	logger log.Logger,
+	tracer trace.Tracer,
+	name string,
+	opts ...trace.SpanOption,
+) (context.Context, TraceLogger, trace.Span) {
+	ctx, span := tracer.Start(ctx, name, opts...)
+	if span == nil {
+		span = trace.NoopSpan()
+	}
+	var recording trace.Span
+	if span.IsRecording() {
+		recording = span
+	}
+	return ctx, &traceLoggerWithSpan{logger: logger, tracer: tracer, span: recording}, span
+}
+
+// withTraceContext appends the trace and span IDs of the span to the log arguments, unless its span context
+// is invalid.
+func withTraceContext(span trace.Span, args []any) []any {
+	sc := span.SpanContext()
+	if sc == nil || !sc.IsValid() {
+		return args
+	}
+	return append(args, "trace_id", sc.TraceID(), "span_id", sc.SpanID())
+}
+
+// addLogEvent adds a log message as an event to the span.
+func addLogEvent(span trace.Span, msg, level string) {
+	span.AddEvent(msg, trace.WithEventAttributes(trace.Attribute{Key: "log.level", Value: level}))
+}
+
 // StartSpan creates a new child span.
 func (tl *traceLoggerWithSpan) StartSpan(ctx context.Context, name string, opts ...trace.SpanOption) (
 	context.Context,
@@ -158,8 +194,7 @@ func (tl *traceLoggerWithSpan) StartSpan(ctx context.Context, name string, opts
 			)
 		}
 	}
-	ctx, span := tl.tracer.Start(ctx, name, opts...)
-	return ctx, &traceLoggerWithSpan{logger: tl.logger, tracer: tl.tracer, span: span}, span
+	return startSpan(ctx, tl.logger, tl.tracer, name, opts...)
 }
 
 // WithSpan returns itself if it already has a span, otherwise creates a new one from context.
@@ -212,13 +247,12 @@ func (tl *traceLoggerWithSpan) RecordSpanError(ctx context.Context, err error, o
 func (tl *traceLoggerWithSpan) Error(msg string, err error, args ...any) {
 	// Add trace context to log message
 	if tl.span != nil {
-		sc := tl.span.SpanContext()
-		if sc.IsValid() {
-			args = append(args, "trace_id", sc.TraceID(), "span_id", sc.SpanID())
-		}
+		args = withTraceContext(tl.span, args)
 		// Record error on span
-		tl.span.RecordError(err)
-		tl.span.SetStatus(trace.StatusError, err.Error())
+		if err != nil {
+			tl.span.RecordError(err)
+			tl.span.SetStatus(trace.StatusError, err.Error())
+		}
 	}
 	tl.logger.Error(msg, err, args...)
 }
@@ -226,16 +260,9 @@ func (tl *traceLoggerWithSpan) Error(msg string, err error, args ...any) {
 func (tl *traceLoggerWithSpan) Info(msg string, args ...any) {
 	// Add trace context to log message
 	if tl.span != nil {
-		sc := tl.span.SpanContext()
-		if sc.IsValid() {
-			args = append(args, "trace_id", sc.TraceID(), "span_id", sc.SpanID())
-		}
+		args = withTraceContext(tl.span, args)
 		// Add as event to span
-		tl.span.AddEvent(
-			msg, trace.WithEventAttributes(
-				trace.Attribute{Key: "log.level", Value: "info"},
-			),
-		)
+		addLogEvent(tl.span, msg, "info")
 	}
 	tl.logger.Info(msg, args...)
 }
@@ -243,16 +270,9 @@ func (tl *traceLoggerWithSpan) Info(msg string, args ...any) {
 func (tl *traceLoggerWithSpan) Warning(msg string, args ...any) {
 	// Add trace context to log message
 	if tl.span != nil {
-		sc := tl.span.SpanContext()
-		if sc.IsValid() {
-			args = append(args, "trace_id", sc.TraceID(), "span_id", sc.SpanID())
-		}
+		args = withTraceContext(tl.span, args)
 		// Add as event to span
-		tl.span.AddEvent(
-			msg, trace.WithEventAttributes(
-				trace.Attribute{Key: "log.level", Value: "warning"},
-			),
-		)
+		addLogEvent(tl.span, msg, "warning")
 	}
 	tl.logger.Warning(msg, args...)
 }
@@ -260,16 +280,9 @@ func (tl *traceLoggerWithSpan) Warning(msg string, args ...any) {
 func (tl *traceLoggerWithSpan) Debug(msg string, args ...any) {
 	// Add trace context to log message
 	if tl.span != nil {
-		sc := tl.span.SpanContext()
-		if sc.IsValid() {
-			args = append(args, "trace_id", sc.TraceID(), "span_id", sc.SpanID())
-		}
+		args = withTraceContext(tl.span, args)
 		// Add as event to span
-		tl.span.AddEvent(
-			msg, trace.WithEventAttributes(
-				trace.Attribute{Key: "log.level", Value: "debug"},
-			),
-		)
+		addLogEvent(tl.span, msg, "debug")
 	}
 	tl.logger.Debug(msg, args...)
 }
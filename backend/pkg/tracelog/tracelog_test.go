@@ -0,0 +1,129 @@
+package tracelog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ktruedat/llm-feedback-analysis/pkg/log"
+	"github.com/ktruedat/llm-feedback-analysis/pkg/trace"
+)
+
+// recordingLogger records the arguments of every message logged through it or its derived loggers.
+type recordingLogger struct {
+	messages *[][]any
+}
+
+func newRecordingLogger() *recordingLogger {
+	return &recordingLogger{messages: &[][]any{}}
+}
+
+func (l *recordingLogger) record(args []any) {
+	*l.messages = append(*l.messages, args)
+}
+
+func (l *recordingLogger) Error(_ string, _ error, args ...any) { l.record(args) }
+func (l *recordingLogger) Info(_ string, args ...any)           { l.record(args) }
+func (l *recordingLogger) Warning(_ string, args ...any)        { l.record(args) }
+func (l *recordingLogger) Debug(_ string, args ...any)          { l.record(args) }
+func (l *recordingLogger) NewGroup(string) log.Logger           { return l }
+func (l *recordingLogger) With(...any) log.Logger               { return l }
+
+// hasTraceContext reports whether any recorded message carries a trace_id.
+func (l *recordingLogger) hasTraceContext() bool {
+	for _, args := range *l.messages {
+		for _, arg := range args {
+			if arg == "trace_id" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nilSpanTracer returns no span, like a careless Tracer implementation might.
+type nilSpanTracer struct{}
+
+func (nilSpanTracer) Start(ctx context.Context, _ string, _ ...trace.SpanOption) (context.Context, trace.Span) {
+	return ctx, nil
+}
+
+func (nilSpanTracer) Shutdown(context.Context) error { return nil }
+
+// logEverything calls every logging and span method of the logger.
+func logEverything(ctx context.Context, tl TraceLogger) {
+	tl.Info("info", "key", "value")
+	tl.Warning("warning")
+	tl.Debug("debug")
+	tl.Error("error", errors.New("failed"))
+	tl.Error("error without error", nil)
+	tl.SetSpanAttributes(ctx, trace.Attribute{Key: "key", Value: "value"})
+	tl.AddSpanEvent(ctx, "event")
+	tl.RecordSpanError(ctx, errors.New("failed"))
+	tl.NewGroup("group").With("key", "value").Info("grouped")
+}
+
+func TestTraceLogger_TracingDisabled(t *testing.T) {
+	tests := []struct {
+		name   string
+		tracer trace.Tracer
+	}{
+		{name: "no-op tracer", tracer: trace.NewNoopTracer()},
+		{name: "nil tracer"},
+		{name: "tracer returning nil spans", tracer: nilSpanTracer{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				logger := newRecordingLogger()
+				tl := NewTraceLogger(logger, tt.tracer)
+				ctx := context.Background()
+
+				logEverything(ctx, tl)
+				logEverything(ctx, tl.WithSpan(ctx))
+
+				spanCtx, spanLogger, span := tl.StartSpan(ctx, "parent")
+				if span == nil {
+					t.Fatal("Expected StartSpan to return a span that can be ended")
+				}
+				logEverything(spanCtx, spanLogger)
+				logEverything(spanCtx, spanLogger.WithSpan(spanCtx))
+
+				childCtx, childLogger, child := spanLogger.StartSpan(spanCtx, "child")
+				logEverything(childCtx, childLogger)
+				child.End(trace.WithError(errors.New("failed")))
+				span.End()
+
+				if logger.hasTraceContext() {
+					t.Error("Expected no trace_id to be logged without a valid span context")
+				}
+			},
+		)
+	}
+}
+
+func TestTraceLogger_TracingEnabled(t *testing.T) {
+	tracer, err := trace.NewTracer(trace.Config{ServiceName: "tracelog-test"})
+	if err != nil {
+		t.Fatalf("Failed to create tracer: %v", err)
+	}
+	t.Cleanup(func() { _ = tracer.Shutdown(context.Background()) })
+
+	logger := newRecordingLogger()
+	tl := NewTraceLogger(logger, tracer)
+
+	ctx, spanLogger, span := tl.StartSpan(context.Background(), "operation")
+	defer span.End()
+
+	spanLogger.Info("within span")
+	if !logger.hasTraceContext() {
+		t.Fatal("Expected the trace_id of the span to be logged")
+	}
+
+	*logger.messages = nil
+	tl.WithSpan(ctx).Error("within span", nil)
+	if !logger.hasTraceContext() {
+		t.Error("Expected WithSpan to find the span in the context")
+	}
+}
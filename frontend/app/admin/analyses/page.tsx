@@ -27,10 +27,10 @@ function AnalysisHistoryPage() {
     setIsLoading(true);
     setError(null);
     try {
-      const response = await apiClient.listAnalyses();
+      const response = await apiClient.listAnalyses(1000, 0);
       // Response might be wrapped in a data property or be direct
       const responseData = response?.data || response;
-      setAnalyses(responseData?.analyses || []);
+      setAnalyses(responseData?.items || []);
     } catch (err: any) {
       if (err.response?.status === 401 || err.response?.status === 403) {
         clearAuth();
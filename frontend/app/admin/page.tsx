@@ -60,7 +60,7 @@ function AdminDashboard() {
     setIsLoadingTopics(true);
     try {
       const response = await apiClient.getTopicsWithStats();
-      const topicsData = response?.topics || response?.data?.topics || [];
+      const topicsData = response?.items || response?.data?.items || [];
       setTopics(topicsData);
     } catch (err: any) {
       console.error('Failed to load topics:', err);
@@ -74,7 +74,7 @@ function AdminDashboard() {
     setError(null);
     try {
       const response = await apiClient.listFeedbacks(1000, 0);
-      const feedbacksData = response.feedbacks || response.data?.feedbacks || [];
+      const feedbacksData = response.items || response.data?.items || [];
       setFeedbacks(feedbacksData);
 
       // Calculate statistics
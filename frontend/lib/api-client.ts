@@ -3,8 +3,21 @@ import axios, { AxiosInstance, AxiosError } from 'axios';
 const API_BASE_URL = process.env.NEXT_PUBLIC_API_URL || 'http://localhost:8080/api';
 
 export interface ApiError {
+  code: string;
   message: string;
-  code?: string;
+  details?: Record<string, unknown>;
+  request_id?: string;
+}
+
+export interface FeedbackExportFilters {
+  source?: string;
+  tag?: string;
+  platform?: string;
+  appVersion?: string;
+  createdFrom?: string;
+  createdTo?: string;
+  minRating?: number;
+  maxRating?: number;
 }
 
 export class ApiClient {
@@ -80,18 +93,25 @@ export class ApiClient {
     return response.data;
   }
 
-  async listFeedbacks(limit?: number, offset?: number) {
+  async listFeedbacks(limit?: number, offset?: number, includeDeleted?: boolean) {
     const params = new URLSearchParams();
     if (limit) params.append('limit', limit.toString());
     if (offset) params.append('offset', offset.toString());
+    if (includeDeleted) params.append('include_deleted', 'true');
     const queryString = params.toString();
     const url = queryString ? `/feedbacks?${queryString}` : '/feedbacks';
     const response = await this.client.get(url);
     return response.data;
   }
 
-  async getFeedback(id: string) {
-    const response = await this.client.get(`/feedbacks/${id}`);
+  async getFeedback(id: string, includeDeleted?: boolean) {
+    const url = includeDeleted ? `/feedbacks/${id}?include_deleted=true` : `/feedbacks/${id}`;
+    const response = await this.client.get(url);
+    return response.data;
+  }
+
+  async getFeedbackAnalyses(id: string) {
+    const response = await this.client.get(`/feedbacks/${id}/analyses`);
     return response.data;
   }
 
@@ -99,14 +119,65 @@ export class ApiClient {
     await this.client.delete(`/feedbacks/${id}`);
   }
 
+  async batchDeleteFeedbacks(feedbackIds: string[]) {
+    const response = await this.client.post('/feedbacks/batch-delete', { feedback_ids: feedbackIds });
+    return response.data;
+  }
+
+  async exportFeedbacks(filters?: FeedbackExportFilters) {
+    const params = new URLSearchParams();
+    if (filters?.source) params.append('source', filters.source);
+    if (filters?.tag) params.append('tag', filters.tag);
+    if (filters?.platform) params.append('platform', filters.platform);
+    if (filters?.appVersion) params.append('app_version', filters.appVersion);
+    if (filters?.createdFrom) params.append('created_from', filters.createdFrom);
+    if (filters?.createdTo) params.append('created_to', filters.createdTo);
+    if (filters?.minRating !== undefined) params.append('min_rating', filters.minRating.toString());
+    if (filters?.maxRating !== undefined) params.append('max_rating', filters.maxRating.toString());
+    const queryString = params.toString();
+    const url = queryString ? `/feedbacks/export?${queryString}` : '/feedbacks/export';
+    const response = await this.client.get<Blob>(url, { responseType: 'blob' });
+    return response.data;
+  }
+
+  async listUnanalyzedFeedbacks(limit?: number, offset?: number) {
+    const params = new URLSearchParams();
+    if (limit) params.append('limit', limit.toString());
+    if (offset) params.append('offset', offset.toString());
+    const queryString = params.toString();
+    const url = queryString ? `/feedbacks/unanalyzed?${queryString}` : '/feedbacks/unanalyzed';
+    const response = await this.client.get(url);
+    return response.data;
+  }
+
+  // User endpoints
+  async listUsers(limit?: number, offset?: number, status?: string, role?: string) {
+    const params = new URLSearchParams();
+    if (limit) params.append('limit', limit.toString());
+    if (offset) params.append('offset', offset.toString());
+    if (status) params.append('status', status);
+    if (role) params.append('role', role);
+    const queryString = params.toString();
+    const url = queryString ? `/users?${queryString}` : '/users';
+    const response = await this.client.get(url);
+    return response.data;
+  }
+
   // Analysis endpoints
-  async getLatestAnalysis() {
-    const response = await this.client.get('/analyses/latest');
+  async getLatestAnalysis(representativeOnly = false) {
+    const response = await this.client.get('/analyses/latest', {
+      params: representativeOnly ? { representative_only: true } : undefined,
+    });
     return response.data;
   }
 
-  async listAnalyses() {
-    const response = await this.client.get('/analyses');
+  async listAnalyses(limit?: number, offset?: number) {
+    const params = new URLSearchParams();
+    if (limit) params.append('limit', limit.toString());
+    if (offset) params.append('offset', offset.toString());
+    const queryString = params.toString();
+    const url = queryString ? `/analyses?${queryString}` : '/analyses';
+    const response = await this.client.get(url);
     return response.data;
   }
 
@@ -115,16 +186,69 @@ export class ApiClient {
     return response.data;
   }
 
+  async getAnalysisStatus(id: string) {
+    const response = await this.client.get(`/analyses/${id}/status`);
+    return response.data;
+  }
+
+  async getAnalysisRawOutput(id: string) {
+    const response = await this.client.get(`/analyses/${id}/raw`);
+    return response.data;
+  }
+
+  async getAnalysisReport(id: string, format: 'markdown' | 'html' = 'markdown') {
+    const response = await this.client.get<Blob>(`/analyses/${id}/report?format=${format}`, {
+      responseType: 'blob',
+    });
+    return response.data;
+  }
+
+  async reprocessUnanalyzed() {
+    const response = await this.client.post('/analyses/reprocess-unanalyzed');
+    return response.data;
+  }
+
   // Topic endpoints
   async getTopicsWithStats() {
     const response = await this.client.get('/topics');
     return response?.data || response;
   }
 
+  async recomputeTopicStats() {
+    const response = await this.client.post('/topics/recompute-stats');
+    return response?.data || response;
+  }
+
   async getTopicDetails(topicEnum: string) {
     const response = await this.client.get(`/topics/${topicEnum}`);
     return response?.data || response;
   }
+
+  async getTopicHistory(topicEnum: string) {
+    const response = await this.client.get(`/topics/${topicEnum}/history`);
+    return response?.data || response;
+  }
+
+  async getTopicSentimentTrend(topicEnum: string) {
+    const response = await this.client.get(`/topics/${topicEnum}/sentiment-trend`);
+    return response?.data || response;
+  }
+
+  async getTopicAnalysis(id: string) {
+    const response = await this.client.get(`/topic-analyses/${id}`);
+    return response?.data || response;
+  }
+
+  // Analytics endpoints
+  async getAnalyticsOverview() {
+    const response = await this.client.get('/analytics/overview');
+    return response?.data || response;
+  }
+
+  async getTokenAccuracy() {
+    const response = await this.client.get('/analytics/token-accuracy');
+    return response?.data || response;
+  }
 }
 
 export const apiClient = new ApiClient();
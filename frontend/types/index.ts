@@ -4,26 +4,70 @@ export interface User {
   created_at: string;
 }
 
+export interface FeedbackMetadata {
+  app_version?: string;
+  platform?: string;
+  os?: string;
+}
+
 export interface Feedback {
   id: string;
   rating: number;
   comment: string;
+  tags?: string[];
+  metadata?: FeedbackMetadata;
   created_at: string;
   updated_at: string;
   deleted_at?: string | null;
+  translated_comment?: string;
+  comment_language?: string;
+  original_comment?: string;
 }
 
-export interface FeedbackListResponse {
-  feedbacks: Feedback[];
+export interface FeedbackDeleteResult {
+  feedback_id: string;
+  status: 'deleted' | 'not_found' | 'already_deleted';
+}
+
+export interface BatchDeleteFeedbacksResult {
+  results: FeedbackDeleteResult[];
+  deleted_count: number;
+}
+
+export interface Pagination {
   total: number;
+  limit: number;
+  offset: number;
+  has_more: boolean;
 }
 
+export interface Paginated<T> extends Pagination {
+  items: T[];
+}
+
+export interface List<T> {
+  items: T[];
+}
+
+export type FeedbackListResponse = Paginated<Feedback>;
+
 export interface UserInfo {
   id: string;
   email: string;
   roles: string[];
 }
 
+export interface AdminUser {
+  id: string;
+  email: string;
+  roles: string[];
+  status: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export type AdminUserListResponse = Paginated<AdminUser>;
+
 export interface LoginResponse {
   token: string;
   expires_in: number;
@@ -49,16 +93,38 @@ export interface Analysis {
   period_end: string;
   feedback_count: number;
   new_feedback_count?: number | null;
-  overall_summary: string;
+  overall_summary: string | null;
   sentiment: 'positive' | 'mixed' | 'negative';
   key_insights: string[];
   model: string;
+  provider?: string;
+  qualified_model: string;
   tokens: number;
   analysis_duration_ms: number;
   status: 'processing' | 'success' | 'failed';
   failure_reason?: string | null;
   created_at: string;
   completed_at?: string | null;
+  no_topics_identified: boolean;
+  deduplicated_count: number;
+  representative: boolean;
+  period_semantics: 'feedback_span' | 'analysis_window';
+}
+
+export interface AnalysisStatus {
+  status: Analysis['status'];
+  created_at: string;
+  completed_at?: string | null;
+}
+
+export interface AnalysisRawOutput {
+  analysis_id: string;
+  output: string;
+  created_at: string;
+}
+
+export interface ReprocessUnanalyzedResult {
+  reprocessed_count: number;
 }
 
 export interface TopicAnalysis {
@@ -68,10 +134,25 @@ export interface TopicAnalysis {
   summary: string;
   feedback_count: number;
   sentiment: 'positive' | 'mixed' | 'negative';
+  sentiment_distribution: SentimentDistribution | null;
+  recommendations: string[];
+  confidence: number | null;
+  sentiment_conflict: boolean;
   created_at: string;
   updated_at: string;
 }
 
+export interface SentimentDistribution {
+  positive: number;
+  mixed: number;
+  negative: number;
+}
+
+export interface TopicAnalysisDetail extends TopicAnalysis {
+  analysis_id: string;
+  feedbacks: Feedback[];
+}
+
 export interface FeedbackWithTopics {
   id: string;
   rating: number;
@@ -80,17 +161,30 @@ export interface FeedbackWithTopics {
   topics: string[];
 }
 
+export interface TopicDelta {
+  topic: string;
+  topic_name: string;
+  previous_count: number;
+  current_count: number;
+  delta: number;
+}
+
 export interface AnalysisDetail {
   analysis: Analysis;
   topics: TopicAnalysis[];
   feedbacks: FeedbackWithTopics[];
+  topic_deltas: TopicDelta[];
 }
 
-export interface AnalysisListResponse {
-  analyses: Analysis[];
-  total: number;
+export type AnalysisListResponse = Paginated<Analysis>;
+
+export interface FeedbackAnalysis {
+  analysis: Analysis;
+  topics: TopicAnalysis[];
 }
 
+export type FeedbackAnalysesResponse = List<FeedbackAnalysis>;
+
 export interface TopicStats {
   topic: string;
   topic_name: string;
@@ -98,9 +192,8 @@ export interface TopicStats {
   average_rating: number;
 }
 
-export interface TopicStatsListResponse {
-  topics: TopicStats[];
-  total: number;
+export interface TopicStatsListResponse extends Paginated<TopicStats> {
+  no_topics_identified: boolean;
 }
 
 export interface TopicDetails {
@@ -111,5 +204,87 @@ export interface TopicDetails {
   feedback_count: number;
   average_rating: number;
   sentiment: 'positive' | 'mixed' | 'negative';
+  rating_alignment: SentimentAlignment;
   feedbacks: Feedback[];
 }
+
+export interface TopicHistoryEntry {
+  topic_analysis_id: string;
+  analysis_id: string;
+  period_start: string;
+  period_end: string;
+  analyzed_at: string;
+  summary: string;
+  sentiment: 'positive' | 'mixed' | 'negative';
+  feedback_count: number;
+}
+
+export type TopicHistoryResponse = List<TopicHistoryEntry>;
+
+export interface TopicSentimentTrendPoint {
+  analysis_id: string;
+  period_end: string;
+  sentiment: 'positive' | 'mixed' | 'negative' | null;
+  sentiment_score: 1 | 0 | -1 | null;
+  feedback_count: number | null;
+}
+
+export type TopicSentimentTrendResponse = List<TopicSentimentTrendPoint>;
+
+export interface SentimentAlignment {
+  status: 'aligned' | 'divergent' | 'mismatched' | 'unknown';
+  mismatch: boolean;
+  implied_sentiment?: 'positive' | 'mixed' | 'negative';
+  low_rating_share: number;
+  high_rating_share: number;
+}
+
+export interface TagTopicCount {
+  topic: string;
+  topic_name: string;
+  feedback_count: number;
+  share: number;
+}
+
+export interface TagAgreement {
+  tag: string;
+  feedback_count: number;
+  dominant_topic?: string;
+  agreement_rate: number;
+  topics: TagTopicCount[];
+}
+
+export interface TagTopicAgreement {
+  agreement_rate: number;
+  tags: TagAgreement[];
+}
+
+export interface AnalyticsOverview {
+  total_analyses: number;
+  successful_analyses: number;
+  analyzed_feedbacks: number;
+  total_tokens: number;
+  estimated_cost_usd: number;
+  average_feedbacks_per_analysis: number;
+  most_common_topic?: string;
+  most_common_topic_name?: string;
+  most_common_topic_feedback_count: number;
+}
+
+export interface TokenAccuracyBucket {
+  min_ratio: number;
+  max_ratio?: number;
+  analysis_count: number;
+}
+
+export interface TokenAccuracy {
+  recording_enabled: boolean;
+  sample_count: number;
+  mean_ratio: number;
+  min_ratio: number;
+  max_ratio: number;
+  p10_ratio: number;
+  median_ratio: number;
+  p90_ratio: number;
+  buckets: TokenAccuracyBucket[];
+}